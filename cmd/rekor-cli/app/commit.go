@@ -0,0 +1,136 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	"github.com/sigstore/rekor/pkg/log"
+	commitment_v001 "github.com/sigstore/rekor/pkg/types/commitment/v0.0.1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+type commitCmdOutput struct {
+	CiphertextSHA256    string `json:",omitempty"`
+	PlaintextCommitment string
+	Salt                string
+	Statement           string `json:",omitempty"`
+}
+
+func (c *commitCmdOutput) String() string {
+	s := ""
+	if c.CiphertextSHA256 != "" {
+		s += fmt.Sprintf("Ciphertext SHA256: %v\n", c.CiphertextSHA256)
+	}
+	s += fmt.Sprintf("Plaintext commitment: %v\n", c.PlaintextCommitment)
+	s += fmt.Sprintf("Salt: %v\n", c.Salt)
+	if c.Statement != "" {
+		s += fmt.Sprintf("Statement to sign written to: %v\n", c.Statement)
+	}
+	s += "Keep the salt secret until the artifact is disclosed; anyone holding it can test guesses of the plaintext.\n"
+	return s
+}
+
+// commitCmd computes the commitment to a confidential artifact that is logged with --type commitment
+var commitCmd = &cobra.Command{
+	Use:   "commit",
+	Short: "Compute a commitment to a confidential artifact",
+	Long: `Computes the SHA256 of the encrypted artifact and the HMAC-SHA256 of its plaintext keyed with a salt,
+and writes the statement that must be signed to log them with 'rekor-cli upload --type commitment'.
+
+Nothing is sent to the server. To disclose the artifact later, publish the plaintext and the salt; anyone can
+then rerun this command with --plaintext and --salt and compare the commitment with the logged entry.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+		if viper.GetString("plaintext") == "" {
+			log.Logger.Error(errors.New("--plaintext must be specified"))
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+		if viper.GetString("statement") != "" && viper.GetString("artifact") == "" {
+			log.Logger.Error(errors.New("--artifact is required when --statement is used"))
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+	},
+	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+		var ciphertextSHA string
+		var err error
+		if artifact := viper.GetString("artifact"); artifact != "" {
+			ciphertextSHA, err = artifactSHA256(artifact)
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact: %w", err)
+			}
+		}
+
+		var salt []byte
+		if saltStr := viper.GetString("salt"); saltStr != "" {
+			salt, err = hex.DecodeString(saltStr)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing --salt: %w", err)
+			}
+		} else {
+			salt = make([]byte, 32)
+			if _, err := rand.Read(salt); err != nil {
+				return nil, err
+			}
+		}
+
+		plaintext, err := os.Open(filepath.Clean(viper.GetString("plaintext")))
+		if err != nil {
+			return nil, err
+		}
+		defer plaintext.Close()
+		commitment, err := commitment_v001.PlaintextCommitment(salt, plaintext)
+		if err != nil {
+			return nil, err
+		}
+
+		o := &commitCmdOutput{
+			CiphertextSHA256:    ciphertextSHA,
+			PlaintextCommitment: commitment,
+			Salt:                hex.EncodeToString(salt),
+		}
+		if statement := viper.GetString("statement"); statement != "" {
+			if err := ioutil.WriteFile(filepath.Clean(statement), commitment_v001.Statement(ciphertextSHA, commitment), 0644); err != nil {
+				return nil, fmt.Errorf("error writing statement: %w", err)
+			}
+			o.Statement = statement
+		}
+		return o, nil
+	}),
+}
+
+func init() {
+	commitCmd.Flags().Var(&fileOrURLFlag{}, "artifact", "path or URL to the encrypted artifact")
+	commitCmd.Flags().String("plaintext", "", "path to the plaintext of the artifact; it is never sent to the server")
+	commitCmd.Flags().String("salt", "", "hex encoded salt of at least 16 bytes (a random 32 byte salt is generated if omitted)")
+	commitCmd.Flags().String("statement", "", "path to write the statement to be signed to")
+
+	rootCmd.AddCommand(commitCmd)
+}
@@ -18,10 +18,8 @@ package app
 import (
 	"bytes"
 	"crypto"
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"strings"
@@ -95,22 +93,7 @@ var logInfoCmd = &cobra.Command{
 			LogRootSignature: signature,
 		}
 
-		publicKey := viper.GetString("rekor_server_public_key")
-		if publicKey == "" {
-			// fetch key from server
-			keyResp, err := rekorClient.Tlog.GetPublicKey(nil)
-			if err != nil {
-				return nil, err
-			}
-			publicKey = keyResp.Payload
-		}
-
-		block, _ := pem.Decode([]byte(publicKey))
-		if block == nil {
-			return nil, errors.New("failed to decode public key of server")
-		}
-
-		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		pub, err := serverPublicKey(rekorClient, serverURL, keyHint)
 		if err != nil {
 			return nil, err
 		}
@@ -32,7 +32,7 @@ type logProofOutput struct {
 }
 
 func (l *logProofOutput) String() string {
-	s := fmt.Sprintf("Current Root Hash: %v\n", l.RootHash)
+	s := fmt.Sprintf("Root Hash: %v\n", l.RootHash)
 	s += "Hashes: ["
 	for i, hash := range l.Hashes {
 		if i+1 == len(l.Hashes) {
@@ -0,0 +1,293 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// manifest records the entries created for a set of artifacts (e.g. the files of a release), so that
+// it can be published alongside them and verified without contacting the log
+type manifest struct {
+	RekorServer string          `json:"rekorServer"`
+	Entries     []manifestEntry `json:"entries"`
+}
+
+type manifestEntry struct {
+	Artifact       string `json:"artifact"`
+	SHA256         string `json:"sha256,omitempty"`
+	UUID           string `json:"uuid"`
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	// Bundle is the path of the entry's bundle, relative to the manifest
+	Bundle string `json:"bundle"`
+}
+
+// entryBundle holds everything needed to verify the inclusion of an entry offline: the entry, its
+// inclusion proof, and the signed tree head the proof is anchored to. When the tree has grown since
+// the inclusion proof was computed, a consistency proof links the two tree sizes.
+type entryBundle struct {
+	LogEntry         models.LogEntry               `json:"logEntry"`
+	InclusionProof   *models.InclusionProof        `json:"inclusionProof"`
+	ConsistencyProof *models.ConsistencyProof      `json:"consistencyProof,omitempty"`
+	SignedTreeHead   *models.LogInfoSignedTreeHead `json:"signedTreeHead"`
+}
+
+func loadManifest(path string) (*manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return &manifest{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("error parsing manifest %v: %w", path, err)
+	}
+	return m, nil
+}
+
+// add records e in the manifest, replacing any previous entry for the same artifact
+func (m *manifest) add(serverURL string, e manifestEntry) error {
+	if m.RekorServer == "" {
+		m.RekorServer = serverURL
+	} else if m.RekorServer != serverURL {
+		return fmt.Errorf("manifest records entries of %v, not %v", m.RekorServer, serverURL)
+	}
+	for i := range m.Entries {
+		if m.Entries[i].Artifact == e.Artifact {
+			m.Entries[i] = e
+			return nil
+		}
+	}
+	m.Entries = append(m.Entries, e)
+	sort.Slice(m.Entries, func(i, j int) bool { return m.Entries[i].Artifact < m.Entries[j].Artifact })
+	return nil
+}
+
+func (m *manifest) save(path string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Clean(path), append(b, '\n'), 0644)
+}
+
+// recordInManifest writes the bundle of the entry uuid and records it in the manifest at manifestPath
+func recordInManifest(rekorClient *client.Rekor, serverURL, manifestPath, uuid string) error {
+	artifact := viper.GetString("artifact")
+	if artifact == "" {
+		artifact = viper.GetString("entry")
+	}
+
+	e := manifestEntry{
+		Artifact: artifact,
+		UUID:     uuid,
+	}
+	if viper.GetString("artifact") != "" {
+		sha, err := artifactSHA256(artifact)
+		if err != nil {
+			return err
+		}
+		e.SHA256 = sha
+	}
+
+	bundle, err := fetchBundle(rekorClient, serverURL, uuid)
+	if err != nil {
+		return err
+	}
+	for _, entry := range bundle.LogEntry {
+		e.LogIndex = *entry.LogIndex
+		e.IntegratedTime = entry.IntegratedTime
+	}
+
+	bundleDir := viper.GetString("bundle-dir")
+	if bundleDir == "" {
+		bundleDir = filepath.Dir(manifestPath)
+	}
+	bundlePath := filepath.Join(bundleDir, uuid+".json")
+	b, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Clean(bundlePath), append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing bundle: %w", err)
+	}
+	manifestDir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return err
+	}
+	absBundlePath, err := filepath.Abs(bundlePath)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(manifestDir, absBundlePath)
+	if err != nil {
+		return err
+	}
+	e.Bundle = filepath.ToSlash(rel)
+
+	m, err := loadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if err := m.add(serverURL, e); err != nil {
+		return err
+	}
+	return m.save(manifestPath)
+}
+
+// fetchBundle retrieves the entry uuid together with its proofs, verifying them against the
+// signed tree head before returning
+func fetchBundle(rekorClient *client.Rekor, serverURL, uuid string) (*entryBundle, error) {
+	entryParams := entries.NewGetLogEntryByUUIDParams()
+	entryParams.EntryUUID = uuid
+	entryResp, err := rekorClient.Entries.GetLogEntryByUUID(entryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	proofParams := entries.NewGetLogEntryProofParams()
+	proofParams.EntryUUID = uuid
+	proofResp, err := rekorClient.Entries.GetLogEntryProof(proofParams)
+	if err != nil {
+		return nil, err
+	}
+	inclusionProof := proofResp.Payload
+
+	hashes := [][]byte{}
+	for _, h := range inclusionProof.Hashes {
+		hb, _ := hex.DecodeString(h)
+		hashes = append(hashes, hb)
+	}
+	proofRoot, _ := hex.DecodeString(*inclusionProof.RootHash)
+	leafHash, _ := hex.DecodeString(uuid)
+	v := logverifier.New(rfc6962.DefaultHasher)
+	if err := v.VerifyInclusionProof(*inclusionProof.LogIndex, *inclusionProof.TreeSize,
+		hashes, proofRoot, leafHash); err != nil {
+		return nil, err
+	}
+
+	// the tree head is fetched after the proof, so it is at least as large as the proof's tree
+	infoResp, err := rekorClient.Tlog.GetLogInfo(nil)
+	if err != nil {
+		return nil, err
+	}
+	sth := infoResp.Payload.SignedTreeHead
+
+	pub, err := serverPublicKey(rekorClient, serverURL, *sth.KeyHint)
+	if err != nil {
+		return nil, err
+	}
+	verifier := tclient.NewLogVerifier(rfc6962.DefaultHasher, pub, crypto.SHA256)
+	lr, err := tcrypto.VerifySignedLogRoot(verifier.PubKey, verifier.SigHash, &trillian.SignedLogRoot{
+		KeyHint:          *sth.KeyHint,
+		LogRoot:          *sth.LogRoot,
+		LogRootSignature: *sth.Signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &entryBundle{
+		LogEntry:       entryResp.Payload,
+		InclusionProof: inclusionProof,
+		SignedTreeHead: sth,
+	}
+
+	treeSize := *inclusionProof.TreeSize
+	switch {
+	case int64(lr.TreeSize) < treeSize:
+		return nil, fmt.Errorf("signed tree head of size %d predates inclusion proof for tree of size %d", lr.TreeSize, treeSize)
+	case int64(lr.TreeSize) == treeSize:
+		if !strings.EqualFold(hex.EncodeToString(lr.RootHash), *inclusionProof.RootHash) {
+			return nil, errors.New("root hash in signed tree head does not match inclusion proof")
+		}
+	default:
+		params := tlog.NewGetLogProofParams()
+		params.FirstSize = &treeSize
+		params.LastSize = int64(lr.TreeSize)
+		consistencyResp, err := rekorClient.Tlog.GetLogProof(params)
+		if err != nil {
+			return nil, err
+		}
+		hashes := [][]byte{}
+		for _, h := range consistencyResp.Payload.Hashes {
+			hb, _ := hex.DecodeString(h)
+			hashes = append(hashes, hb)
+		}
+		if err := v.VerifyConsistencyProof(treeSize, int64(lr.TreeSize), proofRoot, lr.RootHash, hashes); err != nil {
+			return nil, err
+		}
+		bundle.ConsistencyProof = consistencyResp.Payload
+	}
+
+	return bundle, nil
+}
+
+func artifactSHA256(artifact string) (string, error) {
+	var r io.ReadCloser
+	f := fileOrURLFlag{}
+	if err := f.Set(artifact); err != nil {
+		return "", err
+	}
+	if f.IsURL {
+		rc, err := util.FileOrURLReadCloser(context.Background(), artifact, nil)
+		if err != nil {
+			return "", err
+		}
+		r = rc
+	} else {
+		file, err := os.Open(filepath.Clean(artifact))
+		if err != nil {
+			return "", err
+		}
+		r = file
+	}
+	defer r.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", fmt.Errorf("error hashing artifact: %w", err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
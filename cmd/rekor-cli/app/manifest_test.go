@@ -0,0 +1,78 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rekor-manifest.json")
+
+	m, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading missing manifest: %v", err)
+	}
+	if len(m.Entries) != 0 {
+		t.Fatalf("expected empty manifest, got %v", m)
+	}
+
+	const server = "https://rekor.example.com"
+	b := manifestEntry{Artifact: "b.tar.gz", UUID: "bb", LogIndex: 2, Bundle: "bb.json"}
+	a := manifestEntry{Artifact: "a.tar.gz", UUID: "aa", LogIndex: 1, Bundle: "aa.json"}
+	for _, e := range []manifestEntry{b, a} {
+		if err := m.add(server, e); err != nil {
+			t.Fatalf("unexpected error adding %v: %v", e.Artifact, err)
+		}
+	}
+	// re-uploading an artifact replaces its previous entry
+	a.UUID, a.LogIndex, a.Bundle = "cc", 3, "cc.json"
+	if err := m.add(server, a); err != nil {
+		t.Fatalf("unexpected error replacing %v: %v", a.Artifact, err)
+	}
+	if err := m.add("https://other.example.com", b); err == nil {
+		t.Error("expected error adding entry of a different log")
+	}
+
+	if err := m.save(path); err != nil {
+		t.Fatalf("unexpected error saving manifest: %v", err)
+	}
+	got, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading manifest: %v", err)
+	}
+	want := &manifest{RekorServer: server, Entries: []manifestEntry{a, b}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest = %+v, want %+v", got, want)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadManifest(path); err == nil {
+		t.Error("expected error loading malformed manifest")
+	}
+}
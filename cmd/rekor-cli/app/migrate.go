@@ -0,0 +1,296 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// migrationReference is recorded in the extraData of every migrated entry so that it can be
+// traced back to the entry it was copied from
+type migrationReference struct {
+	Server         string `json:"server"`
+	UUID           string `json:"uuid"`
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+}
+
+type migratedEntry struct {
+	SourceUUID      string
+	SourceIndex     int64
+	Location        string `json:",omitempty"`
+	Index           int64  `json:",omitempty"`
+	AlreadyMigrated bool   `json:",omitempty"`
+	Error           string `json:",omitempty"`
+}
+
+type migrateCmdOutput struct {
+	Entries []migratedEntry
+}
+
+func (m *migrateCmdOutput) String() string {
+	str := ""
+	failed := 0
+	for _, e := range m.Entries {
+		switch {
+		case e.Error != "":
+			failed++
+			str += fmt.Sprintf("%v (index %d): error: %v\n", e.SourceUUID, e.SourceIndex, e.Error)
+		case e.AlreadyMigrated:
+			str += fmt.Sprintf("%v (index %d): already migrated to index %d at %v\n", e.SourceUUID, e.SourceIndex, e.Index, e.Location)
+		default:
+			str += fmt.Sprintf("%v (index %d): migrated to index %d at %v\n", e.SourceUUID, e.SourceIndex, e.Index, e.Location)
+		}
+	}
+	str += fmt.Sprintf("Migrated %d of %d entries\n", len(m.Entries)-failed, len(m.Entries))
+	return str
+}
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy entries from one Rekor instance to another",
+	Long: `Copies the entries selected by a log index range or by the search flags from the server given by
+--rekor_server to the server given by --destination.
+
+The canonical body of each entry is re-submitted with a "migratedFrom" object added to its extraData,
+recording where it was copied from. Canonical bodies only carry the digest of the artifact; servers need
+to be able to fetch it again to verify the signature, so --artifact-base-url should point at a location
+serving each artifact under its SHA256 digest.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+		if err := validateMigratePFlags(); err != nil {
+			log.Logger.Error(err)
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+	},
+	Run: format.WrapCmd(func(args []string) (interface{}, error) {
+		source := viper.GetString("rekor_server")
+		sourceClient, err := GetRekorClient(source)
+		if err != nil {
+			return nil, err
+		}
+		destinationClient, err := GetRekorClient(viper.GetString("destination"))
+		if err != nil {
+			return nil, err
+		}
+
+		selected, err := selectEntries(sourceClient)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &migrateCmdOutput{}
+		for uuid, entry := range selected {
+			migrated := migratedEntry{
+				SourceUUID:  uuid,
+				SourceIndex: swag.Int64Value(entry.LogIndex),
+			}
+			if err := migrateEntry(destinationClient, source, uuid, entry, &migrated); err != nil {
+				migrated.Error = err.Error()
+			}
+			result.Entries = append(result.Entries, migrated)
+		}
+		sort.Slice(result.Entries, func(i, j int) bool {
+			return result.Entries[i].SourceIndex < result.Entries[j].SourceIndex
+		})
+		return result, nil
+	}),
+}
+
+func validateMigratePFlags() error {
+	if viper.GetString("destination") == "" {
+		return errors.New("--destination must be specified")
+	}
+	if viper.GetString("destination") == viper.GetString("rekor_server") {
+		return errors.New("--destination must differ from --rekor_server")
+	}
+
+	start := viper.GetString("log-index-start")
+	end := viper.GetString("log-index-end")
+	if start == "" && end == "" {
+		return validateSearchPFlags()
+	}
+	if start == "" || end == "" {
+		return errors.New("both --log-index-start and --log-index-end must be specified")
+	}
+	if viper.GetInt64("log-index-start") > viper.GetInt64("log-index-end") {
+		return errors.New("--log-index-start must not be greater than --log-index-end")
+	}
+	return nil
+}
+
+// selectEntries returns the entries chosen by the index range or search flags, keyed by UUID
+func selectEntries(rekorClient *client.Rekor) (models.LogEntry, error) {
+	selected := models.LogEntry{}
+
+	if viper.GetString("log-index-start") != "" {
+		for i := viper.GetInt64("log-index-start"); i <= viper.GetInt64("log-index-end"); i++ {
+			params := entries.NewGetLogEntryByIndexParams()
+			params.LogIndex = i
+			resp, err := rekorClient.Entries.GetLogEntryByIndex(params)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching entry at index %d: %w", i, err)
+			}
+			for uuid, entry := range resp.Payload {
+				selected[uuid] = entry
+			}
+		}
+		return selected, nil
+	}
+
+	uuids, err := searchIndex(rekorClient)
+	if err != nil {
+		return nil, err
+	}
+	for _, uuid := range uuids {
+		params := entries.NewGetLogEntryByUUIDParams()
+		params.EntryUUID = uuid
+		resp, err := rekorClient.Entries.GetLogEntryByUUID(params)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching entry %v: %w", uuid, err)
+		}
+		for k, entry := range resp.Payload {
+			selected[k] = entry
+		}
+	}
+	return selected, nil
+}
+
+func migrateEntry(rekorClient *client.Rekor, source, uuid string, entry models.LogEntryAnon, migrated *migratedEntry) error {
+	body, ok := entry.Body.(string)
+	if !ok {
+		return errors.New("unexpected entry body")
+	}
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return err
+	}
+
+	ref := migrationReference{
+		Server:         source,
+		UUID:           uuid,
+		LogIndex:       swag.Int64Value(entry.LogIndex),
+		IntegratedTime: entry.IntegratedTime,
+	}
+	pe, err := annotateEntry(b, ref, viper.GetString("artifact-base-url"))
+	if err != nil {
+		return err
+	}
+
+	params := entries.NewCreateLogEntryParams()
+	params.SetProposedEntry(pe)
+	resp, err := rekorClient.Entries.CreateLogEntry(params)
+	if err != nil {
+		var conflict *entries.CreateLogEntryConflict
+		if errors.As(err, &conflict) {
+			migrated.AlreadyMigrated = true
+			migrated.Location = conflict.Location.String()
+			migrated.Index = conflict.XRekorLogIndex
+			return nil
+		}
+		return err
+	}
+
+	migrated.Location = resp.Location.String()
+	for _, e := range resp.Payload {
+		migrated.Index = swag.Int64Value(e.LogIndex)
+	}
+	return nil
+}
+
+// annotateEntry records ref in the extraData of the canonical entry body and, when artifactBaseURL
+// is set, points each spec field that only carries a hash at <artifactBaseURL>/<hash value>.
+//
+// Since ref is derived from the source entry alone, migrating the same entry twice results in an
+// identical submission, which the destination rejects as a duplicate.
+func annotateEntry(body []byte, ref migrationReference, artifactBaseURL string) (models.ProposedEntry, error) {
+	var entry map[string]interface{}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return nil, fmt.Errorf("error parsing entry body: %w", err)
+	}
+	spec, ok := entry["spec"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("entry body has no spec")
+	}
+
+	extraData := map[string]interface{}{}
+	switch existing := spec["extraData"].(type) {
+	case nil:
+	case map[string]interface{}:
+		extraData = existing
+	default:
+		extraData["extraData"] = existing
+	}
+	extraData["migratedFrom"] = ref
+	spec["extraData"] = extraData
+
+	if artifactBaseURL != "" {
+		for _, v := range spec {
+			field, ok := v.(map[string]interface{})
+			if !ok || field["content"] != nil || field["url"] != nil {
+				continue
+			}
+			hash, ok := field["hash"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if value, ok := hash["value"].(string); ok {
+				field["url"] = strings.TrimSuffix(artifactBaseURL, "/") + "/" + value
+			}
+		}
+	}
+
+	annotated, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	return models.UnmarshalProposedEntry(bytes.NewReader(annotated), runtime.JSONConsumer())
+}
+
+func init() {
+	migrateCmd.Flags().Var(&urlFlag{}, "destination", "address of the Rekor server to copy entries to")
+	migrateCmd.Flags().Var(&logIndexFlag{}, "log-index-start", "index of the first entry to copy")
+	migrateCmd.Flags().Var(&logIndexFlag{}, "log-index-end", "index of the last entry to copy")
+	migrateCmd.Flags().String("artifact-base-url", "", "URL under which the artifacts of the copied entries are served by SHA256 digest")
+	if err := addSearchPFlags(migrateCmd); err != nil {
+		log.Logger.Fatal("Error parsing cmd line args:", err)
+	}
+
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func TestAnnotateEntry(t *testing.T) {
+	const sha = "45c7b11fcbf07dec1694adecd8c5b85770a12a6c8dfdcf2580a2db0c47c31779"
+	canonical := func(extraData string) []byte {
+		body := `{"apiVersion":"0.0.1","kind":"rekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"` + sha + `"}},` +
+			`"signature":{"content":"c2ln","format":"pgp","publicKey":{"content":"a2V5"}}`
+		if extraData != "" {
+			body += `,"extraData":` + extraData
+		}
+		return []byte(body + "}}")
+	}
+	ref := migrationReference{
+		Server:         "https://rekor.example.com",
+		UUID:           "af1f6a08d1cf2a2bfa7b59b3b9f6f0e2b4ee6b8bec2bfb1bb618161185c1713f",
+		LogIndex:       42,
+		IntegratedTime: 1620000000,
+	}
+	wantRef := map[string]interface{}{
+		"server":         ref.Server,
+		"uuid":           ref.UUID,
+		"logIndex":       float64(ref.LogIndex),
+		"integratedTime": float64(ref.IntegratedTime),
+	}
+
+	tests := []struct {
+		caseDesc        string
+		body            []byte
+		artifactBaseURL string
+		wantURL         interface{}
+		wantExtraData   map[string]interface{}
+		expectSuccess   bool
+	}{
+		{
+			caseDesc:      "no extraData",
+			body:          canonical(""),
+			wantExtraData: map[string]interface{}{"migratedFrom": wantRef},
+			expectSuccess: true,
+		},
+		{
+			caseDesc:        "existing object extraData and artifact URL",
+			body:            canonical(`{"build":"1234"}`),
+			artifactBaseURL: "https://artifacts.example.com/sha256/",
+			wantURL:         "https://artifacts.example.com/sha256/" + sha,
+			wantExtraData:   map[string]interface{}{"build": "1234", "migratedFrom": wantRef},
+			expectSuccess:   true,
+		},
+		{
+			caseDesc:      "existing non-object extraData",
+			body:          canonical(`"release build"`),
+			wantExtraData: map[string]interface{}{"extraData": "release build", "migratedFrom": wantRef},
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "not an entry",
+			body:          []byte(`[]`),
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "missing spec",
+			body:          []byte(`{"apiVersion":"0.0.1","kind":"rekord"}`),
+			expectSuccess: false,
+		},
+	}
+
+	for _, tc := range tests {
+		pe, err := annotateEntry(tc.body, ref, tc.artifactBaseURL)
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		rekord, ok := pe.(*models.Rekord)
+		if !ok {
+			t.Errorf("unexpected entry type %T in '%v'", pe, tc.caseDesc)
+			continue
+		}
+		specBytes, err := json.Marshal(rekord.Spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec := map[string]interface{}{}
+		if err := json.Unmarshal(specBytes, &spec); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(spec["extraData"], tc.wantExtraData) {
+			t.Errorf("unexpected extraData in '%v': %v", tc.caseDesc, spec["extraData"])
+		}
+		if url := spec["data"].(map[string]interface{})["url"]; url != tc.wantURL {
+			t.Errorf("unexpected data URL in '%v': %v", tc.caseDesc, url)
+		}
+		if _, ok := spec["signature"].(map[string]interface{})["url"]; ok {
+			t.Errorf("signature URL should not be set in '%v'", tc.caseDesc)
+		}
+	}
+}
@@ -27,12 +27,22 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki"
+	commitment_v001 "github.com/sigstore/rekor/pkg/types/commitment/v0.0.1"
+	dct_v001 "github.com/sigstore/rekor/pkg/types/dct/v0.0.1"
+	kmod_v001 "github.com/sigstore/rekor/pkg/types/kmod/v0.0.1"
+	mlmodel_v001 "github.com/sigstore/rekor/pkg/types/mlmodel/v0.0.1"
+	notation_v001 "github.com/sigstore/rekor/pkg/types/notation/v0.0.1"
 	rekord_v001 "github.com/sigstore/rekor/pkg/types/rekord/v0.0.1"
+	release_v001 "github.com/sigstore/rekor/pkg/types/release/v0.0.1"
 	rpm_v001 "github.com/sigstore/rekor/pkg/types/rpm/v0.0.1"
+	tfprovider_v001 "github.com/sigstore/rekor/pkg/types/tfprovider/v0.0.1"
+	vmimage_v001 "github.com/sigstore/rekor/pkg/types/vmimage/v0.0.1"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -44,7 +54,24 @@ func addSearchPFlags(cmd *cobra.Command) error {
 
 	cmd.Flags().Var(&fileOrURLFlag{}, "artifact", "path or URL to artifact file")
 
-	cmd.Flags().Var(&uuidFlag{}, "sha", "the SHA256 sum of the artifact")
+	cmd.Flags().Var(&shaFlag{}, "sha", "the SHA256 sum of the artifact, or another digest of it that entries list, such as its SHA512 sum")
+
+	cmd.Flags().String("release", "", "the release to search for, in the form name@version")
+
+	cmd.Flags().String("image", "", "the VM image to search for, in the form region:id (or just id for images that are not regional)")
+
+	cmd.Flags().String("model", "", "the ML model to search for, in the form name or name@version")
+
+	cmd.Flags().String("principal", "", "the identity to search for, such as a principal of an SSH certificate")
+	cmd.Flags().String("email", "", "the email address to search for, matching the user IDs of PGP keys")
+	cmd.Flags().String("key-id", "", "the key ID to search for, such as the key ID of an SSH certificate")
+	cmd.Flags().String("uri", "", "the URI subject alternative name of signing certificates to search for, such as a CI workflow identity")
+	cmd.Flags().String("oidc-issuer", "", "the OIDC issuer recorded in signing certificates issued by Fulcio to search for")
+	cmd.Flags().String("certificate-identity", "", "the email address or URI subject alternative name of signing certificates issued by Fulcio to search for, along with oidc-issuer")
+	cmd.Flags().String("piv", "", "the serial number of a PIV device holding the signing key to search for, optionally followed by /slot")
+	cmd.Flags().String("subject-digest", "", "the SHA256, SHA384 or SHA512 digest of an artifact to search for attestations about, such as its provenance")
+	cmd.Flags().String("sha-prefix", "", "the first 8 or more hex characters of a digest to search for the entries of every digest starting with them, such as a truncated digest from an incident report")
+	cmd.Flags().String("email-suffix", "", "the end of the email addresses to search for, such as @example.com for every address of a domain")
 	return nil
 }
 
@@ -53,9 +80,27 @@ func validateSearchPFlags() error {
 
 	publicKey := viper.GetString("public-key")
 	sha := viper.GetString("sha")
-
-	if artifactStr == "" && publicKey == "" && sha == "" {
-		return errors.New("either 'sha' or 'artifact' or 'public-key' must be specified")
+	release := viper.GetString("release")
+	image := viper.GetString("image")
+	model := viper.GetString("model")
+	principal := viper.GetString("principal")
+	email := viper.GetString("email")
+	keyID := viper.GetString("key-id")
+	uri := viper.GetString("uri")
+	oidcIssuer := viper.GetString("oidc-issuer")
+	piv := viper.GetString("piv")
+	subjectDigest := viper.GetString("subject-digest")
+	shaPrefix := viper.GetString("sha-prefix")
+	emailSuffix := viper.GetString("email-suffix")
+
+	if artifactStr == "" && publicKey == "" && sha == "" && release == "" && image == "" && model == "" && principal == "" && email == "" && keyID == "" && uri == "" && oidcIssuer == "" && piv == "" && subjectDigest == "" && shaPrefix == "" && emailSuffix == "" {
+		return errors.New("either 'sha' or 'artifact' or 'public-key' or 'release' or 'image' or 'model' or 'principal' or 'email' or 'key-id' or 'uri' or 'oidc-issuer' or 'piv' or 'subject-digest' or 'sha-prefix' or 'email-suffix' must be specified")
+	}
+	if viper.GetString("certificate-identity") != "" && oidcIssuer == "" {
+		return errors.New("oidc-issuer must be specified if searching by certificate-identity")
+	}
+	if release != "" && !strings.Contains(release, "@") {
+		return errors.New("release must be specified in the form name@version")
 	}
 	if publicKey != "" {
 		if viper.GetString("pki-format") == "" {
@@ -66,7 +111,7 @@ func validateSearchPFlags() error {
 }
 
 func addArtifactPFlags(cmd *cobra.Command) error {
-	cmd.Flags().Var(&fileOrURLFlag{}, "signature", "path or URL to detached signature file")
+	cmd.Flags().Var(&fileOrURLFlag{}, "signature", "path or URL to detached signature file; may be omitted for a rekord if the artifact is a PGP cleartext signed message")
 	cmd.Flags().Var(&typeFlag{value: "rekord"}, "type", "type of entry")
 	cmd.Flags().Var(&pkiFormatFlag{value: "pgp"}, "pki-format", "format of the signature and/or public key")
 
@@ -76,6 +121,18 @@ func addArtifactPFlags(cmd *cobra.Command) error {
 
 	cmd.Flags().Var(&fileOrURLFlag{}, "entry", "path or URL to pre-formatted entry file")
 
+	cmd.Flags().String("image-format", "", "format of the VM image (qcow2, raw, vmdk, vhd, ova or ami) when --type is vmimage")
+	cmd.Flags().String("image-provider", "", "cloud provider the VM image is published to")
+	cmd.Flags().String("image-region", "", "region the VM image is published in")
+	cmd.Flags().String("image-id", "", "identifier assigned to the VM image by its provider")
+	cmd.Flags().String("model-name", "", "name of the ML model when --type is mlmodel")
+	cmd.Flags().String("model-version", "", "version of the ML model")
+	cmd.Flags().String("model-format", "safetensors", "format of the ML model file (safetensors or onnx)")
+	cmd.Flags().String("envelope-media-type", "application/jose+json", "media type of the Notation signature envelope (application/jose+json or application/cose) when --type is notation")
+	cmd.Flags().String("gun", "", "globally unique name of the repository the trust data belongs to when --type is dct")
+	cmd.Flags().String("role", "", "TUF role that signed the trust data when --type is dct (defaults to targets)")
+	cmd.Flags().String("plaintext-commitment", "", "hex encoded HMAC-SHA256 of the plaintext, as printed by 'rekor-cli commit', when --type is commitment")
+
 	return nil
 }
 
@@ -127,12 +184,25 @@ func validateArtifactPFlags(uuidValid, indexValid bool) error {
 	}
 
 	if entry == "" {
-		if signature == "" && typeStr == "rekord" {
+		cleartext := typeStr == "rekord" && viper.GetString("pki-format") == "pgp"
+		if signature == "" && !cleartext && (typeStr == "rekord" || typeStr == "release" || typeStr == "tfprovider" || typeStr == "vmimage" || typeStr == "mlmodel" || typeStr == "commitment") {
 			return errors.New("--signature is required when --artifact is used")
 		}
-		if publicKey == "" {
+		if publicKey == "" && typeStr != "notation" {
 			return errors.New("--public-key is required when --artifact is used")
 		}
+		if typeStr == "vmimage" && viper.GetString("image-format") == "" {
+			return errors.New("--image-format is required when --type is vmimage")
+		}
+		if typeStr == "mlmodel" && viper.GetString("model-name") == "" {
+			return errors.New("--model-name is required when --type is mlmodel")
+		}
+		if typeStr == "dct" && viper.GetString("gun") == "" {
+			return errors.New("--gun is required when --type is dct")
+		}
+		if typeStr == "commitment" && viper.GetString("plaintext-commitment") == "" {
+			return errors.New("--plaintext-commitment is required when --type is commitment")
+		}
 	}
 
 	return nil
@@ -245,55 +315,247 @@ func CreateRekordFromPFlags() (models.ProposedEntry, error) {
 		}
 	} else {
 		// we will need artifact, public-key, signature
-		re.RekordObj.Data = &models.RekordV001SchemaData{}
+		artifact := viper.GetString("artifact")
+		signature := viper.GetString("signature")
+		if signature != "" {
+			re.RekordObj.Data = &models.RekordV001SchemaData{}
+			dataURL, err := url.Parse(artifact)
+			if err == nil && dataURL.IsAbs() {
+				re.RekordObj.Data.URL = strfmt.URI(artifact)
+			} else {
+				artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+				if err != nil {
+					return nil, fmt.Errorf("error reading artifact file: %w", err)
+				}
+				re.RekordObj.Data.Content = strfmt.Base64(artifactBytes)
+			}
+		}
+
+		re.RekordObj.Signature = &models.RekordV001SchemaSignature{}
+		re.RekordObj.Signature.Format = viper.GetString("pki-format")
+		sigURL, err := url.Parse(signature)
+		switch {
+		case signature == "":
+			// a PGP cleartext signed message is both artifact and signature; it is sent inline as the signature,
+			// and split by the server
+			var cleartext []byte
+			cleartextURL, err := url.Parse(artifact)
+			if err == nil && cleartextURL.IsAbs() {
+				/* #nosec G107 */
+				cleartextResp, err := http.Get(artifact)
+				if err != nil {
+					return nil, fmt.Errorf("error fetching artifact: %w", err)
+				}
+				defer cleartextResp.Body.Close()
+				cleartext, err = ioutil.ReadAll(cleartextResp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("error fetching artifact: %w", err)
+				}
+			} else {
+				cleartext, err = ioutil.ReadFile(filepath.Clean(artifact))
+				if err != nil {
+					return nil, fmt.Errorf("error reading artifact file: %w", err)
+				}
+			}
+			re.RekordObj.Signature.Content = strfmt.Base64(cleartext)
+		case err == nil && sigURL.IsAbs():
+			re.RekordObj.Signature.URL = strfmt.URI(signature)
+		default:
+			signatureBytes, err := ioutil.ReadFile(filepath.Clean(signature))
+			if err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.RekordObj.Signature.Content = strfmt.Base64(signatureBytes)
+		}
+
+		re.RekordObj.Signature.PublicKey = &models.RekordV001SchemaSignaturePublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.RekordObj.Signature.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.RekordObj.Signature.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.RekordObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateReleaseFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Release{}
+	re := new(release_v001.V001Entry)
+
+	release := viper.GetString("entry")
+	if release != "" {
+		var releaseBytes []byte
+		releaseURL, err := url.Parse(release)
+		if err == nil && releaseURL.IsAbs() {
+			/* #nosec G107 */
+			releaseResp, err := http.Get(release)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'release': %w", err)
+			}
+			defer releaseResp.Body.Close()
+			releaseBytes, err = ioutil.ReadAll(releaseResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'release': %w", err)
+			}
+		} else {
+			releaseBytes, err = ioutil.ReadFile(filepath.Clean(release))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'release' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(releaseBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing release file: %w", err)
+		}
+	} else {
+		// we will need the manifest (as artifact), public-key, signature
+		re.ReleaseObj.Manifest = &models.ReleaseV001SchemaManifest{}
 
 		artifact := viper.GetString("artifact")
 		dataURL, err := url.Parse(artifact)
 		if err == nil && dataURL.IsAbs() {
-			re.RekordObj.Data.URL = strfmt.URI(artifact)
+			re.ReleaseObj.Manifest.URL = strfmt.URI(artifact)
 		} else {
 			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
 			if err != nil {
 				return nil, fmt.Errorf("error reading artifact file: %w", err)
 			}
-			re.RekordObj.Data.Content = strfmt.Base64(artifactBytes)
+			re.ReleaseObj.Manifest.Content = strfmt.Base64(artifactBytes)
 		}
 
-		re.RekordObj.Signature = &models.RekordV001SchemaSignature{}
-		pkiFormat := viper.GetString("pki-format")
-		switch pkiFormat {
-		case "pgp":
-			re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatPgp
-		case "minisign":
-			re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatMinisign
-		case "x509":
-			re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatX509
-		case "ssh":
-			re.RekordObj.Signature.Format = models.RekordV001SchemaSignatureFormatSSH
+		re.ReleaseObj.Signature = &models.ReleaseV001SchemaSignature{}
+		re.ReleaseObj.Signature.Format = viper.GetString("pki-format")
+		signature := viper.GetString("signature")
+		sigURL, err := url.Parse(signature)
+		if err == nil && sigURL.IsAbs() {
+			re.ReleaseObj.Signature.URL = strfmt.URI(signature)
+		} else {
+			signatureBytes, err := ioutil.ReadFile(filepath.Clean(signature))
+			if err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.ReleaseObj.Signature.Content = strfmt.Base64(signatureBytes)
+		}
+
+		re.ReleaseObj.Signature.PublicKey = &models.ReleaseV001SchemaSignaturePublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.ReleaseObj.Signature.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.ReleaseObj.Signature.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.ReleaseObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateTfproviderFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Tfprovider{}
+	re := new(tfprovider_v001.V001Entry)
+
+	tfprovider := viper.GetString("entry")
+	if tfprovider != "" {
+		var tfproviderBytes []byte
+		tfproviderURL, err := url.Parse(tfprovider)
+		if err == nil && tfproviderURL.IsAbs() {
+			/* #nosec G107 */
+			tfproviderResp, err := http.Get(tfprovider)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'tfprovider': %w", err)
+			}
+			defer tfproviderResp.Body.Close()
+			tfproviderBytes, err = ioutil.ReadAll(tfproviderResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'tfprovider': %w", err)
+			}
+		} else {
+			tfproviderBytes, err = ioutil.ReadFile(filepath.Clean(tfprovider))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'tfprovider' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(tfproviderBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing tfprovider file: %w", err)
 		}
+	} else {
+		// we will need the SHA256SUMS file (as artifact), public-key, signature
+		re.TfproviderObj.Sha256sums = &models.TfproviderV001SchemaSha256sums{}
+
+		artifact := viper.GetString("artifact")
+		dataURL, err := url.Parse(artifact)
+		if err == nil && dataURL.IsAbs() {
+			re.TfproviderObj.Sha256sums.URL = strfmt.URI(artifact)
+		} else {
+			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.TfproviderObj.Sha256sums.Content = strfmt.Base64(artifactBytes)
+		}
+
+		re.TfproviderObj.Signature = &models.TfproviderV001SchemaSignature{}
 		signature := viper.GetString("signature")
 		sigURL, err := url.Parse(signature)
 		if err == nil && sigURL.IsAbs() {
-			re.RekordObj.Signature.URL = strfmt.URI(signature)
+			re.TfproviderObj.Signature.URL = strfmt.URI(signature)
 		} else {
 			signatureBytes, err := ioutil.ReadFile(filepath.Clean(signature))
 			if err != nil {
 				return nil, fmt.Errorf("error reading signature file: %w", err)
 			}
-			re.RekordObj.Signature.Content = strfmt.Base64(signatureBytes)
+			re.TfproviderObj.Signature.Content = strfmt.Base64(signatureBytes)
 		}
 
-		re.RekordObj.Signature.PublicKey = &models.RekordV001SchemaSignaturePublicKey{}
+		re.TfproviderObj.PublicKey = &models.TfproviderV001SchemaPublicKey{}
 		publicKey := viper.GetString("public-key")
 		keyURL, err := url.Parse(publicKey)
 		if err == nil && keyURL.IsAbs() {
-			re.RekordObj.Signature.PublicKey.URL = strfmt.URI(publicKey)
+			re.TfproviderObj.PublicKey.URL = strfmt.URI(publicKey)
 		} else {
 			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
 			if err != nil {
 				return nil, fmt.Errorf("error reading public key file: %w", err)
 			}
-			re.RekordObj.Signature.PublicKey.Content = strfmt.Base64(keyBytes)
+			re.TfproviderObj.PublicKey.Content = strfmt.Base64(keyBytes)
 		}
 
 		if err := re.Validate(); err != nil {
@@ -307,7 +569,504 @@ func CreateRekordFromPFlags() (models.ProposedEntry, error) {
 		}
 
 		returnVal.APIVersion = swag.String(re.APIVersion())
-		returnVal.Spec = re.RekordObj
+		returnVal.Spec = re.TfproviderObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateKmodFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Kmod{}
+	re := new(kmod_v001.V001Entry)
+
+	kmod := viper.GetString("entry")
+	if kmod != "" {
+		var kmodBytes []byte
+		kmodURL, err := url.Parse(kmod)
+		if err == nil && kmodURL.IsAbs() {
+			/* #nosec G107 */
+			kmodResp, err := http.Get(kmod)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'kmod': %w", err)
+			}
+			defer kmodResp.Body.Close()
+			kmodBytes, err = ioutil.ReadAll(kmodResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'kmod': %w", err)
+			}
+		} else {
+			kmodBytes, err = ioutil.ReadFile(filepath.Clean(kmod))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'kmod' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(kmodBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing kmod file: %w", err)
+		}
+	} else {
+		// we will need the signed module (as artifact) and public-key
+		re.KmodObj.Module = &models.KmodV001SchemaModule{}
+
+		artifact := viper.GetString("artifact")
+		dataURL, err := url.Parse(artifact)
+		if err == nil && dataURL.IsAbs() {
+			re.KmodObj.Module.URL = strfmt.URI(artifact)
+		} else {
+			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.KmodObj.Module.Content = strfmt.Base64(artifactBytes)
+		}
+
+		re.KmodObj.PublicKey = &models.KmodV001SchemaPublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.KmodObj.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.KmodObj.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.KmodObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateVmimageFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Vmimage{}
+	re := new(vmimage_v001.V001Entry)
+
+	vmimage := viper.GetString("entry")
+	if vmimage != "" {
+		var vmimageBytes []byte
+		vmimageURL, err := url.Parse(vmimage)
+		if err == nil && vmimageURL.IsAbs() {
+			/* #nosec G107 */
+			vmimageResp, err := http.Get(vmimage)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'vmimage': %w", err)
+			}
+			defer vmimageResp.Body.Close()
+			vmimageBytes, err = ioutil.ReadAll(vmimageResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'vmimage': %w", err)
+			}
+		} else {
+			vmimageBytes, err = ioutil.ReadFile(filepath.Clean(vmimage))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'vmimage' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(vmimageBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing vmimage file: %w", err)
+		}
+	} else {
+		// we will need artifact (the image or its manifest), public-key, signature and image-format
+		re.VmimageObj.Image = &models.VmimageV001SchemaImage{}
+
+		artifact := viper.GetString("artifact")
+		dataURL, err := url.Parse(artifact)
+		if err == nil && dataURL.IsAbs() {
+			re.VmimageObj.Image.URL = strfmt.URI(artifact)
+		} else {
+			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.VmimageObj.Image.Content = strfmt.Base64(artifactBytes)
+		}
+		re.VmimageObj.Image.Format = viper.GetString("image-format")
+		if imageID := viper.GetString("image-id"); imageID != "" {
+			re.VmimageObj.Image.Identifier = &models.VmimageV001SchemaImageIdentifier{
+				Provider: viper.GetString("image-provider"),
+				Region:   viper.GetString("image-region"),
+				ID:       swag.String(imageID),
+			}
+		}
+
+		re.VmimageObj.Signature = &models.VmimageV001SchemaSignature{}
+		re.VmimageObj.Signature.Format = viper.GetString("pki-format")
+		signature := viper.GetString("signature")
+		sigURL, err := url.Parse(signature)
+		if err == nil && sigURL.IsAbs() {
+			re.VmimageObj.Signature.URL = strfmt.URI(signature)
+		} else {
+			signatureBytes, err := ioutil.ReadFile(filepath.Clean(signature))
+			if err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.VmimageObj.Signature.Content = strfmt.Base64(signatureBytes)
+		}
+
+		re.VmimageObj.Signature.PublicKey = &models.VmimageV001SchemaSignaturePublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.VmimageObj.Signature.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.VmimageObj.Signature.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.VmimageObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateMlmodelFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Mlmodel{}
+	re := new(mlmodel_v001.V001Entry)
+
+	mlmodel := viper.GetString("entry")
+	if mlmodel != "" {
+		var mlmodelBytes []byte
+		mlmodelURL, err := url.Parse(mlmodel)
+		if err == nil && mlmodelURL.IsAbs() {
+			/* #nosec G107 */
+			mlmodelResp, err := http.Get(mlmodel)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'mlmodel': %w", err)
+			}
+			defer mlmodelResp.Body.Close()
+			mlmodelBytes, err = ioutil.ReadAll(mlmodelResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'mlmodel': %w", err)
+			}
+		} else {
+			mlmodelBytes, err = ioutil.ReadFile(filepath.Clean(mlmodel))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'mlmodel' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(mlmodelBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing mlmodel file: %w", err)
+		}
+	} else {
+		// we will need artifact (the model file), public-key, signature and model-name
+		re.MlmodelObj.Model = &models.MlmodelV001SchemaModel{}
+
+		artifact := viper.GetString("artifact")
+		dataURL, err := url.Parse(artifact)
+		if err == nil && dataURL.IsAbs() {
+			re.MlmodelObj.Model.URL = strfmt.URI(artifact)
+		} else {
+			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.MlmodelObj.Model.Content = strfmt.Base64(artifactBytes)
+		}
+		re.MlmodelObj.Model.Name = viper.GetString("model-name")
+		re.MlmodelObj.Model.Version = viper.GetString("model-version")
+		re.MlmodelObj.Model.Format = viper.GetString("model-format")
+
+		re.MlmodelObj.Signature = &models.MlmodelV001SchemaSignature{}
+		re.MlmodelObj.Signature.Format = viper.GetString("pki-format")
+		signature := viper.GetString("signature")
+		sigURL, err := url.Parse(signature)
+		if err == nil && sigURL.IsAbs() {
+			re.MlmodelObj.Signature.URL = strfmt.URI(signature)
+		} else {
+			signatureBytes, err := ioutil.ReadFile(filepath.Clean(signature))
+			if err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.MlmodelObj.Signature.Content = strfmt.Base64(signatureBytes)
+		}
+
+		re.MlmodelObj.Signature.PublicKey = &models.MlmodelV001SchemaSignaturePublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.MlmodelObj.Signature.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.MlmodelObj.Signature.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.MlmodelObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateNotationFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Notation{}
+	re := new(notation_v001.V001Entry)
+
+	notation := viper.GetString("entry")
+	if notation != "" {
+		var notationBytes []byte
+		notationURL, err := url.Parse(notation)
+		if err == nil && notationURL.IsAbs() {
+			/* #nosec G107 */
+			notationResp, err := http.Get(notation)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'notation': %w", err)
+			}
+			defer notationResp.Body.Close()
+			notationBytes, err = ioutil.ReadAll(notationResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'notation': %w", err)
+			}
+		} else {
+			notationBytes, err = ioutil.ReadFile(filepath.Clean(notation))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'notation' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(notationBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing notation file: %w", err)
+		}
+	} else {
+		// we will need the signature envelope (as artifact); the signing certificate is carried inside it
+		re.NotationObj.Envelope = &models.NotationV001SchemaEnvelope{}
+		re.NotationObj.Envelope.MediaType = swag.String(viper.GetString("envelope-media-type"))
+
+		artifact := viper.GetString("artifact")
+		dataURL, err := url.Parse(artifact)
+		if err == nil && dataURL.IsAbs() {
+			re.NotationObj.Envelope.URL = strfmt.URI(artifact)
+		} else {
+			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.NotationObj.Envelope.Content = strfmt.Base64(artifactBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.NotationObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateDctFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Dct{}
+	re := new(dct_v001.V001Entry)
+
+	dct := viper.GetString("entry")
+	if dct != "" {
+		var dctBytes []byte
+		dctURL, err := url.Parse(dct)
+		if err == nil && dctURL.IsAbs() {
+			/* #nosec G107 */
+			dctResp, err := http.Get(dct)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'dct': %w", err)
+			}
+			defer dctResp.Body.Close()
+			dctBytes, err = ioutil.ReadAll(dctResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'dct': %w", err)
+			}
+		} else {
+			dctBytes, err = ioutil.ReadFile(filepath.Clean(dct))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'dct' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(dctBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing dct file: %w", err)
+		}
+	} else {
+		// we will need the signed targets metadata (as artifact), public-key and gun
+		re.DctObj.Gun = swag.String(viper.GetString("gun"))
+		re.DctObj.Role = viper.GetString("role")
+		re.DctObj.Metadata = &models.DctV001SchemaMetadata{}
+
+		artifact := viper.GetString("artifact")
+		dataURL, err := url.Parse(artifact)
+		if err == nil && dataURL.IsAbs() {
+			re.DctObj.Metadata.URL = strfmt.URI(artifact)
+		} else {
+			artifactBytes, err := ioutil.ReadFile(filepath.Clean(artifact))
+			if err != nil {
+				return nil, fmt.Errorf("error reading artifact file: %w", err)
+			}
+			re.DctObj.Metadata.Content = strfmt.Base64(artifactBytes)
+		}
+
+		re.DctObj.PublicKey = &models.DctV001SchemaPublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.DctObj.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.DctObj.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.DctObj
+	}
+
+	return &returnVal, nil
+}
+
+func CreateCommitmentFromPFlags() (models.ProposedEntry, error) {
+	//TODO: how to select version of item to create
+	returnVal := models.Commitment{}
+	re := new(commitment_v001.V001Entry)
+
+	commitment := viper.GetString("entry")
+	if commitment != "" {
+		var commitmentBytes []byte
+		commitmentURL, err := url.Parse(commitment)
+		if err == nil && commitmentURL.IsAbs() {
+			/* #nosec G107 */
+			commitmentResp, err := http.Get(commitment)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'commitment': %w", err)
+			}
+			defer commitmentResp.Body.Close()
+			commitmentBytes, err = ioutil.ReadAll(commitmentResp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching 'commitment': %w", err)
+			}
+		} else {
+			commitmentBytes, err = ioutil.ReadFile(filepath.Clean(commitment))
+			if err != nil {
+				return nil, fmt.Errorf("error processing 'commitment' file: %w", err)
+			}
+		}
+		if err := json.Unmarshal(commitmentBytes, &returnVal); err != nil {
+			return nil, fmt.Errorf("error parsing commitment file: %w", err)
+		}
+	} else {
+		// we will need artifact (the ciphertext), plaintext-commitment, public-key, signature
+		re.CommitmentObj.Ciphertext = &models.CommitmentV001SchemaCiphertext{}
+
+		// only the hash of the ciphertext is sent, as the log does not retain the ciphertext
+		artifact := viper.GetString("artifact")
+		ciphertextSHA, err := artifactSHA256(artifact)
+		if err != nil {
+			return nil, fmt.Errorf("error reading artifact: %w", err)
+		}
+		re.CommitmentObj.Ciphertext.Hash = &models.CommitmentV001SchemaCiphertextHash{
+			Algorithm: swag.String(models.CommitmentV001SchemaCiphertextHashAlgorithmSha256),
+			Value:     swag.String(ciphertextSHA),
+		}
+
+		re.CommitmentObj.PlaintextCommitment = &models.CommitmentV001SchemaPlaintextCommitment{
+			Algorithm: swag.String(models.CommitmentV001SchemaPlaintextCommitmentAlgorithmHmacSha256),
+			Value:     swag.String(strings.ToLower(viper.GetString("plaintext-commitment"))),
+		}
+
+		re.CommitmentObj.Signature = &models.CommitmentV001SchemaSignature{}
+		re.CommitmentObj.Signature.Format = viper.GetString("pki-format")
+		signature := viper.GetString("signature")
+		sigURL, err := url.Parse(signature)
+		if err == nil && sigURL.IsAbs() {
+			re.CommitmentObj.Signature.URL = strfmt.URI(signature)
+		} else {
+			signatureBytes, err := ioutil.ReadFile(filepath.Clean(signature))
+			if err != nil {
+				return nil, fmt.Errorf("error reading signature file: %w", err)
+			}
+			re.CommitmentObj.Signature.Content = strfmt.Base64(signatureBytes)
+		}
+
+		re.CommitmentObj.Signature.PublicKey = &models.CommitmentV001SchemaSignaturePublicKey{}
+		publicKey := viper.GetString("public-key")
+		keyURL, err := url.Parse(publicKey)
+		if err == nil && keyURL.IsAbs() {
+			re.CommitmentObj.Signature.PublicKey.URL = strfmt.URI(publicKey)
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
+			}
+			re.CommitmentObj.Signature.PublicKey.Content = strfmt.Base64(keyBytes)
+		}
+
+		if err := re.Validate(); err != nil {
+			return nil, err
+		}
+
+		if re.HasExternalEntities() {
+			if err := re.FetchExternalEntities(context.Background()); err != nil {
+				return nil, fmt.Errorf("error retrieving external entities: %v", err)
+			}
+		}
+
+		returnVal.APIVersion = swag.String(re.APIVersion())
+		returnVal.Spec = re.CommitmentObj
 	}
 
 	return &returnVal, nil
@@ -355,14 +1114,22 @@ func (t *typeFlag) String() string {
 
 func (t *typeFlag) Set(s string) error {
 	set := map[string]struct{}{
-		"rekord": {},
-		"rpm":    {},
+		"rekord":     {},
+		"rpm":        {},
+		"release":    {},
+		"tfprovider": {},
+		"kmod":       {},
+		"vmimage":    {},
+		"mlmodel":    {},
+		"notation":   {},
+		"dct":        {},
+		"commitment": {},
 	}
 	if _, ok := set[s]; ok {
 		t.value = s
 		return nil
 	}
-	return fmt.Errorf("value specified is invalid: [%s] supported values are: [rekord, rpm]", s)
+	return fmt.Errorf("value specified is invalid: [%s] supported values are: [rekord, rpm, release, tfprovider, kmod, vmimage, mlmodel, notation, dct, commitment]", s)
 }
 
 type pkiFormatFlag struct {
@@ -378,17 +1145,38 @@ func (f *pkiFormatFlag) String() string {
 }
 
 func (f *pkiFormatFlag) Set(s string) error {
-	set := map[string]struct{}{
-		"pgp":      {},
-		"minisign": {},
-		"x509":     {},
-		"ssh":      {},
-	}
-	if _, ok := set[s]; ok {
+	if pki.IsFormat(s) {
 		f.value = s
 		return nil
 	}
-	return fmt.Errorf("value specified is invalid: [%s] supported values are: [pgp, minisign, x509, ssh]", s)
+	return fmt.Errorf("value specified is invalid: [%s] supported values are: [%s]", s, strings.Join(pki.Formats(), ", "))
+}
+
+// shaFlag is a hex encoded digest of 256, 384 or 512 bits, the sizes of the digests entries are indexed by
+type shaFlag struct {
+	hash string
+}
+
+func (s *shaFlag) String() string {
+	return s.hash
+}
+
+func (s *shaFlag) Set(v string) error {
+	if v == "" {
+		return errors.New("flag must be specified")
+	}
+	if _, err := hex.DecodeString(v); (err != nil) || (len(v) != 64 && len(v) != 96 && len(v) != 128) {
+		if err == nil {
+			err = errors.New("invalid length for value")
+		}
+		return fmt.Errorf("value specified is invalid: %w", err)
+	}
+	s.hash = v
+	return nil
+}
+
+func (s *shaFlag) Type() string {
+	return "sha"
 }
 
 type uuidFlag struct {
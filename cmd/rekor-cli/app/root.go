@@ -54,11 +54,15 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().String("config", "", "config file (default is $HOME/.rekor.yaml)")
 	rootCmd.PersistentFlags().Bool("store_tree_state", true, "whether to store tree state in between invocations for additional verification")
+	rootCmd.PersistentFlags().Bool("tofu", true, "whether to pin the log ID of the server on first use and reject key changes afterwards")
+	rootCmd.PersistentFlags().String("trusted_config", "", "path to a rekor-configuration document obtained out of band (e.g. from a TUF repository) to take the server's public key from")
 
 	rootCmd.PersistentFlags().Var(&urlFlag{url: "https://api.rekor.dev"}, "rekor_server", "Server address:port")
 	rootCmd.PersistentFlags().Var(&formatFlag{format: "default"}, "format", "Command output format")
 
 	rootCmd.PersistentFlags().String("api-key", "", "API key for api.rekor.dev")
+	rootCmd.PersistentFlags().String("oidc-token", "", "OIDC token to authenticate with, for servers that only accept entries from authenticated clients")
+	rootCmd.PersistentFlags().String("tenant", "", "tenant whose log to use, for servers that host the logs of several tenants")
 
 	// these are bound here and not in PreRun so that all child commands can use them
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
@@ -125,8 +129,20 @@ func GetRekorClient(rekorServerURL string) (*client.Rekor, error) {
 	rt.Consumers["application/x-pem-file"] = runtime.TextConsumer()
 	rt.Producers["application/yaml"] = util.YamlProducer()
 
+	var auths []runtime.ClientAuthInfoWriter
 	if viper.GetString("api-key") != "" {
-		rt.DefaultAuthentication = httptransport.APIKeyAuth("apiKey", "query", viper.GetString("api-key"))
+		auths = append(auths, httptransport.APIKeyAuth("apiKey", "query", viper.GetString("api-key")))
+	}
+	if viper.GetString("oidc-token") != "" {
+		auths = append(auths, httptransport.BearerToken(viper.GetString("oidc-token")))
+	}
+	if tenant := viper.GetString("tenant"); tenant != "" {
+		auths = append(auths, runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return r.SetHeaderParam("X-Rekor-Tenant", tenant)
+		}))
+	}
+	if len(auths) > 0 {
+		rt.DefaultAuthentication = httptransport.Compose(auths...)
 	}
 	return client.New(rt, strfmt.Default), nil
 }
@@ -59,3 +59,24 @@ func TestAPIKey(t *testing.T) {
 	}
 	_, _ = client.Tlog.GetPublicKey(nil)
 }
+
+func TestOIDCToken(t *testing.T) {
+	var authorization string
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusOK)
+		}))
+	defer testServer.Close()
+
+	viper.Set("oidc-token", "thisIsAToken")
+	defer viper.Set("oidc-token", "")
+	client, err := GetRekorClient(testServer.URL)
+	if err != nil {
+		t.Error(err)
+	}
+	_, _ = client.Tlog.GetLogInfo(nil)
+	if authorization != "Bearer thisIsAToken" {
+		t.Errorf("unexpected Authorization header %q", authorization)
+	}
+}
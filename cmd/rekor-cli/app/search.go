@@ -31,6 +31,7 @@ import (
 	"github.com/go-openapi/strfmt"
 
 	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	"github.com/sigstore/rekor/pkg/generated/client"
 	"github.com/sigstore/rekor/pkg/generated/client/index"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/log"
@@ -58,7 +59,7 @@ func (s *searchCmdOutput) String() string {
 var searchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Rekor search command",
-	Long:  `Searches the Rekor index to find entries by artifact or public key`,
+	Long:  `Searches the Rekor index to find entries by artifact, public key, release, VM image, ML model, certificate principal, key ID, URI or OIDC issuer, email address or PIV device`,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		// these are bound here so that they are not overwritten by other commands
 		if err := viper.BindPFlags(cmd.Flags()); err != nil {
@@ -71,87 +72,123 @@ var searchCmd = &cobra.Command{
 		}
 	},
 	Run: format.WrapCmd(func(args []string) (interface{}, error) {
-		log := log.Logger
 		rekorClient, err := GetRekorClient(viper.GetString("rekor_server"))
 		if err != nil {
 			return nil, err
 		}
 
-		params := index.NewSearchIndexParams()
-		params.Query = &models.SearchIndex{}
+		uuids, err := searchIndex(rekorClient)
+		if err != nil {
+			return nil, err
+		}
 
-		artifactStr := viper.GetString("artifact")
-		sha := viper.GetString("sha")
-		if sha != "" {
-			params.Query.Hash = sha
-		} else if artifactStr != "" {
-			artifact := fileOrURLFlag{}
-			if err := artifact.Set(artifactStr); err != nil {
-				return nil, err
-			}
+		return &searchCmdOutput{
+			uuids: uuids,
+		}, nil
+	}),
+}
 
-			hasher := sha256.New()
-			var tee io.Reader
-			if artifact.IsURL {
-				/* #nosec G107 */
-				resp, err := http.Get(artifact.String())
-				if err != nil {
-					return nil, fmt.Errorf("error fetching '%v': %w", artifact.String(), err)
-				}
-				defer resp.Body.Close()
-				tee = io.TeeReader(resp.Body, hasher)
-			} else {
-				file, err := os.Open(filepath.Clean(artifact.String()))
-				if err != nil {
-					return nil, fmt.Errorf("error opening file '%v': %w", artifact.String(), err)
-				}
-				defer func() {
-					if err := file.Close(); err != nil {
-						log.Error(err)
-					}
-				}()
+// searchIndex queries the index of rekorClient using the search flags that were given
+func searchIndex(rekorClient *client.Rekor) ([]string, error) {
+	log := log.Logger
+	params := index.NewSearchIndexParams()
+	params.Query = &models.SearchIndex{}
+
+	artifactStr := viper.GetString("artifact")
+	sha := viper.GetString("sha")
+	if sha != "" {
+		params.Query.Hash = sha
+	} else if artifactStr != "" {
+		artifact := fileOrURLFlag{}
+		if err := artifact.Set(artifactStr); err != nil {
+			return nil, err
+		}
 
-				tee = io.TeeReader(file, hasher)
+		hasher := sha256.New()
+		var tee io.Reader
+		if artifact.IsURL {
+			/* #nosec G107 */
+			resp, err := http.Get(artifact.String())
+			if err != nil {
+				return nil, fmt.Errorf("error fetching '%v': %w", artifact.String(), err)
 			}
-			if _, err := ioutil.ReadAll(tee); err != nil {
-				return nil, fmt.Errorf("error processing '%v': %w", artifact.String(), err)
+			defer resp.Body.Close()
+			tee = io.TeeReader(resp.Body, hasher)
+		} else {
+			file, err := os.Open(filepath.Clean(artifact.String()))
+			if err != nil {
+				return nil, fmt.Errorf("error opening file '%v': %w", artifact.String(), err)
 			}
+			defer func() {
+				if err := file.Close(); err != nil {
+					log.Error(err)
+				}
+			}()
 
-			hashVal := strings.ToLower(hex.EncodeToString(hasher.Sum(nil)))
-			params.Query.Hash = hashVal
+			tee = io.TeeReader(file, hasher)
+		}
+		if _, err := ioutil.ReadAll(tee); err != nil {
+			return nil, fmt.Errorf("error processing '%v': %w", artifact.String(), err)
 		}
 
-		publicKeyStr := viper.GetString("public-key")
-		if publicKeyStr != "" {
-			params.Query.PublicKey = &models.SearchIndexPublicKey{}
-			pkiFormat := viper.GetString("pki-format")
-			switch pkiFormat {
-			case "pgp":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatPgp)
-			case "minisign":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatMinisign)
-			case "x509":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatX509)
-			case "ssh":
-				params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatSSH)
-			default:
-				return nil, fmt.Errorf("unknown pki-format %v", pkiFormat)
-			}
-			publicKey := fileOrURLFlag{}
-			if err := publicKey.Set(publicKeyStr); err != nil {
-				return nil, err
-			}
-			if publicKey.IsURL {
-				params.Query.PublicKey.URL = strfmt.URI(publicKey.String())
-			} else {
-				keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey.String()))
-				if err != nil {
-					return nil, fmt.Errorf("error reading public key file: %w", err)
-				}
-				params.Query.PublicKey.Content = strfmt.Base64(keyBytes)
+		hashVal := strings.ToLower(hex.EncodeToString(hasher.Sum(nil)))
+		params.Query.Hash = hashVal
+	}
+
+	publicKeyStr := viper.GetString("public-key")
+	if publicKeyStr != "" {
+		params.Query.PublicKey = &models.SearchIndexPublicKey{}
+		pkiFormat := viper.GetString("pki-format")
+		switch pkiFormat {
+		case "pgp":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatPgp)
+		case "minisign":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatMinisign)
+		case "x509":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatX509)
+		case "ssh":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatSSH)
+		case "ed25519":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatEd25519)
+		case "tuf":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatTuf)
+		case "cosign":
+			params.Query.PublicKey.Format = swag.String(models.SearchIndexPublicKeyFormatCosign)
+		default:
+			return nil, fmt.Errorf("unknown pki-format %v", pkiFormat)
+		}
+		publicKey := fileOrURLFlag{}
+		if err := publicKey.Set(publicKeyStr); err != nil {
+			return nil, err
+		}
+		if publicKey.IsURL {
+			params.Query.PublicKey.URL = strfmt.URI(publicKey.String())
+		} else {
+			keyBytes, err := ioutil.ReadFile(filepath.Clean(publicKey.String()))
+			if err != nil {
+				return nil, fmt.Errorf("error reading public key file: %w", err)
 			}
+			params.Query.PublicKey.Content = strfmt.Base64(keyBytes)
 		}
+	}
 
+	params.Query.Release = viper.GetString("release")
+	params.Query.Image = viper.GetString("image")
+	params.Query.Model = viper.GetString("model")
+	params.Query.Principal = viper.GetString("principal")
+	params.Query.Email = viper.GetString("email")
+	params.Query.KeyID = viper.GetString("key-id")
+	params.Query.URI = viper.GetString("uri")
+	params.Query.OidcIssuer = viper.GetString("oidc-issuer")
+	params.Query.CertificateIdentity = viper.GetString("certificate-identity")
+	params.Query.Piv = viper.GetString("piv")
+	params.Query.SubjectDigest = viper.GetString("subject-digest")
+	params.Query.HashPrefix = viper.GetString("sha-prefix")
+	params.Query.EmailSuffix = viper.GetString("email-suffix")
+
+	// the server may return the results in pages, each continuing where the previous one ended
+	var result []string
+	for {
 		resp, err := rekorClient.Index.SearchIndex(params)
 		if err != nil {
 			switch t := err.(type) {
@@ -164,11 +201,12 @@ var searchCmd = &cobra.Command{
 				return nil, err
 			}
 		}
-
-		return &searchCmdOutput{
-			uuids: resp.GetPayload(),
-		}, nil
-	}),
+		result = append(result, resp.GetPayload()...)
+		if resp.XRekorContinuationToken == "" {
+			return result, nil
+		}
+		params.Query.ContinuationToken = resp.XRekorContinuationToken
+	}
 }
 
 func init() {
@@ -88,3 +88,54 @@ func getRekorDir() (string, error) {
 	}
 	return rekorDir, nil
 }
+
+type pinnedKeys map[string]string
+
+// PinLogID records the log ID first seen for url so later invocations can detect a key change
+func PinLogID(url, logID string) error {
+	rekorDir, err := getRekorDir()
+	if err != nil {
+		return err
+	}
+	pinsPath := filepath.Join(rekorDir, "pinned_keys.json")
+
+	pins := loadPinsFile()
+	if pins == nil {
+		pins = make(pinnedKeys)
+	}
+	pins[url] = logID
+
+	b, err := json.Marshal(&pins)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(pinsPath, b, 0600); err != nil {
+		return err
+	}
+	return nil
+}
+
+func loadPinsFile() pinnedKeys {
+	rekorDir, err := getRekorDir()
+	if err != nil {
+		return nil
+	}
+	fp := filepath.Join(rekorDir, "pinned_keys.json")
+	b, err := ioutil.ReadFile(filepath.Clean(fp))
+	if err != nil {
+		return nil
+	}
+	result := pinnedKeys{}
+	if err := json.Unmarshal(b, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// PinnedLogID returns the log ID previously pinned for url, or an empty string if there is none
+func PinnedLogID(url string) string {
+	if pins := loadPinsFile(); pins != nil {
+		return pins[url]
+	}
+	return ""
+}
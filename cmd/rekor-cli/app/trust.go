@@ -0,0 +1,173 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package app
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/cmd/rekor-cli/app/state"
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// serverPublicKey returns the key that signed tree heads from serverURL carrying keyHint must be
+// verified with; configuration documents may publish keys the log was signed with before being
+// rotated, which are chosen by the key hint of the tree head.
+//
+// In order of precedence, the key is taken from:
+//   - the rekor_server_public_key configuration value
+//   - the configuration document named by --trusted_config, which is expected to have been
+//     distributed out of band (e.g. as a TUF target)
+//   - the server's /.well-known/rekor-configuration document (or /api/v1/log/publicKey on older
+//     servers), pinned on first use when --tofu is set
+func serverPublicKey(rekorClient *client.Rekor, serverURL string, keyHint []byte) (crypto.PublicKey, error) {
+	if publicKey := viper.GetString("rekor_server_public_key"); publicKey != "" {
+		pub, _, err := parsePublicKeyPEM(publicKey)
+		return pub, err
+	}
+
+	if trustedConfig := viper.GetString("trusted_config"); trustedConfig != "" {
+		configBytes, err := ioutil.ReadFile(filepath.Clean(trustedConfig))
+		if err != nil {
+			return nil, fmt.Errorf("error reading trusted configuration: %w", err)
+		}
+		config := models.RekorConfiguration{}
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return nil, fmt.Errorf("error parsing trusted configuration: %w", err)
+		}
+		if err := config.Validate(strfmt.Default); err != nil {
+			return nil, fmt.Errorf("error parsing trusted configuration: %w", err)
+		}
+		pub, _, err := verificationKey(&config, keyHint)
+		return pub, err
+	}
+
+	var pub crypto.PublicKey
+	var logID string
+	result, err := rekorClient.Tlog.GetRekorConfiguration(nil)
+	if err == nil {
+		pub, logID, err = verificationKey(result.GetPayload(), keyHint)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var defaultErr *tlog.GetRekorConfigurationDefault
+		if !errors.As(err, &defaultErr) || defaultErr.Code() != http.StatusNotFound {
+			return nil, err
+		}
+		// servers predating the configuration document only publish the key itself
+		keyResp, err := rekorClient.Tlog.GetPublicKey(nil)
+		if err != nil {
+			return nil, err
+		}
+		pub, logID, err = parsePublicKeyPEM(keyResp.Payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !viper.GetBool("tofu") {
+		return pub, nil
+	}
+	pinned := state.PinnedLogID(serverURL)
+	if pinned == "" {
+		log.CliLogger.Infof("Pinning log ID %v for %v", logID, serverURL)
+		if err := state.PinLogID(serverURL, logID); err != nil {
+			log.CliLogger.Infof("Unable to store pinned log ID: %v", err)
+		}
+	} else if pinned != logID {
+		return nil, fmt.Errorf("log ID %v returned by server does not match previously pinned log ID %v", logID, pinned)
+	}
+	return pub, nil
+}
+
+// verificationKey returns the key in config that verifies tree heads carrying keyHint along with its
+// log ID, which is the active key unless a key published for a previous signing key has that hint
+func verificationKey(config *models.RekorConfiguration, keyHint []byte) (crypto.PublicKey, string, error) {
+	if len(keyHint) > 0 {
+		for _, key := range config.PublicKeys {
+			if key == nil || !bytes.Equal(key.KeyHint, keyHint) {
+				continue
+			}
+			pub, logID, err := parsePublicKeyPEM(swag.StringValue(key.Key))
+			if err != nil {
+				return nil, "", err
+			}
+			if !strings.EqualFold(swag.StringValue(key.LogID), logID) {
+				return nil, "", fmt.Errorf("public key does not match advertised log ID %v", swag.StringValue(key.LogID))
+			}
+			return pub, logID, nil
+		}
+	}
+	pub, err := activeKey(config)
+	if err != nil {
+		return nil, "", err
+	}
+	return pub, strings.ToLower(swag.StringValue(config.LogID)), nil
+}
+
+// activeKey returns the public key of the log ID advertised in config, after checking
+// that the key actually hashes to that log ID
+func activeKey(config *models.RekorConfiguration) (crypto.PublicKey, error) {
+	wantLogID := strings.ToLower(swag.StringValue(config.LogID))
+	for _, key := range config.PublicKeys {
+		if key == nil || !strings.EqualFold(swag.StringValue(key.LogID), wantLogID) {
+			continue
+		}
+		pub, logID, err := parsePublicKeyPEM(swag.StringValue(key.Key))
+		if err != nil {
+			return nil, err
+		}
+		if logID != wantLogID {
+			return nil, fmt.Errorf("public key does not match advertised log ID %v", wantLogID)
+		}
+		return pub, nil
+	}
+	return nil, fmt.Errorf("no public key found for log ID %v", wantLogID)
+}
+
+// parsePublicKeyPEM parses a PEM-encoded PKIX public key and returns it along with its log ID
+func parsePublicKeyPEM(publicKey string) (crypto.PublicKey, string, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return nil, "", errors.New("failed to decode public key of server")
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, "", err
+	}
+	keyHash := sha256.Sum256(block.Bytes)
+	return pub, hex.EncodeToString(keyHash[:]), nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func testConfiguration(t *testing.T) models.RekorConfiguration {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyHash := sha256.Sum256(der)
+	logID := hex.EncodeToString(keyHash[:])
+	key := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	return models.RekorConfiguration{
+		LogID: swag.String(logID),
+		PublicKeys: []*models.RekorConfigurationPublicKeysItems0{
+			{LogID: swag.String(logID), Key: swag.String(key)},
+		},
+		Shards: []*models.RekorConfigurationShardsItems0{
+			{TreeID: swag.String("1"), LogID: swag.String(logID), Active: swag.Bool(true)},
+		},
+		APIVersions: []string{"v1"},
+	}
+}
+
+func TestServerPublicKeyTOFU(t *testing.T) {
+	home, err := ioutil.TempDir("", "rekor-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+
+	config := testConfiguration(t)
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/.well-known/rekor-configuration" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(&config)
+		}))
+	defer testServer.Close()
+
+	viper.Set("tofu", true)
+	defer viper.Set("tofu", nil)
+	client, err := GetRekorClient(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := serverPublicKey(client, testServer.URL, nil); err != nil {
+		t.Fatalf("unexpected error on first use: %v", err)
+	}
+	if _, err := serverPublicKey(client, testServer.URL, nil); err != nil {
+		t.Fatalf("unexpected error with unchanged key: %v", err)
+	}
+
+	// rotating the key behind the same URL must be detected
+	config = testConfiguration(t)
+	if _, err := serverPublicKey(client, testServer.URL, nil); err == nil {
+		t.Fatal("expected error after key change, got none")
+	}
+
+	// an out of band configuration takes precedence over the pin
+	configPath := filepath.Join(home, "config.json")
+	configBytes, err := json.Marshal(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(configPath, configBytes, 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("trusted_config", configPath)
+	defer viper.Set("trusted_config", "")
+	if _, err := serverPublicKey(client, testServer.URL, nil); err != nil {
+		t.Fatalf("unexpected error with trusted configuration: %v", err)
+	}
+}
+
+func TestActiveKey(t *testing.T) {
+	config := testConfiguration(t)
+	if _, err := activeKey(&config); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	other := testConfiguration(t)
+	config.PublicKeys[0].Key = other.PublicKeys[0].Key
+	if _, err := activeKey(&config); err == nil {
+		t.Error("expected error for key not matching log ID, got none")
+	}
+
+	config.LogID = other.LogID
+	config.PublicKeys = nil
+	if _, err := activeKey(&config); err == nil {
+		t.Error("expected error for missing key, got none")
+	}
+}
+
+func TestVerificationKey(t *testing.T) {
+	config := testConfiguration(t)
+	previous := testConfiguration(t).PublicKeys[0]
+	previous.KeyHint = []byte{0, 0, 0, 0, 0, 0, 0, 1}
+	config.PublicKeys = append(config.PublicKeys, previous)
+
+	if _, logID, err := verificationKey(&config, nil); err != nil || logID != *config.LogID {
+		t.Errorf("got log ID %v with error %v, expected the active key", logID, err)
+	}
+	if _, logID, err := verificationKey(&config, previous.KeyHint); err != nil || logID != *previous.LogID {
+		t.Errorf("got log ID %v with error %v, expected the previous key", logID, err)
+	}
+	// tree heads with a hint no previous key was published for are verified with the active key
+	if _, logID, err := verificationKey(&config, []byte{0, 0, 0, 0, 0, 0, 0, 2}); err != nil || logID != *config.LogID {
+		t.Errorf("got log ID %v with error %v, expected the active key", logID, err)
+	}
+
+	previous.LogID = config.LogID
+	if _, _, err := verificationKey(&config, previous.KeyHint); err == nil {
+		t.Error("expected error for key not matching log ID, got none")
+	}
+}
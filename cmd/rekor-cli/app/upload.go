@@ -19,9 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
+	"strings"
 
 	"github.com/go-openapi/swag"
 	"github.com/sigstore/rekor/cmd/rekor-cli/app/format"
+	"github.com/sigstore/rekor/pkg/generated/client"
 	"github.com/sigstore/rekor/pkg/generated/client/entries"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/log"
@@ -33,13 +36,39 @@ type uploadCmdOutput struct {
 	AlreadyExists bool
 	Location      string
 	Index         int64
+	ReusedBy      []string `json:",omitempty"`
+	Manifest      string   `json:",omitempty"`
 }
 
 func (u *uploadCmdOutput) String() string {
 	if u.AlreadyExists {
-		return fmt.Sprintf("Entry already exists; available at: %v%v\n", viper.GetString("rekor_server"), u.Location)
+		str := fmt.Sprintf("Entry already exists at index %d; available at: %v%v\n", u.Index, viper.GetString("rekor_server"), u.Location)
+		if u.Manifest != "" {
+			str += fmt.Sprintf("Recorded entry and bundle in manifest %v\n", u.Manifest)
+		}
+		return str
+	}
+	str := fmt.Sprintf("Created entry at index %d, available at: %v%v\n", u.Index, viper.GetString("rekor_server"), u.Location)
+	if len(u.ReusedBy) > 0 {
+		str += fmt.Sprintf("Warning: the same signature was previously logged under a different public key in entries: %v\n", strings.Join(u.ReusedBy, ", "))
 	}
-	return fmt.Sprintf("Created entry at index %d, available at: %v%v\n", u.Index, viper.GetString("rekor_server"), u.Location)
+	if u.Manifest != "" {
+		str += fmt.Sprintf("Recorded entry and bundle in manifest %v\n", u.Manifest)
+	}
+	return str
+}
+
+type dryRunCmdOutput struct {
+	UUID     string
+	ReusedBy []string `json:",omitempty"`
+}
+
+func (d *dryRunCmdOutput) String() string {
+	str := fmt.Sprintf("Entry is valid and would be added with UUID: %v\n", d.UUID)
+	if len(d.ReusedBy) > 0 {
+		str += fmt.Sprintf("Warning: the same signature was previously logged under a different public key in entries: %v\n", strings.Join(d.ReusedBy, ", "))
+	}
+	return str
 }
 
 // uploadCmd represents the upload command
@@ -56,10 +85,21 @@ var uploadCmd = &cobra.Command{
 			_ = cmd.Help()
 			os.Exit(1)
 		}
+		if viper.GetString("manifest") != "" && viper.GetString("artifact") == "" && viper.GetString("entry") == "" {
+			log.Logger.Error("--manifest requires either --artifact or --entry")
+			_ = cmd.Help()
+			os.Exit(1)
+		}
+		if viper.GetBool("dry-run") && viper.GetString("manifest") != "" {
+			log.Logger.Error("--manifest cannot be used with --dry-run")
+			_ = cmd.Help()
+			os.Exit(1)
+		}
 	},
 	Long: `This command takes the public key, signature and URL of the release artifact and uploads it to the rekor server.`,
 	Run: format.WrapCmd(func(args []string) (interface{}, error) {
-		rekorClient, err := GetRekorClient(viper.GetString("rekor_server"))
+		serverURL := viper.GetString("rekor_server")
+		rekorClient, err := GetRekorClient(serverURL)
 		if err != nil {
 			return nil, err
 		}
@@ -77,41 +117,132 @@ var uploadCmd = &cobra.Command{
 			if err != nil {
 				return nil, err
 			}
+		case "release":
+			entry, err = CreateReleaseFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "tfprovider":
+			entry, err = CreateTfproviderFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "kmod":
+			entry, err = CreateKmodFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "vmimage":
+			entry, err = CreateVmimageFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "mlmodel":
+			entry, err = CreateMlmodelFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "notation":
+			entry, err = CreateNotationFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "dct":
+			entry, err = CreateDctFromPFlags()
+			if err != nil {
+				return nil, err
+			}
+		case "commitment":
+			entry, err = CreateCommitmentFromPFlags()
+			if err != nil {
+				return nil, err
+			}
 		default:
 			return nil, errors.New("unknown type specified")
 		}
 
+		if viper.GetBool("dry-run") {
+			return validateEntry(rekorClient, entry)
+		}
+
 		params.SetProposedEntry(entry)
 
 		resp, err := rekorClient.Entries.CreateLogEntry(params)
 		if err != nil {
 			switch e := err.(type) {
 			case *entries.CreateLogEntryConflict:
-				return &uploadCmdOutput{
+				o := &uploadCmdOutput{
 					Location:      e.Location.String(),
+					Index:         e.XRekorLogIndex,
 					AlreadyExists: true,
-				}, nil
+				}
+				// an existing entry is still recorded, so that interrupted batch uploads can be rerun
+				if manifestPath := viper.GetString("manifest"); manifestPath != "" {
+					if err := recordInManifest(rekorClient, serverURL, manifestPath, path.Base(o.Location)); err != nil {
+						return nil, err
+					}
+					o.Manifest = manifestPath
+				}
+				return o, nil
 			default:
 				return nil, err
 			}
 		}
 
 		var newIndex int64
-		for _, entry := range resp.Payload {
+		var uuid string
+		for k, entry := range resp.Payload {
 			newIndex = swag.Int64Value(entry.LogIndex)
+			uuid = k
+		}
+
+		var reusedBy []string
+		if resp.XRekorSignatureReuse != "" {
+			reusedBy = strings.Split(resp.XRekorSignatureReuse, ",")
 		}
 
-		return &uploadCmdOutput{
+		o := &uploadCmdOutput{
 			Location: string(resp.Location),
 			Index:    newIndex,
-		}, nil
+			ReusedBy: reusedBy,
+		}
+		if manifestPath := viper.GetString("manifest"); manifestPath != "" {
+			if err := recordInManifest(rekorClient, serverURL, manifestPath, uuid); err != nil {
+				return nil, err
+			}
+			o.Manifest = manifestPath
+		}
+		return o, nil
 	}),
 }
 
+// validateEntry asks the server to check entry without adding it to the log
+func validateEntry(rekorClient *client.Rekor, entry models.ProposedEntry) (*dryRunCmdOutput, error) {
+	params := entries.NewValidateLogEntryParams()
+	params.SetProposedEntry(entry)
+	resp, err := rekorClient.Entries.ValidateLogEntry(params)
+	if err != nil {
+		if e, ok := err.(*entries.ValidateLogEntryConflict); ok {
+			return nil, fmt.Errorf("entry already exists; available at: %v%v", viper.GetString("rekor_server"), e.Location)
+		}
+		return nil, err
+	}
+
+	// the ETag is the quoted UUID the entry would be added as
+	o := &dryRunCmdOutput{UUID: strings.Trim(resp.ETag, `"`)}
+	if resp.XRekorSignatureReuse != "" {
+		o.ReusedBy = strings.Split(resp.XRekorSignatureReuse, ",")
+	}
+	return o, nil
+}
+
 func init() {
 	if err := addArtifactPFlags(uploadCmd); err != nil {
 		log.Logger.Fatal("Error parsing cmd line args:", err)
 	}
+	uploadCmd.Flags().String("manifest", "", "path to a JSON manifest to record the entry in; it is created if it does not exist")
+	uploadCmd.Flags().Bool("dry-run", false, "check the entry with the server and print the UUID it would be added with, without adding it to the log")
+	uploadCmd.Flags().String("bundle-dir", "", "directory to write the entry's verification bundle to (defaults to the directory of --manifest)")
 
 	rootCmd.AddCommand(uploadCmd)
 }
@@ -116,6 +116,46 @@ var verifyCmd = &cobra.Command{
 					if err != nil {
 						return nil, err
 					}
+				case "release":
+					entry, err = CreateReleaseFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "tfprovider":
+					entry, err = CreateTfproviderFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "kmod":
+					entry, err = CreateKmodFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "vmimage":
+					entry, err = CreateVmimageFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "mlmodel":
+					entry, err = CreateMlmodelFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "notation":
+					entry, err = CreateNotationFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "dct":
+					entry, err = CreateDctFromPFlags()
+					if err != nil {
+						return nil, err
+					}
+				case "commitment":
+					entry, err = CreateCommitmentFromPFlags()
+					if err != nil {
+						return nil, err
+					}
 				default:
 					return nil, errors.New("invalid type specified")
 				}
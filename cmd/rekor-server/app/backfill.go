@@ -0,0 +1,74 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"flag"
+
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// backfillCmd represents the backfill command
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Rebuild the search index by replaying the log",
+	Long: `Reads every leaf of the tree from --start up to --end, or up to the current tree size, and writes the
+index keys of each entry, its integrated time and its kind to the configured index backend, so that the index
+can be rebuilt from the log after it was lost or moved to another backend.
+
+The index should be empty beforehand, as replayed entries are added to it again rather than replaced. Index
+keys are computed from the entries as they were logged; keys that a kind derives from content which is not
+part of the logged entry, such as the parsed public key of some kinds, are not recovered.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.ConfigureLogger(viper.GetString("log_type"))
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		_ = flag.CommandLine.Parse([]string{})
+
+		ctx := context.Background()
+		if err := api.ConfigureBackfill(ctx); err != nil {
+			return err
+		}
+		stats, err := api.Backfill(ctx, viper.GetString("tenant"), viper.GetInt64("start"), viper.GetInt64("end"), viper.GetInt64("batch-size"), func(s api.BackfillStats) {
+			log.Logger.Infow("backfilled index", "entries", s.Entries, "keys", s.Keys, "skipped", s.Skipped)
+		})
+		if err != nil {
+			return err
+		}
+		log.Logger.Infow("finished backfilling index", "entries", stats.Entries, "keys", stats.Keys, "skipped", stats.Skipped)
+		return nil
+	},
+}
+
+func init() {
+	backfillCmd.Flags().Int64("start", 0, "log index of the first leaf replayed")
+	backfillCmd.Flags().Int64("end", -1, "log index after the last leaf replayed, or -1 for the current tree size")
+	backfillCmd.Flags().Int64("batch-size", 100, "number of leaves read from the log and indexed at a time")
+	backfillCmd.Flags().String("tenant", "", "tenant whose tree is replayed into its index namespace, or empty for the default tree")
+	rootCmd.AddCommand(backfillCmd)
+}
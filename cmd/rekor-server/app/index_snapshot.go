@@ -0,0 +1,130 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// exportIndexCmd represents the export-index command
+var exportIndexCmd = &cobra.Command{
+	Use:   "export-index",
+	Short: "Write the search index to a portable file",
+	Long: `Writes every list, sorted set, counter and value of the configured index backend to --file, preceded by
+the size of the tree of each tenant when the export started. The file can be imported with import-index into
+an empty index of any backend, to move the index to another backend or to bootstrap a read replica without
+replaying the whole log.
+
+Entries added while exporting may be missing from the file; backfill the imported index from the recorded tree
+size of each tree to bring it up to date.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.ConfigureLogger(viper.GetString("log_type"))
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		_ = flag.CommandLine.Parse([]string{})
+
+		path := viper.GetString("file")
+		if path == "" {
+			return errors.New("--file must be set")
+		}
+		ctx := context.Background()
+		if err := api.ConfigureIndexExport(ctx); err != nil {
+			return err
+		}
+		f, err := os.Create(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		stats, err := api.ExportIndex(ctx, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		log.Logger.Infow("finished exporting index", "records", stats.Records, "treeSizes", stats.TreeSizes)
+		return nil
+	},
+}
+
+// importIndexCmd represents the import-index command
+var importIndexCmd = &cobra.Command{
+	Use:   "import-index",
+	Short: "Load the search index from a file written by export-index",
+	Long: `Writes every record of --file to the configured index backend, which must not hold any entries yet, as
+imported lists and counters are added to rather than replaced.
+
+The file holds the index as of the tree sizes it was exported at; run backfill with --start set to the size of
+each tree that has grown since to index the entries added after it.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		// these are bound here so that they are not overwritten by other commands
+		if err := viper.BindPFlags(cmd.Flags()); err != nil {
+			log.Logger.Fatal("Error initializing cmd line args: ", err)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.ConfigureLogger(viper.GetString("log_type"))
+
+		// workaround for https://github.com/sigstore/rekor/issues/68
+		_ = flag.CommandLine.Parse([]string{})
+
+		path := viper.GetString("file")
+		if path == "" {
+			return errors.New("--file must be set")
+		}
+		ctx := context.Background()
+		if err := api.ConfigureIndexImport(ctx); err != nil {
+			return err
+		}
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		stats, err := api.ImportIndex(ctx, f)
+		if err != nil {
+			return err
+		}
+		for tenant, size := range stats.TreeSizes {
+			log.Logger.Infow("imported index up to tree size; backfill from it to index later entries", "tenant", tenant, "start", size)
+		}
+		log.Logger.Infow("finished importing index", "records", stats.Records)
+		return nil
+	},
+}
+
+func init() {
+	exportIndexCmd.Flags().String("file", "", "path of the file the index is written to")
+	importIndexCmd.Flags().String("file", "", "path of a file written by export-index")
+	rootCmd.AddCommand(exportIndexCmd, importIndexCmd)
+}
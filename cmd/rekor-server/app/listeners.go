@@ -0,0 +1,92 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// listenFDsStart is the first file descriptor systemd passes sockets to a service as
+const listenFDsStart = 3
+
+// systemdListeners returns the sockets passed to this process by systemd socket activation, following the
+// LISTEN_PID and LISTEN_FDS protocol of sd_listen_fds(3)
+func systemdListeners() ([]net.Listener, error) {
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil, errors.New("no sockets were passed to this process by systemd")
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", os.Getenv("LISTEN_FDS"))
+	}
+	// the sockets are meant for this process alone, not for anything it starts
+	for _, v := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+		os.Unsetenv(v)
+	}
+
+	listeners := make([]net.Listener, 0, n)
+	for fd := listenFDsStart; fd < listenFDsStart+n; fd++ {
+		f := os.NewFile(uintptr(fd), fmt.Sprintf("systemd socket %d", fd))
+		// FileListener duplicates the descriptor, so the original is closed either way
+		l, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("socket %d passed by systemd: %w", fd, err)
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners, nil
+}
+
+// serveSystemdListeners serves handler on the sockets passed by systemd, returning once serving any of them fails
+func serveSystemdListeners(handler http.Handler) error {
+	listeners, err := systemdListeners()
+	if err != nil {
+		return err
+	}
+	errs := make(chan error, len(listeners))
+	for _, l := range listeners {
+		log.Logger.Infof("Serving rekor server on socket %v passed by systemd", l.Addr())
+		go func(l net.Listener) {
+			errs <- http.Serve(l, handler)
+		}(l)
+	}
+	return <-errs
+}
+
+// removeStaleSocket removes the unix socket left at path by a previous run, which would otherwise stop the
+// server from listening there; anything other than a socket is left alone
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%v exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
@@ -19,8 +19,12 @@ package app
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
 	"github.com/spf13/cobra"
 
 	homedir "github.com/mitchellh/go-homedir"
@@ -57,15 +61,132 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.rekor-server.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logType, "log_type", "dev", "logger type to use (dev/prod)")
 
-	rootCmd.PersistentFlags().String("trillian_log_server.address", "127.0.0.1", "Trillian log server address")
+	rootCmd.PersistentFlags().String("trillian_log_server.address", "127.0.0.1", "Trillian log server address, 'embedded' to keep the log in memory for development, losing it when the server stops, or 'sqlite' to keep it in the SQLite database at trillian_log_server.sqlite_path")
+	rootCmd.PersistentFlags().String("trillian_log_server.sqlite_path", "rekor-log.db", "SQLite database the log is kept in when trillian_log_server.address is 'sqlite', which also holds the key tree heads are signed with; requires a server built with the sqlite tag")
 	rootCmd.PersistentFlags().Uint16("trillian_log_server.port", 8091, "Trillian log server port")
 	rootCmd.PersistentFlags().Uint("trillian_log_server.tlog_id", 0, "Trillian tree id")
+	rootCmd.PersistentFlags().StringSlice("trillian_log_server.inactive_shards", []string{}, "IDs of the frozen trees the log was written to before trillian_log_server.tlog_id, oldest first; their entries precede those of the current tree in the log index, and entries are read from the tree holding them")
+	rootCmd.PersistentFlags().String("trillian_log_server.previous_public_keys", "", "file containing the PEM encoded public keys the log signed tree heads with before its key was rotated, each with a Key-Hint header giving the base64-encoded key hint of the tree heads it signed")
+	rootCmd.PersistentFlags().String("trillian_log_server.signing_curve", "P-256", "curve of the ECDSA key the tree heads of a newly created tree are signed with, one of P-256, P-384 or P-521")
 	rootCmd.PersistentFlags().String("rekor_server.address", "127.0.0.1", "Address to bind to")
 	rootCmd.PersistentFlags().Uint16("rekor_server.port", 3000, "Port to bind to")
+	rootCmd.PersistentFlags().String("rekor_server.socket_path", "", "unix socket to serve the API on in addition to rekor_server.address and rekor_server.port, for reverse proxies and sidecars on the same host")
+	rootCmd.PersistentFlags().Bool("rekor_server.systemd_activation", false, "serves the API on the sockets passed by systemd socket activation instead of binding to rekor_server.address, rekor_server.port and rekor_server.socket_path")
+
+	rootCmd.PersistentFlags().Bool("enable_grpc_api", false, "enables the gRPC API alongside the REST API")
+	rootCmd.PersistentFlags().Uint16("rekor_server.grpc_port", 3001, "Port to bind the gRPC API to")
+
+	rootCmd.PersistentFlags().Bool("read-only", false, "rejects new entries while serving entries, proofs and searches, for read replicas and maintenance freezes (can be changed at runtime through the admin API)")
+
+	rootCmd.PersistentFlags().String("checkpoint.signing_key", "", "file containing the PEM encoded PKCS#8 Ed25519 private key the tree head is signed with when published as a checkpoint in the signed note format; if unset, checkpoints are not published")
+	rootCmd.PersistentFlags().String("checkpoint.key_name", "rekor", "name of the checkpoint signing key in the signatures of checkpoints, which must not contain spaces or '+'")
+	rootCmd.PersistentFlags().String("checkpoint.origin", "", "origin line identifying the log in its checkpoints (defaults to the key name followed by the tree ID)")
+
+	rootCmd.PersistentFlags().StringSlice("tenancy.trees", []string{}, "trees of the tenants served alongside the default tree, each given as name=treeID; requests are served from the tree of the tenant named by their /tenants/<name> path prefix or X-Rekor-Tenant header, with a search index namespace of its own")
+	rootCmd.PersistentFlags().StringSlice("tenancy.writers", []string{}, "identities allowed to add entries to each tenant when writes are authenticated, each given as name=identity; authenticated clients not listed for a tenant may not add entries to it")
+
+	rootCmd.PersistentFlags().Bool("enable_ct_api", false, "serves the read endpoints of RFC 6962 (get-sth, get-sth-consistency, get-proof-by-hash and get-entries) under /ct/v1/ for certificate transparency monitors")
+	rootCmd.PersistentFlags().Bool("enable_graphql_api", false, "serves GraphQL queries composing entries, searches and proofs at /api/v1/graphql")
 
 	rootCmd.PersistentFlags().Bool("enable_retrieve_api", true, "enables Redis-based index API endpoint")
 	rootCmd.PersistentFlags().String("redis_server.address", "127.0.0.1", "Redis server address")
 	rootCmd.PersistentFlags().Uint16("redis_server.port", 6379, "Redis server port")
+	rootCmd.PersistentFlags().String("redis_server.mode", "standalone", "how the Redis index is deployed: standalone, a single server at redis_server.address; cluster, a Redis Cluster discovered from redis_server.nodes; or sentinel, a primary found and followed through failovers by the sentinels at redis_server.nodes")
+	rootCmd.PersistentFlags().StringSlice("redis_server.nodes", []string{}, "host:port addresses of the cluster nodes or sentinels to connect to in cluster or sentinel mode (defaults to redis_server.address and redis_server.port)")
+	rootCmd.PersistentFlags().String("redis_server.sentinel_primary", "mymaster", "name the sentinels monitor the Redis primary under in sentinel mode")
+	rootCmd.PersistentFlags().String("redis_server.username", "", "username to authenticate to Redis with, for servers using ACLs; if empty, only the password is sent")
+	rootCmd.PersistentFlags().String("redis_server.password", "", "password to authenticate to Redis with, or empty to not authenticate")
+	rootCmd.PersistentFlags().Bool("redis_server.enable_tls", false, "connects to Redis over TLS, as many managed Redis offerings require")
+	rootCmd.PersistentFlags().String("redis_server.tls_ca", "", "file containing the PEM encoded certificates of the CAs the Redis server certificate must be issued by, instead of the system roots")
+	rootCmd.PersistentFlags().Bool("redis_server.insecure_skip_verify", false, "accepts any certificate presented by the Redis server over TLS; only for testing")
+	rootCmd.PersistentFlags().Int("redis_server.pool_size", 0, "number of connections kept open to each Redis server, or 0 for the default of 4")
+	rootCmd.PersistentFlags().Bool("detect_signature_reuse", false, "flags new entries whose signature was already logged under a different public key (requires enable_retrieve_api)")
+	rootCmd.PersistentFlags().String("index.backend", "redis", fmt.Sprintf("backend storing the search index, one of %v; the redis backend connects to --redis_server.address", storage.IndexBackends()))
+	rootCmd.PersistentFlags().String("index.dynamodb.table", "rekor-index", "DynamoDB table holding the search index for the dynamodb backend, created with on-demand capacity if it does not exist")
+	rootCmd.PersistentFlags().String("index.dynamodb.region", "", "AWS region of the DynamoDB table, or empty to use the region of the environment")
+	rootCmd.PersistentFlags().String("index.dynamodb.endpoint", "", "URL of the DynamoDB endpoint, to use a local instance instead of the one of the region")
+	rootCmd.PersistentFlags().String("index.bigtable.project", "", "GCP project of the Bigtable instance holding the search index for the bigtable backend")
+	rootCmd.PersistentFlags().String("index.bigtable.instance", "", "Bigtable instance holding the search index for the bigtable backend")
+	rootCmd.PersistentFlags().String("index.bigtable.table", "rekor-index", "Bigtable table holding the search index for the bigtable backend, with column families i and c")
+	rootCmd.PersistentFlags().String("index.bolt.path", "rekor-index.db", "file holding the search index for the bolt backend, which can only be opened by one server at a time")
+	rootCmd.PersistentFlags().String("index.queue_path", "", "file keeping the index writes of added entries until they succeed, so that those pending survive a restart; if empty they are only retried while the server runs")
+	rootCmd.PersistentFlags().String("index.key_scheme", pki.DefaultKeyIndexScheme, fmt.Sprintf("how public keys are turned into search index keys, one of %v; it cannot be changed once entries have been indexed", pki.KeyIndexSchemes()))
+	rootCmd.PersistentFlags().String("attestation_storage.backend", "", fmt.Sprintf("blob store keeping the envelopes of attestation-bearing kinds such as notation out of the log, which then records only their digest, one of %v, or empty to log them inline", storage.BlobStores()))
+	rootCmd.PersistentFlags().Int64("attestation_storage.max_size", 0, "maximum size in bytes of an attestation, whether inline, fetched from a URL or uploaded to attestation storage, or 0 for no limit")
+	rootCmd.PersistentFlags().String("attestation_storage.encryption_key", "", "URL of a KMS key to encrypt stored attestations with, e.g. gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key> or awskms://<key ID or alias>?region=<region>, or empty to store them in plaintext")
+	rootCmd.PersistentFlags().String("attestation_storage.s3.bucket", "", "S3 bucket holding attestations for the s3 blob store")
+	rootCmd.PersistentFlags().String("attestation_storage.s3.prefix", "", "prefix of the names of the objects holding attestations in the S3 bucket")
+	rootCmd.PersistentFlags().String("attestation_storage.s3.region", "", "AWS region of the S3 bucket, or empty to use the region of the environment")
+	rootCmd.PersistentFlags().String("attestation_storage.s3.endpoint", "", "URL of an S3 compatible server to use instead of AWS, whose buckets are addressed by path")
+	rootCmd.PersistentFlags().String("attestation_storage.gcs.bucket", "", "Cloud Storage bucket holding attestations for the gcs blob store, accessed with the application default credentials, such as those of a GKE workload identity")
+	rootCmd.PersistentFlags().String("attestation_storage.gcs.prefix", "", "prefix of the names of the objects holding attestations in the Cloud Storage bucket")
+	rootCmd.PersistentFlags().String("attestation_storage.azure.container_url", "", "URL of the Azure Storage container holding attestations for the azure blob store, e.g. https://<account>.blob.core.windows.net/<container>")
+	rootCmd.PersistentFlags().String("attestation_storage.azure.sas_token", "", "SAS token granting access to the Azure Storage container; the managed identity of the host is used if not set")
+	rootCmd.PersistentFlags().String("attestation_storage.azure.managed_identity_client_id", "", "client ID of the user assigned managed identity to access the Azure Storage container with, instead of the system assigned one")
+	rootCmd.PersistentFlags().String("attestation_storage.azure.prefix", "", "prefix of the names of the blobs holding attestations in the Azure Storage container")
+	rootCmd.PersistentFlags().StringSlice("index.enable_key_extractors", []string{}, fmt.Sprintf("index key extractors to run in addition to those enabled by default, out of %v", types.IndexKeyExtractors()))
+	rootCmd.PersistentFlags().StringSlice("index.disable_key_extractors", []string{}, "index key extractors not to run, such as identities to stop indexing entries under the email addresses and other identities of their signing certificates")
+	rootCmd.PersistentFlags().Int64("index.max_results", 0, "maximum number of UUIDs returned by a single index search, beyond which results are paginated, or 0 for no limit")
+	rootCmd.PersistentFlags().String("pki.x509_trust_roots", "", "file containing the PEM encoded root certificates (such as the Fulcio root, or the Yubico PIV root for attestation certificates) that x509 certificates must be issued by; if unset, any certificate is accepted")
+
+	rootCmd.PersistentFlags().String("pki.ct_log_keys", "", "file containing the PEM encoded public keys of the certificate transparency logs that SCTs embedded in x509 certificates are verified against; if unset, embedded SCTs are not checked")
+	rootCmd.PersistentFlags().Bool("pki.x509_check_validity", false, "rejects entries signed with x509 certificates that are not valid at the time the entry is integrated, as keyless verification requires; by default a certificate only needs to chain to its issuer when it was issued")
+	rootCmd.PersistentFlags().Bool("pki.pgp_reject_expired_keys", false, "rejects entries signed with PGP keys that have expired by the time the entry is proposed, even if they were valid when the signature was made")
+	rootCmd.PersistentFlags().Bool("pki.pgp_reject_revoked_keys", false, "rejects entries signed with PGP keys or subkeys that carry a revocation signature, reporting the policy in the error")
+
+	rootCmd.PersistentFlags().Duration("timeouts.entity_fetch", 30*time.Second, "maximum time to spend fetching and verifying the external entities (artifacts, keys, signatures) of an entry")
+	rootCmd.PersistentFlags().Int64("fetch.max_size", 0, "maximum number of bytes downloaded from a single URL referenced by an entry, or 0 for no limit")
+	rootCmd.PersistentFlags().Int("fetch.retries", 2, "number of times a download from a URL referenced by an entry is resumed with a range request after failing part way through")
+	rootCmd.PersistentFlags().Duration("fetch.retry_delay", time.Second, "time to wait before resuming a failed download")
+	rootCmd.PersistentFlags().Duration("timeouts.trillian_rpc", 20*time.Second, "maximum time to wait for a single RPC to the Trillian log server")
+	rootCmd.PersistentFlags().Duration("timeouts.index", 5*time.Second, "maximum time to wait for a single read or write of the Redis search index")
+
+	rootCmd.PersistentFlags().StringSlice("disabled_kinds", []string{}, "kinds of entries to reject when proposed (can be changed at runtime through the admin API)")
+	rootCmd.PersistentFlags().Float64("rate_limit.entries_per_second", 0, "maximum number of new entries accepted per second across all clients, or 0 for no limit")
+	rootCmd.PersistentFlags().Int("rate_limit.burst", 10, "number of new entries that may be accepted at once before rate_limit.entries_per_second applies")
+	rootCmd.PersistentFlags().Float64("rate_limit.write_requests_per_second", 0, "maximum number of requests to add entries accepted per second across all clients, or 0 for no limit")
+	rootCmd.PersistentFlags().Int("rate_limit.write_burst", 20, "number of requests to add entries that may be accepted at once before rate_limit.write_requests_per_second applies")
+	rootCmd.PersistentFlags().Float64("rate_limit.client_requests_per_second", 0, "maximum number of requests to add entries accepted per second from a single client address, or 0 for no limit")
+	rootCmd.PersistentFlags().Int("rate_limit.client_burst", 5, "number of requests to add entries that a single client may make at once before rate_limit.client_requests_per_second applies")
+	rootCmd.PersistentFlags().String("rate_limit.client_ip_header", "", "header set by a trusted proxy with the client address (such as X-Forwarded-For) to rate limit clients by; if unset, the address requests come from is used")
+
+	rootCmd.PersistentFlags().String("auth.oidc_issuer", "", "URL of the OIDC issuer whose tokens requests to add entries must carry; if unset, anyone may add entries")
+	rootCmd.PersistentFlags().String("auth.oidc_client_id", "", "client ID the OIDC tokens of requests to add entries must be issued for")
+	rootCmd.PersistentFlags().String("auth.oidc_identity_claim", "email", "claim of the OIDC token recorded as the identity of the client adding entries")
+	rootCmd.PersistentFlags().StringSlice("auth.metric_identities", []string{}, "identities whose entries are counted under their own label in rekor_new_entries_by_identity; the entries of other authenticated clients are counted together under the label other")
+	rootCmd.PersistentFlags().Int64("auth.hourly_entry_quota", 0, "maximum number of entries each authenticated identity may add in an hour, counted from the start of each UTC hour, or 0 for no limit")
+	rootCmd.PersistentFlags().Int64("auth.daily_entry_quota", 0, "maximum number of entries each authenticated identity may add in a day, counted from UTC midnight, or 0 for no limit")
+
+	rootCmd.PersistentFlags().Duration("stream.poll_interval", time.Second, "how often streams of newly integrated entries check the log for new entries")
+	rootCmd.PersistentFlags().Int64("stream.max_clients", 100, "maximum number of clients streaming newly integrated entries at once, or 0 for no limit")
+
+	rootCmd.PersistentFlags().StringSlice("cors.allowed_origins", []string{"*"}, "origins browsers may call the API from, where * allows any origin; if empty, no CORS headers are sent")
+	rootCmd.PersistentFlags().StringSlice("cors.allowed_methods", []string{"GET", "HEAD", "POST"}, "methods browsers may call the API with from cors.allowed_origins")
+	rootCmd.PersistentFlags().StringSlice("cors.allowed_headers", []string{}, "request headers browsers may send from cors.allowed_origins in addition to Accept, Content-Type and Origin")
+
+	rootCmd.PersistentFlags().Int64("max_request_body_size", 0, "maximum size in bytes of the body of any request, checked before it is decoded, or 0 for no limit")
+	rootCmd.PersistentFlags().Int64("max_entry_size", 0, "maximum size in bytes of a proposed entry as submitted, checked before it is decoded; batches may be this size for each entry they can hold. 0 means no limit")
+
+	rootCmd.PersistentFlags().Bool("enable_compression", true, "compresses JSON responses with gzip or deflate for clients that accept it in Accept-Encoding")
+
+	rootCmd.PersistentFlags().Bool("enable_admin_api", false, "enables the admin API used to change the log level, disabled kinds and rate limits at runtime")
+	rootCmd.PersistentFlags().String("admin.address", "127.0.0.1", "Address to bind the admin API to")
+	rootCmd.PersistentFlags().Uint16("admin.port", 3002, "Port to bind the admin API to")
+	rootCmd.PersistentFlags().String("admin.token_file", "", "file containing the bearer token required to call the admin API")
+
+	rootCmd.PersistentFlags().String("failover.peer_url", "", "URL of the other instance of a failover pair; leave empty for a standalone instance")
+	rootCmd.PersistentFlags().String("failover.peer_admin_url", "", "URL of the admin API of the other instance of the failover pair")
+	rootCmd.PersistentFlags().String("failover.peer_admin_token_file", "", "file containing the admin token of the other instance (defaults to admin.token_file)")
+	rootCmd.PersistentFlags().String("failover.role", "active", "role this instance of a failover pair starts in, either active or standby")
+	rootCmd.PersistentFlags().String("failover.instance_name", "", "name of this instance in handoff records (defaults to the hostname)")
+	rootCmd.PersistentFlags().Duration("failover.check_interval", 10*time.Second, "how often a standby compares its tree head with its peer's")
+	rootCmd.PersistentFlags().Duration("failover.handoff_timeout", time.Minute, "maximum time a promoted standby waits for its tree to reach the tree head of the handoff")
+	rootCmd.PersistentFlags().Duration("failover.peer_timeout", 10*time.Second, "maximum time to wait for a response from the other instance of the pair")
+
+	rootCmd.PersistentFlags().String("sharding.records_path", "", "file the signed records of the rotations of the log to new trees are appended to, from which the current tree and inactive shards are read when the server starts; must be set to rotate the log")
+	rootCmd.PersistentFlags().Int64("sharding.rotate_at_size", 0, "number of entries at which the current tree is frozen and the log continued in a new tree, or 0 to not rotate on size")
+	rootCmd.PersistentFlags().Duration("sharding.rotate_after", 0, "age at which the current tree is frozen and the log continued in a new tree, or 0 to not rotate on age")
+	rootCmd.PersistentFlags().Duration("sharding.check_interval", time.Minute, "how often the current tree is checked against sharding.rotate_at_size and sharding.rotate_after")
 
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		log.Logger.Fatal(err)
@@ -18,16 +18,33 @@ package app
 
 import (
 	"flag"
+	"fmt"
 	"net/http"
 
 	"github.com/go-openapi/loads"
 	"github.com/sigstore/rekor/pkg/api"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types/commitment"
+	commitment_v001 "github.com/sigstore/rekor/pkg/types/commitment/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/dct"
+	dct_v001 "github.com/sigstore/rekor/pkg/types/dct/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/kmod"
+	kmod_v001 "github.com/sigstore/rekor/pkg/types/kmod/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/mlmodel"
+	mlmodel_v001 "github.com/sigstore/rekor/pkg/types/mlmodel/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/notation"
+	notation_v001 "github.com/sigstore/rekor/pkg/types/notation/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rekord"
 	rekord_v001 "github.com/sigstore/rekor/pkg/types/rekord/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/release"
+	release_v001 "github.com/sigstore/rekor/pkg/types/release/v0.0.1"
 	"github.com/sigstore/rekor/pkg/types/rpm"
 	rpm_v001 "github.com/sigstore/rekor/pkg/types/rpm/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/tfprovider"
+	tfprovider_v001 "github.com/sigstore/rekor/pkg/types/tfprovider/v0.0.1"
+	"github.com/sigstore/rekor/pkg/types/vmimage"
+	vmimage_v001 "github.com/sigstore/rekor/pkg/types/vmimage/v0.0.1"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sigstore/rekor/pkg/generated/restapi"
@@ -62,8 +79,16 @@ var serveCmd = &cobra.Command{
 
 		// these trigger loading of package and therefore init() methods to run
 		pluggableTypeMap := map[string]string{
-			rekord.KIND: rekord_v001.APIVERSION,
-			rpm.KIND:    rpm_v001.APIVERSION,
+			rekord.KIND:     rekord_v001.APIVERSION,
+			rpm.KIND:        rpm_v001.APIVERSION,
+			release.KIND:    release_v001.APIVERSION,
+			tfprovider.KIND: tfprovider_v001.APIVERSION,
+			kmod.KIND:       kmod_v001.APIVERSION,
+			vmimage.KIND:    vmimage_v001.APIVERSION,
+			mlmodel.KIND:    mlmodel_v001.APIVERSION,
+			notation.KIND:   notation_v001.APIVERSION,
+			dct.KIND:        dct_v001.APIVERSION,
+			commitment.KIND: commitment_v001.APIVERSION,
 		}
 
 		for k, v := range pluggableTypeMap {
@@ -74,15 +99,50 @@ var serveCmd = &cobra.Command{
 		server.Host = viper.GetString("rekor_server.address")
 		server.Port = int(viper.GetUint("rekor_server.port"))
 		server.EnabledListeners = []string{"http"}
+		if socketPath := viper.GetString("rekor_server.socket_path"); socketPath != "" {
+			if err := removeStaleSocket(socketPath); err != nil {
+				log.Logger.Fatal(err)
+			}
+			server.SocketPath = socketPath
+			server.EnabledListeners = append(server.EnabledListeners, "unix")
+		}
 
 		api.ConfigureAPI()
 		server.ConfigureAPI()
 
+		if viper.GetBool("enable_grpc_api") {
+			go func() {
+				addr := fmt.Sprintf("%v:%v", viper.GetString("rekor_server.address"), viper.GetUint("rekor_server.grpc_port"))
+				if err := api.ServeGRPC(addr); err != nil {
+					log.Logger.Fatal(err)
+				}
+			}()
+		}
+
+		if viper.GetBool("enable_admin_api") {
+			adminHandler, err := api.NewAdminHandler()
+			if err != nil {
+				log.Logger.Fatal(err)
+			}
+			go func() {
+				addr := fmt.Sprintf("%v:%v", viper.GetString("admin.address"), viper.GetUint("admin.port"))
+				if err := http.ListenAndServe(addr, adminHandler); err != nil {
+					log.Logger.Fatal(err)
+				}
+			}()
+		}
+
 		http.Handle("/metrics", promhttp.Handler())
 		go func() {
 			_ = http.ListenAndServe(":2112", nil)
 		}()
 
+		if viper.GetBool("rekor_server.systemd_activation") {
+			if err := serveSystemdListeners(server.GetHandler()); err != nil {
+				log.Logger.Fatal(err)
+			}
+			return
+		}
 		if err := server.Serve(); err != nil {
 			log.Logger.Fatal(err)
 		}
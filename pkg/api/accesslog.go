@@ -0,0 +1,91 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-chi/chi/middleware"
+	"go.uber.org/zap"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// RequestIDHeader is the header a request ID is propagated from clients and proxies in, and returned to
+// clients in
+const RequestIDHeader = "X-Request-Id"
+
+// request IDs given by clients end up in every line logged for the request, so they are kept short and plain
+var requestIDRegexp = regexp.MustCompile(`^[A-Za-z0-9._:/+=-]{1,128}$`)
+
+// RequestID tags each request with the ID given in its X-Request-Id header, or with a new ID if it has none or
+// one that is not safe to log, and returns the ID in the X-Request-Id header of the response
+func RequestID(handler http.Handler) http.Handler {
+	tagged := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, log.RequestID(r))
+		handler.ServeHTTP(w, r)
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(RequestIDHeader); id != "" && !requestIDRegexp.MatchString(id) {
+			log.Logger.Debugw("replacing request ID given by client", "requestID", id)
+			r.Header.Del(RequestIDHeader)
+		}
+		middleware.RequestID(tagged).ServeHTTP(w, r)
+	})
+}
+
+// AccessLog logs a structured line for each request once its response has been written, along with any panic
+// raised while serving it; the line is tagged with the ID of the request like every other line logged for it
+func AccessLog(handler http.Handler) http.Handler {
+	return middleware.RequestLogger(accessLogFormatter{})(handler)
+}
+
+type accessLogFormatter struct{}
+
+func (accessLogFormatter) NewLogEntry(r *http.Request) middleware.LogEntry {
+	return &accessLogEntry{
+		logger: log.RequestIDLogger(r),
+		fields: []interface{}{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"protocol", r.Proto,
+			"remoteAddr", r.RemoteAddr,
+			"userAgent", r.UserAgent(),
+			"requestSize", r.ContentLength,
+		},
+	}
+}
+
+type accessLogEntry struct {
+	logger *zap.SugaredLogger
+	fields []interface{}
+}
+
+func (e *accessLogEntry) Write(status, bytes int, _ http.Header, elapsed time.Duration, _ interface{}) {
+	fields := append(e.fields, "status", status, "responseSize", bytes, "latency", elapsed)
+	if status >= http.StatusInternalServerError {
+		e.logger.Errorw("served request", fields...)
+		return
+	}
+	e.logger.Infow("served request", fields...)
+}
+
+func (e *accessLogEntry) Panic(v interface{}, stack []byte) {
+	e.logger.Errorw("panic serving request", append(e.fields, "panic", v, "stack", string(stack))...)
+}
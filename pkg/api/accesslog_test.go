@@ -0,0 +1,87 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+func TestRequestID(t *testing.T) {
+	var id, body string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id = log.RequestID(r)
+		body = requestErrorMsg(r, "failed", http.StatusBadRequest).RequestID
+	}))
+
+	tests := []struct {
+		caseDesc  string
+		header    string
+		propagate bool
+	}{
+		{caseDesc: "no ID given"},
+		{caseDesc: "ID given", header: "proxy-1/abc.123", propagate: true},
+		{caseDesc: "ID with spaces", header: "a b"},
+		{caseDesc: "ID with newline", header: "a\nb"},
+		{caseDesc: "ID too long", header: strings.Repeat("a", 129)},
+	}
+	for _, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/log", nil)
+		if tc.header != "" {
+			r.Header.Set(RequestIDHeader, tc.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if id == "" || w.Header().Get(RequestIDHeader) != id || body != id {
+			t.Errorf("%v: request ID %q returned as %q in the header and %q in errors", tc.caseDesc, id, w.Header().Get(RequestIDHeader), body)
+		}
+		if (id == tc.header) != tc.propagate {
+			t.Errorf("%v: unexpected request ID %q", tc.caseDesc, id)
+		}
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	defer func(l *zap.SugaredLogger) { log.Logger = l }(log.Logger)
+	core, logs := observer.New(zap.InfoLevel)
+	log.Logger = zap.New(core).Sugar()
+
+	handler := RequestID(AccessLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("tea"))
+	})))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/log", nil)
+	r.Header.Set(RequestIDHeader, "abc")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	entries := logs.FilterMessage("served request").AllUntimed()
+	if len(entries) != 1 {
+		t.Fatalf("expected one access log line, got %v", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["requestID"] != "abc" || fields["method"] != http.MethodGet || fields["path"] != "/api/v1/log" || fields["status"] != int64(http.StatusTeapot) || fields["responseSize"] != int64(3) {
+		t.Errorf("unexpected access log fields %v", fields)
+	}
+}
@@ -0,0 +1,388 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/time/rate"
+)
+
+// AdminConfig is the part of the server configuration that can be changed at runtime through the admin API
+type AdminConfig struct {
+	LogLevel      string   `json:"logLevel"`
+	DisabledKinds []string `json:"disabledKinds"`
+	// EntryRateLimit is the number of entries accepted per second across all clients; 0 means unlimited
+	EntryRateLimit float64 `json:"entryRateLimit"`
+	EntryRateBurst int     `json:"entryRateBurst"`
+	// ReadOnly rejects new entries while entries, proofs and searches are still served
+	ReadOnly bool `json:"readOnly"`
+}
+
+// adminConfigUpdate holds the fields of a change request; fields that are not set are left unchanged
+type adminConfigUpdate struct {
+	LogLevel       *string   `json:"logLevel"`
+	DisabledKinds  *[]string `json:"disabledKinds"`
+	EntryRateLimit *float64  `json:"entryRateLimit"`
+	EntryRateBurst *int      `json:"entryRateBurst"`
+	ReadOnly       *bool     `json:"readOnly"`
+}
+
+// runtimeConfig holds the settings read on every request that the admin API may change
+type runtimeConfig struct {
+	mu            sync.RWMutex
+	disabledKinds map[string]bool
+	entryLimiter  *rate.Limiter
+	readOnly      bool
+
+	// entries is held for reading while an entry is being added, so that making the instance read-only
+	// waits for the entries in flight
+	entries sync.RWMutex
+}
+
+var runtimeCfg = newRuntimeConfig()
+
+func newRuntimeConfig() *runtimeConfig {
+	return &runtimeConfig{
+		disabledKinds: map[string]bool{},
+		entryLimiter:  rate.NewLimiter(rate.Inf, 0),
+	}
+}
+
+// configureRuntime loads the initial runtime settings from the server configuration
+func configureRuntime() error {
+	limit := viper.GetFloat64("rate_limit.entries_per_second")
+	burst := viper.GetInt("rate_limit.burst")
+	kinds := viper.GetStringSlice("disabled_kinds")
+	readOnly := viper.GetBool("read-only")
+	return runtimeCfg.update(adminConfigUpdate{
+		DisabledKinds:  &kinds,
+		EntryRateLimit: &limit,
+		EntryRateBurst: &burst,
+		ReadOnly:       &readOnly,
+	})
+}
+
+func (c *runtimeConfig) kindEnabled(kind string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.disabledKinds[kind]
+}
+
+func (c *runtimeConfig) allowEntry() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.entryLimiter.Allow()
+}
+
+// beginEntry reports whether new entries are accepted; if they are, done must be called once the entry has
+// been submitted to the log
+func (c *runtimeConfig) beginEntry() (done func(), ok bool) {
+	c.entries.RLock()
+	c.mu.RLock()
+	readOnly := c.readOnly
+	c.mu.RUnlock()
+	if readOnly {
+		c.entries.RUnlock()
+		return nil, false
+	}
+	return c.entries.RUnlock, true
+}
+
+func (c *runtimeConfig) current() AdminConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cfg := AdminConfig{
+		LogLevel:       log.Level.Level().String(),
+		DisabledKinds:  []string{},
+		EntryRateBurst: c.entryLimiter.Burst(),
+		ReadOnly:       c.readOnly,
+	}
+	if limit := c.entryLimiter.Limit(); limit != rate.Inf {
+		cfg.EntryRateLimit = float64(limit)
+	}
+	for k := range c.disabledKinds {
+		cfg.DisabledKinds = append(cfg.DisabledKinds, k)
+	}
+	sort.Strings(cfg.DisabledKinds)
+	return cfg
+}
+
+// update validates every field of u before applying any of them, so that a rejected request changes nothing
+func (c *runtimeConfig) update(u adminConfigUpdate) error {
+	var level zapcore.Level
+	if u.LogLevel != nil {
+		if err := level.UnmarshalText([]byte(*u.LogLevel)); err != nil {
+			return fmt.Errorf("invalid log level: %w", err)
+		}
+	}
+	if u.EntryRateLimit != nil && *u.EntryRateLimit < 0 {
+		return errors.New("entry rate limit must not be negative")
+	}
+	if u.EntryRateBurst != nil && *u.EntryRateBurst < 0 {
+		return errors.New("entry rate burst must not be negative")
+	}
+
+	if u.ReadOnly != nil && *u.ReadOnly {
+		// no entry may be added once the change has been applied
+		c.entries.Lock()
+		defer c.entries.Unlock()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if u.ReadOnly != nil {
+		c.readOnly = *u.ReadOnly
+	}
+	if u.LogLevel != nil {
+		log.Level.SetLevel(level)
+	}
+	if u.DisabledKinds != nil {
+		c.disabledKinds = map[string]bool{}
+		for _, k := range *u.DisabledKinds {
+			if k = strings.TrimSpace(k); k != "" {
+				c.disabledKinds[k] = true
+			}
+		}
+	}
+	if u.EntryRateLimit != nil || u.EntryRateBurst != nil {
+		limit, burst := c.entryLimiter.Limit(), c.entryLimiter.Burst()
+		if u.EntryRateLimit != nil {
+			limit = rate.Limit(*u.EntryRateLimit)
+			if limit == 0 {
+				limit = rate.Inf
+			}
+		}
+		if u.EntryRateBurst != nil {
+			burst = *u.EntryRateBurst
+		}
+		// a limited rate with no burst would reject every entry
+		if limit != rate.Inf && burst == 0 {
+			burst = 1
+		}
+		// a new limiter starts with a full bucket, rather than with the tokens left under the old limit
+		c.entryLimiter = rate.NewLimiter(limit, burst)
+	}
+	return nil
+}
+
+// adminHandler serves the runtime configuration at /api/v1/admin/config; GET returns it and PUT changes
+// the fields given in the request body. Requests must carry the token as a bearer credential.
+type adminHandler struct {
+	token string
+	cfg   *runtimeConfig
+}
+
+// NewAdminHandler returns the handler of the admin API, authenticating requests with the token read
+// from the file configured as admin.token_file
+func NewAdminHandler() (http.Handler, error) {
+	tokenFile := viper.GetString("admin.token_file")
+	if tokenFile == "" {
+		return nil, errors.New("admin.token_file must be set to enable the admin API")
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading admin token: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return nil, fmt.Errorf("admin token file %v is empty", tokenFile)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/api/v1/admin/config", &adminHandler{token: token, cfg: runtimeCfg})
+	fh := &failoverHandler{token: token, f: failover}
+	mux.Handle("/api/v1/admin/failover", fh)
+	mux.Handle("/api/v1/admin/failover/", fh)
+	sh := &shardsHandler{token: token, r: rotation}
+	mux.Handle("/api/v1/admin/shards", sh)
+	mux.Handle("/api/v1/admin/shards/", sh)
+	return mux, nil
+}
+
+func authorized(r *http.Request, token string) bool {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) == 1
+}
+
+func (h *adminHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.RequestIDLogger(r).With("remoteAddr", r.RemoteAddr)
+	if !authorized(r, h.token) {
+		logger.Warnw("rejected unauthenticated admin request", "method", r.Method)
+		writeAdminError(w, http.StatusUnauthorized, "missing or invalid admin token")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var u adminConfigUpdate
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&u); err != nil {
+			writeAdminError(w, http.StatusBadRequest, fmt.Sprintf("error parsing request: %v", err))
+			return
+		}
+		before := h.cfg.current()
+		if err := h.cfg.update(u); err != nil {
+			logger.Warnw("rejected admin configuration change", "error", err)
+			writeAdminError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		// logged at warn so that the audit record survives raising the log level
+		logger.Warnw("admin configuration changed", "before", before, "after", h.cfg.current())
+	default:
+		w.Header().Set("Allow", "GET, PUT")
+		writeAdminError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.cfg.current()); err != nil {
+		logger.Error(err)
+	}
+}
+
+// failoverHandler serves the failover API: GET /api/v1/admin/failover returns the status of this instance,
+// POST /api/v1/admin/failover/promote makes it the active instance of its pair (with ?force=true if the peer
+// cannot be reached), and POST /api/v1/admin/failover/stepdown is called by the peer being promoted
+type failoverHandler struct {
+	token string
+	f     *failoverState
+}
+
+func (h *failoverHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.RequestIDLogger(r).With("remoteAddr", r.RemoteAddr)
+	if !authorized(r, h.token) {
+		logger.Warnw("rejected unauthenticated admin request", "method", r.Method)
+		writeAdminError(w, http.StatusUnauthorized, "missing or invalid admin token")
+		return
+	}
+
+	var result interface{}
+	switch r.URL.Path {
+	case "/api/v1/admin/failover":
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			writeAdminError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+			return
+		}
+		result = h.f.currentStatus()
+	case "/api/v1/admin/failover/promote", "/api/v1/admin/failover/stepdown":
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeAdminError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+			return
+		}
+		var record *HandoffRecord
+		var err error
+		if strings.HasSuffix(r.URL.Path, "/promote") {
+			record, err = h.f.promote(r.Context(), r.URL.Query().Get("force") == "true")
+		} else {
+			record, err = h.f.stepDown(r.Context())
+		}
+		if err != nil {
+			logger.Warnw("failover request failed", "path", r.URL.Path, "error", err)
+			code := http.StatusConflict
+			if errors.Is(err, errNotPaired) {
+				code = http.StatusBadRequest
+			}
+			writeAdminError(w, code, err.Error())
+			return
+		}
+		// logged at warn so that the audit record survives raising the log level
+		logger.Warnw("failover role changed", "path", r.URL.Path, "role", h.f.currentRole(), "handoff", record)
+		result = record
+	default:
+		writeAdminError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.Error(err)
+	}
+}
+
+// shardsHandler serves the rotation of the log: GET /api/v1/admin/shards returns the records of its rotations and
+// POST /api/v1/admin/shards/rotate freezes the current tree and continues the log in a new one
+type shardsHandler struct {
+	token string
+	r     *shardRotation
+}
+
+func (h *shardsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.RequestIDLogger(r).With("remoteAddr", r.RemoteAddr)
+	if !authorized(r, h.token) {
+		logger.Warnw("rejected unauthenticated admin request", "method", r.Method)
+		writeAdminError(w, http.StatusUnauthorized, "missing or invalid admin token")
+		return
+	}
+
+	var result interface{}
+	switch r.URL.Path {
+	case "/api/v1/admin/shards":
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			writeAdminError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+			return
+		}
+		result = h.r.currentRecords()
+	case "/api/v1/admin/shards/rotate":
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeAdminError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+			return
+		}
+		record, err := h.r.rotateNow(r.Context())
+		if err != nil {
+			logger.Warnw("rotation request failed", "error", err)
+			code := http.StatusInternalServerError
+			if errors.Is(err, errRotationDisabled) {
+				code = http.StatusBadRequest
+			}
+			writeAdminError(w, code, err.Error())
+			return
+		}
+		result = record
+	default:
+		writeAdminError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.Error(err)
+	}
+}
+
+func writeAdminError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(errorMsg(message, code))
+}
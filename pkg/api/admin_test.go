@@ -0,0 +1,148 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAdminHandler(t *testing.T) {
+	defer log.Level.SetLevel(log.Level.Level())
+
+	cfg := newRuntimeConfig()
+	h := &adminHandler{token: "s3cret", cfg: cfg}
+
+	do := func(method, token, body string) (int, AdminConfig) {
+		t.Helper()
+		req := httptest.NewRequest(method, "/api/v1/admin/config", strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		var out AdminConfig
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return rec.Code, out
+	}
+
+	tests := []struct {
+		caseDesc     string
+		method       string
+		token        string
+		body         string
+		expectedCode int
+	}{
+		{caseDesc: "no token", method: http.MethodGet, expectedCode: http.StatusUnauthorized},
+		{caseDesc: "wrong token", method: http.MethodGet, token: "guess", expectedCode: http.StatusUnauthorized},
+		{caseDesc: "read configuration", method: http.MethodGet, token: "s3cret", expectedCode: http.StatusOK},
+		{caseDesc: "unsupported method", method: http.MethodPost, token: "s3cret", body: "{}", expectedCode: http.StatusMethodNotAllowed},
+		{caseDesc: "malformed body", method: http.MethodPut, token: "s3cret", body: "{", expectedCode: http.StatusBadRequest},
+		{caseDesc: "invalid log level", method: http.MethodPut, token: "s3cret", body: `{"logLevel":"loud"}`, expectedCode: http.StatusBadRequest},
+		{caseDesc: "negative rate limit", method: http.MethodPut, token: "s3cret", body: `{"entryRateLimit":-1}`, expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		if code, _ := do(tc.method, tc.token, tc.body); code != tc.expectedCode {
+			t.Errorf("unexpected status in '%v': got %v, expected %v", tc.caseDesc, code, tc.expectedCode)
+		}
+	}
+
+	// a rejected change must not be partially applied
+	if code, _ := do(http.MethodPut, "s3cret", `{"disabledKinds":["rpm"],"entryRateLimit":-1}`); code != http.StatusBadRequest {
+		t.Errorf("unexpected status for invalid change: %v", code)
+	}
+	if !cfg.kindEnabled("rpm") {
+		t.Error("rejected change was partially applied")
+	}
+
+	code, got := do(http.MethodPut, "s3cret", `{"logLevel":"warn","disabledKinds":["rpm","kmod"],"entryRateLimit":0.5}`)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status updating configuration: %v", code)
+	}
+	expected := AdminConfig{LogLevel: "warn", DisabledKinds: []string{"kmod", "rpm"}, EntryRateLimit: 0.5, EntryRateBurst: 1}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected configuration: got %+v, expected %+v", got, expected)
+	}
+	if log.Level.Level() != zapcore.WarnLevel {
+		t.Errorf("log level was not changed: %v", log.Level.Level())
+	}
+	if cfg.kindEnabled("rpm") || !cfg.kindEnabled("rekord") {
+		t.Error("disabled kinds were not applied")
+	}
+	if !cfg.allowEntry() || cfg.allowEntry() {
+		t.Error("expected exactly one entry to be allowed by the burst")
+	}
+
+	// fields that are omitted are left unchanged
+	if _, got = do(http.MethodPut, "s3cret", `{"entryRateLimit":0}`); !reflect.DeepEqual(got.DisabledKinds, []string{"kmod", "rpm"}) {
+		t.Errorf("unexpected disabled kinds after partial update: %v", got.DisabledKinds)
+	}
+	if !cfg.allowEntry() {
+		t.Error("expected entries to be unlimited")
+	}
+}
+
+func TestRuntimeConfigReadOnly(t *testing.T) {
+	cfg := newRuntimeConfig()
+	readOnly, writable := true, false
+
+	done, ok := cfg.beginEntry()
+	if !ok {
+		t.Fatal("expected entries to be accepted")
+	}
+	// making the instance read-only waits for the entry in flight
+	updated := make(chan error)
+	go func() {
+		updated <- cfg.update(adminConfigUpdate{ReadOnly: &readOnly})
+	}()
+	select {
+	case <-updated:
+		t.Fatal("instance was made read-only while an entry was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	done()
+	if err := <-updated; err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cfg.beginEntry(); ok {
+		t.Error("read-only instance accepted an entry")
+	}
+	if !cfg.current().ReadOnly {
+		t.Error("read-only instance not reported as such")
+	}
+
+	if err := cfg.update(adminConfigUpdate{ReadOnly: &writable}); err != nil {
+		t.Fatal(err)
+	}
+	if done, ok := cfg.beginEntry(); !ok {
+		t.Error("writable instance rejected an entry")
+	} else {
+		done()
+	}
+}
@@ -18,14 +18,23 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/google/trillian"
 	"github.com/google/trillian/client"
 	"github.com/google/trillian/crypto/keyspb"
-	radix "github.com/mediocregopher/radix/v4"
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 )
@@ -47,29 +56,124 @@ type API struct {
 	logID     int64
 	pubkey    *keyspb.PublicKey
 	verifier  *client.LogVerifier
+	// keys holds the key of the tree followed by the keys it was signed with before being rotated
+	keys []logKey
+	// tenants holds the API serving the tree of each tenant, by tenant name
+	tenants map[string]*API
+	// tenantWriters holds the authenticated identities allowed to add entries to each tenant, by tenant name
+	tenantWriters map[string]map[string]bool
+	// inactiveShards holds the frozen trees the log was written to before the tree, oldest first, and offset the
+	// index in the log of the first entry of the tree, which follows theirs
+	inactiveShards []logShard
+	offset         int64
+	// mu guards the tree, its keys and the inactive shards, which change when the log is rotated to a new tree
+	mu sync.RWMutex
+	// logAdminClient manages the trees of the log, which are created and frozen when it is rotated
+	logAdminClient trillian.TrillianAdminClient
 }
 
 func NewAPI() (*API, error) {
-	logRPCServer := fmt.Sprintf("%s:%d",
-		viper.GetString("trillian_log_server.address"),
-		viper.GetUint("trillian_log_server.port"))
 	ctx := context.Background()
-	tConn, err := dial(ctx, logRPCServer)
+	var logAdminClient trillian.TrillianAdminClient
+	var logClient trillian.TrillianLogClient
+	tLogID := viper.GetInt64("trillian_log_server.tlog_id")
+	switch address := viper.GetString("trillian_log_server.address"); address {
+	case embeddedLogAddress:
+		// the embedded log creates its tree when the server starts, as it does not outlive it
+		if tLogID != 0 || len(viper.GetStringSlice("tenancy.trees")) != 0 || len(viper.GetStringSlice("trillian_log_server.inactive_shards")) != 0 || viper.GetString("sharding.records_path") != "" {
+			return nil, errors.New("trees cannot be given with trillian_log_server.tlog_id, trillian_log_server.inactive_shards, tenancy.trees or sharding.records_path when the log is embedded")
+		}
+		log.Logger.Warn("keeping the log in memory: everything logged will be lost when the server stops")
+		embedded := newEmbeddedLog(newMemoryLogStorage())
+		logAdminClient, logClient = embedded, embedded
+	case sqliteLogAddress:
+		path := viper.GetString("trillian_log_server.sqlite_path")
+		storage, err := openSQLiteLogStorage(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		log.Logger.Infof("keeping the log in SQLite database %v", path)
+		embedded := newEmbeddedLog(storage)
+		logAdminClient, logClient = embedded, embedded
+	default:
+		tConn, err := dial(ctx, fmt.Sprintf("%s:%d", address, viper.GetUint("trillian_log_server.port")))
+		if err != nil {
+			return nil, err
+		}
+		logAdminClient = trillian.NewTrillianAdminClient(tConn)
+		logClient = trillian.NewTrillianLogClient(tConn)
+	}
+
+	inactiveShards := viper.GetStringSlice("trillian_log_server.inactive_shards")
+	records, err := readRotationRecords(viper.GetString("sharding.records_path"))
 	if err != nil {
 		return nil, err
 	}
-	logAdminClient := trillian.NewTrillianAdminClient(tConn)
-	logClient := trillian.NewTrillianLogClient(tConn)
+	if len(records) > 0 {
+		// the trees the log was last rotated to replace those configured, which may predate the rotation
+		last := records[len(records)-1]
+		tLogID = last.ActiveTreeID
+		inactiveShards = make([]string, 0, len(last.InactiveShards))
+		for _, id := range last.InactiveShards {
+			inactiveShards = append(inactiveShards, strconv.FormatInt(id, 10))
+		}
+		log.Logger.Infof("serving tree %v with inactive shards %v as of the rotation at %v", tLogID, inactiveShards, last.RotatedAt)
+	}
 
-	tLogID := viper.GetInt64("trillian_log_server.tlog_id")
+	if tLogID == 0 && viper.GetBool("read-only") {
+		return nil, errors.New("trillian_log_server.tlog_id must be set on read-only instances")
+	}
+	if tLogID == 0 && len(inactiveShards) != 0 {
+		// an existing tree would be picked as the current one, which may be one of the shards
+		return nil, errors.New("trillian_log_server.tlog_id must be set when the log has inactive shards")
+	}
 	if tLogID == 0 {
-		t, err := createAndInitTree(ctx, logAdminClient, logClient)
+		t, err := createAndInitTree(ctx, logAdminClient, logClient, viper.GetString("trillian_log_server.signing_curve"))
 		if err != nil {
 			return nil, err
 		}
 		tLogID = t.TreeId
 	}
 
+	var previousKeys []logKey
+	if keysFile := viper.GetString("trillian_log_server.previous_public_keys"); keysFile != "" {
+		keysPEM, err := ioutil.ReadFile(keysFile)
+		if err != nil {
+			return nil, err
+		}
+		if previousKeys, err = parsePreviousLogKeys(keysPEM); err != nil {
+			return nil, fmt.Errorf("error parsing %v: %w", keysFile, err)
+		}
+	}
+	a, err := newTreeAPI(ctx, logAdminClient, logClient, tLogID, previousKeys)
+	if err != nil {
+		return nil, err
+	}
+	a.logAdminClient = logAdminClient
+	if a.inactiveShards, a.offset, err = newInactiveShards(ctx, logAdminClient, logClient, inactiveShards, tLogID); err != nil {
+		return nil, err
+	}
+
+	tenantTrees, err := parseTenants(viper.GetStringSlice("tenancy.trees"))
+	if err != nil {
+		return nil, err
+	}
+	a.tenants = make(map[string]*API, len(tenantTrees))
+	for tenant, treeID := range tenantTrees {
+		// each tenant's tree is signed with its own key
+		if a.tenants[tenant], err = newTreeAPI(ctx, logAdminClient, logClient, treeID, nil); err != nil {
+			return nil, fmt.Errorf("tree %v of tenant %v: %w", treeID, tenant, err)
+		}
+	}
+	if a.tenantWriters, err = parseTenantWriters(viper.GetStringSlice("tenancy.writers"), tenantTrees); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// newTreeAPI returns the API serving the tree with the given ID, whose tree heads may also have been signed
+// with previousKeys before its key was rotated
+func newTreeAPI(ctx context.Context, logAdminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient, tLogID int64, previousKeys []logKey) (*API, error) {
 	t, err := logAdminClient.GetTree(ctx, &trillian.GetTreeRequest{
 		TreeId: tLogID,
 	})
@@ -80,31 +184,142 @@ func NewAPI() (*API, error) {
 	if err != nil {
 		return nil, err
 	}
+	keys, err := newLogKeys(tLogID, t.PublicKey.GetDer(), previousKeys)
+	if err != nil {
+		return nil, err
+	}
 
 	return &API{
 		logClient: logClient,
 		logID:     tLogID,
 		pubkey:    t.PublicKey,
 		verifier:  verifier,
+		keys:      keys,
 	}, nil
 }
 
 var (
-	api         *API
-	redisClient radix.Client
+	api          *API
+	indexBackend storage.IndexBackend
 )
 
+// ConfigureWrites loads the rate limits, authentication and quotas of the requests that add entries from the
+// server configuration
+func ConfigureWrites(ctx context.Context) error {
+	if err := configureWriteLimits(); err != nil {
+		return err
+	}
+	if err := configureWriteAuth(ctx); err != nil {
+		return err
+	}
+	return configureWriteQuotas()
+}
+
 func ConfigureAPI() {
-	cfg := radix.PoolConfig{}
+	if err := validateTimeouts(); err != nil {
+		log.Logger.Panic(err)
+	}
+	if d := viper.GetDuration("stream.poll_interval"); d <= 0 {
+		log.Logger.Panicf("stream.poll_interval must be greater than 0, got %v", d)
+	}
+	if err := configureRuntime(); err != nil {
+		log.Logger.Panic(err)
+	}
+	if err := ConfigureWrites(context.Background()); err != nil {
+		log.Logger.Panic(err)
+	}
+	if err := pki.SetKeyIndexScheme(viper.GetString("index.key_scheme")); err != nil {
+		log.Logger.Panic(err)
+	}
+	if err := types.ConfigureIndexKeyExtractors(viper.GetStringSlice("index.enable_key_extractors"), viper.GetStringSlice("index.disable_key_extractors")); err != nil {
+		log.Logger.Panic(err)
+	}
+	if err := configureAttestationStorage(context.Background()); err != nil {
+		log.Logger.Panic(err)
+	}
+	if rootsFile := viper.GetString("pki.x509_trust_roots"); rootsFile != "" {
+		roots, err := ioutil.ReadFile(rootsFile)
+		if err != nil {
+			log.Logger.Panic(err)
+		}
+		if err := x509.SetTrustRoots(roots); err != nil {
+			log.Logger.Panic(err)
+		}
+	}
+	if keysFile := viper.GetString("pki.ct_log_keys"); keysFile != "" {
+		keys, err := ioutil.ReadFile(keysFile)
+		if err != nil {
+			log.Logger.Panic(err)
+		}
+		if err := x509.SetCTLogKeys(keys); err != nil {
+			log.Logger.Panic(err)
+		}
+	}
+	pgp.SetKeyPolicy(pgp.KeyPolicy{
+		RejectExpired: viper.GetBool("pki.pgp_reject_expired_keys"),
+		RejectRevoked: viper.GetBool("pki.pgp_reject_revoked_keys"),
+	})
+	util.SetFetchPolicy(util.FetchPolicy{
+		MaxSize:    viper.GetInt64("fetch.max_size"),
+		Retries:    viper.GetInt("fetch.retries"),
+		RetryDelay: viper.GetDuration("fetch.retry_delay"),
+	})
 	var err error
 	api, err = NewAPI()
 	if err != nil {
 		log.Logger.Panic(err)
 	}
+	rotation, err = configureShardRotation(api)
+	if err != nil {
+		log.Logger.Panic(err)
+	}
+	if err := configureCheckpoints(rotation.originTreeID(api.logID)); err != nil {
+		log.Logger.Panic(err)
+	}
+	if err := rotation.start(context.Background()); err != nil {
+		log.Logger.Panic(err)
+	}
+	failover, err = configureFailover(api.verifier, api.logID)
+	if err != nil {
+		log.Logger.Panic(err)
+	}
+	failover.start(context.Background())
 	if viper.GetBool("enable_retrieve_api") {
-		redisClient, err = cfg.New(context.Background(), "tcp", fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port")))
+		indexBackend, err = storage.NewIndexBackend(context.Background(), viper.GetString("index.backend"))
 		if err != nil {
 			log.Logger.Panic(err)
 		}
+		if err := checkKeyIndexScheme(context.Background()); err != nil {
+			log.Logger.Panic(err)
+		}
+		indexWriteQueue, err = newIndexQueue(viper.GetString("index.queue_path"))
+		if err != nil {
+			log.Logger.Panic(err)
+		}
+		go indexWriteQueue.run(context.Background())
+	}
+}
+
+// keyIndexSchemeKey records the scheme the index was built with, since keys indexed under one scheme cannot be
+// found by searching under another
+const keyIndexSchemeKey = "config/index_key_scheme"
+
+func checkKeyIndexScheme(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	scheme := pki.KeyIndexScheme()
+	// read-only instances may be served by a replica of the index that cannot be written to
+	if !viper.GetBool("read-only") {
+		if err := indexBackend.SetIfAbsent(ctx, keyIndexSchemeKey, scheme); err != nil {
+			return err
+		}
+	}
+	recorded, err := indexBackend.Get(ctx, keyIndexSchemeKey)
+	if err != nil {
+		return err
+	}
+	if recorded != "" && recorded != scheme {
+		return fmt.Errorf("index was built with key scheme '%v' but '%v' is configured", recorded, scheme)
 	}
+	return nil
 }
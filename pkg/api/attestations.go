@@ -0,0 +1,216 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// attestationsPath is where attestations are uploaded to attestation storage ahead of proposing the entries that
+// carry them
+const attestationsPath = "/api/v1/attestations"
+
+// configureAttestationStorage makes kinds that carry attestations keep them in the configured blob store, encrypted
+// if a key is configured, logging only their digest, unless no store is configured, and limits their size
+func configureAttestationStorage(ctx context.Context) error {
+	types.SetMaxAttestationSize(viper.GetInt64("attestation_storage.max_size"))
+	name := viper.GetString("attestation_storage.backend")
+	if name == "" {
+		types.SetAttestationStorage(nil)
+		return nil
+	}
+	store, err := storage.NewBlobStore(ctx, name)
+	if err != nil {
+		return err
+	}
+	if keyURL := viper.GetString("attestation_storage.encryption_key"); keyURL != "" {
+		if store, err = storage.OpenEncryptedBlobStore(ctx, store, keyURL); err != nil {
+			return err
+		}
+	}
+	types.SetAttestationStorage(store)
+	return nil
+}
+
+// addStoredAttestations fills in the attestations of a typed entry that were logged by their digest alone, so that
+// clients get them along with the entry
+func addStoredAttestations(ctx context.Context, e *models.LogEntryV2) error {
+	store := types.StoredAttestations()
+	if store == nil {
+		return nil
+	}
+	if e.Notation != nil && e.Notation.Envelope != nil {
+		envelope := e.Notation.Envelope
+		if len(envelope.Content) == 0 && envelope.URL.String() == "" && envelope.Hash != nil {
+			content, err := store.Get(ctx, swag.StringValue(envelope.Hash.Value))
+			if err != nil {
+				return fmt.Errorf("reading stored envelope: %w", err)
+			}
+			envelope.Content = strfmt.Base64(content)
+		}
+	}
+	return nil
+}
+
+// attestationUpload is the response to an attestation upload
+type attestationUpload struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+// attestationReferencesHeader gives the number of entries of the tenant of the request referring to a stored
+// attestation when it is retrieved, if the search index is enabled
+const attestationReferencesHeader = "X-Rekor-Attestation-References"
+
+// ServeAttestations stores the body of requests posted to attestationsPath in attestation storage as it is read,
+// without holding it in memory, and responds with its SHA-256 digest, which entries may then give in place of the
+// attestation; uploads are rate limited and authenticated as writes, and refused while the instance does not accept
+// entries. Stored attestations are served as they were uploaded from attestationsPath/<digest>, so that policy
+// engines can retrieve them given the digest in an entry and clients can skip uploading them again. When tenants
+// are served, attestation storage is shared by all of them, so each tenant is only served the attestations its
+// own entries refer to.
+func ServeAttestations(handler http.Handler) http.Handler {
+	upload := RateLimitWrites(AuthenticateWrites(http.HandlerFunc(uploadAttestation)))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == attestationsPath:
+			if r.Method != http.MethodPost {
+				w.Header().Set("Allow", http.MethodPost)
+				respondJSON(w, http.StatusMethodNotAllowed, requestErrorMsg(r, attestationUploadMethodNotAllowed, http.StatusMethodNotAllowed))
+				return
+			}
+			upload.ServeHTTP(w, r)
+		case strings.HasPrefix(r.URL.Path, attestationsPath+"/"):
+			if r.Method != http.MethodGet {
+				w.Header().Set("Allow", http.MethodGet)
+				respondJSON(w, http.StatusMethodNotAllowed, requestErrorMsg(r, attestationLookupMethodNotAllowed, http.StatusMethodNotAllowed))
+				return
+			}
+			lookupAttestation(w, r, strings.TrimPrefix(r.URL.Path, attestationsPath+"/"))
+		default:
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
+
+func lookupAttestation(w http.ResponseWriter, r *http.Request, digest string) {
+	store := types.StoredAttestations()
+	if store == nil {
+		respondJSON(w, http.StatusNotFound, requestErrorMsg(r, attestationStorageNotEnabled, http.StatusNotFound))
+		return
+	}
+	digest = strings.ToLower(digest)
+	if !govalidator.IsSHA256(digest) {
+		respondJSON(w, http.StatusBadRequest, requestErrorMsg(r, malformedAttestationDigest, http.StatusBadRequest))
+		return
+	}
+	// the entries referring to the attestation are counted before reading it, so that tenants are not told whether
+	// attestations only referred to by other tenants are stored
+	var references *int64
+	if viper.GetBool("enable_retrieve_api") {
+		_, own, err := attestationReferences(r.Context(), digest)
+		if err != nil {
+			log.RequestIDLogger(r).Errorw("counting references to stored attestation", "digest", digest, "error", err)
+			respondJSON(w, http.StatusInternalServerError, requestErrorMsg(r, redisUnexpectedResult, http.StatusInternalServerError))
+			return
+		}
+		references = &own
+	}
+	if servesTenants() && swag.Int64Value(references) == 0 {
+		respondJSON(w, http.StatusNotFound, requestErrorMsg(r, attestationNotFound, http.StatusNotFound))
+		return
+	}
+	content, err := storage.GetStream(r.Context(), store, digest)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		respondJSON(w, http.StatusNotFound, requestErrorMsg(r, attestationNotFound, http.StatusNotFound))
+		return
+	}
+	if err != nil {
+		log.RequestIDLogger(r).Errorw("reading stored attestation", "digest", digest, "error", err)
+		respondJSON(w, http.StatusInternalServerError, requestErrorMsg(r, failedToReadAttestation, http.StatusInternalServerError))
+		return
+	}
+	defer content.Close()
+	if references != nil {
+		w.Header().Set(attestationReferencesHeader, strconv.FormatInt(*references, 10))
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		// the response is aborted rather than completed, so that clients never take a truncated or altered
+		// attestation for the one stored
+		log.RequestIDLogger(r).Errorw("sending stored attestation", "digest", digest, "error", err)
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func uploadAttestation(w http.ResponseWriter, r *http.Request) {
+	store := types.StoredAttestations()
+	if store == nil {
+		respondJSON(w, http.StatusNotFound, requestErrorMsg(r, attestationStorageNotEnabled, http.StatusNotFound))
+		return
+	}
+	done, apiErr := beginWrite(r.Context())
+	if apiErr != nil {
+		log.RequestIDLogger(r).Infow("refusing attestation upload", "error", apiErr.err)
+		respondJSON(w, apiErr.code, requestErrorMsg(r, apiErr.message, apiErr.code))
+		return
+	}
+	defer done()
+	max := types.MaxAttestationSize()
+	if max > 0 && r.ContentLength > max {
+		respondJSON(w, http.StatusRequestEntityTooLarge, requestErrorMsg(r, fmt.Sprintf(attestationTooLarge, max), http.StatusRequestEntityTooLarge))
+		return
+	}
+
+	digest, size, err := storage.PutStream(r.Context(), store, r.Body, max)
+	var sizeErr *storage.BlobSizeError
+	if errors.As(err, &sizeErr) {
+		respondJSON(w, http.StatusRequestEntityTooLarge, requestErrorMsg(r, fmt.Sprintf(attestationTooLarge, max), http.StatusRequestEntityTooLarge))
+		return
+	}
+	if err != nil {
+		log.RequestIDLogger(r).Errorw("storing uploaded attestation", "error", err)
+		respondJSON(w, http.StatusInternalServerError, requestErrorMsg(r, failedToStoreAttestation, http.StatusInternalServerError))
+		return
+	}
+	log.RequestIDLogger(r).Infow("stored uploaded attestation", "digest", digest, "size", size)
+	respondJSON(w, http.StatusCreated, attestationUpload{Digest: digest, Size: size})
+}
+
+func respondJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
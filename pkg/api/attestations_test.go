@@ -0,0 +1,283 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+func TestStoredAttestations(t *testing.T) {
+	defer func(backend string) {
+		viper.Set("attestation_storage.backend", backend)
+		types.SetAttestationStorage(nil)
+	}(viper.GetString("attestation_storage.backend"))
+	ctx := context.Background()
+
+	viper.Set("attestation_storage.backend", "missing")
+	if err := configureAttestationStorage(ctx); err == nil {
+		t.Error("expected error for an unknown blob store")
+	}
+	viper.Set("attestation_storage.backend", "memory")
+	if err := configureAttestationStorage(ctx); err != nil {
+		t.Fatal(err)
+	}
+	digest, err := types.StoredAttestations().Put(ctx, []byte("envelope"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uuid := "3030303030303030303030303030303030303030303030303030303030303030"
+	v1 := func(digest string) models.LogEntry {
+		body := `{"apiVersion":"0.0.1","kind":"notation","spec":{"envelope":{"mediaType":"application/cose","hash":{"algorithm":"sha256","value":"` + digest + `"}}}}`
+		return models.LogEntry{uuid: models.LogEntryAnon{LogIndex: swag.Int64(7), IntegratedTime: 1600000000, Body: []byte(body)}}
+	}
+	entry, err := logEntryV2(ctx, v1(digest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Notation == nil || string(entry.Notation.Envelope.Content) != "envelope" {
+		t.Errorf("stored envelope not added to entry %+v", entry.Notation)
+	}
+	if _, err := logEntryV2(ctx, v1(storage.BlobDigest([]byte("missing")))); err == nil {
+		t.Error("expected error for an envelope missing from storage")
+	}
+
+	viper.Set("attestation_storage.encryption_key", "base64key://")
+	defer viper.Set("attestation_storage.encryption_key", "")
+	if err := configureAttestationStorage(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := types.StoredAttestations().(*storage.EncryptedBlobStore); !ok {
+		t.Errorf("expected attestations to be encrypted, got %T", types.StoredAttestations())
+	}
+	viper.Set("attestation_storage.encryption_key", "unknown://key")
+	if err := configureAttestationStorage(ctx); err == nil {
+		t.Error("expected error for an unknown key URL scheme")
+	}
+	viper.Set("attestation_storage.encryption_key", "")
+
+	viper.Set("attestation_storage.backend", "")
+	if err := configureAttestationStorage(ctx); err != nil || types.StoredAttestations() != nil {
+		t.Errorf("expected attestations to be kept inline, got %v, %v", types.StoredAttestations(), err)
+	}
+}
+
+func TestServeAttestations(t *testing.T) {
+	defer types.SetAttestationStorage(nil)
+	defer types.SetMaxAttestationSize(0)
+	defer func(c *runtimeConfig) { runtimeCfg = c }(runtimeCfg)
+	store := storage.NewMemoryBlobStore()
+	types.SetMaxAttestationSize(8)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := ServeAttestations(next)
+
+	tests := []struct {
+		caseDesc     string
+		store        types.AttestationStorage
+		readOnly     bool
+		method       string
+		path         string
+		body         string
+		expectedCode int
+	}{
+		{caseDesc: "other paths are passed through", store: store, method: http.MethodPost, path: "/api/v1/log/entries", expectedCode: http.StatusTeapot},
+		{caseDesc: "upload", store: store, method: http.MethodPost, path: attestationsPath, body: "envelope", expectedCode: http.StatusCreated},
+		{caseDesc: "upload over the size limit", store: store, method: http.MethodPost, path: attestationsPath, body: "envelopes", expectedCode: http.StatusRequestEntityTooLarge},
+		{caseDesc: "wrong method", store: store, method: http.MethodGet, path: attestationsPath, expectedCode: http.StatusMethodNotAllowed},
+		{caseDesc: "storage not configured", method: http.MethodPost, path: attestationsPath, body: "envelope", expectedCode: http.StatusNotFound},
+		{caseDesc: "read-only instance", store: store, readOnly: true, method: http.MethodPost, path: attestationsPath, body: "manifest", expectedCode: http.StatusServiceUnavailable},
+	}
+	for _, tc := range tests {
+		types.SetAttestationStorage(tc.store)
+		runtimeCfg = newRuntimeConfig()
+		runtimeCfg.readOnly = tc.readOnly
+		r := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		// bodies of unknown length are only rejected once they are read past the limit
+		r.ContentLength = -1
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tc.expectedCode {
+			t.Errorf("unexpected status %v in '%v': %s", w.Code, tc.caseDesc, w.Body)
+			continue
+		}
+		if w.Code != http.StatusCreated {
+			continue
+		}
+		var upload attestationUpload
+		if err := json.Unmarshal(w.Body.Bytes(), &upload); err != nil {
+			t.Fatal(err)
+		}
+		if upload.Digest != storage.BlobDigest([]byte(tc.body)) || upload.Size != int64(len(tc.body)) {
+			t.Errorf("unexpected upload %+v in '%v'", upload, tc.caseDesc)
+		}
+		if content, err := store.Get(context.Background(), upload.Digest); err != nil || string(content) != tc.body {
+			t.Errorf("attestation not stored in '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+// referringEntry keeps an attestation in attestation storage
+type referringEntry struct {
+	indexedEntry
+	digest string
+}
+
+func (e referringEntry) StoredAttestationDigests() []string { return []string{e.digest} }
+
+func TestAttestationReferences(t *testing.T) {
+	defer func(b storage.IndexBackend, enabled bool) {
+		indexBackend = b
+		viper.Set("enable_retrieve_api", enabled)
+		types.SetAttestationStorage(nil)
+	}(indexBackend, viper.GetBool("enable_retrieve_api"))
+	indexBackend = storage.NewMemoryBackend()
+	store := storage.NewMemoryBlobStore()
+	types.SetAttestationStorage(store)
+	ctx := context.Background()
+
+	// the same attestation is logged with the entries of two architectures, and the index write of one is retried
+	digest, err := store.Put(ctx, []byte("provenance"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var writes []storage.IndexWrite
+	for _, uuid := range []string{"amd64", "arm64", "arm64"} {
+		writes = append(writes, indexWrites("notation", referringEntry{indexedEntry{key: "artifact-" + uuid}, digest}, nil, uuid)...)
+	}
+	if err := indexBackend.WriteBatch(ctx, writes); err != nil {
+		t.Fatal(err)
+	}
+	if all, own, err := attestationReferences(ctx, digest); err != nil || all != 2 || own != 2 {
+		t.Errorf("attestationReferences() = %v, %v, %v", all, own, err)
+	}
+	// reference lists are never found by a hash search
+	if uuids, err := indexBackend.Lookup(ctx, digest); err != nil || len(uuids) != 0 {
+		t.Errorf("references returned by hash search: %v, %v", uuids, err)
+	}
+
+	handler := ServeAttestations(http.NotFoundHandler())
+	tests := []struct {
+		caseDesc           string
+		path               string
+		retrieveAPI        bool
+		expectedCode       int
+		expectedReferences string
+	}{
+		{caseDesc: "stored attestation", path: attestationsPath + "/" + digest, retrieveAPI: true, expectedCode: http.StatusOK, expectedReferences: "2"},
+		{caseDesc: "uppercase digest", path: attestationsPath + "/" + strings.ToUpper(digest), retrieveAPI: true, expectedCode: http.StatusOK, expectedReferences: "2"},
+		{caseDesc: "references unknown without the index", path: attestationsPath + "/" + digest, expectedCode: http.StatusOK},
+		{caseDesc: "attestation never stored", path: attestationsPath + "/" + storage.BlobDigest([]byte("missing")), retrieveAPI: true, expectedCode: http.StatusNotFound},
+		{caseDesc: "malformed digest", path: attestationsPath + "/../log", retrieveAPI: true, expectedCode: http.StatusBadRequest},
+	}
+	for _, tc := range tests {
+		viper.Set("enable_retrieve_api", tc.retrieveAPI)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if w.Code != tc.expectedCode {
+			t.Errorf("unexpected status %v in '%v': %s", w.Code, tc.caseDesc, w.Body)
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		if w.Body.String() != "provenance" || w.Header().Get("Content-Type") != "application/octet-stream" {
+			t.Errorf("unexpected attestation %q of type %q in '%v'", w.Body, w.Header().Get("Content-Type"), tc.caseDesc)
+		}
+		if got := w.Header().Get(attestationReferencesHeader); got != tc.expectedReferences {
+			t.Errorf("unexpected references %q in '%v'", got, tc.caseDesc)
+		}
+	}
+}
+
+func TestAttestationReferencesAcrossTenants(t *testing.T) {
+	defer func(b storage.IndexBackend, a *API, enabled bool) {
+		indexBackend, api = b, a
+		viper.Set("enable_retrieve_api", enabled)
+		types.SetAttestationStorage(nil)
+	}(indexBackend, api, viper.GetBool("enable_retrieve_api"))
+	indexBackend = storage.NewMemoryBackend()
+	api = &API{tenants: map[string]*API{"a": {}, "b": {}, "c": {}}}
+	store := storage.NewMemoryBlobStore()
+	types.SetAttestationStorage(store)
+	ctx := context.Background()
+	digest, err := store.Put(ctx, []byte("provenance"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenantA := withTenant(ctx, tenant{name: "a"})
+	tenantB := withTenant(ctx, tenant{name: "b"})
+	tenantC := withTenant(ctx, tenant{name: "c"})
+
+	// the same attestation is logged in two tenants, once with the same entry in both of them
+	for _, w := range []struct {
+		ctx  context.Context
+		uuid string
+	}{{tenantA, "amd64"}, {tenantA, "arm64"}, {tenantB, "amd64"}} {
+		if err := addToIndex(w.ctx, indexWrites("notation", referringEntry{indexedEntry{key: "artifact"}, digest}, nil, w.uuid)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the other lists of the entries are still kept apart
+	if uuids, err := indexBackend.Lookup(ctx, indexKey(tenantB, "artifact")); err != nil || len(uuids) != 1 {
+		t.Errorf("unexpected entries of tenant b: %v, %v", uuids, err)
+	}
+
+	handler := ServeAttestations(http.NotFoundHandler())
+	tests := []struct {
+		caseDesc           string
+		ctx                context.Context
+		retrieveAPI        bool
+		expectedOwn        int64
+		expectedCode       int
+		expectedReferences string
+	}{
+		{caseDesc: "tenant with two entries", ctx: tenantA, retrieveAPI: true, expectedOwn: 2, expectedCode: http.StatusOK, expectedReferences: "2"},
+		{caseDesc: "tenant with one entry", ctx: tenantB, retrieveAPI: true, expectedOwn: 1, expectedCode: http.StatusOK, expectedReferences: "1"},
+		{caseDesc: "tenant without entries", ctx: tenantC, retrieveAPI: true, expectedCode: http.StatusNotFound},
+		{caseDesc: "default tree without entries", ctx: ctx, retrieveAPI: true, expectedCode: http.StatusNotFound},
+		{caseDesc: "references unknown without the index", ctx: tenantA, expectedOwn: 2, expectedCode: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		if all, own, err := attestationReferences(tc.ctx, digest); err != nil || all != 3 || own != tc.expectedOwn {
+			t.Errorf("attestationReferences() in '%v' = %v, %v, %v", tc.caseDesc, all, own, err)
+		}
+		viper.Set("enable_retrieve_api", tc.retrieveAPI)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, attestationsPath+"/"+digest, nil).WithContext(tc.ctx))
+		if w.Code != tc.expectedCode {
+			t.Errorf("unexpected status %v in '%v': %s", w.Code, tc.caseDesc, w.Body)
+			continue
+		}
+		if got := w.Header().Get(attestationReferencesHeader); got != tc.expectedReferences {
+			t.Errorf("unexpected references %q in '%v'", got, tc.caseDesc)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// writeAuthenticator verifies the OIDC tokens that requests to add entries must carry when authenticated
+// writes are enabled, identifying the client by a claim of the token
+type writeAuthenticator struct {
+	verifier *oidc.IDTokenVerifier
+	claim    string
+	// metricIdentities are the identities counted under their own label in the metrics, which would otherwise
+	// grow a series for every client
+	metricIdentities map[string]bool
+}
+
+// writeAuth is nil unless auth.oidc_issuer is set
+var writeAuth *writeAuthenticator
+
+type identityKey struct{}
+
+// withIdentity returns a context carrying the identity of the authenticated client
+func withIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// identityFrom returns the identity of the authenticated client of ctx, or an empty string if the request
+// was not authenticated
+func identityFrom(ctx context.Context) string {
+	identity, _ := ctx.Value(identityKey{}).(string)
+	return identity
+}
+
+// configureWriteAuth enables authenticated writes if an OIDC issuer is configured, discovering the keys
+// it signs tokens with
+func configureWriteAuth(ctx context.Context) error {
+	issuer := viper.GetString("auth.oidc_issuer")
+	if issuer == "" {
+		writeAuth = nil
+		return nil
+	}
+	clientID := viper.GetString("auth.oidc_client_id")
+	if clientID == "" {
+		return errors.New("auth.oidc_client_id must be set when auth.oidc_issuer is")
+	}
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return fmt.Errorf("error discovering OIDC issuer %v: %w", issuer, err)
+	}
+	writeAuth = &writeAuthenticator{
+		verifier:         provider.Verifier(&oidc.Config{ClientID: clientID}),
+		claim:            viper.GetString("auth.oidc_identity_claim"),
+		metricIdentities: map[string]bool{},
+	}
+	for _, identity := range viper.GetStringSlice("auth.metric_identities") {
+		writeAuth.metricIdentities[identity] = true
+	}
+	return nil
+}
+
+// otherIdentities is the metric label of the authenticated clients not listed in auth.metric_identities
+const otherIdentities = "other"
+
+// metricIdentity returns the label the entries added by identity are counted under in the metrics
+func metricIdentity(identity string) string {
+	if a := writeAuth; a != nil && a.metricIdentities[identity] {
+		return identity
+	}
+	return otherIdentities
+}
+
+// authenticate verifies the raw token and returns the identity it was issued to
+func (a *writeAuthenticator) authenticate(ctx context.Context, rawToken string) (string, error) {
+	token, err := a.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return "", err
+	}
+	if a.claim == "" || a.claim == "sub" {
+		return token.Subject, nil
+	}
+	claims := map[string]interface{}{}
+	if err := token.Claims(&claims); err != nil {
+		return "", err
+	}
+	identity, ok := claims[a.claim].(string)
+	if !ok || identity == "" {
+		return "", fmt.Errorf("token has no %v claim", a.claim)
+	}
+	// issuers that let users set their own email address mark whether they have checked it
+	if verified, ok := claims["email_verified"].(bool); a.claim == "email" && ok && !verified {
+		return "", errors.New("email address of token has not been verified")
+	}
+	return identity, nil
+}
+
+// bearerToken returns the token of an Authorization header using the Bearer scheme
+func bearerToken(authorization string) (string, bool) {
+	const prefix = "bearer "
+	if len(authorization) <= len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(authorization[len(prefix):]), true
+}
+
+// grpcIdentity authenticates the client of a gRPC call from the token in its authorization metadata,
+// returning the context of the call with the identity of the client added
+func grpcIdentity(ctx context.Context) (context.Context, *apiError) {
+	if writeAuth == nil {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var authorization string
+	if values := md.Get("authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+	rawToken, ok := bearerToken(authorization)
+	if !ok {
+		return ctx, &apiError{http.StatusUnauthorized, errors.New("no bearer token"), authenticationRequired}
+	}
+	identity, err := writeAuth.authenticate(ctx, rawToken)
+	if err != nil {
+		return ctx, &apiError{http.StatusUnauthorized, err, authenticationRequired}
+	}
+	return withIdentity(ctx, identity), nil
+}
+
+// AuthenticateWrites rejects requests with 401 unless they carry an OIDC token verified by the issuer
+// configured as auth.oidc_issuer, recording the identity the token was issued to; requests are passed
+// through unchanged if no issuer is configured
+func AuthenticateWrites(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a := writeAuth
+		if a == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		identity, err := "", errors.New("no bearer token")
+		if rawToken, ok := bearerToken(r.Header.Get("Authorization")); ok {
+			identity, err = a.authenticate(r.Context(), rawToken)
+		}
+		if err != nil {
+			log.RequestIDLogger(r).Infow("rejecting unauthenticated write request", "remoteAddr", r.RemoteAddr, "error", err)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(requestErrorMsg(r, authenticationRequired, http.StatusUnauthorized))
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), identity)))
+	})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"google.golang.org/grpc/metadata"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+const testIssuer = "https://oauth2.example.com"
+
+func signToken(t *testing.T, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jws.CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+// staticKeySet verifies tokens signed with a single key
+type staticKeySet struct {
+	key crypto.PublicKey
+}
+
+func (s staticKeySet) VerifySignature(_ context.Context, token string) ([]byte, error) {
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, err
+	}
+	return jws.Verify(s.key)
+}
+
+func testAuthenticator(t *testing.T, key *ecdsa.PrivateKey, claim string) *writeAuthenticator {
+	t.Helper()
+	return &writeAuthenticator{
+		verifier: oidc.NewVerifier(testIssuer, staticKeySet{key.Public()}, &oidc.Config{ClientID: "rekor", SupportedSigningAlgs: []string{oidc.ES256}}),
+		claim:    claim,
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	claims := func(extra map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss": testIssuer,
+			"aud": "rekor",
+			"sub": "1234",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range extra {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		caseDesc string
+		claim    string
+		token    string
+		identity string
+	}{
+		{caseDesc: "email", claim: "email", token: signToken(t, key, claims(map[string]interface{}{"email": "jdoe@example.com", "email_verified": true})), identity: "jdoe@example.com"},
+		{caseDesc: "subject", claim: "sub", token: signToken(t, key, claims(nil)), identity: "1234"},
+		{caseDesc: "unverified email", claim: "email", token: signToken(t, key, claims(map[string]interface{}{"email": "jdoe@example.com", "email_verified": false}))},
+		{caseDesc: "missing claim", claim: "email", token: signToken(t, key, claims(nil))},
+		{caseDesc: "other audience", claim: "sub", token: signToken(t, key, claims(map[string]interface{}{"aud": "other"}))},
+		{caseDesc: "expired", claim: "sub", token: signToken(t, key, claims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}))},
+		{caseDesc: "other key", claim: "sub", token: signToken(t, otherKey, claims(nil))},
+		{caseDesc: "not a token", claim: "sub", token: "not a token"},
+	}
+	for _, tc := range tests {
+		identity, err := testAuthenticator(t, key, tc.claim).authenticate(context.Background(), tc.token)
+		if (err == nil) != (tc.identity != "") || identity != tc.identity {
+			t.Errorf("%v: authenticated as %q with error %v, expected %q", tc.caseDesc, identity, err, tc.identity)
+		}
+	}
+}
+
+func TestAuthenticateWrites(t *testing.T) {
+	defer func(a *writeAuthenticator) { writeAuth = a }(writeAuth)
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	token := signToken(t, key, map[string]interface{}{"iss": testIssuer, "aud": "rekor", "sub": "1234", "exp": time.Now().Add(time.Hour).Unix()})
+
+	var identity string
+	handler := AuthenticateWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity = identityFrom(r.Context())
+		w.WriteHeader(http.StatusCreated)
+	}))
+	send := func(authorization string) int {
+		identity = ""
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// without an issuer configured, writes are not authenticated
+	writeAuth = nil
+	if code := send(""); code != http.StatusCreated || identity != "" {
+		t.Errorf("unauthenticated request returned %v as %q", code, identity)
+	}
+
+	writeAuth = testAuthenticator(t, key, "sub")
+	if code := send(""); code != http.StatusUnauthorized {
+		t.Errorf("request without token returned %v", code)
+	}
+	if code := send("Basic dXNlcjpwYXNz"); code != http.StatusUnauthorized {
+		t.Errorf("request with basic credentials returned %v", code)
+	}
+	if code := send("Bearer " + token); code != http.StatusCreated || identity != "1234" {
+		t.Errorf("authenticated request returned %v as %q", code, identity)
+	}
+
+	ctx, apiErr := grpcIdentity(metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token)))
+	if apiErr != nil || identityFrom(ctx) != "1234" {
+		t.Errorf("gRPC call authenticated as %q with error %v", identityFrom(ctx), apiErr)
+	}
+	if _, apiErr := grpcIdentity(context.Background()); apiErr == nil || apiErr.code != http.StatusUnauthorized {
+		t.Errorf("gRPC call without token returned %v", apiErr)
+	}
+}
+
+func TestMetricIdentity(t *testing.T) {
+	defer func(a *writeAuthenticator) { writeAuth = a }(writeAuth)
+	writeAuth = &writeAuthenticator{metricIdentities: map[string]bool{"ci@example.com": true}}
+	for identity, label := range map[string]string{
+		"ci@example.com":   "ci@example.com",
+		"jdoe@example.com": otherIdentities,
+	} {
+		if got := metricIdentity(identity); got != label {
+			t.Errorf("identity %v counted as %v, expected %v", identity, got, label)
+		}
+	}
+	writeAuth = nil
+	if got := metricIdentity("ci@example.com"); got != otherIdentities {
+		t.Errorf("identity counted as %v without authenticated writes", got)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/runtime"
+	"github.com/google/trillian"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// BackfillStats counts what Backfill has replayed so far
+type BackfillStats struct {
+	// Entries is the number of leaves read from the log
+	Entries int64
+	// Keys is the number of values written to the lists of the index
+	Keys int64
+	// Skipped is the number of leaves that could not be decoded into an entry
+	Skipped int64
+}
+
+// ConfigureBackfill connects to the tree and the search index configured for the server, without starting
+// anything that only serving requests needs
+func ConfigureBackfill(ctx context.Context) error {
+	if viper.GetInt64("trillian_log_server.tlog_id") == 0 {
+		return errors.New("trillian_log_server.tlog_id must be set to the tree whose index is rebuilt")
+	}
+	if err := pki.SetKeyIndexScheme(viper.GetString("index.key_scheme")); err != nil {
+		return err
+	}
+	if err := types.ConfigureIndexKeyExtractors(viper.GetStringSlice("index.enable_key_extractors"), viper.GetStringSlice("index.disable_key_extractors")); err != nil {
+		return err
+	}
+	// leaves of kinds that keep their attestations in storage are decoded from them
+	if err := configureAttestationStorage(ctx); err != nil {
+		return err
+	}
+	var err error
+	if api, err = NewAPI(); err != nil {
+		return err
+	}
+	if indexBackend, err = storage.NewIndexBackend(ctx, viper.GetString("index.backend")); err != nil {
+		return err
+	}
+	return checkKeyIndexScheme(ctx)
+}
+
+// Backfill rebuilds the search index of the tree of the named tenant, or of the default log if tenantName is
+// empty, by replaying its leaves from start up to end, or up to the current size of the log if end is negative;
+// the default log is replayed from its inactive shards onwards. The leaves are read and indexed batchSize at a
+// time, and progress is called after each batch.
+func Backfill(ctx context.Context, tenantName string, start, end, batchSize int64, progress func(BackfillStats)) (BackfillStats, error) {
+	var stats BackfillStats
+	if batchSize <= 0 {
+		return stats, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	if tenantName != "" {
+		if _, ok := api.tenants[tenantName]; !ok {
+			return stats, fmt.Errorf("no tenant named %q is configured", tenantName)
+		}
+		ctx = withTenant(ctx, tenant{name: tenantName})
+	}
+	logger := log.ContextLogger(ctx)
+	tc := NewTrillianClient(ctx)
+	if end < 0 {
+		root, err := tc.root()
+		if err != nil {
+			return stats, err
+		}
+		end = tc.offset + int64(root.TreeSize)
+	}
+
+	for index := start; index < end; {
+		count := end - index
+		if count > batchSize {
+			count = batchSize
+		}
+		resp := tc.getLogLeavesByRange(index, count)
+		if resp.err != nil {
+			return stats, fmt.Errorf("reading leaves from index %d: %w", index, resp.err)
+		}
+		leaves := resp.getLeafByRangeResult.GetLeaves()
+		if len(leaves) == 0 {
+			return stats, fmt.Errorf("log returned no leaves at index %d", index)
+		}
+
+		var writes []storage.IndexWrite
+		for _, leaf := range leaves {
+			stats.Entries++
+			kind, leafWrites, err := leafIndexWrites(leaf)
+			if err != nil {
+				stats.Skipped++
+				logger.Warnw("skipping leaf that could not be decoded", "logIndex", leaf.LeafIndex, "error", err)
+				continue
+			}
+			writes = append(writes, leafWrites...)
+			if err := addToTimeIndex(ctx, leaf.LeafIndex, leaf.IntegrateTimestamp.AsTime().Unix()); err != nil {
+				return stats, err
+			}
+			if err := countKind(ctx, kind); err != nil {
+				return stats, err
+			}
+		}
+		if err := addToIndex(ctx, writes); err != nil {
+			return stats, err
+		}
+		if err := addToKeyIndex(ctx, writes); err != nil {
+			return stats, err
+		}
+		stats.Keys += int64(len(writes))
+		index += int64(len(leaves))
+		if progress != nil {
+			progress(stats)
+		}
+	}
+	return stats, nil
+}
+
+// leafIndexWrites decodes leaf into the entry it was logged for, returning its kind and the writes indexing it
+// as the server did when the entry was added
+func leafIndexWrites(leaf *trillian.LogLeaf) (string, []storage.IndexWrite, error) {
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(leaf.LeafValue), runtime.JSONConsumer())
+	if err != nil {
+		return "", nil, err
+	}
+	entry, err := types.NewEntry(pe)
+	if err != nil {
+		return "", nil, err
+	}
+	var sigUse *signatureUse
+	if viper.GetBool("detect_signature_reuse") {
+		if ds, ok := entry.(types.DetachedSignature); ok {
+			if sigUse, err = newSignatureUse(ds); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+	return pe.Kind(), indexWrites(pe.Kind(), entry, sigUse, hex.EncodeToString(leaf.MerkleLeafHash)), nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/google/trillian"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	ttypes "github.com/google/trillian/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// indexedType decodes rekord entries into entries indexed under the "key" field of their spec
+type indexedType struct{}
+
+func (indexedType) Kind() string { return "rekord" }
+
+func (indexedType) UnmarshalEntry(pe models.ProposedEntry) (types.EntryImpl, error) {
+	spec, _ := pe.(*models.Rekord).Spec.(map[string]interface{})
+	key, _ := spec["key"].(string)
+	return indexedEntry{key: key}, nil
+}
+
+type indexedEntry struct {
+	types.EntryImpl
+	key string
+}
+
+func (e indexedEntry) IndexKeys() []string { return []string{e.key} }
+
+func (indexedEntry) HasExternalEntities() bool { return false }
+
+// leavesLogClient serves a tree of the given leaves, returning at most two of them at a time
+type leavesLogClient struct {
+	fakeLogClient
+	leaves [][]byte
+}
+
+func (f leavesLogClient) GetLatestSignedLogRoot(context.Context, *trillian.GetLatestSignedLogRootRequest, ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root, err := (&ttypes.LogRootV1{TreeSize: uint64(len(f.leaves))}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: root}}, nil
+}
+
+func (f leavesLogClient) GetLeavesByRange(_ context.Context, req *trillian.GetLeavesByRangeRequest, _ ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	resp := &trillian.GetLeavesByRangeResponse{}
+	for i := req.StartIndex; i < req.StartIndex+req.Count && i < req.StartIndex+2 && i < int64(len(f.leaves)); i++ {
+		resp.Leaves = append(resp.Leaves, &trillian.LogLeaf{
+			LeafIndex:          i,
+			LeafValue:          f.leaves[i],
+			MerkleLeafHash:     rfc6962.DefaultHasher.HashLeaf(f.leaves[i]),
+			IntegrateTimestamp: timestamppb.Now(),
+		})
+	}
+	return resp, nil
+}
+
+func TestBackfill(t *testing.T) {
+	if factory, ok := types.TypeMap.Get("rekord"); ok {
+		defer types.TypeMap.Set("rekord", factory)
+	}
+	types.TypeMap.Set("rekord", func() types.TypeImpl { return indexedType{} })
+	leaves := [][]byte{
+		[]byte(`{"apiVersion":"0.0.1","kind":"rekord","spec":{"key":"a"}}`),
+		[]byte(`{"apiVersion":"0.0.1","kind":"rekord","spec":{"key":"b"}}`),
+		[]byte(`not an entry`),
+		[]byte(`{"apiVersion":"0.0.1","kind":"rekord","spec":{"key":"a","again":true}}`),
+	}
+	defer func(a *API, b storage.IndexBackend) { api, indexBackend = a, b }(api, indexBackend)
+	api = &API{logClient: leavesLogClient{leaves: leaves}}
+	backend := storage.NewMemoryBackend()
+	indexBackend = backend
+
+	var batches int
+	stats, err := Backfill(context.Background(), "", 0, -1, 3, func(BackfillStats) { batches++ })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats != (BackfillStats{Entries: 4, Keys: 3, Skipped: 1}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	// the log returns fewer leaves than asked for, which are followed by the next batch
+	if batches != 2 {
+		t.Errorf("expected 2 batches, got %v", batches)
+	}
+
+	uuid := func(leaf []byte) string { return hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(leaf)) }
+	if got, _ := backend.Lookup(context.Background(), "a"); !reflect.DeepEqual(got, []string{uuid(leaves[3]), uuid(leaves[0])}) {
+		t.Errorf("unexpected UUIDs under a: %v", got)
+	}
+	if counts, _ := backend.Counters(context.Background(), kindCountsKey); counts["rekord"] != 3 {
+		t.Errorf("unexpected kind counts %v", counts)
+	}
+	if n, _ := backend.RangeByScore(context.Background(), integratedTimeKey, 0, 1<<62, 0, 10); len(n) != 3 {
+		t.Errorf("expected 3 entries in the time index, got %v", n)
+	}
+
+	if _, err := Backfill(context.Background(), "missing", 0, -1, 3, nil); err == nil {
+		t.Error("expected error backfilling unknown tenant")
+	}
+}
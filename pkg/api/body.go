@@ -0,0 +1,74 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// maxBatchEntries is the largest number of entries a batch may propose, as set by the API definition
+const maxBatchEntries = 100
+
+// limitBodies rejects requests with a body larger than the limit returned by limit with 413 before they are
+// decoded, where a limit of 0 means unlimited. Bodies that do not declare their length fail to decode once
+// they exceed the limit.
+func limitBodies(handler http.Handler, limit func() int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		max := limit()
+		if max <= 0 || r.Body == nil {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if r.ContentLength > max {
+			log.RequestIDLogger(r).Infow("rejecting request body", "contentLength", r.ContentLength, "limit", max)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			_ = json.NewEncoder(w).Encode(requestErrorMsg(r, fmt.Sprintf(requestBodyTooLarge, max), http.StatusRequestEntityTooLarge))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, max)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// LimitRequestBodies limits the body of every request to max_request_body_size bytes
+func LimitRequestBodies(handler http.Handler) http.Handler {
+	return limitBodies(handler, func() int64 {
+		return viper.GetInt64("max_request_body_size")
+	})
+}
+
+// LimitEntryBodies limits the body of requests proposing a single entry to max_entry_size bytes
+func LimitEntryBodies(handler http.Handler) http.Handler {
+	return limitBodies(handler, func() int64 {
+		return viper.GetInt64("max_entry_size")
+	})
+}
+
+// LimitBatchBodies limits the body of requests proposing a batch of entries to max_entry_size bytes for each
+// entry the batch may hold
+func LimitBatchBodies(handler http.Handler) http.Handler {
+	return limitBodies(handler, func() int64 {
+		return viper.GetInt64("max_entry_size") * maxBatchEntries
+	})
+}
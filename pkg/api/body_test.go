@@ -0,0 +1,60 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitBodies(t *testing.T) {
+	limit := int64(0)
+	handler := limitBodies(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := ioutil.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}), func() int64 { return limit })
+	send := func(body string, chunked bool) int {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", strings.NewReader(body))
+		if chunked {
+			r.ContentLength = -1
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	if code := send("0123456789", false); code != http.StatusCreated {
+		t.Errorf("unlimited request returned %v", code)
+	}
+	limit = 10
+	if code := send("0123456789", false); code != http.StatusCreated {
+		t.Errorf("request at the limit returned %v", code)
+	}
+	if code := send("0123456789a", false); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("request over the limit returned %v", code)
+	}
+	// bodies without a declared length are cut off once they pass the limit
+	if code := send("0123456789a", true); code != http.StatusBadRequest {
+		t.Errorf("chunked request over the limit returned %v", code)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime/middleware"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/spf13/viper"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// algEd25519 is the identifier the signed note format prefixes Ed25519 keys with
+const algEd25519 = 1
+
+var (
+	// checkpointSigner is nil unless checkpoint.signing_key is set
+	checkpointSigner note.Signer
+	checkpointOrigin string
+	// checkpointTreeID is the tree checkpointOrigin identifies; the checkpoints of other trees carry their ID
+	checkpointTreeID int64
+)
+
+// configureCheckpoints loads the key checkpoints of the tree with the given ID are signed with
+func configureCheckpoints(logID int64) error {
+	checkpointSigner = nil
+	checkpointTreeID = logID
+	keyFile := viper.GetString("checkpoint.signing_key")
+	if keyFile == "" {
+		return nil
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return err
+	}
+	name := viper.GetString("checkpoint.key_name")
+	signer, vkey, err := newCheckpointSigner(name, keyPEM)
+	if err != nil {
+		return fmt.Errorf("error parsing %v: %w", keyFile, err)
+	}
+	checkpointOrigin = viper.GetString("checkpoint.origin")
+	if checkpointOrigin == "" {
+		checkpointOrigin = fmt.Sprintf("%v - %d", name, logID)
+	}
+	if strings.Contains(checkpointOrigin, "\n") {
+		return errors.New("checkpoint.origin must be a single line")
+	}
+	checkpointSigner = signer
+	log.Logger.Infof("Signing checkpoints with origin %q, verifiable with the note key %v", checkpointOrigin, vkey)
+	return nil
+}
+
+// newCheckpointSigner returns a signer of notes named name using the PEM encoded PKCS#8 Ed25519 private key,
+// along with the verifier key that witnesses and verifiers are configured with
+func newCheckpointSigner(name string, keyPEM []byte) (note.Signer, string, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, "", errors.New("no PEM encoded private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, "", err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		// the signed note format is only verified with Ed25519 keys
+		return nil, "", fmt.Errorf("checkpoints must be signed with an Ed25519 key, not %T", key)
+	}
+	vkey, err := note.NewEd25519VerifierKey(name, edKey.Public().(ed25519.PublicKey))
+	if err != nil {
+		return nil, "", err
+	}
+	// the signer key carries the same name and key hash as the verifier key, followed by the seed of the key
+	fields := strings.Split(vkey, "+")
+	skey := fmt.Sprintf("PRIVATE+KEY+%v+%v+%v", name, fields[1], base64.StdEncoding.EncodeToString(append([]byte{algEd25519}, edKey.Seed()...)))
+	signer, err := note.NewSigner(skey)
+	if err != nil {
+		return nil, "", err
+	}
+	return signer, vkey, nil
+}
+
+// checkpointText returns the body of the checkpoint of a tree of size treeSize with the given root hash
+func checkpointText(origin string, treeSize uint64, rootHash []byte) string {
+	return fmt.Sprintf("%v\n%d\n%v\n", origin, treeSize, base64.StdEncoding.EncodeToString(rootHash))
+}
+
+func GetLogCheckpointHandler(params tlog.GetLogCheckpointParams) middleware.Responder {
+	tc := NewTrillianClient(params.HTTPRequest.Context())
+
+	resp := tc.getLatest(0)
+	if resp.status != codes.OK {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError)
+	}
+
+	// only tree heads signed with the key we're aware of are vouched for
+	root, err := tcrypto.VerifySignedLogRoot(tc.verifier.PubKey, tc.verifier.SigHash, resp.getLatestResult.SignedLogRoot)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+	}
+
+	origin := checkpointOrigin
+	if tc.logID != checkpointTreeID {
+		// the trees of tenants and those the log was rotated to are told apart by their IDs
+		origin = fmt.Sprintf("%v - %d", checkpointSigner.Name(), tc.logID)
+	}
+	checkpoint, err := note.Sign(&note.Note{Text: checkpointText(origin, root.TreeSize, root.RootHash)}, checkpointSigner)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, "")
+	}
+	return tlog.NewGetLogCheckpointOK().WithPayload(string(checkpoint))
+}
+
+func GetLogCheckpointNotImplementedHandler(params tlog.GetLogCheckpointParams) middleware.Responder {
+	err := models.Error{
+		Code:    http.StatusNotImplemented,
+		Message: "Checkpoints are not signed by this Rekor instance",
+	}
+
+	return tlog.NewGetLogCheckpointDefault(http.StatusNotImplemented).WithPayload(&err)
+}
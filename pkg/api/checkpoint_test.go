@@ -0,0 +1,78 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"golang.org/x/mod/sumdb/note"
+)
+
+func pkcs8PEM(t *testing.T, key interface{}) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestCheckpointSigner(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	signer, vkey, err := newCheckpointSigner("rekor.example.com", pkcs8PEM(t, edKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := checkpointText("rekor.example.com - 1234", 42, []byte{0xde, 0xad, 0xbe, 0xef})
+	if text != "rekor.example.com - 1234\n42\n3q2+7w==\n" {
+		t.Errorf("unexpected checkpoint text %q", text)
+	}
+	signed, err := note.Sign(&note.Note{Text: text}, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := note.Open(signed, note.VerifierList(verifier))
+	if err != nil {
+		t.Fatalf("checkpoint could not be verified: %v", err)
+	}
+	if n.Text != text || len(n.Sigs) != 1 || n.Sigs[0].Name != "rekor.example.com" {
+		t.Errorf("unexpected note %+v", n)
+	}
+
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if _, _, err := newCheckpointSigner("rekor.example.com", pkcs8PEM(t, ecKey)); err == nil {
+		t.Error("checkpoint signer created with an ECDSA key")
+	}
+	if _, _, err := newCheckpointSigner("rekor example", pkcs8PEM(t, edKey)); err == nil {
+		t.Error("checkpoint signer created with an invalid key name")
+	}
+	if _, _, err := newCheckpointSigner("rekor.example.com", []byte("not a key")); err == nil {
+		t.Error("checkpoint signer created without a key")
+	}
+}
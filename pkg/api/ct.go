@@ -0,0 +1,270 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// ctPathPrefix is the prefix of the read endpoints of RFC 6962 that the CT API serves
+const ctPathPrefix = "/ct/v1/"
+
+// maxCTEntries is the largest number of entries returned by a single get-entries request, as with the
+// entries API; clients of RFC 6962 logs request the rest of a range that is cut short
+const maxCTEntries = 100
+
+// ctSTH is the response of get-sth. The tree head signature is the signature of the log over its Trillian log
+// root, as in the signed tree head of the log info, rather than an RFC 6962 TreeHeadSignature.
+type ctSTH struct {
+	TreeSize          uint64 `json:"tree_size"`
+	Timestamp         uint64 `json:"timestamp"`
+	SHA256RootHash    []byte `json:"sha256_root_hash"`
+	TreeHeadSignature []byte `json:"tree_head_signature"`
+}
+
+// ctConsistency is the response of get-sth-consistency
+type ctConsistency struct {
+	Consistency [][]byte `json:"consistency"`
+}
+
+// ctInclusion is the response of get-proof-by-hash
+type ctInclusion struct {
+	LeafIndex int64    `json:"leaf_index"`
+	AuditPath [][]byte `json:"audit_path"`
+}
+
+// ctEntry is an entry of the response of get-entries; the leaf input is the canonicalized entry, which is
+// hashed into the tree in the same way as an RFC 6962 MerkleTreeLeaf, and there is no extra data
+type ctEntry struct {
+	LeafInput []byte `json:"leaf_input"`
+	ExtraData []byte `json:"extra_data"`
+}
+
+type ctEntries struct {
+	Entries []ctEntry `json:"entries"`
+}
+
+func ctGetSTH(tc TrillianClient) (*ctSTH, *apiError) {
+	resp := tc.getLatest(0)
+	if resp.status != codes.OK {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError}
+	}
+	slr := resp.getLatestResult.SignedLogRoot
+	root, err := tcrypto.VerifySignedLogRoot(tc.verifier.PubKey, tc.verifier.SigHash, slr)
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, err, trillianUnexpectedResult}
+	}
+	return &ctSTH{
+		TreeSize:          root.TreeSize,
+		Timestamp:         root.TimestampNanos / 1e6,
+		SHA256RootHash:    root.RootHash,
+		TreeHeadSignature: slr.GetLogRootSignature(),
+	}, nil
+}
+
+func ctGetSTHConsistency(tc TrillianClient, first, second int64) (*ctConsistency, *apiError) {
+	if first < 0 || second < first {
+		return nil, &apiError{http.StatusBadRequest, nil, fmt.Sprintf(firstSizeLessThanLastSize, first, second)}
+	}
+	// the empty tree and the tree itself are consistent with any tree without proof
+	if first == 0 || first == second {
+		return &ctConsistency{Consistency: [][]byte{}}, nil
+	}
+	resp := tc.getConsistencyProof(first, second)
+	if resp.status != codes.OK {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError}
+	}
+	proof := resp.getConsistencyProofResult.GetProof()
+	if proof == nil {
+		// as with the proofs of the log API, the proof is empty if the tree is smaller than second
+		var root types.LogRootV1
+		if err := root.UnmarshalBinary(resp.getConsistencyProofResult.GetSignedLogRoot().GetLogRoot()); err != nil {
+			return nil, &apiError{http.StatusInternalServerError, err, trillianUnexpectedResult}
+		}
+		return nil, &apiError{http.StatusBadRequest, nil, fmt.Sprintf(lastSizeGreaterThanKnown, second, root.TreeSize)}
+	}
+	return &ctConsistency{Consistency: append([][]byte{}, proof.Hashes...)}, nil
+}
+
+func ctGetProofByHash(tc TrillianClient, leafHash []byte, treeSize int64) (*ctInclusion, *apiError) {
+	if treeSize < 1 {
+		return nil, &apiError{http.StatusBadRequest, nil, invalidTreeSize}
+	}
+	root, err := tc.root()
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianCommunicationError}
+	}
+	if uint64(treeSize) > root.TreeSize {
+		return nil, &apiError{http.StatusBadRequest, nil, fmt.Sprintf(lastSizeGreaterThanKnown, treeSize, root.TreeSize)}
+	}
+	// the proof is checked against the root of the tree at the size requested
+	if uint64(treeSize) < root.TreeSize {
+		rootHash, err := tc.rootAt(treeSize)
+		if err != nil {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("computing root at size %v: %w", treeSize, err), trillianUnexpectedResult}
+		}
+		root = types.LogRootV1{TreeSize: uint64(treeSize), RootHash: rootHash}
+	}
+
+	resp := tc.getProofByHashAt(leafHash, root)
+	switch resp.status {
+	case codes.OK:
+	case codes.NotFound:
+		return nil, &apiError{http.StatusNotFound, resp.err, ""}
+	default:
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError}
+	}
+	proofs := resp.getProofResult.GetProof()
+	if len(proofs) == 0 {
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("no leaf with hash %x in tree of size %v", leafHash, treeSize), ""}
+	}
+	return &ctInclusion{
+		LeafIndex: proofs[0].LeafIndex,
+		AuditPath: append([][]byte{}, proofs[0].Hashes...),
+	}, nil
+}
+
+func ctGetEntries(tc TrillianClient, start, end int64) (*ctEntries, *apiError) {
+	if start < 0 {
+		return nil, &apiError{http.StatusBadRequest, nil, negativeLogIndex}
+	}
+	if end < start {
+		return nil, &apiError{http.StatusBadRequest, nil, fmt.Sprintf(endBeforeStart, end, start)}
+	}
+	count := end - start + 1
+	if count > maxCTEntries {
+		count = maxCTEntries
+	}
+
+	resp := tc.getLeavesByRange(start, count)
+	switch resp.status {
+	case codes.OK:
+	case codes.NotFound, codes.OutOfRange:
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), ""}
+	default:
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError}
+	}
+	leaves := resp.getLeafByRangeResult.GetLeaves()
+	if len(leaves) == 0 {
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("no leaves from index %v", start), ""}
+	}
+
+	result := &ctEntries{Entries: make([]ctEntry, 0, len(leaves))}
+	for i, leaf := range leaves {
+		if leaf.LeafIndex != start+int64(i) {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("leaf %v has index %v", start+int64(i), leaf.LeafIndex), trillianUnexpectedResult}
+		}
+		result.Entries = append(result.Entries, ctEntry{LeafInput: leaf.LeafValue, ExtraData: []byte{}})
+	}
+	return result, nil
+}
+
+// ServeCTAPI serves the read endpoints of RFC 6962 (get-sth, get-sth-consistency, get-proof-by-hash and
+// get-entries) under /ct/v1/, so that tooling written to monitor certificate transparency logs can follow the log
+func ServeCTAPI(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, ctPathPrefix) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		serveCT(NewTrillianClient(r.Context()), w, r)
+	})
+}
+
+func serveCT(tc TrillianClient, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		writeCTError(w, r, &apiError{http.StatusMethodNotAllowed, nil, http.StatusText(http.StatusMethodNotAllowed)})
+		return
+	}
+
+	query := r.URL.Query()
+	// every parameter of these endpoints but the leaf hash is an integer
+	var ints []int64
+	intParams := func(names ...string) *apiError {
+		ints = make([]int64, len(names))
+		for i, name := range names {
+			if query.Get(name) == "" {
+				return &apiError{http.StatusBadRequest, nil, fmt.Sprintf("%v is required", name)}
+			}
+			v, err := strconv.ParseInt(query.Get(name), 10, 64)
+			if err != nil {
+				return &apiError{http.StatusBadRequest, err, fmt.Sprintf("%v must be an integer", name)}
+			}
+			ints[i] = v
+		}
+		return nil
+	}
+
+	var result interface{}
+	var apiErr *apiError
+	switch strings.TrimPrefix(r.URL.Path, ctPathPrefix) {
+	case "get-sth":
+		result, apiErr = ctGetSTH(tc)
+	case "get-sth-consistency":
+		if apiErr = intParams("first", "second"); apiErr == nil {
+			result, apiErr = ctGetSTHConsistency(tc, ints[0], ints[1])
+		}
+	case "get-proof-by-hash":
+		if apiErr = intParams("tree_size"); apiErr == nil {
+			leafHash, err := base64.StdEncoding.DecodeString(query.Get("hash"))
+			if err != nil || len(leafHash) != sha256.Size {
+				apiErr = &apiError{http.StatusBadRequest, err, "hash must be a base64-encoded SHA256 leaf hash"}
+				break
+			}
+			result, apiErr = ctGetProofByHash(tc, leafHash, ints[0])
+		}
+	case "get-entries":
+		if apiErr = intParams("start", "end"); apiErr == nil {
+			result, apiErr = ctGetEntries(tc, ints[0], ints[1])
+		}
+	default:
+		apiErr = &apiError{http.StatusNotFound, nil, http.StatusText(http.StatusNotFound)}
+	}
+	if apiErr != nil {
+		writeCTError(w, r, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.RequestIDLogger(r).Error(err)
+	}
+}
+
+func writeCTError(w http.ResponseWriter, r *http.Request, apiErr *apiError) {
+	log.RequestIDLogger(r).Errorw("exiting with error", "path", r.URL.Path, "statusCode", apiErr.code, "clientMessage", apiErr.message, "error", apiErr.err)
+	message := apiErr.message
+	if message == "" {
+		message = http.StatusText(apiErr.code)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.code)
+	_ = json.NewEncoder(w).Encode(requestErrorMsg(r, message, apiErr.code))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	tclient "github.com/google/trillian/client"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+)
+
+func TestServeCT(t *testing.T) {
+	h := rfc6962.DefaultHasher
+	leafHashes := [][]byte{h.HashLeaf([]byte("a")), h.HashLeaf([]byte("b"))}
+	proofs := TrillianClient{
+		client:   proofLogClient{leafHashes: leafHashes},
+		context:  context.Background(),
+		verifier: tclient.NewLogVerifier(h, nil, crypto.SHA256),
+	}
+	leaves := TrillianClient{client: historyLogClient{leafHashes: append(leafHashes, h.HashLeaf([]byte("c")))}, context: context.Background()}
+
+	get := func(tc TrillianClient, method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		serveCT(tc, w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	w := get(proofs, http.MethodGet, "/ct/v1/get-proof-by-hash?tree_size=2&hash="+url.QueryEscape(base64.StdEncoding.EncodeToString(leafHashes[1])))
+	var inclusion ctInclusion
+	if err := json.Unmarshal(w.Body.Bytes(), &inclusion); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("get-proof-by-hash returned %v: %s", w.Code, w.Body.Bytes())
+	}
+	if inclusion.LeafIndex != 1 || len(inclusion.AuditPath) != 1 || !bytes.Equal(inclusion.AuditPath[0], leafHashes[0]) {
+		t.Errorf("unexpected inclusion proof %+v", inclusion)
+	}
+
+	w = get(leaves, http.MethodGet, "/ct/v1/get-entries?start=1&end=5")
+	var entries ctEntries
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("get-entries returned %v: %s", w.Code, w.Body.Bytes())
+	}
+	if len(entries.Entries) != 2 {
+		t.Errorf("expected the 2 entries from index 1, got %v", len(entries.Entries))
+	}
+
+	w = get(proofs, http.MethodGet, "/ct/v1/get-sth-consistency?first=0&second=2")
+	if w.Code != http.StatusOK || w.Body.String() != "{\"consistency\":[]}\n" {
+		t.Errorf("consistency with the empty tree returned %v: %s", w.Code, w.Body.Bytes())
+	}
+
+	tests := []struct {
+		caseDesc string
+		method   string
+		path     string
+		code     int
+	}{
+		{caseDesc: "tree size beyond the log", method: http.MethodGet, path: "/ct/v1/get-proof-by-hash?tree_size=3&hash=" + url.QueryEscape(base64.StdEncoding.EncodeToString(leafHashes[0])), code: http.StatusBadRequest},
+		{caseDesc: "hash not base64", method: http.MethodGet, path: "/ct/v1/get-proof-by-hash?tree_size=2&hash=zz", code: http.StatusBadRequest},
+		{caseDesc: "missing end", method: http.MethodGet, path: "/ct/v1/get-entries?start=0", code: http.StatusBadRequest},
+		{caseDesc: "end before start", method: http.MethodGet, path: "/ct/v1/get-entries?start=2&end=1", code: http.StatusBadRequest},
+		{caseDesc: "not an integer", method: http.MethodGet, path: "/ct/v1/get-entries?start=a&end=1", code: http.StatusBadRequest},
+		{caseDesc: "second before first", method: http.MethodGet, path: "/ct/v1/get-sth-consistency?first=2&second=1", code: http.StatusBadRequest},
+		{caseDesc: "write endpoint", method: http.MethodGet, path: "/ct/v1/add-chain", code: http.StatusNotFound},
+		{caseDesc: "post", method: http.MethodPost, path: "/ct/v1/get-sth", code: http.StatusMethodNotAllowed},
+	}
+	for _, tc := range tests {
+		if w := get(proofs, tc.method, tc.path); w.Code != tc.code {
+			t.Errorf("%v: got %v, expected %v: %s", tc.caseDesc, w.Code, tc.code, w.Body.Bytes())
+		}
+	}
+}
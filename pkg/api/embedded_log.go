@@ -0,0 +1,847 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/crypto/keyspb"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// embeddedLogAddress is the trillian_log_server.address that makes the server keep the log in memory instead of in
+// a Trillian log server
+const embeddedLogAddress = "embedded"
+
+// embeddedLogCurves are the curves of the keys the embedded log can sign tree heads with
+var embeddedLogCurves = map[keyspb.Specification_ECDSA_Curve]elliptic.Curve{
+	keyspb.Specification_ECDSA_P256: elliptic.P256(),
+	keyspb.Specification_ECDSA_P384: elliptic.P384(),
+	keyspb.Specification_ECDSA_P521: elliptic.P521(),
+}
+
+// embeddedLogStorage holds the trees of an embedded log: their leaves, the hashes of their complete subtrees and
+// their signed tree heads. It is only used by one embedded log at a time, under its lock. Trees that do not exist
+// are reported with NotFound status errors.
+type embeddedLogStorage interface {
+	// CreateTree stores a new tree along with the PKCS #8 encoded private key its tree heads are signed with
+	CreateTree(ctx context.Context, tree *trillian.Tree, key []byte) error
+	// UpdateTree replaces a stored tree, keeping its private key
+	UpdateTree(ctx context.Context, tree *trillian.Tree) error
+	Trees(ctx context.Context) ([]*trillian.Tree, error)
+	// Tree returns a tree along with its private key
+	Tree(ctx context.Context, id int64) (*trillian.Tree, []byte, error)
+	// Root returns the latest signed tree head of a tree, or nil if it is not initialised
+	Root(ctx context.Context, id int64) (*trillian.SignedLogRoot, error)
+	// LeafIndex returns the index of the leaf of a tree with the given Merkle leaf hash, or -1 if there is none
+	LeafIndex(ctx context.Context, id int64, hash []byte) (int64, error)
+	// Leaves returns up to count leaves of a tree starting at index start
+	Leaves(ctx context.Context, id, start, count int64) ([]*trillian.LogLeaf, error)
+	// Node returns the hash of the complete subtree of 2^level leaves of a tree starting at leaf index<<level
+	Node(ctx context.Context, id int64, level uint, index int64) ([]byte, error)
+	// Append stores the leaves appended to a tree along with the subtrees they complete and the tree head signing
+	// them, all at once
+	Append(ctx context.Context, id int64, leaves []*trillian.LogLeaf, nodes []treeNode, root *trillian.SignedLogRoot) error
+}
+
+// nodeID identifies the complete subtree of 2^level leaves starting at leaf index<<level
+type nodeID struct {
+	level uint
+	index int64
+}
+
+// treeNode is the hash of a complete subtree
+type treeNode struct {
+	nodeID
+	hash []byte
+}
+
+// embeddedLog serves the Trillian log and admin APIs in-process from its storage, so that the server can be run
+// without a Trillian log server and its database. Leaves are integrated as soon as they are queued.
+type embeddedLog struct {
+	mu      sync.Mutex
+	storage embeddedLogStorage
+	// signers caches the signer of each tree by its ID
+	signers map[int64]*tcrypto.Signer
+}
+
+func newEmbeddedLog(storage embeddedLogStorage) *embeddedLog {
+	return &embeddedLog{storage: storage, signers: map[int64]*tcrypto.Signer{}}
+}
+
+var (
+	_ trillian.TrillianLogClient   = &embeddedLog{}
+	_ trillian.TrillianAdminClient = &embeddedLog{}
+)
+
+func (e *embeddedLog) ListTrees(ctx context.Context, _ *trillian.ListTreesRequest, _ ...grpc.CallOption) (*trillian.ListTreesResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	trees, err := e.storage.Trees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.ListTreesResponse{Tree: trees}, nil
+}
+
+func (e *embeddedLog) GetTree(ctx context.Context, req *trillian.GetTreeRequest, _ ...grpc.CallOption) (*trillian.Tree, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	tree, _, err := e.storage.Tree(ctx, req.TreeId)
+	return tree, err
+}
+
+// CreateTree creates a log tree with a random ID, signed with a new key of the curve in the key specification
+func (e *embeddedLog) CreateTree(ctx context.Context, req *trillian.CreateTreeRequest, _ ...grpc.CallOption) (*trillian.Tree, error) {
+	if req.Tree == nil || req.Tree.TreeType != trillian.TreeType_LOG {
+		return nil, status.Error(codes.InvalidArgument, "the embedded log only holds log trees")
+	}
+	curve, ok := embeddedLogCurves[req.KeySpec.GetEcdsaParams().GetCurve()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported key specification %v", req.KeySpec)
+	}
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generating key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshaling public key: %v", err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "marshaling private key: %v", err)
+	}
+	id, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "generating tree ID: %v", err)
+	}
+
+	tree := proto.Clone(req.Tree).(*trillian.Tree)
+	tree.TreeId = id.Int64() + 1
+	tree.PublicKey = &keyspb.PublicKey{Der: der}
+	tree.CreateTime = timestamppb.Now()
+	tree.UpdateTime = tree.CreateTime
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.storage.CreateTree(ctx, tree, privateDER); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// UpdateTree changes the state of a tree between active and frozen, which are the only changes the embedded log
+// supports; frozen trees take no new leaves
+func (e *embeddedLog) UpdateTree(ctx context.Context, req *trillian.UpdateTreeRequest, _ ...grpc.CallOption) (*trillian.Tree, error) {
+	if req.Tree == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing tree")
+	}
+	for _, path := range req.UpdateMask.GetPaths() {
+		if path != "tree_state" {
+			return nil, status.Errorf(codes.InvalidArgument, "field %v of trees of the embedded log cannot be updated", path)
+		}
+	}
+	if state := req.Tree.TreeState; state != trillian.TreeState_ACTIVE && state != trillian.TreeState_FROZEN {
+		return nil, status.Errorf(codes.InvalidArgument, "trees of the embedded log cannot be in state %v", state)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	tree, _, err := e.storage.Tree(ctx, req.Tree.TreeId)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return tree, nil
+	}
+	tree.TreeState = req.Tree.TreeState
+	tree.UpdateTime = timestamppb.Now()
+	if err := e.storage.UpdateTree(ctx, tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func (e *embeddedLog) DeleteTree(context.Context, *trillian.DeleteTreeRequest, ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "trees of the embedded log cannot be deleted")
+}
+
+func (e *embeddedLog) UndeleteTree(context.Context, *trillian.UndeleteTreeRequest, ...grpc.CallOption) (*trillian.Tree, error) {
+	return nil, status.Error(codes.Unimplemented, "trees of the embedded log cannot be deleted")
+}
+
+func (e *embeddedLog) InitLog(ctx context.Context, req *trillian.InitLogRequest, _ ...grpc.CallOption) (*trillian.InitLogResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, err := e.storage.Root(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if root != nil {
+		return nil, status.Error(codes.AlreadyExists, "log is already initialised")
+	}
+	if root, err = e.sign(ctx, req.LogId, types.LogRootV1{}, 0, rfc6962.DefaultHasher.EmptyRoot()); err != nil {
+		return nil, err
+	}
+	if err := e.storage.Append(ctx, req.LogId, nil, nil, root); err != nil {
+		return nil, err
+	}
+	return &trillian.InitLogResponse{Created: root}, nil
+}
+
+func (e *embeddedLog) QueueLeaf(ctx context.Context, req *trillian.QueueLeafRequest, _ ...grpc.CallOption) (*trillian.QueueLeafResponse, error) {
+	resp, err := e.QueueLeaves(ctx, &trillian.QueueLeavesRequest{LogId: req.LogId, Leaves: []*trillian.LogLeaf{req.Leaf}})
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.QueueLeafResponse{QueuedLeaf: resp.QueuedLeaves[0]}, nil
+}
+
+// QueueLeaves integrates the leaves that are not in the tree yet under a single new tree head; leaves already in
+// the tree are returned as they were logged with the status AlreadyExists
+func (e *embeddedLog) QueueLeaves(ctx context.Context, req *trillian.QueueLeavesRequest, _ ...grpc.CallOption) (*trillian.QueueLeavesResponse, error) {
+	for _, leaf := range req.Leaves {
+		if leaf == nil {
+			return nil, status.Error(codes.InvalidArgument, "missing leaf")
+		}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	tree, _, err := e.storage.Tree(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if tree.TreeState == trillian.TreeState_FROZEN {
+		return nil, status.Errorf(codes.PermissionDenied, "tree %v is frozen", req.LogId)
+	}
+	_, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the subtrees completed by each leaf are hashed from those in storage and those completed by the leaves before it
+	var nodes []treeNode
+	completed := map[nodeID][]byte{}
+	node := nodeReader(func(level uint, index int64) ([]byte, error) {
+		if hash, ok := completed[nodeID{level, index}]; ok {
+			return hash, nil
+		}
+		return e.storage.Node(ctx, req.LogId, level, index)
+	})
+	complete := func(id nodeID, hash []byte) {
+		completed[id] = hash
+		nodes = append(nodes, treeNode{nodeID: id, hash: hash})
+	}
+
+	now := timestamppb.Now()
+	size := int64(head.TreeSize)
+	resp := &trillian.QueueLeavesResponse{}
+	var added []*trillian.LogLeaf
+	queued := map[string]*trillian.LogLeaf{}
+	for _, leaf := range req.Leaves {
+		hash := rfc6962.DefaultHasher.HashLeaf(leaf.LeafValue)
+		existing, err := e.leafByHash(ctx, req.LogId, hash)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			existing = queued[string(hash)]
+		}
+		if existing != nil {
+			resp.QueuedLeaves = append(resp.QueuedLeaves, &trillian.QueuedLogLeaf{
+				Leaf:   proto.Clone(existing).(*trillian.LogLeaf),
+				Status: &rpcstatus.Status{Code: int32(codes.AlreadyExists), Message: "leaf already exists"},
+			})
+			continue
+		}
+
+		logged := proto.Clone(leaf).(*trillian.LogLeaf)
+		logged.MerkleLeafHash = hash
+		if len(logged.LeafIdentityHash) == 0 {
+			logged.LeafIdentityHash = hash
+		}
+		logged.LeafIndex = size
+		logged.QueueTimestamp = now
+		logged.IntegrateTimestamp = now
+		subtree := hash
+		complete(nodeID{0, size}, subtree)
+		for level, index := uint(0), size; index&1 == 1; level, index = level+1, index>>1 {
+			left, err := node(level, index-1)
+			if err != nil {
+				return nil, err
+			}
+			subtree = rfc6962.DefaultHasher.HashChildren(left, subtree)
+			complete(nodeID{level + 1, index >> 1}, subtree)
+		}
+		size++
+		added = append(added, logged)
+		queued[string(hash)] = logged
+		resp.QueuedLeaves = append(resp.QueuedLeaves, &trillian.QueuedLogLeaf{Leaf: proto.Clone(logged).(*trillian.LogLeaf)})
+	}
+	if len(added) == 0 {
+		return resp, nil
+	}
+
+	rootHash, err := node.subtree(0, size)
+	if err != nil {
+		return nil, err
+	}
+	root, err := e.sign(ctx, req.LogId, head, size, rootHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := e.storage.Append(ctx, req.LogId, added, nodes, root); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (e *embeddedLog) AddSequencedLeaf(context.Context, *trillian.AddSequencedLeafRequest, ...grpc.CallOption) (*trillian.AddSequencedLeafResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "the embedded log does not hold pre-ordered logs")
+}
+
+func (e *embeddedLog) AddSequencedLeaves(context.Context, *trillian.AddSequencedLeavesRequest, ...grpc.CallOption) (*trillian.AddSequencedLeavesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "the embedded log does not hold pre-ordered logs")
+}
+
+func (e *embeddedLog) GetInclusionProof(ctx context.Context, req *trillian.GetInclusionProofRequest, _ ...grpc.CallOption) (*trillian.GetInclusionProofResponse, error) {
+	if err := checkLeafIndex(req.LeafIndex, req.TreeSize); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetInclusionProofResponse{SignedLogRoot: root}
+	if resp.Proof, err = e.inclusionProof(ctx, req.LogId, head, req.LeafIndex, req.TreeSize); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetInclusionProofByHash returns NotFound unless the leaf is in the tree of the requested size
+func (e *embeddedLog) GetInclusionProofByHash(ctx context.Context, req *trillian.GetInclusionProofByHashRequest, _ ...grpc.CallOption) (*trillian.GetInclusionProofByHashResponse, error) {
+	if req.TreeSize <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "tree size %v, want > 0", req.TreeSize)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	index, err := e.storage.LeafIndex(ctx, req.LogId, req.LeafHash)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= req.TreeSize || req.TreeSize > int64(head.TreeSize) {
+		return nil, status.Errorf(codes.NotFound, "no leaf found for hash %x in tree size %v", req.LeafHash, req.TreeSize)
+	}
+	proof, err := e.inclusionProof(ctx, req.LogId, head, index, req.TreeSize)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetInclusionProofByHashResponse{Proof: []*trillian.Proof{proof}, SignedLogRoot: root}, nil
+}
+
+func (e *embeddedLog) GetConsistencyProof(ctx context.Context, req *trillian.GetConsistencyProofRequest, _ ...grpc.CallOption) (*trillian.GetConsistencyProofResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetConsistencyProofResponse{SignedLogRoot: root}
+	if resp.Proof, err = e.consistencyProof(ctx, req.LogId, head, req.FirstTreeSize, req.SecondTreeSize); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLatestSignedLogRoot also returns the proof of consistency of the tree with its earlier size FirstTreeSize,
+// unless it is 0
+func (e *embeddedLog) GetLatestSignedLogRoot(ctx context.Context, req *trillian.GetLatestSignedLogRootRequest, _ ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: root}
+	if req.FirstTreeSize != 0 {
+		if resp.Proof, err = e.consistencyProof(ctx, req.LogId, head, req.FirstTreeSize, int64(head.TreeSize)); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (e *embeddedLog) GetSequencedLeafCount(ctx context.Context, req *trillian.GetSequencedLeafCountRequest, _ ...grpc.CallOption) (*trillian.GetSequencedLeafCountResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetSequencedLeafCountResponse{LeafCount: int64(head.TreeSize)}, nil
+}
+
+func (e *embeddedLog) GetEntryAndProof(ctx context.Context, req *trillian.GetEntryAndProofRequest, _ ...grpc.CallOption) (*trillian.GetEntryAndProofResponse, error) {
+	if err := checkLeafIndex(req.LeafIndex, req.TreeSize); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	if req.TreeSize > int64(head.TreeSize) {
+		return nil, status.Errorf(codes.NotFound, "tree is smaller than %v", req.TreeSize)
+	}
+	leaves, err := e.storage.Leaves(ctx, req.LogId, req.LeafIndex, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) != 1 {
+		return nil, status.Errorf(codes.Internal, "missing leaf %v", req.LeafIndex)
+	}
+	proof, err := e.inclusionProof(ctx, req.LogId, head, req.LeafIndex, req.TreeSize)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetEntryAndProofResponse{Proof: proof, Leaf: leaves[0], SignedLogRoot: root}, nil
+}
+
+func (e *embeddedLog) GetLeavesByIndex(ctx context.Context, req *trillian.GetLeavesByIndexRequest, _ ...grpc.CallOption) (*trillian.GetLeavesByIndexResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, head, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLeavesByIndexResponse{SignedLogRoot: root}
+	for _, index := range req.LeafIndex {
+		if index < 0 || index >= int64(head.TreeSize) {
+			return nil, status.Errorf(codes.OutOfRange, "no leaf at index %v", index)
+		}
+		leaves, err := e.storage.Leaves(ctx, req.LogId, index, 1)
+		if err != nil {
+			return nil, err
+		}
+		resp.Leaves = append(resp.Leaves, leaves...)
+	}
+	return resp, nil
+}
+
+// GetLeavesByRange returns fewer than Count leaves if the tree ends before the range does, and none if it starts
+// after the tree ends
+func (e *embeddedLog) GetLeavesByRange(ctx context.Context, req *trillian.GetLeavesByRangeRequest, _ ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	if req.StartIndex < 0 || req.Count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid range of %v leaves from index %v", req.Count, req.StartIndex)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, _, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLeavesByRangeResponse{SignedLogRoot: root}
+	if resp.Leaves, err = e.storage.Leaves(ctx, req.LogId, req.StartIndex, req.Count); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetLeavesByHash returns the leaves found in the order of the hashes requested, skipping those that are not in the
+// tree
+func (e *embeddedLog) GetLeavesByHash(ctx context.Context, req *trillian.GetLeavesByHashRequest, _ ...grpc.CallOption) (*trillian.GetLeavesByHashResponse, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	root, _, err := e.head(ctx, req.LogId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &trillian.GetLeavesByHashResponse{SignedLogRoot: root}
+	for _, hash := range req.LeafHash {
+		leaf, err := e.leafByHash(ctx, req.LogId, hash)
+		if err != nil {
+			return nil, err
+		}
+		if leaf != nil {
+			resp.Leaves = append(resp.Leaves, leaf)
+		}
+	}
+	return resp, nil
+}
+
+// head returns the latest signed tree head of an initialised tree along with the root it signs
+func (e *embeddedLog) head(ctx context.Context, id int64) (*trillian.SignedLogRoot, types.LogRootV1, error) {
+	root, err := e.storage.Root(ctx, id)
+	if err != nil {
+		return nil, types.LogRootV1{}, err
+	}
+	if root == nil {
+		return nil, types.LogRootV1{}, status.Errorf(codes.FailedPrecondition, "log %v is not initialised", id)
+	}
+	var head types.LogRootV1
+	if err := head.UnmarshalBinary(root.LogRoot); err != nil {
+		return nil, types.LogRootV1{}, status.Errorf(codes.Internal, "reading tree head: %v", err)
+	}
+	return root, head, nil
+}
+
+// leafByHash returns the leaf with the given Merkle leaf hash, or nil if the tree does not hold it
+func (e *embeddedLog) leafByHash(ctx context.Context, id int64, hash []byte) (*trillian.LogLeaf, error) {
+	index, err := e.storage.LeafIndex(ctx, id, hash)
+	if err != nil || index < 0 {
+		return nil, err
+	}
+	leaves, err := e.storage.Leaves(ctx, id, index, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) != 1 {
+		return nil, status.Errorf(codes.Internal, "missing leaf %v", index)
+	}
+	return leaves[0], nil
+}
+
+// sign signs a tree head for a tree of size leaves, with a timestamp later than that of the previous tree head so
+// that clients waiting for the tree to grow take it as an update
+func (e *embeddedLog) sign(ctx context.Context, id int64, previous types.LogRootV1, size int64, rootHash []byte) (*trillian.SignedLogRoot, error) {
+	signer, ok := e.signers[id]
+	if !ok {
+		_, der, err := e.storage.Tree(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "parsing private key of tree %v: %v", id, err)
+		}
+		cryptoSigner, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, status.Errorf(codes.Internal, "private key of tree %v cannot sign", id)
+		}
+		signer = tcrypto.NewSigner(id, cryptoSigner, crypto.SHA256)
+		e.signers[id] = signer
+	}
+
+	timestamp := uint64(time.Now().UnixNano())
+	if timestamp <= previous.TimestampNanos {
+		timestamp = previous.TimestampNanos + 1
+	}
+	root, err := signer.SignLogRoot(&types.LogRootV1{
+		TreeSize:       uint64(size),
+		RootHash:       rootHash,
+		TimestampNanos: timestamp,
+		Revision:       previous.Revision + 1,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "signing tree head: %v", err)
+	}
+	return root, nil
+}
+
+func (e *embeddedLog) nodes(ctx context.Context, id int64) nodeReader {
+	return func(level uint, index int64) ([]byte, error) {
+		return e.storage.Node(ctx, id, level, index)
+	}
+}
+
+// checkLeafIndex validates the leaf index and tree size of a request for an inclusion proof as Trillian does, which
+// accepts sizes larger than that of the tree
+func checkLeafIndex(index, size int64) error {
+	if size <= 0 {
+		return status.Errorf(codes.InvalidArgument, "tree size %v, want > 0", size)
+	}
+	if index < 0 || index >= size {
+		return status.Errorf(codes.InvalidArgument, "leaf index %v is not in a tree of size %v", index, size)
+	}
+	return nil
+}
+
+// inclusionProof returns the proof of inclusion of the leaf at index in the tree when it had size leaves, or nil if
+// the tree is not that large yet
+func (e *embeddedLog) inclusionProof(ctx context.Context, id int64, head types.LogRootV1, index, size int64) (*trillian.Proof, error) {
+	if size > int64(head.TreeSize) {
+		return nil, nil
+	}
+	hashes, err := e.nodes(ctx, id).inclusionPath(index, 0, size)
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.Proof{LeafIndex: index, Hashes: hashes}, nil
+}
+
+// consistencyProof returns the proof of consistency of the tree when it had second leaves with the tree when it had
+// first leaves, or nil if the tree is not second leaves large yet
+func (e *embeddedLog) consistencyProof(ctx context.Context, id int64, head types.LogRootV1, first, second int64) (*trillian.Proof, error) {
+	if first <= 0 || second < first {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tree sizes %v and %v for a consistency proof", first, second)
+	}
+	if second > int64(head.TreeSize) {
+		return nil, nil
+	}
+	proof := &trillian.Proof{}
+	if first < second {
+		var err error
+		if proof.Hashes, err = e.nodes(ctx, id).consistencyPath(first, 0, second, true); err != nil {
+			return nil, err
+		}
+	}
+	return proof, nil
+}
+
+// nodeReader returns the hash of the complete subtree of 2^level leaves of a tree starting at leaf index<<level. The
+// RFC 6962 hashes and proofs of the tree are computed from those of its complete subtrees, as the left subtree of
+// every split of a range of leaves is complete.
+type nodeReader func(level uint, index int64) ([]byte, error)
+
+// splitPoint returns the largest power of two smaller than n, which is greater than 1, where RFC 6962 splits a tree
+// of n leaves into subtrees
+func splitPoint(n int64) int64 {
+	k := int64(1)
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// subtree returns the hash of the leaves from lo to hi, a range of a split of the tree
+func (node nodeReader) subtree(lo, hi int64) ([]byte, error) {
+	n := hi - lo
+	switch {
+	case n == 0:
+		return rfc6962.DefaultHasher.EmptyRoot(), nil
+	case n&(n-1) == 0:
+		level := uint(bits.TrailingZeros64(uint64(n)))
+		return node(level, lo>>level)
+	}
+	k := splitPoint(n)
+	left, err := node.subtree(lo, lo+k)
+	if err != nil {
+		return nil, err
+	}
+	right, err := node.subtree(lo+k, hi)
+	if err != nil {
+		return nil, err
+	}
+	return rfc6962.DefaultHasher.HashChildren(left, right), nil
+}
+
+// inclusionPath returns the RFC 6962 audit path of the leaf at index in the subtree of the leaves from lo to hi
+func (node nodeReader) inclusionPath(index, lo, hi int64) ([][]byte, error) {
+	if hi-lo <= 1 {
+		return nil, nil
+	}
+	k := splitPoint(hi - lo)
+	var path [][]byte
+	var sibling []byte
+	var err error
+	if index < lo+k {
+		if path, err = node.inclusionPath(index, lo, lo+k); err == nil {
+			sibling, err = node.subtree(lo+k, hi)
+		}
+	} else {
+		if path, err = node.inclusionPath(index, lo+k, hi); err == nil {
+			sibling, err = node.subtree(lo, lo+k)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(path, sibling), nil
+}
+
+// consistencyPath returns the RFC 6962 consistency proof of the subtree of the leaves from lo to hi with its first
+// size leaves; complete is whether the subtree of those leaves is one whose hash the verifier already knows
+func (node nodeReader) consistencyPath(size, lo, hi int64, complete bool) ([][]byte, error) {
+	if size == hi-lo {
+		if complete {
+			return nil, nil
+		}
+		hash, err := node.subtree(lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{hash}, nil
+	}
+	k := splitPoint(hi - lo)
+	var path [][]byte
+	var sibling []byte
+	var err error
+	if size <= k {
+		if path, err = node.consistencyPath(size, lo, lo+k, complete); err == nil {
+			sibling, err = node.subtree(lo+k, hi)
+		}
+	} else {
+		if path, err = node.consistencyPath(size-k, lo+k, hi, false); err == nil {
+			sibling, err = node.subtree(lo, lo+k)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(path, sibling), nil
+}
+
+// memoryLogStorage keeps the trees of an embedded log in memory, so that they are lost when the server stops
+type memoryLogStorage struct {
+	trees map[int64]*memoryTree
+}
+
+type memoryTree struct {
+	tree   *trillian.Tree
+	key    []byte
+	root   *trillian.SignedLogRoot
+	leaves []*trillian.LogLeaf
+	// byHash holds the index of each leaf by its Merkle leaf hash
+	byHash map[string]int64
+	nodes  map[nodeID][]byte
+}
+
+func newMemoryLogStorage() *memoryLogStorage {
+	return &memoryLogStorage{trees: map[int64]*memoryTree{}}
+}
+
+func (m *memoryLogStorage) CreateTree(_ context.Context, tree *trillian.Tree, key []byte) error {
+	if _, ok := m.trees[tree.TreeId]; ok {
+		return status.Errorf(codes.AlreadyExists, "tree %v already exists", tree.TreeId)
+	}
+	m.trees[tree.TreeId] = &memoryTree{
+		tree:   proto.Clone(tree).(*trillian.Tree),
+		key:    key,
+		byHash: map[string]int64{},
+		nodes:  map[nodeID][]byte{},
+	}
+	return nil
+}
+
+func (m *memoryLogStorage) UpdateTree(_ context.Context, tree *trillian.Tree) error {
+	t, err := m.tree(tree.TreeId)
+	if err != nil {
+		return err
+	}
+	t.tree = proto.Clone(tree).(*trillian.Tree)
+	return nil
+}
+
+func (m *memoryLogStorage) Trees(context.Context) ([]*trillian.Tree, error) {
+	var trees []*trillian.Tree
+	for _, t := range m.trees {
+		trees = append(trees, proto.Clone(t.tree).(*trillian.Tree))
+	}
+	return trees, nil
+}
+
+func (m *memoryLogStorage) Tree(_ context.Context, id int64) (*trillian.Tree, []byte, error) {
+	t, err := m.tree(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proto.Clone(t.tree).(*trillian.Tree), t.key, nil
+}
+
+func (m *memoryLogStorage) Root(_ context.Context, id int64) (*trillian.SignedLogRoot, error) {
+	t, err := m.tree(id)
+	if err != nil || t.root == nil {
+		return nil, err
+	}
+	return proto.Clone(t.root).(*trillian.SignedLogRoot), nil
+}
+
+func (m *memoryLogStorage) LeafIndex(_ context.Context, id int64, hash []byte) (int64, error) {
+	t, err := m.tree(id)
+	if err != nil {
+		return 0, err
+	}
+	if index, ok := t.byHash[string(hash)]; ok {
+		return index, nil
+	}
+	return -1, nil
+}
+
+func (m *memoryLogStorage) Leaves(_ context.Context, id, start, count int64) ([]*trillian.LogLeaf, error) {
+	t, err := m.tree(id)
+	if err != nil {
+		return nil, err
+	}
+	var leaves []*trillian.LogLeaf
+	for i := start; i < int64(len(t.leaves)) && i-start < count; i++ {
+		leaves = append(leaves, proto.Clone(t.leaves[i]).(*trillian.LogLeaf))
+	}
+	return leaves, nil
+}
+
+func (m *memoryLogStorage) Node(_ context.Context, id int64, level uint, index int64) ([]byte, error) {
+	t, err := m.tree(id)
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := t.nodes[nodeID{level, index}]
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "missing node %v at level %v of tree %v", index, level, id)
+	}
+	return hash, nil
+}
+
+func (m *memoryLogStorage) Append(_ context.Context, id int64, leaves []*trillian.LogLeaf, nodes []treeNode, root *trillian.SignedLogRoot) error {
+	t, err := m.tree(id)
+	if err != nil {
+		return err
+	}
+	for _, leaf := range leaves {
+		t.byHash[string(leaf.MerkleLeafHash)] = leaf.LeafIndex
+		t.leaves = append(t.leaves, proto.Clone(leaf).(*trillian.LogLeaf))
+	}
+	for _, n := range nodes {
+		t.nodes[n.nodeID] = n.hash
+	}
+	t.root = proto.Clone(root).(*trillian.SignedLogRoot)
+	return nil
+}
+
+func (m *memoryLogStorage) tree(id int64) (*memoryTree, error) {
+	t, ok := m.trees[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "tree %v not found", id)
+	}
+	return t, nil
+}
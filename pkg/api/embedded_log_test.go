@@ -0,0 +1,184 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+)
+
+func newEmbeddedTrillianClient(t *testing.T, storage embeddedLogStorage) TrillianClient {
+	t.Helper()
+	ctx := context.Background()
+	embedded := newEmbeddedLog(storage)
+	tree, err := createAndInitTree(ctx, embedded, embedded, "P-256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := newTreeAPI(ctx, embedded, embedded, tree.TreeId, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return TrillianClient{
+		client:   a.logClient,
+		logID:    a.logID,
+		context:  ctx,
+		pubkey:   a.pubkey,
+		verifier: a.verifier,
+		keys:     a.keys,
+	}
+}
+
+func TestEmbeddedLog(t *testing.T) {
+	testEmbeddedLog(t, newEmbeddedTrillianClient(t, newMemoryLogStorage()))
+}
+
+// testEmbeddedLog logs 11 leaves in an empty tree and checks the proofs the log serves for them
+func testEmbeddedLog(t *testing.T, tc TrillianClient) {
+	t.Helper()
+	// leaves are integrated as they are queued, one at a time or in batches
+	for i := 0; i < 5; i++ {
+		resp := tc.addLeaf([]byte(fmt.Sprintf("leaf %v", i)))
+		if resp.err != nil {
+			t.Fatalf("unexpected error adding leaf %v: %v", i, resp.err)
+		}
+		if leaf := resp.getAddResult.QueuedLeaf.Leaf; leaf.LeafIndex != int64(i) || leaf.IntegrateTimestamp == nil {
+			t.Errorf("unexpected leaf %v: %v", i, leaf)
+		}
+	}
+	var batch [][]byte
+	for i := 5; i < 11; i++ {
+		batch = append(batch, []byte(fmt.Sprintf("leaf %v", i)))
+	}
+	resp := tc.addLeaves(batch)
+	if resp.err != nil {
+		t.Fatalf("unexpected error adding leaves: %v", resp.err)
+	}
+	for i, queued := range resp.getAddLeavesResult.QueuedLeaves {
+		if queued.Leaf.LeafIndex != int64(i+5) {
+			t.Errorf("unexpected index of leaf %v: %v", i+5, queued.Leaf.LeafIndex)
+		}
+	}
+
+	// a leaf that is already logged is returned with its index
+	resp = tc.addLeaf([]byte("leaf 3"))
+	if resp.err != nil {
+		t.Fatalf("unexpected error adding existing leaf: %v", resp.err)
+	}
+	if queued := resp.getAddResult.QueuedLeaf; queued.Status.GetCode() != int32(codes.AlreadyExists) || queued.Leaf.LeafIndex != 3 {
+		t.Errorf("unexpected result adding existing leaf: %v", queued)
+	}
+
+	root, err := tc.root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.TreeSize != 11 {
+		t.Fatalf("unexpected tree size %v", root.TreeSize)
+	}
+
+	// inclusion proofs are verified by the client against the signed tree head
+	for i := 0; i < 11; i++ {
+		resp := tc.getProofByHash(rfc6962.DefaultHasher.HashLeaf([]byte(fmt.Sprintf("leaf %v", i))))
+		if resp.err != nil {
+			t.Errorf("unexpected error getting proof of leaf %v: %v", i, resp.err)
+		}
+	}
+	if resp := tc.getProofByHash(rfc6962.DefaultHasher.HashLeaf([]byte("missing"))); resp.status != codes.NotFound {
+		t.Errorf("unexpected status getting proof of missing leaf: %v", resp.status)
+	}
+
+	// earlier roots are consistent with the latest one
+	v := logverifier.New(rfc6962.DefaultHasher)
+	for size := int64(1); size <= 11; size++ {
+		old, err := tc.rootAt(size)
+		if err != nil {
+			t.Fatalf("unexpected error getting root at size %v: %v", size, err)
+		}
+		resp := tc.getConsistencyProof(size, 11)
+		if resp.err != nil {
+			t.Fatalf("unexpected error getting consistency proof from size %v: %v", size, resp.err)
+		}
+		if err := v.VerifyConsistencyProof(size, 11, old, root.RootHash, resp.getConsistencyProofResult.Proof.Hashes); err != nil {
+			t.Errorf("invalid consistency proof from size %v: %v", size, err)
+		}
+	}
+	if resp := tc.getConsistencyProof(5, 12); resp.err != nil || resp.getConsistencyProofResult.Proof != nil {
+		t.Errorf("unexpected result getting consistency proof with a tree larger than the log: %v", resp.err)
+	}
+
+	// the latest tree head proves its consistency with the one given
+	latest := tc.getLatest(4)
+	if latest.err != nil {
+		t.Fatal(latest.err)
+	}
+	old, err := tc.rootAt(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tc.verifier.VerifyRoot(&types.LogRootV1{TreeSize: 4, RootHash: old}, latest.getLatestResult.SignedLogRoot, latest.getLatestResult.Proof.Hashes); err != nil {
+		t.Errorf("unexpected error verifying latest tree head: %v", err)
+	}
+
+	// leaves are read back by range and by hash
+	leaves := tc.getLeavesByRange(9, 5)
+	if leaves.err != nil || len(leaves.getLeafByRangeResult.Leaves) != 2 {
+		t.Errorf("unexpected leaves at the end of the tree: %v", leaves.err)
+	}
+	byHash := tc.getLeafByHash([][]byte{rfc6962.DefaultHasher.HashLeaf([]byte("leaf 7"))})
+	if byHash.err != nil || len(byHash.getLeafResult.Leaves) != 1 || !bytes.Equal(byHash.getLeafResult.Leaves[0].LeafValue, []byte("leaf 7")) {
+		t.Errorf("unexpected leaves by hash: %v", byHash.err)
+	}
+}
+
+func TestEmbeddedLogTrees(t *testing.T) {
+	ctx := context.Background()
+	embedded := newEmbeddedLog(newMemoryLogStorage())
+
+	first, err := createAndInitTree(ctx, embedded, embedded, "P-384")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the tree that exists is used rather than creating another
+	second, err := createAndInitTree(ctx, embedded, embedded, "P-384")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.TreeId != second.TreeId {
+		t.Errorf("unexpected new tree %v after %v", second.TreeId, first.TreeId)
+	}
+	if _, err := newTreeAPI(ctx, embedded, embedded, first.TreeId+1, nil); err == nil {
+		t.Error("expected error for unknown tree")
+	}
+}
+
+func TestSQLiteLogWithoutDriver(t *testing.T) {
+	if hasSQLDriver(sqliteDriver) {
+		t.Skip("built with the SQLite driver")
+	}
+	if _, err := openSQLiteLogStorage(context.Background(), filepath.Join(t.TempDir(), "log.db")); err == nil {
+		t.Error("expected error opening SQLite log storage without the driver")
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/protobuf"
+)
+
+// The CBOR form of an entry has the fields of its JSON form, except that the body, hashes and the fields of the
+// signed tree head are byte strings rather than base64 or hex encoded text
+type cborLogEntry struct {
+	Body           []byte            `cbor:"body"`
+	IntegratedTime int64             `cbor:"integratedTime,omitempty"`
+	LogIndex       int64             `cbor:"logIndex"`
+	Verification   *cborVerification `cbor:"verification,omitempty"`
+}
+
+type cborVerification struct {
+	InclusionProof *cborInclusionProof `cbor:"inclusionProof,omitempty"`
+	SignedTreeHead *cborSignedTreeHead `cbor:"signedTreeHead,omitempty"`
+}
+
+type cborInclusionProof struct {
+	LogIndex int64    `cbor:"logIndex"`
+	RootHash []byte   `cbor:"rootHash"`
+	TreeSize int64    `cbor:"treeSize"`
+	Hashes   [][]byte `cbor:"hashes"`
+}
+
+type cborSignedTreeHead struct {
+	KeyHint   []byte `cbor:"keyHint"`
+	LogRoot   []byte `cbor:"logRoot"`
+	Signature []byte `cbor:"signature"`
+}
+
+type cborError struct {
+	Code      int64  `cbor:"code"`
+	Message   string `cbor:"message"`
+	Type      string `cbor:"type,omitempty"`
+	Field     string `cbor:"field,omitempty"`
+	RequestID string `cbor:"requestId,omitempty"`
+}
+
+// cborEncMode encodes maps with their keys sorted, so that an entry is always encoded the same way
+var cborEncMode, _ = cbor.CoreDetEncOptions().EncMode()
+
+func cborLogEntries(logEntry models.LogEntry) (map[string]cborLogEntry, error) {
+	result := make(map[string]cborLogEntry, len(logEntry))
+	for uuid, entry := range logEntry {
+		body, _ := entry.Body.([]byte)
+		e := cborLogEntry{Body: body, IntegratedTime: entry.IntegratedTime, LogIndex: swag.Int64Value(entry.LogIndex)}
+		if v := entry.Verification; v != nil {
+			e.Verification = &cborVerification{}
+			if p := v.InclusionProof; p != nil {
+				rootHash, err := hex.DecodeString(swag.StringValue(p.RootHash))
+				if err != nil {
+					return nil, err
+				}
+				proof := &cborInclusionProof{LogIndex: swag.Int64Value(p.LogIndex), RootHash: rootHash, TreeSize: swag.Int64Value(p.TreeSize), Hashes: [][]byte{}}
+				for _, h := range p.Hashes {
+					hash, err := hex.DecodeString(h)
+					if err != nil {
+						return nil, err
+					}
+					proof.Hashes = append(proof.Hashes, hash)
+				}
+				e.Verification.InclusionProof = proof
+			}
+			if sth := v.SignedTreeHead; sth != nil {
+				e.Verification.SignedTreeHead = &cborSignedTreeHead{}
+				if sth.KeyHint != nil {
+					e.Verification.SignedTreeHead.KeyHint = *sth.KeyHint
+				}
+				if sth.LogRoot != nil {
+					e.Verification.SignedTreeHead.LogRoot = *sth.LogRoot
+				}
+				if sth.Signature != nil {
+					e.Verification.SignedTreeHead.Signature = *sth.Signature
+				}
+			}
+		}
+		result[uuid] = e
+	}
+	return result, nil
+}
+
+// CborProducer encodes the entries and errors returned by the REST API as deterministic CBOR
+func CborProducer() runtime.Producer {
+	return runtime.ProducerFunc(func(w io.Writer, v interface{}) error {
+		// the generated models are binary marshalers, which CBOR would encode as byte strings holding their JSON
+		var value interface{}
+		switch v := v.(type) {
+		case models.LogEntry:
+			entries, err := cborLogEntries(v)
+			if err != nil {
+				return err
+			}
+			value = entries
+		case *models.Error:
+			value = cborError{Code: v.Code, Message: v.Message, Type: v.Type, Field: v.Field, RequestID: v.RequestID}
+		default:
+			return fmt.Errorf("%T cannot be encoded as CBOR", v)
+		}
+		return cborEncMode.NewEncoder(w).Encode(value)
+	})
+}
+
+// ProtobufProducer encodes the entries and errors returned by the REST API as the LogEntry and Error messages
+// of the gRPC API
+func ProtobufProducer() runtime.Producer {
+	return runtime.ProducerFunc(func(w io.Writer, v interface{}) error {
+		var m proto.Message
+		switch v := v.(type) {
+		case models.LogEntry:
+			m = protoLogEntry(v)
+		case *models.Error:
+			m = &protobuf.Error{Code: v.Code, Message: v.Message, Type: v.Type, RequestId: v.RequestID}
+		default:
+			return fmt.Errorf("%T cannot be encoded as protobuf", v)
+		}
+		b, err := proto.Marshal(m)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	})
+}
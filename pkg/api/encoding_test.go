@@ -0,0 +1,100 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-openapi/swag"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/protobuf"
+)
+
+var testLogEntry = models.LogEntry{
+	"abcd": models.LogEntryAnon{
+		LogIndex:       swag.Int64(3),
+		Body:           []byte("{}"),
+		IntegratedTime: 1600000000,
+		Verification: &models.LogEntryAnonVerification{
+			InclusionProof: &models.InclusionProof{LogIndex: swag.Int64(3), TreeSize: swag.Int64(4), RootHash: swag.String("ef"), Hashes: []string{"01", "02"}},
+		},
+	},
+}
+
+func TestProtobufProducer(t *testing.T) {
+	var b bytes.Buffer
+	if err := ProtobufProducer().Produce(&b, testLogEntry); err != nil {
+		t.Fatal(err)
+	}
+	var entry protobuf.LogEntry
+	if err := proto.Unmarshal(b.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Uuid != "abcd" || entry.LogIndex != 3 || string(entry.Body) != "{}" || entry.InclusionProof.GetTreeSize() != 4 || len(entry.InclusionProof.GetHashes()) != 2 {
+		t.Errorf("unexpected entry %v", &entry)
+	}
+
+	b.Reset()
+	if err := ProtobufProducer().Produce(&b, &models.Error{Code: 404, Message: "missing", RequestID: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+	var pbErr protobuf.Error
+	if err := proto.Unmarshal(b.Bytes(), &pbErr); err != nil || pbErr.Code != 404 || pbErr.RequestId != "abc" {
+		t.Errorf("unexpected error %v, %v", &pbErr, err)
+	}
+
+	if err := ProtobufProducer().Produce(&b, "text"); err == nil {
+		t.Error("expected error encoding a string as protobuf")
+	}
+}
+
+func TestCborProducer(t *testing.T) {
+	var b bytes.Buffer
+	if err := CborProducer().Produce(&b, testLogEntry); err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]cborLogEntry
+	if err := cbor.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	e := decoded["abcd"]
+	if string(e.Body) != "{}" || e.LogIndex != 3 || e.Verification == nil || e.Verification.InclusionProof == nil {
+		t.Fatalf("unexpected CBOR entry %+v", e)
+	}
+	if p := e.Verification.InclusionProof; !bytes.Equal(p.RootHash, []byte{0xef}) || len(p.Hashes) != 2 || !bytes.Equal(p.Hashes[1], []byte{0x02}) {
+		t.Errorf("unexpected CBOR inclusion proof %+v", p)
+	}
+
+	// entries are always encoded the same way
+	var again bytes.Buffer
+	if err := CborProducer().Produce(&again, testLogEntry); err != nil || !bytes.Equal(b.Bytes(), again.Bytes()) {
+		t.Errorf("entry encoded differently: %v", err)
+	}
+
+	b.Reset()
+	if err := CborProducer().Produce(&b, &models.Error{Code: 404, Message: "missing"}); err != nil {
+		t.Fatal(err)
+	}
+	var cErr cborError
+	if err := cbor.Unmarshal(b.Bytes(), &cErr); err != nil || cErr.Code != 404 || cErr.Message != "missing" {
+		t.Errorf("unexpected error %+v, %v", cErr, err)
+	}
+}
@@ -18,12 +18,17 @@ package api
 import (
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/trillian"
 	"github.com/spf13/viper"
@@ -35,7 +40,11 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	pkix509 "github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/storage"
 	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 
@@ -44,26 +53,47 @@ import (
 	tclient "github.com/google/trillian/client"
 	tcrypto "github.com/google/trillian/crypto"
 	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	ttypes "github.com/google/trillian/types"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
 )
 
 func GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middleware.Responder {
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+	ctx := params.HTTPRequest.Context()
+	logEntry, apiErr := logEntryByIndex(ctx, params.LogIndex)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	if swag.BoolValue(params.IncludeProof) {
+		if err := addInclusionProofs(NewTrillianClient(ctx), []models.LogEntry{logEntry}); err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianUnexpectedResult)
+		}
+		// the proof changes as the log grows, so the response has no validator
+		return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry)
+	}
+	etag := entryETag(logEntry)
+	if etagMatches(params.IfNoneMatch, etag) {
+		return entries.NewGetLogEntryByIndexNotModified().WithETag(etag)
+	}
+	return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry).WithETag(etag)
+}
 
-	resp := tc.getLeafByIndex(params.LogIndex)
+func logEntryByIndex(ctx context.Context, index int64) (models.LogEntry, *apiError) {
+	tc := NewTrillianClient(ctx)
+
+	resp := tc.getLogLeafByIndex(index)
 	switch resp.status {
 	case codes.OK:
 	case codes.NotFound, codes.OutOfRange:
-		return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), "")
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), ""}
 	default:
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc err: %w", resp.err), trillianCommunicationError)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc err: %w", resp.err), trillianCommunicationError}
 	}
 
 	leaves := resp.getLeafByRangeResult.GetLeaves()
 	if len(leaves) > 1 {
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("len(leaves): %v", len(leaves)), trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("len(leaves): %v", len(leaves)), trillianUnexpectedResult}
 	} else if len(leaves) == 0 {
-		return handleRekorAPIError(params, http.StatusNotFound, errors.New("grpc returned 0 leaves with success code"), "")
+		return nil, &apiError{http.StatusNotFound, errors.New("grpc returned 0 leaves with success code"), ""}
 	}
 	leaf := leaves[0]
 
@@ -74,27 +104,273 @@ func GetLogEntryByIndexHandler(params entries.GetLogEntryByIndexParams) middlewa
 			IntegratedTime: leaf.IntegrateTimestamp.AsTime().Unix(),
 		},
 	}
-	return entries.NewGetLogEntryByIndexOK().WithPayload(logEntry)
+	return logEntry, nil
 }
 
-func CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Responder {
-	httpReq := params.HTTPRequest
-	entry, err := types.NewEntry(params.ProposedEntry)
+// GetLogEntriesByRangeHandler returns consecutive entries of the log, so that monitors and mirrors can read it
+// without a request per entry
+func GetLogEntriesByRangeHandler(params entries.GetLogEntriesByRangeParams) middleware.Responder {
+	tc := NewTrillianClient(params.HTTPRequest.Context())
+
+	resp := tc.getLogLeavesByRange(params.Start, params.Count)
+	switch resp.status {
+	case codes.OK:
+	case codes.NotFound, codes.OutOfRange:
+		return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), "")
+	default:
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc err: %w", resp.err), trillianCommunicationError)
+	}
+
+	leaves := resp.getLeafByRangeResult.GetLeaves()
+	if int64(len(leaves)) > params.Count {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("len(leaves): %v", len(leaves)), trillianUnexpectedResult)
+	} else if len(leaves) == 0 {
+		return handleRekorAPIError(params, http.StatusNotFound, errors.New("grpc returned 0 leaves with success code"), "")
+	}
+
+	result := make([]models.LogEntry, 0, len(leaves))
+	for i, leaf := range leaves {
+		if leaf.LeafIndex != params.Start+int64(i) {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("leaf %v has index %v", params.Start+int64(i), leaf.LeafIndex), trillianUnexpectedResult)
+		}
+		result = append(result, models.LogEntry{
+			hex.EncodeToString(leaf.MerkleLeafHash): models.LogEntryAnon{
+				LogIndex:       swag.Int64(leaf.LeafIndex),
+				Body:           leaf.LeafValue,
+				IntegratedTime: leaf.IntegrateTimestamp.AsTime().Unix(),
+			},
+		})
+	}
+	return entries.NewGetLogEntriesByRangeOK().WithPayload(result)
+}
+
+// validityKey is implemented by public keys that are only valid for a period of time, such as x509 certificates
+type validityKey interface {
+	ValidAt(t time.Time) error
+}
+
+// apiError is an error reported to the client, with the HTTP status code it is reported with; the gRPC API
+// maps the status code to the equivalent gRPC code
+type apiError struct {
+	code    int
+	err     error
+	message string
+}
+
+// result logs the error and returns it as the result of an entry of a batch
+func (e *apiError) result(r *http.Request) *models.BatchEntryResult {
+	log.RequestIDLogger(r).Errorw("rejecting entry of batch", "statusCode", e.code, "clientMessage", e.message, "error", e.err)
+	return &models.BatchEntryResult{Error: problem(e.message, e.code, e.err)}
+}
+
+// preparedEntry is a proposed entry that has been canonicalized and checked against the policies of this
+// instance, ready to be added to the log
+type preparedEntry struct {
+	kind     string
+	entry    types.EntryImpl
+	leaf     []byte
+	sigUse   *signatureUse
+	reusedBy []string
+}
+
+// beginWrite reports whether this instance accepts new entries from the client of ctx, returning the error to
+// reject them with if it does not; otherwise done must be called once the entry has been submitted to the log
+func beginWrite(ctx context.Context) (done func(), apiErr *apiError) {
+	if apiErr := authorizeTenantWrite(ctx); apiErr != nil {
+		return nil, apiErr
+	}
+	unfrozen, ok := runtimeCfg.beginEntry()
+	if !ok {
+		return nil, &apiError{http.StatusServiceUnavailable, errors.New("instance is read-only"), readOnlyInstance}
+	}
+	active, ok := failover.beginEntry()
+	if !ok {
+		unfrozen()
+		return nil, &apiError{http.StatusServiceUnavailable, errors.New("instance is on standby"), standbyInstance}
+	}
+	return func() {
+		active()
+		unfrozen()
+	}, nil
+}
+
+func prepareEntry(ctx context.Context, pe models.ProposedEntry) (p *preparedEntry, apiErr *apiError) {
+	defer func() {
+		if apiErr != nil {
+			apiErr.err = newEntryError(pe, apiErr.err)
+		}
+	}()
+	if kind := pe.Kind(); !runtimeCfg.kindEnabled(kind) {
+		return nil, &apiError{http.StatusBadRequest, fmt.Errorf("kind %v is disabled", kind), fmt.Sprintf(kindDisabled, kind)}
+	}
+	entry, err := types.NewEntry(pe)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusBadRequest, err, err.Error())
+		return nil, &apiError{http.StatusBadRequest, err, err.Error()}
 	}
 
-	leaf, err := entry.Canonicalize(httpReq.Context())
+	// canonicalizing fetches any content the entry references and verifies its signature
+	logger := log.ContextLogger(ctx)
+	logger.Debugw("canonicalizing proposed entry", "kind", pe.Kind())
+	start := time.Now()
+	fetchCtx, cancel := withTimeout(ctx, fetchTimeout)
+	defer cancel()
+	leaf, err := entry.Canonicalize(fetchCtx)
+	logger.Debugw("canonicalized proposed entry", "kind", pe.Kind(), "duration", time.Since(start), "error", err)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, failedToGenerateCanonicalEntry)
+		var pe *pgp.PolicyError
+		if errors.As(err, &pe) {
+			return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(keyRejectedByPolicy, pe.KeyID, pe.Reason)}
+		}
+		var se *pkix509.SCTError
+		if errors.As(err, &se) {
+			return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(invalidEmbeddedSCT, se.Reason)}
+		}
+		var sizeErr *util.SizeError
+		if errors.As(err, &sizeErr) {
+			return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(entityTooLarge, sizeErr.URL, sizeErr.Limit)}
+		}
+		var attErr *types.AttestationSizeError
+		if errors.As(err, &attErr) {
+			return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(attestationTooLarge, attErr.Limit)}
+		}
+		var fetchErr *util.FetchError
+		if errors.As(err, &fetchErr) {
+			return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(fetchFailed, fetchErr.URL, fetchErr.Err)}
+		}
+		var sigErr *types.VerificationError
+		if errors.As(err, &sigErr) {
+			return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(invalidSignature, sigErr.Err)}
+		}
+		return nil, &apiError{http.StatusInternalServerError, err, failedToGenerateCanonicalEntry}
+	}
+	if viper.GetBool("pki.x509_check_validity") {
+		if sk, ok := entry.(types.SigningKey); ok {
+			// the entry is integrated as soon as it is queued, so the current time stands in for its integrated time
+			if vk, ok := sk.SigningKey().(validityKey); ok {
+				if err := vk.ValidAt(time.Now()); err != nil {
+					return nil, &apiError{http.StatusBadRequest, err, fmt.Sprintf(certificateNotValid, err)}
+				}
+			}
+		}
 	}
 
-	tc := NewTrillianClient(httpReq.Context())
+	p = &preparedEntry{kind: pe.Kind(), entry: entry, leaf: leaf}
+	if viper.GetBool("enable_retrieve_api") && viper.GetBool("detect_signature_reuse") {
+		if ds, ok := entry.(types.DetachedSignature); ok {
+			// failing to check for reuse is not a reason to reject an otherwise valid entry
+			p.sigUse, err = newSignatureUse(ds)
+			if err == nil {
+				p.reusedBy, err = p.sigUse.reusedBy(ctx)
+			}
+			if err != nil {
+				logger.Error(err)
+			}
+		}
+	}
+	return p, nil
+}
+
+// added records that the entry was added to the log as uuid in the metrics, statistics and search index,
+// indexing the integrated leaf by the time it was integrated at
+func (p *preparedEntry) added(ctx context.Context, uuid string, leaf *trillian.LogLeaf) {
+	logger := log.ContextLogger(ctx)
+	metricNewEntries.Inc()
+	recordIntegration(leaf)
+	if identity := identityFrom(ctx); identity != "" {
+		metricNewEntriesByIdentity.WithLabelValues(metricIdentity(identity)).Inc()
+		logger.Infow("entry added by authenticated client", "uuid", uuid, "identity", identity)
+	}
+	if len(p.reusedBy) > 0 {
+		metricSignatureReuse.Inc()
+		logger.Warnw("signature previously logged under a different public key", "uuid", uuid, "reusedBy", p.reusedBy)
+	}
+
+	if viper.GetBool("enable_retrieve_api") {
+		// the index is written after the request completes, within the namespace of its tenant, and retried until
+		// it succeeds
+		indexWriteQueue.enqueue(&indexJob{
+			Tenant:         tenantFrom(ctx),
+			UUID:           uuid,
+			Kind:           p.kind,
+			LogIndex:       leaf.LeafIndex,
+			IntegratedTime: leaf.IntegrateTimestamp.AsTime().Unix(),
+			Writes:         indexWrites(p.kind, p.entry, p.sigUse, uuid),
+		})
+	}
+}
+
+// storeAttestations stores the attestations the entry logs by their digest alone once it has been added to the
+// log, or found to be there already, so that entries that are rejected or only validated never leave attestations
+// nothing refers to in attestation storage
+func (p *preparedEntry) storeAttestations(ctx context.Context) *apiError {
+	pending, ok := p.entry.(types.PendingAttestations)
+	store := types.StoredAttestations()
+	if !ok || store == nil {
+		return nil
+	}
+	for _, content := range pending.PendingAttestations() {
+		if _, err := store.Put(ctx, content); err != nil {
+			return &apiError{http.StatusInternalServerError, fmt.Errorf("storing attestation: %w", err), failedToStoreAttestation}
+		}
+	}
+	return nil
+}
+
+// indexWrites returns the writes indexing entry under uuid, including the keys of the extractors enabled for its
+// kind, its references to stored attestations and the use of its signature if tracked
+func indexWrites(kind string, entry types.EntryImpl, sigUse *signatureUse, uuid string) []storage.IndexWrite {
+	var writes []storage.IndexWrite
+	for _, key := range append(entry.IndexKeys(), types.ExtractIndexKeys(kind, entry)...) {
+		writes = append(writes, storage.IndexWrite{Key: key, Value: uuid})
+	}
+	if refs, ok := entry.(types.AttestationReferences); ok {
+		for _, digest := range refs.StoredAttestationDigests() {
+			writes = append(writes, storage.IndexWrite{Key: attestationReferencesKey(digest), Value: uuid})
+		}
+	}
+	if sigUse != nil {
+		writes = append(writes, sigUse.write(uuid))
+	}
+	return writes
+}
+
+// admitEntry counts an entry about to be queued against the quota of the client of ctx and the entry rate of this
+// instance, returning the error to reject it with if either has been used up; otherwise refund must be called if
+// the entry is not added after all, as a duplicate or on failing to queue it. Invalid entries and those that are
+// only validated never reach this point, so they use up neither.
+func admitEntry(ctx context.Context) (refund func(), apiErr *apiError) {
+	identity, now := identityFrom(ctx), time.Now()
+	if !writeQuotas.take(identity, now) {
+		return nil, &apiError{http.StatusTooManyRequests, fmt.Errorf("entry quota of %v exceeded", identity), entryQuotaExceeded}
+	}
+	if !runtimeCfg.allowEntry() {
+		writeQuotas.refund(identity, now)
+		return nil, &apiError{http.StatusTooManyRequests, errors.New("entry rate limit exceeded"), entryRateExceeded}
+	}
+	return func() { writeQuotas.refund(identity, now) }, nil
+}
+
+// add adds the entry to the log on its own, returning its UUID and the entry as queued; if an equivalent entry
+// is already in the log, its UUID and the existing entry are returned along with a conflict
+func (p *preparedEntry) add(ctx context.Context) (string, models.LogEntryAnon, *apiError) {
+	refund, apiErr := admitEntry(ctx)
+	if apiErr != nil {
+		return "", models.LogEntryAnon{}, apiErr
+	}
+	added := false
+	defer func() {
+		if !added {
+			refund()
+		}
+	}()
+	tc := NewTrillianClient(ctx)
 
-	resp := tc.addLeaf(leaf)
+	start := time.Now()
+	resp := tc.addLeaf(p.leaf)
+	log.ContextLogger(ctx).Debugw("queued leaf", "logID", tc.logID, "duration", time.Since(start), "status", resp.status)
 	//this represents overall GRPC response state (not the results of insertion into the log)
 	if resp.status != codes.OK {
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult)
+		return "", models.LogEntryAnon{}, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult}
 	}
 
 	//this represents the results of inserting the proposed leaf into the log; status is nil in success path
@@ -103,37 +379,241 @@ func CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Respo
 		switch insertionStatus.Code {
 		case int32(code.Code_OK):
 		case int32(code.Code_ALREADY_EXISTS), int32(code.Code_FAILED_PRECONDITION):
-			existingUUID := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(leaf))
-			return handleRekorAPIError(params, http.StatusConflict, fmt.Errorf("grpc error: %v", insertionStatus.String()), fmt.Sprintf(entryAlreadyExists, existingUUID), "entryURL", getEntryURL(*httpReq.URL, existingUUID))
+			existingUUID := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(p.leaf))
+			apiErr := &apiError{http.StatusConflict, fmt.Errorf("grpc error: %v", insertionStatus.String()), fmt.Sprintf(entryAlreadyExists, existingUUID)}
+			existing, err := existingEntry(ctx, existingUUID, resp.getAddResult.QueuedLeaf.Leaf)
+			if err != nil {
+				log.ContextLogger(ctx).Error(err)
+			}
+			// the attestations of the existing entry are stored again in case storing them failed when it was added
+			if storeErr := p.storeAttestations(ctx); storeErr != nil {
+				log.ContextLogger(ctx).Error(storeErr.err)
+			}
+			return existingUUID, existing, apiErr
 		default:
-			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %v", insertionStatus.String()), trillianUnexpectedResult)
+			return "", models.LogEntryAnon{}, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %v", insertionStatus.String()), trillianUnexpectedResult}
 		}
 	}
 
 	// We made it this far, that means the entry was successfully added.
-	metricNewEntries.Inc()
-
 	queuedLeaf := resp.getAddResult.QueuedLeaf.Leaf
 	uuid := hex.EncodeToString(queuedLeaf.GetMerkleLeafHash())
+	added = true
+	p.added(ctx, uuid, queuedLeaf)
+	// the entry is in the log either way; retrying it stores its attestations along with the conflict
+	if apiErr := p.storeAttestations(ctx); apiErr != nil {
+		return "", models.LogEntryAnon{}, apiErr
+	}
+
+	return uuid, models.LogEntryAnon{
+		LogIndex: swag.Int64(queuedLeaf.LeafIndex),
+		Body:     queuedLeaf.GetLeafValue(),
+	}, nil
+}
+
+// existingEntry returns the entry already in the log with uuid, using the leaf returned when queueing the
+// duplicate if it has been integrated
+func existingEntry(ctx context.Context, uuid string, leaf *trillian.LogLeaf) (models.LogEntryAnon, error) {
+	if leaf != nil && leaf.IntegrateTimestamp != nil {
+		return models.LogEntryAnon{
+			LogIndex:       swag.Int64(leaf.LeafIndex),
+			Body:           leaf.LeafValue,
+			IntegratedTime: leaf.IntegrateTimestamp.AsTime().Unix(),
+		}, nil
+	}
+	logEntry, apiErr := logEntryByUUID(ctx, uuid)
+	if apiErr != nil {
+		return models.LogEntryAnon{}, fmt.Errorf("looking up existing entry %v: %w", uuid, apiErr.err)
+	}
+	return logEntry[uuid], nil
+}
+
+// conflictFields returns the fields describing the existing entry to return with a conflict
+func conflictFields(entryURL strfmt.URI, uuid string, existing models.LogEntryAnon) []interface{} {
+	fields := []interface{}{"entryURL", entryURL, "uuid", uuid}
+	if existing.LogIndex != nil {
+		fields = append(fields, "logIndex", *existing.LogIndex)
+	}
+	return fields
+}
+
+// conflictResult returns the result of an entry of a batch that is already in the log, which carries the existing
+// entry and its location as the response to a single duplicate entry does
+func conflictResult(r *http.Request, apiErr *apiError, entryURL strfmt.URI, uuid string, existing models.LogEntryAnon) *models.BatchEntryResult {
+	result := apiErr.result(r)
+	result.Location = entryURL
+	if existing.LogIndex != nil {
+		result.Entry = models.LogEntry{uuid: existing}
+	}
+	return result
+}
+
+func CreateLogEntryHandler(params entries.CreateLogEntryParams) middleware.Responder {
+	httpReq := params.HTTPRequest
+	done, apiErr := beginWrite(httpReq.Context())
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	defer done()
+	p, apiErr := prepareEntry(httpReq.Context(), params.ProposedEntry)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+
+	uuid, entry, apiErr := p.add(httpReq.Context())
+	if apiErr != nil {
+		if apiErr.code == http.StatusConflict {
+			return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message, conflictFields(getEntryURL(requestURL(httpReq), uuid), uuid, entry)...)
+		}
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+
+	logEntry := models.LogEntry{
+		uuid: entry,
+	}
+
+	created := entries.NewCreateLogEntryCreated().WithPayload(logEntry).WithLocation(getEntryURL(requestURL(httpReq), uuid)).WithETag(entryETag(logEntry))
+	if len(p.reusedBy) > 0 {
+		created = created.WithXRekorSignatureReuse(strings.Join(p.reusedBy, ","))
+	}
+	return created
+}
+
+// ValidateLogEntryHandler runs the checks of CreateLogEntryHandler on the proposed entry without adding it to
+// the log, returning the canonical entry under the UUID it would be added as
+func ValidateLogEntryHandler(params entries.ValidateLogEntryParams) middleware.Responder {
+	httpReq := params.HTTPRequest
+	p, apiErr := prepareEntry(httpReq.Context(), params.ProposedEntry)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+
+	uuid := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(p.leaf))
+	if existing, apiErr := logEntryByUUID(httpReq.Context(), uuid); apiErr == nil {
+		entriesURL := requestURL(httpReq)
+		entriesURL.Path = path.Dir(entriesURL.Path)
+		return handleRekorAPIError(params, http.StatusConflict, errors.New("entry already exists"), fmt.Sprintf(entryAlreadyExists, uuid), conflictFields(getEntryURL(entriesURL, uuid), uuid, existing[uuid])...)
+	} else if apiErr.code != http.StatusNotFound {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
 
 	logEntry := models.LogEntry{
 		uuid: models.LogEntryAnon{
-			LogIndex: swag.Int64(queuedLeaf.LeafIndex),
-			Body:     queuedLeaf.GetLeafValue(),
+			Body: p.leaf,
 		},
 	}
+	ok := entries.NewValidateLogEntryOK().WithPayload(logEntry).WithETag(entryETag(logEntry))
+	if len(p.reusedBy) > 0 {
+		ok = ok.WithXRekorSignatureReuse(strings.Join(p.reusedBy, ","))
+	}
+	return ok
+}
 
-	if viper.GetBool("enable_retrieve_api") {
-		go func() {
-			for _, key := range entry.IndexKeys() {
-				if err := addToIndex(context.Background(), key, uuid); err != nil {
-					log.RequestIDLogger(params.HTTPRequest).Error(err)
-				}
+// batchConcurrency is the number of entries of a batch that are canonicalized at once
+const batchConcurrency = 8
+
+func CreateLogEntriesHandler(params entries.CreateLogEntriesParams) middleware.Responder {
+	httpReq := params.HTTPRequest
+	done, apiErr := beginWrite(httpReq.Context())
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	defer done()
+
+	results := make([]*models.BatchEntryResult, len(params.ProposedEntries))
+	prepared := make([]*preparedEntry, len(params.ProposedEntries))
+	sem := make(chan struct{}, batchConcurrency)
+	g, _ := errgroup.WithContext(httpReq.Context())
+	for i := range params.ProposedEntries {
+		i := i
+		sem <- struct{}{}
+		g.Go(func() error {
+			defer func() { <-sem }()
+			p, apiErr := prepareEntry(httpReq.Context(), params.ProposedEntries[i])
+			if apiErr != nil {
+				results[i] = apiErr.result(httpReq)
+				return nil
 			}
-		}()
+			prepared[i] = p
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	// leaves holds the leaves of the entries that were not rejected, which are queued together
+	var leaves [][]byte
+	var indexes []int
+	var refunds []func()
+	for i, p := range prepared {
+		if p == nil {
+			continue
+		}
+		refund, apiErr := admitEntry(httpReq.Context())
+		if apiErr != nil {
+			results[i] = apiErr.result(httpReq)
+			continue
+		}
+		leaves = append(leaves, p.leaf)
+		indexes = append(indexes, i)
+		refunds = append(refunds, refund)
+	}
+	if len(leaves) == 0 {
+		return entries.NewCreateLogEntriesOK().WithPayload(results)
+	}
+
+	tc := NewTrillianClient(httpReq.Context())
+	resp := tc.addLeaves(leaves)
+	if resp.status != codes.OK {
+		for _, refund := range refunds {
+			refund()
+		}
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult)
 	}
 
-	return entries.NewCreateLogEntryCreated().WithPayload(logEntry).WithLocation(getEntryURL(*httpReq.URL, uuid)).WithETag(uuid)
+	entriesURL := requestURL(httpReq)
+	entriesURL.Path = path.Dir(entriesURL.Path)
+	for j, queued := range resp.getAddLeavesResult.QueuedLeaves {
+		i, p := indexes[j], prepared[indexes[j]]
+		if queued.Status != nil {
+			switch queued.Status.Code {
+			case int32(code.Code_OK):
+			case int32(code.Code_ALREADY_EXISTS), int32(code.Code_FAILED_PRECONDITION):
+				refunds[j]()
+				existingUUID := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(p.leaf))
+				apiErr := &apiError{http.StatusConflict, fmt.Errorf("grpc error: %v", queued.Status.String()), fmt.Sprintf(entryAlreadyExists, existingUUID)}
+				existing, err := existingEntry(httpReq.Context(), existingUUID, queued.Leaf)
+				if err != nil {
+					log.ContextLogger(httpReq.Context()).Error(err)
+				}
+				if storeErr := p.storeAttestations(httpReq.Context()); storeErr != nil {
+					log.ContextLogger(httpReq.Context()).Error(storeErr.err)
+				}
+				results[i] = conflictResult(httpReq, apiErr, getEntryURL(entriesURL, existingUUID), existingUUID, existing)
+				continue
+			default:
+				refunds[j]()
+				results[i] = (&apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %v", queued.Status.String()), trillianUnexpectedResult}).result(httpReq)
+				continue
+			}
+		}
+
+		uuid := hex.EncodeToString(queued.Leaf.GetMerkleLeafHash())
+		p.added(httpReq.Context(), uuid, queued.Leaf)
+		if apiErr := p.storeAttestations(httpReq.Context()); apiErr != nil {
+			results[i] = apiErr.result(httpReq)
+			continue
+		}
+		results[i] = &models.BatchEntryResult{
+			Entry: models.LogEntry{
+				uuid: models.LogEntryAnon{
+					LogIndex: swag.Int64(queued.Leaf.LeafIndex),
+					Body:     queued.Leaf.GetLeafValue(),
+				},
+			},
+			Location: getEntryURL(entriesURL, uuid),
+		}
+	}
+	return entries.NewCreateLogEntriesOK().WithPayload(results)
 }
 
 func getEntryURL(locationURL url.URL, uuid string) strfmt.URI {
@@ -147,25 +627,81 @@ func getEntryURL(locationURL url.URL, uuid string) strfmt.URI {
 }
 
 func GetLogEntryByUUIDHandler(params entries.GetLogEntryByUUIDParams) middleware.Responder {
-	hashValue, _ := hex.DecodeString(params.EntryUUID)
+	ctx := params.HTTPRequest.Context()
+	logEntry, apiErr := logEntryByUUID(ctx, params.EntryUUID)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	if swag.BoolValue(params.IncludeProof) {
+		if err := addInclusionProofs(NewTrillianClient(ctx), []models.LogEntry{logEntry}); err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianUnexpectedResult)
+		}
+		// the proof changes as the log grows, so the response has no validator
+		return entries.NewGetLogEntryByUUIDOK().WithPayload(logEntry)
+	}
+	etag := entryETag(logEntry)
+	if etagMatches(params.IfNoneMatch, etag) {
+		return entries.NewGetLogEntryByUUIDNotModified().WithETag(etag)
+	}
+	return entries.NewGetLogEntryByUUIDOK().WithPayload(logEntry).WithETag(etag)
+}
+
+// entryETag returns the strong validator of an entry, which is its quoted UUID as entries never change once
+// integrated
+func entryETag(logEntry models.LogEntry) string {
+	for uuid := range logEntry {
+		return `"` + uuid + `"`
+	}
+	return ""
+}
+
+// etagMatches reports whether the If-None-Match header ifNoneMatch lists etag, using the weak comparison it
+// is defined with
+func etagMatches(ifNoneMatch *string, etag string) bool {
+	if ifNoneMatch == nil {
+		return false
+	}
+	for _, tag := range strings.Split(*ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || strings.EqualFold(strings.Trim(tag, `"`), strings.Trim(etag, `"`)) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeUUID returns the Merkle leaf hash an entry UUID is the hex encoding of
+func decodeUUID(uuid string) ([]byte, *apiError) {
+	hashValue, err := hex.DecodeString(uuid)
+	if err != nil || len(hashValue) != sha256.Size {
+		return nil, &apiError{http.StatusBadRequest, fmt.Errorf("invalid UUID %q", uuid), malformedUUID}
+	}
+	return hashValue, nil
+}
+
+func logEntryByUUID(ctx context.Context, entryUUID string) (models.LogEntry, *apiError) {
+	hashValue, apiErr := decodeUUID(entryUUID)
+	if apiErr != nil {
+		return nil, apiErr
+	}
 	hashes := [][]byte{hashValue}
 
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+	tc := NewTrillianClient(ctx)
 
-	resp := tc.getLeafByHash(hashes) // TODO: if this API is deprecated, we need to ask for inclusion proof and then use index in proof result to get leaf
+	resp := tc.getLogLeavesByHash(hashes) // TODO: if this API is deprecated, we need to ask for inclusion proof and then use index in proof result to get leaf
 	switch resp.status {
 	case codes.OK:
 	case codes.NotFound:
-		return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), "")
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), ""}
 	default:
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult}
 	}
 
 	leaves := resp.getLeafResult.GetLeaves()
 	if len(leaves) > 1 {
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("len(leaves): %v", len(leaves)), trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("len(leaves): %v", len(leaves)), trillianUnexpectedResult}
 	} else if len(leaves) == 0 {
-		return handleRekorAPIError(params, http.StatusNotFound, errors.New("grpc returned 0 leaves with success code"), "")
+		return nil, &apiError{http.StatusNotFound, errors.New("grpc returned 0 leaves with success code"), ""}
 	}
 	leaf := leaves[0]
 
@@ -178,36 +714,73 @@ func GetLogEntryByUUIDHandler(params entries.GetLogEntryByUUIDParams) middleware
 			IntegratedTime: leaf.IntegrateTimestamp.AsTime().Unix(),
 		},
 	}
-	return entries.NewGetLogEntryByUUIDOK().WithPayload(logEntry)
+	return logEntry, nil
 }
 
 func GetLogEntryProofHandler(params entries.GetLogEntryProofParams) middleware.Responder {
-	hashValue, _ := hex.DecodeString(params.EntryUUID)
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+	inclusionProof, apiErr := entryInclusionProof(params.HTTPRequest.Context(), params.EntryUUID)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	return entries.NewGetLogEntryProofOK().WithPayload(inclusionProof)
+}
 
-	resp := tc.getProofByHash(hashValue)
+// GetLogEntryProofByLeafHandler returns the inclusion proof of the entry with the given leaf hash, or of the
+// entry with the given canonicalized content for clients that kept only the entry they submitted
+func GetLogEntryProofByLeafHandler(params entries.GetLogEntryProofByLeafParams) middleware.Responder {
+	uuid, apiErr := queriedLeafHash(params.Query)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	inclusionProof, apiErr := entryInclusionProof(params.HTTPRequest.Context(), uuid)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	return entries.NewGetLogEntryProofByLeafOK().WithPayload(inclusionProof)
+}
+
+// queriedLeafHash returns the hex-encoded leaf hash named by query, hashing its canonicalized entry as the log
+// does if the hash is not given
+func queriedLeafHash(query *models.InclusionProofQuery) (string, *apiError) {
+	if (query.LeafHash == "") == (len(query.CanonicalEntry) == 0) {
+		return "", &apiError{http.StatusBadRequest, nil, leafHashOrCanonicalEntry}
+	}
+	if query.LeafHash != "" {
+		return query.LeafHash, nil
+	}
+	return hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(query.CanonicalEntry)), nil
+}
+
+func entryInclusionProof(ctx context.Context, uuid string) (*models.InclusionProof, *apiError) {
+	hashValue, apiErr := decodeUUID(uuid)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	// the proof is in the tree holding the entry, whose key it is verified with
+	tc := NewTrillianClient(ctx)
+	tc, resp := tc.getLogProofByHash(hashValue)
 	switch resp.status {
 	case codes.OK:
 	case codes.NotFound:
-		return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), "")
+		return nil, &apiError{http.StatusNotFound, fmt.Errorf("grpc error: %w", resp.err), ""}
 	default:
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianUnexpectedResult}
 	}
 	result := resp.getProofResult
 
 	// validate result is signed with the key we're aware of
 	pub, err := x509.ParsePKIXPublicKey(tc.pubkey.Der)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, "")
+		return nil, &apiError{http.StatusInternalServerError, err, ""}
 	}
 	verifier := tclient.NewLogVerifier(rfc6962.DefaultHasher, pub, crypto.SHA256)
 	root, err := tcrypto.VerifySignedLogRoot(verifier.PubKey, verifier.SigHash, result.SignedLogRoot)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, err, trillianUnexpectedResult}
 	}
 
 	if len(result.Proof) != 1 {
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("len(result.Proof) = %v", len(result.Proof)), trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("len(result.Proof) = %v", len(result.Proof)), trillianUnexpectedResult}
 	}
 	proof := result.Proof[0]
 
@@ -216,13 +789,12 @@ func GetLogEntryProofHandler(params entries.GetLogEntryProofParams) middleware.R
 		hashes = append(hashes, hex.EncodeToString(hash))
 	}
 
-	inclusionProof := models.InclusionProof{
+	return &models.InclusionProof{
 		TreeSize: swag.Int64(int64(root.TreeSize)),
 		RootHash: swag.String(hex.EncodeToString(root.RootHash)),
 		LogIndex: swag.Int64(proof.GetLeafIndex()),
 		Hashes:   hashes,
-	}
-	return entries.NewGetLogEntryProofOK().WithPayload(&inclusionProof)
+	}, nil
 }
 
 func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Responder {
@@ -254,13 +826,15 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 					return err
 				}
 
+				fetchCtx, cancel := withTimeout(httpReqCtx, fetchTimeout)
+				defer cancel()
 				if entry.HasExternalEntities() {
-					if err := entry.FetchExternalEntities(httpReqCtx); err != nil {
+					if err := entry.FetchExternalEntities(fetchCtx); err != nil {
 						return err
 					}
 				}
 
-				leaf, err := entry.Canonicalize(httpReqCtx)
+				leaf, err := entry.Canonicalize(fetchCtx)
 				if err != nil {
 					code = http.StatusInternalServerError
 					return err
@@ -276,7 +850,7 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 			return handleRekorAPIError(params, code, err, err.Error())
 		}
 
-		resp := tc.getLeafByHash(searchHashes) // TODO: if this API is deprecated, we need to ask for inclusion proof and then use index in proof result to get leaf
+		resp := tc.getLogLeavesByHash(searchHashes) // TODO: if this API is deprecated, we need to ask for inclusion proof and then use index in proof result to get leaf
 		switch resp.status {
 		case codes.OK, codes.NotFound:
 		default:
@@ -301,7 +875,7 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 		for i, logIndex := range params.Entry.LogIndexes {
 			i, logIndex := i, logIndex // https://golang.org/doc/faq#closures_and_goroutines
 			g.Go(func() error {
-				resp := tc.getLeafByIndex(swag.Int64Value(logIndex))
+				resp := tc.getLogLeafByIndex(swag.Int64Value(logIndex))
 				switch resp.status {
 				case codes.OK, codes.NotFound:
 				default:
@@ -318,9 +892,9 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 				}
 				return nil
 			})
-			if err := g.Wait(); err != nil {
-				return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianUnexpectedResult)
-			}
+		}
+		if err := g.Wait(); err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianUnexpectedResult)
 		}
 
 		for _, leaf := range leaves {
@@ -336,5 +910,112 @@ func SearchLogQueryHandler(params entries.SearchLogQueryParams) middleware.Respo
 		}
 	}
 
+	if params.Entry.IncludeProofs && len(resultPayload) > 0 {
+		if err := addInclusionProofs(tc, resultPayload); err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianUnexpectedResult)
+		}
+	}
+
 	return entries.NewSearchLogQueryOK().WithPayload(resultPayload)
 }
+
+// addInclusionProofs adds the inclusion proof of each of the entries, all against the latest tree head, so that a
+// verifier checking many entries only has to check a single signed tree head; the signed tree head is added to
+// each entry so that it can be verified on its own. Entries of inactive shards are proven against the tree head
+// their shard was frozen at.
+func addInclusionProofs(tc TrillianClient, logEntries []models.LogEntry) error {
+	heads := map[int64]*proofHead{}
+	for _, logEntry := range logEntries {
+		for _, entry := range logEntry {
+			shard := tc.shardAt(swag.Int64Value(entry.LogIndex))
+			if _, ok := heads[shard.logID]; ok {
+				continue
+			}
+			head, err := newProofHead(shard)
+			if err != nil {
+				return err
+			}
+			heads[shard.logID] = head
+		}
+	}
+
+	// the entries are read before any proof is added, as the maps holding them are written to concurrently
+	type pendingProof struct {
+		logEntry models.LogEntry
+		uuid     string
+		entry    models.LogEntryAnon
+	}
+	var pending []pendingProof
+	for _, logEntry := range logEntries {
+		for uuid, entry := range logEntry {
+			pending = append(pending, pendingProof{logEntry: logEntry, uuid: uuid, entry: entry})
+		}
+	}
+
+	var mu sync.Mutex
+	g, _ := errgroup.WithContext(tc.context)
+	for _, p := range pending {
+		logEntry, uuid, entry := p.logEntry, p.uuid, p.entry
+		g.Go(func() error {
+			hashValue, err := hex.DecodeString(uuid)
+			if err != nil {
+				return err
+			}
+			head := heads[tc.shardAt(swag.Int64Value(entry.LogIndex)).logID]
+			root := head.root
+			resp := head.tc.getProofByHashAt(hashValue, root)
+			if resp.err != nil {
+				return resp.err
+			}
+			if len(resp.getProofResult.Proof) != 1 {
+				return fmt.Errorf("len(result.Proof) = %v", len(resp.getProofResult.Proof))
+			}
+			proof := resp.getProofResult.Proof[0]
+
+			hashes := []string{}
+			for _, hash := range proof.Hashes {
+				hashes = append(hashes, hex.EncodeToString(hash))
+			}
+			entry.Verification = &models.LogEntryAnonVerification{
+				InclusionProof: &models.InclusionProof{
+					TreeSize: swag.Int64(int64(root.TreeSize)),
+					RootHash: swag.String(hex.EncodeToString(root.RootHash)),
+					LogIndex: swag.Int64(proof.GetLeafIndex()),
+					Hashes:   hashes,
+				},
+				SignedTreeHead: head.sth,
+			}
+			mu.Lock()
+			logEntry[uuid] = entry
+			mu.Unlock()
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// proofHead is the tree head the inclusion proofs of the entries of a shard are computed against
+type proofHead struct {
+	tc   TrillianClient
+	root ttypes.LogRootV1
+	sth  *models.LogEntryAnonVerificationSignedTreeHead
+}
+
+func newProofHead(tc TrillianClient) (*proofHead, error) {
+	slr, root, err := tc.signedRoot()
+	if err != nil {
+		return nil, err
+	}
+	keyHint := strfmt.Base64(slr.GetKeyHint())
+	logRoot := strfmt.Base64(slr.GetLogRoot())
+	signature := strfmt.Base64(slr.GetLogRootSignature())
+	return &proofHead{
+		tc:   tc,
+		root: root,
+		sth: &models.LogEntryAnonVerificationSignedTreeHead{
+			KeyHint:   &keyHint,
+			LogRoot:   &logRoot,
+			Signature: &signature,
+		},
+	}, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+)
+
+func TestQueriedLeafHash(t *testing.T) {
+	entry := []byte(`{"apiVersion":"0.0.1","kind":"rekord","spec":{}}`)
+	leafHash := hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf(entry))
+
+	tests := []struct {
+		caseDesc string
+		query    models.InclusionProofQuery
+		expected string
+	}{
+		{caseDesc: "leaf hash", query: models.InclusionProofQuery{LeafHash: leafHash}, expected: leafHash},
+		{caseDesc: "canonical entry", query: models.InclusionProofQuery{CanonicalEntry: entry}, expected: leafHash},
+		{caseDesc: "neither", query: models.InclusionProofQuery{}},
+		{caseDesc: "both", query: models.InclusionProofQuery{LeafHash: leafHash, CanonicalEntry: entry}},
+	}
+	for _, tc := range tests {
+		got, apiErr := queriedLeafHash(&tc.query)
+		if tc.expected == "" {
+			if apiErr == nil || apiErr.code != http.StatusBadRequest {
+				t.Errorf("%v: expected bad request, got %q with %v", tc.caseDesc, got, apiErr)
+			}
+			continue
+		}
+		if apiErr != nil || got != tc.expected {
+			t.Errorf("%v: got %q with %v, expected %q", tc.caseDesc, got, apiErr, tc.expected)
+		}
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	uuid := "3030303030303030303030303030303030303030303030303030303030303030"
+	etag := entryETag(models.LogEntry{uuid: models.LogEntryAnon{}})
+	if etag != `"`+uuid+`"` {
+		t.Fatalf("unexpected ETag %v", etag)
+	}
+
+	tests := []struct {
+		caseDesc    string
+		ifNoneMatch string
+		expected    bool
+	}{
+		{caseDesc: "same tag", ifNoneMatch: etag, expected: true},
+		{caseDesc: "weak tag", ifNoneMatch: "W/" + etag, expected: true},
+		{caseDesc: "unquoted upper case UUID", ifNoneMatch: strings.ToUpper(uuid), expected: true},
+		{caseDesc: "one of several", ifNoneMatch: `"other", ` + etag, expected: true},
+		{caseDesc: "any", ifNoneMatch: "*", expected: true},
+		{caseDesc: "other tag", ifNoneMatch: `"other"`},
+	}
+	for _, tc := range tests {
+		if got := etagMatches(&tc.ifNoneMatch, etag); got != tc.expected {
+			t.Errorf("%v: matched %v, expected %v", tc.caseDesc, got, tc.expected)
+		}
+	}
+	if etagMatches(nil, etag) {
+		t.Error("missing header matched")
+	}
+}
+
+func TestConflictResponse(t *testing.T) {
+	uuid := strings.Repeat("ab", 32)
+	params := entries.CreateLogEntryParams{HTTPRequest: httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", nil)}
+	existing := models.LogEntryAnon{LogIndex: swag.Int64(42)}
+	resp := handleRekorAPIError(params, http.StatusConflict, errors.New("exists"), "exists", conflictFields("http://localhost/api/v1/log/entries/"+strfmt.URI(uuid), uuid, existing)...)
+
+	w := httptest.NewRecorder()
+	resp.WriteResponse(w, runtime.JSONProducer())
+	if w.Code != http.StatusConflict {
+		t.Fatalf("conflict returned %v", w.Code)
+	}
+	// the validator is quoted as for the entry itself, so it can be sent back in If-None-Match
+	if got := w.Header().Get("ETag"); got != `"`+uuid+`"` {
+		t.Errorf("ETag %q, expected %q", got, `"`+uuid+`"`)
+	}
+	if got := w.Header().Get("X-Rekor-Log-Index"); got != "42" {
+		t.Errorf("X-Rekor-Log-Index %q, expected 42", got)
+	}
+	if got := w.Header().Get("Location"); !strings.HasSuffix(got, "/api/v1/log/entries/"+uuid) {
+		t.Errorf("unexpected Location %q", got)
+	}
+}
+
+func TestBatchConflictResult(t *testing.T) {
+	uuid := strings.Repeat("ab", 32)
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries/batch", nil)
+	apiErr := &apiError{http.StatusConflict, errors.New("exists"), fmt.Sprintf(entryAlreadyExists, uuid)}
+	entryURL := getEntryURL(url.URL{Scheme: "http", Host: "localhost", Path: "/api/v1/log/entries"}, uuid)
+	result := conflictResult(r, apiErr, entryURL, uuid, models.LogEntryAnon{LogIndex: swag.Int64(42)})
+
+	if result.Error == nil || result.Error.Code != http.StatusConflict {
+		t.Errorf("unexpected error %+v", result.Error)
+	}
+	if result.Location != "http://localhost/api/v1/log/entries/"+strfmt.URI(uuid) {
+		t.Errorf("unexpected location %v", result.Location)
+	}
+	if existing, ok := result.Entry[uuid]; !ok || swag.Int64Value(existing.LogIndex) != 42 {
+		t.Errorf("unexpected existing entry %+v", result.Entry)
+	}
+	if err := result.Validate(strfmt.Default); err != nil {
+		t.Errorf("invalid result: %v", err)
+	}
+
+	// the location is returned even if the existing entry could not be read
+	if result := conflictResult(r, apiErr, entryURL, uuid, models.LogEntryAnon{}); result.Location != entryURL || result.Entry != nil {
+		t.Errorf("unexpected result without existing entry %+v", result)
+	}
+}
+
+func TestAdmitEntryRate(t *testing.T) {
+	defer func(c *runtimeConfig, q *entryQuotas) { runtimeCfg, writeQuotas = c, q }(runtimeCfg, writeQuotas)
+	runtimeCfg = newRuntimeConfig()
+	limit, burst := 0.001, 1
+	if err := runtimeCfg.update(adminConfigUpdate{EntryRateLimit: &limit, EntryRateBurst: &burst}); err != nil {
+		t.Fatal(err)
+	}
+	writeQuotas = newEntryQuotas(0, 2)
+	ctx := withIdentity(context.Background(), "jdoe")
+
+	if _, apiErr := admitEntry(ctx); apiErr != nil {
+		t.Fatalf("entry rejected within the entry rate: %v", apiErr.err)
+	}
+	_, apiErr := admitEntry(ctx)
+	if apiErr == nil || apiErr.code != http.StatusTooManyRequests || apiErr.message != entryRateExceeded {
+		t.Fatalf("expected entry beyond the entry rate to be rejected, got %v", apiErr)
+	}
+	// the entry rejected for the entry rate is not counted against the quota of its client
+	if status := writeQuotas.status("jdoe", time.Now()); len(status) != 1 || status[0].remaining != 1 {
+		t.Errorf("unexpected quota status %+v", status)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+)
+
+// typedBody sets the property of e holding the body of an entry of kind and apiVersion to an empty schema for the
+// body to be decoded into, returning nil for kinds and versions the server does not know
+func typedBody(e *models.LogEntryV2, kind, apiVersion string) interface{} {
+	if apiVersion != "0.0.1" {
+		return nil
+	}
+	switch kind {
+	case "commitment":
+		e.Commitment = &models.CommitmentV001Schema{}
+		return e.Commitment
+	case "dct":
+		e.Dct = &models.DctV001Schema{}
+		return e.Dct
+	case "kmod":
+		e.Kmod = &models.KmodV001Schema{}
+		return e.Kmod
+	case "mlmodel":
+		e.Mlmodel = &models.MlmodelV001Schema{}
+		return e.Mlmodel
+	case "notation":
+		e.Notation = &models.NotationV001Schema{}
+		return e.Notation
+	case "rekord":
+		e.Rekord = &models.RekordV001Schema{}
+		return e.Rekord
+	case "release":
+		e.Release = &models.ReleaseV001Schema{}
+		return e.Release
+	case "rpm":
+		e.Rpm = &models.RpmV001Schema{}
+		return e.Rpm
+	case "tfprovider":
+		e.Tfprovider = &models.TfproviderV001Schema{}
+		return e.Tfprovider
+	case "vmimage":
+		e.Vmimage = &models.VmimageV001Schema{}
+		return e.Vmimage
+	}
+	return nil
+}
+
+// logEntryV2 converts an entry retrieved in the v1 format to the v2 format, decoding its canonical body into the
+// schema of its kind along with the attestations it only logged the digest of; the typed body is left out for
+// kinds or versions the server does not know
+func logEntryV2(ctx context.Context, logEntry models.LogEntry) (*models.LogEntryV2, error) {
+	for uuid, anon := range logEntry {
+		body, ok := anon.Body.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unexpected body of type %T", anon.Body)
+		}
+		var canonical struct {
+			Kind       string          `json:"kind"`
+			APIVersion string          `json:"apiVersion"`
+			Spec       json.RawMessage `json:"spec"`
+		}
+		if err := json.Unmarshal(body, &canonical); err != nil {
+			return nil, fmt.Errorf("error decoding entry %v: %w", uuid, err)
+		}
+		canonicalBody := strfmt.Base64(body)
+		entry := &models.LogEntryV2{
+			UUID:           swag.String(uuid),
+			LogIndex:       anon.LogIndex,
+			IntegratedTime: swag.Int64(anon.IntegratedTime),
+			Kind:           swag.String(canonical.Kind),
+			APIVersion:     swag.String(canonical.APIVersion),
+			CanonicalBody:  &canonicalBody,
+		}
+		if typed := typedBody(entry, canonical.Kind, canonical.APIVersion); typed != nil {
+			if err := json.Unmarshal(canonical.Spec, typed); err != nil {
+				return nil, fmt.Errorf("error decoding %v entry %v: %w", canonical.Kind, uuid, err)
+			}
+			if err := addStoredAttestations(ctx, entry); err != nil {
+				return nil, fmt.Errorf("entry %v: %w", uuid, err)
+			}
+		}
+		return entry, nil
+	}
+	return nil, errors.New("no entry returned")
+}
+
+// GetLogEntryV2ByIndexHandler returns an entry by index in the v2 format
+func GetLogEntryV2ByIndexHandler(params entries.GetLogEntryV2ByIndexParams) middleware.Responder {
+	logEntry, apiErr := logEntryByIndex(params.HTTPRequest.Context(), params.LogIndex)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	entry, err := logEntryV2(params.HTTPRequest.Context(), logEntry)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+	}
+	return entries.NewGetLogEntryV2ByIndexOK().WithPayload(entry)
+}
+
+// GetLogEntryV2ByUUIDHandler returns an entry by UUID in the v2 format
+func GetLogEntryV2ByUUIDHandler(params entries.GetLogEntryV2ByUUIDParams) middleware.Responder {
+	logEntry, apiErr := logEntryByUUID(params.HTTPRequest.Context(), params.EntryUUID)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	entry, err := logEntryV2(params.HTTPRequest.Context(), logEntry)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+	}
+	return entries.NewGetLogEntryV2ByUUIDOK().WithPayload(entry)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+func TestLogEntryV2(t *testing.T) {
+	uuid := "3030303030303030303030303030303030303030303030303030303030303030"
+	v1 := func(body string) models.LogEntry {
+		return models.LogEntry{uuid: models.LogEntryAnon{LogIndex: swag.Int64(7), IntegratedTime: 1600000000, Body: []byte(body)}}
+	}
+
+	body := `{"apiVersion":"0.0.1","kind":"rekord","spec":{"data":{"hash":{"algorithm":"sha256","value":"` + uuid + `"}},"signature":{"content":"c2lnbmF0dXJl","format":"x509","publicKey":{"content":"a2V5"}}}}`
+	entry, err := logEntryV2(context.Background(), v1(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *entry.UUID != uuid || *entry.LogIndex != 7 || *entry.IntegratedTime != 1600000000 || *entry.Kind != "rekord" || *entry.APIVersion != "0.0.1" {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if !bytes.Equal(*entry.CanonicalBody, []byte(body)) {
+		t.Errorf("unexpected canonical body %s", *entry.CanonicalBody)
+	}
+	// base64 fields of the body are decoded by its schema
+	if entry.Rekord == nil || string(entry.Rekord.Signature.Content) != "signature" || string(entry.Rekord.Signature.PublicKey.Content) != "key" {
+		t.Errorf("unexpected rekord body %+v", entry.Rekord)
+	}
+
+	// the body of a kind the server does not know is only returned in canonical form
+	entry, err = logEntryV2(context.Background(), v1(`{"apiVersion":"0.0.1","kind":"unknown","spec":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *entry.Kind != "unknown" || entry.Rekord != nil {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+
+	if _, err := logEntryV2(context.Background(), v1(`{"apiVersion":"0.0.1","kind":"rekord","spec":{"signature":"not an object"}}`)); err == nil {
+		t.Error("malformed body was decoded")
+	}
+}
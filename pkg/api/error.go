@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -31,26 +31,84 @@ import (
 )
 
 const (
-	trillianCommunicationError     = "Unexpected error communicating with transparency log"
-	trillianUnexpectedResult       = "Unexpected result from transparency log"
-	failedToGenerateCanonicalEntry = "Error generating canonicalized entry"
-	entryAlreadyExists             = "An equivalent entry already exists in the transparency log with UUID %v"
-	firstSizeLessThanLastSize      = "firstSize(%d) must be less than lastSize(%d)"
-	malformedUUID                  = "UUID must be a 64-character hexadecimal string"
-	malformedHash                  = "Hash must be a 64-character hexadecimal string created from SHA256 algorithm"
-	malformedPublicKey             = "Public key provided could not be parsed"
-	failedToGenerateCanonicalKey   = "Error generating canonicalized public key"
-	redisUnexpectedResult          = "Unexpected result from searching index"
-	lastSizeGreaterThanKnown       = "The tree size requested(%d) was greater than what is currently observable(%d)"
+	trillianCommunicationError        = "Unexpected error communicating with transparency log"
+	trillianUnexpectedResult          = "Unexpected result from transparency log"
+	failedToGenerateCanonicalEntry    = "Error generating canonicalized entry"
+	entryAlreadyExists                = "An equivalent entry already exists in the transparency log with UUID %v"
+	firstSizeLessThanLastSize         = "firstSize(%d) must be less than lastSize(%d)"
+	malformedUUID                     = "UUID must be a 64-character hexadecimal string"
+	malformedHash                     = "Hash must be a hexadecimal string created from the SHA256, SHA384 or SHA512 algorithm"
+	malformedPublicKey                = "Public key provided could not be parsed"
+	malformedRelease                  = "Release must be specified in the form name@version"
+	identityWithoutIssuer             = "A certificate identity must be searched for along with the OIDC issuer that authenticated it"
+	failedToGenerateCanonicalKey      = "Error generating canonicalized public key"
+	redisUnexpectedResult             = "Unexpected result from searching index"
+	lastSizeGreaterThanKnown          = "The tree size requested(%d) was greater than what is currently observable(%d)"
+	kindDisabled                      = "Entries of kind '%v' are not currently accepted by this instance"
+	entryRateExceeded                 = "Too many entries are being submitted; try again later"
+	writeRateExceeded                 = "Too many requests to add entries have been made; try again later"
+	entryQuotaExceeded                = "The quota of entries this client may add has been used up; try again once it is reset"
+	authenticationRequired            = "Adding entries requires a valid OIDC token from the issuer trusted by this instance"
+	tenantWriteForbidden              = "This client may not add entries to tenant %v"
+	standbyInstance                   = "This instance is on standby and does not accept new entries"
+	readOnlyInstance                  = "This instance is read-only and does not accept new entries"
+	invalidEmbeddedSCT                = "The SCTs embedded in the signing certificate could not be verified: %v"
+	keyRejectedByPolicy               = "The signing key %X was rejected by the policy of this instance: %v"
+	certificateNotValid               = "The signing certificate is not valid at the time the entry is integrated: %v"
+	entityTooLarge                    = "The content of %v is larger than the %v bytes this instance accepts"
+	malformedContinuationToken        = "Continuation token is invalid or was returned for a different query"
+	negativeLogIndex                  = "Log index must not be negative"
+	invalidTreeSize                   = "Tree sizes must be at least 1"
+	tooManyStreams                    = "Too many clients are streaming entries from this instance; try again later"
+	leafHashOrCanonicalEntry          = "Exactly one of leafHash and canonicalEntry must be given"
+	endBeforeStart                    = "end(%d) must not be before start(%d)"
+	malformedKeyHint                  = "Key hint must be base64-encoded"
+	invalidSignature                  = "The signature of the entry could not be verified: %v"
+	fetchFailed                       = "The content of %v could not be fetched: %v"
+	requestBodyTooLarge               = "Request bodies must not be larger than %v bytes"
+	searchIndexNotEnabled             = "Search Index API not enabled in this Rekor instance"
+	graphqlMethodNotAllowed           = "GraphQL queries must be posted as JSON"
+	attestationUploadMethodNotAllowed = "Attestations must be uploaded with POST"
+	attestationStorageNotEnabled      = "Attestation storage is not enabled in this Rekor instance"
+	attestationTooLarge               = "Attestations must not be larger than %v bytes"
+	failedToStoreAttestation          = "Error storing attestation"
+	failedToReadAttestation           = "Error reading stored attestation"
+	attestationLookupMethodNotAllowed = "Stored attestations must be retrieved with GET"
+	malformedAttestationDigest        = "Attestations are retrieved by the hex-encoded SHA256 digest of their content"
+	attestationNotFound               = "No attestation is stored with this digest"
 )
 
+// errorMsg returns the body of an error response, which is also an RFC 7807 problem details object of the
+// type about:blank
 func errorMsg(message string, code int) *models.Error {
 	return &models.Error{
 		Code:    int64(code),
 		Message: message,
+		Type:    "about:blank",
+		Title:   http.StatusText(code),
+		Status:  int64(code),
+		Detail:  message,
 	}
 }
 
+// requestErrorMsg returns the body of an error response to r, carrying the ID of r so that operators can find
+// the lines logged while serving it
+func requestErrorMsg(r *http.Request, message string, code int) *models.Error {
+	e := errorMsg(message, code)
+	e.RequestID = log.RequestID(r)
+	return e
+}
+
+// fieldValue returns the value following key in fields, which holds alternating keys and values
+func fieldValue(fields []interface{}, key string) (interface{}, bool) {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == key {
+			return fields[i+1], true
+		}
+	}
+	return nil, false
+}
+
 func handleRekorAPIError(params interface{}, code int, err error, message string, fields ...interface{}) middleware.Responder {
 	if message == "" {
 		message = http.StatusText(code)
@@ -68,14 +126,31 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 		}
 	}
 
+	payload := func(r *http.Request) *models.Error {
+		e := problem(message, code, err)
+		e.RequestID = log.RequestID(r)
+		return e
+	}
+
 	switch params := params.(type) {
+	case entries.GetLogEntriesByRangeParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusNotFound:
+			return entries.NewGetLogEntriesByRangeNotFound()
+		default:
+			return entries.NewGetLogEntriesByRangeDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.StreamLogEntriesParams:
+		logMsg(params.HTTPRequest)
+		return entries.NewStreamLogEntriesDefault(code).WithPayload(payload(params.HTTPRequest))
 	case entries.GetLogEntryByIndexParams:
 		logMsg(params.HTTPRequest)
 		switch code {
 		case http.StatusNotFound:
 			return entries.NewGetLogEntryByIndexNotFound()
 		default:
-			return entries.NewGetLogEntryByIndexDefault(code).WithPayload(errorMsg(message, code))
+			return entries.NewGetLogEntryByIndexDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
 	case entries.GetLogEntryByUUIDParams:
 		logMsg(params.HTTPRequest)
@@ -83,7 +158,23 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 		case http.StatusNotFound:
 			return entries.NewGetLogEntryByUUIDNotFound()
 		default:
-			return entries.NewGetLogEntryByUUIDDefault(code).WithPayload(errorMsg(message, code))
+			return entries.NewGetLogEntryByUUIDDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.GetLogEntryV2ByIndexParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusNotFound:
+			return entries.NewGetLogEntryV2ByIndexNotFound()
+		default:
+			return entries.NewGetLogEntryV2ByIndexDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.GetLogEntryV2ByUUIDParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusNotFound:
+			return entries.NewGetLogEntryV2ByUUIDNotFound()
+		default:
+			return entries.NewGetLogEntryV2ByUUIDDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
 	case entries.GetLogEntryProofParams:
 		logMsg(params.HTTPRequest)
@@ -91,59 +182,119 @@ func handleRekorAPIError(params interface{}, code int, err error, message string
 		case http.StatusNotFound:
 			return entries.NewGetLogEntryProofNotFound()
 		default:
-			return entries.NewGetLogEntryProofDefault(code).WithPayload(errorMsg(message, code))
+			return entries.NewGetLogEntryProofDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.GetLogEntryProofByLeafParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return entries.NewGetLogEntryProofByLeafBadRequest().WithPayload(payload(params.HTTPRequest))
+		case http.StatusNotFound:
+			return entries.NewGetLogEntryProofByLeafNotFound()
+		default:
+			return entries.NewGetLogEntryProofByLeafDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.GetLogEntryIndexesByTimeParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return entries.NewGetLogEntryIndexesByTimeBadRequest().WithPayload(payload(params.HTTPRequest))
+		default:
+			return entries.NewGetLogEntryIndexesByTimeDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
 	case entries.CreateLogEntryParams:
 		logMsg(params.HTTPRequest)
 		switch code {
 		case http.StatusBadRequest:
-			return entries.NewCreateLogEntryBadRequest().WithPayload(errorMsg(message, code))
+			return entries.NewCreateLogEntryBadRequest().WithPayload(payload(params.HTTPRequest))
+		case http.StatusConflict:
+			resp := entries.NewCreateLogEntryConflict().WithPayload(payload(params.HTTPRequest))
+			if entryURL, ok := fieldValue(fields, "entryURL"); ok {
+				resp.SetLocation(entryURL.(strfmt.URI))
+			}
+			if uuid, ok := fieldValue(fields, "uuid"); ok {
+				resp.SetETag(entryETag(models.LogEntry{uuid.(string): models.LogEntryAnon{}}))
+			}
+			if logIndex, ok := fieldValue(fields, "logIndex"); ok {
+				resp.SetXRekorLogIndex(logIndex.(int64))
+			}
+			return resp
+		default:
+			return entries.NewCreateLogEntryDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.ValidateLogEntryParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return entries.NewValidateLogEntryBadRequest().WithPayload(payload(params.HTTPRequest))
 		case http.StatusConflict:
-			resp := entries.NewCreateLogEntryConflict().WithPayload(errorMsg(message, code))
-			locationFound := false
-			for _, field := range fields {
-				if locationFound {
-					existingURL := field.(strfmt.URI)
-					resp.SetLocation(existingURL)
-					break
-				} else if field.(string) == "entryURL" {
-					locationFound = true
-					continue
-				}
+			resp := entries.NewValidateLogEntryConflict().WithPayload(payload(params.HTTPRequest))
+			if entryURL, ok := fieldValue(fields, "entryURL"); ok {
+				resp.SetLocation(entryURL.(strfmt.URI))
+			}
+			if uuid, ok := fieldValue(fields, "uuid"); ok {
+				resp.SetETag(entryETag(models.LogEntry{uuid.(string): models.LogEntryAnon{}}))
+			}
+			if logIndex, ok := fieldValue(fields, "logIndex"); ok {
+				resp.SetXRekorLogIndex(logIndex.(int64))
 			}
 			return resp
 		default:
-			return entries.NewCreateLogEntryDefault(code).WithPayload(errorMsg(message, code))
+			return entries.NewValidateLogEntryDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case entries.CreateLogEntriesParams:
+		logMsg(params.HTTPRequest)
+		switch code {
+		case http.StatusBadRequest:
+			return entries.NewCreateLogEntriesBadRequest().WithPayload(payload(params.HTTPRequest))
+		default:
+			return entries.NewCreateLogEntriesDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
 	case entries.SearchLogQueryParams:
 		logMsg(params.HTTPRequest)
 		switch code {
 		case http.StatusBadRequest:
-			return entries.NewSearchLogQueryBadRequest().WithPayload(errorMsg(message, code))
+			return entries.NewSearchLogQueryBadRequest().WithPayload(payload(params.HTTPRequest))
 		default:
-			return entries.NewSearchLogQueryDefault(code).WithPayload(errorMsg(message, code))
+			return entries.NewSearchLogQueryDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
+	case tlog.GetLogCheckpointParams:
+		logMsg(params.HTTPRequest)
+		return tlog.NewGetLogCheckpointDefault(code).WithPayload(payload(params.HTTPRequest))
 	case tlog.GetLogInfoParams:
 		logMsg(params.HTTPRequest)
-		return tlog.NewGetLogInfoDefault(code).WithPayload(errorMsg(message, code))
+		return tlog.NewGetLogInfoDefault(code).WithPayload(payload(params.HTTPRequest))
 	case tlog.GetLogProofParams:
 		logMsg(params.HTTPRequest)
 		switch code {
 		case http.StatusBadRequest:
-			return tlog.NewGetLogProofBadRequest().WithPayload(errorMsg(message, code))
+			return tlog.NewGetLogProofBadRequest().WithPayload(payload(params.HTTPRequest))
 		default:
-			return tlog.NewGetLogProofDefault(code).WithPayload(errorMsg(message, code))
+			return tlog.NewGetLogProofDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
+	case tlog.GetLogStatsParams:
+		logMsg(params.HTTPRequest)
+		return tlog.NewGetLogStatsDefault(code).WithPayload(payload(params.HTTPRequest))
 	case tlog.GetPublicKeyParams:
 		logMsg(params.HTTPRequest)
-		return tlog.NewGetPublicKeyDefault(code).WithPayload(errorMsg(message, code))
+		switch code {
+		case http.StatusBadRequest:
+			return tlog.NewGetPublicKeyBadRequest().WithPayload(payload(params.HTTPRequest))
+		case http.StatusNotFound:
+			return tlog.NewGetPublicKeyNotFound()
+		default:
+			return tlog.NewGetPublicKeyDefault(code).WithPayload(payload(params.HTTPRequest))
+		}
+	case tlog.GetRekorConfigurationParams:
+		logMsg(params.HTTPRequest)
+		return tlog.NewGetRekorConfigurationDefault(code).WithPayload(payload(params.HTTPRequest))
 	case index.SearchIndexParams:
 		logMsg(params.HTTPRequest)
 		switch code {
 		case http.StatusBadRequest:
-			return index.NewSearchIndexBadRequest().WithPayload(errorMsg(message, code))
+			return index.NewSearchIndexBadRequest().WithPayload(payload(params.HTTPRequest))
 		default:
-			return index.NewSearchIndexDefault(code).WithPayload(errorMsg(message, code))
+			return index.NewSearchIndexDefault(code).WithPayload(payload(params.HTTPRequest))
 		}
 	default:
 		log.Logger.Errorf("unable to find method for type %T; error: %v", params, err)
@@ -0,0 +1,561 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	roleActive  = "active"
+	roleStandby = "standby"
+)
+
+var errNotPaired = errors.New("this instance is not part of a failover pair")
+
+// SignedTreeHead is a log root as signed by the Trillian log server
+type SignedTreeHead struct {
+	KeyHint   []byte `json:"keyHint"`
+	LogRoot   []byte `json:"logRoot"`
+	Signature []byte `json:"signature"`
+}
+
+func newSignedTreeHead(slr *trillian.SignedLogRoot) SignedTreeHead {
+	return SignedTreeHead{KeyHint: slr.KeyHint, LogRoot: slr.LogRoot, Signature: slr.LogRootSignature}
+}
+
+func (s SignedTreeHead) signedLogRoot() *trillian.SignedLogRoot {
+	return &trillian.SignedLogRoot{KeyHint: s.KeyHint, LogRoot: s.LogRoot, LogRootSignature: s.Signature}
+}
+
+// HandoffRecord documents a transfer of the active role between the instances of a failover pair. It carries
+// the tree head the outgoing instance signed off on once it had stopped accepting entries, and the incoming
+// instance only takes over once its own tree has been verified to be consistent with it.
+type HandoffRecord struct {
+	From           string         `json:"from"`
+	To             string         `json:"to,omitempty"`
+	TreeID         int64          `json:"treeID"`
+	TreeSize       uint64         `json:"treeSize"`
+	RootHash       string         `json:"rootHash"`
+	SignedTreeHead SignedTreeHead `json:"signedTreeHead"`
+	SteppedDownAt  time.Time      `json:"steppedDownAt"`
+	PromotedAt     *time.Time     `json:"promotedAt,omitempty"`
+	// Forced is set if the outgoing instance could not be reached, in which case the record holds the last tree
+	// head of the peer that this instance had verified
+	Forced bool `json:"forced,omitempty"`
+}
+
+// FailoverStatus is the role of an instance, and the outcome of the last comparison of its tree with its peer's
+type FailoverStatus struct {
+	Instance string `json:"instance"`
+	Role     string `json:"role"`
+	Peer     string `json:"peer,omitempty"`
+	// VerifiedTreeSize is the size of the last tree head of the peer found to be consistent with the local tree
+	VerifiedTreeSize uint64         `json:"verifiedTreeSize"`
+	LastCheck        *time.Time     `json:"lastCheck,omitempty"`
+	Consistent       bool           `json:"consistent"`
+	Error            string         `json:"error,omitempty"`
+	LastHandoff      *HandoffRecord `json:"lastHandoff,omitempty"`
+}
+
+// failoverPeer is the other instance of a failover pair
+type failoverPeer interface {
+	signedTreeHead(ctx context.Context) (*trillian.SignedLogRoot, error)
+	consistencyProof(ctx context.Context, first, last uint64) ([][]byte, error)
+	status(ctx context.Context) (*FailoverStatus, error)
+	stepDown(ctx context.Context) (*HandoffRecord, error)
+}
+
+// failoverLog is the tree served by this instance
+type failoverLog interface {
+	signedTreeHead(ctx context.Context) (*trillian.SignedLogRoot, error)
+	consistencyProof(ctx context.Context, first, last uint64) ([][]byte, error)
+}
+
+// failoverState tracks whether this instance is the one of its pair accepting new entries
+type failoverState struct {
+	// entries is held for reading while an entry is being added, and for writing while the role changes, so that
+	// stepping down waits for the entries in flight
+	entries sync.RWMutex
+
+	mu       sync.Mutex
+	role     string
+	peer     failoverPeer
+	local    failoverLog
+	verifier *tclient.LogVerifier
+	treeID   int64
+	status   FailoverStatus
+	// verified is the last signed tree head of the peer found to be consistent with the local tree
+	verified *trillian.SignedLogRoot
+
+	checkInterval  time.Duration
+	handoffTimeout time.Duration
+}
+
+var failover = &failoverState{role: roleActive}
+
+// configureFailover sets up the role of this instance from the server configuration; an instance without a peer
+// is always active
+func configureFailover(verifier *tclient.LogVerifier, treeID int64) (*failoverState, error) {
+	instance := viper.GetString("failover.instance_name")
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+	f := &failoverState{
+		role:           roleActive,
+		local:          trillianLog{},
+		verifier:       verifier,
+		treeID:         treeID,
+		checkInterval:  viper.GetDuration("failover.check_interval"),
+		handoffTimeout: viper.GetDuration("failover.handoff_timeout"),
+		status:         FailoverStatus{Instance: instance},
+	}
+
+	peerURL := viper.GetString("failover.peer_url")
+	if peerURL == "" {
+		reportRole(roleActive)
+		return f, nil
+	}
+	role := viper.GetString("failover.role")
+	if role != roleActive && role != roleStandby {
+		return nil, fmt.Errorf("failover.role must be %v or %v", roleActive, roleStandby)
+	}
+	if f.checkInterval <= 0 || f.handoffTimeout <= 0 {
+		return nil, errors.New("failover.check_interval and failover.handoff_timeout must be positive")
+	}
+	peer, err := newRekorPeer(peerURL, viper.GetString("failover.peer_admin_url"), viper.GetString("failover.peer_admin_token_file"))
+	if err != nil {
+		return nil, err
+	}
+	f.peer = peer
+	f.role = role
+	f.status.Peer = peerURL
+	reportRole(role)
+	return f, nil
+}
+
+// start refuses to become active while the peer reports that it is active, and then compares the local tree with
+// the peer's whenever this instance is on standby
+func (f *failoverState) start(ctx context.Context) {
+	if f.peer == nil {
+		return
+	}
+	if f.currentRole() == roleActive {
+		if s, err := f.peer.status(ctx); err == nil && s.Role == roleActive {
+			log.Logger.Errorw("peer is already active; starting on standby instead", "peer", f.status.Peer)
+			f.setRole(roleStandby)
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(f.checkInterval)
+		defer ticker.Stop()
+		for {
+			if f.currentRole() == roleStandby {
+				if err := f.check(ctx); err != nil {
+					log.Logger.Errorw("tree of standby is not consistent with its peer", "peer", f.status.Peer, "error", err)
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// beginEntry reports whether this instance accepts new entries; if it does, done must be called once the entry
+// has been submitted to the log
+func (f *failoverState) beginEntry() (done func(), ok bool) {
+	f.entries.RLock()
+	if f.currentRole() != roleActive {
+		f.entries.RUnlock()
+		return nil, false
+	}
+	return f.entries.RUnlock, true
+}
+
+func (f *failoverState) currentRole() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.role
+}
+
+func (f *failoverState) setRole(role string) {
+	f.entries.Lock()
+	defer f.entries.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.role = role
+	reportRole(role)
+}
+
+func reportRole(role string) {
+	if role == roleActive {
+		metricFailoverActive.Set(1)
+	} else {
+		metricFailoverActive.Set(0)
+	}
+}
+
+func (f *failoverState) currentStatus() FailoverStatus {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.status
+	s.Role = f.role
+	return s
+}
+
+// check verifies that the latest tree heads of this instance and its peer are consistent with each other
+func (f *failoverState) check(ctx context.Context) error {
+	if f.peer == nil {
+		return errNotPaired
+	}
+	peerSLR, err := f.verifyConsistent(ctx)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	f.status.LastCheck = &now
+	f.status.Consistent = err == nil
+	f.status.Error = ""
+	if err != nil {
+		f.status.Error = err.Error()
+		metricFailoverConsistent.Set(0)
+		return err
+	}
+	root, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, peerSLR)
+	if err != nil {
+		return err
+	}
+	f.verified = peerSLR
+	f.status.VerifiedTreeSize = root.TreeSize
+	metricFailoverConsistent.Set(1)
+	return nil
+}
+
+// verifyConsistent returns the tree head of the peer once it has been verified against the local tree
+func (f *failoverState) verifyConsistent(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	peerSLR, err := f.peer.signedTreeHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching tree head of peer: %w", err)
+	}
+	if err := f.verifyAgainstLocal(ctx, peerSLR, func(first, last uint64) ([][]byte, error) {
+		return f.peer.consistencyProof(ctx, first, last)
+	}); err != nil {
+		return nil, err
+	}
+	return peerSLR, nil
+}
+
+// verifyAgainstLocal checks that slr is consistent with the latest local tree head, using a consistency proof
+// from whichever of the two trees is larger; peerProof returns a proof from the tree of slr
+func (f *failoverState) verifyAgainstLocal(ctx context.Context, slr *trillian.SignedLogRoot, peerProof func(first, last uint64) ([][]byte, error)) error {
+	root, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, slr)
+	if err != nil {
+		return fmt.Errorf("error verifying tree head of peer: %w", err)
+	}
+	localSLR, err := f.local.signedTreeHead(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching local tree head: %w", err)
+	}
+	localRoot, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, localSLR)
+	if err != nil {
+		return fmt.Errorf("error verifying local tree head: %w", err)
+	}
+
+	var proof [][]byte
+	if localRoot.TreeSize <= root.TreeSize {
+		if localRoot.TreeSize != 0 && localRoot.TreeSize != root.TreeSize {
+			if proof, err = peerProof(localRoot.TreeSize, root.TreeSize); err != nil {
+				return fmt.Errorf("error fetching consistency proof: %w", err)
+			}
+		}
+		_, err = f.verifier.VerifyRoot(localRoot, slr, proof)
+		return err
+	}
+	if root.TreeSize != 0 {
+		if proof, err = f.local.consistencyProof(ctx, root.TreeSize, localRoot.TreeSize); err != nil {
+			return fmt.Errorf("error fetching consistency proof: %w", err)
+		}
+	}
+	_, err = f.verifier.VerifyRoot(root, localSLR, proof)
+	return err
+}
+
+// stepDown stops this instance from accepting entries, waiting for those in flight, and returns the record of the
+// handoff for the peer to verify before it takes over
+func (f *failoverState) stepDown(ctx context.Context) (*HandoffRecord, error) {
+	if f.peer == nil {
+		return nil, errNotPaired
+	}
+	f.setRole(roleStandby)
+
+	slr, err := f.local.signedTreeHead(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching local tree head: %w", err)
+	}
+	root, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, slr)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying local tree head: %w", err)
+	}
+	record := &HandoffRecord{
+		From:           f.status.Instance,
+		TreeID:         f.treeID,
+		TreeSize:       root.TreeSize,
+		RootHash:       hex.EncodeToString(root.RootHash),
+		SignedTreeHead: newSignedTreeHead(slr),
+		SteppedDownAt:  time.Now(),
+	}
+	f.mu.Lock()
+	f.status.LastHandoff = record
+	f.mu.Unlock()
+	return record, nil
+}
+
+// promote makes this instance the active one of its pair. The peer is asked to step down first; if it cannot be
+// reached and force is set, the last tree head of the peer verified by check is used instead. In both cases the
+// local tree must reach and be consistent with the tree head of the handoff within the handoff timeout.
+func (f *failoverState) promote(ctx context.Context, force bool) (*HandoffRecord, error) {
+	if f.peer == nil {
+		return nil, errNotPaired
+	}
+	if f.currentRole() == roleActive {
+		return nil, errors.New("this instance is already active")
+	}
+
+	record, err := f.peer.stepDown(ctx)
+	if err != nil {
+		if !force {
+			return nil, fmt.Errorf("peer did not step down: %w", err)
+		}
+		if record, err = f.forcedRecord(); err != nil {
+			return nil, err
+		}
+	}
+
+	root, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, record.SignedTreeHead.signedLogRoot())
+	if err != nil {
+		return nil, fmt.Errorf("error verifying tree head of handoff: %w", err)
+	}
+	if record.TreeID != f.treeID || record.TreeSize != root.TreeSize || !strings.EqualFold(record.RootHash, hex.EncodeToString(root.RootHash)) {
+		return nil, errors.New("handoff record does not match its signed tree head")
+	}
+	if err := f.awaitTreeHead(ctx, root.TreeSize, record.SignedTreeHead.signedLogRoot()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record.To = f.status.Instance
+	record.PromotedAt = &now
+	f.setRole(roleActive)
+	f.mu.Lock()
+	f.status.LastHandoff = record
+	f.mu.Unlock()
+	return record, nil
+}
+
+func (f *failoverState) forcedRecord() (*HandoffRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.verified == nil {
+		return nil, errors.New("no tree head of the peer has been verified to force the handoff from")
+	}
+	root, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, f.verified)
+	if err != nil {
+		return nil, err
+	}
+	return &HandoffRecord{
+		From:           f.status.Peer,
+		TreeID:         f.treeID,
+		TreeSize:       root.TreeSize,
+		RootHash:       hex.EncodeToString(root.RootHash),
+		SignedTreeHead: newSignedTreeHead(f.verified),
+		SteppedDownAt:  time.Now(),
+		Forced:         true,
+	}, nil
+}
+
+// awaitTreeHead waits until the local tree has grown to at least size and is consistent with slr
+func (f *failoverState) awaitTreeHead(ctx context.Context, size uint64, slr *trillian.SignedLogRoot) error {
+	ctx, cancel := context.WithTimeout(ctx, f.handoffTimeout)
+	defer cancel()
+	interval := f.handoffTimeout / 20
+	for {
+		if localSLR, err := f.local.signedTreeHead(ctx); err == nil {
+			localRoot, err := tcrypto.VerifySignedLogRoot(f.verifier.PubKey, f.verifier.SigHash, localSLR)
+			if err == nil && localRoot.TreeSize >= size {
+				return f.verifyAgainstLocal(ctx, slr, func(uint64, uint64) ([][]byte, error) {
+					return nil, errors.New("local tree is smaller than the tree head of the handoff")
+				})
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("local tree did not reach the size of the handoff (%d): %w", size, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// trillianLog is the tree of this instance in the Trillian log server
+type trillianLog struct{}
+
+func (trillianLog) signedTreeHead(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	tc := NewTrillianClient(ctx)
+	resp := tc.getLatest(0)
+	if resp.status != codes.OK {
+		return nil, resp.err
+	}
+	return resp.getLatestResult.SignedLogRoot, nil
+}
+
+func (trillianLog) consistencyProof(ctx context.Context, first, last uint64) ([][]byte, error) {
+	tc := NewTrillianClient(ctx)
+	resp := tc.getConsistencyProof(int64(first), int64(last))
+	if resp.status != codes.OK {
+		return nil, resp.err
+	}
+	return resp.getConsistencyProofResult.Proof.Hashes, nil
+}
+
+// rekorPeer reaches the other instance of the pair through its public API, and its admin API for the handoff
+type rekorPeer struct {
+	client     *client.Rekor
+	adminURL   string
+	token      string
+	httpClient *http.Client
+}
+
+func newRekorPeer(peerURL, adminURL, tokenFile string) (*rekorPeer, error) {
+	u, err := url.Parse(peerURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid failover.peer_url: %w", err)
+	}
+	if adminURL == "" {
+		return nil, errors.New("failover.peer_admin_url must be set when failover.peer_url is")
+	}
+	if tokenFile == "" {
+		tokenFile = viper.GetString("admin.token_file")
+	}
+	b, err := ioutil.ReadFile(filepath.Clean(tokenFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading admin token of peer: %w", err)
+	}
+	timeout := viper.GetDuration("failover.peer_timeout")
+	transport := httptransport.New(u.Host, client.DefaultBasePath, []string{u.Scheme})
+	transport.Transport = &http.Transport{ResponseHeaderTimeout: timeout}
+	return &rekorPeer{
+		client:     client.New(transport, strfmt.Default),
+		adminURL:   strings.TrimSuffix(adminURL, "/"),
+		token:      strings.TrimSpace(string(b)),
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (p *rekorPeer) signedTreeHead(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	resp, err := p.client.Tlog.GetLogInfo(tlog.NewGetLogInfoParamsWithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	sth := resp.Payload.SignedTreeHead
+	if sth == nil || sth.KeyHint == nil || sth.LogRoot == nil || sth.Signature == nil {
+		return nil, errors.New("peer did not return a signed tree head")
+	}
+	return &trillian.SignedLogRoot{KeyHint: *sth.KeyHint, LogRoot: *sth.LogRoot, LogRootSignature: *sth.Signature}, nil
+}
+
+func (p *rekorPeer) consistencyProof(ctx context.Context, first, last uint64) ([][]byte, error) {
+	params := tlog.NewGetLogProofParamsWithContext(ctx)
+	firstSize := int64(first)
+	params.FirstSize = &firstSize
+	params.LastSize = int64(last)
+	resp, err := p.client.Tlog.GetLogProof(params)
+	if err != nil {
+		return nil, err
+	}
+	var proof [][]byte
+	for _, h := range resp.Payload.Hashes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hash in consistency proof: %w", err)
+		}
+		proof = append(proof, b)
+	}
+	return proof, nil
+}
+
+func (p *rekorPeer) status(ctx context.Context) (*FailoverStatus, error) {
+	var s FailoverStatus
+	if err := p.admin(ctx, http.MethodGet, "/api/v1/admin/failover", &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (p *rekorPeer) stepDown(ctx context.Context) (*HandoffRecord, error) {
+	var record HandoffRecord
+	if err := p.admin(ctx, http.MethodPost, "/api/v1/admin/failover/stepdown", &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (p *rekorPeer) admin(ctx context.Context, method, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, p.adminURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.token)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("admin API of peer returned %v: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,295 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+)
+
+var (
+	leafA = rfc6962.DefaultHasher.HashLeaf([]byte("a"))
+	leafB = rfc6962.DefaultHasher.HashLeaf([]byte("b"))
+	leafC = rfc6962.DefaultHasher.HashLeaf([]byte("c"))
+)
+
+// fakeTree is a log of up to two leaves, where the second leaf can differ between trees
+type fakeTree struct {
+	mu     sync.Mutex
+	signer *tcrypto.Signer
+	size   uint64
+	second []byte
+}
+
+func (f *fakeTree) root(size uint64) []byte {
+	switch size {
+	case 0:
+		return rfc6962.DefaultHasher.EmptyRoot()
+	case 1:
+		return leafA
+	default:
+		return rfc6962.DefaultHasher.HashChildren(leafA, f.second)
+	}
+}
+
+func (f *fakeTree) setSize(size uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.size = size
+}
+
+func (f *fakeTree) signedTreeHead(ctx context.Context) (*trillian.SignedLogRoot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.signer.SignLogRoot(&types.LogRootV1{TreeSize: f.size, RootHash: f.root(f.size)})
+}
+
+func (f *fakeTree) consistencyProof(ctx context.Context, first, last uint64) ([][]byte, error) {
+	if first != 1 || last != 2 {
+		return nil, errors.New("unexpected proof request")
+	}
+	return [][]byte{f.second}, nil
+}
+
+type fakePeer struct {
+	*fakeTree
+	role       string
+	onStepDown func(ctx context.Context) (*HandoffRecord, error)
+}
+
+func (p *fakePeer) status(ctx context.Context) (*FailoverStatus, error) {
+	return &FailoverStatus{Role: p.role}, nil
+}
+
+func (p *fakePeer) stepDown(ctx context.Context) (*HandoffRecord, error) {
+	return p.onStepDown(ctx)
+}
+
+func newTestFailover(t *testing.T, role string, localSize uint64, peer *fakePeer) (*failoverState, *fakeTree) {
+	t.Helper()
+	local := &fakeTree{signer: peer.signer, size: localSize, second: leafB}
+	return &failoverState{
+		role:           role,
+		peer:           peer,
+		local:          local,
+		verifier:       tclient.NewLogVerifier(rfc6962.DefaultHasher, peer.signer.Public(), crypto.SHA256),
+		treeID:         1,
+		handoffTimeout: 100 * time.Millisecond,
+		status:         FailoverStatus{Instance: "b", Peer: "a"},
+	}, local
+}
+
+func newTestSigner(t *testing.T) *tcrypto.Signer {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tcrypto.NewSHA256Signer(priv)
+}
+
+func TestFailoverCheck(t *testing.T) {
+	signer := newTestSigner(t)
+	tests := []struct {
+		caseDesc      string
+		localSize     uint64
+		peerSize      uint64
+		peerSecond    []byte
+		peerSigner    *tcrypto.Signer
+		expectSuccess bool
+	}{
+		{caseDesc: "same tree head", localSize: 2, peerSize: 2, peerSecond: leafB, expectSuccess: true},
+		{caseDesc: "peer is ahead", localSize: 1, peerSize: 2, peerSecond: leafB, expectSuccess: true},
+		{caseDesc: "standby is ahead", localSize: 2, peerSize: 1, peerSecond: leafB, expectSuccess: true},
+		{caseDesc: "empty standby", localSize: 0, peerSize: 2, peerSecond: leafB, expectSuccess: true},
+		{caseDesc: "trees have forked", localSize: 2, peerSize: 2, peerSecond: leafC, expectSuccess: false},
+		{caseDesc: "tree head signed by another key", localSize: 2, peerSize: 2, peerSecond: leafB, peerSigner: newTestSigner(t), expectSuccess: false},
+	}
+
+	for _, tc := range tests {
+		peerSigner := signer
+		if tc.peerSigner != nil {
+			peerSigner = tc.peerSigner
+		}
+		f, _ := newTestFailover(t, roleStandby, tc.localSize, &fakePeer{fakeTree: &fakeTree{signer: signer, size: tc.peerSize, second: tc.peerSecond}})
+		f.peer = &fakePeer{fakeTree: &fakeTree{signer: peerSigner, size: tc.peerSize, second: tc.peerSecond}}
+
+		err := f.check(context.Background())
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+			continue
+		}
+		s := f.currentStatus()
+		if s.Consistent != tc.expectSuccess || s.LastCheck == nil || (err != nil) != (s.Error != "") {
+			t.Errorf("unexpected status in '%v': %+v", tc.caseDesc, s)
+		}
+		if tc.expectSuccess && s.VerifiedTreeSize != tc.peerSize {
+			t.Errorf("unexpected verified tree size in '%v': %v", tc.caseDesc, s.VerifiedTreeSize)
+		}
+	}
+}
+
+func TestFailoverHandoff(t *testing.T) {
+	signer := newTestSigner(t)
+	ctx := context.Background()
+
+	// the outgoing instance hands off at size 2, which the standby reaches shortly afterwards
+	active, _ := newTestFailover(t, roleActive, 2, &fakePeer{fakeTree: &fakeTree{signer: signer, second: leafB}})
+	peer := &fakePeer{fakeTree: &fakeTree{signer: signer, size: 2, second: leafB}, onStepDown: active.stepDown}
+	standby, local := newTestFailover(t, roleStandby, 1, peer)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		local.setSize(2)
+	}()
+
+	record, err := standby.promote(ctx, false)
+	if err != nil {
+		t.Fatalf("unexpected error promoting standby: %v", err)
+	}
+	if standby.currentRole() != roleActive || active.currentRole() != roleStandby {
+		t.Errorf("unexpected roles after handoff: %v, %v", standby.currentRole(), active.currentRole())
+	}
+	if record.From != "b" || record.To != "b" || record.TreeSize != 2 || record.PromotedAt == nil || record.Forced {
+		t.Errorf("unexpected handoff record: %+v", record)
+	}
+	if _, err := standby.promote(ctx, false); err == nil {
+		t.Error("expected error promoting active instance")
+	}
+
+	// the standby does not take over a tree head it cannot reach
+	active, _ = newTestFailover(t, roleActive, 2, &fakePeer{fakeTree: &fakeTree{signer: signer, second: leafB}})
+	peer = &fakePeer{fakeTree: &fakeTree{signer: signer, size: 2, second: leafB}, onStepDown: active.stepDown}
+	standby, _ = newTestFailover(t, roleStandby, 1, peer)
+	if _, err := standby.promote(ctx, false); err == nil || standby.currentRole() != roleStandby {
+		t.Errorf("expected promotion to fail while the tree is behind the handoff: %v", err)
+	}
+
+	// nor one that is inconsistent with its own tree
+	active, activeTree := newTestFailover(t, roleActive, 2, &fakePeer{fakeTree: &fakeTree{signer: signer, second: leafB}})
+	activeTree.second = leafC
+	peer = &fakePeer{fakeTree: &fakeTree{signer: signer, size: 2, second: leafC}, onStepDown: active.stepDown}
+	standby, _ = newTestFailover(t, roleStandby, 2, peer)
+	if _, err := standby.promote(ctx, false); err == nil || standby.currentRole() != roleStandby {
+		t.Errorf("expected promotion to fail with forked trees: %v", err)
+	}
+
+	// an unreachable peer can only be taken over from explicitly, once its tree head has been verified
+	peer = &fakePeer{fakeTree: &fakeTree{signer: signer, size: 2, second: leafB}, onStepDown: func(context.Context) (*HandoffRecord, error) {
+		return nil, errors.New("connection refused")
+	}}
+	standby, _ = newTestFailover(t, roleStandby, 2, peer)
+	if _, err := standby.promote(ctx, false); err == nil {
+		t.Error("expected error promoting without the peer stepping down")
+	}
+	if _, err := standby.promote(ctx, true); err == nil {
+		t.Error("expected error forcing promotion without a verified tree head")
+	}
+	if err := standby.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if record, err := standby.promote(ctx, true); err != nil || !record.Forced || record.TreeSize != 2 {
+		t.Errorf("unexpected result forcing promotion: %+v, %v", record, err)
+	}
+}
+
+func TestFailoverStepDownWaitsForEntries(t *testing.T) {
+	signer := newTestSigner(t)
+	f, _ := newTestFailover(t, roleActive, 2, &fakePeer{fakeTree: &fakeTree{signer: signer, second: leafB}})
+
+	done, ok := f.beginEntry()
+	if !ok {
+		t.Fatal("expected active instance to accept entries")
+	}
+	stepped := make(chan struct{})
+	go func() {
+		if _, err := f.stepDown(context.Background()); err != nil {
+			t.Error(err)
+		}
+		close(stepped)
+	}()
+
+	select {
+	case <-stepped:
+		t.Fatal("stepped down with an entry in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+	done()
+	<-stepped
+
+	if _, ok := f.beginEntry(); ok {
+		t.Error("expected standby instance to reject entries")
+	}
+}
+
+func TestFailoverHandler(t *testing.T) {
+	h := &failoverHandler{token: "s3cret", f: &failoverState{role: roleActive, status: FailoverStatus{Instance: "a"}}}
+
+	do := func(method, path, token string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		caseDesc     string
+		method       string
+		path         string
+		token        string
+		expectedCode int
+	}{
+		{caseDesc: "no token", method: http.MethodGet, path: "/api/v1/admin/failover", expectedCode: http.StatusUnauthorized},
+		{caseDesc: "status", method: http.MethodGet, path: "/api/v1/admin/failover", token: "s3cret", expectedCode: http.StatusOK},
+		{caseDesc: "promote with wrong method", method: http.MethodGet, path: "/api/v1/admin/failover/promote", token: "s3cret", expectedCode: http.StatusMethodNotAllowed},
+		{caseDesc: "promote without peer", method: http.MethodPost, path: "/api/v1/admin/failover/promote", token: "s3cret", expectedCode: http.StatusBadRequest},
+		{caseDesc: "step down without peer", method: http.MethodPost, path: "/api/v1/admin/failover/stepdown", token: "s3cret", expectedCode: http.StatusBadRequest},
+		{caseDesc: "unknown path", method: http.MethodGet, path: "/api/v1/admin/failover/other", token: "s3cret", expectedCode: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		if rec := do(tc.method, tc.path, tc.token); rec.Code != tc.expectedCode {
+			t.Errorf("unexpected status in '%v': got %v, expected %v", tc.caseDesc, rec.Code, tc.expectedCode)
+		}
+	}
+
+	var s FailoverStatus
+	if err := json.Unmarshal(do(http.MethodGet, "/api/v1/admin/failover", "s3cret").Body.Bytes(), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Instance != "a" || s.Role != roleActive {
+		t.Errorf("unexpected status: %+v", s)
+	}
+}
@@ -0,0 +1,421 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// graphqlPath is where queries are served when enable_graphql_api is set
+const graphqlPath = "/api/v1/graphql"
+
+// maxGraphQLResults bounds the entries a single search resolves, as each entry is read from the log on its own
+const maxGraphQLResults = 100
+
+// graphqlSchema composes the read endpoints of the REST API, so that a page showing entries along with their
+// proofs can be served by a single query. Log indexes, tree sizes and times are 64-bit integers, which GraphQL
+// Int cannot hold; they are given as numbers or decimal strings.
+const graphqlSchema = `
+schema {
+	query: Query
+}
+
+scalar Int64
+
+type Query {
+	# The current state of the log
+	logInfo: LogInfo!
+	# The entry with the given UUID or log index, exactly one of which must be given
+	entry(uuid: String, logIndex: Int64): Entry
+	# The entries matching all of the given criteria, as searched by the index API, at most 100 at a time
+	search(
+		hash: String
+		publicKey: PublicKeyInput
+		email: String
+		principal: String
+		keyId: String
+		uri: String
+		release: String
+		image: String
+		model: String
+		certificateIdentity: String
+		oidcIssuer: String
+		piv: String
+		subjectDigest: String
+		limit: Int64
+		continuationToken: String
+	): SearchResult!
+}
+
+type LogInfo {
+	treeSize: Int64!
+	# Hex encoded
+	rootHash: String!
+	signedTreeHead: SignedTreeHead!
+}
+
+# The fields of the signed tree head, each base64 encoded
+type SignedTreeHead {
+	keyHint: String!
+	logRoot: String!
+	signature: String!
+}
+
+type Entry {
+	uuid: String!
+	logIndex: Int64!
+	integratedTime: Int64!
+	# The canonicalized entry as stored in the log, base64 encoded; attestations are held in the entry itself
+	body: String!
+	inclusionProof: InclusionProof
+}
+
+type InclusionProof {
+	logIndex: Int64!
+	treeSize: Int64!
+	# Hex encoded
+	rootHash: String!
+	# Hex encoded
+	hashes: [String!]!
+}
+
+input PublicKeyInput {
+	format: String!
+	# Base64 encoded
+	content: String
+	url: String
+}
+
+type SearchResult {
+	entries: [Entry!]!
+	# Set if more entries match; passed back to continue the search
+	continuationToken: String
+}
+`
+
+var graphqlHandler = &relay.Handler{
+	Schema: graphql.MustParseSchema(graphqlSchema, &graphqlQuery{}, graphql.MaxDepth(8), graphql.MaxParallelism(10)),
+}
+
+// ServeGraphQL serves GraphQL queries posted to /api/v1/graphql, resolving them against the same tree and
+// search index as the REST API
+func ServeGraphQL(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != graphqlPath {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_ = json.NewEncoder(w).Encode(requestErrorMsg(r, graphqlMethodNotAllowed, http.StatusMethodNotAllowed))
+			return
+		}
+		graphqlHandler.ServeHTTP(w, r)
+	})
+}
+
+// graphqlError logs apiErr and returns the message it is reported to clients with
+func graphqlError(ctx context.Context, apiErr *apiError) error {
+	message := apiErr.message
+	if message == "" {
+		message = http.StatusText(apiErr.code)
+	}
+	log.ContextLogger(ctx).Errorw("resolving GraphQL query", "statusCode", apiErr.code, "clientMessage", message, "error", apiErr.err)
+	return errors.New(message)
+}
+
+// graphqlInt64 is the Int64 scalar of the schema
+type graphqlInt64 int64
+
+func (graphqlInt64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+func (i *graphqlInt64) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		*i = graphqlInt64(input)
+	case float64:
+		// variables are decoded from JSON as floats
+		if input != math.Trunc(input) || math.Abs(input) > 1<<53 {
+			return fmt.Errorf("%v is not an integer that can be given as a number; give it as a string", input)
+		}
+		*i = graphqlInt64(input)
+	case string:
+		v, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a 64-bit integer", input)
+		}
+		*i = graphqlInt64(v)
+	default:
+		return fmt.Errorf("wrong type for Int64: %T", input)
+	}
+	return nil
+}
+
+type graphqlQuery struct{}
+
+func (graphqlQuery) LogInfo(ctx context.Context) (*graphqlLogInfo, error) {
+	logInfo, apiErr := currentLogInfo(ctx)
+	if apiErr != nil {
+		return nil, graphqlError(ctx, apiErr)
+	}
+	return &graphqlLogInfo{logInfo}, nil
+}
+
+func (graphqlQuery) Entry(ctx context.Context, args struct {
+	UUID     *string
+	LogIndex *graphqlInt64
+}) (*graphqlEntry, error) {
+	var logEntry models.LogEntry
+	var apiErr *apiError
+	switch {
+	case (args.UUID == nil) == (args.LogIndex == nil):
+		return nil, errors.New("exactly one of uuid and logIndex must be given")
+	case args.UUID != nil:
+		logEntry, apiErr = logEntryByUUID(ctx, *args.UUID)
+	case *args.LogIndex < 0:
+		return nil, errors.New(negativeLogIndex)
+	default:
+		logEntry, apiErr = logEntryByIndex(ctx, int64(*args.LogIndex))
+	}
+	if apiErr != nil {
+		if apiErr.code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, graphqlError(ctx, apiErr)
+	}
+	for uuid, entry := range logEntry {
+		e := &graphqlEntry{uuid: uuid}
+		e.once.Do(func() { e.entry = entry })
+		return e, nil
+	}
+	return nil, nil
+}
+
+type graphqlPublicKey struct {
+	Format  string
+	Content *string
+	URL     *string
+}
+
+func (graphqlQuery) Search(ctx context.Context, args struct {
+	Hash                *string
+	PublicKey           *graphqlPublicKey
+	Email               *string
+	Principal           *string
+	KeyID               *string
+	URI                 *string
+	Release             *string
+	Image               *string
+	Model               *string
+	CertificateIdentity *string
+	OidcIssuer          *string
+	Piv                 *string
+	SubjectDigest       *string
+	Limit               *graphqlInt64
+	ContinuationToken   *string
+}) (*graphqlSearchResult, error) {
+	if !viper.GetBool("enable_retrieve_api") {
+		return nil, errors.New(searchIndexNotEnabled)
+	}
+	query := &models.SearchIndex{
+		Hash:                swag.StringValue(args.Hash),
+		Email:               swag.StringValue(args.Email),
+		Principal:           swag.StringValue(args.Principal),
+		KeyID:               swag.StringValue(args.KeyID),
+		URI:                 swag.StringValue(args.URI),
+		Release:             swag.StringValue(args.Release),
+		Image:               swag.StringValue(args.Image),
+		Model:               swag.StringValue(args.Model),
+		CertificateIdentity: swag.StringValue(args.CertificateIdentity),
+		OidcIssuer:          swag.StringValue(args.OidcIssuer),
+		Piv:                 swag.StringValue(args.Piv),
+		SubjectDigest:       swag.StringValue(args.SubjectDigest),
+		ContinuationToken:   swag.StringValue(args.ContinuationToken),
+		Limit:               maxGraphQLResults,
+	}
+	if args.Limit != nil && *args.Limit > 0 && *args.Limit < maxGraphQLResults {
+		query.Limit = int64(*args.Limit)
+	}
+	if pk := args.PublicKey; pk != nil {
+		query.PublicKey = &models.SearchIndexPublicKey{Format: swag.String(pk.Format), URL: strfmt.URI(swag.StringValue(pk.URL))}
+		if pk.Content != nil {
+			content, err := base64.StdEncoding.DecodeString(*pk.Content)
+			if err != nil {
+				return nil, errors.New(malformedPublicKey)
+			}
+			query.PublicKey.Content = content
+		}
+	}
+	if err := query.Validate(strfmt.Default); err != nil {
+		return nil, err
+	}
+
+	uuids, token, apiErr := searchIndex(ctx, query)
+	if apiErr != nil {
+		return nil, graphqlError(ctx, apiErr)
+	}
+	result := &graphqlSearchResult{entries: make([]*graphqlEntry, 0, len(uuids))}
+	for _, uuid := range uuids {
+		result.entries = append(result.entries, &graphqlEntry{uuid: uuid})
+	}
+	if token != "" {
+		result.continuationToken = &token
+	}
+	return result, nil
+}
+
+type graphqlSearchResult struct {
+	entries           []*graphqlEntry
+	continuationToken *string
+}
+
+func (r *graphqlSearchResult) Entries() []*graphqlEntry {
+	return r.entries
+}
+
+func (r *graphqlSearchResult) ContinuationToken() *string {
+	return r.continuationToken
+}
+
+type graphqlLogInfo struct {
+	info *models.LogInfo
+}
+
+func (l *graphqlLogInfo) TreeSize() graphqlInt64 {
+	return graphqlInt64(swag.Int64Value(l.info.TreeSize))
+}
+
+func (l *graphqlLogInfo) RootHash() string {
+	return swag.StringValue(l.info.RootHash)
+}
+
+func (l *graphqlLogInfo) SignedTreeHead() *graphqlSignedTreeHead {
+	return &graphqlSignedTreeHead{l.info.SignedTreeHead}
+}
+
+type graphqlSignedTreeHead struct {
+	sth *models.LogInfoSignedTreeHead
+}
+
+func (s *graphqlSignedTreeHead) KeyHint() string {
+	return s.sth.KeyHint.String()
+}
+
+func (s *graphqlSignedTreeHead) LogRoot() string {
+	return s.sth.LogRoot.String()
+}
+
+func (s *graphqlSignedTreeHead) Signature() string {
+	return s.sth.Signature.String()
+}
+
+// graphqlEntry is an entry of the log, which is only read from the log once a field other than its UUID is
+// resolved
+type graphqlEntry struct {
+	uuid string
+
+	once   sync.Once
+	entry  models.LogEntryAnon
+	apiErr *apiError
+}
+
+func (e *graphqlEntry) load(ctx context.Context) (models.LogEntryAnon, error) {
+	e.once.Do(func() {
+		var logEntry models.LogEntry
+		logEntry, e.apiErr = logEntryByUUID(ctx, e.uuid)
+		if e.apiErr == nil {
+			e.entry = logEntry[e.uuid]
+		}
+	})
+	if e.apiErr != nil {
+		return models.LogEntryAnon{}, graphqlError(ctx, e.apiErr)
+	}
+	return e.entry, nil
+}
+
+func (e *graphqlEntry) UUID() string {
+	return e.uuid
+}
+
+func (e *graphqlEntry) LogIndex(ctx context.Context) (graphqlInt64, error) {
+	entry, err := e.load(ctx)
+	return graphqlInt64(swag.Int64Value(entry.LogIndex)), err
+}
+
+func (e *graphqlEntry) IntegratedTime(ctx context.Context) (graphqlInt64, error) {
+	entry, err := e.load(ctx)
+	return graphqlInt64(entry.IntegratedTime), err
+}
+
+func (e *graphqlEntry) Body(ctx context.Context) (string, error) {
+	entry, err := e.load(ctx)
+	body, _ := entry.Body.([]byte)
+	return base64.StdEncoding.EncodeToString(body), err
+}
+
+func (e *graphqlEntry) InclusionProof(ctx context.Context) (*graphqlInclusionProof, error) {
+	proof, apiErr := entryInclusionProof(ctx, e.uuid)
+	if apiErr != nil {
+		// entries are only proven once they are integrated into the tree
+		if apiErr.code == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, graphqlError(ctx, apiErr)
+	}
+	return &graphqlInclusionProof{proof}, nil
+}
+
+type graphqlInclusionProof struct {
+	proof *models.InclusionProof
+}
+
+func (p *graphqlInclusionProof) LogIndex() graphqlInt64 {
+	return graphqlInt64(swag.Int64Value(p.proof.LogIndex))
+}
+
+func (p *graphqlInclusionProof) TreeSize() graphqlInt64 {
+	return graphqlInt64(swag.Int64Value(p.proof.TreeSize))
+}
+
+func (p *graphqlInclusionProof) RootHash() string {
+	return swag.StringValue(p.proof.RootHash)
+}
+
+func (p *graphqlInclusionProof) Hashes() []string {
+	return p.proof.Hashes
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestGraphQLInt64(t *testing.T) {
+	tests := []struct {
+		caseDesc string
+		input    interface{}
+		expected int64
+		wantErr  bool
+	}{
+		{caseDesc: "literal", input: int32(42), expected: 42},
+		{caseDesc: "variable", input: float64(3000000000), expected: 3000000000},
+		{caseDesc: "string", input: "9000000000000000000", expected: 9000000000000000000},
+		{caseDesc: "fraction", input: 1.5, wantErr: true},
+		{caseDesc: "imprecise float", input: float64(1 << 60), wantErr: true},
+		{caseDesc: "not a number", input: "abc", wantErr: true},
+		{caseDesc: "wrong type", input: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		var i graphqlInt64
+		err := i.UnmarshalGraphQL(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%v: unexpected error %v", tc.caseDesc, err)
+		}
+		if err == nil && int64(i) != tc.expected {
+			t.Errorf("%v: got %v, expected %v", tc.caseDesc, i, tc.expected)
+		}
+	}
+}
+
+func TestServeGraphQL(t *testing.T) {
+	defer func(enabled bool) { viper.Set("enable_retrieve_api", enabled) }(viper.GetBool("enable_retrieve_api"))
+	viper.Set("enable_retrieve_api", false)
+
+	next := false
+	handler := ServeGraphQL(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next = true
+	}))
+	post := func(query string) (int, string) {
+		body, _ := json.Marshal(map[string]string{"query": query})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, graphqlPath, strings.NewReader(string(body))))
+		var response struct {
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || len(response.Errors) == 0 {
+			return w.Code, ""
+		}
+		return w.Code, response.Errors[0].Message
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/log", nil))
+	if !next {
+		t.Error("request for the REST API was not passed on")
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, graphqlPath, nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET returned %v with headers %v", w.Code, w.Header())
+	}
+
+	tests := []struct {
+		caseDesc string
+		query    string
+		message  string
+	}{
+		{caseDesc: "neither UUID nor index", query: "{ entry { uuid } }", message: "exactly one of uuid and logIndex must be given"},
+		{caseDesc: "both UUID and index", query: `{ entry(uuid: "ab", logIndex: 1) { uuid } }`, message: "exactly one of uuid and logIndex must be given"},
+		{caseDesc: "negative index", query: `{ entry(logIndex: "-1") { uuid } }`, message: negativeLogIndex},
+		{caseDesc: "search disabled", query: `{ search(hash: "ab") { entries { uuid } } }`, message: searchIndexNotEnabled},
+		{caseDesc: "unknown field", query: "{ tree { size } }", message: `Cannot query field "tree" on type "Query".`},
+	}
+	for _, tc := range tests {
+		if code, message := post(tc.query); code != http.StatusOK || message != tc.message {
+			t.Errorf("%v: got %v with error %q, expected %q", tc.caseDesc, code, message, tc.message)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/protobuf"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+type grpcServer struct {
+	protobuf.UnimplementedRekorServer
+}
+
+// NewGRPCServer returns a gRPC server with the Rekor service registered
+func NewGRPCServer() *grpc.Server {
+	s := grpc.NewServer()
+	protobuf.RegisterRekorServer(s, &grpcServer{})
+	return s
+}
+
+// ServeGRPC serves the Rekor gRPC service on addr until the listener fails
+func ServeGRPC(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	log.Logger.Infof("Serving rekor gRPC API at %v", lis.Addr())
+	return NewGRPCServer().Serve(lis)
+}
+
+// VerifyArtifacts looks up each digest received on the stream in the index and
+// responds with every matching entry and its inclusion proof
+func (s *grpcServer) VerifyArtifacts(stream protobuf.Rekor_VerifyArtifactsServer) error {
+	if !viper.GetBool("enable_retrieve_api") {
+		return status.Error(codes.Unimplemented, "Search Index API not enabled in this Rekor instance")
+	}
+
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &protobuf.VerifyArtifactsResponse{Digest: req.GetDigest()}
+		entries, err := verifyArtifact(ctx, req.GetDigest())
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			resp.Error = err.Error()
+		} else {
+			resp.Entries = entries
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func verifyArtifact(ctx context.Context, digest string) ([]*protobuf.EntrySummary, error) {
+	if !govalidator.IsSHA256(digest) && !govalidator.IsSHA384(digest) && !govalidator.IsSHA512(digest) {
+		return nil, errors.New(malformedHash)
+	}
+
+	indexCtx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	uuids, err := indexBackend.Lookup(indexCtx, indexKey(ctx, strings.ToLower(digest)))
+	if err != nil {
+		log.Logger.Error(err)
+		return nil, errors.New(redisUnexpectedResult)
+	}
+
+	tc := NewTrillianClient(ctx)
+	result := make([]*protobuf.EntrySummary, 0, len(uuids))
+	for _, uuid := range uuids {
+		summary, err := entrySummary(&tc, uuid)
+		if err != nil {
+			log.Logger.Error(err)
+			return nil, errors.New(trillianUnexpectedResult)
+		}
+		result = append(result, summary)
+	}
+	return result, nil
+}
+
+func entrySummary(tc *TrillianClient, uuid string) (*protobuf.EntrySummary, error) {
+	hashValue, err := hex.DecodeString(uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := tc.getLogLeavesByHash([][]byte{hashValue})
+	if resp.status != codes.OK {
+		return nil, fmt.Errorf("grpc error: %w", resp.err)
+	}
+	leaves := resp.getLeafResult.GetLeaves()
+	if len(leaves) != 1 {
+		return nil, fmt.Errorf("len(leaves): %v", len(leaves))
+	}
+	leaf := leaves[0]
+
+	// the proof is in the tree holding the entry, whose key it is verified with
+	shard, resp := tc.getLogProofByHash(hashValue)
+	if resp.status != codes.OK {
+		return nil, fmt.Errorf("grpc error: %w", resp.err)
+	}
+	result := resp.getProofResult
+	root, err := tcrypto.VerifySignedLogRoot(shard.verifier.PubKey, shard.verifier.SigHash, result.SignedLogRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Proof) != 1 {
+		return nil, fmt.Errorf("len(result.Proof) = %v", len(result.Proof))
+	}
+	proof := result.Proof[0]
+
+	hashes := make([]string, 0, len(proof.Hashes))
+	for _, hash := range proof.Hashes {
+		hashes = append(hashes, hex.EncodeToString(hash))
+	}
+
+	return &protobuf.EntrySummary{
+		Uuid:           hex.EncodeToString(leaf.GetMerkleLeafHash()),
+		LogIndex:       leaf.GetLeafIndex(),
+		IntegratedTime: leaf.GetIntegrateTimestamp().AsTime().Unix(),
+		InclusionProof: &protobuf.InclusionProof{
+			LogIndex: proof.GetLeafIndex(),
+			RootHash: hex.EncodeToString(root.RootHash),
+			TreeSize: int64(root.TreeSize),
+			Hashes:   hashes,
+		},
+	}, nil
+}
+
+// grpcCodes maps the HTTP status codes of API errors to the equivalent gRPC codes
+var grpcCodes = map[int]codes.Code{
+	http.StatusBadRequest:         codes.InvalidArgument,
+	http.StatusUnauthorized:       codes.Unauthenticated,
+	http.StatusNotFound:           codes.NotFound,
+	http.StatusConflict:           codes.AlreadyExists,
+	http.StatusTooManyRequests:    codes.ResourceExhausted,
+	http.StatusNotImplemented:     codes.Unimplemented,
+	http.StatusServiceUnavailable: codes.Unavailable,
+}
+
+// grpcError logs the error and returns it as a gRPC status
+func (e *apiError) grpcError(ctx context.Context) error {
+	message := e.message
+	if message == "" {
+		message = http.StatusText(e.code)
+	}
+	log.ContextLogger(ctx).Errorw("exiting with error", "statusCode", e.code, "clientMessage", message, "error", e.err)
+	c, ok := grpcCodes[e.code]
+	if !ok {
+		c = codes.Internal
+	}
+	return status.Error(c, message)
+}
+
+// protoLogEntry converts a log entry of the REST API, which holds a single entry keyed by its UUID
+func protoLogEntry(logEntry models.LogEntry) *protobuf.LogEntry {
+	for uuid, entry := range logEntry {
+		body, _ := entry.Body.([]byte)
+		result := &protobuf.LogEntry{
+			Uuid:           uuid,
+			LogIndex:       swag.Int64Value(entry.LogIndex),
+			Body:           body,
+			IntegratedTime: entry.IntegratedTime,
+		}
+		if entry.Verification != nil && entry.Verification.InclusionProof != nil {
+			proof := entry.Verification.InclusionProof
+			result.InclusionProof = &protobuf.InclusionProof{
+				LogIndex: swag.Int64Value(proof.LogIndex),
+				RootHash: swag.StringValue(proof.RootHash),
+				TreeSize: swag.Int64Value(proof.TreeSize),
+				Hashes:   proof.Hashes,
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// proposedEntry decodes the entry proposed by req as the REST API would decode the equivalent request body
+func proposedEntry(req *protobuf.CreateEntryRequest) (models.ProposedEntry, error) {
+	b, err := json.Marshal(struct {
+		Kind       string          `json:"kind"`
+		APIVersion string          `json:"apiVersion"`
+		Spec       json.RawMessage `json:"spec"`
+	}{req.GetKind(), req.GetApiVersion(), req.GetSpec()})
+	if err != nil {
+		return nil, fmt.Errorf("spec is not valid JSON: %w", err)
+	}
+	pe, err := models.UnmarshalProposedEntry(bytes.NewReader(b), runtime.JSONConsumer())
+	if err != nil {
+		return nil, err
+	}
+	if err := pe.Validate(strfmt.Default); err != nil {
+		return nil, err
+	}
+	return pe, nil
+}
+
+func (s *grpcServer) CreateEntry(ctx context.Context, req *protobuf.CreateEntryRequest) (*protobuf.CreateEntryResponse, error) {
+	if delay := writeLimits.reserve(grpcClientAddress(ctx), time.Now()); delay > 0 {
+		return nil, (&apiError{http.StatusTooManyRequests, fmt.Errorf("write rate limit exceeded, retry after %v", delay), writeRateExceeded}).grpcError(ctx)
+	}
+	ctx, apiErr := grpcIdentity(ctx)
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	done, apiErr := beginWrite(ctx)
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	defer done()
+	pe, err := proposedEntry(req)
+	if err != nil {
+		return nil, (&apiError{http.StatusBadRequest, err, err.Error()}).grpcError(ctx)
+	}
+	p, apiErr := prepareEntry(ctx, pe)
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	uuid, entry, apiErr := p.add(ctx)
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	return &protobuf.CreateEntryResponse{
+		Entry:             protoLogEntry(models.LogEntry{uuid: entry}),
+		SignatureReusedBy: p.reusedBy,
+	}, nil
+}
+
+func (s *grpcServer) GetEntry(ctx context.Context, req *protobuf.GetEntryRequest) (*protobuf.LogEntry, error) {
+	var logEntry models.LogEntry
+	var apiErr *apiError
+	if req.GetUuid() != "" {
+		logEntry, apiErr = logEntryByUUID(ctx, req.GetUuid())
+	} else if req.GetLogIndex() < 0 {
+		apiErr = &apiError{http.StatusBadRequest, fmt.Errorf("invalid log index %v", req.GetLogIndex()), negativeLogIndex}
+	} else {
+		logEntry, apiErr = logEntryByIndex(ctx, req.GetLogIndex())
+	}
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	return protoLogEntry(logEntry), nil
+}
+
+func (s *grpcServer) GetInclusionProof(ctx context.Context, req *protobuf.GetInclusionProofRequest) (*protobuf.InclusionProof, error) {
+	proof, apiErr := entryInclusionProof(ctx, req.GetUuid())
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	return &protobuf.InclusionProof{
+		LogIndex: swag.Int64Value(proof.LogIndex),
+		RootHash: swag.StringValue(proof.RootHash),
+		TreeSize: swag.Int64Value(proof.TreeSize),
+		Hashes:   proof.Hashes,
+	}, nil
+}
+
+func (s *grpcServer) GetConsistencyProof(ctx context.Context, req *protobuf.GetConsistencyProofRequest) (*protobuf.ConsistencyProof, error) {
+	firstSize := req.GetFirstSize()
+	if firstSize == 0 {
+		firstSize = 1
+	}
+	if firstSize < 1 || req.GetLastSize() < 1 {
+		return nil, (&apiError{http.StatusBadRequest, fmt.Errorf("invalid tree sizes %v and %v", firstSize, req.GetLastSize()), invalidTreeSize}).grpcError(ctx)
+	}
+	proof, apiErr := logConsistencyProof(ctx, firstSize, req.GetLastSize())
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	return &protobuf.ConsistencyProof{
+		RootHash: swag.StringValue(proof.RootHash),
+		Hashes:   proof.Hashes,
+	}, nil
+}
+
+// searchIndexQuery converts req to the query of the REST API, validated as the REST API validates it
+func searchIndexQuery(req *protobuf.SearchIndexRequest) (*models.SearchIndex, error) {
+	query := &models.SearchIndex{
+		Hash:                req.GetHash(),
+		Release:             req.GetRelease(),
+		Image:               req.GetImage(),
+		Model:               req.GetModel(),
+		Principal:           req.GetPrincipal(),
+		Email:               req.GetEmail(),
+		KeyID:               req.GetKeyId(),
+		URI:                 req.GetUri(),
+		OidcIssuer:          req.GetOidcIssuer(),
+		CertificateIdentity: req.GetCertificateIdentity(),
+		Piv:                 req.GetPiv(),
+		SubjectDigest:       req.GetSubjectDigest(),
+		HashPrefix:          req.GetHashPrefix(),
+		EmailSuffix:         req.GetEmailSuffix(),
+		Limit:               req.GetLimit(),
+		ContinuationToken:   req.GetContinuationToken(),
+	}
+	if pk := req.GetPublicKey(); pk != nil {
+		query.PublicKey = &models.SearchIndexPublicKey{
+			Format:  swag.String(pk.GetFormat()),
+			Content: strfmt.Base64(pk.GetContent()),
+			URL:     strfmt.URI(pk.GetUrl()),
+		}
+	}
+	if err := query.Validate(strfmt.Default); err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+func (s *grpcServer) SearchIndex(ctx context.Context, req *protobuf.SearchIndexRequest) (*protobuf.SearchIndexResponse, error) {
+	if !viper.GetBool("enable_retrieve_api") {
+		return nil, status.Error(codes.Unimplemented, "Search Index API not enabled in this Rekor instance")
+	}
+	query, err := searchIndexQuery(req)
+	if err != nil {
+		return nil, (&apiError{http.StatusBadRequest, err, err.Error()}).grpcError(ctx)
+	}
+	uuids, token, apiErr := searchIndex(ctx, query)
+	if apiErr != nil {
+		return nil, apiErr.grpcError(ctx)
+	}
+	return &protobuf.SearchIndexResponse{Uuids: uuids, ContinuationToken: token}, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/protobuf"
+)
+
+func TestProposedEntry(t *testing.T) {
+	tests := []struct {
+		caseDesc   string
+		req        *protobuf.CreateEntryRequest
+		errorFound bool
+	}{
+		{caseDesc: "valid entry", req: &protobuf.CreateEntryRequest{Kind: "rekord", ApiVersion: "0.0.1", Spec: []byte(`{"data":{}}`)}},
+		{caseDesc: "unknown kind", req: &protobuf.CreateEntryRequest{Kind: "unknown", ApiVersion: "0.0.1", Spec: []byte(`{}`)}, errorFound: true},
+		{caseDesc: "missing kind", req: &protobuf.CreateEntryRequest{ApiVersion: "0.0.1", Spec: []byte(`{}`)}, errorFound: true},
+		{caseDesc: "invalid version", req: &protobuf.CreateEntryRequest{Kind: "rekord", ApiVersion: "one", Spec: []byte(`{}`)}, errorFound: true},
+		{caseDesc: "spec not JSON", req: &protobuf.CreateEntryRequest{Kind: "rekord", ApiVersion: "0.0.1", Spec: []byte(`not JSON`)}, errorFound: true},
+		{caseDesc: "missing spec", req: &protobuf.CreateEntryRequest{Kind: "rekord", ApiVersion: "0.0.1"}, errorFound: true},
+	}
+
+	for _, tc := range tests {
+		pe, err := proposedEntry(tc.req)
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result decoding proposed entry: %v", tc.caseDesc, err)
+			continue
+		}
+		if err == nil && pe.Kind() != tc.req.Kind {
+			t.Errorf("%v: decoded entry of kind %v", tc.caseDesc, pe.Kind())
+		}
+	}
+}
+
+func TestSearchIndexQuery(t *testing.T) {
+	if _, err := searchIndexQuery(&protobuf.SearchIndexRequest{Hash: "not a hash"}); err == nil {
+		t.Error("expected error for invalid hash")
+	}
+	if _, err := searchIndexQuery(&protobuf.SearchIndexRequest{PublicKey: &protobuf.PublicKey{Format: "unknown", Content: []byte("key")}}); err == nil {
+		t.Error("expected error for unknown key format")
+	}
+	query, err := searchIndexQuery(&protobuf.SearchIndexRequest{Email: "jdoe@example.com", PublicKey: &protobuf.PublicKey{Format: "pgp", Content: []byte("key")}, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query.Email != "jdoe@example.com" || query.Limit != 10 || swag.StringValue(query.PublicKey.Format) != models.SearchIndexPublicKeyFormatPgp {
+		t.Errorf("unexpected query %+v", query)
+	}
+}
+
+func TestSearchIndexIdentityWithoutIssuer(t *testing.T) {
+	// rejected before the index is searched
+	_, _, apiErr := searchIndex(context.Background(), &models.SearchIndex{CertificateIdentity: "jdoe@example.com"})
+	if apiErr == nil || apiErr.code != http.StatusBadRequest {
+		t.Errorf("expected bad request for certificate identity without issuer, got %v", apiErr)
+	}
+}
+
+func TestGRPCError(t *testing.T) {
+	tests := []struct {
+		err     *apiError
+		code    codes.Code
+		message string
+	}{
+		{&apiError{http.StatusBadRequest, errors.New("bad"), malformedUUID}, codes.InvalidArgument, malformedUUID},
+		{&apiError{http.StatusNotFound, errors.New("missing"), ""}, codes.NotFound, http.StatusText(http.StatusNotFound)},
+		{&apiError{http.StatusConflict, errors.New("exists"), "exists"}, codes.AlreadyExists, "exists"},
+		{&apiError{http.StatusInternalServerError, errors.New("failed"), trillianUnexpectedResult}, codes.Internal, trillianUnexpectedResult},
+	}
+	for _, tc := range tests {
+		s := status.Convert(tc.err.grpcError(context.Background()))
+		if s.Code() != tc.code || s.Message() != tc.message {
+			t.Errorf("HTTP status %v mapped to %v %q, expected %v %q", tc.err.code, s.Code(), s.Message(), tc.code, tc.message)
+		}
+	}
+}
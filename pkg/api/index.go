@@ -22,77 +22,282 @@ import (
 	"encoding/hex"
 	"errors"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/sigstore/rekor/pkg/pki"
-
-	radix "github.com/mediocregopher/radix/v4"
+	"github.com/sigstore/rekor/pkg/pki/dsse"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/pki/ssh"
+	"github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/storage"
+	"github.com/sigstore/rekor/pkg/types"
+	mlmodel_v001 "github.com/sigstore/rekor/pkg/types/mlmodel/v0.0.1"
+	release_v001 "github.com/sigstore/rekor/pkg/types/release/v0.0.1"
+	vmimage_v001 "github.com/sigstore/rekor/pkg/types/vmimage/v0.0.1"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
+	"github.com/spf13/viper"
+
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"github.com/sigstore/rekor/pkg/generated/restapi/operations/index"
 	"github.com/sigstore/rekor/pkg/util"
 )
 
 func SearchIndexHandler(params index.SearchIndexParams) middleware.Responder {
-	httpReqCtx := params.HTTPRequest.Context()
+	result, token, apiErr := searchIndex(params.HTTPRequest.Context(), params.Query)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	return index.NewSearchIndexOK().WithPayload(result).WithXRekorContinuationToken(token)
+}
 
-	var result []string
-	if params.Query.Hash != "" {
-		//validate this is only a valid sha256 hash
-		if !govalidator.IsSHA256(params.Query.Hash) {
-			return handleRekorAPIError(params, http.StatusBadRequest, errors.New("invalid hash value specified"), malformedHash)
+// searchIndex returns the UUIDs of the entries matching query, and the token to continue from if the results
+// are paginated and more remain
+func searchIndex(ctx context.Context, query *models.SearchIndex) ([]string, string, *apiError) {
+	indexCtx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+
+	// the index keys to search, in the order their results are returned
+	var keys []string
+	if query.Hash != "" {
+		// entries are indexed by the hex encoded digests of their content, which are at least 256 bits long
+		if !govalidator.IsSHA256(query.Hash) && !govalidator.IsSHA384(query.Hash) && !govalidator.IsSHA512(query.Hash) {
+			return nil, "", &apiError{http.StatusBadRequest, errors.New("invalid hash value specified"), malformedHash}
 		}
-		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(params.Query.Hash), "0", "-1")); err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		keys = append(keys, strings.ToLower(query.Hash))
+	}
+	if query.HashPrefix != "" {
+		matches, err := hashKeysWithPrefix(indexCtx, query.HashPrefix)
+		if err != nil {
+			return nil, "", &apiError{http.StatusInternalServerError, err, redisUnexpectedResult}
 		}
-		result = append(result, resultUUIDs...)
+		keys = append(keys, matches...)
 	}
-	if params.Query.PublicKey != nil {
-		af := pki.NewArtifactFactory(swag.StringValue(params.Query.PublicKey.Format))
-		keyReader, err := util.FileOrURLReadCloser(httpReqCtx, params.Query.PublicKey.URL.String(), params.Query.PublicKey.Content)
+	if query.PublicKey != nil {
+		af := pki.NewArtifactFactory(swag.StringValue(query.PublicKey.Format))
+		fetchCtx, cancel := withTimeout(ctx, fetchTimeout)
+		defer cancel()
+		keyReader, err := util.FileOrURLReadCloser(fetchCtx, query.PublicKey.URL.String(), query.PublicKey.Content)
 		if err != nil {
-			return handleRekorAPIError(params, http.StatusBadRequest, err, malformedPublicKey)
+			return nil, "", &apiError{http.StatusBadRequest, err, malformedPublicKey}
 		}
 		defer keyReader.Close()
 
 		key, err := af.NewPublicKey(keyReader)
 		if err != nil {
-			return handleRekorAPIError(params, http.StatusBadRequest, err, malformedPublicKey)
+			return nil, "", &apiError{http.StatusBadRequest, err, malformedPublicKey}
 		}
-		canonicalKey, err := key.CanonicalValue()
+		keyIndex, err := pki.KeyIndex(key)
 		if err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, failedToGenerateCanonicalKey)
+			return nil, "", &apiError{http.StatusInternalServerError, err, failedToGenerateCanonicalKey}
+		}
+		keys = append(keys, keyIndex)
+	}
+	if query.Release != "" {
+		name, version := splitRelease(query.Release)
+		if name == "" || version == "" {
+			return nil, "", &apiError{http.StatusBadRequest, errors.New("invalid release value specified"), malformedRelease}
 		}
+		keys = append(keys, release_v001.ReleaseKey(name, version))
+	}
 
-		hasher := sha256.New()
-		if _, err := hasher.Write(canonicalKey); err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, failedToGenerateCanonicalKey)
+	if query.Image != "" {
+		keys = append(keys, vmimage_v001.ImageKey(query.Image))
+	}
+
+	if query.Model != "" {
+		keys = append(keys, mlmodel_v001.ModelKey(query.Model))
+	}
+
+	if query.Principal != "" {
+		keys = append(keys, ssh.PrincipalKey(query.Principal))
+	}
+
+	if query.Email != "" {
+		keys = append(keys, pgp.EmailKey(query.Email))
+	}
+
+	if query.EmailSuffix != "" {
+		matches, err := emailKeysWithSuffix(indexCtx, query.EmailSuffix)
+		if err != nil {
+			return nil, "", &apiError{http.StatusInternalServerError, err, redisUnexpectedResult}
 		}
-		keyHash := hasher.Sum(nil)
-		var resultUUIDs []string
-		if err := redisClient.Do(httpReqCtx, radix.Cmd(&resultUUIDs, "LRANGE", strings.ToLower(hex.EncodeToString(keyHash)), "0", "-1")); err != nil {
-			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		keys = append(keys, matches...)
+	}
+
+	if query.KeyID != "" {
+		keys = append(keys, ssh.KeyIDKey(query.KeyID))
+	}
+
+	if query.URI != "" {
+		keys = append(keys, x509.URIKey(query.URI))
+	}
+
+	if query.CertificateIdentity != "" {
+		// the identity is only meaningful along with the issuer that authenticated it
+		if query.OidcIssuer == "" {
+			return nil, "", &apiError{http.StatusBadRequest, errors.New("certificate identity specified without OIDC issuer"), identityWithoutIssuer}
 		}
-		result = append(result, resultUUIDs...)
+		keys = append(keys, x509.IdentityKey(query.OidcIssuer, query.CertificateIdentity))
+	} else if query.OidcIssuer != "" {
+		keys = append(keys, x509.IssuerKey(query.OidcIssuer))
 	}
 
-	return index.NewSearchIndexOK().WithPayload(result)
+	if query.Piv != "" {
+		keys = append(keys, x509.PIVKey(query.Piv))
+	}
+
+	if query.SubjectDigest != "" {
+		keys = append(keys, dsse.SubjectKey(query.SubjectDigest))
+	}
+
+	for i := range keys {
+		keys[i] = indexKey(ctx, keys[i])
+	}
+
+	limit := query.Limit
+	if maxResults := viper.GetInt64("index.max_results"); maxResults > 0 && (limit == 0 || limit > maxResults) {
+		limit = maxResults
+	}
+	if limit == 0 && query.ContinuationToken == "" {
+		lists, err := indexBackend.LookupBatch(indexCtx, keys)
+		if err != nil {
+			return nil, "", &apiError{http.StatusInternalServerError, err, redisUnexpectedResult}
+		}
+		var result []string
+		for _, resultUUIDs := range lists {
+			result = append(result, resultUUIDs...)
+		}
+		return result, "", nil
+	}
+
+	result, token, err := searchIndexPage(indexCtx, backendIndexLists{}, keys, query.ContinuationToken, limit)
+	if err != nil {
+		if errors.Is(err, errInvalidContinuationToken) {
+			return nil, "", &apiError{http.StatusBadRequest, err, malformedContinuationToken}
+		}
+		return nil, "", &apiError{http.StatusInternalServerError, err, redisUnexpectedResult}
+	}
+	return result, token, nil
 }
 
 func SearchIndexNotImplementedHandler(params index.SearchIndexParams) middleware.Responder {
 	err := models.Error{
 		Code:    http.StatusNotImplemented,
-		Message: "Search Index API not enabled in this Rekor instance",
+		Message: searchIndexNotEnabled,
 	}
 
 	return index.NewSearchIndexDefault(http.StatusNotImplemented).WithPayload(&err)
 
 }
 
-func addToIndex(ctx context.Context, key, value string) error {
-	return redisClient.Do(ctx, radix.Cmd(nil, "LPUSH", key, value))
+// splitRelease splits a release identifier of the form name@version; the name may itself contain '@'
+func splitRelease(release string) (string, string) {
+	idx := strings.LastIndex(release, "@")
+	if idx == -1 {
+		return "", ""
+	}
+	return release[:idx], release[idx+1:]
+}
+
+// addToIndex adds the values of writes to the lists of the current tenant in a single batch
+func addToIndex(ctx context.Context, writes []storage.IndexWrite) error {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	tenantWrites := make([]storage.IndexWrite, len(writes))
+	for i, w := range writes {
+		tenantWrites[i] = tenantIndexWrite(ctx, w)
+	}
+	return indexBackend.WriteBatch(ctx, tenantWrites)
+}
+
+// tenantIndexWrite scopes w to the current tenant. Attestation storage is shared by all tenants, so the entries
+// referring to a stored attestation are listed together whatever their tenant, by their UUID scoped instead.
+func tenantIndexWrite(ctx context.Context, w storage.IndexWrite) storage.IndexWrite {
+	if strings.HasPrefix(w.Key, attestationReferencesPrefix) {
+		return storage.IndexWrite{Key: w.Key, Value: indexKey(ctx, w.Value)}
+	}
+	return storage.IndexWrite{Key: indexKey(ctx, w.Key), Value: w.Value}
+}
+
+// signatureUse tracks which public keys a detached signature has been logged under; the index list at
+// indexKey holds one "<key digest>/<uuid>" value per entry carrying the signature
+type signatureUse struct {
+	indexKey  string
+	keyDigest string
+}
+
+func newSignatureUse(entry types.DetachedSignature) (*signatureUse, error) {
+	sig, key, err := entry.CanonicalSignatureAndKey()
+	if err != nil {
+		return nil, err
+	}
+	sigDigest := sha256.Sum256(sig)
+	keyDigest := sha256.Sum256(key)
+	return &signatureUse{
+		// prefixed so these lists can never be returned by a hash search
+		indexKey:  "signature/" + hex.EncodeToString(sigDigest[:]),
+		keyDigest: hex.EncodeToString(keyDigest[:]),
+	}, nil
+}
+
+// reusedBy returns the UUIDs of entries that logged the same signature under a different public key
+func (s *signatureUse) reusedBy(ctx context.Context) ([]string, error) {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	values, err := indexBackend.Lookup(ctx, indexKey(ctx, s.indexKey))
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, value := range values {
+		keyDigest, uuid := path.Split(value)
+		if strings.TrimSuffix(keyDigest, "/") != s.keyDigest {
+			result = append(result, uuid)
+		}
+	}
+	return result, nil
+}
+
+// write records that the entry with uuid carries the signature
+func (s *signatureUse) write(uuid string) storage.IndexWrite {
+	return storage.IndexWrite{Key: s.indexKey, Value: path.Join(s.keyDigest, uuid)}
+}
+
+// attestationReferencesPrefix prefixes the index lists of the entries referring to stored attestations, so these
+// lists can never be returned by a hash search
+const attestationReferencesPrefix = "attestation/"
+
+// attestationReferencesKey returns the index list holding the UUID of each entry of any tenant referring to the
+// stored attestation with digest
+func attestationReferencesKey(digest string) string {
+	return attestationReferencesPrefix + digest
+}
+
+// attestationReferences returns the number of entries of all tenants referring to the stored attestation with
+// digest, which may only be removed from attestation storage once none does, and the number of those that are
+// entries of the tenant of ctx. UUIDs written more than once, as retried index writes may be, are counted once.
+func attestationReferences(ctx context.Context, digest string) (all int64, tenant int64, err error) {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	uuids, err := indexBackend.Lookup(ctx, attestationReferencesKey(digest))
+	if err != nil {
+		return 0, 0, err
+	}
+	prefix := indexKey(ctx, "")
+	seen := map[string]bool{}
+	for _, uuid := range uuids {
+		if seen[uuid] {
+			continue
+		}
+		seen[uuid] = true
+		// the UUIDs of the entries of tenants are scoped to them by tenantIndexWrite
+		if strings.HasPrefix(uuid, prefix) && !strings.Contains(strings.TrimPrefix(uuid, prefix), "/") {
+			tenant++
+		}
+	}
+	return int64(len(seen)), tenant, nil
 }
@@ -0,0 +1,127 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// errInvalidContinuationToken is returned when a continuation token cannot be decoded, or was issued for a
+// search over different index keys
+var errInvalidContinuationToken = errors.New("invalid continuation token")
+
+// indexLists reads the lists of UUIDs the search index keeps under each key; new UUIDs are pushed onto the
+// head of a list, so positions counted from its tail never change
+type indexLists interface {
+	length(ctx context.Context, key string) (int64, error)
+	// rangeFromTail returns the UUIDs from the from-th to the to-th last of the list, newest first
+	rangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error)
+}
+
+// backendIndexLists reads the lists from the configured index backend
+type backendIndexLists struct{}
+
+func (backendIndexLists) length(ctx context.Context, key string) (int64, error) {
+	return indexBackend.Length(ctx, key)
+}
+
+func (backendIndexLists) rangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error) {
+	return indexBackend.RangeFromTail(ctx, key, from, to)
+}
+
+// indexCursor is the position a page of search results ended at: Remaining UUIDs of the Key-th index key are
+// left to return, counted from the tail of its list, before moving on to the next key
+type indexCursor struct {
+	Keys      string `json:"k"`
+	Key       int    `json:"i"`
+	Remaining int64  `json:"r"`
+}
+
+// keysDigest ties a cursor to the index keys of the query it was issued for
+func keysDigest(keys []string) string {
+	digest := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return hex.EncodeToString(digest[:8])
+}
+
+func (c indexCursor) token() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func parseContinuationToken(token string, keys []string) (*indexCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidContinuationToken
+	}
+	c := &indexCursor{}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, errInvalidContinuationToken
+	}
+	if c.Keys != keysDigest(keys) || c.Key < 0 || c.Key >= len(keys) || c.Remaining < 0 {
+		return nil, errInvalidContinuationToken
+	}
+	return c, nil
+}
+
+// searchIndexPage returns up to limit UUIDs (or all remaining ones if limit is 0) indexed under keys, starting
+// where the page that returned token ended, and the token to continue from if any UUIDs are left. Each key is
+// read newest first from the length its list had when the search first reached it, so UUIDs indexed while
+// paging through the results neither shift the pages nor appear in them.
+func searchIndexPage(ctx context.Context, lists indexLists, keys []string, token string, limit int64) ([]string, string, error) {
+	c := &indexCursor{Keys: keysDigest(keys), Remaining: -1}
+	if token != "" {
+		var err error
+		if c, err = parseContinuationToken(token, keys); err != nil {
+			return nil, "", err
+		}
+	}
+
+	result := []string{}
+	for c.Key < len(keys) {
+		if c.Remaining < 0 {
+			n, err := lists.length(ctx, keys[c.Key])
+			if err != nil {
+				return nil, "", err
+			}
+			c.Remaining = n
+		}
+		n := c.Remaining
+		if limit > 0 && n > limit-int64(len(result)) {
+			n = limit - int64(len(result))
+		}
+		if n > 0 {
+			uuids, err := lists.rangeFromTail(ctx, keys[c.Key], c.Remaining, c.Remaining-n+1)
+			if err != nil {
+				return nil, "", err
+			}
+			result = append(result, uuids...)
+			c.Remaining -= n
+		}
+		if c.Remaining > 0 {
+			// the page is full
+			return result, c.token(), nil
+		}
+		c.Key, c.Remaining = c.Key+1, -1
+	}
+	return result, "", nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeIndexLists keeps each list newest first, as LPUSH does
+type fakeIndexLists map[string][]string
+
+func (f fakeIndexLists) push(key string, values ...string) {
+	for _, v := range values {
+		f[key] = append([]string{v}, f[key]...)
+	}
+}
+
+func (f fakeIndexLists) length(_ context.Context, key string) (int64, error) {
+	return int64(len(f[key])), nil
+}
+
+func (f fakeIndexLists) rangeFromTail(_ context.Context, key string, from, to int64) ([]string, error) {
+	l := f[key]
+	n := int64(len(l))
+	return append([]string{}, l[n-from:n-to+1]...), nil
+}
+
+func TestSearchIndexPage(t *testing.T) {
+	lists := fakeIndexLists{}
+	for i := 1; i <= 5; i++ {
+		lists.push("a", fmt.Sprintf("a%v", i))
+	}
+	lists.push("b", "b1", "b2")
+	keys := []string{"a", "empty", "b"}
+
+	// all results in one page
+	all, token, err := searchIndexPage(context.Background(), lists, keys, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a5", "a4", "a3", "a2", "a1", "b2", "b1"}; !reflect.DeepEqual(all, want) || token != "" {
+		t.Fatalf("unexpected results %v, token %q", all, token)
+	}
+
+	tests := []struct {
+		caseDesc string
+		limit    int64
+		pages    [][]string
+	}{
+		{caseDesc: "pages within a key", limit: 2, pages: [][]string{{"a5", "a4"}, {"a3", "a2"}, {"a1", "b2"}, {"b1"}}},
+		{caseDesc: "page ends with a key", limit: 5, pages: [][]string{{"a5", "a4", "a3", "a2", "a1"}, {"b2", "b1"}}},
+		{caseDesc: "page ends with the last key", limit: 7, pages: [][]string{all}},
+		{caseDesc: "limit above the number of results", limit: 100, pages: [][]string{all}},
+	}
+	for _, tc := range tests {
+		token := ""
+		for i, want := range tc.pages {
+			var page []string
+			page, token, err = searchIndexPage(context.Background(), lists, keys, token, tc.limit)
+			if err != nil {
+				t.Fatalf("%v: page %v: %v", tc.caseDesc, i, err)
+			}
+			if !reflect.DeepEqual(page, want) {
+				t.Errorf("%v: page %v is %v, expected %v", tc.caseDesc, i, page, want)
+			}
+			if last := i == len(tc.pages)-1; last != (token == "") {
+				t.Errorf("%v: page %v has unexpected continuation token %q", tc.caseDesc, i, token)
+			}
+		}
+	}
+
+	// UUIDs indexed while paging do not shift the pages of keys the search has already reached
+	page, token, err := searchIndexPage(context.Background(), lists, keys, "", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lists.push("a", "a6", "a7")
+	lists.push("b", "b3")
+	page2, token, err := searchIndexPage(context.Background(), lists, keys, token, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a5", "a4", "a3", "a2", "a1", "b3"}; !reflect.DeepEqual(append(page, page2...), want) || token == "" {
+		t.Errorf("unexpected results %v, token %q", append(page, page2...), token)
+	}
+
+	// tokens only continue the query they were returned for
+	for _, bad := range []string{"e30", "not a token!", (indexCursor{Keys: keysDigest(keys[:2]), Key: 1}).token()} {
+		if _, _, err := searchIndexPage(context.Background(), lists, keys, bad, 3); !errors.Is(err, errInvalidContinuationToken) {
+			t.Errorf("expected invalid token error for %q, got %v", bad, err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/storage"
+)
+
+const (
+	// minIndexRetryDelay and maxIndexRetryDelay bound the wait before retrying an index job that failed
+	minIndexRetryDelay = 100 * time.Millisecond
+	maxIndexRetryDelay = 30 * time.Second
+)
+
+var indexQueueBucket = []byte("pending")
+
+// indexJob holds what the index records about an entry added to the log until all of it has been written.
+// Each step is marked done once applied, so that retrying a job that failed part way does not repeat the steps
+// that succeeded.
+type indexJob struct {
+	Tenant         string               `json:"tenant,omitempty"`
+	UUID           string               `json:"uuid"`
+	Kind           string               `json:"kind"`
+	LogIndex       int64                `json:"logIndex"`
+	IntegratedTime int64                `json:"integratedTime"`
+	Writes         []storage.IndexWrite `json:"writes"`
+
+	Indexed bool `json:"indexed,omitempty"`
+	Listed  bool `json:"listed,omitempty"`
+	Timed   bool `json:"timed,omitempty"`
+	Counted bool `json:"counted,omitempty"`
+}
+
+// apply writes the steps of the job not done yet, calling saveStep after each one
+func (j *indexJob) apply(ctx context.Context, saveStep func()) error {
+	if j.Tenant != "" {
+		ctx = withTenant(ctx, tenant{name: j.Tenant})
+	}
+	if !j.Indexed {
+		if err := addToIndex(ctx, j.Writes); err != nil {
+			return err
+		}
+		j.Indexed = true
+		saveStep()
+	}
+	if !j.Listed {
+		if err := addToKeyIndex(ctx, j.Writes); err != nil {
+			return err
+		}
+		j.Listed = true
+		saveStep()
+	}
+	if !j.Timed {
+		if err := addToTimeIndex(ctx, j.LogIndex, j.IntegratedTime); err != nil {
+			return err
+		}
+		j.Timed = true
+		saveStep()
+	}
+	if !j.Counted {
+		if err := countKind(ctx, j.Kind); err != nil {
+			return err
+		}
+		j.Counted = true
+		saveStep()
+	}
+	return nil
+}
+
+type queuedIndexJob struct {
+	id  uint64
+	job *indexJob
+}
+
+// indexQueue applies index jobs in the order they were queued, retrying each one until it succeeds so that an
+// outage of the index backend delays rather than loses the index keys of entries that made it into the log.
+// With a file, jobs are written to it before they are applied and removed once done, so that those pending
+// when the server stops are applied after it restarts.
+type indexQueue struct {
+	mu     sync.Mutex
+	jobs   []queuedIndexJob
+	nextID uint64
+	wake   chan struct{}
+	db     *bolt.DB
+}
+
+var indexWriteQueue *indexQueue
+
+// newIndexQueue returns a queue kept in the file at path, loading the jobs still pending in it, or a queue kept
+// in memory only if path is empty
+func newIndexQueue(path string) (*indexQueue, error) {
+	q := &indexQueue{wake: make(chan struct{}, 1)}
+	if path == "" {
+		return q, nil
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(indexQueueBucket)
+		if err != nil {
+			return err
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var job indexJob
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			q.jobs = append(q.jobs, queuedIndexJob{id: binary.BigEndian.Uint64(k), job: &job})
+			return nil
+		})
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if len(q.jobs) > 0 {
+		q.nextID = q.jobs[len(q.jobs)-1].id + 1
+		log.Logger.Infow("resuming pending index jobs", "pending", len(q.jobs), "path", path)
+	}
+	q.db = db
+	metricIndexQueuePending.Set(float64(len(q.jobs)))
+	return q, nil
+}
+
+func queueKey(id uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, id)
+	return k
+}
+
+// save writes the job to the file of the queue, if it has one
+func (q *indexQueue) save(qj queuedIndexJob) error {
+	if q.db == nil {
+		return nil
+	}
+	value, err := json.Marshal(qj.job)
+	if err != nil {
+		return err
+	}
+	return q.db.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(indexQueueBucket).Put(queueKey(qj.id), value)
+	})
+}
+
+func (q *indexQueue) remove(qj queuedIndexJob) error {
+	if q.db == nil {
+		return nil
+	}
+	return q.db.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(indexQueueBucket).Delete(queueKey(qj.id))
+	})
+}
+
+// enqueue adds job to the end of the queue once it has been saved; failing to save it only loses it if the
+// server stops before it is applied
+func (q *indexQueue) enqueue(job *indexJob) {
+	q.mu.Lock()
+	qj := queuedIndexJob{id: q.nextID, job: job}
+	q.nextID++
+	q.mu.Unlock()
+
+	if err := q.save(qj); err != nil {
+		log.Logger.Errorw("failed to save index job", "uuid", job.UUID, "error", err)
+	}
+
+	q.mu.Lock()
+	q.jobs = append(q.jobs, qj)
+	metricIndexQueuePending.Set(float64(len(q.jobs)))
+	q.mu.Unlock()
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *indexQueue) head() (queuedIndexJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.jobs) == 0 {
+		return queuedIndexJob{}, false
+	}
+	return q.jobs[0], true
+}
+
+func (q *indexQueue) pop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs = q.jobs[1:]
+	metricIndexQueuePending.Set(float64(len(q.jobs)))
+}
+
+// run applies queued jobs until ctx is done, waiting longer after each failure to apply the same job
+func (q *indexQueue) run(ctx context.Context) {
+	delay := minIndexRetryDelay
+	for {
+		qj, ok := q.head()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-q.wake:
+				continue
+			}
+		}
+		err := qj.job.apply(ctx, func() {
+			if err := q.save(qj); err != nil {
+				log.Logger.Errorw("failed to save index job", "uuid", qj.job.UUID, "error", err)
+			}
+		})
+		if err == nil {
+			if err := q.remove(qj); err != nil {
+				log.Logger.Errorw("failed to remove applied index job", "uuid", qj.job.UUID, "error", err)
+			}
+			q.pop()
+			delay = minIndexRetryDelay
+			continue
+		}
+		metricIndexWriteFailures.Inc()
+		log.Logger.Warnw("failed to write to index, retrying", "uuid", qj.job.UUID, "retryIn", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxIndexRetryDelay {
+			delay = maxIndexRetryDelay
+		}
+	}
+}
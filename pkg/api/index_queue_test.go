@@ -0,0 +1,119 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sigstore/rekor/pkg/storage"
+)
+
+// flakyBackend fails the first failures calls to WriteScore, as an index backend that is briefly unreachable
+type flakyBackend struct {
+	storage.IndexBackend
+	failures int
+}
+
+func (f *flakyBackend) WriteScore(ctx context.Context, key, member string, score int64) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("connection refused")
+	}
+	return f.IndexBackend.WriteScore(ctx, key, member, score)
+}
+
+func TestIndexQueueRetries(t *testing.T) {
+	defer func(b storage.IndexBackend) { indexBackend = b }(indexBackend)
+	memory := storage.NewMemoryBackend()
+	indexBackend = &flakyBackend{IndexBackend: memory, failures: 2}
+
+	q, err := newIndexQueue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		q.run(ctx)
+		close(done)
+	}()
+	q.enqueue(&indexJob{UUID: "u1", Kind: "rekord", LogIndex: 1, IntegratedTime: 100, Writes: []storage.IndexWrite{{Key: "a", Value: "u1"}}})
+	q.enqueue(&indexJob{UUID: "u2", Kind: "rekord", LogIndex: 2, IntegratedTime: 200, Writes: []storage.IndexWrite{{Key: "a", Value: "u2"}}})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, pending := q.head(); !pending {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("queue was not drained")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	// the list writes that succeeded before the time index failed must not be repeated
+	if got, _ := memory.Lookup(context.Background(), "a"); !reflect.DeepEqual(got, []string{"u2", "u1"}) {
+		t.Errorf("unexpected UUIDs under a: %v", got)
+	}
+	if got, _ := memory.RangeByScore(context.Background(), integratedTimeKey, 0, 1000, 0, 10); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("unexpected time index %v", got)
+	}
+	if counts, _ := memory.Counters(context.Background(), kindCountsKey); counts["rekord"] != 2 {
+		t.Errorf("unexpected kind counts %v", counts)
+	}
+}
+
+func TestIndexQueueResumes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.db")
+	q, err := newIndexQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.enqueue(&indexJob{UUID: "u1", Writes: []storage.IndexWrite{{Key: "a", Value: "u1"}}})
+	q.enqueue(&indexJob{UUID: "u2", Indexed: true})
+	// a job applied before the server stopped must not be resumed
+	applied, _ := q.head()
+	if err := q.remove(applied); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if q, err = newIndexQueue(path); err != nil {
+		t.Fatal(err)
+	}
+	defer q.db.Close()
+	if len(q.jobs) != 1 {
+		t.Fatalf("expected 1 pending job, got %v", len(q.jobs))
+	}
+	if got := q.jobs[0].job; got.UUID != "u2" || !got.Indexed {
+		t.Errorf("unexpected pending job %+v", got)
+	}
+	q.enqueue(&indexJob{UUID: "u3"})
+	if q.jobs[1].id <= q.jobs[0].id {
+		t.Errorf("job queued after resuming has id %v, not after %v", q.jobs[1].id, q.jobs[0].id)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian/types"
+)
+
+// keyHintHeader is the PEM header giving the key hint of the tree heads a previous log key signed
+const keyHintHeader = "Key-Hint"
+
+// logKey is a public key that tree heads signed by this instance can be verified with, identified by the key hint
+// Trillian sets on the tree heads it signs, which is the big-endian encoding of the ID of the signing tree
+type logKey struct {
+	hint []byte
+	der  []byte
+}
+
+// logID is the SHA256 hash of the DER encoding of the key, as RFC 6962 derives log IDs
+func (k logKey) logID() string {
+	digest := sha256.Sum256(k.der)
+	return hex.EncodeToString(digest[:])
+}
+
+func (k logKey) pem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: k.der}))
+}
+
+// parsePreviousLogKeys parses the PEM-encoded public keys the log signed tree heads with before its key was
+// rotated, each of which must carry a Key-Hint header with the base64-encoded hint of the tree heads it signed
+func parsePreviousLogKeys(pemBytes []byte) ([]logKey, error) {
+	var keys []logKey
+	for {
+		var block *pem.Block
+		block, pemBytes = pem.Decode(pemBytes)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("unexpected PEM block of type %v", block.Type)
+		}
+		if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, err
+		}
+		hint, err := base64.StdEncoding.DecodeString(block.Headers[keyHintHeader])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v header: %w", keyHintHeader, err)
+		}
+		if len(hint) == 0 {
+			return nil, fmt.Errorf("public key has no %v header", keyHintHeader)
+		}
+		keys = append(keys, logKey{hint: hint, der: block.Bytes})
+	}
+	if len(bytes.TrimSpace(pemBytes)) > 0 {
+		return nil, errors.New("trailing data after PEM-encoded public keys")
+	}
+	return keys, nil
+}
+
+// newLogKeys returns the key of the tree with ID treeID followed by the keys in previous, failing if two keys
+// share a hint as tree heads signed with one could not be told apart from those signed with the other
+func newLogKeys(treeID int64, der []byte, previous []logKey) ([]logKey, error) {
+	keys := append([]logKey{{hint: types.SerializeKeyHint(treeID), der: der}}, previous...)
+	seen := map[string]bool{}
+	for _, k := range keys {
+		if seen[string(k.hint)] {
+			return nil, fmt.Errorf("more than one log key has key hint %v", base64.StdEncoding.EncodeToString(k.hint))
+		}
+		seen[string(k.hint)] = true
+	}
+	return keys, nil
+}
+
+// findLogKey returns the key that tree heads carrying hint were signed with
+func findLogKey(keys []logKey, hint []byte) (logKey, bool) {
+	for _, k := range keys {
+		if bytes.Equal(k.hint, hint) {
+			return k, true
+		}
+	}
+	return logKey{}, false
+}
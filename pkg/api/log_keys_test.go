@@ -0,0 +1,93 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/google/trillian/types"
+)
+
+func testKeyDER(t *testing.T) []byte {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestParsePreviousLogKeys(t *testing.T) {
+	first, second := testKeyDER(t), testKeyDER(t)
+	keyPEM := func(der []byte, headers map[string]string) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Headers: headers, Bytes: der})
+	}
+	hint := func(treeID int64) map[string]string {
+		return map[string]string{keyHintHeader: base64.StdEncoding.EncodeToString(types.SerializeKeyHint(treeID))}
+	}
+
+	keys, err := parsePreviousLogKeys(append(keyPEM(first, hint(1)), keyPEM(second, hint(2))...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || !bytes.Equal(keys[0].der, first) || !bytes.Equal(keys[1].hint, types.SerializeKeyHint(2)) {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	for caseDesc, pemBytes := range map[string][]byte{
+		"missing hint":  keyPEM(first, nil),
+		"invalid hint":  keyPEM(first, map[string]string{keyHintHeader: "not base64!"}),
+		"not a key":     keyPEM([]byte("not a key"), hint(1)),
+		"trailing data": append(keyPEM(first, hint(1)), "garbage"...),
+	} {
+		if _, err := parsePreviousLogKeys(pemBytes); err == nil {
+			t.Errorf("%v: expected error, got none", caseDesc)
+		}
+	}
+}
+
+func TestLogKeys(t *testing.T) {
+	active, previous := testKeyDER(t), testKeyDER(t)
+	keys, err := newLogKeys(2, active, []logKey{{hint: types.SerializeKeyHint(1), der: previous}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, ok := findLogKey(keys, types.SerializeKeyHint(2)); !ok || !bytes.Equal(k.der, active) {
+		t.Error("active key not found by the hint of its tree")
+	}
+	if k, ok := findLogKey(keys, types.SerializeKeyHint(1)); !ok || !bytes.Equal(k.der, previous) {
+		t.Error("previous key not found by its hint")
+	}
+	if _, ok := findLogKey(keys, types.SerializeKeyHint(3)); ok {
+		t.Error("found a key for an unknown hint")
+	}
+
+	if _, err := newLogKeys(1, active, []logKey{{hint: types.SerializeKeyHint(1), der: previous}}); err == nil {
+		t.Error("expected error for keys sharing a hint, got none")
+	}
+}
@@ -11,6 +11,39 @@ var (
 		Help: "The total number of new log entries",
 	})
 
+	metricNewEntriesByIdentity = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_new_entries_by_identity",
+		Help: "The total number of new log entries added by each authenticated client listed in auth.metric_identities, and by all others together",
+	}, []string{"identity"})
+
+	metricSignatureReuse = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rekor_signature_reuse",
+		Help: "The total number of new log entries whose signature was already logged under a different public key",
+	})
+
+	metricFailoverActive = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rekor_failover_active",
+		Help: "Whether this instance is the one of its failover pair accepting new entries",
+	})
+	metricFailoverConsistent = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rekor_failover_consistent",
+		Help: "Whether the last comparison of the tree of this standby instance with its peer's found them consistent",
+	})
+
+	metricShardRotations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rekor_shard_rotations",
+		Help: "The total number of rotations of the log to a new tree, by the reason for the rotation",
+	}, []string{"reason"})
+
+	metricIndexQueuePending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "rekor_index_queue_pending",
+		Help: "The number of added entries whose index writes have not all been applied yet",
+	})
+	metricIndexWriteFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "rekor_index_write_failures",
+		Help: "The total number of failed attempts at applying the index writes of an added entry, which are retried",
+	})
+
 	MetricLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name: "rekor_api_latency",
 		Help: "Api Latency on calls",
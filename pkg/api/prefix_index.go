@@ -0,0 +1,97 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/storage"
+)
+
+const (
+	// hashKeysKey is the set of the digests entries are indexed under, scanned for those starting with a prefix;
+	// prefixed so it can never be returned by a hash search
+	hashKeysKey = "keys/hash"
+	// reversedEmailKeysKey is the set of the email addresses entries are indexed under, each reversed so that
+	// those ending with a suffix, such as a domain, are found by scanning for the reversed suffix
+	reversedEmailKeysKey = "keys/email_reversed"
+
+	// minHashPrefixLength keeps a prefix search from matching a large part of the index
+	minHashPrefixLength = 8
+	// maxPrefixMatches bounds the number of index keys a single prefix or suffix search is expanded into
+	maxPrefixMatches = 100
+)
+
+// addToKeyIndex adds the keys written to by writes that can be searched by prefix or suffix to the sets those
+// searches scan, within the namespace of the current tenant
+func addToKeyIndex(ctx context.Context, writes []storage.IndexWrite) error {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	emailPrefix := pgp.EmailKey("")
+	seen := map[string]bool{}
+	for _, w := range writes {
+		if seen[w.Key] {
+			continue
+		}
+		seen[w.Key] = true
+		var set, member string
+		switch {
+		case govalidator.IsSHA256(w.Key) || govalidator.IsSHA384(w.Key) || govalidator.IsSHA512(w.Key):
+			set, member = hashKeysKey, w.Key
+		case strings.HasPrefix(w.Key, emailPrefix):
+			set, member = reversedEmailKeysKey, reverse(strings.TrimPrefix(w.Key, emailPrefix))
+		default:
+			continue
+		}
+		if err := indexBackend.WriteScore(ctx, indexKey(ctx, set), member, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashKeysWithPrefix returns the digests entries are indexed under that start with prefix
+func hashKeysWithPrefix(ctx context.Context, prefix string) ([]string, error) {
+	return indexBackend.RangeByPrefix(ctx, indexKey(ctx, hashKeysKey), strings.ToLower(prefix), maxPrefixMatches)
+}
+
+// emailKeysWithSuffix returns the index keys of the email addresses entries are indexed under that end with
+// suffix
+func emailKeysWithSuffix(ctx context.Context, suffix string) ([]string, error) {
+	members, err := indexBackend.RangeByPrefix(ctx, indexKey(ctx, reversedEmailKeysKey), reverse(strings.ToLower(suffix)), maxPrefixMatches)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = pgp.EmailKey(reverse(member))
+	}
+	return keys, nil
+}
+
+// reverse returns s with its runes in reverse order
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
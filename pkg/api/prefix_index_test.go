@@ -0,0 +1,81 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/storage"
+)
+
+func TestPrefixSearch(t *testing.T) {
+	defer func(b storage.IndexBackend) { indexBackend = b }(indexBackend)
+	indexBackend = storage.NewMemoryBackend()
+
+	digestA := "abcdef01" + strings.Repeat("0", 56)
+	digestB := "abcdef01" + strings.Repeat("1", 56)
+	digestC := "fedcba98" + strings.Repeat("0", 56)
+	ctx := context.Background()
+	for uuid, keys := range map[string][]string{
+		"u1": {digestA, pgp.EmailKey("alice@example.com")},
+		"u2": {digestB, pgp.EmailKey("bob@Example.com"), "release:rekor@1.0"},
+		"u3": {digestC, pgp.EmailKey("carol@example.org")},
+	} {
+		var writes []storage.IndexWrite
+		for _, key := range keys {
+			writes = append(writes, storage.IndexWrite{Key: key, Value: uuid})
+		}
+		if err := addToIndex(ctx, writes); err != nil {
+			t.Fatal(err)
+		}
+		if err := addToKeyIndex(ctx, writes); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		caseDesc string
+		query    models.SearchIndex
+		want     []string
+	}{
+		{caseDesc: "hash prefix", query: models.SearchIndex{HashPrefix: "ABCDEF01"}, want: []string{"u1", "u2"}},
+		{caseDesc: "hash prefix of a single digest", query: models.SearchIndex{HashPrefix: "abcdef011"}, want: []string{"u2"}},
+		{caseDesc: "unmatched hash prefix", query: models.SearchIndex{HashPrefix: "01234567"}, want: nil},
+		{caseDesc: "email domain", query: models.SearchIndex{EmailSuffix: "@example.com"}, want: []string{"u2", "u1"}},
+		{caseDesc: "top level domain", query: models.SearchIndex{EmailSuffix: ".org"}, want: []string{"u3"}},
+	}
+	for _, tc := range tests {
+		got, _, apiErr := searchIndex(ctx, &tc.query)
+		if apiErr != nil {
+			t.Fatalf("%v: unexpected error %v", tc.caseDesc, apiErr.err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %v, want %v", tc.caseDesc, got, tc.want)
+		}
+	}
+
+	// keys are listed within the namespace of the tenant they were written for
+	tenantCtx := withTenant(ctx, tenant{name: "other"})
+	if got, _ := hashKeysWithPrefix(tenantCtx, "abcdef01"); len(got) != 0 {
+		t.Errorf("found keys of the default tree in a tenant: %v", got)
+	}
+}
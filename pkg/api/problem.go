@@ -0,0 +1,186 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	oaerrors "github.com/go-openapi/errors"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	pkix509 "github.com/sigstore/rekor/pkg/pki/x509"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+// problemContentType is the media type of RFC 7807 problem details
+const problemContentType = "application/problem+json"
+
+// The types of problem a proposed entry can be rejected with. Errors that are not about the content of an entry
+// have the type about:blank, meaning that the status code describes the problem.
+const (
+	problemTypePrefix         = "urn:rekor:error:"
+	problemInvalidEntry       = problemTypePrefix + "invalid-entry"
+	problemInvalidSignature   = problemTypePrefix + "invalid-signature"
+	problemFetchFailed        = problemTypePrefix + "fetch-failed"
+	problemEntityTooLarge     = problemTypePrefix + "entity-too-large"
+	problemKeyRejected        = problemTypePrefix + "key-rejected"
+	problemInvalidCertificate = problemTypePrefix + "invalid-certificate"
+)
+
+var problemTitles = map[string]string{
+	problemInvalidEntry:       "The entry is invalid",
+	problemInvalidSignature:   "The signature of the entry could not be verified",
+	problemFetchFailed:        "Content referenced by the entry could not be fetched",
+	problemEntityTooLarge:     "Content referenced by the entry is too large",
+	problemKeyRejected:        "The signing key was rejected by policy",
+	problemInvalidCertificate: "The signing certificate is not valid",
+}
+
+// entryError is the error a proposed entry was rejected with, along with the kind and version of the entry
+type entryError struct {
+	kind       string
+	apiVersion string
+	err        error
+}
+
+func (e *entryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *entryError) Unwrap() error {
+	return e.err
+}
+
+// newEntryError returns err as the error pe was rejected with
+func newEntryError(pe models.ProposedEntry, err error) *entryError {
+	// the version is only held by the concrete type of each kind, so it is read back from the JSON of the entry
+	var version struct {
+		APIVersion string `json:"apiVersion"`
+	}
+	if b, jsonErr := json.Marshal(pe); jsonErr == nil {
+		_ = json.Unmarshal(b, &version)
+	}
+	return &entryError{kind: pe.Kind(), apiVersion: version.APIVersion, err: err}
+}
+
+// problem returns the error response with message and code, describing the entry that err rejected if any
+func problem(message string, code int, err error) *models.Error {
+	e := errorMsg(message, code)
+	var ee *entryError
+	if !errors.As(err, &ee) {
+		return e
+	}
+	e.Kind, e.APIVersion = ee.kind, ee.apiVersion
+	// server errors say nothing about the entry
+	if code >= http.StatusInternalServerError {
+		return e
+	}
+	e.Type, e.Field = entryProblem(ee.err)
+	e.Title = problemTitles[e.Type]
+	return e
+}
+
+// entryProblem returns the type of problem that err rejected an entry with, and the path of the field that
+// failed validation if it is known
+func entryProblem(err error) (string, string) {
+	var (
+		policyErr   *pgp.PolicyError
+		sctErr      *pkix509.SCTError
+		validityErr *pkix509.ValidityError
+		sizeErr     *util.SizeError
+		attErr      *types.AttestationSizeError
+		fetchErr    *util.FetchError
+		sigErr      *types.VerificationError
+	)
+	switch {
+	case errors.As(err, &policyErr):
+		return problemKeyRejected, ""
+	case errors.As(err, &sctErr), errors.As(err, &validityErr):
+		return problemInvalidCertificate, ""
+	case errors.As(err, &sizeErr), errors.As(err, &attErr):
+		return problemEntityTooLarge, ""
+	case errors.As(err, &fetchErr):
+		return problemFetchFailed, ""
+	case errors.As(err, &sigErr):
+		return problemInvalidSignature, ""
+	}
+	return problemInvalidEntry, validationField(err)
+}
+
+// validationField returns the path within the proposed entry of the first field that err reports as failing
+// schema validation
+func validationField(err error) string {
+	var ce *oaerrors.CompositeError
+	if errors.As(err, &ce) {
+		for _, e := range ce.Errors {
+			if field := validationField(e); field != "" {
+				return field
+			}
+		}
+	}
+	var ve *oaerrors.Validation
+	if errors.As(err, &ve) && ve.Name != "" {
+		return "spec." + ve.Name
+	}
+	return ""
+}
+
+// acceptsProblemJSON reports whether the Accept header of a request lists the problem details media type
+func acceptsProblemJSON(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		if mediaType, _, err := mime.ParseMediaType(mediaRange); err == nil && mediaType == problemContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// ProblemJSON serves error responses as application/problem+json to clients that list it in their Accept
+// header; other clients are served the same body as application/json
+func ProblemJSON(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !acceptsProblemJSON(r.Header.Get("Accept")) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(&problemWriter{w}, r)
+	})
+}
+
+type problemWriter struct {
+	http.ResponseWriter
+}
+
+func (w *problemWriter) WriteHeader(code int) {
+	if code >= http.StatusBadRequest && strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.Header().Set("Content-Type", problemContentType)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streamed responses through unbuffered
+func (w *problemWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+)
+
+func TestProblem(t *testing.T) {
+	pe := &models.Rekord{APIVersion: swag.String("0.0.1")}
+	invalidSpec := (&models.RekordV001Schema{Data: &models.RekordV001SchemaData{}}).Validate(strfmt.Default)
+	if invalidSpec == nil {
+		t.Fatal("expected an invalid spec")
+	}
+
+	tests := []struct {
+		caseDesc string
+		code     int
+		err      error
+		typ      string
+		field    string
+	}{
+		{caseDesc: "not an entry", code: http.StatusBadRequest, err: errors.New("bad"), typ: "about:blank"},
+		{caseDesc: "server error", code: http.StatusInternalServerError, err: newEntryError(pe, errors.New("bad")), typ: "about:blank"},
+		{caseDesc: "schema", code: http.StatusBadRequest, err: newEntryError(pe, invalidSpec), typ: problemInvalidEntry, field: "spec.signature"},
+		{caseDesc: "signature", code: http.StatusBadRequest, err: newEntryError(pe, &types.VerificationError{Err: errors.New("bad")}), typ: problemInvalidSignature},
+		{caseDesc: "key policy", code: http.StatusBadRequest, err: newEntryError(pe, &types.VerificationError{Err: &pgp.PolicyError{Reason: "expired"}}), typ: problemKeyRejected},
+		{caseDesc: "fetch", code: http.StatusBadRequest, err: newEntryError(pe, fmt.Errorf("reading: %w", &util.FetchError{URL: "http://example.com", Err: errors.New("404")})), typ: problemFetchFailed},
+		{caseDesc: "size", code: http.StatusBadRequest, err: newEntryError(pe, &util.SizeError{URL: "http://example.com", Limit: 1}), typ: problemEntityTooLarge},
+	}
+	for _, tc := range tests {
+		e := problem("message", tc.code, tc.err)
+		if e.Type != tc.typ || e.Field != tc.field {
+			t.Errorf("%v: got type %q and field %q, expected %q and %q", tc.caseDesc, e.Type, e.Field, tc.typ, tc.field)
+		}
+		if e.Title == "" || e.Status != int64(tc.code) || e.Detail != "message" || e.Code != int64(tc.code) || e.Message != "message" {
+			t.Errorf("%v: incomplete problem %+v", tc.caseDesc, e)
+		}
+		if _, ok := tc.err.(*entryError); ok && (e.Kind != "rekord" || e.APIVersion != "0.0.1") {
+			t.Errorf("%v: got kind %q and version %q", tc.caseDesc, e.Kind, e.APIVersion)
+		}
+	}
+}
+
+func TestProblemJSON(t *testing.T) {
+	handler := ProblemJSON(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		code := http.StatusOK
+		if r.URL.Path == "/error" {
+			code = http.StatusBadRequest
+		}
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(errorMsg("message", code))
+	}))
+
+	tests := []struct {
+		path        string
+		accept      string
+		contentType string
+	}{
+		{path: "/error", accept: "application/json", contentType: "application/json"},
+		{path: "/error", accept: "application/json, application/problem+json;q=0.9", contentType: problemContentType},
+		{path: "/", accept: "application/json, application/problem+json", contentType: "application/json"},
+		{path: "/error", contentType: "application/json"},
+	}
+	for _, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.accept != "" {
+			r.Header.Set("Accept", tc.accept)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if got := w.Header().Get("Content-Type"); got != tc.contentType {
+			t.Errorf("%v accepting %q: got content type %q, expected %q", tc.path, tc.accept, got, tc.contentType)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/urfave/negroni"
+)
+
+// quotaWindow is a period of fixed length, aligned to UTC, in which an identity may add up to limit entries
+type quotaWindow struct {
+	name   string
+	length time.Duration
+	limit  int64
+}
+
+type windowUsage struct {
+	start time.Time
+	count int64
+}
+
+// quotaStatus is the usage of an identity in a quota window, as reported to clients
+type quotaStatus struct {
+	window    quotaWindow
+	remaining int64
+	reset     time.Time
+}
+
+// entryQuotas limits the number of entries each authenticated identity may add in each window. Usage is
+// counted by each instance, so instances behind a load balancer each allow the full quota.
+type entryQuotas struct {
+	windows []quotaWindow
+
+	mu        sync.Mutex
+	usage     map[string][]windowUsage
+	lastSweep time.Time
+}
+
+var writeQuotas = newEntryQuotas(0, 0)
+
+// newEntryQuotas returns quotas allowing each identity hourly entries an hour and daily entries a day, where
+// a quota of 0 means unlimited
+func newEntryQuotas(hourly, daily int64) *entryQuotas {
+	q := &entryQuotas{usage: map[string][]windowUsage{}}
+	if hourly > 0 {
+		q.windows = append(q.windows, quotaWindow{name: "Hourly", length: time.Hour, limit: hourly})
+	}
+	if daily > 0 {
+		q.windows = append(q.windows, quotaWindow{name: "Daily", length: 24 * time.Hour, limit: daily})
+	}
+	return q
+}
+
+// configureWriteQuotas loads the quotas of authenticated identities from the server configuration
+func configureWriteQuotas() error {
+	hourly := viper.GetInt64("auth.hourly_entry_quota")
+	daily := viper.GetInt64("auth.daily_entry_quota")
+	if hourly < 0 || daily < 0 {
+		return errors.New("entry quotas must not be negative")
+	}
+	writeQuotas = newEntryQuotas(hourly, daily)
+	return nil
+}
+
+// usageOf returns the usage of identity in each window at now, starting the windows that have elapsed afresh
+func (q *entryQuotas) usageOf(identity string, now time.Time) []windowUsage {
+	q.sweep(now)
+	u, ok := q.usage[identity]
+	if !ok {
+		u = make([]windowUsage, len(q.windows))
+		q.usage[identity] = u
+	}
+	for i, w := range q.windows {
+		if start := now.UTC().Truncate(w.length); !u[i].start.Equal(start) {
+			u[i] = windowUsage{start: start}
+		}
+	}
+	return u
+}
+
+// sweep drops the usage of identities that have not added entries in any current window
+func (q *entryQuotas) sweep(now time.Time) {
+	if now.Sub(q.lastSweep) < clientSweepInterval {
+		return
+	}
+	q.lastSweep = now
+	for identity, u := range q.usage {
+		current := false
+		for i, w := range q.windows {
+			current = current || u[i].start.Equal(now.UTC().Truncate(w.length))
+		}
+		if !current {
+			delete(q.usage, identity)
+		}
+	}
+}
+
+// take counts an entry added by identity at now, returning false without counting it if identity has used
+// up its quota in any window; clients that were not authenticated are not limited
+func (q *entryQuotas) take(identity string, now time.Time) bool {
+	if identity == "" || len(q.windows) == 0 {
+		return true
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	u := q.usageOf(identity, now)
+	for i, w := range q.windows {
+		if u[i].count >= w.limit {
+			return false
+		}
+	}
+	for i := range q.windows {
+		u[i].count++
+	}
+	return true
+}
+
+// refund gives back the entry counted by take for identity at taken, for an entry that was not added after all,
+// in the windows that have not started afresh since
+func (q *entryQuotas) refund(identity string, taken time.Time) {
+	if identity == "" || len(q.windows) == 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	u, ok := q.usage[identity]
+	if !ok {
+		return
+	}
+	for i, w := range q.windows {
+		if u[i].start.Equal(taken.UTC().Truncate(w.length)) && u[i].count > 0 {
+			u[i].count--
+		}
+	}
+}
+
+// status returns the usage of identity in each window at now
+func (q *entryQuotas) status(identity string, now time.Time) []quotaStatus {
+	if identity == "" || len(q.windows) == 0 {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	u := q.usageOf(identity, now)
+	result := make([]quotaStatus, 0, len(q.windows))
+	for i, w := range q.windows {
+		result = append(result, quotaStatus{window: w, remaining: w.limit - u[i].count, reset: u[i].start.Add(w.length)})
+	}
+	return result
+}
+
+// QuotaHeaders reports the quotas of the authenticated client to requests that add entries in the
+// X-Rekor-Quota-<Window>-Limit, -Remaining and -Reset headers, where Reset is the number of seconds until the
+// window starts afresh; clients that have used up a quota are told when they may retry in Retry-After
+func QuotaHeaders(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := identityFrom(r.Context())
+		if identity == "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		ww := negroni.NewResponseWriter(w)
+		ww.Before(func(rw negroni.ResponseWriter) {
+			now := time.Now()
+			var retryAfter time.Duration
+			for _, s := range writeQuotas.status(identity, now) {
+				rw.Header().Set(fmt.Sprintf("X-Rekor-Quota-%v-Limit", s.window.name), strconv.FormatInt(s.window.limit, 10))
+				rw.Header().Set(fmt.Sprintf("X-Rekor-Quota-%v-Remaining", s.window.name), strconv.FormatInt(s.remaining, 10))
+				reset := s.reset.Sub(now)
+				rw.Header().Set(fmt.Sprintf("X-Rekor-Quota-%v-Reset", s.window.name), strconv.FormatInt(int64(math.Ceil(reset.Seconds())), 10))
+				if s.remaining <= 0 && reset > retryAfter {
+					retryAfter = reset
+				}
+			}
+			if rw.Status() == http.StatusTooManyRequests && retryAfter > 0 {
+				rw.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10))
+			}
+		})
+		handler.ServeHTTP(ww, r)
+	})
+}
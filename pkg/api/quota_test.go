@@ -0,0 +1,141 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEntryQuotasTake(t *testing.T) {
+	q := newEntryQuotas(2, 3)
+	now := time.Date(2021, 6, 1, 10, 59, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if !q.take("jdoe", now) {
+			t.Fatalf("entry %v rejected within the hourly quota", i)
+		}
+	}
+	if q.take("jdoe", now) {
+		t.Error("entry accepted beyond the hourly quota")
+	}
+	if !q.take("other", now) {
+		t.Error("the quota of one identity limited another")
+	}
+	if !q.take("", now) {
+		t.Error("unauthenticated client limited")
+	}
+
+	// the hourly quota starts afresh at the next hour, while the daily quota carries on
+	now = now.Add(2 * time.Minute)
+	if !q.take("jdoe", now) {
+		t.Error("entry rejected in a new hour")
+	}
+	if q.take("jdoe", now) {
+		t.Error("entry accepted beyond the daily quota")
+	}
+	status := q.status("jdoe", now)
+	if len(status) != 2 || status[0].remaining != 1 || status[1].remaining != 0 {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if !status[1].reset.Equal(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("daily quota resets at %v", status[1].reset)
+	}
+
+	if unlimited := newEntryQuotas(0, 0); !unlimited.take("jdoe", now) || unlimited.status("jdoe", now) != nil {
+		t.Error("identity limited without quotas")
+	}
+}
+
+func TestEntryQuotasRefund(t *testing.T) {
+	q := newEntryQuotas(1, 0)
+	taken := time.Date(2021, 6, 1, 10, 59, 0, 0, time.UTC)
+	if !q.take("jdoe", taken) {
+		t.Fatal("entry rejected within the quota")
+	}
+	q.refund("jdoe", taken)
+	if !q.take("jdoe", taken) {
+		t.Error("refunded entry still counted")
+	}
+	// entries refunded once their window has started afresh are not given back to the new window
+	now := taken.Add(2 * time.Minute)
+	if !q.take("jdoe", now) {
+		t.Fatal("entry rejected in a new hour")
+	}
+	q.refund("jdoe", taken)
+	if q.take("jdoe", now) {
+		t.Error("entry of the previous hour refunded to the new one")
+	}
+	q.refund("other", now)
+}
+
+func TestAdmitEntry(t *testing.T) {
+	defer func(q *entryQuotas) { writeQuotas = q }(writeQuotas)
+	writeQuotas = newEntryQuotas(0, 1)
+	ctx := withIdentity(context.Background(), "jdoe")
+
+	// entries that are not added after all, such as duplicates, are refunded
+	refund, apiErr := admitEntry(ctx)
+	if apiErr != nil {
+		t.Fatalf("entry rejected within the quota: %v", apiErr.err)
+	}
+	refund()
+	if _, apiErr = admitEntry(ctx); apiErr != nil {
+		t.Fatalf("refunded entry still counted: %v", apiErr.err)
+	}
+	if _, apiErr = admitEntry(ctx); apiErr == nil || apiErr.code != http.StatusTooManyRequests {
+		t.Errorf("expected entry beyond the quota to be rejected with 429, got %v", apiErr)
+	}
+}
+
+func TestQuotaHeaders(t *testing.T) {
+	defer func(q *entryQuotas) { writeQuotas = q }(writeQuotas)
+	writeQuotas = newEntryQuotas(0, 1)
+
+	handler := QuotaHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !writeQuotas.take(identityFrom(r.Context()), time.Now()) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	send := func(identity string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", nil)
+		r = r.WithContext(withIdentity(r.Context(), identity))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := send("jdoe")
+	if w.Code != http.StatusCreated || w.Header().Get("X-Rekor-Quota-Daily-Limit") != "1" || w.Header().Get("X-Rekor-Quota-Daily-Remaining") != "0" || w.Header().Get("X-Rekor-Quota-Daily-Reset") == "" {
+		t.Errorf("unexpected response %v with headers %v", w.Code, w.Header())
+	}
+	if w.Header().Get("X-Rekor-Quota-Hourly-Limit") != "" {
+		t.Error("unlimited window reported")
+	}
+	w = send("jdoe")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("unexpected response %v with headers %v", w.Code, w.Header())
+	}
+	if w = send(""); len(w.Header()) != 0 {
+		t.Errorf("quota reported to unauthenticated client: %v", w.Header())
+	}
+}
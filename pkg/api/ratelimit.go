@@ -0,0 +1,197 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/peer"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// clientSweepInterval is how often the buckets of clients that have stopped writing are dropped
+const clientSweepInterval = time.Minute
+
+// writeLimiter limits the rate of requests that write to the log with a token bucket shared by all clients
+// and a token bucket for each client address
+type writeLimiter struct {
+	global      *rate.Limiter
+	clientLimit rate.Limit
+	clientBurst int
+	// clientHeader names the header set by a trusted proxy with the address of the client; the address the
+	// request came from is used if it is empty
+	clientHeader string
+
+	mu        sync.Mutex
+	clients   map[string]*clientBucket
+	lastSweep time.Time
+}
+
+type clientBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+var (
+	writeLimits      = newWriteLimiter(0, 0, 0, 0, "")
+	unlimitedClients = rate.NewLimiter(rate.Inf, 0)
+)
+
+// newWriteLimiter returns a limiter allowing limit requests per second across all clients and clientLimit
+// requests per second from each client, where a limit of 0 means unlimited
+func newWriteLimiter(limit float64, burst int, clientLimit float64, clientBurst int, clientHeader string) *writeLimiter {
+	return &writeLimiter{
+		global:       rate.NewLimiter(bucketLimit(limit, &burst), burst),
+		clientLimit:  bucketLimit(clientLimit, &clientBurst),
+		clientBurst:  clientBurst,
+		clientHeader: clientHeader,
+		clients:      map[string]*clientBucket{},
+	}
+}
+
+// bucketLimit converts a configured rate to the limit of a token bucket, raising burst to 1 for limited
+// rates as a bucket holding no tokens would reject every request
+func bucketLimit(limit float64, burst *int) rate.Limit {
+	if limit == 0 {
+		return rate.Inf
+	}
+	if *burst == 0 {
+		*burst = 1
+	}
+	return rate.Limit(limit)
+}
+
+// configureWriteLimits loads the write rate limits from the server configuration
+func configureWriteLimits() error {
+	limit := viper.GetFloat64("rate_limit.write_requests_per_second")
+	burst := viper.GetInt("rate_limit.write_burst")
+	clientLimit := viper.GetFloat64("rate_limit.client_requests_per_second")
+	clientBurst := viper.GetInt("rate_limit.client_burst")
+	if limit < 0 || clientLimit < 0 {
+		return errors.New("write rate limits must not be negative")
+	}
+	if burst < 0 || clientBurst < 0 {
+		return errors.New("write rate bursts must not be negative")
+	}
+	writeLimits = newWriteLimiter(limit, burst, clientLimit, clientBurst, viper.GetString("rate_limit.client_ip_header"))
+	return nil
+}
+
+// reserve takes a token for a request from client at now, returning how long the client must wait before
+// retrying if either its bucket or the global one is empty; no token is taken from either bucket then
+func (l *writeLimiter) reserve(client string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	g, r := l.global.ReserveN(now, 1), l.bucketFor(client, now).ReserveN(now, 1)
+	delay := g.DelayFrom(now)
+	if d := r.DelayFrom(now); d > delay {
+		delay = d
+	}
+	if delay > 0 {
+		g.CancelAt(now)
+		r.CancelAt(now)
+	}
+	return delay
+}
+
+// bucketFor returns the bucket of client, which is shared by all clients if they are not limited
+func (l *writeLimiter) bucketFor(client string, now time.Time) *rate.Limiter {
+	if l.clientLimit == rate.Inf {
+		return unlimitedClients
+	}
+	l.sweep(now)
+	c, ok := l.clients[client]
+	if !ok {
+		c = &clientBucket{limiter: rate.NewLimiter(l.clientLimit, l.clientBurst)}
+		l.clients[client] = c
+	}
+	c.lastSeen = now
+	return c.limiter
+}
+
+// sweep drops the buckets of clients that have been idle long enough for their bucket to refill, as a new
+// bucket starts full
+func (l *writeLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < clientSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	refill := time.Duration(float64(l.clientBurst) / float64(l.clientLimit) * float64(time.Second))
+	for client, c := range l.clients {
+		if now.Sub(c.lastSeen) >= refill {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// clientAddress returns the address of the client that sent r
+func (l *writeLimiter) clientAddress(r *http.Request) string {
+	if l.clientHeader != "" {
+		// X-Forwarded-For lists the client first, followed by the proxies the request passed through
+		if v := strings.TrimSpace(strings.Split(r.Header.Get(l.clientHeader), ",")[0]); v != "" {
+			return v
+		}
+	}
+	return hostOf(r.RemoteAddr)
+}
+
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// grpcClientAddress returns the address of the client of a gRPC call
+func grpcClientAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return hostOf(p.Addr.String())
+	}
+	return ""
+}
+
+// RateLimitWrites rejects requests with 429 once the write rate limits under rate_limit are exceeded, telling
+// clients when they may retry in the Retry-After header
+func RateLimitWrites(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := writeLimits
+		client := l.clientAddress(r)
+		if delay := l.reserve(client, time.Now()); delay > 0 {
+			log.RequestIDLogger(r).Infow("rate limiting write request", "client", client, "retryAfter", delay)
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(delay.Seconds())), 10))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(requestErrorMsg(r, writeRateExceeded, http.StatusTooManyRequests))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
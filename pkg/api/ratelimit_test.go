@@ -0,0 +1,100 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWriteLimiterReserve(t *testing.T) {
+	now := time.Now()
+
+	// each client may write once per second, with a burst of 2
+	l := newWriteLimiter(0, 0, 1, 2, "")
+	for i := 0; i < 2; i++ {
+		if d := l.reserve("a", now); d != 0 {
+			t.Fatalf("request %v within burst delayed by %v", i, d)
+		}
+	}
+	if d := l.reserve("a", now); d != time.Second {
+		t.Errorf("request beyond burst delayed by %v, expected 1s", d)
+	}
+	// a rejected request does not use up a token
+	if d := l.reserve("a", now.Add(time.Second)); d != 0 {
+		t.Errorf("request after refill delayed by %v", d)
+	}
+	if d := l.reserve("b", now); d != 0 {
+		t.Errorf("request from another client delayed by %v", d)
+	}
+
+	// all clients share 2 writes per second, and an unset burst allows one at a time
+	l = newWriteLimiter(2, 0, 0, 0, "")
+	if d := l.reserve("a", now); d != 0 {
+		t.Fatalf("first request delayed by %v", d)
+	}
+	if d := l.reserve("b", now); d != 500*time.Millisecond {
+		t.Errorf("request beyond global limit delayed by %v, expected 500ms", d)
+	}
+
+	// the bucket of an idle client is dropped once it has refilled
+	l = newWriteLimiter(0, 0, 1, 2, "")
+	l.reserve("a", now)
+	l.reserve("b", now.Add(clientSweepInterval))
+	if _, ok := l.clients["a"]; ok {
+		t.Error("bucket of idle client was kept")
+	}
+}
+
+func TestRateLimitWrites(t *testing.T) {
+	defer func(l *writeLimiter) { writeLimits = l }(writeLimits)
+	writeLimits = newWriteLimiter(0, 0, 0.5, 1, "X-Forwarded-For")
+
+	handler := RateLimitWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	send := func(forwardedFor string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/log/entries", nil)
+		r.RemoteAddr = "192.0.2.1:1234"
+		if forwardedFor != "" {
+			r.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := send("198.51.100.1, 192.0.2.1"); w.Code != http.StatusCreated {
+		t.Fatalf("first request returned %v", w.Code)
+	}
+	w := send("198.51.100.1")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "2" {
+		t.Errorf("second request returned %v with Retry-After %q", w.Code, w.Header().Get("Retry-After"))
+	}
+	if w := send("198.51.100.2"); w.Code != http.StatusCreated {
+		t.Errorf("request from another client returned %v", w.Code)
+	}
+	// requests without the header are limited by the address they came from
+	if w := send(""); w.Code != http.StatusCreated {
+		t.Errorf("request without header returned %v", w.Code)
+	}
+	if w := send(""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("second request without header returned %v", w.Code)
+	}
+}
@@ -0,0 +1,371 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/trillian"
+	"github.com/spf13/viper"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/genproto/protobuf/field_mask"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+const (
+	rotationReasonSize   = "size"
+	rotationReasonAge    = "age"
+	rotationReasonManual = "manual"
+)
+
+var errRotationDisabled = errors.New("sharding.records_path must be set to rotate the log")
+
+// RotationRecord documents the rotation of the log to a new tree: the tree head its previous tree was frozen at,
+// which it becomes an inactive shard with, and the first tree head of the tree that takes the new entries. It is
+// appended to the records file, from which the trees of the log are read when the server starts, and carries a
+// note over the same fields signed with the checkpoint key.
+type RotationRecord struct {
+	Reason         string         `json:"reason"`
+	FrozenTreeID   int64          `json:"frozenTreeID"`
+	FrozenTreeSize uint64         `json:"frozenTreeSize"`
+	FrozenRootHash string         `json:"frozenRootHash"`
+	FrozenTreeHead SignedTreeHead `json:"frozenTreeHead"`
+	ActiveTreeID   int64          `json:"activeTreeID"`
+	ActiveTreeHead SignedTreeHead `json:"activeTreeHead"`
+	// Offset is the index in the log of the first entry of the active tree
+	Offset int64 `json:"offset"`
+	// InactiveShards holds the IDs of the frozen trees of the log after the rotation, oldest first
+	InactiveShards []int64   `json:"inactiveShards"`
+	RotatedAt      time.Time `json:"rotatedAt"`
+	Note           string    `json:"note"`
+}
+
+// rotationText returns the body of the signed note of a rotation
+func rotationText(name string, r *RotationRecord) string {
+	rootHash, _ := hex.DecodeString(r.FrozenRootHash)
+	return fmt.Sprintf("%v - rotation\nfrozen %d %d %v\nactive %d %d\n%v\n", name, r.FrozenTreeID, r.FrozenTreeSize,
+		base64.StdEncoding.EncodeToString(rootHash), r.ActiveTreeID, r.Offset, r.RotatedAt.UTC().Format(time.RFC3339))
+}
+
+// shardRotation freezes the current tree of the log and continues the log in a new tree once the current one
+// reaches the configured size or age, so that no tree grows without bound
+type shardRotation struct {
+	// mu serializes rotations and guards records
+	mu      sync.Mutex
+	records []RotationRecord
+
+	api           *API
+	path          string
+	curve         string
+	maxSize       int64
+	maxAge        time.Duration
+	checkInterval time.Duration
+}
+
+var rotation = &shardRotation{}
+
+// configureShardRotation sets up the rotation of the tree of a from the server configuration, loading the records
+// of its earlier rotations; the log is only rotated when sharding.records_path is set
+func configureShardRotation(a *API) (*shardRotation, error) {
+	r := &shardRotation{
+		api:           a,
+		path:          viper.GetString("sharding.records_path"),
+		curve:         viper.GetString("trillian_log_server.signing_curve"),
+		maxSize:       viper.GetInt64("sharding.rotate_at_size"),
+		maxAge:        viper.GetDuration("sharding.rotate_after"),
+		checkInterval: viper.GetDuration("sharding.check_interval"),
+	}
+	if r.maxSize < 0 || r.maxAge < 0 {
+		return nil, errors.New("sharding.rotate_at_size and sharding.rotate_after must not be negative")
+	}
+	if r.path == "" {
+		if r.policy() {
+			return nil, errRotationDisabled
+		}
+		return r, nil
+	}
+	if viper.GetBool("read-only") && r.policy() {
+		return nil, errors.New("read-only instances cannot rotate the log")
+	}
+	if viper.GetString("failover.peer_url") != "" {
+		// the peer would go on serving the frozen tree
+		return nil, errors.New("the log of a failover pair cannot be rotated")
+	}
+	records, err := readRotationRecords(r.path)
+	if err != nil {
+		return nil, err
+	}
+	r.records = records
+	return r, nil
+}
+
+// readRotationRecords returns the records of the rotations of the log in the file at path, oldest first, or none
+// if path is empty or the file does not exist yet
+func readRotationRecords(path string) ([]RotationRecord, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []RotationRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record RotationRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return nil, fmt.Errorf("error parsing rotation record %v of %v: %w", len(records)+1, path, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+// policy reports whether the log is rotated on reaching a size or an age, rather than only when asked to
+func (r *shardRotation) policy() bool {
+	return r.maxSize > 0 || r.maxAge > 0
+}
+
+// originTreeID returns the tree the log was first rotated from, which the configured checkpoint origin identifies,
+// or current if the log has not been rotated
+func (r *shardRotation) originTreeID(current int64) int64 {
+	if len(r.records) == 0 {
+		return current
+	}
+	return r.records[0].FrozenTreeID
+}
+
+// start checks the tree against the policy every check interval, rotating it when it is due
+func (r *shardRotation) start(ctx context.Context) error {
+	if r.path == "" {
+		return nil
+	}
+	if checkpointSigner == nil {
+		return errors.New("checkpoint.signing_key must be set to rotate the log, as its rotations are signed with it")
+	}
+	if !r.policy() {
+		return nil
+	}
+	if r.checkInterval <= 0 {
+		return errors.New("sharding.check_interval must be positive")
+	}
+	go func() {
+		ticker := time.NewTicker(r.checkInterval)
+		defer ticker.Stop()
+		for {
+			if err := r.check(ctx); err != nil {
+				log.Logger.Errorw("error rotating the log", "error", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// check rotates the tree if it has reached the configured size or age
+func (r *shardRotation) check(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	reason, err := r.due(ctx)
+	if err != nil || reason == "" {
+		return err
+	}
+	_, err = r.rotate(ctx, reason)
+	return err
+}
+
+// rotateNow rotates the tree regardless of the policy
+func (r *shardRotation) rotateNow(ctx context.Context) (*RotationRecord, error) {
+	if r.path == "" {
+		return nil, errRotationDisabled
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rotate(ctx, rotationReasonManual)
+}
+
+// due returns the reason the current tree is to be rotated, or "" if it is not
+func (r *shardRotation) due(ctx context.Context) (string, error) {
+	current := r.api.current()
+	if r.maxSize > 0 {
+		tc := current.client(ctx)
+		root, err := tc.root()
+		if err != nil {
+			return "", err
+		}
+		if int64(root.TreeSize) >= r.maxSize {
+			return rotationReasonSize, nil
+		}
+	}
+	if r.maxAge > 0 {
+		ctx, cancel := withTimeout(ctx, trillianTimeout)
+		defer cancel()
+		tree, err := r.api.logAdminClient.GetTree(ctx, &trillian.GetTreeRequest{TreeId: current.logID})
+		if err != nil {
+			return "", err
+		}
+		if time.Since(tree.CreateTime.AsTime()) >= r.maxAge {
+			return rotationReasonAge, nil
+		}
+	}
+	return "", nil
+}
+
+// rotate freezes the current tree and makes a new tree the current one, after appending the signed record of the
+// rotation to the records file. New entries wait for the rotation and those in flight are logged in the tree being
+// frozen. If the new tree cannot be set up, the frozen tree is made active again. r.mu must be held.
+func (r *shardRotation) rotate(ctx context.Context, reason string) (*RotationRecord, error) {
+	if checkpointSigner == nil {
+		return nil, errors.New("rotations cannot be signed without checkpoint.signing_key")
+	}
+	runtimeCfg.entries.Lock()
+	defer runtimeCfg.entries.Unlock()
+
+	current := r.api.current()
+	if err := r.setTreeState(ctx, current.logID, trillian.TreeState_FROZEN); err != nil {
+		return nil, fmt.Errorf("error freezing tree %v: %w", current.logID, err)
+	}
+	record, next, err := r.provision(ctx, current, reason)
+	if err != nil {
+		if reactivateErr := r.setTreeState(ctx, current.logID, trillian.TreeState_ACTIVE); reactivateErr != nil {
+			log.Logger.Errorw("frozen tree could not be made active again", "tree", current.logID, "error", reactivateErr)
+		}
+		return nil, err
+	}
+
+	current.size = int64(record.FrozenTreeSize)
+	r.api.activate(next, current)
+	r.records = append(r.records, *record)
+	metricShardRotations.WithLabelValues(reason).Inc()
+	// logged at warn so that the audit record survives raising the log level
+	log.Logger.Warnw("rotated the log to a new tree", "record", record)
+	return record, nil
+}
+
+// provision creates the tree the log continues in after the frozen tree of current and records the rotation to it
+func (r *shardRotation) provision(ctx context.Context, current logShard, reason string) (*RotationRecord, *API, error) {
+	frozen := current.client(ctx)
+	slr, root, err := frozen.verifiedSignedRoot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading tree head of frozen tree %v: %w", current.logID, err)
+	}
+
+	tree, err := createTree(ctx, r.api.logAdminClient, current.logClient, r.curve)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating tree: %w", err)
+	}
+	next, err := newTreeAPI(ctx, r.api.logAdminClient, current.logClient, tree.TreeId, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	offset := current.start + int64(root.TreeSize)
+	active := logShard{API: next, start: offset}.client(ctx)
+	nextSLR, _, err := active.verifiedSignedRoot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading tree head of new tree %v: %w", tree.TreeId, err)
+	}
+
+	record := &RotationRecord{
+		Reason:         reason,
+		FrozenTreeID:   current.logID,
+		FrozenTreeSize: root.TreeSize,
+		FrozenRootHash: hex.EncodeToString(root.RootHash),
+		FrozenTreeHead: newSignedTreeHead(slr),
+		ActiveTreeID:   tree.TreeId,
+		ActiveTreeHead: newSignedTreeHead(nextSLR),
+		Offset:         offset,
+		RotatedAt:      time.Now().UTC(),
+	}
+	for _, s := range r.api.shards() {
+		record.InactiveShards = append(record.InactiveShards, s.logID)
+	}
+	record.InactiveShards = append(record.InactiveShards, current.logID)
+	signed, err := note.Sign(&note.Note{Text: rotationText(checkpointSigner.Name(), record)}, checkpointSigner)
+	if err != nil {
+		return nil, nil, err
+	}
+	record.Note = string(signed)
+	if err := r.appendRecord(record); err != nil {
+		return nil, nil, fmt.Errorf("error writing rotation record: %w", err)
+	}
+	return record, next, nil
+}
+
+// appendRecord appends a record to the records file, syncing it so that the server starts with the new tree if it
+// stops right after the rotation
+func (r *shardRotation) appendRecord(record *RotationRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Clean(r.path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (r *shardRotation) setTreeState(ctx context.Context, treeID int64, state trillian.TreeState) error {
+	ctx, cancel := withTimeout(ctx, trillianTimeout)
+	defer cancel()
+	_, err := r.api.logAdminClient.UpdateTree(ctx, &trillian.UpdateTreeRequest{
+		Tree:       &trillian.Tree{TreeId: treeID, TreeState: state},
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"tree_state"}},
+	})
+	return err
+}
+
+// currentRecords returns the records of the rotations of the log, oldest first
+func (r *shardRotation) currentRecords() []RotationRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RotationRecord{}, r.records...)
+}
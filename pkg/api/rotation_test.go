@@ -0,0 +1,147 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"golang.org/x/mod/sumdb/note"
+)
+
+func TestShardRotation(t *testing.T) {
+	ctx := context.Background()
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	signer, vkey, err := newCheckpointSigner("rekor.example.com", pkcs8PEM(t, edKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(s note.Signer) { checkpointSigner = s }(checkpointSigner)
+	checkpointSigner = signer
+	defer func(a *API) { api = a }(api)
+
+	embedded := newEmbeddedLog(newMemoryLogStorage())
+	tree, err := createAndInitTree(ctx, embedded, embedded, "P-256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api, err = newTreeAPI(ctx, embedded, embedded, tree.TreeId, nil); err != nil {
+		t.Fatal(err)
+	}
+	api.logAdminClient = embedded
+	first := NewTrillianClient(ctx)
+	for i := 0; i < 3; i++ {
+		if resp := first.addLeaf([]byte(fmt.Sprintf("leaf %v", i))); resp.err != nil {
+			t.Fatal(resp.err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "rotations")
+	r := &shardRotation{api: api, path: path, curve: "P-256", maxSize: 3}
+	if err := r.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	records := r.currentRecords()
+	if len(records) != 1 {
+		t.Fatalf("unexpected number of rotations %v", len(records))
+	}
+	record := records[0]
+	if record.Reason != rotationReasonSize || record.FrozenTreeID != tree.TreeId || record.FrozenTreeSize != 3 || record.Offset != 3 ||
+		len(record.InactiveShards) != 1 || record.InactiveShards[0] != tree.TreeId {
+		t.Errorf("unexpected rotation record %+v", record)
+	}
+	verifier, err := note.NewVerifier(vkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := note.Open([]byte(record.Note), note.VerifierList(verifier)); err != nil || n.Text != rotationText("rekor.example.com", &record) {
+		t.Errorf("rotation note could not be verified: %v", err)
+	}
+
+	// the frozen tree takes no new entries, which go to the new tree after those of the frozen one
+	if resp := first.addLeaf([]byte("leaf 3")); resp.err == nil {
+		t.Error("leaf added to the frozen tree")
+	}
+	tc := NewTrillianClient(ctx)
+	if tc.logID != record.ActiveTreeID || tc.offset != 3 || len(tc.shards) != 1 {
+		t.Fatalf("unexpected client of tree %v at offset %v after rotation", tc.logID, tc.offset)
+	}
+	resp := tc.addLeaf([]byte("leaf 3"))
+	if resp.err != nil {
+		t.Fatal(resp.err)
+	}
+	if index := resp.getAddResult.QueuedLeaf.Leaf.LeafIndex; index != 3 {
+		t.Errorf("unexpected index %v of leaf added after rotation", index)
+	}
+	if resp := tc.getLogLeavesByRange(0, 10); resp.err != nil || len(resp.getLeafByRangeResult.Leaves) != 4 {
+		t.Errorf("unexpected leaves of rotated log: %v", resp.err)
+	}
+
+	// the new tree is not due until it reaches the size in turn, but can be rotated at any time
+	if err := r.check(ctx); err != nil || len(r.currentRecords()) != 1 {
+		t.Errorf("unexpected rotation of tree below the size: %v", err)
+	}
+	second, err := r.rotateNow(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Reason != rotationReasonManual || second.Offset != 4 || len(second.InactiveShards) != 2 || second.InactiveShards[1] != record.ActiveTreeID {
+		t.Errorf("unexpected record of second rotation %+v", second)
+	}
+
+	// the server starts from the trees of the last rotation
+	read, err := readRotationRecords(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 2 || read[1].ActiveTreeID != second.ActiveTreeID || read[1].Note != second.Note {
+		t.Errorf("unexpected records read back %+v", read)
+	}
+	if id := r.originTreeID(second.ActiveTreeID); id != tree.TreeId {
+		t.Errorf("unexpected checkpoint origin tree %v", id)
+	}
+}
+
+func TestConfigureShardRotation(t *testing.T) {
+	defer viper.Reset()
+	path := filepath.Join(t.TempDir(), "rotations")
+	for _, tc := range []struct {
+		settings map[string]interface{}
+		valid    bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"sharding.records_path": path}, true},
+		{map[string]interface{}{"sharding.records_path": path, "sharding.rotate_at_size": 1000}, true},
+		{map[string]interface{}{"sharding.rotate_at_size": 1000}, false},
+		{map[string]interface{}{"sharding.records_path": path, "sharding.rotate_at_size": -1}, false},
+		{map[string]interface{}{"sharding.records_path": path, "sharding.rotate_after": "1h", "read-only": true}, false},
+		{map[string]interface{}{"sharding.records_path": path, "failover.peer_url": "https://peer.example.com"}, false},
+	} {
+		viper.Reset()
+		for k, v := range tc.settings {
+			viper.Set(k, v)
+		}
+		if _, err := configureShardRotation(&API{}); (err == nil) != tc.valid {
+			t.Errorf("unexpected result for %v: %v", tc.settings, err)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// logShard is a frozen tree the log was written to before its current tree. The entries of the shards and of the
+// current tree follow each other in the log index, so that the entry at index i of a shard is at index start+i of
+// the log.
+type logShard struct {
+	*API
+	start int64
+	size  int64
+}
+
+// newInactiveShards returns the shards with the given tree IDs, oldest first, along with the index in the log of
+// the first entry of the current tree, which follows them
+func newInactiveShards(ctx context.Context, logAdminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient, treeIDs []string, currentTreeID int64) ([]logShard, int64, error) {
+	seen := map[int64]bool{currentTreeID: true}
+	var shards []logShard
+	var start int64
+	for _, spec := range treeIDs {
+		treeID, err := strconv.ParseInt(spec, 10, 64)
+		if err != nil || treeID <= 0 {
+			return nil, 0, fmt.Errorf("tree ID %q of inactive shard must be a positive integer", spec)
+		}
+		if seen[treeID] {
+			return nil, 0, fmt.Errorf("tree %v is given more than once as a shard of the log", treeID)
+		}
+		seen[treeID] = true
+
+		t, err := logAdminClient.GetTree(ctx, &trillian.GetTreeRequest{TreeId: treeID})
+		if err != nil {
+			return nil, 0, fmt.Errorf("inactive shard %v: %w", treeID, err)
+		}
+		if t.TreeState != trillian.TreeState_FROZEN {
+			// the size of the shard is read once, so the entries it takes from now on are not served and those of
+			// later shards move in the log index when the server restarts
+			log.Logger.Warnf("inactive shard %v is in state %v rather than frozen", treeID, t.TreeState)
+		}
+		a, err := newTreeAPI(ctx, logAdminClient, logClient, treeID, nil)
+		if err != nil {
+			return nil, 0, fmt.Errorf("inactive shard %v: %w", treeID, err)
+		}
+		shard := logShard{API: a, start: start}
+		tc := shard.client(ctx)
+		root, err := tc.root()
+		if err != nil {
+			return nil, 0, fmt.Errorf("reading the size of inactive shard %v: %w", treeID, err)
+		}
+		shard.size = int64(root.TreeSize)
+		shards = append(shards, shard)
+		start += shard.size
+	}
+	return shards, start, nil
+}
+
+// current returns the current tree of the log as a shard starting at its index in the log
+func (a *API) current() logShard {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return logShard{
+		API: &API{
+			logClient: a.logClient,
+			logID:     a.logID,
+			pubkey:    a.pubkey,
+			verifier:  a.verifier,
+			keys:      a.keys,
+		},
+		start: a.offset,
+	}
+}
+
+// shards returns the inactive shards of the log, oldest first
+func (a *API) shards() []logShard {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.inactiveShards
+}
+
+// activate makes the tree of next the current tree of the log, following frozen as its newest inactive shard
+func (a *API) activate(next *API, frozen logShard) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	// clients hold on to the previous shards, so they are copied rather than appended to
+	shards := make([]logShard, 0, len(a.inactiveShards)+1)
+	a.inactiveShards = append(append(shards, a.inactiveShards...), frozen)
+	a.offset = frozen.start + frozen.size
+	a.logClient = next.logClient
+	a.logID = next.logID
+	a.pubkey = next.pubkey
+	a.verifier = next.verifier
+	a.keys = next.keys
+}
+
+// client returns a client of the tree of the shard, whose leaves are given their index in the log
+func (s logShard) client(ctx context.Context) TrillianClient {
+	return TrillianClient{
+		client:   s.logClient,
+		logID:    s.logID,
+		context:  ctx,
+		pubkey:   s.pubkey,
+		verifier: s.verifier,
+		keys:     s.keys,
+		offset:   s.start,
+	}
+}
+
+// shardAt returns the client of the shard holding the entry at index in the log, which is the current tree for
+// the indexes following those of the inactive shards
+func (t *TrillianClient) shardAt(index int64) TrillianClient {
+	for _, s := range t.shards {
+		if index < s.start+s.size {
+			return s.client(t.context)
+		}
+	}
+	return *t
+}
+
+// getLogLeafByIndex returns the leaf at index in the log, from the shard holding it
+func (t *TrillianClient) getLogLeafByIndex(index int64) *Response {
+	return t.getLogLeavesByRange(index, 1)
+}
+
+// getLogLeavesByRange returns up to count leaves starting at index start of the log, reading them from each of the
+// shards the range spans; the leaves have their index in the log, and fewer are returned when the log ends before
+// the range does
+func (t *TrillianClient) getLogLeavesByRange(start, count int64) *Response {
+	var leaves []*trillian.LogLeaf
+	for {
+		shard := t.shardAt(start)
+		resp := shard.getLeavesByRange(start-shard.offset, count)
+		if resp.err != nil {
+			return resp
+		}
+		batch := resp.getLeafByRangeResult.GetLeaves()
+		for _, leaf := range batch {
+			leaf.LeafIndex += shard.offset
+		}
+		leaves = append(leaves, batch...)
+		start += int64(len(batch))
+		count -= int64(len(batch))
+		if count <= 0 || len(batch) == 0 || shard.logID == t.logID {
+			resp.getLeafByRangeResult.Leaves = leaves
+			return resp
+		}
+	}
+}
+
+// getLogLeavesByHash returns the leaves with the given hashes from the current tree or, for those it does not hold,
+// from the inactive shards; the leaves have their index in the log
+func (t *TrillianClient) getLogLeavesByHash(hashValues [][]byte) *Response {
+	resp := t.getLeafByHash(hashValues)
+	if resp.err != nil {
+		return resp
+	}
+	for _, leaf := range resp.getLeafResult.Leaves {
+		leaf.LeafIndex += t.offset
+	}
+	if len(t.shards) == 0 {
+		return resp
+	}
+	inactive, err := t.inactiveShardLeaves(missingLeaves(hashValues, resp.getLeafResult.Leaves))
+	if err != nil {
+		return &Response{
+			status: status.Code(err),
+			err:    err,
+		}
+	}
+	for _, hash := range hashValues {
+		if leaf, ok := inactive[string(hash)]; ok {
+			resp.getLeafResult.Leaves = append(resp.getLeafResult.Leaves, leaf)
+		}
+	}
+	return resp
+}
+
+// inactiveShardLeaves returns the leaves with the given hashes held by the inactive shards, by hash; the leaves have
+// their index in the log
+func (t *TrillianClient) inactiveShardLeaves(hashValues [][]byte) (map[string]*trillian.LogLeaf, error) {
+	found := map[string]*trillian.LogLeaf{}
+	// the newest shards are the most likely to hold the entries looked up
+	for i := len(t.shards) - 1; i >= 0 && len(hashValues) > 0; i-- {
+		shard := t.shards[i].client(t.context)
+		resp := shard.getLeafByHash(hashValues)
+		if resp.err != nil {
+			return nil, resp.err
+		}
+		leaves := resp.getLeafResult.GetLeaves()
+		for _, leaf := range leaves {
+			leaf.LeafIndex += shard.offset
+			found[string(leaf.MerkleLeafHash)] = leaf
+		}
+		hashValues = missingLeaves(hashValues, leaves)
+	}
+	return found, nil
+}
+
+// missingLeaves returns the hashes of hashValues that none of leaves has
+func missingLeaves(hashValues [][]byte, leaves []*trillian.LogLeaf) [][]byte {
+	found := make(map[string]bool, len(leaves))
+	for _, leaf := range leaves {
+		found[string(leaf.MerkleLeafHash)] = true
+	}
+	var missing [][]byte
+	for _, hash := range hashValues {
+		if !found[string(hash)] {
+			missing = append(missing, hash)
+		}
+	}
+	return missing
+}
+
+// alreadyLogged returns the result of queueing a leaf that is already held by an inactive shard, which is that of
+// queueing a leaf already in the tree
+func alreadyLogged(leaf *trillian.LogLeaf) *trillian.QueuedLogLeaf {
+	return &trillian.QueuedLogLeaf{
+		Leaf:   leaf,
+		Status: status.Newf(codes.AlreadyExists, "leaf is at index %v of the log", leaf.LeafIndex).Proto(),
+	}
+}
+
+// leafHashes returns the Merkle leaf hashes of byteValues
+func leafHashes(byteValues [][]byte) [][]byte {
+	hashes := make([][]byte, 0, len(byteValues))
+	for _, v := range byteValues {
+		hashes = append(hashes, rfc6962.DefaultHasher.HashLeaf(v))
+	}
+	return hashes
+}
+
+// getLogProofByHash returns the inclusion proof of a leaf in the current tree or, if it is not there, in the
+// inactive shard holding it, along with the client of the tree the proof is in
+func (t *TrillianClient) getLogProofByHash(hashValue []byte) (TrillianClient, *Response) {
+	resp := t.getProofByHash(hashValue)
+	if resp.status != codes.NotFound {
+		return *t, resp
+	}
+	for i := len(t.shards) - 1; i >= 0; i-- {
+		shard := t.shards[i].client(t.context)
+		if shardResp := shard.getProofByHash(hashValue); shardResp.status != codes.NotFound {
+			return shard, shardResp
+		}
+	}
+	return *t, resp
+}
+
+// inactiveShardInfo returns the tree head each inactive shard was frozen at, oldest first
+func (t *TrillianClient) inactiveShardInfo() ([]*models.InactiveShardLogInfo, error) {
+	result := make([]*models.InactiveShardLogInfo, 0, len(t.shards))
+	for _, s := range t.shards {
+		shard := s.client(t.context)
+		slr, root, err := shard.verifiedSignedRoot()
+		if err != nil {
+			return nil, fmt.Errorf("reading tree head of inactive shard %v: %w", s.logID, err)
+		}
+		keyHint := strfmt.Base64(slr.GetKeyHint())
+		logRoot := strfmt.Base64(slr.GetLogRoot())
+		signature := strfmt.Base64(slr.GetLogRootSignature())
+		result = append(result, &models.InactiveShardLogInfo{
+			TreeID:   swag.String(strconv.FormatInt(s.logID, 10)),
+			RootHash: swag.String(hex.EncodeToString(root.RootHash)),
+			TreeSize: swag.Int64(int64(root.TreeSize)),
+			SignedTreeHead: &models.InactiveShardLogInfoSignedTreeHead{
+				KeyHint:   &keyHint,
+				LogRoot:   &logRoot,
+				Signature: &signature,
+			},
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// newShardedTrillianClient returns a client of a log whose two inactive shards hold the leaves "a0" to "a2" and
+// "b0" to "b1", and whose current tree holds the leaves "c0" and "c1"
+func newShardedTrillianClient(t *testing.T) TrillianClient {
+	t.Helper()
+	ctx := context.Background()
+	embedded := newEmbeddedLog(newMemoryLogStorage())
+	var treeIDs []string
+	var current *API
+	for _, shard := range []struct {
+		prefix string
+		size   int
+	}{{"a", 3}, {"b", 2}, {"c", 2}} {
+		tree, err := embedded.CreateTree(ctx, &trillian.CreateTreeRequest{
+			Tree: &trillian.Tree{
+				TreeType:           trillian.TreeType_LOG,
+				HashStrategy:       trillian.HashStrategy_RFC6962_SHA256,
+				HashAlgorithm:      sigpb.DigitallySigned_SHA256,
+				SignatureAlgorithm: sigpb.DigitallySigned_ECDSA,
+				TreeState:          trillian.TreeState_ACTIVE,
+			},
+			KeySpec: &keyspb.Specification{
+				Params: &keyspb.Specification_EcdsaParams{EcdsaParams: &keyspb.Specification_ECDSA{Curve: keyspb.Specification_ECDSA_P256}},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tclient.InitLog(ctx, tree, embedded); err != nil {
+			t.Fatal(err)
+		}
+		a, err := newTreeAPI(ctx, embedded, embedded, tree.TreeId, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tc := logShard{API: a}.client(ctx)
+		for i := 0; i < shard.size; i++ {
+			if resp := tc.addLeaf([]byte(fmt.Sprintf("%v%v", shard.prefix, i))); resp.err != nil {
+				t.Fatal(resp.err)
+			}
+		}
+		treeIDs = append(treeIDs, strconv.FormatInt(tree.TreeId, 10))
+		current = a
+	}
+
+	shards, offset, err := newInactiveShards(ctx, embedded, embedded, treeIDs[:2], current.logID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset != 5 || len(shards) != 2 || shards[1].start != 3 || shards[1].size != 2 {
+		t.Fatalf("unexpected shards %+v with offset %v", shards, offset)
+	}
+	return TrillianClient{
+		client:   current.logClient,
+		logID:    current.logID,
+		context:  ctx,
+		pubkey:   current.pubkey,
+		verifier: current.verifier,
+		keys:     current.keys,
+		offset:   offset,
+		shards:   shards,
+	}
+}
+
+func TestShardedLogLeaves(t *testing.T) {
+	tc := newShardedTrillianClient(t)
+	values := []string{"a0", "a1", "a2", "b0", "b1", "c0", "c1"}
+
+	// ranges are read across shards, with the index of each leaf in the log
+	resp := tc.getLogLeavesByRange(1, 10)
+	if resp.err != nil {
+		t.Fatal(resp.err)
+	}
+	leaves := resp.getLeafByRangeResult.Leaves
+	if len(leaves) != 6 {
+		t.Fatalf("unexpected number of leaves %v", len(leaves))
+	}
+	for i, leaf := range leaves {
+		if leaf.LeafIndex != int64(i+1) || string(leaf.LeafValue) != values[i+1] {
+			t.Errorf("unexpected leaf %v at index %v", string(leaf.LeafValue), leaf.LeafIndex)
+		}
+	}
+	if resp := tc.getLogLeafByIndex(4); resp.err != nil || len(resp.getLeafByRangeResult.Leaves) != 1 || string(resp.getLeafByRangeResult.Leaves[0].LeafValue) != "b1" {
+		t.Errorf("unexpected leaf at index 4: %v", resp.err)
+	}
+	if resp := tc.getLogLeafByIndex(7); resp.err != nil || len(resp.getLeafByRangeResult.Leaves) != 0 {
+		t.Errorf("unexpected leaf past the end of the log: %v", resp.err)
+	}
+
+	// leaves are found by hash in whichever shard holds them
+	var hashes [][]byte
+	for _, v := range append(values, "missing") {
+		hashes = append(hashes, rfc6962.DefaultHasher.HashLeaf([]byte(v)))
+	}
+	byHash := tc.getLogLeavesByHash(hashes)
+	if byHash.err != nil {
+		t.Fatal(byHash.err)
+	}
+	if len(byHash.getLeafResult.Leaves) != len(values) {
+		t.Fatalf("unexpected number of leaves by hash %v", len(byHash.getLeafResult.Leaves))
+	}
+	for _, leaf := range byHash.getLeafResult.Leaves {
+		if values[leaf.LeafIndex] != string(leaf.LeafValue) {
+			t.Errorf("unexpected index %v of leaf %v", leaf.LeafIndex, string(leaf.LeafValue))
+		}
+	}
+}
+
+func TestShardedLogAddLeaves(t *testing.T) {
+	tc := newShardedTrillianClient(t)
+
+	// new leaves are added to the current tree, after the leaves of the shards in the log
+	resp := tc.addLeaf([]byte("c2"))
+	if resp.err != nil {
+		t.Fatal(resp.err)
+	}
+	if leaf := resp.getAddResult.QueuedLeaf.Leaf; leaf.LeafIndex != 7 {
+		t.Errorf("unexpected index %v of new leaf", leaf.LeafIndex)
+	}
+
+	// leaves of the shards are reported as existing, as those of the current tree are
+	for _, v := range []string{"a1", "c0"} {
+		resp := tc.addLeaf([]byte(v))
+		if resp.err != nil {
+			t.Fatal(resp.err)
+		}
+		queued := resp.getAddResult.QueuedLeaf
+		if queued.Status.GetCode() != int32(codes.AlreadyExists) || string(queued.Leaf.LeafValue) != v {
+			t.Errorf("unexpected result adding existing leaf %v: %v", v, queued)
+		}
+	}
+
+	batch := tc.addLeaves([][]byte{[]byte("b0"), []byte("c3"), []byte("a2"), []byte("c4")})
+	if batch.err != nil {
+		t.Fatal(batch.err)
+	}
+	for i, want := range []struct {
+		index  int64
+		exists bool
+	}{{3, true}, {8, false}, {2, true}, {9, false}} {
+		queued := batch.getAddLeavesResult.QueuedLeaves[i]
+		if exists := queued.Status.GetCode() == int32(codes.AlreadyExists); exists != want.exists || queued.Leaf.LeafIndex != want.index {
+			t.Errorf("unexpected result for leaf %v of batch: %v", i, queued)
+		}
+	}
+}
+
+func TestShardedLogProofs(t *testing.T) {
+	tc := newShardedTrillianClient(t)
+
+	// leaves are proven against the tree head of the shard holding them
+	hash := rfc6962.DefaultHasher.HashLeaf([]byte("b1"))
+	shard, resp := tc.getLogProofByHash(hash)
+	if resp.err != nil {
+		t.Fatal(resp.err)
+	}
+	if shard.logID != tc.shards[1].logID {
+		t.Errorf("proof of leaf of shard %v read from tree %v", tc.shards[1].logID, shard.logID)
+	}
+	root, err := shard.root()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof := resp.getProofResult.Proof[0]
+	if err := logverifier.New(rfc6962.DefaultHasher).VerifyInclusionProof(proof.LeafIndex, int64(root.TreeSize), proof.Hashes, root.RootHash, hash); err != nil {
+		t.Errorf("invalid inclusion proof: %v", err)
+	}
+	if _, resp := tc.getLogProofByHash(rfc6962.DefaultHasher.HashLeaf([]byte("missing"))); resp.status != codes.NotFound {
+		t.Errorf("unexpected status getting proof of missing leaf: %v", resp.status)
+	}
+
+	var logEntries []models.LogEntry
+	for i, v := range []string{"a0", "b1", "c1"} {
+		logEntries = append(logEntries, models.LogEntry{
+			hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf([]byte(v))): models.LogEntryAnon{LogIndex: swag.Int64([]int64{0, 4, 6}[i])},
+		})
+	}
+	if err := addInclusionProofs(tc, logEntries); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []struct{ index, size int64 }{{0, 3}, {1, 2}, {1, 2}} {
+		for _, e := range logEntries[i] {
+			if p := e.Verification.InclusionProof; *p.LogIndex != want.index || *p.TreeSize != want.size {
+				t.Errorf("unexpected inclusion proof of entry %v: %+v", i, p)
+			}
+		}
+	}
+
+	info, err := tc.inactiveShardInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info) != 2 || *info[0].TreeSize != 3 || *info[1].TreeSize != 2 || *info[1].TreeID != strconv.FormatInt(tc.shards[1].logID, 10) {
+		t.Errorf("unexpected inactive shards %+v", info)
+	}
+}
+
+func TestNewInactiveShards(t *testing.T) {
+	ctx := context.Background()
+	embedded := newEmbeddedLog(newMemoryLogStorage())
+	tree, err := createAndInitTree(ctx, embedded, embedded, "P-256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := strconv.FormatInt(tree.TreeId, 10)
+	for _, treeIDs := range [][]string{
+		{"shard"},
+		{"-1"},
+		{id, id},
+		{strconv.FormatInt(tree.TreeId+1, 10)},
+	} {
+		if _, _, err := newInactiveShards(ctx, embedded, embedded, treeIDs, 0); err == nil {
+			t.Errorf("expected error for inactive shards %v", treeIDs)
+		}
+	}
+	if _, _, err := newInactiveShards(ctx, embedded, embedded, []string{id}, tree.TreeId); err == nil {
+		t.Error("expected error for the current tree given as an inactive shard")
+	}
+}
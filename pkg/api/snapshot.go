@@ -0,0 +1,207 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/storage"
+)
+
+// indexSnapshotFormat names the layout of the files written by ExportIndex: a header line followed by one
+// storage.IndexRecord per line, all encoded as JSON
+const indexSnapshotFormat = "rekor-index-snapshot/v1"
+
+// importBatchSize is the number of list values ImportIndex writes at a time
+const importBatchSize = 1000
+
+// indexSnapshotHeader is the first line of an index snapshot
+type indexSnapshotHeader struct {
+	Format string `json:"format"`
+	// KeyScheme is the key index scheme the index was built with, if it was recorded
+	KeyScheme string `json:"keyScheme,omitempty"`
+	// TreeSizes is the size of the tree of each tenant, with the default tree under an empty name, when the
+	// export started; every entry below it had been logged before the index was read
+	TreeSizes map[string]int64 `json:"treeSizes"`
+}
+
+// IndexSnapshotStats describes the snapshot ExportIndex wrote or ImportIndex read
+type IndexSnapshotStats struct {
+	// Records is the number of records copied, each holding a structure of the index or part of a large one
+	Records int64
+	// TreeSizes is the watermark of the snapshot: the size of the tree of each tenant when it was exported, with
+	// the default tree under an empty name
+	TreeSizes map[string]int64
+}
+
+// ConfigureIndexExport connects to the trees and the search index configured for the server, without starting
+// anything that only serving requests needs
+func ConfigureIndexExport(ctx context.Context) error {
+	if viper.GetInt64("trillian_log_server.tlog_id") == 0 {
+		return errors.New("trillian_log_server.tlog_id must be set to the tree whose index is exported")
+	}
+	var err error
+	if api, err = NewAPI(); err != nil {
+		return err
+	}
+	indexBackend, err = storage.NewIndexBackend(ctx, viper.GetString("index.backend"))
+	return err
+}
+
+// ConfigureIndexImport connects to the search index configured for the server; the key index scheme is taken
+// from the imported snapshot rather than checked against the configured one
+func ConfigureIndexImport(ctx context.Context) error {
+	var err error
+	indexBackend, err = storage.NewIndexBackend(ctx, viper.GetString("index.backend"))
+	return err
+}
+
+// ExportIndex writes every structure of the search index to w, preceded by the size of each tree before the
+// index was read. Entries added while exporting may or may not be part of the snapshot, so an index imported from
+// it is brought up to date by backfilling it from the recorded tree sizes.
+func ExportIndex(ctx context.Context, w io.Writer) (IndexSnapshotStats, error) {
+	stats := IndexSnapshotStats{TreeSizes: map[string]int64{}}
+	tenantNames := []string{""}
+	for name := range api.tenants {
+		tenantNames = append(tenantNames, name)
+	}
+	for _, name := range tenantNames {
+		tenantCtx := ctx
+		if name != "" {
+			tenantCtx = withTenant(ctx, tenant{name: name})
+		}
+		tc := NewTrillianClient(tenantCtx)
+		root, err := tc.root()
+		if err != nil {
+			return stats, fmt.Errorf("reading size of tree %q: %w", name, err)
+		}
+		stats.TreeSizes[name] = int64(root.TreeSize)
+	}
+	scheme, err := indexBackend.Get(ctx, keyIndexSchemeKey)
+	if err != nil {
+		return stats, err
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(indexSnapshotHeader{Format: indexSnapshotFormat, KeyScheme: scheme, TreeSizes: stats.TreeSizes}); err != nil {
+		return stats, err
+	}
+	if err := indexBackend.Scan(ctx, func(r storage.IndexRecord) error {
+		stats.Records++
+		return enc.Encode(r)
+	}); err != nil {
+		return stats, err
+	}
+	return stats, bw.Flush()
+}
+
+// errIndexNotEmpty stops the scan checking whether an index is empty
+var errIndexNotEmpty = errors.New("the index already holds entries; import into an empty index")
+
+// ImportIndex writes the structures of a snapshot written by ExportIndex to the search index, which must not hold
+// any entries yet, as imported lists and counters are added to rather than replaced
+func ImportIndex(ctx context.Context, r io.Reader) (IndexSnapshotStats, error) {
+	var stats IndexSnapshotStats
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var header indexSnapshotHeader
+	if err := dec.Decode(&header); err != nil {
+		return stats, fmt.Errorf("reading snapshot header: %w", err)
+	}
+	if header.Format != indexSnapshotFormat {
+		return stats, fmt.Errorf("unsupported snapshot format %q", header.Format)
+	}
+	stats.TreeSizes = header.TreeSizes
+
+	// values, such as the recorded key scheme, are only ever set once and may be written by a server already
+	if err := indexBackend.Scan(ctx, func(r storage.IndexRecord) error {
+		if r.Type != storage.RecordValue {
+			return errIndexNotEmpty
+		}
+		return nil
+	}); err != nil {
+		return stats, err
+	}
+	if header.KeyScheme != "" {
+		recorded, err := indexBackend.Get(ctx, keyIndexSchemeKey)
+		if err != nil {
+			return stats, err
+		}
+		if recorded != "" && recorded != header.KeyScheme {
+			return stats, fmt.Errorf("snapshot was built with key scheme '%v' but the index records '%v'", header.KeyScheme, recorded)
+		}
+	}
+
+	for {
+		var record storage.IndexRecord
+		if err := dec.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				return stats, nil
+			}
+			return stats, fmt.Errorf("reading record %d: %w", stats.Records+1, err)
+		}
+		if err := importRecord(ctx, record); err != nil {
+			return stats, fmt.Errorf("importing %v %v: %w", record.Type, record.Key, err)
+		}
+		stats.Records++
+	}
+}
+
+// importRecord writes a single record under its key as exported, which already includes the namespace of its
+// tenant
+func importRecord(ctx context.Context, r storage.IndexRecord) error {
+	switch r.Type {
+	case storage.RecordList:
+		for start := 0; start < len(r.Values); start += importBatchSize {
+			end := start + importBatchSize
+			if end > len(r.Values) {
+				end = len(r.Values)
+			}
+			writes := make([]storage.IndexWrite, 0, end-start)
+			for _, value := range r.Values[start:end] {
+				writes = append(writes, storage.IndexWrite{Key: r.Key, Value: value})
+			}
+			if err := indexBackend.WriteBatch(ctx, writes); err != nil {
+				return err
+			}
+		}
+	case storage.RecordScores:
+		for member, score := range r.Scores {
+			if err := indexBackend.WriteScore(ctx, r.Key, member, score); err != nil {
+				return err
+			}
+		}
+	case storage.RecordCounters:
+		for field, n := range r.Scores {
+			if err := indexBackend.Increment(ctx, r.Key, field, n); err != nil {
+				return err
+			}
+		}
+	case storage.RecordValue:
+		return indexBackend.SetIfAbsent(ctx, r.Key, r.Value)
+	default:
+		return fmt.Errorf("unknown record type %q", r.Type)
+	}
+	return nil
+}
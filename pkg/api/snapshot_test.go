@@ -0,0 +1,103 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/storage"
+)
+
+func TestIndexSnapshot(t *testing.T) {
+	defer func(a *API, b storage.IndexBackend) { api, indexBackend = a, b }(api, indexBackend)
+	api = &API{
+		logClient: leavesLogClient{leaves: [][]byte{[]byte("a"), []byte("b")}},
+		tenants:   map[string]*API{"other": {logClient: leavesLogClient{leaves: [][]byte{[]byte("c")}}}},
+	}
+	ctx := context.Background()
+	source := storage.NewMemoryBackend()
+	indexBackend = source
+	_ = source.SetIfAbsent(ctx, keyIndexSchemeKey, "fingerprint")
+	_ = source.WriteBatch(ctx, []storage.IndexWrite{{Key: "k", Value: "u1"}, {Key: "k", Value: "u2"}, {Key: "other/k", Value: "u3"}})
+	_ = addToTimeIndex(ctx, 1, 1600000000)
+	_ = countKind(ctx, "rekord")
+	_ = countKind(ctx, "rekord")
+
+	var buf bytes.Buffer
+	stats, err := ExportIndex(ctx, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int64{"": 2, "other": 1}; !reflect.DeepEqual(stats.TreeSizes, want) {
+		t.Errorf("exported tree sizes %v, want %v", stats.TreeSizes, want)
+	}
+	snapshot := buf.String()
+
+	target := storage.NewMemoryBackend()
+	indexBackend = target
+	imported, err := ImportIndex(ctx, strings.NewReader(snapshot))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.Records != stats.Records || !reflect.DeepEqual(imported.TreeSizes, stats.TreeSizes) {
+		t.Errorf("imported %+v, exported %+v", imported, stats)
+	}
+	var exported, copied []storage.IndexRecord
+	_ = source.Scan(ctx, func(r storage.IndexRecord) error { exported = append(exported, r); return nil })
+	_ = target.Scan(ctx, func(r storage.IndexRecord) error { copied = append(copied, r); return nil })
+	if !reflect.DeepEqual(copied, exported) {
+		t.Errorf("imported index %+v, want %+v", copied, exported)
+	}
+
+	empty := func() storage.IndexBackend { return storage.NewMemoryBackend() }
+	tests := []struct {
+		caseDesc string
+		backend  func() storage.IndexBackend
+		snapshot string
+	}{
+		{
+			caseDesc: "index already holds entries",
+			backend: func() storage.IndexBackend {
+				b := storage.NewMemoryBackend()
+				_ = b.Write(ctx, "k", "u")
+				return b
+			},
+			snapshot: snapshot,
+		},
+		{
+			caseDesc: "index records another key scheme",
+			backend: func() storage.IndexBackend {
+				b := storage.NewMemoryBackend()
+				_ = b.SetIfAbsent(ctx, keyIndexSchemeKey, "raw")
+				return b
+			},
+			snapshot: snapshot,
+		},
+		{caseDesc: "unknown format", backend: empty, snapshot: `{"format":"other"}`},
+		{caseDesc: "unknown record type", backend: empty, snapshot: `{"format":"` + indexSnapshotFormat + `"}` + "\n" + `{"type":"other","key":"k"}`},
+	}
+	for _, tc := range tests {
+		indexBackend = tc.backend()
+		if _, err := ImportIndex(ctx, strings.NewReader(tc.snapshot)); err == nil {
+			t.Errorf("%v: expected error", tc.caseDesc)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/trillian"
+	"github.com/google/trillian/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// sqliteLogAddress is the trillian_log_server.address that makes the server keep the log in the SQLite database at
+// trillian_log_server.sqlite_path instead of in a Trillian log server
+const sqliteLogAddress = "sqlite"
+
+// sqliteDriver is the database/sql driver SQLite databases are opened with; as it requires cgo, it is only linked
+// into builds with the sqlite tag
+const sqliteDriver = "sqlite3"
+
+// sqliteLogSchema creates the tables of an SQLite log storage; trees, leaves and tree heads are stored as serialized
+// protocol buffers, along with the columns they are looked up by
+var sqliteLogSchema = []string{
+	`CREATE TABLE IF NOT EXISTS trees (
+		tree_id INTEGER PRIMARY KEY,
+		tree BLOB NOT NULL,
+		private_key BLOB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS tree_heads (
+		tree_id INTEGER NOT NULL REFERENCES trees (tree_id),
+		revision INTEGER NOT NULL,
+		signed_log_root BLOB NOT NULL,
+		PRIMARY KEY (tree_id, revision)
+	)`,
+	`CREATE TABLE IF NOT EXISTS leaves (
+		tree_id INTEGER NOT NULL REFERENCES trees (tree_id),
+		leaf_index INTEGER NOT NULL,
+		merkle_leaf_hash BLOB NOT NULL,
+		leaf BLOB NOT NULL,
+		PRIMARY KEY (tree_id, leaf_index),
+		UNIQUE (tree_id, merkle_leaf_hash)
+	)`,
+	`CREATE TABLE IF NOT EXISTS nodes (
+		tree_id INTEGER NOT NULL REFERENCES trees (tree_id),
+		level INTEGER NOT NULL,
+		node_index INTEGER NOT NULL,
+		hash BLOB NOT NULL,
+		PRIMARY KEY (tree_id, level, node_index)
+	)`,
+}
+
+// sqliteLogStorage keeps the trees of an embedded log in an SQLite database, so that a single server can run a
+// durable log without a Trillian log server and its database. The database holds the private keys the tree heads
+// are signed with.
+type sqliteLogStorage struct {
+	db *sql.DB
+}
+
+// openSQLiteLogStorage opens the SQLite database at path, creating it if it does not exist
+func openSQLiteLogStorage(ctx context.Context, path string) (*sqliteLogStorage, error) {
+	if !hasSQLDriver(sqliteDriver) {
+		return nil, errors.New("this server was built without SQLite support, which requires building it with the sqlite tag")
+	}
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		return nil, err
+	}
+	// writes are serialized by the embedded log, and a single connection keeps them from contending for locks
+	db.SetMaxOpenConns(1)
+	for _, stmt := range append([]string{"PRAGMA journal_mode=WAL", "PRAGMA foreign_keys=ON"}, sqliteLogSchema...) {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("initializing SQLite log storage %v: %w", path, err)
+		}
+	}
+	return &sqliteLogStorage{db: db}, nil
+}
+
+func hasSQLDriver(name string) bool {
+	for _, d := range sql.Drivers() {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sqliteLogStorage) CreateTree(ctx context.Context, tree *trillian.Tree, key []byte) error {
+	serialized, err := proto.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "INSERT INTO trees (tree_id, tree, private_key) VALUES (?, ?, ?)", tree.TreeId, serialized, key)
+	return err
+}
+
+func (s *sqliteLogStorage) UpdateTree(ctx context.Context, tree *trillian.Tree) error {
+	serialized, err := proto.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(ctx, "UPDATE trees SET tree = ? WHERE tree_id = ?", serialized, tree.TreeId)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return status.Errorf(codes.NotFound, "tree %v not found", tree.TreeId)
+	}
+	return nil
+}
+
+func (s *sqliteLogStorage) Trees(ctx context.Context) ([]*trillian.Tree, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT tree FROM trees ORDER BY tree_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var trees []*trillian.Tree
+	for rows.Next() {
+		var serialized []byte
+		if err := rows.Scan(&serialized); err != nil {
+			return nil, err
+		}
+		tree := &trillian.Tree{}
+		if err := proto.Unmarshal(serialized, tree); err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+	}
+	return trees, rows.Err()
+}
+
+func (s *sqliteLogStorage) Tree(ctx context.Context, id int64) (*trillian.Tree, []byte, error) {
+	var serialized, key []byte
+	err := s.db.QueryRowContext(ctx, "SELECT tree, private_key FROM trees WHERE tree_id = ?", id).Scan(&serialized, &key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, status.Errorf(codes.NotFound, "tree %v not found", id)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	tree := &trillian.Tree{}
+	if err := proto.Unmarshal(serialized, tree); err != nil {
+		return nil, nil, err
+	}
+	return tree, key, nil
+}
+
+func (s *sqliteLogStorage) Root(ctx context.Context, id int64) (*trillian.SignedLogRoot, error) {
+	var serialized []byte
+	err := s.db.QueryRowContext(ctx, "SELECT signed_log_root FROM tree_heads WHERE tree_id = ? ORDER BY revision DESC LIMIT 1", id).Scan(&serialized)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the tree is not initialised, if it exists
+		_, _, err := s.Tree(ctx, id)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	root := &trillian.SignedLogRoot{}
+	if err := proto.Unmarshal(serialized, root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func (s *sqliteLogStorage) LeafIndex(ctx context.Context, id int64, hash []byte) (int64, error) {
+	var index int64
+	err := s.db.QueryRowContext(ctx, "SELECT leaf_index FROM leaves WHERE tree_id = ? AND merkle_leaf_hash = ?", id, hash).Scan(&index)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, nil
+	}
+	return index, err
+}
+
+func (s *sqliteLogStorage) Leaves(ctx context.Context, id, start, count int64) ([]*trillian.LogLeaf, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT leaf FROM leaves WHERE tree_id = ? AND leaf_index >= ? ORDER BY leaf_index LIMIT ?", id, start, count)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var leaves []*trillian.LogLeaf
+	for rows.Next() {
+		var serialized []byte
+		if err := rows.Scan(&serialized); err != nil {
+			return nil, err
+		}
+		leaf := &trillian.LogLeaf{}
+		if err := proto.Unmarshal(serialized, leaf); err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leaf)
+	}
+	return leaves, rows.Err()
+}
+
+func (s *sqliteLogStorage) Node(ctx context.Context, id int64, level uint, index int64) ([]byte, error) {
+	var hash []byte
+	err := s.db.QueryRowContext(ctx, "SELECT hash FROM nodes WHERE tree_id = ? AND level = ? AND node_index = ?", id, level, index).Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "missing node %v at level %v of tree %v", index, level, id)
+	}
+	return hash, err
+}
+
+func (s *sqliteLogStorage) Append(ctx context.Context, id int64, leaves []*trillian.LogLeaf, nodes []treeNode, root *trillian.SignedLogRoot) error {
+	var head types.LogRootV1
+	if err := head.UnmarshalBinary(root.LogRoot); err != nil {
+		return err
+	}
+	serializedRoot, err := proto.Marshal(root)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint: errcheck
+	for _, leaf := range leaves {
+		serialized, err := proto.Marshal(leaf)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO leaves (tree_id, leaf_index, merkle_leaf_hash, leaf) VALUES (?, ?, ?, ?)",
+			id, leaf.LeafIndex, leaf.MerkleLeafHash, serialized); err != nil {
+			return err
+		}
+	}
+	for _, n := range nodes {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO nodes (tree_id, level, node_index, hash) VALUES (?, ?, ?, ?)",
+			id, n.level, n.index, n.hash); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO tree_heads (tree_id, revision, signed_log_root) VALUES (?, ?, ?)",
+		id, int64(head.Revision), serializedRoot); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteLogStorage) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,59 @@
+// +build sqlite
+
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestSQLiteLog(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "log.db")
+	storage, err := openSQLiteLogStorage(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tc := newEmbeddedTrillianClient(t, storage)
+	testEmbeddedLog(t, tc)
+	if err := storage.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the tree, its key and its leaves outlive the server
+	if storage, err = openSQLiteLogStorage(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+	defer storage.Close()
+	reopened := newEmbeddedTrillianClient(t, storage)
+	if reopened.logID != tc.logID || string(reopened.pubkey.Der) != string(tc.pubkey.Der) {
+		t.Fatalf("unexpected tree %v after reopening tree %v", reopened.logID, tc.logID)
+	}
+	resp := reopened.addLeaf([]byte("leaf 11"))
+	if resp.err != nil {
+		t.Fatalf("unexpected error adding leaf after reopening: %v", resp.err)
+	}
+	if index := resp.getAddResult.QueuedLeaf.Leaf.LeafIndex; index != 11 {
+		t.Errorf("unexpected index %v of leaf added after reopening", index)
+	}
+	if resp := reopened.getConsistencyProof(5, 12); resp.err != nil || resp.getConsistencyProofResult.Proof == nil {
+		t.Errorf("unexpected result getting consistency proof after reopening: %v", resp.err)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+	"github.com/google/trillian"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/tlog"
+)
+
+// kindCountsKey is the redis hash counting the entries of each kind added to the log; prefixed so it can never
+// be returned by a hash search
+const kindCountsKey = "stats/kinds"
+
+// latencyWindowSize is the number of recent entries integration latency percentiles are computed over
+const latencyWindowSize = 1000
+
+// latencyWindow keeps the integration latencies of the most recent entries added through this instance
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	size    int
+}
+
+var integrationLatency = &latencyWindow{size: latencyWindowSize}
+
+// record adds the latency of an entry, replacing the oldest one once the window is full
+func (w *latencyWindow) record(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.samples) < w.size {
+		w.samples = append(w.samples, d)
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % w.size
+}
+
+// percentiles returns the number of latencies in the window and the nearest-rank value of each percentile in ps
+func (w *latencyWindow) percentiles(ps ...int) (int, []time.Duration) {
+	w.mu.Lock()
+	sorted := append([]time.Duration(nil), w.samples...)
+	w.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	result := make([]time.Duration, len(ps))
+	if len(sorted) == 0 {
+		return 0, result
+	}
+	for i, p := range ps {
+		rank := (p*len(sorted) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		result[i] = sorted[rank-1]
+	}
+	return len(sorted), result
+}
+
+// recordIntegration records how long leaf waited between being queued and integrated into the log
+func recordIntegration(leaf *trillian.LogLeaf) {
+	if leaf.QueueTimestamp == nil || leaf.IntegrateTimestamp == nil {
+		return
+	}
+	if d := leaf.IntegrateTimestamp.AsTime().Sub(leaf.QueueTimestamp.AsTime()); d >= 0 {
+		integrationLatency.record(d)
+	}
+}
+
+func countKind(ctx context.Context, kind string) error {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	return indexBackend.Increment(ctx, indexKey(ctx, kindCountsKey), kind, 1)
+}
+
+func kindCounts(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	return indexBackend.Counters(ctx, indexKey(ctx, kindCountsKey))
+}
+
+// latencyStats summarizes the integration latencies in w, or returns nil if no entry has been added yet
+func latencyStats(w *latencyWindow) *models.LogStatsIntegrationLatency {
+	n, ps := w.percentiles(50, 90, 99)
+	if n == 0 {
+		return nil
+	}
+	return &models.LogStatsIntegrationLatency{
+		Samples: swag.Int64(int64(n)),
+		P50:     swag.Int64(ps[0].Milliseconds()),
+		P90:     swag.Int64(ps[1].Milliseconds()),
+		P99:     swag.Int64(ps[2].Milliseconds()),
+	}
+}
+
+func GetLogStatsHandler(params tlog.GetLogStatsParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+	tc := NewTrillianClient(ctx)
+
+	root, err := tc.root()
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianCommunicationError)
+	}
+	stats := models.LogStats{
+		TreeSize:           swag.Int64(int64(root.TreeSize)),
+		IntegrationLatency: latencyStats(integrationLatency),
+	}
+	if viper.GetBool("enable_retrieve_api") {
+		if stats.EntriesByKind, err = kindCounts(ctx); err != nil {
+			return handleRekorAPIError(params, http.StatusInternalServerError, err, redisUnexpectedResult)
+		}
+	}
+	return tlog.NewGetLogStatsOK().WithPayload(&stats)
+}
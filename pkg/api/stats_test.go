@@ -0,0 +1,46 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyWindow(t *testing.T) {
+	w := &latencyWindow{size: 100}
+	if stats := latencyStats(w); stats != nil {
+		t.Errorf("empty window returned %+v", stats)
+	}
+
+	for i := 1; i <= 100; i++ {
+		w.record(time.Duration(i) * time.Millisecond)
+	}
+	stats := latencyStats(w)
+	if *stats.Samples != 100 || *stats.P50 != 50 || *stats.P90 != 90 || *stats.P99 != 99 {
+		t.Errorf("unexpected percentiles %v %v %v of %v samples", *stats.P50, *stats.P90, *stats.P99, *stats.Samples)
+	}
+
+	// once the window is full the oldest latencies are replaced
+	for i := 0; i < 50; i++ {
+		w.record(time.Second)
+	}
+	n, ps := w.percentiles(0, 50, 100)
+	if n != 100 || ps[0] != 51*time.Millisecond || ps[1] != 100*time.Millisecond || ps[2] != time.Second {
+		t.Errorf("unexpected percentiles %v of %v samples", ps, n)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/google/trillian"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/codes"
+
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+const (
+	// maxStreamBatch is the number of leaves read from the log at once while a stream catches up
+	maxStreamBatch = 100
+	// streamKeepAlive is how long a stream may stay idle before a comment is sent, so that proxies
+	// do not close the connection
+	streamKeepAlive = 15 * time.Second
+)
+
+// streamClients counts the streams currently open against stream.max_clients
+var streamClients int64
+
+// logLeaves reads the leaves of the log as it grows
+type logLeaves interface {
+	size(ctx context.Context) (int64, error)
+	// leaves returns up to count leaves starting at index start, in index order
+	leaves(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error)
+}
+
+type trillianLogLeaves struct{}
+
+func (trillianLogLeaves) size(ctx context.Context) (int64, error) {
+	tc := NewTrillianClient(ctx)
+	root, err := tc.root()
+	if err != nil {
+		return 0, err
+	}
+	return tc.offset + int64(root.TreeSize), nil
+}
+
+func (trillianLogLeaves) leaves(ctx context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	tc := NewTrillianClient(ctx)
+	resp := tc.getLogLeavesByRange(start, count)
+	if resp.status != codes.OK {
+		return nil, resp.err
+	}
+	return resp.getLeafByRangeResult.GetLeaves(), nil
+}
+
+// streamEvent is the data of the event sent for each entry integrated into the log
+type streamEvent struct {
+	UUID           string `json:"uuid"`
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	Kind           string `json:"kind,omitempty"`
+	APIVersion     string `json:"apiVersion,omitempty"`
+}
+
+// writeStreamEvent writes the event for leaf, using its log index as the event id so that clients can
+// resume the stream from it
+func writeStreamEvent(w io.Writer, leaf *trillian.LogLeaf) error {
+	event := streamEvent{
+		UUID:           hex.EncodeToString(leaf.MerkleLeafHash),
+		LogIndex:       leaf.LeafIndex,
+		IntegratedTime: leaf.IntegrateTimestamp.AsTime().Unix(),
+	}
+	// the kind and version are taken from the canonicalized entry; they are left out for leaves that
+	// cannot be decoded rather than ending the stream
+	var body struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := json.Unmarshal(leaf.LeafValue, &body); err == nil {
+		event.Kind, event.APIVersion = body.Kind, body.APIVersion
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: entry\ndata: %s\n\n", leaf.LeafIndex, data)
+	return err
+}
+
+// streamEntries writes an event for each leaf of the log from index next onwards, polling the log for new
+// leaves every pollInterval, until ctx is done or reading the log fails
+func streamEntries(ctx context.Context, w io.Writer, flush func(), leaves logLeaves, next int64, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	lastWrite := time.Now()
+	for {
+		size, err := leaves.size(ctx)
+		if err != nil {
+			return err
+		}
+		for next < size {
+			count := size - next
+			if count > maxStreamBatch {
+				count = maxStreamBatch
+			}
+			batch, err := leaves.leaves(ctx, next, count)
+			if err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return fmt.Errorf("no leaves returned from index %v of tree of size %v", next, size)
+			}
+			for _, leaf := range batch {
+				if leaf.LeafIndex != next {
+					return fmt.Errorf("leaf %v has index %v", next, leaf.LeafIndex)
+				}
+				if err := writeStreamEvent(w, leaf); err != nil {
+					return err
+				}
+				next++
+			}
+			flush()
+			lastWrite = time.Now()
+		}
+		if time.Since(lastWrite) >= streamKeepAlive {
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return err
+			}
+			flush()
+			lastWrite = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// StreamLogEntriesHandler streams an event for each entry as it is integrated into the log. Streams end when
+// the write timeout of the server expires or reading the log fails; clients resume them by sending the id of
+// the last event received as Last-Event-ID, which EventSource implementations do when they reconnect.
+func StreamLogEntriesHandler(params entries.StreamLogEntriesParams) middleware.Responder {
+	ctx := params.HTTPRequest.Context()
+
+	open := atomic.AddInt64(&streamClients, 1)
+	if maxClients := viper.GetInt64("stream.max_clients"); maxClients > 0 && open > maxClients {
+		atomic.AddInt64(&streamClients, -1)
+		return handleRekorAPIError(params, http.StatusServiceUnavailable, fmt.Errorf("%v streams open", open-1), tooManyStreams)
+	}
+
+	var next int64
+	switch {
+	case params.LastEventID != nil:
+		next = *params.LastEventID + 1
+	case params.Start != nil:
+		next = *params.Start
+	default:
+		size, err := trillianLogLeaves{}.size(ctx)
+		if err != nil {
+			atomic.AddInt64(&streamClients, -1)
+			return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", err), trillianCommunicationError)
+		}
+		next = size
+	}
+
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		defer atomic.AddInt64(&streamClients, -1)
+
+		flusher, ok := rw.(http.Flusher)
+		if !ok {
+			log.ContextLogger(ctx).Error("response writer does not support streaming")
+			rw.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set(runtime.HeaderContentType, "text/event-stream")
+		rw.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		if err := streamEntries(ctx, rw, flusher.Flush, trillianLogLeaves{}, next, viper.GetDuration("stream.poll_interval")); err != nil && ctx.Err() == nil {
+			log.ContextLogger(ctx).Errorf("ending stream of log entries: %v", err)
+		}
+	})
+}
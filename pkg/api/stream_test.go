@@ -0,0 +1,112 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/trillian"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testLeaf(index int64) *trillian.LogLeaf {
+	return &trillian.LogLeaf{
+		LeafIndex:          index,
+		MerkleLeafHash:     []byte{byte(index)},
+		LeafValue:          []byte(`{"apiVersion":"0.0.1","kind":"rekord","spec":{}}`),
+		IntegrateTimestamp: timestamppb.New(time.Unix(1600000000+index, 0)),
+	}
+}
+
+// fakeLogLeaves grows to each of sizes in turn as the stream polls it, then cancels the stream
+type fakeLogLeaves struct {
+	sizes  []int64
+	cancel context.CancelFunc
+}
+
+func (f *fakeLogLeaves) size(_ context.Context) (int64, error) {
+	size := f.sizes[0]
+	if len(f.sizes) > 1 {
+		f.sizes = f.sizes[1:]
+	} else {
+		f.cancel()
+	}
+	return size, nil
+}
+
+func (f *fakeLogLeaves) leaves(_ context.Context, start, count int64) ([]*trillian.LogLeaf, error) {
+	if count > maxStreamBatch {
+		return nil, fmt.Errorf("requested %v leaves", count)
+	}
+	result := []*trillian.LogLeaf{}
+	for i := start; i < start+count; i++ {
+		result = append(result, testLeaf(i))
+	}
+	return result, nil
+}
+
+func TestWriteStreamEvent(t *testing.T) {
+	var b bytes.Buffer
+	if err := writeStreamEvent(&b, testLeaf(10)); err != nil {
+		t.Fatal(err)
+	}
+	want := "id: 10\nevent: entry\ndata: {\"uuid\":\"0a\",\"logIndex\":10,\"integratedTime\":1600000010,\"kind\":\"rekord\",\"apiVersion\":\"0.0.1\"}\n\n"
+	if b.String() != want {
+		t.Errorf("unexpected event %q", b.String())
+	}
+
+	b.Reset()
+	leaf := testLeaf(1)
+	leaf.LeafValue = []byte("not JSON")
+	if err := writeStreamEvent(&b, leaf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(b.String(), "kind") {
+		t.Errorf("unexpected event %q", b.String())
+	}
+}
+
+func TestStreamEntries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leaves := &fakeLogLeaves{sizes: []int64{3, 3, 250}, cancel: cancel}
+
+	var b bytes.Buffer
+	flushes := 0
+	if err := streamEntries(ctx, &b, func() { flushes++ }, leaves, 2, time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{}
+	for _, line := range strings.Split(b.String(), "\n") {
+		if strings.HasPrefix(line, "id: ") {
+			ids = append(ids, strings.TrimPrefix(line, "id: "))
+		}
+	}
+	if len(ids) != 248 || ids[0] != "2" || ids[247] != "249" {
+		t.Errorf("unexpected event ids %v", ids)
+	}
+	// entries 2, 3-102, 103-202 and 203-249
+	if flushes != 4 {
+		t.Errorf("stream flushed %v times", flushes)
+	}
+}
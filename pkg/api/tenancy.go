@@ -0,0 +1,191 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+// TenantHeader is the header requests name the tenant they are made for with, as an alternative to
+// prefixing their path with /tenants/<tenant>
+const TenantHeader = "X-Rekor-Tenant"
+
+const tenantPathPrefix = "/tenants/"
+
+// tenant names appear in paths and index keys, so they are limited to lowercase letters, digits and dashes
+var tenantNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+type tenantKey struct{}
+
+// tenant is the tenant a request was made for, and the path prefix it was named with if any
+type tenant struct {
+	name       string
+	pathPrefix string
+}
+
+func withTenant(ctx context.Context, t tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// tenantFrom returns the name of the tenant of ctx, or an empty string for requests served by the default tree
+func tenantFrom(ctx context.Context) string {
+	t, _ := ctx.Value(tenantKey{}).(tenant)
+	return t.name
+}
+
+// servesTenants reports whether this instance serves tenants alongside the default tree
+func servesTenants() bool {
+	return api != nil && len(api.tenants) > 0
+}
+
+// parseTenants parses the trees of each tenant given as name=treeID
+func parseTenants(specs []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(specs))
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("tenant %q must be given as name=treeID", spec)
+		}
+		name := parts[0]
+		if !tenantNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("tenant name %q must only contain lowercase letters, digits and dashes", name)
+		}
+		if _, ok := result[name]; ok {
+			return nil, fmt.Errorf("tenant %v is given more than once", name)
+		}
+		treeID, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || treeID <= 0 {
+			return nil, fmt.Errorf("tree ID %q of tenant %v must be a positive integer", parts[1], name)
+		}
+		result[name] = treeID
+	}
+	return result, nil
+}
+
+// parseTenantWriters parses the identities allowed to add entries to each of tenants given as name=identity
+func parseTenantWriters(specs []string, tenants map[string]int64) (map[string]map[string]bool, error) {
+	result := map[string]map[string]bool{}
+	for _, spec := range specs {
+		parts := strings.SplitN(spec, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("tenant writer %q must be given as name=identity", spec)
+		}
+		name, identity := parts[0], parts[1]
+		if _, ok := tenants[name]; !ok {
+			return nil, fmt.Errorf("writer %v is given for tenant %v, which is not served", identity, name)
+		}
+		if result[name] == nil {
+			result[name] = map[string]bool{}
+		}
+		result[name][identity] = true
+	}
+	return result, nil
+}
+
+// authorizeTenantWrite rejects requests to add entries to a tenant unless the client authenticated as one of the
+// writers of the tenant, so that tenants never add entries to each other's logs; without authentication there
+// is no identity to check, and anyone may add entries to any tenant
+func authorizeTenantWrite(ctx context.Context) *apiError {
+	name := tenantFrom(ctx)
+	if name == "" || writeAuth == nil {
+		return nil
+	}
+	identity := identityFrom(ctx)
+	if api.tenantWriters[name][identity] {
+		return nil
+	}
+	return &apiError{http.StatusForbidden, fmt.Errorf("%v is not a writer of tenant %v", identity, name), fmt.Sprintf(tenantWriteForbidden, name)}
+}
+
+// indexKey returns key within the search index namespace of the tenant of ctx, so that tenants never see
+// each other's entries in search results or statistics
+func indexKey(ctx context.Context, key string) string {
+	if name := tenantFrom(ctx); name != "" {
+		return "tenant/" + name + "/" + key
+	}
+	return key
+}
+
+// requestURL returns the URL of r as the client requested it, including the tenant prefix removed from its path
+func requestURL(r *http.Request) url.URL {
+	u := *r.URL
+	if t, _ := r.Context().Value(tenantKey{}).(tenant); t.pathPrefix != "" {
+		u.Path = t.pathPrefix + u.Path
+		u.RawPath = ""
+	}
+	return u
+}
+
+// ResolveTenants serves requests whose path starts with /tenants/<tenant>, or that carry the X-Rekor-Tenant
+// header, from the tree of that tenant; other requests are served from the default tree
+func ResolveTenants(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var t tenant
+		if strings.HasPrefix(r.URL.Path, tenantPathPrefix) {
+			rest := strings.TrimPrefix(r.URL.Path, tenantPathPrefix)
+			name := rest
+			if i := strings.Index(rest, "/"); i >= 0 {
+				name = rest[:i]
+			}
+			t = tenant{name: name, pathPrefix: tenantPathPrefix + name}
+		}
+		if header := r.Header.Get(TenantHeader); header != "" {
+			if t.name != "" && t.name != header {
+				writeTenantError(w, r, http.StatusBadRequest, fmt.Sprintf("The %v header names tenant %v but the path names tenant %v", TenantHeader, header, t.name))
+				return
+			}
+			t.name = header
+		}
+		if t.name == "" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if _, ok := api.tenants[t.name]; !ok {
+			writeTenantError(w, r, http.StatusNotFound, fmt.Sprintf("No tenant named %q is served by this instance", t.name))
+			return
+		}
+
+		if t.pathPrefix != "" {
+			// shallow copies share the URL, which the caller may still use
+			r2 := new(http.Request)
+			*r2 = *r
+			u := *r.URL
+			u.Path = strings.TrimPrefix(u.Path, t.pathPrefix)
+			u.RawPath = ""
+			r2.URL = &u
+			r = r2
+		}
+		log.RequestIDLogger(r).Debugw("serving request for tenant", "tenant", t.name)
+		handler.ServeHTTP(w, r.WithContext(withTenant(r.Context(), t)))
+	})
+}
+
+func writeTenantError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	log.RequestIDLogger(r).Infow("rejecting request for tenant", "path", r.URL.Path, "statusCode", code, "clientMessage", message)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(requestErrorMsg(r, message, code))
+}
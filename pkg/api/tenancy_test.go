@@ -0,0 +1,143 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTenants(t *testing.T) {
+	tenants, err := parseTenants([]string{"org-a=1", "org-b=2"})
+	if err != nil || len(tenants) != 2 || tenants["org-a"] != 1 || tenants["org-b"] != 2 {
+		t.Errorf("unexpected tenants %v with error %v", tenants, err)
+	}
+	for _, specs := range [][]string{{"org-a"}, {"Org=1"}, {"org/a=1"}, {"org-a=x"}, {"org-a=0"}, {"org-a=1", "org-a=2"}} {
+		if _, err := parseTenants(specs); err == nil {
+			t.Errorf("expected error parsing tenants %v", specs)
+		}
+	}
+}
+
+func TestParseTenantWriters(t *testing.T) {
+	tenants := map[string]int64{"org-a": 1, "org-b": 2}
+	writers, err := parseTenantWriters([]string{"org-a=ci@org-a.example.com", "org-a=release@org-a.example.com", "org-b=ci@org-b.example.com"}, tenants)
+	if err != nil || len(writers["org-a"]) != 2 || !writers["org-b"]["ci@org-b.example.com"] {
+		t.Errorf("unexpected writers %v with error %v", writers, err)
+	}
+	for _, specs := range [][]string{{"org-a"}, {"org-a="}, {"org-c=ci@org-c.example.com"}} {
+		if _, err := parseTenantWriters(specs, tenants); err == nil {
+			t.Errorf("expected error parsing tenant writers %v", specs)
+		}
+	}
+}
+
+func TestAuthorizeTenantWrite(t *testing.T) {
+	defer func(a *API, w *writeAuthenticator) { api, writeAuth = a, w }(api, writeAuth)
+	api = &API{tenants: map[string]*API{"org-a": {}, "org-b": {}}, tenantWriters: map[string]map[string]bool{"org-a": {"ci@org-a.example.com": true}}}
+
+	tests := []struct {
+		caseDesc      string
+		authenticated bool
+		tenant        string
+		identity      string
+		expectSuccess bool
+	}{
+		{caseDesc: "writer of the tenant", authenticated: true, tenant: "org-a", identity: "ci@org-a.example.com", expectSuccess: true},
+		{caseDesc: "writer of another tenant", authenticated: true, tenant: "org-a", identity: "ci@org-b.example.com"},
+		{caseDesc: "tenant without writers", authenticated: true, tenant: "org-b", identity: "ci@org-a.example.com"},
+		{caseDesc: "default tree", authenticated: true, identity: "ci@org-b.example.com", expectSuccess: true},
+		{caseDesc: "writes not authenticated", tenant: "org-b", expectSuccess: true},
+	}
+	for _, tc := range tests {
+		writeAuth = nil
+		if tc.authenticated {
+			writeAuth = &writeAuthenticator{}
+		}
+		ctx := withIdentity(context.Background(), tc.identity)
+		if tc.tenant != "" {
+			ctx = withTenant(ctx, tenant{name: tc.tenant})
+		}
+		apiErr := authorizeTenantWrite(ctx)
+		if (apiErr == nil) != tc.expectSuccess {
+			t.Errorf("%v: unexpected error %v", tc.caseDesc, apiErr)
+		}
+		if apiErr != nil && apiErr.code != http.StatusForbidden {
+			t.Errorf("%v: unexpected status %v", tc.caseDesc, apiErr.code)
+		}
+	}
+}
+
+func TestResolveTenants(t *testing.T) {
+	defer func(a *API) { api = a }(api)
+	api = &API{logID: 1, tenants: map[string]*API{"org-a": {logID: 2}}}
+
+	var path, location, tenant, key string
+	var logID int64
+	handler := ResolveTenants(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := requestURL(r)
+		path, location, tenant, key = r.URL.Path, u.Path, tenantFrom(r.Context()), indexKey(r.Context(), "sha256")
+		logID = NewTrillianClient(r.Context()).logID
+	}))
+
+	tests := []struct {
+		caseDesc string
+		path     string
+		header   string
+		code     int
+		expected string
+		tenant   string
+		key      string
+	}{
+		{caseDesc: "default tree", path: "/api/v1/log", code: http.StatusOK, expected: "/api/v1/log", key: "sha256"},
+		{caseDesc: "path prefix", path: "/tenants/org-a/api/v1/log", code: http.StatusOK, expected: "/api/v1/log", tenant: "org-a", key: "tenant/org-a/sha256"},
+		{caseDesc: "header", path: "/api/v1/log", header: "org-a", code: http.StatusOK, expected: "/api/v1/log", tenant: "org-a", key: "tenant/org-a/sha256"},
+		{caseDesc: "same tenant in both", path: "/tenants/org-a/api/v1/log", header: "org-a", code: http.StatusOK, expected: "/api/v1/log", tenant: "org-a", key: "tenant/org-a/sha256"},
+		{caseDesc: "different tenants", path: "/tenants/org-a/api/v1/log", header: "org-b", code: http.StatusBadRequest},
+		{caseDesc: "unknown tenant", path: "/tenants/org-b/api/v1/log", code: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		path, location, tenant, key = "", "", "", ""
+		r := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.header != "" {
+			r.Header.Set(TenantHeader, tc.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tc.code {
+			t.Errorf("%v: got %v, expected %v", tc.caseDesc, w.Code, tc.code)
+			continue
+		}
+		if tc.code != http.StatusOK {
+			continue
+		}
+		if path != tc.expected || tenant != tc.tenant || key != tc.key {
+			t.Errorf("%v: served %v for tenant %q with index key %q", tc.caseDesc, path, tenant, key)
+		}
+		if (tc.tenant == "") != (logID == 1) {
+			t.Errorf("%v: served from tree %v", tc.caseDesc, logID)
+		}
+		if location != tc.path {
+			t.Errorf("%v: request URL %v, expected %v", tc.caseDesc, location, tc.path)
+		}
+		if r.URL.Path != tc.path {
+			t.Errorf("%v: the original request was changed to %v", tc.caseDesc, r.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations/entries"
+)
+
+// integratedTimeKey is the sorted set holding the log index of each entry, scored by the time in seconds
+// since the Unix epoch that it was integrated at; prefixed so it can never be returned by a hash search
+const integratedTimeKey = "time/integrated"
+
+// defaultTimeRangeLimit is the number of log indexes returned by a time range query that does not set a limit
+const defaultTimeRangeLimit = 100
+
+// timeIndex reads the log indexes of entries by the time they were integrated at
+type timeIndex interface {
+	// rangeByTime returns up to limit log indexes of entries integrated between start and end inclusive,
+	// ordered by integrated time, after skipping the first offset of them
+	rangeByTime(ctx context.Context, start, end, offset, limit int64) ([]string, error)
+}
+
+// backendTimeIndex reads the sorted set of the current tenant from the configured index backend
+type backendTimeIndex struct{}
+
+func (backendTimeIndex) rangeByTime(ctx context.Context, start, end, offset, limit int64) ([]string, error) {
+	return indexBackend.RangeByScore(ctx, indexKey(ctx, integratedTimeKey), start, end, offset, limit)
+}
+
+// addToTimeIndex records logIndex under integratedTime, in seconds since the Unix epoch
+func addToTimeIndex(ctx context.Context, logIndex, integratedTime int64) error {
+	ctx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	return indexBackend.WriteScore(ctx, indexKey(ctx, integratedTimeKey), strconv.FormatInt(logIndex, 10), integratedTime)
+}
+
+// entryIndexesByTime returns a page of the log indexes of entries integrated between start and end inclusive
+func entryIndexesByTime(ctx context.Context, idx timeIndex, start, end, offset, limit int64) ([]int64, *apiError) {
+	if end < start {
+		return nil, &apiError{http.StatusBadRequest, fmt.Errorf("end %v is before start %v", end, start), fmt.Sprintf(endBeforeStart, end, start)}
+	}
+	indexCtx, cancel := withTimeout(ctx, indexTimeout)
+	defer cancel()
+	members, err := idx.rangeByTime(indexCtx, start, end, offset, limit)
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, err, redisUnexpectedResult}
+	}
+	result := make([]int64, 0, len(members))
+	for _, member := range members {
+		logIndex, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("time index member %q: %w", member, err), redisUnexpectedResult}
+		}
+		result = append(result, logIndex)
+	}
+	return result, nil
+}
+
+// GetLogEntryIndexesByTimeHandler lists the log indexes of entries integrated within a time range, so that the
+// entries logged during a window can be found without reading the whole log
+func GetLogEntryIndexesByTimeHandler(params entries.GetLogEntryIndexesByTimeParams) middleware.Responder {
+	limit := int64(defaultTimeRangeLimit)
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	result, apiErr := entryIndexesByTime(params.HTTPRequest.Context(), backendTimeIndex{}, params.Start, params.End, swag.Int64Value(params.Offset), limit)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	return entries.NewGetLogEntryIndexesByTimeOK().WithPayload(result)
+}
+
+func GetLogEntryIndexesByTimeNotImplementedHandler(params entries.GetLogEntryIndexesByTimeParams) middleware.Responder {
+	err := models.Error{
+		Code:    http.StatusNotImplemented,
+		Message: "Time index API not enabled in this Rekor instance",
+	}
+
+	return entries.NewGetLogEntryIndexesByTimeDefault(http.StatusNotImplemented).WithPayload(&err)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// fakeTimeIndex holds the integrated time of each log index, which it assigns in integrated time order
+type fakeTimeIndex []int64
+
+func (f fakeTimeIndex) rangeByTime(_ context.Context, start, end, offset, limit int64) ([]string, error) {
+	result := []string{}
+	for logIndex, integratedTime := range f {
+		if integratedTime < start || integratedTime > end {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		if int64(len(result)) == limit {
+			break
+		}
+		result = append(result, strconv.Itoa(logIndex))
+	}
+	return result, nil
+}
+
+func TestEntryIndexesByTime(t *testing.T) {
+	idx := fakeTimeIndex{100, 100, 105, 110, 110, 110, 120}
+
+	tests := []struct {
+		caseDesc                  string
+		start, end, offset, limit int64
+		want                      []int64
+	}{
+		{caseDesc: "whole window", start: 100, end: 110, limit: 10, want: []int64{0, 1, 2, 3, 4, 5}},
+		{caseDesc: "single second", start: 110, end: 110, limit: 10, want: []int64{3, 4, 5}},
+		{caseDesc: "first page", start: 100, end: 110, limit: 4, want: []int64{0, 1, 2, 3}},
+		{caseDesc: "second page", start: 100, end: 110, offset: 4, limit: 4, want: []int64{4, 5}},
+		{caseDesc: "empty window", start: 111, end: 119, limit: 10, want: []int64{}},
+	}
+	for _, tc := range tests {
+		got, apiErr := entryIndexesByTime(context.Background(), idx, tc.start, tc.end, tc.offset, tc.limit)
+		if apiErr != nil {
+			t.Errorf("%v: unexpected error %v", tc.caseDesc, apiErr.err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %v, expected %v", tc.caseDesc, got, tc.want)
+		}
+	}
+
+	if _, apiErr := entryIndexesByTime(context.Background(), idx, 110, 100, 0, 10); apiErr == nil || apiErr.code != http.StatusBadRequest {
+		t.Errorf("end before start returned %v", apiErr)
+	}
+}
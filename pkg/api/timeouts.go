@@ -0,0 +1,56 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// fetchTimeout bounds fetching and verifying the external entities of a proposed entry
+	fetchTimeout = "timeouts.entity_fetch"
+	// trillianTimeout bounds a single RPC to the Trillian log server
+	trillianTimeout = "timeouts.trillian_rpc"
+	// indexTimeout bounds a single read or write of the search index
+	indexTimeout = "timeouts.index"
+)
+
+// maxTimeout guards against configuration mistakes such as a missing unit suffix
+const maxTimeout = time.Hour
+
+func validateTimeouts() error {
+	for _, key := range []string{fetchTimeout, trillianTimeout, indexTimeout} {
+		d := viper.GetDuration(key)
+		if d <= 0 || d > maxTimeout {
+			return fmt.Errorf("%v must be greater than 0 and at most %v, got %v", key, maxTimeout, d)
+		}
+	}
+	return nil
+}
+
+// withTimeout derives a context from ctx that expires after the duration configured under key;
+// if no duration is configured, the deadline of ctx (if any) is kept
+func withTimeout(ctx context.Context, key string) (context.Context, context.CancelFunc) {
+	if d := viper.GetDuration(key); d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
@@ -16,19 +16,22 @@ limitations under the License.
 package api
 
 import (
-	"bytes"
+	"context"
 	"crypto"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
-	"encoding/pem"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki"
 	"google.golang.org/grpc/codes"
 
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	tclient "github.com/google/trillian/client"
 	tcrypto "github.com/google/trillian/crypto"
 	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
@@ -36,23 +39,33 @@ import (
 )
 
 func GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+	logInfo, apiErr := currentLogInfo(params.HTTPRequest.Context())
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
+	}
+	return tlog.NewGetLogInfoOK().WithPayload(logInfo)
+}
+
+// currentLogInfo returns the current size and root hash of the log along with the signed tree head they were
+// read from, and the tree heads its inactive shards were frozen at
+func currentLogInfo(ctx context.Context) (*models.LogInfo, *apiError) {
+	tc := NewTrillianClient(ctx)
 
 	resp := tc.getLatest(0)
 	if resp.status != codes.OK {
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError}
 	}
 	result := resp.getLatestResult
 
 	// validate result is signed with the key we're aware of
 	pub, err := x509.ParsePKIXPublicKey(tc.pubkey.Der)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, "")
+		return nil, &apiError{http.StatusInternalServerError, err, ""}
 	}
 	verifier := tclient.NewLogVerifier(rfc6962.DefaultHasher, pub, crypto.SHA256)
 	root, err := tcrypto.VerifySignedLogRoot(verifier.PubKey, verifier.SigHash, result.SignedLogRoot)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, err, trillianUnexpectedResult}
 	}
 
 	hashString := hex.EncodeToString(root.RootHash)
@@ -67,38 +80,50 @@ func GetLogInfoHandler(params tlog.GetLogInfoParams) middleware.Responder {
 		Signature: &signature,
 	}
 
-	logInfo := models.LogInfo{
+	inactiveShards, err := tc.inactiveShardInfo()
+	if err != nil {
+		return nil, &apiError{http.StatusInternalServerError, err, trillianCommunicationError}
+	}
+
+	return &models.LogInfo{
 		RootHash:       &hashString,
 		TreeSize:       &treeSize,
 		SignedTreeHead: &sth,
-	}
-	return tlog.NewGetLogInfoOK().WithPayload(&logInfo)
+		InactiveShards: inactiveShards,
+	}, nil
 }
 
 func GetLogProofHandler(params tlog.GetLogProofParams) middleware.Responder {
-	if *params.FirstSize > params.LastSize {
-		return handleRekorAPIError(params, http.StatusBadRequest, nil, fmt.Sprintf(firstSizeLessThanLastSize, *params.FirstSize, params.LastSize))
+	consistencyProof, apiErr := logConsistencyProof(params.HTTPRequest.Context(), *params.FirstSize, params.LastSize)
+	if apiErr != nil {
+		return handleRekorAPIError(params, apiErr.code, apiErr.err, apiErr.message)
 	}
-	tc := NewTrillianClient(params.HTTPRequest.Context())
+	return tlog.NewGetLogProofOK().WithPayload(consistencyProof)
+}
 
-	resp := tc.getConsistencyProof(*params.FirstSize, params.LastSize)
+func logConsistencyProof(ctx context.Context, firstSize, lastSize int64) (*models.ConsistencyProof, *apiError) {
+	if firstSize > lastSize {
+		return nil, &apiError{http.StatusBadRequest, nil, fmt.Sprintf(firstSizeLessThanLastSize, firstSize, lastSize)}
+	}
+	tc := NewTrillianClient(ctx)
+
+	resp := tc.getConsistencyProof(firstSize, lastSize)
 	if resp.status != codes.OK {
-		return handleRekorAPIError(params, http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError)
+		return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("grpc error: %w", resp.err), trillianCommunicationError}
 	}
 	result := resp.getConsistencyProofResult
 
 	// validate result is signed with the key we're aware of
 	pub, err := x509.ParsePKIXPublicKey(tc.pubkey.Der)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, "")
+		return nil, &apiError{http.StatusInternalServerError, err, ""}
 	}
 	verifier := tclient.NewLogVerifier(rfc6962.DefaultHasher, pub, crypto.SHA256)
 	root, err := tcrypto.VerifySignedLogRoot(verifier.PubKey, verifier.SigHash, result.SignedLogRoot)
 	if err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+		return nil, &apiError{http.StatusInternalServerError, err, trillianUnexpectedResult}
 	}
 
-	hashString := hex.EncodeToString(root.RootHash)
 	proofHashes := []string{}
 
 	if proof := result.GetProof(); proof != nil {
@@ -109,28 +134,79 @@ func GetLogProofHandler(params tlog.GetLogProofParams) middleware.Responder {
 		// The proof field may be empty if the requested tree_size was larger than that available at the server
 		// (e.g. because there is skew between server instances, and an earlier client request was processed by
 		// a more up-to-date instance). root.TreeSize is the maximum size currently observed
-		return handleRekorAPIError(params, http.StatusBadRequest, nil, fmt.Sprintf(lastSizeGreaterThanKnown, params.LastSize, root.TreeSize))
+		return nil, &apiError{http.StatusBadRequest, nil, fmt.Sprintf(lastSizeGreaterThanKnown, lastSize, root.TreeSize)}
 	}
 
-	consistencyProof := models.ConsistencyProof{
+	// the proof may be between sizes the log has since grown past, so it is returned with the root it ends at
+	rootHash := root.RootHash
+	if uint64(lastSize) < root.TreeSize {
+		if rootHash, err = tc.rootAt(lastSize); err != nil {
+			return nil, &apiError{http.StatusInternalServerError, fmt.Errorf("computing root at size %v: %w", lastSize, err), trillianUnexpectedResult}
+		}
+	}
+	hashString := hex.EncodeToString(rootHash)
+
+	return &models.ConsistencyProof{
 		RootHash: &hashString,
 		Hashes:   proofHashes,
-	}
-	return tlog.NewGetLogProofOK().WithPayload(&consistencyProof)
+	}, nil
 }
 
 func GetPublicKeyHandler(params tlog.GetPublicKeyParams) middleware.Responder {
 	tc := NewTrillianClient(params.HTTPRequest.Context())
 
-	keyBuf := bytes.Buffer{}
-	block := &pem.Block{
-		Type:  "PUBLIC KEY",
-		Bytes: tc.pubkey.Der,
+	if params.KeyHint == nil {
+		return tlog.NewGetPublicKeyOK().WithPayload(tc.keys[0].pem())
+	}
+	hint, err := base64.StdEncoding.DecodeString(*params.KeyHint)
+	if err != nil {
+		return handleRekorAPIError(params, http.StatusBadRequest, err, malformedKeyHint)
 	}
+	key, ok := findLogKey(tc.keys, hint)
+	if !ok {
+		return handleRekorAPIError(params, http.StatusNotFound, fmt.Errorf("no log key has key hint %v", *params.KeyHint), "")
+	}
+	return tlog.NewGetPublicKeyOK().WithPayload(key.pem())
+}
+
+func GetRekorConfigurationHandler(params tlog.GetRekorConfigurationParams) middleware.Responder {
+	tc := NewTrillianClient(params.HTTPRequest.Context())
 
-	if err := pem.Encode(&keyBuf, block); err != nil {
-		return handleRekorAPIError(params, http.StatusInternalServerError, err, trillianUnexpectedResult)
+	// the log ID is derived from the key in the same way as RFC 6962, so clients can pin it independently of the URL
+	logID := tc.keys[0].logID()
+
+	// keys the log was signed with before being rotated are published along with the active one, so that tree
+	// heads signed before the rotation can still be verified
+	publicKeys := make([]*models.RekorConfigurationPublicKeysItems0, 0, len(tc.keys))
+	for _, k := range tc.keys {
+		publicKeys = append(publicKeys, &models.RekorConfigurationPublicKeysItems0{
+			LogID:   swag.String(k.logID()),
+			Key:     swag.String(k.pem()),
+			KeyHint: strfmt.Base64(k.hint),
+		})
 	}
 
-	return tlog.NewGetPublicKeyOK().WithPayload(keyBuf.String())
+	// entries are read from the inactive shards, oldest first, before the tree currently accepting them
+	shards := make([]*models.RekorConfigurationShardsItems0, 0, len(tc.shards)+1)
+	for _, s := range tc.shards {
+		shards = append(shards, &models.RekorConfigurationShardsItems0{
+			TreeID: swag.String(strconv.FormatInt(s.logID, 10)),
+			LogID:  swag.String(s.keys[0].logID()),
+			Active: swag.Bool(false),
+		})
+	}
+	shards = append(shards, &models.RekorConfigurationShardsItems0{
+		TreeID: swag.String(strconv.FormatInt(tc.logID, 10)),
+		LogID:  swag.String(logID),
+		Active: swag.Bool(true),
+	})
+
+	config := models.RekorConfiguration{
+		LogID:          swag.String(logID),
+		PublicKeys:     publicKeys,
+		Shards:         shards,
+		APIVersions:    []string{"v1", "v2"},
+		IndexKeyScheme: pki.KeyIndexScheme(),
+	}
+	return tlog.NewGetRekorConfigurationOK().WithPayload(&config)
 }
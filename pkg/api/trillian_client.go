@@ -18,6 +18,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -26,9 +27,12 @@ import (
 
 	"github.com/google/trillian"
 	"github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
 	"github.com/google/trillian/crypto/keyspb"
 	"github.com/google/trillian/crypto/sigpb"
+	"github.com/google/trillian/merkle/logverifier"
 	_ "github.com/google/trillian/merkle/rfc6962" //register hasher
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
 	"github.com/google/trillian/types"
 )
 
@@ -38,15 +42,30 @@ type TrillianClient struct {
 	context  context.Context
 	pubkey   *keyspb.PublicKey
 	verifier *client.LogVerifier
+	keys     []logKey
+	// offset is the index in the log of the first leaf of the tree, following the leaves of the inactive shards
+	offset int64
+	// shards holds the inactive shards of the log, oldest first
+	shards []logShard
 }
 
+// NewTrillianClient returns a client of the tree of the tenant of ctx, or of the default tree
 func NewTrillianClient(ctx context.Context) TrillianClient {
+	a := api
+	if name := tenantFrom(ctx); name != "" {
+		a = api.tenants[name]
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return TrillianClient{
-		client:   api.logClient,
-		logID:    api.logID,
+		client:   a.logClient,
+		logID:    a.logID,
 		context:  ctx,
-		pubkey:   api.pubkey,
-		verifier: api.verifier,
+		pubkey:   a.pubkey,
+		verifier: a.verifier,
+		keys:     a.keys,
+		offset:   a.offset,
+		shards:   a.inactiveShards,
 	}
 }
 
@@ -54,6 +73,7 @@ type Response struct {
 	status                    codes.Code
 	err                       error
 	getAddResult              *trillian.QueueLeafResponse
+	getAddLeavesResult        *trillian.QueueLeavesResponse
 	getLeafResult             *trillian.GetLeavesByHashResponse
 	getProofResult            *trillian.GetInclusionProofByHashResponse
 	getLeafByRangeResult      *trillian.GetLeavesByRangeResponse
@@ -62,21 +82,63 @@ type Response struct {
 }
 
 func (t *TrillianClient) root() (types.LogRootV1, error) {
+	_, root, err := t.signedRoot()
+	return root, err
+}
+
+// signedRoot returns the latest signed tree head along with the root it signs
+func (t *TrillianClient) signedRoot() (*trillian.SignedLogRoot, types.LogRootV1, error) {
 	rqst := &trillian.GetLatestSignedLogRootRequest{
 		LogId: t.logID,
 	}
-	resp, err := t.client.GetLatestSignedLogRoot(t.context, rqst)
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
+	defer cancel()
+
+	resp, err := t.client.GetLatestSignedLogRoot(ctx, rqst)
 	if err != nil {
-		return types.LogRootV1{}, err
+		return nil, types.LogRootV1{}, err
 	}
 	var root types.LogRootV1
 	if err := root.UnmarshalBinary(resp.SignedLogRoot.LogRoot); err != nil {
-		return types.LogRootV1{}, err
+		return nil, types.LogRootV1{}, err
 	}
-	return root, nil
+	return resp.SignedLogRoot, root, nil
 }
 
+// verifiedSignedRoot returns the latest signed tree head of the tree along with the root it signs, verified with
+// the key of the tree
+func (t *TrillianClient) verifiedSignedRoot() (*trillian.SignedLogRoot, *types.LogRootV1, error) {
+	slr, _, err := t.signedRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := tcrypto.VerifySignedLogRoot(t.verifier.PubKey, t.verifier.SigHash, slr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return slr, root, nil
+}
+
+// addLeaf queues a leaf and waits for it to be integrated, returning it with its index in the log; a leaf held by an
+// inactive shard is returned as already existing without being queued
 func (t *TrillianClient) addLeaf(byteValue []byte) *Response {
+	if len(t.shards) > 0 {
+		hash := rfc6962.DefaultHasher.HashLeaf(byteValue)
+		existing, err := t.inactiveShardLeaves([][]byte{hash})
+		if err != nil {
+			return &Response{
+				status: status.Code(err),
+				err:    err,
+			}
+		}
+		if leaf, ok := existing[string(hash)]; ok {
+			return &Response{
+				status:       codes.OK,
+				getAddResult: &trillian.QueueLeafResponse{QueuedLeaf: alreadyLogged(leaf)},
+			}
+		}
+	}
+
 	leaf := &trillian.LogLeaf{
 		LeafValue: byteValue,
 	}
@@ -84,10 +146,13 @@ func (t *TrillianClient) addLeaf(byteValue []byte) *Response {
 		LogId: t.logID,
 		Leaf:  leaf,
 	}
-	resp, err := t.client.QueueLeaf(t.context, rqst)
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
+	defer cancel()
+
+	resp, err := t.client.QueueLeaf(ctx, rqst)
 
-	// check for error
-	if err != nil || (resp.QueuedLeaf.Status != nil && resp.QueuedLeaf.Status.Code != int32(codes.OK)) {
+	// check for error; a leaf that already exists is looked up like a new one so that its index is returned
+	if err != nil || (resp.QueuedLeaf.Status != nil && resp.QueuedLeaf.Status.Code != int32(codes.OK) && resp.QueuedLeaf.Status.Code != int32(codes.AlreadyExists)) {
 		return &Response{
 			status:       status.Code(err),
 			err:          err,
@@ -123,6 +188,7 @@ func (t *TrillianClient) addLeaf(byteValue []byte) *Response {
 
 	//overwrite queued leaf that doesn't have index set
 	resp.QueuedLeaf.Leaf = leafResp.getLeafResult.Leaves[0]
+	resp.QueuedLeaf.Leaf.LeafIndex += t.offset
 
 	return &Response{
 		status:       status.Code(err),
@@ -131,13 +197,145 @@ func (t *TrillianClient) addLeaf(byteValue []byte) *Response {
 	}
 }
 
+// addLeaves queues a batch of leaves and waits for the ones that were newly queued to be integrated; leaves that
+// were not queued keep the status Trillian returned for them, in the same order as byteValues. Leaves held by an
+// inactive shard are returned as already existing without being queued.
+func (t *TrillianClient) addLeaves(byteValues [][]byte) *Response {
+	if len(t.shards) == 0 {
+		return t.queueLeaves(byteValues)
+	}
+	hashes := leafHashes(byteValues)
+	existing, err := t.inactiveShardLeaves(hashes)
+	if err != nil {
+		return &Response{
+			status: status.Code(err),
+			err:    err,
+		}
+	}
+	var pending [][]byte
+	for i, v := range byteValues {
+		if _, ok := existing[string(hashes[i])]; !ok {
+			pending = append(pending, v)
+		}
+	}
+	resp := &Response{
+		status:             codes.OK,
+		getAddLeavesResult: &trillian.QueueLeavesResponse{},
+	}
+	if len(pending) > 0 {
+		if resp = t.queueLeaves(pending); resp.err != nil {
+			return resp
+		}
+	}
+	queued := resp.getAddLeavesResult.QueuedLeaves
+	results := make([]*trillian.QueuedLogLeaf, 0, len(byteValues))
+	for _, hash := range hashes {
+		if leaf, ok := existing[string(hash)]; ok {
+			results = append(results, alreadyLogged(leaf))
+			continue
+		}
+		results = append(results, queued[0])
+		queued = queued[1:]
+	}
+	resp.getAddLeavesResult.QueuedLeaves = results
+	return resp
+}
+
+// queueLeaves queues a batch of leaves in the tree, returning them with their index in the log
+func (t *TrillianClient) queueLeaves(byteValues [][]byte) *Response {
+	rqst := &trillian.QueueLeavesRequest{
+		LogId: t.logID,
+	}
+	for _, v := range byteValues {
+		rqst.Leaves = append(rqst.Leaves, &trillian.LogLeaf{LeafValue: v})
+	}
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
+	defer cancel()
+
+	resp, err := t.client.QueueLeaves(ctx, rqst)
+	if err != nil {
+		return &Response{
+			status:             status.Code(err),
+			err:                err,
+			getAddLeavesResult: resp,
+		}
+	}
+	if len(resp.QueuedLeaves) != len(byteValues) {
+		err := fmt.Errorf("%v leaves queued for a batch of %v", len(resp.QueuedLeaves), len(byteValues))
+		return &Response{
+			status:             codes.Internal,
+			err:                err,
+			getAddLeavesResult: resp,
+		}
+	}
+
+	root, err := t.root()
+	if err != nil {
+		return &Response{
+			status:             status.Code(err),
+			err:                err,
+			getAddLeavesResult: resp,
+		}
+	}
+	logClient := client.New(t.logID, t.client, t.verifier, root)
+	var hashes [][]byte
+	for i, queued := range resp.QueuedLeaves {
+		if queued.Status != nil && queued.Status.Code != int32(codes.OK) {
+			continue
+		}
+		if err := logClient.WaitForInclusion(t.context, byteValues[i]); err != nil {
+			return &Response{
+				status:             status.Code(err),
+				err:                err,
+				getAddLeavesResult: resp,
+			}
+		}
+		hashes = append(hashes, queued.Leaf.MerkleLeafHash)
+	}
+	if len(hashes) == 0 {
+		return &Response{
+			status:             codes.OK,
+			getAddLeavesResult: resp,
+		}
+	}
+
+	leafResp := t.getLeafByHash(hashes)
+	if leafResp.err != nil {
+		return &Response{
+			status:             status.Code(leafResp.err),
+			err:                leafResp.err,
+			getAddLeavesResult: resp,
+		}
+	}
+
+	//overwrite queued leaves that don't have their index set
+	leaves := map[string]*trillian.LogLeaf{}
+	for _, leaf := range leafResp.getLeafResult.Leaves {
+		leaf.LeafIndex += t.offset
+		leaves[string(leaf.MerkleLeafHash)] = leaf
+	}
+	for _, queued := range resp.QueuedLeaves {
+		if leaf, ok := leaves[string(queued.Leaf.MerkleLeafHash)]; ok && (queued.Status == nil || queued.Status.Code == int32(codes.OK)) {
+			queued.Leaf = leaf
+		}
+	}
+
+	return &Response{
+		status:             codes.OK,
+		getAddLeavesResult: resp,
+	}
+}
+
 func (t *TrillianClient) getLeafByHash(hashValues [][]byte) *Response {
 	rqst := &trillian.GetLeavesByHashRequest{
 		LogId:    t.logID,
 		LeafHash: hashValues,
 	}
 
-	resp, err := t.client.GetLeavesByHash(t.context, rqst)
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
+	defer cancel()
+
+	resp, err := t.client.GetLeavesByHash(ctx, rqst)
 
 	return &Response{
 		status:        status.Code(err),
@@ -147,15 +345,21 @@ func (t *TrillianClient) getLeafByHash(hashValues [][]byte) *Response {
 }
 
 func (t *TrillianClient) getLeafByIndex(index int64) *Response {
+	return t.getLeavesByRange(index, 1)
+}
+
+// getLeavesByRange returns up to count leaves starting at index start; fewer are returned when the tree ends
+// before the range does
+func (t *TrillianClient) getLeavesByRange(start, count int64) *Response {
 
-	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
 	defer cancel()
 
 	resp, err := t.client.GetLeavesByRange(ctx,
 		&trillian.GetLeavesByRangeRequest{
 			LogId:      t.logID,
-			StartIndex: index,
-			Count:      1,
+			StartIndex: start,
+			Count:      count,
 		})
 
 	return &Response{
@@ -166,9 +370,6 @@ func (t *TrillianClient) getLeafByIndex(index int64) *Response {
 }
 
 func (t *TrillianClient) getProofByHash(hashValue []byte) *Response {
-	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
-	defer cancel()
-
 	root, err := t.root()
 	if err != nil {
 		return &Response{
@@ -176,6 +377,14 @@ func (t *TrillianClient) getProofByHash(hashValue []byte) *Response {
 			err:    err,
 		}
 	}
+	return t.getProofByHashAt(hashValue, root)
+}
+
+// getProofByHashAt returns the inclusion proof of a leaf in the tree of the given root, so that the proofs of
+// several leaves can be checked against the same tree head
+func (t *TrillianClient) getProofByHashAt(hashValue []byte, root types.LogRootV1) *Response {
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
+	defer cancel()
 
 	resp, err := t.client.GetInclusionProofByHash(ctx,
 		&trillian.GetInclusionProofByHashRequest{
@@ -204,7 +413,7 @@ func (t *TrillianClient) getProofByHash(hashValue []byte) *Response {
 
 func (t *TrillianClient) getLatest(leafSizeInt int64) *Response {
 
-	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
 	defer cancel()
 
 	resp, err := t.client.GetLatestSignedLogRoot(ctx,
@@ -222,7 +431,7 @@ func (t *TrillianClient) getLatest(leafSizeInt int64) *Response {
 
 func (t *TrillianClient) getConsistencyProof(firstSize, lastSize int64) *Response {
 
-	ctx, cancel := context.WithTimeout(t.context, 20*time.Second)
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
 	defer cancel()
 
 	resp, err := t.client.GetConsistencyProof(ctx,
@@ -239,7 +448,47 @@ func (t *TrillianClient) getConsistencyProof(firstSize, lastSize int64) *Respons
 	}
 }
 
-func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient) (*trillian.Tree, error) {
+// rootAt returns the root hash of the tree when it held size leaves, computed from the inclusion proof of its
+// last leaf at that size as Trillian only signs the latest tree head
+func (t *TrillianClient) rootAt(size int64) ([]byte, error) {
+	if size == 0 {
+		return rfc6962.DefaultHasher.EmptyRoot(), nil
+	}
+	resp := t.getLeafByIndex(size - 1)
+	if resp.err != nil {
+		return nil, resp.err
+	}
+	leaves := resp.getLeafByRangeResult.GetLeaves()
+	if len(leaves) != 1 {
+		return nil, fmt.Errorf("tree has no leaf at index %v", size-1)
+	}
+
+	ctx, cancel := withTimeout(t.context, trillianTimeout)
+	defer cancel()
+
+	proof, err := t.client.GetInclusionProof(ctx,
+		&trillian.GetInclusionProofRequest{
+			LogId:     t.logID,
+			LeafIndex: size - 1,
+			TreeSize:  size,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if proof.GetProof() == nil {
+		return nil, fmt.Errorf("tree is smaller than %v", size)
+	}
+	return logverifier.New(rfc6962.DefaultHasher).RootFromInclusionProof(size-1, size, proof.Proof.Hashes, leaves[0].MerkleLeafHash)
+}
+
+// signingCurves are the curves of the keys Trillian can be asked to sign tree heads with
+var signingCurves = map[string]keyspb.Specification_ECDSA_Curve{
+	"P-256": keyspb.Specification_ECDSA_P256,
+	"P-384": keyspb.Specification_ECDSA_P384,
+	"P-521": keyspb.Specification_ECDSA_P521,
+}
+
+func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient, curve string) (*trillian.Tree, error) {
 	// First look for and use an existing tree
 	trees, err := adminClient.ListTrees(ctx, &trillian.ListTreesRequest{})
 	if err != nil {
@@ -253,6 +502,15 @@ func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminCl
 	}
 
 	// Otherwise create and initialize one
+	return createTree(ctx, adminClient, logClient, curve)
+}
+
+// createTree creates and initialises a new log tree whose tree heads are signed with a key of the given curve
+func createTree(ctx context.Context, adminClient trillian.TrillianAdminClient, logClient trillian.TrillianLogClient, curve string) (*trillian.Tree, error) {
+	c, ok := signingCurves[curve]
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing curve %q", curve)
+	}
 	t, err := adminClient.CreateTree(ctx, &trillian.CreateTreeRequest{
 		Tree: &trillian.Tree{
 			TreeType:           trillian.TreeType_LOG,
@@ -264,7 +522,7 @@ func createAndInitTree(ctx context.Context, adminClient trillian.TrillianAdminCl
 		},
 		KeySpec: &keyspb.Specification{
 			Params: &keyspb.Specification_EcdsaParams{
-				EcdsaParams: &keyspb.Specification_ECDSA{},
+				EcdsaParams: &keyspb.Specification_ECDSA{Curve: c},
 			},
 		},
 	})
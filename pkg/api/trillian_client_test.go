@@ -0,0 +1,297 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/crypto/keyspb"
+	"github.com/google/trillian/crypto/sigpb"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+)
+
+type fakeAdminClient struct {
+	trillian.TrillianAdminClient
+	created *trillian.CreateTreeRequest
+}
+
+func (f *fakeAdminClient) ListTrees(context.Context, *trillian.ListTreesRequest, ...grpc.CallOption) (*trillian.ListTreesResponse, error) {
+	return &trillian.ListTreesResponse{}, nil
+}
+
+func (f *fakeAdminClient) CreateTree(_ context.Context, req *trillian.CreateTreeRequest, _ ...grpc.CallOption) (*trillian.Tree, error) {
+	f.created = req
+	return &trillian.Tree{TreeId: 1, TreeType: req.Tree.TreeType}, nil
+}
+
+type fakeLogClient struct {
+	trillian.TrillianLogClient
+}
+
+func (fakeLogClient) InitLog(context.Context, *trillian.InitLogRequest, ...grpc.CallOption) (*trillian.InitLogResponse, error) {
+	return &trillian.InitLogResponse{}, nil
+}
+
+func (fakeLogClient) GetLatestSignedLogRoot(context.Context, *trillian.GetLatestSignedLogRootRequest, ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	return &trillian.GetLatestSignedLogRootResponse{}, nil
+}
+
+func TestCreateAndInitTreeCurve(t *testing.T) {
+	tests := []struct {
+		curve         string
+		expected      keyspb.Specification_ECDSA_Curve
+		expectSuccess bool
+	}{
+		{curve: "P-256", expected: keyspb.Specification_ECDSA_P256, expectSuccess: true},
+		{curve: "P-384", expected: keyspb.Specification_ECDSA_P384, expectSuccess: true},
+		{curve: "P-521", expected: keyspb.Specification_ECDSA_P521, expectSuccess: true},
+		{curve: "P-224", expectSuccess: false},
+	}
+	for _, tc := range tests {
+		admin := &fakeAdminClient{}
+		_, err := createAndInitTree(context.Background(), admin, fakeLogClient{}, tc.curve)
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result creating tree with curve %v: %v", tc.curve, err)
+			continue
+		}
+		if !tc.expectSuccess {
+			if admin.created != nil {
+				t.Errorf("tree created with unsupported curve %v", tc.curve)
+			}
+			continue
+		}
+		if got := admin.created.KeySpec.GetEcdsaParams().GetCurve(); got != tc.expected {
+			t.Errorf("unexpected curve for %v: %v", tc.curve, got)
+		}
+	}
+}
+
+func TestVerifyTreeHeadCurves(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(priv.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Trillian signs the tree heads of every tree with SHA-256, whatever the curve of its key
+		verifier, err := tclient.NewLogVerifierFromTree(&trillian.Tree{
+			TreeType:           trillian.TreeType_LOG,
+			HashStrategy:       trillian.HashStrategy_RFC6962_SHA256,
+			HashAlgorithm:      sigpb.DigitallySigned_SHA256,
+			SignatureAlgorithm: sigpb.DigitallySigned_ECDSA,
+			PublicKey:          &keyspb.PublicKey{Der: der},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		slr, err := tcrypto.NewSigner(0, priv, crypto.SHA256).SignLogRoot(&types.LogRootV1{TreeSize: 0, RootHash: rfc6962.DefaultHasher.EmptyRoot()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := verifier.VerifyRoot(&types.LogRootV1{}, slr, nil); err != nil {
+			t.Errorf("unexpected error verifying tree head signed with %v key: %v", curve.Params().Name, err)
+		}
+	}
+}
+
+// batchLogClient queues leaves with the given statuses, or returns queued if set
+type batchLogClient struct {
+	fakeLogClient
+	statuses []code.Code
+	queued   []*trillian.QueuedLogLeaf
+}
+
+func (f batchLogClient) QueueLeaves(_ context.Context, req *trillian.QueueLeavesRequest, _ ...grpc.CallOption) (*trillian.QueueLeavesResponse, error) {
+	if f.queued != nil {
+		return &trillian.QueueLeavesResponse{QueuedLeaves: f.queued}, nil
+	}
+	resp := &trillian.QueueLeavesResponse{}
+	for i, leaf := range req.Leaves {
+		resp.QueuedLeaves = append(resp.QueuedLeaves, &trillian.QueuedLogLeaf{
+			Leaf:   &trillian.LogLeaf{LeafValue: leaf.LeafValue, MerkleLeafHash: rfc6962.DefaultHasher.HashLeaf(leaf.LeafValue)},
+			Status: &status.Status{Code: int32(f.statuses[i])},
+		})
+	}
+	return resp, nil
+}
+
+func (batchLogClient) GetLatestSignedLogRoot(context.Context, *trillian.GetLatestSignedLogRootRequest, ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root, err := (&types.LogRootV1{RootHash: rfc6962.DefaultHasher.EmptyRoot()}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: root}}, nil
+}
+
+func TestAddLeavesNotQueued(t *testing.T) {
+	leaves := [][]byte{[]byte("a"), []byte("b")}
+
+	// leaves that are not newly queued are returned with their status, without waiting for inclusion
+	tc := TrillianClient{client: batchLogClient{statuses: []code.Code{code.Code_ALREADY_EXISTS, code.Code_FAILED_PRECONDITION}}, context: context.Background()}
+	resp := tc.addLeaves(leaves)
+	if resp.err != nil {
+		t.Fatalf("unexpected error adding leaves: %v", resp.err)
+	}
+	for i, queued := range resp.getAddLeavesResult.QueuedLeaves {
+		if queued.Status.Code == int32(code.Code_OK) || string(queued.Leaf.LeafValue) != string(leaves[i]) {
+			t.Errorf("unexpected queued leaf %v: %v", i, queued)
+		}
+	}
+
+	// a response that does not account for every leaf is an error
+	tc = TrillianClient{client: batchLogClient{queued: []*trillian.QueuedLogLeaf{{}}}, context: context.Background()}
+	if resp := tc.addLeaves(leaves); resp.err == nil {
+		t.Error("expected error for response with fewer leaves than the batch")
+	}
+}
+
+// proofLogClient serves inclusion proofs of a tree of two leaves
+type proofLogClient struct {
+	fakeLogClient
+	leafHashes [][]byte
+}
+
+func (f proofLogClient) GetLatestSignedLogRoot(context.Context, *trillian.GetLatestSignedLogRootRequest, ...grpc.CallOption) (*trillian.GetLatestSignedLogRootResponse, error) {
+	root, err := (&types.LogRootV1{TreeSize: 2, RootHash: rfc6962.DefaultHasher.HashChildren(f.leafHashes[0], f.leafHashes[1])}).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &trillian.GetLatestSignedLogRootResponse{SignedLogRoot: &trillian.SignedLogRoot{LogRoot: root}}, nil
+}
+
+func (f proofLogClient) GetInclusionProofByHash(_ context.Context, req *trillian.GetInclusionProofByHashRequest, _ ...grpc.CallOption) (*trillian.GetInclusionProofByHashResponse, error) {
+	for i, h := range f.leafHashes {
+		if string(h) == string(req.LeafHash) {
+			return &trillian.GetInclusionProofByHashResponse{
+				Proof: []*trillian.Proof{{LeafIndex: int64(i), Hashes: [][]byte{f.leafHashes[1-i]}}},
+			}, nil
+		}
+	}
+	return nil, errors.New("leaf not found")
+}
+
+func TestAddInclusionProofs(t *testing.T) {
+	leafHashes := [][]byte{rfc6962.DefaultHasher.HashLeaf([]byte("a")), rfc6962.DefaultHasher.HashLeaf([]byte("b"))}
+	tc := TrillianClient{
+		client:   proofLogClient{leafHashes: leafHashes},
+		context:  context.Background(),
+		verifier: tclient.NewLogVerifier(rfc6962.DefaultHasher, nil, crypto.SHA256),
+	}
+
+	logEntries := []models.LogEntry{}
+	for i, h := range leafHashes {
+		logEntries = append(logEntries, models.LogEntry{hex.EncodeToString(h): models.LogEntryAnon{LogIndex: swag.Int64(int64(i))}})
+	}
+	if err := addInclusionProofs(tc, logEntries); err != nil {
+		t.Fatal(err)
+	}
+	rootHash := hex.EncodeToString(rfc6962.DefaultHasher.HashChildren(leafHashes[0], leafHashes[1]))
+	for i, logEntry := range logEntries {
+		for _, e := range logEntry {
+			if e.Verification == nil || e.Verification.InclusionProof == nil {
+				t.Fatalf("entry %v has no inclusion proof", i)
+			}
+			proof := e.Verification.InclusionProof
+			if *proof.LogIndex != int64(i) || *proof.TreeSize != 2 || *proof.RootHash != rootHash || len(proof.Hashes) != 1 || proof.Hashes[0] != hex.EncodeToString(leafHashes[1-i]) {
+				t.Errorf("unexpected inclusion proof of entry %v: %+v", i, proof)
+			}
+			if sth := e.Verification.SignedTreeHead; sth == nil || sth.LogRoot == nil || len(*sth.LogRoot) == 0 {
+				t.Errorf("entry %v has no signed tree head", i)
+			}
+		}
+	}
+
+	unknown := []models.LogEntry{{hex.EncodeToString(rfc6962.DefaultHasher.HashLeaf([]byte("c"))): models.LogEntryAnon{}}}
+	if err := addInclusionProofs(tc, unknown); err == nil {
+		t.Error("expected error adding inclusion proof of an entry not in the tree")
+	}
+}
+
+// historyLogClient serves the leaves of a tree of three leaves, and the inclusion proofs of the last leaf of
+// each of its earlier sizes
+type historyLogClient struct {
+	fakeLogClient
+	leafHashes [][]byte
+}
+
+func (f historyLogClient) GetLeavesByRange(_ context.Context, req *trillian.GetLeavesByRangeRequest, _ ...grpc.CallOption) (*trillian.GetLeavesByRangeResponse, error) {
+	resp := &trillian.GetLeavesByRangeResponse{}
+	for i := req.StartIndex; i < req.StartIndex+req.Count && i < int64(len(f.leafHashes)); i++ {
+		resp.Leaves = append(resp.Leaves, &trillian.LogLeaf{LeafIndex: i, MerkleLeafHash: f.leafHashes[i]})
+	}
+	return resp, nil
+}
+
+func (f historyLogClient) GetInclusionProof(_ context.Context, req *trillian.GetInclusionProofRequest, _ ...grpc.CallOption) (*trillian.GetInclusionProofResponse, error) {
+	proofs := map[int64][][]byte{
+		1: {},
+		2: {f.leafHashes[0]},
+		3: {rfc6962.DefaultHasher.HashChildren(f.leafHashes[0], f.leafHashes[1])},
+	}
+	hashes, ok := proofs[req.TreeSize]
+	if !ok || req.LeafIndex != req.TreeSize-1 {
+		return &trillian.GetInclusionProofResponse{}, nil
+	}
+	return &trillian.GetInclusionProofResponse{Proof: &trillian.Proof{LeafIndex: req.LeafIndex, Hashes: hashes}}, nil
+}
+
+func TestRootAt(t *testing.T) {
+	h := rfc6962.DefaultHasher
+	leafHashes := [][]byte{h.HashLeaf([]byte("a")), h.HashLeaf([]byte("b")), h.HashLeaf([]byte("c"))}
+	tc := TrillianClient{client: historyLogClient{leafHashes: leafHashes}, context: context.Background()}
+
+	roots := map[int64][]byte{
+		0: h.EmptyRoot(),
+		1: leafHashes[0],
+		2: h.HashChildren(leafHashes[0], leafHashes[1]),
+		3: h.HashChildren(h.HashChildren(leafHashes[0], leafHashes[1]), leafHashes[2]),
+	}
+	for size, want := range roots {
+		got, err := tc.rootAt(size)
+		if err != nil {
+			t.Errorf("unexpected error computing root at size %v: %v", size, err)
+			continue
+		}
+		if hex.EncodeToString(got) != hex.EncodeToString(want) {
+			t.Errorf("unexpected root at size %v: %x", size, got)
+		}
+	}
+	if _, err := tc.rootAt(4); err == nil {
+		t.Error("expected error computing root beyond the size of the tree")
+	}
+}
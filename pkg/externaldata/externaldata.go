@@ -0,0 +1,489 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externaldata serves Rekor lookups as an OPA Gatekeeper external data provider: each key of a
+// request is an artifact digest, and its value is the list of log entries for that digest whose inclusion
+// in the log was verified, along with the identity that signed each of them.
+package externaldata
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/google/trillian"
+	tclient "github.com/google/trillian/client"
+	tcrypto "github.com/google/trillian/crypto"
+	"github.com/google/trillian/merkle/logverifier"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/client/entries"
+	"github.com/sigstore/rekor/pkg/generated/client/index"
+	"github.com/sigstore/rekor/pkg/generated/client/tlog"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+)
+
+const (
+	// APIVersion is the version of the Gatekeeper external data protocol that is served
+	APIVersion = "externaldata.gatekeeper.sh/v1alpha1"
+
+	requestKind  = "ProviderRequest"
+	responseKind = "ProviderResponse"
+
+	// maxCachedDigests bounds the memory used by the cache
+	maxCachedDigests = 10000
+)
+
+// ProviderRequest is the body Gatekeeper sends to an external data provider
+type ProviderRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Request    struct {
+		Keys []string `json:"keys"`
+	} `json:"request"`
+}
+
+// ProviderResponse is the body an external data provider returns to Gatekeeper
+type ProviderResponse struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Response   Response `json:"response"`
+}
+
+// Response holds the result for each key of a request, or the error that prevented handling it
+type Response struct {
+	// Idempotent is false, since new entries for a digest may be added to the log at any time
+	Idempotent  bool   `json:"idempotent"`
+	Items       []Item `json:"items"`
+	SystemError string `json:"systemError,omitempty"`
+}
+
+// Item is the result for one key of a request; Value is a []Entry unless Error is set
+type Item struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+	Error string      `json:"error,omitempty"`
+}
+
+// Entry is a log entry for a digest whose inclusion in the log has been verified
+type Entry struct {
+	UUID           string    `json:"uuid"`
+	LogIndex       int64     `json:"logIndex"`
+	IntegratedTime int64     `json:"integratedTime"`
+	Kind           string    `json:"kind"`
+	APIVersion     string    `json:"apiVersion"`
+	Identity       *Identity `json:"identity,omitempty"`
+}
+
+// Identity describes the public key that signed an entry
+type Identity struct {
+	Format    string `json:"format"`
+	KeySHA256 string `json:"keySHA256"`
+	// Principals are the identities an issuer bound the key to, such as those of an SSH certificate
+	Principals []string `json:"principals,omitempty"`
+	// Emails are the email addresses of the user IDs of the key, such as those of a PGP key
+	Emails []string `json:"emails,omitempty"`
+	// KeyID is the key ID the certificate of the key was issued with, such as that of an SSH certificate
+	KeyID string `json:"keyId,omitempty"`
+	// URIs are the URI subject alternative names of a certificate, such as the workflow identity of a CI job
+	URIs []string `json:"uris,omitempty"`
+	// Issuer is the OIDC issuer that authenticated the subject of a certificate issued by Fulcio
+	Issuer string `json:"issuer,omitempty"`
+	// ValidAtIntegration reports whether an x509 certificate (and its chain) was valid at the integrated time of
+	// the entry, as keyless verification requires
+	ValidAtIntegration *bool `json:"validAtIntegration,omitempty"`
+}
+
+// principalKey is implemented by public keys that may carry issuer-bound principals
+type principalKey interface {
+	Principals() []string
+}
+
+// emailKey is implemented by public keys that carry user IDs with email addresses
+type emailKey interface {
+	Emails() []string
+}
+
+// keyIDKey is implemented by public keys that may carry a certificate key ID
+type keyIDKey interface {
+	KeyID() string
+}
+
+// certificateKey is implemented by public keys that may be certificates issued to an OIDC identity
+type certificateKey interface {
+	URIs() []string
+	Issuer() string
+}
+
+// validityKey is implemented by public keys that are only valid for a period of time, such as certificates
+type validityKey interface {
+	ValidAt(t time.Time) error
+}
+
+// Provider looks up digests in a Rekor log and verifies the results against the log's public key
+type Provider struct {
+	client   *client.Rekor
+	verifier *tclient.LogVerifier
+	ttl      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedLookup
+}
+
+type cachedLookup struct {
+	entries []Entry
+	expires time.Time
+}
+
+// NewProvider returns a provider querying rekorClient, whose signed tree heads must verify with logPublicKey;
+// successful lookups are cached for ttl, or not at all if ttl is zero
+func NewProvider(rekorClient *client.Rekor, logPublicKey crypto.PublicKey, ttl time.Duration) *Provider {
+	return &Provider{
+		client:   rekorClient,
+		verifier: tclient.NewLogVerifier(rfc6962.DefaultHasher, logPublicKey, crypto.SHA256),
+		ttl:      ttl,
+		cache:    map[string]cachedLookup{},
+	}
+}
+
+// normalizeDigest accepts a hex encoded SHA256 digest, optionally prefixed with "sha256:", or an image
+// reference pinned by digest (such as registry.example/app@sha256:...)
+func normalizeDigest(key string) (string, error) {
+	digest := key
+	if i := strings.LastIndex(digest, "@"); i >= 0 {
+		digest = digest[i+1:]
+	}
+	digest = strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+	if !govalidator.IsSHA256(digest) {
+		return "", fmt.Errorf("%v is not a SHA256 digest or an image reference pinned by one", key)
+	}
+	return digest, nil
+}
+
+// Lookup returns the verified entries of the log for the digest of an artifact
+func (p *Provider) Lookup(ctx context.Context, key string) ([]Entry, error) {
+	digest, err := normalizeDigest(key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	cached, ok := p.cache[digest]
+	p.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.entries, nil
+	}
+
+	result, err := p.lookup(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.ttl > 0 {
+		p.mu.Lock()
+		if len(p.cache) >= maxCachedDigests {
+			for k, v := range p.cache {
+				if !now.Before(v.expires) {
+					delete(p.cache, k)
+				}
+			}
+		}
+		if len(p.cache) >= maxCachedDigests {
+			p.cache = map[string]cachedLookup{}
+		}
+		p.cache[digest] = cachedLookup{entries: result, expires: now.Add(p.ttl)}
+		p.mu.Unlock()
+	}
+	return result, nil
+}
+
+func (p *Provider) lookup(ctx context.Context, digest string) ([]Entry, error) {
+	searchParams := index.NewSearchIndexParamsWithContext(ctx)
+	searchParams.Query = &models.SearchIndex{Hash: digest}
+	searchResp, err := p.client.Index.SearchIndex(searchParams)
+	if err != nil {
+		return nil, fmt.Errorf("error searching log: %w", err)
+	}
+	uuids := searchResp.GetPayload()
+	sort.Strings(uuids)
+
+	result := make([]Entry, 0, len(uuids))
+	proofs := make([]*models.InclusionProof, 0, len(uuids))
+	for _, uuid := range uuids {
+		entry, proof, err := p.fetchEntry(ctx, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("error verifying entry %v: %w", uuid, err)
+		}
+		result = append(result, *entry)
+		proofs = append(proofs, proof)
+	}
+	if len(proofs) == 0 {
+		return result, nil
+	}
+
+	// the tree head is fetched after the proofs, so it is at least as large as the tree of any of them
+	if err := p.verifyRoots(ctx, proofs); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fetchEntry returns entry uuid, having checked that uuid is the leaf hash of its body and that its
+// inclusion proof is valid; the proof's root hash is checked against a signed tree head by the caller
+func (p *Provider) fetchEntry(ctx context.Context, uuid string) (*Entry, *models.InclusionProof, error) {
+	entryParams := entries.NewGetLogEntryByUUIDParamsWithContext(ctx)
+	entryParams.EntryUUID = uuid
+	entryResp, err := p.client.Entries.GetLogEntryByUUID(entryParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	logEntry, ok := entryResp.Payload[uuid]
+	if !ok {
+		return nil, nil, errors.New("entry missing from response")
+	}
+	bodyStr, ok := logEntry.Body.(string)
+	if !ok {
+		return nil, nil, errors.New("unexpected type of entry body")
+	}
+	body, err := base64.StdEncoding.DecodeString(bodyStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	leafHash := rfc6962.DefaultHasher.HashLeaf(body)
+	if !strings.EqualFold(hex.EncodeToString(leafHash), uuid) {
+		return nil, nil, errors.New("entry body does not match its UUID")
+	}
+
+	proofParams := entries.NewGetLogEntryProofParamsWithContext(ctx)
+	proofParams.EntryUUID = uuid
+	proofResp, err := p.client.Entries.GetLogEntryProof(proofParams)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := proofResp.Payload
+	hashes, err := decodeHashes(proof.Hashes)
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := hex.DecodeString(*proof.RootHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	v := logverifier.New(rfc6962.DefaultHasher)
+	if err := v.VerifyInclusionProof(*proof.LogIndex, *proof.TreeSize, hashes, root, leafHash); err != nil {
+		return nil, nil, err
+	}
+
+	entry, err := parseEntry(body, logEntry.IntegratedTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry.UUID = uuid
+	entry.LogIndex = *logEntry.LogIndex
+	return entry, proof, nil
+}
+
+// verifyRoots checks that the root of every proof is a root of the log, by checking it against (or for
+// consistency with) the current signed tree head
+func (p *Provider) verifyRoots(ctx context.Context, proofs []*models.InclusionProof) error {
+	infoResp, err := p.client.Tlog.GetLogInfo(tlog.NewGetLogInfoParamsWithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("error fetching signed tree head: %w", err)
+	}
+	sth := infoResp.Payload.SignedTreeHead
+	if sth == nil || sth.KeyHint == nil || sth.LogRoot == nil || sth.Signature == nil {
+		return errors.New("log did not return a signed tree head")
+	}
+	lr, err := tcrypto.VerifySignedLogRoot(p.verifier.PubKey, p.verifier.SigHash, &trillian.SignedLogRoot{
+		KeyHint:          *sth.KeyHint,
+		LogRoot:          *sth.LogRoot,
+		LogRootSignature: *sth.Signature,
+	})
+	if err != nil {
+		return fmt.Errorf("error verifying signed tree head: %w", err)
+	}
+
+	v := logverifier.New(rfc6962.DefaultHasher)
+	for _, proof := range proofs {
+		treeSize := *proof.TreeSize
+		switch {
+		case int64(lr.TreeSize) < treeSize:
+			return fmt.Errorf("signed tree head of size %d predates inclusion proof for tree of size %d", lr.TreeSize, treeSize)
+		case int64(lr.TreeSize) == treeSize:
+			if !strings.EqualFold(hex.EncodeToString(lr.RootHash), *proof.RootHash) {
+				return errors.New("root hash in signed tree head does not match inclusion proof")
+			}
+		default:
+			params := tlog.NewGetLogProofParamsWithContext(ctx)
+			params.FirstSize = &treeSize
+			params.LastSize = int64(lr.TreeSize)
+			consistencyResp, err := p.client.Tlog.GetLogProof(params)
+			if err != nil {
+				return fmt.Errorf("error fetching consistency proof: %w", err)
+			}
+			hashes, err := decodeHashes(consistencyResp.Payload.Hashes)
+			if err != nil {
+				return err
+			}
+			root, err := hex.DecodeString(*proof.RootHash)
+			if err != nil {
+				return err
+			}
+			if err := v.VerifyConsistencyProof(treeSize, int64(lr.TreeSize), root, lr.RootHash, hashes); err != nil {
+				return fmt.Errorf("error verifying consistency proof: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func decodeHashes(hexHashes []string) ([][]byte, error) {
+	hashes := make([][]byte, 0, len(hexHashes))
+	for _, h := range hexHashes {
+		hb, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hb)
+	}
+	return hashes, nil
+}
+
+// loggedKey holds the fields that carry the signing key in the canonical bodies of most kinds; kinds
+// that record it elsewhere are returned without an identity
+type loggedKey struct {
+	Kind       string `json:"kind"`
+	APIVersion string `json:"apiVersion"`
+	Spec       struct {
+		Signature *struct {
+			Format    string `json:"format"`
+			PublicKey *struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+		// rpm entries record a PGP key alongside the package rather than with a signature
+		PublicKey *struct {
+			Content []byte `json:"content"`
+		} `json:"publicKey"`
+	} `json:"spec"`
+}
+
+func parseEntry(body []byte, integratedTime int64) (*Entry, error) {
+	var lk loggedKey
+	if err := json.Unmarshal(body, &lk); err != nil {
+		return nil, fmt.Errorf("error parsing entry body: %w", err)
+	}
+	entry := &Entry{Kind: lk.Kind, APIVersion: lk.APIVersion, IntegratedTime: integratedTime}
+
+	var format string
+	var content []byte
+	switch {
+	case lk.Spec.Signature != nil && lk.Spec.Signature.PublicKey != nil:
+		format, content = lk.Spec.Signature.Format, lk.Spec.Signature.PublicKey.Content
+	case lk.Spec.PublicKey != nil && lk.Kind == "rpm":
+		format, content = "pgp", lk.Spec.PublicKey.Content
+	}
+	if format == "" || len(content) == 0 {
+		return entry, nil
+	}
+
+	key, err := pki.NewArtifactFactory(format).NewPublicKey(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key of entry: %w", err)
+	}
+	canonicalKey, err := key.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+	keyHash := sha256.Sum256(canonicalKey)
+	entry.Identity = &Identity{
+		Format:    format,
+		KeySHA256: hex.EncodeToString(keyHash[:]),
+	}
+	if pk, ok := key.(principalKey); ok {
+		entry.Identity.Principals = pk.Principals()
+	}
+	if ek, ok := key.(emailKey); ok {
+		entry.Identity.Emails = ek.Emails()
+	}
+	if kk, ok := key.(keyIDKey); ok {
+		entry.Identity.KeyID = kk.KeyID()
+	}
+	if ck, ok := key.(certificateKey); ok {
+		entry.Identity.URIs = ck.URIs()
+		entry.Identity.Issuer = ck.Issuer()
+	}
+	if vk, ok := key.(validityKey); ok && format == "x509" {
+		valid := vk.ValidAt(time.Unix(integratedTime, 0)) == nil
+		entry.Identity.ValidAtIntegration = &valid
+	}
+	return entry, nil
+}
+
+// ServeHTTP implements the external data provider protocol; a failed lookup is reported as the error
+// of its item, so that policies can decide whether to fail open or closed for each key
+func (p *Provider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := ProviderResponse{
+		APIVersion: APIVersion,
+		Kind:       responseKind,
+		Response:   Response{Items: []Item{}},
+	}
+	code := http.StatusOK
+
+	var req ProviderRequest
+	switch {
+	case r.Method != http.MethodPost:
+		code, resp.Response.SystemError = http.StatusMethodNotAllowed, "only POST is supported"
+	case json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req) != nil:
+		code, resp.Response.SystemError = http.StatusBadRequest, "error parsing request"
+	case req.APIVersion != APIVersion || req.Kind != requestKind:
+		code, resp.Response.SystemError = http.StatusBadRequest, fmt.Sprintf("expected a %v of %v", requestKind, APIVersion)
+	default:
+		for _, key := range req.Request.Keys {
+			item := Item{Key: key}
+			result, err := p.Lookup(r.Context(), key)
+			if err != nil {
+				log.RequestIDLogger(r).Errorw("external data lookup failed", "key", key, "error", err)
+				item.Error = err.Error()
+			} else {
+				item.Value = result
+			}
+			resp.Response.Items = append(resp.Response.Items, item)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.RequestIDLogger(r).Error(err)
+	}
+}
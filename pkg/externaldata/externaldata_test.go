@@ -0,0 +1,310 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldata
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	tcrypto "github.com/google/trillian/crypto"
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/google/trillian/types"
+	"github.com/sigstore/rekor/pkg/generated/client"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/crypto/ssh"
+)
+
+// fakeLog serves a log of two leaves, the first of which is the entry of digest
+type fakeLog struct {
+	digest    string
+	body      []byte
+	other     []byte
+	signer    *tcrypto.Signer
+	proofSize int64
+	badRoot   bool
+	searches  int32
+}
+
+func (f *fakeLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	leaf := rfc6962.DefaultHasher.HashLeaf(f.body)
+	otherLeaf := rfc6962.DefaultHasher.HashLeaf(f.other)
+	uuid := hex.EncodeToString(leaf)
+	root := rfc6962.DefaultHasher.HashChildren(leaf, otherLeaf)
+	if f.badRoot {
+		root = otherLeaf
+	}
+
+	var resp interface{}
+	switch {
+	case r.URL.Path == "/api/v1/index/retrieve":
+		atomic.AddInt32(&f.searches, 1)
+		var query models.SearchIndex
+		if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resp = []string{}
+		if query.Hash == f.digest {
+			resp = []string{uuid}
+		}
+	case r.URL.Path == "/api/v1/log/entries/"+uuid:
+		resp = models.LogEntry{uuid: models.LogEntryAnon{
+			Body:           base64.StdEncoding.EncodeToString(f.body),
+			LogIndex:       swag.Int64(0),
+			IntegratedTime: 1234,
+		}}
+	case r.URL.Path == "/api/v1/log/entries/"+uuid+"/proof":
+		// a proof against the first tree head only has the leaf itself as the root
+		proof := models.InclusionProof{LogIndex: swag.Int64(0), TreeSize: swag.Int64(f.proofSize), Hashes: []string{}}
+		if f.proofSize == 1 {
+			proof.RootHash = swag.String(hex.EncodeToString(leaf))
+		} else {
+			proof.RootHash = swag.String(hex.EncodeToString(rfc6962.DefaultHasher.HashChildren(leaf, otherLeaf)))
+			proof.Hashes = []string{hex.EncodeToString(otherLeaf)}
+		}
+		resp = proof
+	case r.URL.Path == "/api/v1/log":
+		slr, err := f.signer.SignLogRoot(&types.LogRootV1{TreeSize: 2, RootHash: root})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp = models.LogInfo{
+			RootHash: swag.String(hex.EncodeToString(root)),
+			TreeSize: swag.Int64(2),
+			SignedTreeHead: &models.LogInfoSignedTreeHead{
+				KeyHint:   (*strfmt.Base64)(&slr.KeyHint),
+				LogRoot:   (*strfmt.Base64)(&slr.LogRoot),
+				Signature: (*strfmt.Base64)(&slr.LogRootSignature),
+			},
+		}
+	case r.URL.Path == "/api/v1/log/proof":
+		resp = models.ConsistencyProof{
+			RootHash: swag.String(hex.EncodeToString(root)),
+			Hashes:   []string{hex.EncodeToString(otherLeaf)},
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newTestProvider(t *testing.T, f *fakeLog, ttl time.Duration) *Provider {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.signer = tcrypto.NewSHA256Signer(priv)
+
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rekorClient := client.New(httptransport.New(u.Host, client.DefaultBasePath, []string{u.Scheme}), strfmt.Default)
+	return NewProvider(rekorClient, priv.Public(), ttl)
+}
+
+func TestLookup(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(edPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := ssh.MarshalAuthorizedKey(sshPub)
+	body, err := json.Marshal(map[string]interface{}{
+		"kind":       "rekord",
+		"apiVersion": "0.0.1",
+		"spec": map[string]interface{}{
+			"signature": map[string]interface{}{
+				"format":    "ssh",
+				"content":   []byte("sig"),
+				"publicKey": map[string]interface{}{"content": pub},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := strings.Repeat("ab", 32)
+	keyHash := sha256.Sum256(pub)
+
+	tests := []struct {
+		caseDesc      string
+		key           string
+		proofSize     int64
+		badRoot       bool
+		expectEntries int
+		expectSuccess bool
+	}{
+		{caseDesc: "proof against current tree head", key: digest, proofSize: 2, expectEntries: 1, expectSuccess: true},
+		{caseDesc: "proof against earlier tree head", key: "sha256:" + digest, proofSize: 1, expectEntries: 1, expectSuccess: true},
+		{caseDesc: "image reference", key: "registry.example/app@sha256:" + strings.ToUpper(digest), proofSize: 2, expectEntries: 1, expectSuccess: true},
+		{caseDesc: "digest without entries", key: strings.Repeat("cd", 32), proofSize: 2, expectEntries: 0, expectSuccess: true},
+		{caseDesc: "tree head does not match proof", key: digest, proofSize: 2, badRoot: true, expectSuccess: false},
+		{caseDesc: "not a digest", key: "registry.example/app:latest", proofSize: 2, expectSuccess: false},
+	}
+
+	for _, tc := range tests {
+		f := &fakeLog{digest: digest, body: body, other: []byte("other"), proofSize: tc.proofSize, badRoot: tc.badRoot}
+		p := newTestProvider(t, f, 0)
+		entries, err := p.Lookup(context.Background(), tc.key)
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if len(entries) != tc.expectEntries {
+			t.Errorf("unexpected number of entries in '%v': %v", tc.caseDesc, len(entries))
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		e := entries[0]
+		if e.Kind != "rekord" || e.IntegratedTime != 1234 || e.Identity == nil {
+			t.Errorf("unexpected entry in '%v': %+v", tc.caseDesc, e)
+		} else if e.Identity.Format != "ssh" || e.Identity.KeySHA256 != hex.EncodeToString(keyHash[:]) {
+			t.Errorf("unexpected identity in '%v': %+v", tc.caseDesc, e.Identity)
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	f := &fakeLog{digest: strings.Repeat("ab", 32), body: []byte(`{"kind":"kmod","apiVersion":"0.0.1","spec":{}}`), other: []byte("other"), proofSize: 2}
+	p := newTestProvider(t, f, time.Minute)
+
+	post := func(body string) (int, ProviderResponse) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+		var resp ProviderResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Code, resp
+	}
+
+	req := `{"apiVersion":"externaldata.gatekeeper.sh/v1alpha1","kind":"ProviderRequest","request":{"keys":["` + f.digest + `","not-a-digest"]}}`
+	code, resp := post(req)
+	if code != http.StatusOK || resp.Response.SystemError != "" || len(resp.Response.Items) != 2 {
+		t.Fatalf("unexpected response: %v %+v", code, resp)
+	}
+	if resp.Response.Items[0].Error != "" || resp.Response.Items[1].Error == "" {
+		t.Errorf("unexpected items: %+v", resp.Response.Items)
+	}
+	if resp.Kind != responseKind || resp.APIVersion != APIVersion {
+		t.Errorf("unexpected response type: %v %v", resp.APIVersion, resp.Kind)
+	}
+
+	// the second lookup of the digest is served from the cache
+	if _, resp := post(req); resp.Response.Items[0].Error != "" {
+		t.Errorf("unexpected error: %v", resp.Response.Items[0].Error)
+	}
+	if searches := atomic.LoadInt32(&f.searches); searches != 1 {
+		t.Errorf("expected one search of the log, got %v", searches)
+	}
+
+	if code, resp := post(`{"apiVersion":"v1","kind":"Pod"}`); code != http.StatusBadRequest || resp.Response.SystemError == "" {
+		t.Errorf("unexpected response to wrong request type: %v %+v", code, resp)
+	}
+	if code, _ := post(`{`); code != http.StatusBadRequest {
+		t.Errorf("unexpected response to malformed request: %v", code)
+	}
+}
+
+func TestParseEntryValidity(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issued := time.Unix(1625000000, 0)
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		NotBefore:      issued,
+		NotAfter:       issued.Add(10 * time.Minute),
+		EmailAddresses: []string{"alice@example.com"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, priv.Public(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"kind":       "rekord",
+		"apiVersion": "0.0.1",
+		"spec": map[string]interface{}{
+			"signature": map[string]interface{}{
+				"format":    "x509",
+				"publicKey": map[string]interface{}{"content": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caseDesc       string
+		integratedTime time.Time
+		valid          bool
+	}{
+		{caseDesc: "integrated while valid", integratedTime: issued.Add(time.Minute), valid: true},
+		{caseDesc: "integrated after expiry", integratedTime: issued.Add(time.Hour), valid: false},
+		{caseDesc: "integrated before issuance", integratedTime: issued.Add(-time.Minute), valid: false},
+	}
+	for _, tc := range tests {
+		entry, err := parseEntry(body, tc.integratedTime.Unix())
+		if err != nil {
+			t.Fatalf("%v: %v", tc.caseDesc, err)
+		}
+		if entry.Identity == nil || entry.Identity.ValidAtIntegration == nil {
+			t.Fatalf("%v: validity not reported", tc.caseDesc)
+		}
+		if *entry.Identity.ValidAtIntegration != tc.valid {
+			t.Errorf("%v: validAtIntegration = %v, want %v", tc.caseDesc, *entry.Identity.ValidAtIntegration, tc.valid)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 )
@@ -87,6 +88,9 @@ type CreateLogEntryCreated struct {
 	/*URI location of log entry
 	 */
 	Location strfmt.URI
+	/*Comma-separated UUIDs of existing entries that logged the same signature under a different public key
+	 */
+	XRekorSignatureReuse string
 
 	Payload models.LogEntry
 }
@@ -112,6 +116,9 @@ func (o *CreateLogEntryCreated) readResponse(response runtime.ClientResponse, co
 	}
 	o.Location = *(location.(*strfmt.URI))
 
+	// response header X-Rekor-Signature-Reuse
+	o.XRekorSignatureReuse = response.GetHeader("X-Rekor-Signature-Reuse")
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -163,7 +170,14 @@ func NewCreateLogEntryConflict() *CreateLogEntryConflict {
 The request conflicts with the current state of the transparency log
 */
 type CreateLogEntryConflict struct {
+	/*UUID of the existing entry
+	 */
+	ETag string
+
 	Location strfmt.URI
+	/*Log index of the existing entry
+	 */
+	XRekorLogIndex int64
 
 	Payload *models.Error
 }
@@ -178,6 +192,9 @@ func (o *CreateLogEntryConflict) GetPayload() *models.Error {
 
 func (o *CreateLogEntryConflict) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// response header ETag
+	o.ETag = response.GetHeader("ETag")
+
 	// response header Location
 
 	location, err := formats.Parse("uri", response.GetHeader("Location"))
@@ -186,6 +203,15 @@ func (o *CreateLogEntryConflict) readResponse(response runtime.ClientResponse, c
 	}
 	o.Location = *(location.(*strfmt.URI))
 
+	// response header X-Rekor-Log-Index
+	if hdrXRekorLogIndex := response.GetHeader("X-Rekor-Log-Index"); hdrXRekorLogIndex != "" {
+		xRekorLogIndex, err := swag.ConvertInt64(hdrXRekorLogIndex)
+		if err != nil {
+			return errors.InvalidType("X-Rekor-Log-Index", "header", "int64", hdrXRekorLogIndex)
+		}
+		o.XRekorLogIndex = xRekorLogIndex
+	}
+
 	o.Payload = new(models.Error)
 
 	// response payload
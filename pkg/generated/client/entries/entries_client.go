@@ -42,19 +42,71 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	CreateLogEntries(params *CreateLogEntriesParams) (*CreateLogEntriesOK, error)
+
 	CreateLogEntry(params *CreateLogEntryParams) (*CreateLogEntryCreated, error)
 
+	GetLogEntriesByRange(params *GetLogEntriesByRangeParams) (*GetLogEntriesByRangeOK, error)
+
 	GetLogEntryByIndex(params *GetLogEntryByIndexParams) (*GetLogEntryByIndexOK, error)
 
 	GetLogEntryByUUID(params *GetLogEntryByUUIDParams) (*GetLogEntryByUUIDOK, error)
 
+	GetLogEntryIndexesByTime(params *GetLogEntryIndexesByTimeParams) (*GetLogEntryIndexesByTimeOK, error)
+
 	GetLogEntryProof(params *GetLogEntryProofParams) (*GetLogEntryProofOK, error)
 
+	GetLogEntryProofByLeaf(params *GetLogEntryProofByLeafParams) (*GetLogEntryProofByLeafOK, error)
+
+	GetLogEntryV2ByIndex(params *GetLogEntryV2ByIndexParams) (*GetLogEntryV2ByIndexOK, error)
+
+	GetLogEntryV2ByUUID(params *GetLogEntryV2ByUUIDParams) (*GetLogEntryV2ByUUIDOK, error)
+
 	SearchLogQuery(params *SearchLogQueryParams) (*SearchLogQueryOK, error)
 
+	StreamLogEntries(params *StreamLogEntriesParams) (*StreamLogEntriesOK, error)
+
+	ValidateLogEntry(params *ValidateLogEntryParams) (*ValidateLogEntryOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  CreateLogEntries creates a batch of entries in the transparency log
+
+  Creates an entry in the transparency log for each of the proposed entries, queueing them together. The result of each entry is returned in the order the entries were given; an entry that is rejected does not prevent the others from being created.
+
+*/
+func (a *Client) CreateLogEntries(params *CreateLogEntriesParams) (*CreateLogEntriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewCreateLogEntriesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "createLogEntries",
+		Method:             "POST",
+		PathPattern:        "/api/v1/log/entries/batch",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &CreateLogEntriesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*CreateLogEntriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*CreateLogEntriesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   CreateLogEntry creates an entry in the transparency log
 
@@ -91,6 +143,42 @@ func (a *Client) CreateLogEntry(params *CreateLogEntryParams) (*CreateLogEntryCr
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetLogEntriesByRange retrieves consecutive entries from the transparency log by index
+
+  Returns up to count consecutive entries starting at the entry with index start, in index order. Fewer entries are returned when the log ends before the range does, so clients tailing the log should continue from the index after the last entry returned.
+
+*/
+func (a *Client) GetLogEntriesByRange(params *GetLogEntriesByRangeParams) (*GetLogEntriesByRangeOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogEntriesByRangeParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogEntriesByRange",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/entries/range",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogEntriesByRangeReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogEntriesByRangeOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogEntriesByRangeDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetLogEntryByIndex retrieves an entry from the transparency log if it exists by index
 */
@@ -104,7 +192,7 @@ func (a *Client) GetLogEntryByIndex(params *GetLogEntryByIndexParams) (*GetLogEn
 		ID:                 "getLogEntryByIndex",
 		Method:             "GET",
 		PathPattern:        "/api/v1/log/entries",
-		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml", "application/cbor", "application/x-protobuf"},
 		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
 		Schemes:            []string{"http"},
 		Params:             params,
@@ -137,7 +225,7 @@ func (a *Client) GetLogEntryByUUID(params *GetLogEntryByUUIDParams) (*GetLogEntr
 		ID:                 "getLogEntryByUUID",
 		Method:             "GET",
 		PathPattern:        "/api/v1/log/entries/{entryUUID}",
-		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml", "application/cbor", "application/x-protobuf"},
 		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
 		Schemes:            []string{"http"},
 		Params:             params,
@@ -157,6 +245,42 @@ func (a *Client) GetLogEntryByUUID(params *GetLogEntryByUUIDParams) (*GetLogEntr
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetLogEntryIndexesByTime lists the indexes of entries integrated into the log within a time range
+
+  Returns the log indexes of the entries integrated into the log between start and end inclusive, ordered by integrated time. At most limit indexes are returned, so clients should request the next page with offset increased by the number of indexes returned until fewer than limit are. Only entries added while the search index was enabled are included.
+
+*/
+func (a *Client) GetLogEntryIndexesByTime(params *GetLogEntryIndexesByTimeParams) (*GetLogEntryIndexesByTimeOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogEntryIndexesByTimeParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogEntryIndexesByTime",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/entries/time",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogEntryIndexesByTimeReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogEntryIndexesByTimeOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogEntryIndexesByTimeDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetLogEntryProof gets information required to generate an inclusion proof for a specified entry in the transparency log
 
@@ -192,6 +316,111 @@ func (a *Client) GetLogEntryProof(params *GetLogEntryProofParams) (*GetLogEntryP
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetLogEntryProofByLeaf gets information required to generate an inclusion proof for an entry identified by its leaf hash or canonicalized content
+
+  Returns root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log, for the entry with the given merkle leaf hash or with the given canonicalized content. Clients that only kept the entry they submitted can use it to prove inclusion without knowing its UUID or log index.
+*/
+func (a *Client) GetLogEntryProofByLeaf(params *GetLogEntryProofByLeafParams) (*GetLogEntryProofByLeafOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogEntryProofByLeafParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogEntryProofByLeaf",
+		Method:             "POST",
+		PathPattern:        "/api/v1/log/entries/proof",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogEntryProofByLeafReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogEntryProofByLeafOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogEntryProofByLeafDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  GetLogEntryV2ByIndex retrieves an entry from the transparency log by index with its body typed by kind
+
+  Returns the entry in the v2 format, which holds the body of the entry in a property named after its kind with the schema of that kind rather than as a generic spec, along with the canonical JSON the UUID of the entry is computed from. The v1 endpoints continue to return entries in the v1 format.
+*/
+func (a *Client) GetLogEntryV2ByIndex(params *GetLogEntryV2ByIndexParams) (*GetLogEntryV2ByIndexOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogEntryV2ByIndexParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogEntryV2ByIndex",
+		Method:             "GET",
+		PathPattern:        "/api/v2/log/entries",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogEntryV2ByIndexReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogEntryV2ByIndexOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogEntryV2ByIndexDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  GetLogEntryV2ByUUID retrieves an entry from the transparency log by UUID with its body typed by kind
+
+  Returns the entry in the v2 format, which holds the body of the entry in a property named after its kind with the schema of that kind rather than as a generic spec, along with the canonical JSON the UUID of the entry is computed from. The v1 endpoints continue to return entries in the v1 format.
+*/
+func (a *Client) GetLogEntryV2ByUUID(params *GetLogEntryV2ByUUIDParams) (*GetLogEntryV2ByUUIDOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogEntryV2ByUUIDParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogEntryV2ByUUID",
+		Method:             "GET",
+		PathPattern:        "/api/v2/log/entries/{entryUUID}",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogEntryV2ByUUIDReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogEntryV2ByUUIDOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogEntryV2ByUUIDDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   SearchLogQuery searches transparency log for one or more log entries
 */
@@ -225,6 +454,77 @@ func (a *Client) SearchLogQuery(params *SearchLogQueryParams) (*SearchLogQueryOK
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  StreamLogEntries streams the entries integrated into the transparency log as server sent events
+
+  Sends an event for each entry as it is integrated into the log, in index order. Each event carries the log index of the entry as its id and the UUID, kind, API version and integrated time of the entry as JSON data. The stream starts at the entry with index start, or after the entry named by Last-Event-ID when a client reconnects, or otherwise with the next entry to be integrated.
+
+*/
+func (a *Client) StreamLogEntries(params *StreamLogEntriesParams) (*StreamLogEntriesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewStreamLogEntriesParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "streamLogEntries",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/stream",
+		ProducesMediaTypes: []string{"text/event-stream"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &StreamLogEntriesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*StreamLogEntriesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*StreamLogEntriesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+  ValidateLogEntry checks an entry and returns the UUID it would have without adding it to the transparency log
+
+  Runs the same checks as creating the entry, fetching any external entities and verifying its signature, and returns the canonical entry with the UUID it would be added to the log as, so that clients can check entries before submitting them. Nothing is added to the log.
+*/
+func (a *Client) ValidateLogEntry(params *ValidateLogEntryParams) (*ValidateLogEntryOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewValidateLogEntryParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "validateLogEntry",
+		Method:             "POST",
+		PathPattern:        "/api/v1/log/entries/validate",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ValidateLogEntryReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ValidateLogEntryOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ValidateLogEntryDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
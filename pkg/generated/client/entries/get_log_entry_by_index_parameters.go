@@ -78,6 +78,16 @@ for the get log entry by index operation typically these are written to a http.R
 */
 type GetLogEntryByIndexParams struct {
 
+	/*IfNoneMatch
+	  the ETag of a copy of the entry held by the client; the entry is not returned if it still matches
+
+	*/
+	IfNoneMatch *string
+	/*IncludeProof
+	  whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests
+
+	*/
+	IncludeProof *bool
 	/*LogIndex
 	  specifies the index of the entry in the transparency log to be retrieved
 
@@ -122,6 +132,17 @@ func (o *GetLogEntryByIndexParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIncludeProof adds the includeProof to the get log entry by index params
+func (o *GetLogEntryByIndexParams) WithIncludeProof(includeProof *bool) *GetLogEntryByIndexParams {
+	o.SetIncludeProof(includeProof)
+	return o
+}
+
+// SetIncludeProof adds the includeProof to the get log entry by index params
+func (o *GetLogEntryByIndexParams) SetIncludeProof(includeProof *bool) {
+	o.IncludeProof = includeProof
+}
+
 // WithLogIndex adds the logIndex to the get log entry by index params
 func (o *GetLogEntryByIndexParams) WithLogIndex(logIndex int64) *GetLogEntryByIndexParams {
 	o.SetLogIndex(logIndex)
@@ -133,6 +154,17 @@ func (o *GetLogEntryByIndexParams) SetLogIndex(logIndex int64) {
 	o.LogIndex = logIndex
 }
 
+// WithIfNoneMatch adds the ifNoneMatch to the get log entry by index params
+func (o *GetLogEntryByIndexParams) WithIfNoneMatch(ifNoneMatch *string) *GetLogEntryByIndexParams {
+	o.SetIfNoneMatch(ifNoneMatch)
+	return o
+}
+
+// SetIfNoneMatch adds the ifNoneMatch to the get log entry by index params
+func (o *GetLogEntryByIndexParams) SetIfNoneMatch(ifNoneMatch *string) {
+	o.IfNoneMatch = ifNoneMatch
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetLogEntryByIndexParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -141,6 +173,31 @@ func (o *GetLogEntryByIndexParams) WriteToRequest(r runtime.ClientRequest, reg s
 	}
 	var res []error
 
+	if o.IfNoneMatch != nil {
+
+		// header param If-None-Match
+		if err := r.SetHeaderParam("If-None-Match", *o.IfNoneMatch); err != nil {
+			return err
+		}
+
+	}
+
+	if o.IncludeProof != nil {
+
+		// query param includeProof
+		var qrIncludeProof bool
+		if o.IncludeProof != nil {
+			qrIncludeProof = *o.IncludeProof
+		}
+		qIncludeProof := swag.FormatBool(qrIncludeProof)
+		if qIncludeProof != "" {
+			if err := r.SetQueryParam("includeProof", qIncludeProof); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	// query param logIndex
 	qrLogIndex := o.LogIndex
 	qLogIndex := swag.FormatInt64(qrLogIndex)
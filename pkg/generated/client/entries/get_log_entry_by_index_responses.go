@@ -46,6 +46,12 @@ func (o *GetLogEntryByIndexReader) ReadResponse(response runtime.ClientResponse,
 			return nil, err
 		}
 		return result, nil
+	case 304:
+		result := NewGetLogEntryByIndexNotModified()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 404:
 		result := NewGetLogEntryByIndexNotFound()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -74,6 +80,10 @@ func NewGetLogEntryByIndexOK() *GetLogEntryByIndexOK {
 the entry in the transparency log requested
 */
 type GetLogEntryByIndexOK struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+	 */
+	ETag string
+
 	Payload models.LogEntry
 }
 
@@ -87,6 +97,9 @@ func (o *GetLogEntryByIndexOK) GetPayload() models.LogEntry {
 
 func (o *GetLogEntryByIndexOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// response header ETag
+	o.ETag = response.GetHeader("ETag")
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -95,6 +108,33 @@ func (o *GetLogEntryByIndexOK) readResponse(response runtime.ClientResponse, con
 	return nil
 }
 
+// NewGetLogEntryByIndexNotModified creates a GetLogEntryByIndexNotModified with default headers values
+func NewGetLogEntryByIndexNotModified() *GetLogEntryByIndexNotModified {
+	return &GetLogEntryByIndexNotModified{}
+}
+
+/*GetLogEntryByIndexNotModified handles this case with default header values.
+
+the entry has not changed since the copy with the ETag in If-None-Match was retrieved
+*/
+type GetLogEntryByIndexNotModified struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+	 */
+	ETag string
+}
+
+func (o *GetLogEntryByIndexNotModified) Error() string {
+	return fmt.Sprintf("[GET /api/v1/log/entries][%d] getLogEntryByIndexNotModified ", 304)
+}
+
+func (o *GetLogEntryByIndexNotModified) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header ETag
+	o.ETag = response.GetHeader("ETag")
+
+	return nil
+}
+
 // NewGetLogEntryByIndexNotFound creates a GetLogEntryByIndexNotFound with default headers values
 func NewGetLogEntryByIndexNotFound() *GetLogEntryByIndexNotFound {
 	return &GetLogEntryByIndexNotFound{}
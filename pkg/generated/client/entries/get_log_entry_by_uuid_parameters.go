@@ -31,6 +31,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewGetLogEntryByUUIDParams creates a new GetLogEntryByUUIDParams object
@@ -77,11 +78,21 @@ for the get log entry by UUID operation typically these are written to a http.Re
 */
 type GetLogEntryByUUIDParams struct {
 
+	/*IfNoneMatch
+	  the ETag of a copy of the entry held by the client; the entry is not returned if it still matches
+
+	*/
+	IfNoneMatch *string
 	/*EntryUUID
 	  the UUID of the entry to be retrieved from the log. The UUID is also the merkle tree hash of the entry.
 
 	*/
 	EntryUUID string
+	/*IncludeProof
+	  whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests
+
+	*/
+	IncludeProof *bool
 
 	timeout    time.Duration
 	Context    context.Context
@@ -132,6 +143,28 @@ func (o *GetLogEntryByUUIDParams) SetEntryUUID(entryUUID string) {
 	o.EntryUUID = entryUUID
 }
 
+// WithIncludeProof adds the includeProof to the get log entry by UUID params
+func (o *GetLogEntryByUUIDParams) WithIncludeProof(includeProof *bool) *GetLogEntryByUUIDParams {
+	o.SetIncludeProof(includeProof)
+	return o
+}
+
+// SetIncludeProof adds the includeProof to the get log entry by UUID params
+func (o *GetLogEntryByUUIDParams) SetIncludeProof(includeProof *bool) {
+	o.IncludeProof = includeProof
+}
+
+// WithIfNoneMatch adds the ifNoneMatch to the get log entry by UUID params
+func (o *GetLogEntryByUUIDParams) WithIfNoneMatch(ifNoneMatch *string) *GetLogEntryByUUIDParams {
+	o.SetIfNoneMatch(ifNoneMatch)
+	return o
+}
+
+// SetIfNoneMatch adds the ifNoneMatch to the get log entry by UUID params
+func (o *GetLogEntryByUUIDParams) SetIfNoneMatch(ifNoneMatch *string) {
+	o.IfNoneMatch = ifNoneMatch
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetLogEntryByUUIDParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -140,11 +173,36 @@ func (o *GetLogEntryByUUIDParams) WriteToRequest(r runtime.ClientRequest, reg st
 	}
 	var res []error
 
+	if o.IfNoneMatch != nil {
+
+		// header param If-None-Match
+		if err := r.SetHeaderParam("If-None-Match", *o.IfNoneMatch); err != nil {
+			return err
+		}
+
+	}
+
 	// path param entryUUID
 	if err := r.SetPathParam("entryUUID", o.EntryUUID); err != nil {
 		return err
 	}
 
+	if o.IncludeProof != nil {
+
+		// query param includeProof
+		var qrIncludeProof bool
+		if o.IncludeProof != nil {
+			qrIncludeProof = *o.IncludeProof
+		}
+		qIncludeProof := swag.FormatBool(qrIncludeProof)
+		if qIncludeProof != "" {
+			if err := r.SetQueryParam("includeProof", qIncludeProof); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -46,6 +46,12 @@ func (o *GetLogEntryByUUIDReader) ReadResponse(response runtime.ClientResponse,
 			return nil, err
 		}
 		return result, nil
+	case 304:
+		result := NewGetLogEntryByUUIDNotModified()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 404:
 		result := NewGetLogEntryByUUIDNotFound()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -74,6 +80,10 @@ func NewGetLogEntryByUUIDOK() *GetLogEntryByUUIDOK {
 the entry in the transparency log requested
 */
 type GetLogEntryByUUIDOK struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+	 */
+	ETag string
+
 	Payload models.LogEntry
 }
 
@@ -87,6 +97,9 @@ func (o *GetLogEntryByUUIDOK) GetPayload() models.LogEntry {
 
 func (o *GetLogEntryByUUIDOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// response header ETag
+	o.ETag = response.GetHeader("ETag")
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -95,6 +108,33 @@ func (o *GetLogEntryByUUIDOK) readResponse(response runtime.ClientResponse, cons
 	return nil
 }
 
+// NewGetLogEntryByUUIDNotModified creates a GetLogEntryByUUIDNotModified with default headers values
+func NewGetLogEntryByUUIDNotModified() *GetLogEntryByUUIDNotModified {
+	return &GetLogEntryByUUIDNotModified{}
+}
+
+/*GetLogEntryByUUIDNotModified handles this case with default header values.
+
+the entry has not changed since the copy with the ETag in If-None-Match was retrieved
+*/
+type GetLogEntryByUUIDNotModified struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+	 */
+	ETag string
+}
+
+func (o *GetLogEntryByUUIDNotModified) Error() string {
+	return fmt.Sprintf("[GET /api/v1/log/entries/{entryUUID}][%d] getLogEntryByUuidNotModified ", 304)
+}
+
+func (o *GetLogEntryByUUIDNotModified) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	// response header ETag
+	o.ETag = response.GetHeader("ETag")
+
+	return nil
+}
+
 // NewGetLogEntryByUUIDNotFound creates a GetLogEntryByUUIDNotFound with default headers values
 func NewGetLogEntryByUUIDNotFound() *GetLogEntryByUUIDNotFound {
 	return &GetLogEntryByUUIDNotFound{}
@@ -74,6 +74,10 @@ func NewSearchIndexOK() *SearchIndexOK {
 Returns zero or more entry UUIDs from the transparency log based on search query
 */
 type SearchIndexOK struct {
+	/*Token to pass as continuationToken to retrieve the next page of results, if there are more
+	 */
+	XRekorContinuationToken string
+
 	Payload []string
 }
 
@@ -87,6 +91,9 @@ func (o *SearchIndexOK) GetPayload() []string {
 
 func (o *SearchIndexOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// response header X-Rekor-Continuation-Token
+	o.XRekorContinuationToken = response.GetHeader("X-Rekor-Continuation-Token")
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
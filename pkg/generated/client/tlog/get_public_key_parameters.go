@@ -36,7 +36,7 @@ import (
 // NewGetPublicKeyParams creates a new GetPublicKeyParams object
 // with the default values initialized.
 func NewGetPublicKeyParams() *GetPublicKeyParams {
-
+	var ()
 	return &GetPublicKeyParams{
 
 		timeout: cr.DefaultTimeout,
@@ -46,7 +46,7 @@ func NewGetPublicKeyParams() *GetPublicKeyParams {
 // NewGetPublicKeyParamsWithTimeout creates a new GetPublicKeyParams object
 // with the default values initialized, and the ability to set a timeout on a request
 func NewGetPublicKeyParamsWithTimeout(timeout time.Duration) *GetPublicKeyParams {
-
+	var ()
 	return &GetPublicKeyParams{
 
 		timeout: timeout,
@@ -56,7 +56,7 @@ func NewGetPublicKeyParamsWithTimeout(timeout time.Duration) *GetPublicKeyParams
 // NewGetPublicKeyParamsWithContext creates a new GetPublicKeyParams object
 // with the default values initialized, and the ability to set a context for a request
 func NewGetPublicKeyParamsWithContext(ctx context.Context) *GetPublicKeyParams {
-
+	var ()
 	return &GetPublicKeyParams{
 
 		Context: ctx,
@@ -66,7 +66,7 @@ func NewGetPublicKeyParamsWithContext(ctx context.Context) *GetPublicKeyParams {
 // NewGetPublicKeyParamsWithHTTPClient creates a new GetPublicKeyParams object
 // with the default values initialized, and the ability to set a custom HTTPClient for a request
 func NewGetPublicKeyParamsWithHTTPClient(client *http.Client) *GetPublicKeyParams {
-
+	var ()
 	return &GetPublicKeyParams{
 		HTTPClient: client,
 	}
@@ -76,6 +76,13 @@ func NewGetPublicKeyParamsWithHTTPClient(client *http.Client) *GetPublicKeyParam
 for the get public key operation typically these are written to a http.Request
 */
 type GetPublicKeyParams struct {
+
+	/*KeyHint
+	  the base64-encoded key hint of the signed tree head to be validated, to retrieve the key it was produced with rather than the active one
+
+	*/
+	KeyHint *string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -114,6 +121,17 @@ func (o *GetPublicKeyParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithKeyHint adds the keyHint to the get public key params
+func (o *GetPublicKeyParams) WithKeyHint(keyHint *string) *GetPublicKeyParams {
+	o.SetKeyHint(keyHint)
+	return o
+}
+
+// SetKeyHint adds the keyHint to the get public key params
+func (o *GetPublicKeyParams) SetKeyHint(keyHint *string) {
+	o.KeyHint = keyHint
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *GetPublicKeyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -122,6 +140,22 @@ func (o *GetPublicKeyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.
 	}
 	var res []error
 
+	if o.KeyHint != nil {
+
+		// query param keyHint
+		var qrKeyHint string
+		if o.KeyHint != nil {
+			qrKeyHint = *o.KeyHint
+		}
+		qKeyHint := qrKeyHint
+		if qKeyHint != "" {
+			if err := r.SetQueryParam("keyHint", qKeyHint); err != nil {
+				return err
+			}
+		}
+
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -46,6 +46,18 @@ func (o *GetPublicKeyReader) ReadResponse(response runtime.ClientResponse, consu
 			return nil, err
 		}
 		return result, nil
+	case 400:
+		result := NewGetPublicKeyBadRequest()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
+	case 404:
+		result := NewGetPublicKeyNotFound()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	default:
 		result := NewGetPublicKeyDefault(response.Code())
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -89,6 +101,60 @@ func (o *GetPublicKeyOK) readResponse(response runtime.ClientResponse, consumer
 	return nil
 }
 
+// NewGetPublicKeyBadRequest creates a GetPublicKeyBadRequest with default headers values
+func NewGetPublicKeyBadRequest() *GetPublicKeyBadRequest {
+	return &GetPublicKeyBadRequest{}
+}
+
+/*GetPublicKeyBadRequest handles this case with default header values.
+
+The content supplied to the server was invalid
+*/
+type GetPublicKeyBadRequest struct {
+	Payload *models.Error
+}
+
+func (o *GetPublicKeyBadRequest) Error() string {
+	return fmt.Sprintf("[GET /api/v1/log/publicKey][%d] getPublicKeyBadRequest  %+v", 400, o.Payload)
+}
+
+func (o *GetPublicKeyBadRequest) GetPayload() *models.Error {
+	return o.Payload
+}
+
+func (o *GetPublicKeyBadRequest) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	o.Payload = new(models.Error)
+
+	// response payload
+	if err := consumer.Consume(response.Body(), o.Payload); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
+
+// NewGetPublicKeyNotFound creates a GetPublicKeyNotFound with default headers values
+func NewGetPublicKeyNotFound() *GetPublicKeyNotFound {
+	return &GetPublicKeyNotFound{}
+}
+
+/*GetPublicKeyNotFound handles this case with default header values.
+
+The content requested could not be found
+*/
+type GetPublicKeyNotFound struct {
+}
+
+func (o *GetPublicKeyNotFound) Error() string {
+	return fmt.Sprintf("[GET /api/v1/log/publicKey][%d] getPublicKeyNotFound ", 404)
+}
+
+func (o *GetPublicKeyNotFound) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
 // NewGetPublicKeyDefault creates a GetPublicKeyDefault with default headers values
 func NewGetPublicKeyDefault(code int) *GetPublicKeyDefault {
 	return &GetPublicKeyDefault{
@@ -42,15 +42,56 @@ type Client struct {
 
 // ClientService is the interface for Client methods
 type ClientService interface {
+	GetLogCheckpoint(params *GetLogCheckpointParams) (*GetLogCheckpointOK, error)
+
 	GetLogInfo(params *GetLogInfoParams) (*GetLogInfoOK, error)
 
 	GetLogProof(params *GetLogProofParams) (*GetLogProofOK, error)
 
+	GetLogStats(params *GetLogStatsParams) (*GetLogStatsOK, error)
+
 	GetPublicKey(params *GetPublicKeyParams) (*GetPublicKeyOK, error)
 
+	GetRekorConfiguration(params *GetRekorConfigurationParams) (*GetRekorConfigurationOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
+/*
+  GetLogCheckpoint gets the current tree head as a signed checkpoint
+
+  Returns the current size and root hash of the log as a checkpoint in the signed note format, with an origin line identifying the log, the tree size, the base64-encoded root hash and the signature of the log, for witnesses and verifiers that consume checkpoints.
+*/
+func (a *Client) GetLogCheckpoint(params *GetLogCheckpointParams) (*GetLogCheckpointOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogCheckpointParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogCheckpoint",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/checkpoint",
+		ProducesMediaTypes: []string{"text/plain"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogCheckpointReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogCheckpointOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogCheckpointDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetLogInfo gets information about the current state of the transparency log
 
@@ -89,7 +130,7 @@ func (a *Client) GetLogInfo(params *GetLogInfoParams) (*GetLogInfoOK, error) {
 /*
   GetLogProof gets information required to generate a consistency proof for the transparency log
 
-  Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log
+  Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log. Both sizes may be earlier than the current size of the log, so that tree heads recorded in the past can be checked against each other
 */
 func (a *Client) GetLogProof(params *GetLogProofParams) (*GetLogProofOK, error) {
 	// TODO: Validate the params before sending
@@ -121,10 +162,46 @@ func (a *Client) GetLogProof(params *GetLogProofParams) (*GetLogProofOK, error)
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetLogStats gets statistics about the entries of the transparency log
+
+  Returns the current size of the log, the number of entries of each kind and percentiles of the time recent entries took to be integrated, so that dashboards need not crawl the log or scrape metrics to compute them.
+*/
+func (a *Client) GetLogStats(params *GetLogStatsParams) (*GetLogStatsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetLogStatsParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getLogStats",
+		Method:             "GET",
+		PathPattern:        "/api/v1/log/stats",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetLogStatsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetLogStatsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetLogStatsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
   GetPublicKey retrieves the public key that can be used to validate the signed tree head
 
-  Returns the public key that can be used to validate the signed tree head
+  Returns the public key that can be used to validate the signed tree head. Once the key of the log has been rotated, the keys that validate tree heads signed before the rotation are returned when their key hint is given
+
 */
 func (a *Client) GetPublicKey(params *GetPublicKeyParams) (*GetPublicKeyOK, error) {
 	// TODO: Validate the params before sending
@@ -156,6 +233,41 @@ func (a *Client) GetPublicKey(params *GetPublicKeyParams) (*GetPublicKeyOK, erro
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+  GetRekorConfiguration retrieves the parameters needed to bootstrap trust in this instance
+
+  Returns the log ID, public keys, shard map and supported API versions of this transparency log
+*/
+func (a *Client) GetRekorConfiguration(params *GetRekorConfigurationParams) (*GetRekorConfigurationOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetRekorConfigurationParams()
+	}
+
+	result, err := a.transport.Submit(&runtime.ClientOperation{
+		ID:                 "getRekorConfiguration",
+		Method:             "GET",
+		PathPattern:        "/.well-known/rekor-configuration",
+		ProducesMediaTypes: []string{"application/json;q=1", "application/yaml"},
+		ConsumesMediaTypes: []string{"application/json", "application/yaml"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetRekorConfigurationReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetRekorConfigurationOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetRekorConfigurationDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
@@ -27,16 +27,40 @@ import (
 	"github.com/go-openapi/swag"
 )
 
-// Error error
+// Error An error, which is also an RFC 7807 problem details object
 //
 // swagger:model Error
 type Error struct {
 
+	// API version of the proposed entry the error was returned for
+	APIVersion string `json:"apiVersion,omitempty"`
+
 	// code
 	Code int64 `json:"code,omitempty"`
 
+	// Explanation of this occurrence of the problem; the same as message
+	Detail string `json:"detail,omitempty"`
+
+	// Path of the field of the proposed entry that failed validation
+	Field string `json:"field,omitempty"`
+
+	// Kind of the proposed entry the error was returned for
+	Kind string `json:"kind,omitempty"`
+
 	// message
 	Message string `json:"message,omitempty"`
+
+	// ID of the request the error was returned for, which is also returned in the X-Request-Id header
+	RequestID string `json:"requestId,omitempty"`
+
+	// HTTP status code of the response; the same as code
+	Status int64 `json:"status,omitempty"`
+
+	// Short summary of the type of problem
+	Title string `json:"title,omitempty"`
+
+	// URI identifying the type of problem, as defined by RFC 7807; clients can rely on it to tell failures apart
+	Type string `json:"type,omitempty"`
 }
 
 // Validate validates this error
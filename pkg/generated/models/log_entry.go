@@ -72,6 +72,9 @@ type LogEntryAnon struct {
 	// log index
 	// Minimum: 0
 	LogIndex *int64 `json:"logIndex,omitempty"`
+
+	// verification
+	Verification *LogEntryAnonVerification `json:"verification,omitempty"`
 }
 
 // Validate validates this log entry anon
@@ -86,6 +89,10 @@ func (m *LogEntryAnon) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateVerification(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -110,6 +117,24 @@ func (m *LogEntryAnon) validateLogIndex(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *LogEntryAnon) validateVerification(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Verification) { // not required
+		return nil
+	}
+
+	if m.Verification != nil {
+		if err := m.Verification.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("verification")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *LogEntryAnon) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -127,3 +152,175 @@ func (m *LogEntryAnon) UnmarshalBinary(b []byte) error {
 	*m = res
 	return nil
 }
+
+// LogEntryAnonVerification log entry anon verification
+//
+// swagger:model LogEntryAnonVerification
+type LogEntryAnonVerification struct {
+
+	// inclusion proof
+	InclusionProof *InclusionProof `json:"inclusionProof,omitempty"`
+
+	// signed tree head
+	SignedTreeHead *LogEntryAnonVerificationSignedTreeHead `json:"signedTreeHead,omitempty"`
+}
+
+// Validate validates this log entry anon verification
+func (m *LogEntryAnonVerification) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateInclusionProof(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignedTreeHead(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *LogEntryAnonVerification) validateInclusionProof(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.InclusionProof) { // not required
+		return nil
+	}
+
+	if m.InclusionProof != nil {
+		if err := m.InclusionProof.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("verification" + "." + "inclusionProof")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *LogEntryAnonVerification) validateSignedTreeHead(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SignedTreeHead) { // not required
+		return nil
+	}
+
+	if m.SignedTreeHead != nil {
+		if err := m.SignedTreeHead.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("verification" + "." + "signedTreeHead")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *LogEntryAnonVerification) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *LogEntryAnonVerification) UnmarshalBinary(b []byte) error {
+	var res LogEntryAnonVerification
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
+// LogEntryAnonVerificationSignedTreeHead The signed tree head the inclusion proof was computed against
+//
+// swagger:model LogEntryAnonVerificationSignedTreeHead
+type LogEntryAnonVerificationSignedTreeHead struct {
+
+	// Key hint
+	// Required: true
+	// Format: byte
+	KeyHint *strfmt.Base64 `json:"keyHint"`
+
+	// Log root
+	// Required: true
+	// Format: byte
+	LogRoot *strfmt.Base64 `json:"logRoot"`
+
+	// Signature for log root
+	// Required: true
+	// Format: byte
+	Signature *strfmt.Base64 `json:"signature"`
+}
+
+// Validate validates this log entry anon verification signed tree head
+func (m *LogEntryAnonVerificationSignedTreeHead) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateKeyHint(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLogRoot(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSignature(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *LogEntryAnonVerificationSignedTreeHead) validateKeyHint(formats strfmt.Registry) error {
+
+	if err := validate.Required("verification"+"."+"signedTreeHead"+"."+"keyHint", "body", m.KeyHint); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *LogEntryAnonVerificationSignedTreeHead) validateLogRoot(formats strfmt.Registry) error {
+
+	if err := validate.Required("verification"+"."+"signedTreeHead"+"."+"logRoot", "body", m.LogRoot); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *LogEntryAnonVerificationSignedTreeHead) validateSignature(formats strfmt.Registry) error {
+
+	if err := validate.Required("verification"+"."+"signedTreeHead"+"."+"signature", "body", m.Signature); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *LogEntryAnonVerificationSignedTreeHead) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *LogEntryAnonVerificationSignedTreeHead) UnmarshalBinary(b []byte) error {
+	var res LogEntryAnonVerificationSignedTreeHead
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -23,6 +23,8 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
@@ -34,6 +36,9 @@ import (
 // swagger:model LogInfo
 type LogInfo struct {
 
+	// The frozen shards of the log, oldest first, whose entries precede those of the tree above in the log index
+	InactiveShards []*InactiveShardLogInfo `json:"inactiveShards"`
+
 	// The current hash value stored at the root of the merkle tree
 	// Required: true
 	// Pattern: ^[0-9a-fA-F]{64}$
@@ -53,6 +58,10 @@ type LogInfo struct {
 func (m *LogInfo) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateInactiveShards(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateRootHash(formats); err != nil {
 		res = append(res, err)
 	}
@@ -71,6 +80,31 @@ func (m *LogInfo) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *LogInfo) validateInactiveShards(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.InactiveShards) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.InactiveShards); i++ {
+		if swag.IsZero(m.InactiveShards[i]) { // not required
+			continue
+		}
+
+		if m.InactiveShards[i] != nil {
+			if err := m.InactiveShards[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("inactiveShards" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *LogInfo) validateRootHash(formats strfmt.Registry) error {
 
 	if err := validate.Required("rootHash", "body", m.RootHash); err != nil {
@@ -24,6 +24,7 @@ package models
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -39,8 +40,7 @@ import (
 type RekordV001Schema struct {
 
 	// data
-	// Required: true
-	Data *RekordV001SchemaData `json:"data"`
+	Data *RekordV001SchemaData `json:"data,omitempty"`
 
 	// Arbitrary content to be included in the verifiable entry in the transparency log
 	ExtraData interface{} `json:"extraData,omitempty"`
@@ -70,8 +70,8 @@ func (m *RekordV001Schema) Validate(formats strfmt.Registry) error {
 
 func (m *RekordV001Schema) validateData(formats strfmt.Registry) error {
 
-	if err := validate.Required("data", "body", m.Data); err != nil {
-		return err
+	if swag.IsZero(m.Data) { // not required
+		return nil
 	}
 
 	if m.Data != nil {
@@ -122,11 +122,14 @@ func (m *RekordV001Schema) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
-// RekordV001SchemaData Information about the content associated with the entry
+// RekordV001SchemaData Information about the content associated with the entry; it is omitted when the signature is a PGP cleartext signed message, in which case the signed text is the content
 //
 // swagger:model RekordV001SchemaData
 type RekordV001SchemaData struct {
 
+	// Further hashes of the content computed with other algorithms; each is verified against the content and indexed, so that the entry can be found by any of them
+	AdditionalHashes []*RekordV001SchemaDataAdditionalHashesItems0 `json:"additionalHashes,omitempty"`
+
 	// Specifies the content inline within the document
 	// Format: byte
 	Content strfmt.Base64 `json:"content,omitempty"`
@@ -143,6 +146,10 @@ type RekordV001SchemaData struct {
 func (m *RekordV001SchemaData) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAdditionalHashes(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateHash(formats); err != nil {
 		res = append(res, err)
 	}
@@ -157,6 +164,31 @@ func (m *RekordV001SchemaData) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *RekordV001SchemaData) validateAdditionalHashes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AdditionalHashes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.AdditionalHashes); i++ {
+		if swag.IsZero(m.AdditionalHashes[i]) { // not required
+			continue
+		}
+
+		if m.AdditionalHashes[i] != nil {
+			if err := m.AdditionalHashes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("data" + "." + "additionalHashes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *RekordV001SchemaData) validateHash(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Hash) { // not required
@@ -206,6 +238,124 @@ func (m *RekordV001SchemaData) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// RekordV001SchemaDataAdditionalHashesItems0 rekord v001 schema data additional hashes items0
+//
+// swagger:model RekordV001SchemaDataAdditionalHashesItems0
+type RekordV001SchemaDataAdditionalHashesItems0 struct {
+
+	// The hashing function used to compute the hash value
+	// Required: true
+	// Enum: [sha256 sha384 sha512 sha3-256 sha3-512 blake2b-256 blake3]
+	Algorithm *string `json:"algorithm"`
+
+	// The hash value for the content
+	// Required: true
+	Value *string `json:"value"`
+}
+
+// Validate validates this rekord v001 schema data additional hashes items0
+func (m *RekordV001SchemaDataAdditionalHashesItems0) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAlgorithm(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateValue(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var rekordV001SchemaDataAdditionalHashesItems0TypeAlgorithmPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["sha256","sha384","sha512","sha3-256","sha3-512","blake2b-256","blake3"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		rekordV001SchemaDataAdditionalHashesItems0TypeAlgorithmPropEnum = append(rekordV001SchemaDataAdditionalHashesItems0TypeAlgorithmPropEnum, v)
+	}
+}
+
+const (
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha256 captures enum value "sha256"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha256 string = "sha256"
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha384 captures enum value "sha384"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha384 string = "sha384"
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha512 captures enum value "sha512"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha512 string = "sha512"
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha3256 captures enum value "sha3-256"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha3256 string = "sha3-256"
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha3512 captures enum value "sha3-512"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmSha3512 string = "sha3-512"
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmBlake2b256 captures enum value "blake2b-256"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmBlake2b256 string = "blake2b-256"
+
+	// RekordV001SchemaDataAdditionalHashesItems0AlgorithmBlake3 captures enum value "blake3"
+	RekordV001SchemaDataAdditionalHashesItems0AlgorithmBlake3 string = "blake3"
+)
+
+// prop value enum
+func (m *RekordV001SchemaDataAdditionalHashesItems0) validateAlgorithmEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, rekordV001SchemaDataAdditionalHashesItems0TypeAlgorithmPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *RekordV001SchemaDataAdditionalHashesItems0) validateAlgorithm(formats strfmt.Registry) error {
+
+	if err := validate.Required("algorithm", "body", m.Algorithm); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateAlgorithmEnum("algorithm", "body", *m.Algorithm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *RekordV001SchemaDataAdditionalHashesItems0) validateValue(formats strfmt.Registry) error {
+
+	if err := validate.Required("value", "body", m.Value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *RekordV001SchemaDataAdditionalHashesItems0) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *RekordV001SchemaDataAdditionalHashesItems0) UnmarshalBinary(b []byte) error {
+	var res RekordV001SchemaDataAdditionalHashesItems0
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
+
 // RekordV001SchemaDataHash Specifies the hash algorithm and value for the content
 //
 // swagger:model RekordV001SchemaDataHash
@@ -213,7 +363,7 @@ type RekordV001SchemaDataHash struct {
 
 	// The hashing function used to compute the hash value
 	// Required: true
-	// Enum: [sha256]
+	// Enum: [sha256 sha384 sha512 sha3-256 sha3-512 blake2b-256 blake3]
 	Algorithm *string `json:"algorithm"`
 
 	// The hash value for the content
@@ -243,7 +393,7 @@ var rekordV001SchemaDataHashTypeAlgorithmPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["sha256"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["sha256","sha384","sha512","sha3-256","sha3-512","blake2b-256","blake3"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -255,6 +405,24 @@ const (
 
 	// RekordV001SchemaDataHashAlgorithmSha256 captures enum value "sha256"
 	RekordV001SchemaDataHashAlgorithmSha256 string = "sha256"
+
+	// RekordV001SchemaDataHashAlgorithmSha384 captures enum value "sha384"
+	RekordV001SchemaDataHashAlgorithmSha384 string = "sha384"
+
+	// RekordV001SchemaDataHashAlgorithmSha512 captures enum value "sha512"
+	RekordV001SchemaDataHashAlgorithmSha512 string = "sha512"
+
+	// RekordV001SchemaDataHashAlgorithmSha3256 captures enum value "sha3-256"
+	RekordV001SchemaDataHashAlgorithmSha3256 string = "sha3-256"
+
+	// RekordV001SchemaDataHashAlgorithmSha3512 captures enum value "sha3-512"
+	RekordV001SchemaDataHashAlgorithmSha3512 string = "sha3-512"
+
+	// RekordV001SchemaDataHashAlgorithmBlake2b256 captures enum value "blake2b-256"
+	RekordV001SchemaDataHashAlgorithmBlake2b256 string = "blake2b-256"
+
+	// RekordV001SchemaDataHashAlgorithmBlake3 captures enum value "blake3"
+	RekordV001SchemaDataHashAlgorithmBlake3 string = "blake3"
 )
 
 // prop value enum
@@ -316,7 +484,6 @@ type RekordV001SchemaSignature struct {
 	Content strfmt.Base64 `json:"content,omitempty"`
 
 	// Specifies the format of the signature
-	// Enum: [pgp minisign x509 ssh]
 	Format string `json:"format,omitempty"`
 
 	// public key
@@ -331,10 +498,6 @@ type RekordV001SchemaSignature struct {
 func (m *RekordV001SchemaSignature) Validate(formats strfmt.Registry) error {
 	var res []error
 
-	if err := m.validateFormat(formats); err != nil {
-		res = append(res, err)
-	}
-
 	if err := m.validatePublicKey(formats); err != nil {
 		res = append(res, err)
 	}
@@ -349,55 +512,6 @@ func (m *RekordV001SchemaSignature) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
-var rekordV001SchemaSignatureTypeFormatPropEnum []interface{}
-
-func init() {
-	var res []string
-	if err := json.Unmarshal([]byte(`["pgp","minisign","x509","ssh"]`), &res); err != nil {
-		panic(err)
-	}
-	for _, v := range res {
-		rekordV001SchemaSignatureTypeFormatPropEnum = append(rekordV001SchemaSignatureTypeFormatPropEnum, v)
-	}
-}
-
-const (
-
-	// RekordV001SchemaSignatureFormatPgp captures enum value "pgp"
-	RekordV001SchemaSignatureFormatPgp string = "pgp"
-
-	// RekordV001SchemaSignatureFormatMinisign captures enum value "minisign"
-	RekordV001SchemaSignatureFormatMinisign string = "minisign"
-
-	// RekordV001SchemaSignatureFormatX509 captures enum value "x509"
-	RekordV001SchemaSignatureFormatX509 string = "x509"
-
-	// RekordV001SchemaSignatureFormatSSH captures enum value "ssh"
-	RekordV001SchemaSignatureFormatSSH string = "ssh"
-)
-
-// prop value enum
-func (m *RekordV001SchemaSignature) validateFormatEnum(path, location string, value string) error {
-	if err := validate.EnumCase(path, location, value, rekordV001SchemaSignatureTypeFormatPropEnum, true); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (m *RekordV001SchemaSignature) validateFormat(formats strfmt.Registry) error {
-
-	if swag.IsZero(m.Format) { // not required
-		return nil
-	}
-
-	// value enum
-	if err := m.validateFormatEnum("signature"+"."+"format", "body", m.Format); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (m *RekordV001SchemaSignature) validatePublicKey(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.PublicKey) { // not required
@@ -36,12 +36,61 @@ import (
 // swagger:model SearchIndex
 type SearchIndex struct {
 
+	// SAN email address or URI of a signing certificate issued by Fulcio; requires oidcIssuer, and matches only certificates issued for this identity after authentication by that issuer
+	CertificateIdentity string `json:"certificateIdentity,omitempty"`
+
+	// Token returned with the previous page of results, to continue the search where that page ended
+	ContinuationToken string `json:"continuationToken,omitempty"`
+
+	// Email address of a user ID of the signing key, such as one of the user IDs of a PGP key
+	Email string `json:"email,omitempty"`
+
+	// End of the email addresses to search for, such as @example.com to find the entries of every address of a domain
+	EmailSuffix string `json:"emailSuffix,omitempty"`
+
 	// hash
-	// Pattern: ^[0-9a-fA-F]{64}$
+	// Pattern: ^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$
 	Hash string `json:"hash,omitempty"`
 
+	// Hex encoded prefix of at least 8 characters of a digest entries are indexed under, such as a truncated digest from an incident report, to find the entries of every digest starting with it
+	// Pattern: ^[0-9a-fA-F]{8,128}$
+	HashPrefix string `json:"hashPrefix,omitempty"`
+
+	// VM image identifier in the form region:id, or just id for images that are not regional
+	Image string `json:"image,omitempty"`
+
+	// Key ID of the certificate of the signing key, such as the key ID of an SSH certificate
+	KeyID string `json:"keyId,omitempty"`
+
+	// Maximum number of UUIDs to return; if more entries match, a continuation token is returned with the results
+	// Maximum: 1000
+	// Minimum: 1
+	Limit int64 `json:"limit,omitempty"`
+
+	// Name of an ML model, optionally followed by @version
+	Model string `json:"model,omitempty"`
+
+	// OIDC issuer that authenticated the subject of the signing certificate, as recorded by Fulcio
+	OidcIssuer string `json:"oidcIssuer,omitempty"`
+
+	// Serial number of a PIV device attested to hold the signing key, optionally followed by /slot, such as 12345678/9c
+	Piv string `json:"piv,omitempty"`
+
+	// Identity bound to the signing key by its issuer, such as a principal of an SSH certificate
+	Principal string `json:"principal,omitempty"`
+
 	// public key
 	PublicKey *SearchIndexPublicKey `json:"publicKey,omitempty"`
+
+	// Release identifier in the form name@version
+	Release string `json:"release,omitempty"`
+
+	// Hex encoded SHA256, SHA384 or SHA512 digest of an artifact that is the subject of an attestation, such as an in-toto statement, to find the attestations about it
+	// Pattern: ^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$
+	SubjectDigest string `json:"subjectDigest,omitempty"`
+
+	// URI subject alternative name of the signing certificate, such as the workflow identity of a CI job
+	URI string `json:"uri,omitempty"`
 }
 
 // Validate validates this search index
@@ -52,10 +101,22 @@ func (m *SearchIndex) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateHashPrefix(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateLimit(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validatePublicKey(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateSubjectDigest(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -68,7 +129,37 @@ func (m *SearchIndex) validateHash(formats strfmt.Registry) error {
 		return nil
 	}
 
-	if err := validate.Pattern("hash", "body", string(m.Hash), `^[0-9a-fA-F]{64}$`); err != nil {
+	if err := validate.Pattern("hash", "body", string(m.Hash), `^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SearchIndex) validateHashPrefix(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.HashPrefix) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("hashPrefix", "body", string(m.HashPrefix), `^[0-9a-fA-F]{8,128}$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SearchIndex) validateLimit(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Limit) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("limit", "body", int64(m.Limit), 1, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("limit", "body", int64(m.Limit), 1000, false); err != nil {
 		return err
 	}
 
@@ -93,6 +184,19 @@ func (m *SearchIndex) validatePublicKey(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SearchIndex) validateSubjectDigest(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SubjectDigest) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("subjectDigest", "body", string(m.SubjectDigest), `^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *SearchIndex) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -122,7 +226,7 @@ type SearchIndexPublicKey struct {
 
 	// format
 	// Required: true
-	// Enum: [pgp x509 minisign ssh]
+	// Enum: [pgp x509 minisign ssh ed25519 tuf cosign]
 	Format *string `json:"format"`
 
 	// url
@@ -152,7 +256,7 @@ var searchIndexPublicKeyTypeFormatPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["pgp","x509","minisign","ssh"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["pgp","x509","minisign","ssh","ed25519","tuf","cosign"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -173,6 +277,15 @@ const (
 
 	// SearchIndexPublicKeyFormatSSH captures enum value "ssh"
 	SearchIndexPublicKeyFormatSSH string = "ssh"
+
+	// SearchIndexPublicKeyFormatEd25519 captures enum value "ed25519"
+	SearchIndexPublicKeyFormatEd25519 string = "ed25519"
+
+	// SearchIndexPublicKeyFormatTuf captures enum value "tuf"
+	SearchIndexPublicKeyFormatTuf string = "tuf"
+
+	// SearchIndexPublicKeyFormatCosign captures enum value "cosign"
+	SearchIndexPublicKeyFormatCosign string = "cosign"
 )
 
 // prop value enum
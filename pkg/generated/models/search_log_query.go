@@ -44,6 +44,9 @@ type SearchLogQuery struct {
 	// entry u UI ds
 	EntryUUIDs []string `json:"entryUUIDs"`
 
+	// Whether to return an inclusion proof with each entry; all of the proofs are against the same tree head
+	IncludeProofs bool `json:"includeProofs,omitempty"`
+
 	// log indexes
 	// Min Items: 1
 	LogIndexes []*int64 `json:"logIndexes"`
@@ -66,6 +69,8 @@ func (m *SearchLogQuery) UnmarshalJSON(raw []byte) error {
 
 		EntryUUIDs []string `json:"entryUUIDs"`
 
+		IncludeProofs bool `json:"includeProofs,omitempty"`
+
 		LogIndexes []*int64 `json:"logIndexes"`
 	}
 	buf := bytes.NewBuffer(raw)
@@ -93,6 +98,9 @@ func (m *SearchLogQuery) UnmarshalJSON(raw []byte) error {
 	// entryUUIDs
 	result.EntryUUIDs = data.EntryUUIDs
 
+	// includeProofs
+	result.IncludeProofs = data.IncludeProofs
+
 	// logIndexes
 	result.LogIndexes = data.LogIndexes
 
@@ -108,11 +116,15 @@ func (m SearchLogQuery) MarshalJSON() ([]byte, error) {
 	b1, err = json.Marshal(struct {
 		EntryUUIDs []string `json:"entryUUIDs"`
 
+		IncludeProofs bool `json:"includeProofs,omitempty"`
+
 		LogIndexes []*int64 `json:"logIndexes"`
 	}{
 
 		EntryUUIDs: m.EntryUUIDs,
 
+		IncludeProofs: m.IncludeProofs,
+
 		LogIndexes: m.LogIndexes,
 	})
 	if err != nil {
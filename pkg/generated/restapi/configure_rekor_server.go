@@ -18,9 +18,12 @@ limitations under the License.
 package restapi
 
 import (
+	"compress/gzip"
+	"context"
 	"crypto/tls"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/middleware"
@@ -70,36 +73,83 @@ func configureAPI(api *operations.RekorServerAPI) http.Handler {
 	api.YamlProducer = util.YamlProducer()
 
 	api.ApplicationXPemFileProducer = runtime.TextProducer()
-
+	// entries are also served in compact binary forms to verifiers that cannot afford JSON and base64
+	api.ApplicationCborProducer = pkgapi.CborProducer()
+	api.ApplicationXProtobufProducer = pkgapi.ProtobufProducer()
+	api.TextEventStreamProducer = runtime.TextProducer()
+	api.TxtProducer = runtime.TextProducer()
+
+	api.EntriesCreateLogEntriesHandler = entries.CreateLogEntriesHandlerFunc(pkgapi.CreateLogEntriesHandler)
+	api.EntriesValidateLogEntryHandler = entries.ValidateLogEntryHandlerFunc(pkgapi.ValidateLogEntryHandler)
 	api.EntriesCreateLogEntryHandler = entries.CreateLogEntryHandlerFunc(pkgapi.CreateLogEntryHandler)
+	api.EntriesGetLogEntriesByRangeHandler = entries.GetLogEntriesByRangeHandlerFunc(pkgapi.GetLogEntriesByRangeHandler)
 	api.EntriesGetLogEntryByIndexHandler = entries.GetLogEntryByIndexHandlerFunc(pkgapi.GetLogEntryByIndexHandler)
 	api.EntriesGetLogEntryByUUIDHandler = entries.GetLogEntryByUUIDHandlerFunc(pkgapi.GetLogEntryByUUIDHandler)
 	api.EntriesGetLogEntryProofHandler = entries.GetLogEntryProofHandlerFunc(pkgapi.GetLogEntryProofHandler)
+	api.EntriesGetLogEntryProofByLeafHandler = entries.GetLogEntryProofByLeafHandlerFunc(pkgapi.GetLogEntryProofByLeafHandler)
+	api.EntriesGetLogEntryV2ByIndexHandler = entries.GetLogEntryV2ByIndexHandlerFunc(pkgapi.GetLogEntryV2ByIndexHandler)
+	api.EntriesGetLogEntryV2ByUUIDHandler = entries.GetLogEntryV2ByUUIDHandlerFunc(pkgapi.GetLogEntryV2ByUUIDHandler)
 	api.EntriesSearchLogQueryHandler = entries.SearchLogQueryHandlerFunc(pkgapi.SearchLogQueryHandler)
+	api.EntriesStreamLogEntriesHandler = entries.StreamLogEntriesHandlerFunc(pkgapi.StreamLogEntriesHandler)
 
 	api.TlogGetLogInfoHandler = tlog.GetLogInfoHandlerFunc(pkgapi.GetLogInfoHandler)
 	api.TlogGetLogProofHandler = tlog.GetLogProofHandlerFunc(pkgapi.GetLogProofHandler)
+	api.TlogGetLogStatsHandler = tlog.GetLogStatsHandlerFunc(pkgapi.GetLogStatsHandler)
 	api.TlogGetPublicKeyHandler = tlog.GetPublicKeyHandlerFunc(pkgapi.GetPublicKeyHandler)
+	api.TlogGetRekorConfigurationHandler = tlog.GetRekorConfigurationHandlerFunc(pkgapi.GetRekorConfigurationHandler)
 
 	if viper.GetBool("enable_retrieve_api") {
 		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexHandler)
+		api.EntriesGetLogEntryIndexesByTimeHandler = entries.GetLogEntryIndexesByTimeHandlerFunc(pkgapi.GetLogEntryIndexesByTimeHandler)
 	} else {
 		api.IndexSearchIndexHandler = index.SearchIndexHandlerFunc(pkgapi.SearchIndexNotImplementedHandler)
+		api.EntriesGetLogEntryIndexesByTimeHandler = entries.GetLogEntryIndexesByTimeHandlerFunc(pkgapi.GetLogEntryIndexesByTimeNotImplementedHandler)
+	}
+
+	if viper.GetString("checkpoint.signing_key") != "" {
+		api.TlogGetLogCheckpointHandler = tlog.GetLogCheckpointHandlerFunc(pkgapi.GetLogCheckpointHandler)
+	} else {
+		api.TlogGetLogCheckpointHandler = tlog.GetLogCheckpointHandlerFunc(pkgapi.GetLogCheckpointNotImplementedHandler)
 	}
 
 	api.PreServerShutdown = func() {}
 
 	api.ServerShutdown = func() {}
 
+	//rate limited, then authenticated and told about their quota; each middleware wraps those added before it
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries", pkgapi.QuotaHeaders)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/batch", pkgapi.QuotaHeaders)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/validate", pkgapi.QuotaHeaders)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries", pkgapi.AuthenticateWrites)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/batch", pkgapi.AuthenticateWrites)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/validate", pkgapi.AuthenticateWrites)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries", pkgapi.RateLimitWrites)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/batch", pkgapi.RateLimitWrites)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/validate", pkgapi.RateLimitWrites)
+
+	//bodies of proposed entries are limited in size before they are decoded
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries", pkgapi.LimitEntryBodies)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/batch", pkgapi.LimitBatchBodies)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/validate", pkgapi.LimitEntryBodies)
+
 	//not cacheable
 	api.AddMiddlewareFor("GET", "/api/v1/log", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/log/checkpoint", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/log/proof", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/log/stats", middleware.NoCache)
 	api.AddMiddlewareFor("GET", "/api/v1/log/entries/{entryUUID}/proof", middleware.NoCache)
+	api.AddMiddlewareFor("POST", "/api/v1/log/entries/proof", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/log/stream", middleware.NoCache)
+	api.AddMiddlewareFor("GET", "/api/v1/log/entries/time", middleware.NoCache)
+
+	//cache briefly, as the log key changes when it is rotated
+	api.AddMiddlewareFor("GET", "/api/v1/log/publicKey", cacheBriefly)
 
 	//cache forever
-	api.AddMiddlewareFor("GET", "/api/v1/log/publicKey", cacheForever)
 	api.AddMiddlewareFor("GET", "/api/v1/log/entries", cacheForever)
 	api.AddMiddlewareFor("GET", "/api/v1/log/entries/{entryUUID}", cacheForever)
+	api.AddMiddlewareFor("GET", "/api/v2/log/entries", cacheForever)
+	api.AddMiddlewareFor("GET", "/api/v2/log/entries/{entryUUID}", cacheForever)
 
 	return setupGlobalMiddleware(api.Serve(setupMiddlewares))
 }
@@ -122,29 +172,45 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 	return handler
 }
 
-// We need this type to act as an adapter between zap and the middleware request logger.
-type logAdapter struct {
-}
-
-func (l *logAdapter) Print(v ...interface{}) {
-	log.Logger.Info(v...)
-}
-
 // The middleware configuration happens before anything, this middleware also applies to serving the swagger.json document.
 // So this is a good place to plug in a panic handling middleware, logging and metrics
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
-	middleware.DefaultLogger = middleware.RequestLogger(
-		&middleware.DefaultLogFormatter{Logger: &logAdapter{}})
-	returnHandler := middleware.Logger(handler)
-	returnHandler = middleware.Recoverer(returnHandler)
-	returnHandler = middleware.Heartbeat("/ping")(returnHandler)
+	returnHandler := middleware.Recoverer(handler)
+	if viper.GetBool("enable_ct_api") {
+		returnHandler = pkgapi.ServeCTAPI(returnHandler)
+	}
+	if viper.GetBool("enable_graphql_api") {
+		returnHandler = pkgapi.ServeGraphQL(returnHandler)
+	}
+	if viper.GetString("attestation_storage.backend") != "" {
+		returnHandler = pkgapi.ServeAttestations(returnHandler)
+	}
+	if len(viper.GetStringSlice("tenancy.trees")) > 0 {
+		returnHandler = pkgapi.ResolveTenants(returnHandler)
+	}
+	returnHandler = pkgapi.ProblemJSON(returnHandler)
+	returnHandler = pkgapi.LimitRequestBodies(returnHandler)
+
+	if origins := viper.GetStringSlice("cors.allowed_origins"); len(origins) > 0 {
+		handleCORS := cors.New(cors.Options{
+			AllowedOrigins: origins,
+			AllowedMethods: viper.GetStringSlice("cors.allowed_methods"),
+			AllowedHeaders: append([]string{"Accept", "Content-Type", "Origin"}, viper.GetStringSlice("cors.allowed_headers")...),
+		}).Handler
+		returnHandler = handleCORS(returnHandler)
+	}
 
-	handleCORS := cors.Default().Handler
-	returnHandler = handleCORS(returnHandler)
+	// entries holding large attestations are several megabytes of base64 encoded JSON
+	if viper.GetBool("enable_compression") {
+		returnHandler = compress(returnHandler)
+	}
 
 	returnHandler = wrapMetrics(returnHandler)
+	returnHandler = pkgapi.AccessLog(returnHandler)
+	// health checks are neither logged nor measured
+	returnHandler = middleware.Heartbeat("/ping")(returnHandler)
 
-	return middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return pkgapi.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		r = r.WithContext(log.WithRequestID(ctx, middleware.GetReqID(ctx)))
 		defer func() {
@@ -155,6 +221,60 @@ func setupGlobalMiddleware(handler http.Handler) http.Handler {
 	}))
 }
 
+type varyKey struct{}
+
+// compress compresses JSON responses with gzip or deflate for clients accepting them. The compressor replaces the
+// Vary header of the handlers with Accept-Encoding, so their values are carried around it and added back. The ETags
+// of compressed responses, and of those not modified for clients accepting compression, are marked weak as the
+// compressed body of an entry is not the same as the identity one.
+func compress(handler http.Handler) http.Handler {
+	compressed := middleware.Compress(gzip.DefaultCompression, "application/json", "application/problem+json")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := negroni.NewResponseWriter(w)
+		ww.Before(func(w negroni.ResponseWriter) {
+			if vary, ok := r.Context().Value(varyKey{}).(*[]string); ok {
+				*vary = w.Header().Values("Vary")
+			}
+		})
+		handler.ServeHTTP(ww, r)
+	}))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var vary []string
+		ww := negroni.NewResponseWriter(w)
+		ww.Before(func(w negroni.ResponseWriter) {
+			h := w.Header()
+			for _, v := range append(vary, "Accept-Encoding") {
+				if !hasVary(h, v) {
+					h.Add("Vary", v)
+				}
+			}
+			encoded := h.Get("Content-Encoding") != "" || (w.Status() == http.StatusNotModified && acceptsCompression(r))
+			if etag := h.Get("ETag"); encoded && etag != "" && !strings.HasPrefix(etag, "W/") {
+				h.Set("ETag", "W/"+etag)
+			}
+		})
+		compressed.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), varyKey{}, &vary)))
+	})
+}
+
+// hasVary reports whether the Vary header lists the request header name
+func hasVary(h http.Header, name string) bool {
+	for _, value := range h.Values("Vary") {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// acceptsCompression reports whether the response to the request would be compressed, matching the encodings it
+// accepts as the compressor does
+func acceptsCompression(r *http.Request) bool {
+	accepted := strings.ToLower(r.Header.Get("Accept-Encoding"))
+	return strings.Contains(accepted, "gzip") || strings.Contains(accepted, "deflate")
+}
+
 func wrapMetrics(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -172,12 +292,50 @@ func wrapMetrics(handler http.Handler) http.Handler {
 	})
 }
 
+// cacheForever marks successful and not modified responses as immutable, except for entries returned with an
+// inclusion proof as the tree head the proof is computed against changes as the log grows. When tenants are
+// served, the same path is served from the tree of the tenant named by the X-Rekor-Tenant header, so caches are
+// told to key responses by it.
 func cacheForever(handler http.Handler) http.Handler {
+	tenancy := len(viper.GetStringSlice("tenancy.trees")) > 0
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if includeProof, _ := strconv.ParseBool(r.URL.Query().Get("includeProof")); includeProof {
+			middleware.NoCache(handler).ServeHTTP(w, r)
+			return
+		}
 		ww := negroni.NewResponseWriter(w)
 		ww.Before(func(w negroni.ResponseWriter) {
-			if w.Status() >= 200 && w.Status() <= 299 {
+			if (w.Status() >= 200 && w.Status() <= 299) || w.Status() == http.StatusNotModified {
 				w.Header().Set("Cache-Control", "s-maxage=31536000, max-age=31536000, immutable")
+				// entries are served in the form negotiated by the Accept header
+				w.Header().Add("Vary", "Accept")
+				if tenancy {
+					w.Header().Add("Vary", pkgapi.TenantHeader)
+				}
+			}
+		})
+		handler.ServeHTTP(ww, r)
+	})
+}
+
+// publicKeyMaxAge is how long responses for the active log key may be cached, bounding how long clients keep
+// using a key after it is rotated
+const publicKeyMaxAge = 5 * time.Minute
+
+// cacheBriefly lets successful and not modified responses be cached for publicKeyMaxAge, keyed by the tenant header
+// when tenants are served, as for cacheForever.
+func cacheBriefly(handler http.Handler) http.Handler {
+	tenancy := len(viper.GetStringSlice("tenancy.trees")) > 0
+	maxAge := strconv.Itoa(int(publicKeyMaxAge.Seconds()))
+	cacheControl := "s-maxage=" + maxAge + ", max-age=" + maxAge
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := negroni.NewResponseWriter(w)
+		ww.Before(func(w negroni.ResponseWriter) {
+			if (w.Status() >= 200 && w.Status() <= 299) || w.Status() == http.StatusNotModified {
+				w.Header().Set("Cache-Control", cacheControl)
+				if tenancy {
+					w.Header().Add("Vary", pkgapi.TenantHeader)
+				}
 			}
 		})
 		handler.ServeHTTP(ww, r)
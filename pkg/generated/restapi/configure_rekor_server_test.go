@@ -0,0 +1,223 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/loads"
+	"github.com/spf13/viper"
+	jose "gopkg.in/square/go-jose.v2"
+
+	pkgapi "github.com/sigstore/rekor/pkg/api"
+	"github.com/sigstore/rekor/pkg/generated/restapi/operations"
+)
+
+func TestCompressCaching(t *testing.T) {
+	handler := compress(cacheForever(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"1234"`)
+		if r.Header.Get("If-None-Match") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"body":"` + strings.Repeat("a", 4096) + `"}`))
+	})))
+
+	tests := []struct {
+		caseDesc       string
+		acceptEncoding string
+		ifNoneMatch    string
+		encoding       string
+		etag           string
+	}{
+		{caseDesc: "gzip", acceptEncoding: "gzip", encoding: "gzip", etag: `W/"1234"`},
+		{caseDesc: "identity", etag: `"1234"`},
+		{caseDesc: "deflate", acceptEncoding: "deflate", encoding: "deflate", etag: `W/"1234"`},
+		{caseDesc: "not modified with gzip", acceptEncoding: "gzip, deflate", ifNoneMatch: `W/"1234"`, etag: `W/"1234"`},
+		{caseDesc: "not modified without gzip", ifNoneMatch: `"1234"`, etag: `"1234"`},
+	}
+	for _, tc := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/log/entries/1234", nil)
+		if tc.acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		}
+		if tc.ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if encoding := w.Header().Get("Content-Encoding"); encoding != tc.encoding {
+			t.Errorf("%v: unexpected encoding %q", tc.caseDesc, encoding)
+		}
+		if etag := w.Header().Get("ETag"); etag != tc.etag {
+			t.Errorf("%v: unexpected ETag %v", tc.caseDesc, etag)
+		}
+		// caches key every representation by both the negotiated type and encoding
+		if !hasVary(w.Header(), "Accept") || !hasVary(w.Header(), "Accept-Encoding") {
+			t.Errorf("%v: unexpected Vary header %v", tc.caseDesc, w.Header().Values("Vary"))
+		}
+	}
+}
+
+// testIssuer serves the discovery document and keys of an OIDC issuer signing tokens with key
+func testIssuer(t *testing.T, key *ecdsa.PrivateKey) *httptest.Server {
+	t.Helper()
+	var issuer *httptest.Server
+	issuer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"issuer":                                issuer.URL,
+				"jwks_uri":                              issuer.URL + "/keys",
+				"id_token_signing_alg_values_supported": []string{"ES256"},
+			})
+		case "/keys":
+			_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), Algorithm: "ES256", Use: "sig"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return issuer
+}
+
+func TestWriteMiddlewares(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	issuer := testIssuer(t, key)
+	defer issuer.Close()
+
+	defer func(issuer, clientID, claim string, daily int64) {
+		viper.Set("auth.oidc_issuer", issuer)
+		viper.Set("auth.oidc_client_id", clientID)
+		viper.Set("auth.oidc_identity_claim", claim)
+		viper.Set("auth.daily_entry_quota", daily)
+		if err := pkgapi.ConfigureWrites(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}(viper.GetString("auth.oidc_issuer"), viper.GetString("auth.oidc_client_id"), viper.GetString("auth.oidc_identity_claim"), viper.GetInt64("auth.daily_entry_quota"))
+	viper.Set("auth.oidc_issuer", issuer.URL)
+	viper.Set("auth.oidc_client_id", "rekor")
+	viper.Set("auth.oidc_identity_claim", "sub")
+	viper.Set("auth.daily_entry_quota", 5)
+	if err := pkgapi.ConfigureWrites(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, _ := json.Marshal(map[string]interface{}{"iss": issuer.URL, "aud": "rekor", "sub": "jdoe", "exp": time.Now().Add(time.Hour).Unix()})
+	jws, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jws.CompactSerialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := loads.Embedded(SwaggerJSON, FlatSwaggerJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := configureAPI(operations.NewRekorServerAPI(doc))
+
+	tests := []struct {
+		caseDesc      string
+		path          string
+		authorization string
+		expectedCode  int
+		quota         string
+	}{
+		// the entry is rejected for lacking a kind after it has been authenticated and told about its quota
+		{caseDesc: "authenticated entry", path: "/api/v1/log/entries", authorization: "Bearer " + token, expectedCode: http.StatusUnprocessableEntity, quota: "5"},
+		{caseDesc: "authenticated batch", path: "/api/v1/log/entries/batch", authorization: "Bearer " + token, expectedCode: http.StatusUnprocessableEntity, quota: "5"},
+		{caseDesc: "authenticated validation", path: "/api/v1/log/entries/validate", authorization: "Bearer " + token, expectedCode: http.StatusUnprocessableEntity, quota: "5"},
+		{caseDesc: "unauthenticated entry", path: "/api/v1/log/entries", expectedCode: http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		body := "{}"
+		if strings.HasSuffix(tc.path, "/batch") {
+			body = "[{}]"
+		}
+		r := httptest.NewRequest(http.MethodPost, tc.path, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		if tc.authorization != "" {
+			r.Header.Set("Authorization", tc.authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != tc.expectedCode {
+			t.Errorf("%v: unexpected status %v: %s", tc.caseDesc, w.Code, w.Body)
+		}
+		if got := w.Header().Get("X-Rekor-Quota-Daily-Limit"); got != tc.quota {
+			t.Errorf("%v: unexpected daily quota %q", tc.caseDesc, got)
+		}
+	}
+}
+
+func TestCacheForeverTenants(t *testing.T) {
+	defer viper.Set("tenancy.trees", viper.GetStringSlice("tenancy.trees"))
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, trees := range [][]string{nil, {"acme=1234"}} {
+		viper.Set("tenancy.trees", trees)
+		w := httptest.NewRecorder()
+		cacheForever(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/log/entries/1234", nil))
+		if !strings.Contains(w.Header().Get("Cache-Control"), "immutable") || !hasVary(w.Header(), "Accept") {
+			t.Errorf("unexpected headers %v with tenants %v", w.Header(), trees)
+		}
+		// the same URL is served from the tree of the tenant named by the header
+		if hasVary(w.Header(), pkgapi.TenantHeader) != (len(trees) > 0) {
+			t.Errorf("unexpected Vary header %v with tenants %v", w.Header().Values("Vary"), trees)
+		}
+	}
+}
+
+func TestCacheBriefly(t *testing.T) {
+	defer viper.Set("tenancy.trees", viper.GetStringSlice("tenancy.trees"))
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, trees := range [][]string{nil, {"acme=1234"}} {
+		viper.Set("tenancy.trees", trees)
+		w := httptest.NewRecorder()
+		cacheBriefly(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/log/publicKey", nil))
+		// the key changes when it is rotated, so it must not be cached as immutable
+		if cc := w.Header().Get("Cache-Control"); cc != "s-maxage=300, max-age=300" {
+			t.Errorf("unexpected Cache-Control %q with tenants %v", cc, trees)
+		}
+		if hasVary(w.Header(), pkgapi.TenantHeader) != (len(trees) > 0) {
+			t.Errorf("unexpected Vary header %v with tenants %v", w.Header().Values("Vary"), trees)
+		}
+	}
+}
@@ -54,6 +54,27 @@ func init() {
   },
   "host": "api.rekor.dev",
   "paths": {
+    "/.well-known/rekor-configuration": {
+      "get": {
+        "description": "Returns the log ID, public keys, shard map and supported API versions of this transparency log",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Retrieve the parameters needed to bootstrap trust in this instance",
+        "operationId": "getRekorConfiguration",
+        "responses": {
+          "200": {
+            "description": "A JSON object describing the configuration of this transparency log",
+            "schema": {
+              "$ref": "#/definitions/RekorConfiguration"
+            }
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/index/retrieve": {
       "post": {
         "tags": [
@@ -74,6 +95,12 @@ func init() {
         "responses": {
           "200": {
             "description": "Returns zero or more entry UUIDs from the transparency log based on search query",
+            "headers": {
+              "X-Rekor-Continuation-Token": {
+                "type": "string",
+                "description": "Token to pass as continuationToken to retrieve the next page of results, if there are more"
+              }
+            },
             "schema": {
               "type": "array",
               "items": {
@@ -113,8 +140,38 @@ func init() {
         }
       }
     },
+    "/api/v1/log/checkpoint": {
+      "get": {
+        "description": "Returns the current size and root hash of the log as a checkpoint in the signed note format, with an origin line identifying the log, the tree size, the base64-encoded root hash and the signature of the log, for witnesses and verifiers that consume checkpoints.\n",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get the current tree head as a signed checkpoint",
+        "operationId": "getLogCheckpoint",
+        "responses": {
+          "200": {
+            "description": "The current tree head as a signed checkpoint",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
     "/api/v1/log/entries": {
       "get": {
+        "produces": [
+          "application/json;q=1",
+          "application/yaml",
+          "application/cbor",
+          "application/x-protobuf"
+        ],
         "tags": [
           "entries"
         ],
@@ -127,6 +184,18 @@ func init() {
             "name": "logIndex",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests",
+            "name": "includeProof",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the ETag of a copy of the entry held by the client; the entry is not returned if it still matches",
+            "name": "If-None-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -134,6 +203,21 @@ func init() {
             "description": "the entry in the transparency log requested",
             "schema": {
               "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
+            }
+          },
+          "304": {
+            "description": "the entry has not changed since the copy with the ETag in If-None-Match was retrieved",
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
             }
           },
           "404": {
@@ -176,6 +260,10 @@ func init() {
                 "type": "string",
                 "format": "uri",
                 "description": "URI location of log entry"
+              },
+              "X-Rekor-Signature-Reuse": {
+                "type": "string",
+                "description": "Comma-separated UUIDs of existing entries that logged the same signature under a different public key"
               }
             }
           },
@@ -191,30 +279,36 @@ func init() {
         }
       }
     },
-    "/api/v1/log/entries/retrieve": {
+    "/api/v1/log/entries/batch": {
       "post": {
+        "description": "Creates an entry in the transparency log for each of the proposed entries, queueing them together. The result of each entry is returned in the order the entries were given; an entry that is rejected does not prevent the others from being created.\n",
         "tags": [
           "entries"
         ],
-        "summary": "Searches transparency log for one or more log entries",
-        "operationId": "searchLogQuery",
+        "summary": "Creates a batch of entries in the transparency log",
+        "operationId": "createLogEntries",
         "parameters": [
           {
-            "name": "entry",
+            "name": "proposedEntries",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/SearchLogQuery"
+              "type": "array",
+              "maxItems": 100,
+              "minItems": 1,
+              "items": {
+                "$ref": "#/definitions/ProposedEntry"
+              }
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "Returns zero or more entries from the transparency log, according to how many were included in request query",
+            "description": "Returns the result of each proposed entry, in the order the entries were given",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/LogEntry"
+                "$ref": "#/definitions/BatchEntryResult"
               }
             }
           },
@@ -227,30 +321,34 @@ func init() {
         }
       }
     },
-    "/api/v1/log/entries/{entryUUID}": {
-      "get": {
+    "/api/v1/log/entries/proof": {
+      "post": {
+        "description": "Returns root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log, for the entry with the given merkle leaf hash or with the given canonicalized content. Clients that only kept the entry they submitted can use it to prove inclusion without knowing its UUID or log index.\n",
         "tags": [
           "entries"
         ],
-        "summary": "Retrieves an entry from the transparency log (if it exists) by UUID",
-        "operationId": "getLogEntryByUUID",
+        "summary": "Get information required to generate an inclusion proof for an entry identified by its leaf hash or canonicalized content",
+        "operationId": "getLogEntryProofByLeaf",
         "parameters": [
           {
-            "pattern": "^[0-9a-fA-F]{64}$",
-            "type": "string",
-            "description": "the UUID of the entry to be retrieved from the log. The UUID is also the merkle tree hash of the entry.",
-            "name": "entryUUID",
-            "in": "path",
-            "required": true
+            "name": "query",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/InclusionProofQuery"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "the entry in the transparency log requested",
+            "description": "Information needed for a client to compute the inclusion proof",
             "schema": {
-              "$ref": "#/definitions/LogEntry"
+              "$ref": "#/definitions/InclusionProof"
             }
           },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
           "404": {
             "$ref": "#/responses/NotFound"
           },
@@ -260,29 +358,40 @@ func init() {
         }
       }
     },
-    "/api/v1/log/entries/{entryUUID}/proof": {
+    "/api/v1/log/entries/range": {
       "get": {
-        "description": "Returns root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log",
+        "description": "Returns up to count consecutive entries starting at the entry with index start, in index order. Fewer entries are returned when the log ends before the range does, so clients tailing the log should continue from the index after the last entry returned.\n",
         "tags": [
           "entries"
         ],
-        "summary": "Get information required to generate an inclusion proof for a specified entry in the transparency log",
-        "operationId": "getLogEntryProof",
+        "summary": "Retrieves consecutive entries from the transparency log by index",
+        "operationId": "getLogEntriesByRange",
         "parameters": [
           {
-            "pattern": "^[0-9a-fA-F]{64}$",
-            "type": "string",
-            "description": "the UUID of the entry for which the inclusion proof information should be returned",
-            "name": "entryUUID",
-            "in": "path",
+            "type": "integer",
+            "description": "specifies the index of the first entry to be retrieved",
+            "name": "start",
+            "in": "query",
+            "required": true
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "description": "specifies the number of entries to be retrieved",
+            "name": "count",
+            "in": "query",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Information needed for a client to compute the inclusion proof",
+            "description": "the entries in the transparency log requested",
             "schema": {
-              "$ref": "#/definitions/InclusionProof"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/LogEntry"
+              }
             }
           },
           "404": {
@@ -294,37 +403,88 @@ func init() {
         }
       }
     },
-    "/api/v1/log/proof": {
+    "/api/v1/log/entries/retrieve": {
+      "post": {
+        "tags": [
+          "entries"
+        ],
+        "summary": "Searches transparency log for one or more log entries",
+        "operationId": "searchLogQuery",
+        "parameters": [
+          {
+            "name": "entry",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SearchLogQuery"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Returns zero or more entries from the transparency log, according to how many were included in request query",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/LogEntry"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/time": {
       "get": {
-        "description": "Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log",
+        "description": "Returns the log indexes of the entries integrated into the log between start and end inclusive, ordered by integrated time. At most limit indexes are returned, so clients should request the next page with offset increased by the number of indexes returned until fewer than limit are. Only entries added while the search index was enabled are included.\n",
         "tags": [
-          "tlog"
+          "entries"
         ],
-        "summary": "Get information required to generate a consistency proof for the transparency log",
-        "operationId": "getLogProof",
+        "summary": "Lists the indexes of entries integrated into the log within a time range",
+        "operationId": "getLogEntryIndexesByTime",
         "parameters": [
           {
-            "minimum": 1,
             "type": "integer",
-            "default": 1,
-            "description": "The size of the tree that you wish to prove consistency from (1 means the beginning of the log) Defaults to 1 if not specified\n",
-            "name": "firstSize",
-            "in": "query"
+            "description": "specifies the earliest integrated time, in seconds since the Unix epoch, of entries to be returned",
+            "name": "start",
+            "in": "query",
+            "required": true
           },
           {
-            "minimum": 1,
             "type": "integer",
-            "description": "The size of the tree that you wish to prove consistency to",
-            "name": "lastSize",
+            "description": "specifies the latest integrated time, in seconds since the Unix epoch, of entries to be returned",
+            "name": "end",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "integer",
+            "description": "specifies the number of matching log indexes to skip, to continue from a previous page of results",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "description": "specifies the maximum number of log indexes to be returned",
+            "name": "limit",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "All hashes required to compute the consistency proof",
+            "description": "the log indexes of the entries integrated within the time range",
             "schema": {
-              "$ref": "#/definitions/ConsistencyProof"
+              "type": "array",
+              "items": {
+                "type": "integer"
+              }
             }
           },
           "400": {
@@ -336,76 +496,383 @@ func init() {
         }
       }
     },
-    "/api/v1/log/publicKey": {
-      "get": {
-        "description": "Returns the public key that can be used to validate the signed tree head",
-        "produces": [
-          "application/x-pem-file"
-        ],
+    "/api/v1/log/entries/validate": {
+      "post": {
+        "description": "Runs the same checks as creating the entry, fetching any external entities and verifying its signature, and returns the canonical entry with the UUID it would be added to the log as, so that clients can check entries before submitting them. Nothing is added to the log.\n",
         "tags": [
-          "tlog"
+          "entries"
+        ],
+        "summary": "Checks an entry and returns the UUID it would have without adding it to the transparency log",
+        "operationId": "validateLogEntry",
+        "parameters": [
+          {
+            "name": "proposedEntry",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ProposedEntry"
+            }
+          }
         ],
-        "summary": "Retrieve the public key that can be used to validate the signed tree head",
-        "operationId": "getPublicKey",
         "responses": {
           "200": {
-            "description": "The public key",
+            "description": "The entry is valid and would be added to the log as the UUID returned",
             "schema": {
-              "type": "string"
+              "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID the entry would be added to the log as"
+              },
+              "X-Rekor-Signature-Reuse": {
+                "type": "string",
+                "description": "Comma-separated UUIDs of existing entries that logged the same signature under a different public key"
+              }
             }
           },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "409": {
+            "$ref": "#/responses/Conflict"
+          },
           "default": {
             "$ref": "#/responses/InternalServerError"
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "ConsistencyProof": {
-      "type": "object",
-      "required": [
-        "rootHash",
-        "hashes"
-      ],
-      "properties": {
-        "hashes": {
-          "type": "array",
-          "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
+    },
+    "/api/v1/log/entries/{entryUUID}": {
+      "get": {
+        "produces": [
+          "application/json;q=1",
+          "application/yaml",
+          "application/cbor",
+          "application/x-protobuf"
+        ],
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves an entry from the transparency log (if it exists) by UUID",
+        "operationId": "getLogEntryByUUID",
+        "parameters": [
+          {
+            "pattern": "^[0-9a-fA-F]{64}$",
             "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
+            "description": "the UUID of the entry to be retrieved from the log. The UUID is also the merkle tree hash of the entry.",
+            "name": "entryUUID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests",
+            "name": "includeProof",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the ETag of a copy of the entry held by the client; the entry is not returned if it still matches",
+            "name": "If-None-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entry in the transparency log requested",
+            "schema": {
+              "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
+            }
+          },
+          "304": {
+            "description": "the entry has not changed since the copy with the ETag in If-None-Match was retrieved",
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
           }
-        },
-        "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
-          "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
         }
       }
     },
-    "Error": {
+    "/api/v1/log/entries/{entryUUID}/proof": {
+      "get": {
+        "description": "Returns root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Get information required to generate an inclusion proof for a specified entry in the transparency log",
+        "operationId": "getLogEntryProof",
+        "parameters": [
+          {
+            "pattern": "^[0-9a-fA-F]{64}$",
+            "type": "string",
+            "description": "the UUID of the entry for which the inclusion proof information should be returned",
+            "name": "entryUUID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Information needed for a client to compute the inclusion proof",
+            "schema": {
+              "$ref": "#/definitions/InclusionProof"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/log/proof": {
+      "get": {
+        "description": "Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log. Both sizes may be earlier than the current size of the log, so that tree heads recorded in the past can be checked against each other\n",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get information required to generate a consistency proof for the transparency log",
+        "operationId": "getLogProof",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "default": 1,
+            "description": "The size of the tree that you wish to prove consistency from (1 means the beginning of the log) Defaults to 1 if not specified\n",
+            "name": "firstSize",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The size of the tree that you wish to prove consistency to",
+            "name": "lastSize",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "All hashes required to compute the consistency proof",
+            "schema": {
+              "$ref": "#/definitions/ConsistencyProof"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/log/publicKey": {
+      "get": {
+        "description": "Returns the public key that can be used to validate the signed tree head. Once the key of the log has been rotated, the keys that validate tree heads signed before the rotation are returned when their key hint is given\n",
+        "produces": [
+          "application/x-pem-file"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Retrieve the public key that can be used to validate the signed tree head",
+        "operationId": "getPublicKey",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "the base64-encoded key hint of the signed tree head to be validated, to retrieve the key it was produced with rather than the active one",
+            "name": "keyHint",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The public key",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/log/stats": {
+      "get": {
+        "description": "Returns the current size of the log, the number of entries of each kind and percentiles of the time recent entries took to be integrated, so that dashboards need not crawl the log or scrape metrics to compute them.",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get statistics about the entries of the transparency log",
+        "operationId": "getLogStats",
+        "responses": {
+          "200": {
+            "description": "A JSON object with the statistics of the log as properties",
+            "schema": {
+              "$ref": "#/definitions/LogStats"
+            }
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/log/stream": {
+      "get": {
+        "description": "Sends an event for each entry as it is integrated into the log, in index order. Each event carries the log index of the entry as its id and the UUID, kind, API version and integrated time of the entry as JSON data. The stream starts at the entry with index start, or after the entry named by Last-Event-ID when a client reconnects, or otherwise with the next entry to be integrated.\n",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "entries"
+        ],
+        "summary": "Streams the entries integrated into the transparency log as server-sent events",
+        "operationId": "streamLogEntries",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "specifies the index of the first entry to be streamed",
+            "name": "start",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "the index of the last entry received before reconnecting; the stream resumes after it",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of events for newly integrated entries"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v2/log/entries": {
+      "get": {
+        "description": "Returns the entry in the v2 format, which holds the body of the entry in a property named after its kind with the schema of that kind rather than as a generic spec, along with the canonical JSON the UUID of the entry is computed from. The v1 endpoints continue to return entries in the v1 format.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves an entry from the transparency log by index with its body typed by kind",
+        "operationId": "getLogEntryV2ByIndex",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "specifies the index of the entry in the transparency log to be retrieved",
+            "name": "logIndex",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entry in the transparency log requested",
+            "schema": {
+              "$ref": "#/definitions/LogEntryV2"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v2/log/entries/{entryUUID}": {
+      "get": {
+        "description": "Returns the entry in the v2 format, which holds the body of the entry in a property named after its kind with the schema of that kind rather than as a generic spec, along with the canonical JSON the UUID of the entry is computed from. The v1 endpoints continue to return entries in the v1 format.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves an entry from the transparency log by UUID with its body typed by kind",
+        "operationId": "getLogEntryV2ByUUID",
+        "parameters": [
+          {
+            "pattern": "^[0-9a-fA-F]{64}$",
+            "type": "string",
+            "description": "the UUID of the entry to be retrieved from the log. The UUID is also the merkle tree hash of the entry.",
+            "name": "entryUUID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entry in the transparency log requested",
+            "schema": {
+              "$ref": "#/definitions/LogEntryV2"
+            }
+          },
+          "404": {
+            "$ref": "#/responses/NotFound"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "BatchEntryResult": {
+      "description": "The result of one of the entries of a batch; either the entry created in the transparency log, or the error it was rejected with. An entry already in the log is rejected along with the existing entry.",
       "type": "object",
       "properties": {
-        "code": {
-          "type": "integer"
+        "entry": {
+          "$ref": "#/definitions/LogEntry"
         },
-        "message": {
-          "type": "string"
+        "error": {
+          "$ref": "#/definitions/Error"
+        },
+        "location": {
+          "description": "The URL of the entry, or of the existing entry for an entry already in the log",
+          "type": "string",
+          "format": "uri"
         }
       }
     },
-    "InclusionProof": {
+    "ConsistencyProof": {
       "type": "object",
       "required": [
-        "logIndex",
         "rootHash",
-        "treeSize",
         "hashes"
       ],
       "properties": {
         "hashes": {
-          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
           "type": "array",
           "items": {
             "description": "SHA256 hash value expressed in hexadecimal format",
@@ -413,22 +880,158 @@ func init() {
             "pattern": "^[0-9a-fA-F]{64}$"
           }
         },
-        "logIndex": {
-          "description": "The index of the entry in the transparency log",
-          "type": "integer"
-        },
         "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+          "description": "The hash value stored at the root of the merkle tree when it held lastSize entries",
           "type": "string",
           "pattern": "^[0-9a-fA-F]{64}$"
-        },
-        "treeSize": {
-          "description": "The size of the merkle tree at the time the inclusion proof was generated",
+        }
+      }
+    },
+    "Error": {
+      "description": "An error, which is also an RFC 7807 problem details object",
+      "type": "object",
+      "properties": {
+        "apiVersion": {
+          "description": "API version of the proposed entry the error was returned for",
+          "type": "string"
+        },
+        "code": {
+          "type": "integer"
+        },
+        "detail": {
+          "description": "Explanation of this occurrence of the problem; the same as message",
+          "type": "string"
+        },
+        "field": {
+          "description": "Path of the field of the proposed entry that failed validation",
+          "type": "string"
+        },
+        "kind": {
+          "description": "Kind of the proposed entry the error was returned for",
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "requestId": {
+          "description": "ID of the request the error was returned for, which is also returned in the X-Request-Id header",
+          "type": "string"
+        },
+        "status": {
+          "description": "HTTP status code of the response; the same as code",
+          "type": "integer"
+        },
+        "title": {
+          "description": "Short summary of the type of problem",
+          "type": "string"
+        },
+        "type": {
+          "description": "URI identifying the type of problem, as defined by RFC 7807; clients can rely on it to tell failures apart",
+          "type": "string"
+        }
+      }
+    },
+    "InactiveShardLogInfo": {
+      "type": "object",
+      "required": [
+        "treeID",
+        "rootHash",
+        "treeSize",
+        "signedTreeHead"
+      ],
+      "properties": {
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree of the shard when it was frozen",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "signedTreeHead": {
+          "description": "The signed tree head the shard was frozen at",
+          "type": "object",
+          "required": [
+            "keyHint",
+            "logRoot",
+            "signature"
+          ],
+          "properties": {
+            "keyHint": {
+              "description": "Key hint",
+              "type": "string",
+              "format": "byte"
+            },
+            "logRoot": {
+              "description": "Log root",
+              "type": "string",
+              "format": "byte"
+            },
+            "signature": {
+              "description": "Signature for log root",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        },
+        "treeID": {
+          "description": "The Trillian tree ID of the shard",
+          "type": "string"
+        },
+        "treeSize": {
+          "description": "The number of entries in the shard",
+          "type": "integer",
+          "minimum": 0
+        }
+      }
+    },
+    "InclusionProof": {
+      "type": "object",
+      "required": [
+        "logIndex",
+        "rootHash",
+        "treeSize",
+        "hashes"
+      ],
+      "properties": {
+        "hashes": {
+          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "logIndex": {
+          "description": "The index of the entry in the transparency log",
+          "type": "integer"
+        },
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "treeSize": {
+          "description": "The size of the merkle tree at the time the inclusion proof was generated",
           "type": "integer",
           "minimum": 1
         }
       }
     },
+    "InclusionProofQuery": {
+      "description": "Names the entry to prove the inclusion of; exactly one of leafHash and canonicalEntry must be given",
+      "type": "object",
+      "properties": {
+        "canonicalEntry": {
+          "description": "The canonicalized entry as it is stored in the log, whose leaf hash is computed by the server",
+          "type": "string",
+          "format": "byte"
+        },
+        "leafHash": {
+          "description": "The merkle leaf hash of the entry, which is also its UUID",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
     "LogEntry": {
       "type": "object",
       "additionalProperties": {
@@ -446,10 +1049,114 @@ func init() {
           },
           "logIndex": {
             "type": "integer"
+          },
+          "verification": {
+            "type": "object",
+            "properties": {
+              "inclusionProof": {
+                "$ref": "#/definitions/InclusionProof"
+              },
+              "signedTreeHead": {
+                "description": "The signed tree head the inclusion proof was computed against",
+                "type": "object",
+                "required": [
+                  "keyHint",
+                  "logRoot",
+                  "signature"
+                ],
+                "properties": {
+                  "keyHint": {
+                    "description": "Key hint",
+                    "type": "string",
+                    "format": "byte"
+                  },
+                  "logRoot": {
+                    "description": "Log root",
+                    "type": "string",
+                    "format": "byte"
+                  },
+                  "signature": {
+                    "description": "Signature for log root",
+                    "type": "string",
+                    "format": "byte"
+                  }
+                }
+              }
+            }
           }
         }
       }
     },
+    "LogEntryV2": {
+      "description": "An entry of the transparency log with the body of its kind as a typed property named after the kind, rather than a generic spec, along with the canonical JSON its UUID is computed from\n",
+      "type": "object",
+      "required": [
+        "uuid",
+        "logIndex",
+        "integratedTime",
+        "kind",
+        "apiVersion",
+        "canonicalBody"
+      ],
+      "properties": {
+        "apiVersion": {
+          "description": "The version of the schema of the kind the entry was proposed with",
+          "type": "string"
+        },
+        "canonicalBody": {
+          "description": "The canonical JSON of the entry exactly as it was hashed into the log",
+          "type": "string",
+          "format": "byte"
+        },
+        "commitment": {
+          "$ref": "pkg/types/commitment/v0.0.1/commitment_v0_0_1_schema.json"
+        },
+        "dct": {
+          "$ref": "pkg/types/dct/v0.0.1/dct_v0_0_1_schema.json"
+        },
+        "integratedTime": {
+          "description": "The time the entry was integrated into the log, in seconds since the Unix epoch",
+          "type": "integer"
+        },
+        "kind": {
+          "description": "The kind of the entry, which names the property holding its body",
+          "type": "string"
+        },
+        "kmod": {
+          "$ref": "pkg/types/kmod/v0.0.1/kmod_v0_0_1_schema.json"
+        },
+        "logIndex": {
+          "description": "The index of the entry in the log",
+          "type": "integer"
+        },
+        "mlmodel": {
+          "$ref": "pkg/types/mlmodel/v0.0.1/mlmodel_v0_0_1_schema.json"
+        },
+        "notation": {
+          "$ref": "pkg/types/notation/v0.0.1/notation_v0_0_1_schema.json"
+        },
+        "rekord": {
+          "$ref": "pkg/types/rekord/v0.0.1/rekord_v0_0_1_schema.json"
+        },
+        "release": {
+          "$ref": "pkg/types/release/v0.0.1/release_v0_0_1_schema.json"
+        },
+        "rpm": {
+          "$ref": "pkg/types/rpm/v0.0.1/rpm_v0_0_1_schema.json"
+        },
+        "tfprovider": {
+          "$ref": "pkg/types/tfprovider/v0.0.1/tfprovider_v0_0_1_schema.json"
+        },
+        "uuid": {
+          "description": "The UUID of the entry, which is the RFC 6962 leaf hash of its canonical body",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "vmimage": {
+          "$ref": "pkg/types/vmimage/v0.0.1/vmimage_v0_0_1_schema.json"
+        }
+      }
+    },
     "LogInfo": {
       "type": "object",
       "required": [
@@ -458,6 +1165,13 @@ func init() {
         "signedTreeHead"
       ],
       "properties": {
+        "inactiveShards": {
+          "description": "The frozen shards of the log, oldest first, whose entries precede those of the tree above in the log index",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/InactiveShardLogInfo"
+          }
+        },
         "rootHash": {
           "description": "The current hash value stored at the root of the merkle tree",
           "type": "string",
@@ -496,6 +1210,53 @@ func init() {
         }
       }
     },
+    "LogStats": {
+      "type": "object",
+      "required": [
+        "treeSize"
+      ],
+      "properties": {
+        "entriesByKind": {
+          "description": "The number of entries of each kind added to the log, counted while the search index is enabled",
+          "type": "object",
+          "additionalProperties": {
+            "type": "integer"
+          }
+        },
+        "integrationLatency": {
+          "description": "Percentiles of the time in milliseconds between entries being queued and integrated into the log, over the most recent entries added through this instance",
+          "type": "object",
+          "required": [
+            "samples",
+            "p50",
+            "p90",
+            "p99"
+          ],
+          "properties": {
+            "p50": {
+              "description": "The median integration latency",
+              "type": "integer"
+            },
+            "p90": {
+              "description": "The 90th percentile of integration latency",
+              "type": "integer"
+            },
+            "p99": {
+              "description": "The 99th percentile of integration latency",
+              "type": "integer"
+            },
+            "samples": {
+              "description": "The number of recent entries the percentiles are computed over",
+              "type": "integer"
+            }
+          }
+        },
+        "treeSize": {
+          "description": "The current number of entries in the log",
+          "type": "integer"
+        }
+      }
+    },
     "ProposedEntry": {
       "type": "object",
       "required": [
@@ -508,12 +1269,140 @@ func init() {
       },
       "discriminator": "kind"
     },
+    "RekorConfiguration": {
+      "type": "object",
+      "required": [
+        "logID",
+        "publicKeys",
+        "shards",
+        "apiVersions"
+      ],
+      "properties": {
+        "apiVersions": {
+          "description": "The versions of the REST API served by this instance",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "indexKeyScheme": {
+          "description": "How public keys are turned into the keys used by the search index, such as 'sha256' for the hex-encoded SHA256 hash of the canonical encoding of the key",
+          "type": "string"
+        },
+        "logID": {
+          "description": "The SHA256 hash of the DER-encoded public key of the active shard, expressed in hexadecimal format",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "publicKeys": {
+          "description": "The public keys that can be used to validate signed tree heads produced by this instance",
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": [
+              "logID",
+              "key"
+            ],
+            "properties": {
+              "key": {
+                "description": "The PEM-encoded public key",
+                "type": "string"
+              },
+              "keyHint": {
+                "description": "The hint carried by signed tree heads produced with this key, identifying it as the key to validate them with",
+                "type": "string",
+                "format": "byte"
+              },
+              "logID": {
+                "description": "The SHA256 hash of the DER-encoded public key, expressed in hexadecimal format",
+                "type": "string",
+                "pattern": "^[0-9a-fA-F]{64}$"
+              }
+            }
+          }
+        },
+        "shards": {
+          "description": "The trees backing this instance, including the one currently accepting entries",
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": [
+              "treeID",
+              "logID",
+              "active"
+            ],
+            "properties": {
+              "active": {
+                "description": "Whether new entries are written to this shard",
+                "type": "boolean"
+              },
+              "logID": {
+                "description": "The SHA256 hash of the DER-encoded public key of the shard, expressed in hexadecimal format",
+                "type": "string",
+                "pattern": "^[0-9a-fA-F]{64}$"
+              },
+              "treeID": {
+                "description": "The Trillian tree ID of the shard",
+                "type": "string"
+              }
+            }
+          }
+        }
+      }
+    },
     "SearchIndex": {
       "type": "object",
       "properties": {
+        "certificateIdentity": {
+          "description": "SAN email address or URI of a signing certificate issued by Fulcio; requires oidcIssuer, and matches only certificates issued for this identity after authentication by that issuer\n",
+          "type": "string"
+        },
+        "email": {
+          "description": "Email address of a user ID of the signing key, such as one of the user IDs of a PGP key",
+          "type": "string"
+        },
+        "emailSuffix": {
+          "description": "End of the email addresses to search for, such as @example.com to find the entries of every address of a domain",
+          "type": "string"
+        },
         "hash": {
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+          "pattern": "^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$"
+        },
+        "hashPrefix": {
+          "description": "Hex encoded prefix of at least 8 characters of a digest entries are indexed under, such as a truncated digest from an incident report, to find the entries of every digest starting with it",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{8,128}$"
+        },
+        "image": {
+          "description": "VM image identifier in the form region:id, or just id for images that are not regional",
+          "type": "string"
+        },
+        "keyId": {
+          "description": "Key ID of the certificate of the signing key, such as the key ID of an SSH certificate",
+          "type": "string"
+        },
+        "limit": {
+          "description": "Maximum number of UUIDs to return; if more entries match, a continuation token is returned with the results",
+          "type": "integer",
+          "maximum": 1000,
+          "minimum": 1
+        },
+        "model": {
+          "description": "Name of an ML model, optionally followed by @version",
+          "type": "string"
+        },
+        "oidcIssuer": {
+          "description": "OIDC issuer that authenticated the subject of the signing certificate, as recorded by Fulcio",
+          "type": "string"
+        },
+        "piv": {
+          "description": "Serial number of a PIV device attested to hold the signing key, optionally followed by /slot, such as 12345678/9c",
+          "type": "string"
+        },
+        "principal": {
+          "description": "Identity bound to the signing key by its issuer, such as a principal of an SSH certificate",
+          "type": "string"
         },
         "publicKey": {
           "type": "object",
@@ -531,7 +1420,10 @@ func init() {
                 "pgp",
                 "x509",
                 "minisign",
-                "ssh"
+                "ssh",
+                "ed25519",
+                "tuf",
+                "cosign"
               ]
             },
             "url": {
@@ -539,6 +1431,19 @@ func init() {
               "format": "uri"
             }
           }
+        },
+        "release": {
+          "description": "Release identifier in the form name@version",
+          "type": "string"
+        },
+        "subjectDigest": {
+          "description": "Hex encoded SHA256, SHA384 or SHA512 digest of an artifact that is the subject of an attestation, such as an in-toto statement, to find the attestations about it",
+          "type": "string",
+          "pattern": "^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$"
+        },
+        "uri": {
+          "description": "URI subject alternative name of the signing certificate, such as the workflow identity of a CI job",
+          "type": "string"
         }
       }
     },
@@ -560,6 +1465,10 @@ func init() {
             "minItems": 1
           }
         },
+        "includeProofs": {
+          "description": "Whether to return an inclusion proof with each entry; all of the proofs are against the same tree head",
+          "type": "boolean"
+        },
         "logIndexes": {
           "type": "array",
           "minItems": 1,
@@ -569,8 +1478,138 @@ func init() {
         }
       }
     },
-    "rekord": {
-      "description": "Rekord object",
+    "commitment": {
+      "description": "Encrypted artifact commitment object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/commitment/commitment_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "dct": {
+      "description": "Docker Content Trust (Notary v1) signed targets metadata object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/dct/dct_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "kmod": {
+      "description": "Signed Linux kernel module object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/kmod/kmod_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "mlmodel": {
+      "description": "Signed ML model object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/mlmodel/mlmodel_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "notation": {
+      "description": "Notation (Notary v2) signature object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/notation/notation_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rekord": {
+      "description": "Rekord object",
       "type": "object",
       "allOf": [
         {
@@ -595,6 +1634,32 @@ func init() {
         }
       ]
     },
+    "release": {
+      "description": "Release object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/release/release_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
     "rpm": {
       "description": "RPM object",
       "type": "object",
@@ -620,6 +1685,58 @@ func init() {
           "additionalProperties": false
         }
       ]
+    },
+    "tfprovider": {
+      "description": "Terraform provider release object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/tfprovider/tfprovider_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "vmimage": {
+      "description": "Signed VM or cloud image object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "type": "object",
+              "$ref": "pkg/types/vmimage/vmimage_schema.json"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
     }
   },
   "responses": {
@@ -635,9 +1752,17 @@ func init() {
         "$ref": "#/definitions/Error"
       },
       "headers": {
+        "ETag": {
+          "type": "string",
+          "description": "UUID of the existing entry"
+        },
         "Location": {
           "type": "string",
           "format": "uri"
+        },
+        "X-Rekor-Log-Index": {
+          "type": "integer",
+          "description": "Log index of the existing entry"
         }
       }
     },
@@ -672,6 +1797,30 @@ func init() {
   },
   "host": "api.rekor.dev",
   "paths": {
+    "/.well-known/rekor-configuration": {
+      "get": {
+        "description": "Returns the log ID, public keys, shard map and supported API versions of this transparency log",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Retrieve the parameters needed to bootstrap trust in this instance",
+        "operationId": "getRekorConfiguration",
+        "responses": {
+          "200": {
+            "description": "A JSON object describing the configuration of this transparency log",
+            "schema": {
+              "$ref": "#/definitions/RekorConfiguration"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/v1/index/retrieve": {
       "post": {
         "tags": [
@@ -692,6 +1841,12 @@ func init() {
         "responses": {
           "200": {
             "description": "Returns zero or more entry UUIDs from the transparency log based on search query",
+            "headers": {
+              "X-Rekor-Continuation-Token": {
+                "type": "string",
+                "description": "Token to pass as continuationToken to retrieve the next page of results, if there are more"
+              }
+            },
             "schema": {
               "type": "array",
               "items": {
@@ -740,8 +1895,41 @@ func init() {
         }
       }
     },
+    "/api/v1/log/checkpoint": {
+      "get": {
+        "description": "Returns the current size and root hash of the log as a checkpoint in the signed note format, with an origin line identifying the log, the tree size, the base64-encoded root hash and the signature of the log, for witnesses and verifiers that consume checkpoints.\n",
+        "produces": [
+          "text/plain"
+        ],
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get the current tree head as a signed checkpoint",
+        "operationId": "getLogCheckpoint",
+        "responses": {
+          "200": {
+            "description": "The current tree head as a signed checkpoint",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/v1/log/entries": {
       "get": {
+        "produces": [
+          "application/json;q=1",
+          "application/yaml",
+          "application/cbor",
+          "application/x-protobuf"
+        ],
         "tags": [
           "entries"
         ],
@@ -755,6 +1943,18 @@ func init() {
             "name": "logIndex",
             "in": "query",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests",
+            "name": "includeProof",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the ETag of a copy of the entry held by the client; the entry is not returned if it still matches",
+            "name": "If-None-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -762,6 +1962,21 @@ func init() {
             "description": "the entry in the transparency log requested",
             "schema": {
               "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
+            }
+          },
+          "304": {
+            "description": "the entry has not changed since the copy with the ETag in If-None-Match was retrieved",
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
             }
           },
           "404": {
@@ -807,6 +2022,10 @@ func init() {
                 "type": "string",
                 "format": "uri",
                 "description": "URI location of log entry"
+              },
+              "X-Rekor-Signature-Reuse": {
+                "type": "string",
+                "description": "Comma-separated UUIDs of existing entries that logged the same signature under a different public key"
               }
             }
           },
@@ -822,12 +2041,154 @@ func init() {
               "$ref": "#/definitions/Error"
             },
             "headers": {
-              "Location": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of the existing entry"
+              },
+              "Location": {
                 "type": "string",
                 "format": "uri"
+              },
+              "X-Rekor-Log-Index": {
+                "type": "integer",
+                "description": "Log index of the existing entry"
+              }
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/batch": {
+      "post": {
+        "description": "Creates an entry in the transparency log for each of the proposed entries, queueing them together. The result of each entry is returned in the order the entries were given; an entry that is rejected does not prevent the others from being created.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Creates a batch of entries in the transparency log",
+        "operationId": "createLogEntries",
+        "parameters": [
+          {
+            "name": "proposedEntries",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "type": "array",
+              "maxItems": 100,
+              "minItems": 1,
+              "items": {
+                "$ref": "#/definitions/ProposedEntry"
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Returns the result of each proposed entry, in the order the entries were given",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/BatchEntryResult"
+              }
+            }
+          },
+          "400": {
+            "$ref": "#/responses/BadContent"
+          },
+          "default": {
+            "$ref": "#/responses/InternalServerError"
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/proof": {
+      "post": {
+        "description": "Returns root hash, tree size, and a list of hashes that can be used to calculate proof of an entry being included in the transparency log, for the entry with the given merkle leaf hash or with the given canonicalized content. Clients that only kept the entry they submitted can use it to prove inclusion without knowing its UUID or log index.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Get information required to generate an inclusion proof for an entry identified by its leaf hash or canonicalized content",
+        "operationId": "getLogEntryProofByLeaf",
+        "parameters": [
+          {
+            "name": "query",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/InclusionProofQuery"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Information needed for a client to compute the inclusion proof",
+            "schema": {
+              "$ref": "#/definitions/InclusionProof"
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/range": {
+      "get": {
+        "description": "Returns up to count consecutive entries starting at the entry with index start, in index order. Fewer entries are returned when the log ends before the range does, so clients tailing the log should continue from the index after the last entry returned.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves consecutive entries from the transparency log by index",
+        "operationId": "getLogEntriesByRange",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the index of the first entry to be retrieved",
+            "name": "start",
+            "in": "query",
+            "required": true
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "description": "specifies the number of entries to be retrieved",
+            "name": "count",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entries in the transparency log requested",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/LogEntry"
               }
             }
           },
+          "404": {
+            "description": "The content requested could not be found"
+          },
           "default": {
             "description": "There was an internal error in the server while processing the request",
             "schema": {
@@ -879,8 +2240,150 @@ func init() {
         }
       }
     },
+    "/api/v1/log/entries/time": {
+      "get": {
+        "description": "Returns the log indexes of the entries integrated into the log between start and end inclusive, ordered by integrated time. At most limit indexes are returned, so clients should request the next page with offset increased by the number of indexes returned until fewer than limit are. Only entries added while the search index was enabled are included.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Lists the indexes of entries integrated into the log within a time range",
+        "operationId": "getLogEntryIndexesByTime",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the earliest integrated time, in seconds since the Unix epoch, of entries to be returned",
+            "name": "start",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the latest integrated time, in seconds since the Unix epoch, of entries to be returned",
+            "name": "end",
+            "in": "query",
+            "required": true
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the number of matching log indexes to skip, to continue from a previous page of results",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "description": "specifies the maximum number of log indexes to be returned",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the log indexes of the entries integrated within the time range",
+            "schema": {
+              "type": "array",
+              "items": {
+                "type": "integer"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/entries/validate": {
+      "post": {
+        "description": "Runs the same checks as creating the entry, fetching any external entities and verifying its signature, and returns the canonical entry with the UUID it would be added to the log as, so that clients can check entries before submitting them. Nothing is added to the log.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Checks an entry and returns the UUID it would have without adding it to the transparency log",
+        "operationId": "validateLogEntry",
+        "parameters": [
+          {
+            "name": "proposedEntry",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ProposedEntry"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The entry is valid and would be added to the log as the UUID returned",
+            "schema": {
+              "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID the entry would be added to the log as"
+              },
+              "X-Rekor-Signature-Reuse": {
+                "type": "string",
+                "description": "Comma-separated UUIDs of existing entries that logged the same signature under a different public key"
+              }
+            }
+          },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "409": {
+            "description": "The request conflicts with the current state of the transparency log",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "UUID of the existing entry"
+              },
+              "Location": {
+                "type": "string",
+                "format": "uri"
+              },
+              "X-Rekor-Log-Index": {
+                "type": "integer",
+                "description": "Log index of the existing entry"
+              }
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/api/v1/log/entries/{entryUUID}": {
       "get": {
+        "produces": [
+          "application/json;q=1",
+          "application/yaml",
+          "application/cbor",
+          "application/x-protobuf"
+        ],
         "tags": [
           "entries"
         ],
@@ -894,6 +2397,18 @@ func init() {
             "name": "entryUUID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "boolean",
+            "description": "whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests",
+            "name": "includeProof",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "the ETag of a copy of the entry held by the client; the entry is not returned if it still matches",
+            "name": "If-None-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -901,6 +2416,21 @@ func init() {
             "description": "the entry in the transparency log requested",
             "schema": {
               "$ref": "#/definitions/LogEntry"
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
+            }
+          },
+          "304": {
+            "description": "the entry has not changed since the copy with the ETag in If-None-Match was retrieved",
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "the UUID of the entry in quotes, which is a strong validator as entries never change once integrated"
+              }
             }
           },
           "404": {
@@ -954,7 +2484,7 @@ func init() {
     },
     "/api/v1/log/proof": {
       "get": {
-        "description": "Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log",
+        "description": "Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log. Both sizes may be earlier than the current size of the log, so that tree heads recorded in the past can be checked against each other\n",
         "tags": [
           "tlog"
         ],
@@ -1002,7 +2532,7 @@ func init() {
     },
     "/api/v1/log/publicKey": {
       "get": {
-        "description": "Returns the public key that can be used to validate the signed tree head",
+        "description": "Returns the public key that can be used to validate the signed tree head. Once the key of the log has been rotated, the keys that validate tree heads signed before the rotation are returned when their key hint is given\n",
         "produces": [
           "application/x-pem-file"
         ],
@@ -1011,6 +2541,14 @@ func init() {
         ],
         "summary": "Retrieve the public key that can be used to validate the signed tree head",
         "operationId": "getPublicKey",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "the base64-encoded key hint of the signed tree head to be validated, to retrieve the key it was produced with rather than the active one",
+            "name": "keyHint",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
             "description": "The public key",
@@ -1018,6 +2556,153 @@ func init() {
               "type": "string"
             }
           },
+          "400": {
+            "description": "The content supplied to the server was invalid",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/stats": {
+      "get": {
+        "description": "Returns the current size of the log, the number of entries of each kind and percentiles of the time recent entries took to be integrated, so that dashboards need not crawl the log or scrape metrics to compute them.",
+        "tags": [
+          "tlog"
+        ],
+        "summary": "Get statistics about the entries of the transparency log",
+        "operationId": "getLogStats",
+        "responses": {
+          "200": {
+            "description": "A JSON object with the statistics of the log as properties",
+            "schema": {
+              "$ref": "#/definitions/LogStats"
+            }
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v1/log/stream": {
+      "get": {
+        "description": "Sends an event for each entry as it is integrated into the log, in index order. Each event carries the log index of the entry as its id and the UUID, kind, API version and integrated time of the entry as JSON data. The stream starts at the entry with index start, or after the entry named by Last-Event-ID when a client reconnects, or otherwise with the next entry to be integrated.\n",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "entries"
+        ],
+        "summary": "Streams the entries integrated into the transparency log as server-sent events",
+        "operationId": "streamLogEntries",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the index of the first entry to be streamed",
+            "name": "start",
+            "in": "query"
+          },
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "the index of the last entry received before reconnecting; the stream resumes after it",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of events for newly integrated entries"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v2/log/entries": {
+      "get": {
+        "description": "Returns the entry in the v2 format, which holds the body of the entry in a property named after its kind with the schema of that kind rather than as a generic spec, along with the canonical JSON the UUID of the entry is computed from. The v1 endpoints continue to return entries in the v1 format.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves an entry from the transparency log by index with its body typed by kind",
+        "operationId": "getLogEntryV2ByIndex",
+        "parameters": [
+          {
+            "minimum": 0,
+            "type": "integer",
+            "description": "specifies the index of the entry in the transparency log to be retrieved",
+            "name": "logIndex",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entry in the transparency log requested",
+            "schema": {
+              "$ref": "#/definitions/LogEntryV2"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
+          "default": {
+            "description": "There was an internal error in the server while processing the request",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/api/v2/log/entries/{entryUUID}": {
+      "get": {
+        "description": "Returns the entry in the v2 format, which holds the body of the entry in a property named after its kind with the schema of that kind rather than as a generic spec, along with the canonical JSON the UUID of the entry is computed from. The v1 endpoints continue to return entries in the v1 format.\n",
+        "tags": [
+          "entries"
+        ],
+        "summary": "Retrieves an entry from the transparency log by UUID with its body typed by kind",
+        "operationId": "getLogEntryV2ByUUID",
+        "parameters": [
+          {
+            "pattern": "^[0-9a-fA-F]{64}$",
+            "type": "string",
+            "description": "the UUID of the entry to be retrieved from the log. The UUID is also the merkle tree hash of the entry.",
+            "name": "entryUUID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the entry in the transparency log requested",
+            "schema": {
+              "$ref": "#/definitions/LogEntryV2"
+            }
+          },
+          "404": {
+            "description": "The content requested could not be found"
+          },
           "default": {
             "description": "There was an internal error in the server while processing the request",
             "schema": {
@@ -1029,290 +2714,3764 @@ func init() {
     }
   },
   "definitions": {
-    "ConsistencyProof": {
+    "BatchEntryResult": {
+      "description": "The result of one of the entries of a batch; either the entry created in the transparency log, or the error it was rejected with. An entry already in the log is rejected along with the existing entry.",
+      "type": "object",
+      "properties": {
+        "entry": {
+          "$ref": "#/definitions/LogEntry"
+        },
+        "error": {
+          "$ref": "#/definitions/Error"
+        },
+        "location": {
+          "description": "The URL of the entry, or of the existing entry for an entry already in the log",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "CommitmentV001SchemaCiphertext": {
+      "description": "Information about the encrypted artifact",
       "type": "object",
       "required": [
-        "rootHash",
-        "hashes"
+        "hash"
       ],
       "properties": {
-        "hashes": {
-          "type": "array",
-          "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
+        "content": {
+          "description": "Specifies the ciphertext inline within the document, which is used to check the hash value and is not retained",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the ciphertext",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the ciphertext",
+              "type": "string"
+            }
           }
         },
-        "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+        "url": {
+          "description": "Specifies the location of the ciphertext, which is fetched to check the hash value and is not retained",
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+          "format": "uri"
         }
       }
     },
-    "Error": {
+    "CommitmentV001SchemaCiphertextHash": {
+      "description": "Specifies the hash algorithm and value for the ciphertext",
       "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
       "properties": {
-        "code": {
-          "type": "integer"
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
         },
-        "message": {
+        "value": {
+          "description": "The hash value for the ciphertext",
           "type": "string"
         }
       }
     },
-    "InclusionProof": {
+    "CommitmentV001SchemaPlaintextCommitment": {
+      "description": "The HMAC of the plaintext keyed with a salt that is withheld until the artifact is disclosed",
       "type": "object",
       "required": [
-        "logIndex",
-        "rootHash",
-        "treeSize",
-        "hashes"
+        "algorithm",
+        "value"
       ],
       "properties": {
-        "hashes": {
-          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
-          "type": "array",
-          "items": {
-            "description": "SHA256 hash value expressed in hexadecimal format",
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$"
-          }
-        },
-        "logIndex": {
-          "description": "The index of the entry in the transparency log",
-          "type": "integer",
-          "minimum": 0
-        },
-        "rootHash": {
-          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+        "algorithm": {
+          "description": "The keyed hashing function used to compute the commitment",
           "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
+          "enum": [
+            "hmac-sha256"
+          ]
         },
-        "treeSize": {
-          "description": "The size of the merkle tree at the time the inclusion proof was generated",
-          "type": "integer",
-          "minimum": 1
+        "value": {
+          "description": "The hex encoded commitment value",
+          "type": "string",
+          "pattern": "^[0-9a-f]{64}$"
         }
       }
     },
-    "LogEntry": {
+    "CommitmentV001SchemaSignature": {
+      "description": "Information about the detached signature over the commitment statement",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "CommitmentV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "ConsistencyProof": {
+      "type": "object",
+      "required": [
+        "rootHash",
+        "hashes"
+      ],
+      "properties": {
+        "hashes": {
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree when it held lastSize entries",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "DctV001SchemaMetadata": {
+      "description": "The signed targets metadata (targets.json or a delegated role) as served by the Notary server",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the metadata inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the metadata",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the metadata",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the metadata",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "DctV001SchemaMetadataHash": {
+      "description": "Specifies the hash algorithm and value for the metadata",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the metadata",
+          "type": "string"
+        }
+      }
+    },
+    "DctV001SchemaPublicKey": {
+      "description": "The public key of the role, as a PEM or DER encoded X.509 certificate or public key, or as the TUF key object found in root.json",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "DctV001SchemaTargetsItems0": {
+      "type": "object",
+      "required": [
+        "name",
+        "digest",
+        "length"
+      ],
+      "properties": {
+        "digest": {
+          "description": "The hex encoded SHA256 digest of the target",
+          "type": "string",
+          "pattern": "^[0-9a-f]{64}$"
+        },
+        "length": {
+          "description": "The length of the target in bytes",
+          "type": "integer",
+          "minimum": 0
+        },
+        "name": {
+          "description": "The name of the target, usually an image tag",
+          "type": "string"
+        }
+      }
+    },
+    "Error": {
+      "description": "An error, which is also an RFC 7807 problem details object",
+      "type": "object",
+      "properties": {
+        "apiVersion": {
+          "description": "API version of the proposed entry the error was returned for",
+          "type": "string"
+        },
+        "code": {
+          "type": "integer"
+        },
+        "detail": {
+          "description": "Explanation of this occurrence of the problem; the same as message",
+          "type": "string"
+        },
+        "field": {
+          "description": "Path of the field of the proposed entry that failed validation",
+          "type": "string"
+        },
+        "kind": {
+          "description": "Kind of the proposed entry the error was returned for",
+          "type": "string"
+        },
+        "message": {
+          "type": "string"
+        },
+        "requestId": {
+          "description": "ID of the request the error was returned for, which is also returned in the X-Request-Id header",
+          "type": "string"
+        },
+        "status": {
+          "description": "HTTP status code of the response; the same as code",
+          "type": "integer"
+        },
+        "title": {
+          "description": "Short summary of the type of problem",
+          "type": "string"
+        },
+        "type": {
+          "description": "URI identifying the type of problem, as defined by RFC 7807; clients can rely on it to tell failures apart",
+          "type": "string"
+        }
+      }
+    },
+    "InactiveShardLogInfo": {
+      "type": "object",
+      "required": [
+        "treeID",
+        "rootHash",
+        "treeSize",
+        "signedTreeHead"
+      ],
+      "properties": {
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree of the shard when it was frozen",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "signedTreeHead": {
+          "description": "The signed tree head the shard was frozen at",
+          "type": "object",
+          "required": [
+            "keyHint",
+            "logRoot",
+            "signature"
+          ],
+          "properties": {
+            "keyHint": {
+              "description": "Key hint",
+              "type": "string",
+              "format": "byte"
+            },
+            "logRoot": {
+              "description": "Log root",
+              "type": "string",
+              "format": "byte"
+            },
+            "signature": {
+              "description": "Signature for log root",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        },
+        "treeID": {
+          "description": "The Trillian tree ID of the shard",
+          "type": "string"
+        },
+        "treeSize": {
+          "description": "The number of entries in the shard",
+          "type": "integer",
+          "minimum": 0
+        }
+      }
+    },
+    "InactiveShardLogInfoSignedTreeHead": {
+      "description": "The signed tree head the shard was frozen at",
+      "type": "object",
+      "required": [
+        "keyHint",
+        "logRoot",
+        "signature"
+      ],
+      "properties": {
+        "keyHint": {
+          "description": "Key hint",
+          "type": "string",
+          "format": "byte"
+        },
+        "logRoot": {
+          "description": "Log root",
+          "type": "string",
+          "format": "byte"
+        },
+        "signature": {
+          "description": "Signature for log root",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "InclusionProof": {
+      "type": "object",
+      "required": [
+        "logIndex",
+        "rootHash",
+        "treeSize",
+        "hashes"
+      ],
+      "properties": {
+        "hashes": {
+          "description": "A list of hashes required to compute the inclusion proof, sorted in order from leaf to root",
+          "type": "array",
+          "items": {
+            "description": "SHA256 hash value expressed in hexadecimal format",
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$"
+          }
+        },
+        "logIndex": {
+          "description": "The index of the entry in the transparency log",
+          "type": "integer",
+          "minimum": 0
+        },
+        "rootHash": {
+          "description": "The hash value stored at the root of the merkle tree at the time the proof was generated",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "treeSize": {
+          "description": "The size of the merkle tree at the time the inclusion proof was generated",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "KmodV001SchemaModule": {
+      "description": "Information about the signed kernel module (.ko file) associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the module inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the signed module file",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the signed module file",
+              "type": "string"
+            }
+          }
+        },
+        "strippedHash": {
+          "description": "Specifies the hash algorithm and value for the module with its appended signature removed; this is populated by the server",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the stripped module",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the module; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "KmodV001SchemaModuleHash": {
+      "description": "Specifies the hash algorithm and value for the signed module file",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the signed module file",
+          "type": "string"
+        }
+      }
+    },
+    "KmodV001SchemaModuleStrippedHash": {
+      "description": "Specifies the hash algorithm and value for the module with its appended signature removed; this is populated by the server",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the stripped module",
+          "type": "string"
+        }
+      }
+    },
+    "KmodV001SchemaPublicKey": {
+      "description": "The X.509 certificate that can verify the module signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the certificate inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the certificate",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "InclusionProofQuery": {
+      "description": "Names the entry to prove the inclusion of; exactly one of leafHash and canonicalEntry must be given",
+      "type": "object",
+      "properties": {
+        "canonicalEntry": {
+          "description": "The canonicalized entry as it is stored in the log, whose leaf hash is computed by the server",
+          "type": "string",
+          "format": "byte"
+        },
+        "leafHash": {
+          "description": "The merkle leaf hash of the entry, which is also its UUID",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "LogEntry": {
+      "type": "object",
+      "additionalProperties": {
+        "$ref": "#/definitions/LogEntryAnon"
+      }
+    },
+    "LogEntryAnon": {
+      "type": "object",
+      "required": [
+        "body"
+      ],
+      "properties": {
+        "body": {
+          "type": "object",
+          "additionalProperties": true
+        },
+        "integratedTime": {
+          "type": "integer"
+        },
+        "logIndex": {
+          "type": "integer",
+          "minimum": 0
+        },
+        "verification": {
+          "$ref": "#/definitions/LogEntryAnonVerification"
+        }
+      }
+    },
+    "LogEntryAnonVerification": {
+      "type": "object",
+      "properties": {
+        "inclusionProof": {
+          "$ref": "#/definitions/InclusionProof"
+        },
+        "signedTreeHead": {
+          "description": "The signed tree head the inclusion proof was computed against",
+          "type": "object",
+          "required": [
+            "keyHint",
+            "logRoot",
+            "signature"
+          ],
+          "properties": {
+            "keyHint": {
+              "description": "Key hint",
+              "type": "string",
+              "format": "byte"
+            },
+            "logRoot": {
+              "description": "Log root",
+              "type": "string",
+              "format": "byte"
+            },
+            "signature": {
+              "description": "Signature for log root",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        }
+      }
+    },
+    "LogEntryAnonVerificationSignedTreeHead": {
+      "description": "The signed tree head the inclusion proof was computed against",
+      "type": "object",
+      "required": [
+        "keyHint",
+        "logRoot",
+        "signature"
+      ],
+      "properties": {
+        "keyHint": {
+          "description": "Key hint",
+          "type": "string",
+          "format": "byte"
+        },
+        "logRoot": {
+          "description": "Log root",
+          "type": "string",
+          "format": "byte"
+        },
+        "signature": {
+          "description": "Signature for log root",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "LogEntryV2": {
+      "description": "An entry of the transparency log with the body of its kind as a typed property named after the kind, rather than a generic spec, along with the canonical JSON its UUID is computed from\n",
+      "type": "object",
+      "required": [
+        "uuid",
+        "logIndex",
+        "integratedTime",
+        "kind",
+        "apiVersion",
+        "canonicalBody"
+      ],
+      "properties": {
+        "apiVersion": {
+          "description": "The version of the schema of the kind the entry was proposed with",
+          "type": "string"
+        },
+        "canonicalBody": {
+          "description": "The canonical JSON of the entry exactly as it was hashed into the log",
+          "type": "string",
+          "format": "byte"
+        },
+        "commitment": {
+          "$ref": "#/definitions/commitmentV001Schema"
+        },
+        "dct": {
+          "$ref": "#/definitions/dctV001Schema"
+        },
+        "integratedTime": {
+          "description": "The time the entry was integrated into the log, in seconds since the Unix epoch",
+          "type": "integer"
+        },
+        "kind": {
+          "description": "The kind of the entry, which names the property holding its body",
+          "type": "string"
+        },
+        "kmod": {
+          "$ref": "#/definitions/kmodV001Schema"
+        },
+        "logIndex": {
+          "description": "The index of the entry in the log",
+          "type": "integer",
+          "minimum": 0
+        },
+        "mlmodel": {
+          "$ref": "#/definitions/mlmodelV001Schema"
+        },
+        "notation": {
+          "$ref": "#/definitions/notationV001Schema"
+        },
+        "rekord": {
+          "$ref": "#/definitions/rekordV001Schema"
+        },
+        "release": {
+          "$ref": "#/definitions/releaseV001Schema"
+        },
+        "rpm": {
+          "$ref": "#/definitions/rpmV001Schema"
+        },
+        "tfprovider": {
+          "$ref": "#/definitions/tfproviderV001Schema"
+        },
+        "uuid": {
+          "description": "The UUID of the entry, which is the RFC 6962 leaf hash of its canonical body",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "vmimage": {
+          "$ref": "#/definitions/vmimageV001Schema"
+        }
+      }
+    },
+    "LogInfo": {
+      "type": "object",
+      "required": [
+        "rootHash",
+        "treeSize",
+        "signedTreeHead"
+      ],
+      "properties": {
+        "inactiveShards": {
+          "description": "The frozen shards of the log, oldest first, whose entries precede those of the tree above in the log index",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/InactiveShardLogInfo"
+          }
+        },
+        "rootHash": {
+          "description": "The current hash value stored at the root of the merkle tree",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "signedTreeHead": {
+          "description": "The current signed tree head",
+          "type": "object",
+          "required": [
+            "keyHint",
+            "logRoot",
+            "signature"
+          ],
+          "properties": {
+            "keyHint": {
+              "description": "Key hint",
+              "type": "string",
+              "format": "byte"
+            },
+            "logRoot": {
+              "description": "Log root",
+              "type": "string",
+              "format": "byte"
+            },
+            "signature": {
+              "description": "Signature for log root",
+              "type": "string",
+              "format": "byte"
+            }
+          }
+        },
+        "treeSize": {
+          "description": "The current number of nodes in the merkle tree",
+          "type": "integer",
+          "minimum": 1
+        }
+      }
+    },
+    "LogInfoSignedTreeHead": {
+      "description": "The current signed tree head",
+      "type": "object",
+      "required": [
+        "keyHint",
+        "logRoot",
+        "signature"
+      ],
+      "properties": {
+        "keyHint": {
+          "description": "Key hint",
+          "type": "string",
+          "format": "byte"
+        },
+        "logRoot": {
+          "description": "Log root",
+          "type": "string",
+          "format": "byte"
+        },
+        "signature": {
+          "description": "Signature for log root",
+          "type": "string",
+          "format": "byte"
+        }
+      }
+    },
+    "LogStats": {
+      "type": "object",
+      "required": [
+        "treeSize"
+      ],
+      "properties": {
+        "entriesByKind": {
+          "description": "The number of entries of each kind added to the log, counted while the search index is enabled",
+          "type": "object",
+          "additionalProperties": {
+            "type": "integer"
+          }
+        },
+        "integrationLatency": {
+          "description": "Percentiles of the time in milliseconds between entries being queued and integrated into the log, over the most recent entries added through this instance",
+          "type": "object",
+          "required": [
+            "samples",
+            "p50",
+            "p90",
+            "p99"
+          ],
+          "properties": {
+            "p50": {
+              "description": "The median integration latency",
+              "type": "integer"
+            },
+            "p90": {
+              "description": "The 90th percentile of integration latency",
+              "type": "integer"
+            },
+            "p99": {
+              "description": "The 99th percentile of integration latency",
+              "type": "integer"
+            },
+            "samples": {
+              "description": "The number of recent entries the percentiles are computed over",
+              "type": "integer"
+            }
+          }
+        },
+        "treeSize": {
+          "description": "The current number of entries in the log",
+          "type": "integer",
+          "minimum": 0
+        }
+      }
+    },
+    "LogStatsIntegrationLatency": {
+      "description": "Percentiles of the time in milliseconds between entries being queued and integrated into the log, over the most recent entries added through this instance",
+      "type": "object",
+      "required": [
+        "samples",
+        "p50",
+        "p90",
+        "p99"
+      ],
+      "properties": {
+        "p50": {
+          "description": "The median integration latency",
+          "type": "integer"
+        },
+        "p90": {
+          "description": "The 90th percentile of integration latency",
+          "type": "integer"
+        },
+        "p99": {
+          "description": "The 99th percentile of integration latency",
+          "type": "integer"
+        },
+        "samples": {
+          "description": "The number of recent entries the percentiles are computed over",
+          "type": "integer"
+        }
+      }
+    },
+    "MlmodelV001SchemaModel": {
+      "description": "Information about the signed model weights file",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "name",
+            "format",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "name",
+            "format",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the model weights inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "The serialization format of the model weights",
+          "type": "string",
+          "enum": [
+            "safetensors",
+            "onnx"
+          ]
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the model weights",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the model weights",
+              "type": "string"
+            }
+          }
+        },
+        "name": {
+          "description": "The name of the model as published, e.g. on a model hub",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._/-]*$"
+        },
+        "url": {
+          "description": "Specifies the location of the model weights",
+          "type": "string",
+          "format": "uri"
+        },
+        "version": {
+          "description": "The version or revision of the model",
+          "type": "string"
+        }
+      }
+    },
+    "MlmodelV001SchemaModelHash": {
+      "description": "Specifies the hash algorithm and value for the model weights",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the model weights",
+          "type": "string"
+        }
+      }
+    },
+    "MlmodelV001SchemaProvenance": {
+      "description": "How the model was produced",
+      "type": "object",
+      "properties": {
+        "baseModel": {
+          "description": "The name of the model this one was fine-tuned from, if any",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._/-]*$"
+        },
+        "datasets": {
+          "description": "The datasets the model was trained on",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/MlmodelV001SchemaProvenanceDatasetsItems0"
+          }
+        },
+        "framework": {
+          "description": "The framework used to train the model, e.g. pytorch",
+          "type": "string"
+        },
+        "trainedAt": {
+          "description": "When training of the model completed",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "MlmodelV001SchemaProvenanceDatasetsItems0": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "digest": {
+          "description": "The SHA256 digest of the dataset, expressed in hexadecimal format",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "name": {
+          "description": "The name of the dataset",
+          "type": "string"
+        }
+      }
+    },
+    "MlmodelV001SchemaSignature": {
+      "description": "Information about the detached signature over the model weights file",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "MlmodelV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "NotationV001SchemaEnvelope": {
+      "description": "The Notation signature envelope, which carries the signing certificate chain",
+      "type": "object",
+      "required": [
+        "mediaType"
+      ],
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the signature envelope inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the signature envelope; entries logged while the server stores attestations separately carry only the hash, by which the envelope is retrieved from that storage",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the signature envelope",
+              "type": "string"
+            }
+          }
+        },
+        "mediaType": {
+          "description": "Specifies the type of the signature envelope",
+          "type": "string",
+          "enum": [
+            "application/jose+json",
+            "application/cose"
+          ]
+        },
+        "url": {
+          "description": "Specifies the location of the signature envelope",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "NotationV001SchemaEnvelopeHash": {
+      "description": "Specifies the hash algorithm and value for the signature envelope; entries logged while the server stores attestations separately carry only the hash, by which the envelope is retrieved from that storage",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the signature envelope",
+          "type": "string"
+        }
+      }
+    },
+    "NotationV001SchemaTargetArtifact": {
+      "description": "The OCI descriptor of the signed artifact, as read from the envelope payload; this is populated by the server",
+      "type": "object",
+      "required": [
+        "mediaType",
+        "digest",
+        "size"
+      ],
+      "properties": {
+        "digest": {
+          "description": "The digest of the signed artifact, in the form algorithm:hex",
+          "type": "string",
+          "pattern": "^[a-z0-9]+(?:[+._-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$"
+        },
+        "mediaType": {
+          "description": "The media type of the signed artifact",
+          "type": "string"
+        },
+        "size": {
+          "description": "The size of the signed artifact in bytes",
+          "type": "integer",
+          "minimum": 0
+        }
+      }
+    },
+    "ProposedEntry": {
+      "type": "object",
+      "required": [
+        "kind"
+      ],
+      "properties": {
+        "kind": {
+          "type": "string"
+        }
+      },
+      "discriminator": "kind"
+    },
+    "RekorConfiguration": {
+      "type": "object",
+      "required": [
+        "logID",
+        "publicKeys",
+        "shards",
+        "apiVersions"
+      ],
+      "properties": {
+        "apiVersions": {
+          "description": "The versions of the REST API served by this instance",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "indexKeyScheme": {
+          "description": "How public keys are turned into the keys used by the search index, such as 'sha256' for the hex-encoded SHA256 hash of the canonical encoding of the key",
+          "type": "string"
+        },
+        "logID": {
+          "description": "The SHA256 hash of the DER-encoded public key of the active shard, expressed in hexadecimal format",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "publicKeys": {
+          "description": "The public keys that can be used to validate signed tree heads produced by this instance",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RekorConfigurationPublicKeysItems0"
+          }
+        },
+        "shards": {
+          "description": "The trees backing this instance, including the one currently accepting entries",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RekorConfigurationShardsItems0"
+          }
+        }
+      }
+    },
+    "RekorConfigurationPublicKeysItems0": {
+      "type": "object",
+      "required": [
+        "logID",
+        "key"
+      ],
+      "properties": {
+        "key": {
+          "description": "The PEM-encoded public key",
+          "type": "string"
+        },
+        "keyHint": {
+          "description": "The hint carried by signed tree heads produced with this key, identifying it as the key to validate them with",
+          "type": "string",
+          "format": "byte"
+        },
+        "logID": {
+          "description": "The SHA256 hash of the DER-encoded public key, expressed in hexadecimal format",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        }
+      }
+    },
+    "RekorConfigurationShardsItems0": {
+      "type": "object",
+      "required": [
+        "treeID",
+        "logID",
+        "active"
+      ],
+      "properties": {
+        "active": {
+          "description": "Whether new entries are written to this shard",
+          "type": "boolean"
+        },
+        "logID": {
+          "description": "The SHA256 hash of the DER-encoded public key of the shard, expressed in hexadecimal format",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{64}$"
+        },
+        "treeID": {
+          "description": "The Trillian tree ID of the shard",
+          "type": "string"
+        }
+      }
+    },
+    "RekordV001SchemaData": {
+      "description": "Information about the content associated with the entry; it is omitted when the signature is a PGP cleartext signed message, in which case the signed text is the content",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "additionalHashes": {
+          "description": "Further hashes of the content computed with other algorithms; each is verified against the content and indexed, so that the entry can be found by any of them",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/RekordV001SchemaDataAdditionalHashesItems0"
+          },
+          "x-omitempty": true
+        },
+        "content": {
+          "description": "Specifies the content inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the content",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256",
+                "sha384",
+                "sha512",
+                "sha3-256",
+                "sha3-512",
+                "blake2b-256",
+                "blake3"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the content",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the content; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "RekordV001SchemaDataAdditionalHashesItems0": {
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256",
+            "sha384",
+            "sha512",
+            "sha3-256",
+            "sha3-512",
+            "blake2b-256",
+            "blake3"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the content",
+          "type": "string"
+        }
+      }
+    },
+    "RekordV001SchemaDataHash": {
+      "description": "Specifies the hash algorithm and value for the content",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256",
+            "sha384",
+            "sha512",
+            "sha3-256",
+            "sha3-512",
+            "blake2b-256",
+            "blake3"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the content",
+          "type": "string"
+        }
+      }
+    },
+    "RekordV001SchemaSignature": {
+      "description": "Information about the detached signature associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "RekordV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "ReleaseV001SchemaManifest": {
+      "description": "Information about the signed JSON manifest that lists the artifacts of the release",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the manifest inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the manifest",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the manifest",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the manifest; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "ReleaseV001SchemaManifestHash": {
+      "description": "Specifies the hash algorithm and value for the manifest",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the manifest",
+          "type": "string"
+        }
+      }
+    },
+    "ReleaseV001SchemaRelease": {
+      "description": "The release identifier and artifacts as read from the manifest; this is populated by the server",
+      "type": "object",
+      "required": [
+        "name",
+        "version",
+        "artifacts"
+      ],
+      "properties": {
+        "artifacts": {
+          "description": "The artifacts that make up the release, typically one per platform",
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "$ref": "#/definitions/ReleaseV001SchemaReleaseArtifactsItems0"
+          }
+        },
+        "name": {
+          "description": "The name of the project or component being released",
+          "type": "string",
+          "minLength": 1
+        },
+        "version": {
+          "description": "The version of the release, e.g. v1.2.3",
+          "type": "string",
+          "minLength": 1
+        }
+      }
+    },
+    "ReleaseV001SchemaReleaseArtifactsItems0": {
+      "type": "object",
+      "required": [
+        "hash"
+      ],
+      "properties": {
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the artifact",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the artifact",
+              "type": "string"
+            }
+          }
+        },
+        "name": {
+          "description": "The file name of the artifact",
+          "type": "string"
+        },
+        "platform": {
+          "description": "The platform the artifact was built for, e.g. linux/amd64",
+          "type": "string"
+        }
+      }
+    },
+    "ReleaseV001SchemaReleaseArtifactsItems0Hash": {
+      "description": "Specifies the hash algorithm and value for the artifact",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the artifact",
+          "type": "string"
+        }
+      }
+    },
+    "ReleaseV001SchemaSignature": {
+      "description": "Information about the detached signature over the release manifest",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "ReleaseV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "RpmV001SchemaPackage": {
+      "description": "Information about the package associated with the entry",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the package inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the package",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the package",
+              "type": "string"
+            }
+          }
+        },
+        "headers": {
+          "description": "Values of the RPM headers",
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "RpmV001SchemaPackageHash": {
+      "description": "Specifies the hash algorithm and value for the package",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the package",
+          "type": "string"
+        }
+      }
+    },
+    "RpmV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the RPM signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "SearchIndex": {
+      "type": "object",
+      "properties": {
+        "certificateIdentity": {
+          "description": "SAN email address or URI of a signing certificate issued by Fulcio; requires oidcIssuer, and matches only certificates issued for this identity after authentication by that issuer\n",
+          "type": "string"
+        },
+        "email": {
+          "description": "Email address of a user ID of the signing key, such as one of the user IDs of a PGP key",
+          "type": "string"
+        },
+        "emailSuffix": {
+          "description": "End of the email addresses to search for, such as @example.com to find the entries of every address of a domain",
+          "type": "string"
+        },
+        "hash": {
+          "type": "string",
+          "pattern": "^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$"
+        },
+        "hashPrefix": {
+          "description": "Hex encoded prefix of at least 8 characters of a digest entries are indexed under, such as a truncated digest from an incident report, to find the entries of every digest starting with it",
+          "type": "string",
+          "pattern": "^[0-9a-fA-F]{8,128}$"
+        },
+        "image": {
+          "description": "VM image identifier in the form region:id, or just id for images that are not regional",
+          "type": "string"
+        },
+        "keyId": {
+          "description": "Key ID of the certificate of the signing key, such as the key ID of an SSH certificate",
+          "type": "string"
+        },
+        "limit": {
+          "description": "Maximum number of UUIDs to return; if more entries match, a continuation token is returned with the results",
+          "type": "integer",
+          "maximum": 1000,
+          "minimum": 1
+        },
+        "model": {
+          "description": "Name of an ML model, optionally followed by @version",
+          "type": "string"
+        },
+        "oidcIssuer": {
+          "description": "OIDC issuer that authenticated the subject of the signing certificate, as recorded by Fulcio",
+          "type": "string"
+        },
+        "piv": {
+          "description": "Serial number of a PIV device attested to hold the signing key, optionally followed by /slot, such as 12345678/9c",
+          "type": "string"
+        },
+        "principal": {
+          "description": "Identity bound to the signing key by its issuer, such as a principal of an SSH certificate",
+          "type": "string"
+        },
+        "publicKey": {
+          "type": "object",
+          "required": [
+            "format"
+          ],
+          "properties": {
+            "content": {
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "type": "string",
+              "enum": [
+                "pgp",
+                "x509",
+                "minisign",
+                "ssh",
+                "ed25519",
+                "tuf",
+                "cosign"
+              ]
+            },
+            "url": {
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "release": {
+          "description": "Release identifier in the form name@version",
+          "type": "string"
+        },
+        "subjectDigest": {
+          "description": "Hex encoded SHA256, SHA384 or SHA512 digest of an artifact that is the subject of an attestation, such as an in-toto statement, to find the attestations about it",
+          "type": "string",
+          "pattern": "^([0-9a-fA-F]{64}|[0-9a-fA-F]{96}|[0-9a-fA-F]{128})$"
+        },
+        "uri": {
+          "description": "URI subject alternative name of the signing certificate, such as the workflow identity of a CI job",
+          "type": "string"
+        }
+      }
+    },
+    "SearchIndexPublicKey": {
+      "type": "object",
+      "required": [
+        "format"
+      ],
+      "properties": {
+        "content": {
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "type": "string",
+          "enum": [
+            "pgp",
+            "x509",
+            "minisign",
+            "ssh",
+            "ed25519",
+            "tuf",
+            "cosign"
+          ]
+        },
+        "url": {
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "SearchLogQuery": {
+      "type": "object",
+      "properties": {
+        "entries": {
+          "type": "array",
+          "items": {
+            "minItems": 1,
+            "$ref": "#/definitions/ProposedEntry"
+          }
+        },
+        "entryUUIDs": {
+          "type": "array",
+          "items": {
+            "type": "string",
+            "pattern": "^[0-9a-fA-F]{64}$",
+            "minItems": 1
+          }
+        },
+        "includeProofs": {
+          "description": "Whether to return an inclusion proof with each entry; all of the proofs are against the same tree head",
+          "type": "boolean"
+        },
+        "logIndexes": {
+          "type": "array",
+          "minItems": 1,
+          "items": {
+            "type": "integer",
+            "minimum": 0
+          }
+        }
+      }
+    },
+    "TfproviderV001SchemaPublicKey": {
+      "description": "The PGP public key that can verify the SHA256SUMS signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "TfproviderV001SchemaSha256sums": {
+      "description": "Information about the SHA256SUMS file published with the provider release",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "archives": {
+          "description": "The provider zip archives listed in the SHA256SUMS file; this is populated by the server",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/TfproviderV001SchemaSha256sumsArchivesItems0"
+          }
+        },
+        "content": {
+          "description": "Specifies the SHA256SUMS file inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the SHA256SUMS file",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the SHA256SUMS file",
+              "type": "string"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the SHA256SUMS file; if this is specified, a hash value must also be provided",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "TfproviderV001SchemaSha256sumsArchivesItems0": {
+      "type": "object",
+      "required": [
+        "filename",
+        "sha256"
+      ],
+      "properties": {
+        "filename": {
+          "description": "The file name of the provider archive",
+          "type": "string"
+        },
+        "sha256": {
+          "description": "The SHA256 digest of the provider archive",
+          "type": "string"
+        }
+      }
+    },
+    "TfproviderV001SchemaSha256sumsHash": {
+      "description": "Specifies the hash algorithm and value for the SHA256SUMS file",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the SHA256SUMS file",
+          "type": "string"
+        }
+      }
+    },
+    "TfproviderV001SchemaSignature": {
+      "description": "The detached PGP signature over the SHA256SUMS file",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "VmimageV001SchemaImage": {
+      "description": "Information about the signed image",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the image inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "The format of the image; for cloud images (e.g. ami) the signed content is the image manifest",
+          "type": "string",
+          "enum": [
+            "qcow2",
+            "raw",
+            "vmdk",
+            "vhd",
+            "ova",
+            "ami"
+          ]
+        },
+        "hash": {
+          "description": "Specifies the hash algorithm and value for the image",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The hashing function used to compute the hash value",
+              "type": "string",
+              "enum": [
+                "sha256"
+              ]
+            },
+            "value": {
+              "description": "The hash value for the image",
+              "type": "string"
+            }
+          }
+        },
+        "identifier": {
+          "description": "Where the image is published",
+          "type": "object",
+          "required": [
+            "id"
+          ],
+          "properties": {
+            "id": {
+              "description": "The identifier assigned to the image by the provider",
+              "type": "string",
+              "pattern": "^[A-Za-z0-9][A-Za-z0-9._:/-]*$"
+            },
+            "provider": {
+              "description": "The cloud provider or registry the image is published to",
+              "type": "string",
+              "pattern": "^[a-z0-9][a-z0-9-]*$"
+            },
+            "region": {
+              "description": "The region the image is published in, for regional images",
+              "type": "string",
+              "pattern": "^[a-z0-9][a-z0-9-]*$"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the image",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "VmimageV001SchemaImageHash": {
+      "description": "Specifies the hash algorithm and value for the image",
+      "type": "object",
+      "required": [
+        "algorithm",
+        "value"
+      ],
+      "properties": {
+        "algorithm": {
+          "description": "The hashing function used to compute the hash value",
+          "type": "string",
+          "enum": [
+            "sha256"
+          ]
+        },
+        "value": {
+          "description": "The hash value for the image",
+          "type": "string"
+        }
+      }
+    },
+    "VmimageV001SchemaImageIdentifier": {
+      "description": "Where the image is published",
+      "type": "object",
+      "required": [
+        "id"
+      ],
+      "properties": {
+        "id": {
+          "description": "The identifier assigned to the image by the provider",
+          "type": "string",
+          "pattern": "^[A-Za-z0-9][A-Za-z0-9._:/-]*$"
+        },
+        "provider": {
+          "description": "The cloud provider or registry the image is published to",
+          "type": "string",
+          "pattern": "^[a-z0-9][a-z0-9-]*$"
+        },
+        "region": {
+          "description": "The region the image is published in, for regional images",
+          "type": "string",
+          "pattern": "^[a-z0-9][a-z0-9-]*$"
+        }
+      }
+    },
+    "VmimageV001SchemaSignature": {
+      "description": "Information about the detached signature over the image (or, for cloud images, its manifest)",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "format",
+            "publicKey",
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the signature inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "format": {
+          "description": "Specifies the format of the signature",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "The public key that can verify the signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "url": {
+          "description": "Specifies the location of the signature",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "VmimageV001SchemaSignaturePublicKey": {
+      "description": "The public key that can verify the signature",
+      "type": "object",
+      "oneOf": [
+        {
+          "required": [
+            "url"
+          ]
+        },
+        {
+          "required": [
+            "content"
+          ]
+        }
+      ],
+      "properties": {
+        "content": {
+          "description": "Specifies the content of the public key inline within the document",
+          "type": "string",
+          "format": "byte"
+        },
+        "url": {
+          "description": "Specifies the location of the public key",
+          "type": "string",
+          "format": "uri"
+        }
+      }
+    },
+    "commitment": {
+      "description": "Encrypted artifact commitment object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/commitmentSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "commitmentSchema": {
+      "description": "Schema for encrypted artifact commitment objects",
+      "type": "object",
+      "title": "Encrypted Artifact Commitment Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/commitmentV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/commitment/commitment_schema.json"
+    },
+    "commitmentV001Schema": {
+      "description": "Schema for entries committing to a confidential artifact by the hash of its ciphertext and a salted HMAC of its plaintext",
+      "type": "object",
+      "title": "Encrypted Artifact Commitment v0.0.1 Schema",
+      "required": [
+        "ciphertext",
+        "plaintextCommitment",
+        "signature"
+      ],
+      "properties": {
+        "ciphertext": {
+          "description": "Information about the encrypted artifact",
+          "type": "object",
+          "required": [
+            "hash"
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the ciphertext inline within the document, which is used to check the hash value and is not retained",
+              "type": "string",
+              "format": "byte"
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the ciphertext",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the ciphertext",
+                  "type": "string"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the ciphertext, which is fetched to check the hash value and is not retained",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "plaintextCommitment": {
+          "description": "The HMAC of the plaintext keyed with a salt that is withheld until the artifact is disclosed",
+          "type": "object",
+          "required": [
+            "algorithm",
+            "value"
+          ],
+          "properties": {
+            "algorithm": {
+              "description": "The keyed hashing function used to compute the commitment",
+              "type": "string",
+              "enum": [
+                "hmac-sha256"
+              ]
+            },
+            "value": {
+              "description": "The hex encoded commitment value",
+              "type": "string",
+              "pattern": "^[0-9a-f]{64}$"
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the detached signature over the commitment statement",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the format of the signature",
+              "type": "string"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                },
+                "url": {
+                  "description": "Specifies the location of the public key",
+                  "type": "string",
+                  "format": "uri"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/commitment/commitment_v0_0_1_schema.json"
+    },
+    "dct": {
+      "description": "Docker Content Trust (Notary v1) signed targets metadata object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/dctSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "dctSchema": {
+      "description": "Schema for Docker Content Trust (Notary v1) signed targets metadata objects",
+      "type": "object",
+      "title": "Docker Content Trust Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/dctV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/dct/dct_schema.json"
+    },
+    "dctV001Schema": {
+      "description": "Schema for Docker Content Trust (Notary v1) signed targets metadata entries",
+      "type": "object",
+      "title": "Docker Content Trust v0.0.1 Schema",
+      "required": [
+        "gun",
+        "publicKey",
+        "metadata"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "gun": {
+          "description": "The globally unique name of the repository the trust data belongs to, e.g. docker.io/library/alpine",
+          "type": "string",
+          "pattern": "^[a-zA-Z0-9][a-zA-Z0-9._:/-]*$"
+        },
+        "metadata": {
+          "description": "The signed targets metadata (targets.json or a delegated role) as served by the Notary server",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the metadata inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the metadata",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the metadata",
+                  "type": "string"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the metadata",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The public key of the role, as a PEM or DER encoded X.509 certificate or public key, or as the TUF key object found in root.json",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "role": {
+          "description": "The TUF role that signed the metadata; defaults to targets",
+          "type": "string",
+          "pattern": "^targets(/[a-zA-Z0-9._-]+)*$"
+        },
+        "targets": {
+          "description": "The targets listed in the metadata; this is populated by the server",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DctV001SchemaTargetsItems0"
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/dct/dct_v0_0_1_schema.json"
+    },
+    "kmod": {
+      "description": "Signed Linux kernel module object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/kmodSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "kmodSchema": {
+      "description": "Schema for signed Linux kernel module objects",
+      "type": "object",
+      "title": "Kernel Module Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/kmodV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/kmod/kmod_schema.json"
+    },
+    "kmodV001Schema": {
+      "description": "Schema for signed Linux kernel module entries",
+      "type": "object",
+      "title": "Kernel Module v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "module"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "module": {
+          "description": "Information about the signed kernel module (.ko file) associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the module inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the signed module file",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the signed module file",
+                  "type": "string"
+                }
+              }
+            },
+            "strippedHash": {
+              "description": "Specifies the hash algorithm and value for the module with its appended signature removed; this is populated by the server",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the stripped module",
+                  "type": "string"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the module; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "publicKey": {
+          "description": "The X.509 certificate that can verify the module signature",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the certificate inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the certificate",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/kmod/kmod_v0_0_1_schema.json"
+    },
+    "mlmodel": {
+      "description": "Signed ML model object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/mlmodelSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "mlmodelSchema": {
+      "description": "Schema for ML model objects",
+      "type": "object",
+      "title": "ML Model Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/mlmodelV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/mlmodel/mlmodel_schema.json"
+    },
+    "mlmodelV001Schema": {
+      "description": "Schema for ML model object",
+      "type": "object",
+      "title": "ML Model v0.0.1 Schema",
+      "required": [
+        "signature",
+        "model"
+      ],
+      "properties": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "model": {
+          "description": "Information about the signed model weights file",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "name",
+                "format",
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "name",
+                "format",
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the model weights inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "The serialization format of the model weights",
+              "type": "string",
+              "enum": [
+                "safetensors",
+                "onnx"
+              ]
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the model weights",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the model weights",
+                  "type": "string"
+                }
+              }
+            },
+            "name": {
+              "description": "The name of the model as published, e.g. on a model hub",
+              "type": "string",
+              "pattern": "^[A-Za-z0-9][A-Za-z0-9._/-]*$"
+            },
+            "url": {
+              "description": "Specifies the location of the model weights",
+              "type": "string",
+              "format": "uri"
+            },
+            "version": {
+              "description": "The version or revision of the model",
+              "type": "string"
+            }
+          }
+        },
+        "provenance": {
+          "description": "How the model was produced",
+          "type": "object",
+          "properties": {
+            "baseModel": {
+              "description": "The name of the model this one was fine-tuned from, if any",
+              "type": "string",
+              "pattern": "^[A-Za-z0-9][A-Za-z0-9._/-]*$"
+            },
+            "datasets": {
+              "description": "The datasets the model was trained on",
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/MlmodelV001SchemaProvenanceDatasetsItems0"
+              }
+            },
+            "framework": {
+              "description": "The framework used to train the model, e.g. pytorch",
+              "type": "string"
+            },
+            "trainedAt": {
+              "description": "When training of the model completed",
+              "type": "string",
+              "format": "date-time"
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the detached signature over the model weights file",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the format of the signature",
+              "type": "string"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                },
+                "url": {
+                  "description": "Specifies the location of the public key",
+                  "type": "string",
+                  "format": "uri"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/mlmodel/mlmodel_v0_0_1_schema.json"
+    },
+    "notation": {
+      "description": "Notation (Notary v2) signature object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/notationSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "notationSchema": {
+      "description": "Schema for Notation signature envelope objects",
+      "type": "object",
+      "title": "Notation Signature Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/notationV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/notation/notation_schema.json"
+    },
+    "notationV001Schema": {
+      "description": "Schema for Notation (Notary v2) signature envelope entries",
+      "type": "object",
+      "title": "Notation v0.0.1 Schema",
+      "required": [
+        "envelope"
+      ],
+      "properties": {
+        "envelope": {
+          "description": "The Notation signature envelope, which carries the signing certificate chain",
+          "type": "object",
+          "required": [
+            "mediaType"
+          ],
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the signature envelope inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the signature envelope; entries logged while the server stores attestations separately carry only the hash, by which the envelope is retrieved from that storage",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the signature envelope",
+                  "type": "string"
+                }
+              }
+            },
+            "mediaType": {
+              "description": "Specifies the type of the signature envelope",
+              "type": "string",
+              "enum": [
+                "application/jose+json",
+                "application/cose"
+              ]
+            },
+            "url": {
+              "description": "Specifies the location of the signature envelope",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "targetArtifact": {
+          "description": "The OCI descriptor of the signed artifact, as read from the envelope payload; this is populated by the server",
+          "type": "object",
+          "required": [
+            "mediaType",
+            "digest",
+            "size"
+          ],
+          "properties": {
+            "digest": {
+              "description": "The digest of the signed artifact, in the form algorithm:hex",
+              "type": "string",
+              "pattern": "^[a-z0-9]+(?:[+._-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$"
+            },
+            "mediaType": {
+              "description": "The media type of the signed artifact",
+              "type": "string"
+            },
+            "size": {
+              "description": "The size of the signed artifact in bytes",
+              "type": "integer",
+              "minimum": 0
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/notation/notation_v0_0_1_schema.json"
+    },
+    "rekord": {
+      "description": "Rekord object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/rekordSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "rekordSchema": {
+      "description": "Schema for Rekord objects",
+      "type": "object",
+      "title": "Rekor Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/rekordV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/rekord/rekord_schema.json"
+    },
+    "rekordV001Schema": {
+      "description": "Schema for Rekord object",
+      "type": "object",
+      "title": "Rekor v0.0.1 Schema",
+      "required": [
+        "signature"
+      ],
+      "properties": {
+        "data": {
+          "description": "Information about the content associated with the entry; it is omitted when the signature is a PGP cleartext signed message, in which case the signed text is the content",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "additionalHashes": {
+              "description": "Further hashes of the content computed with other algorithms; each is verified against the content and indexed, so that the entry can be found by any of them",
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/RekordV001SchemaDataAdditionalHashesItems0"
+              },
+              "x-omitempty": true
+            },
+            "content": {
+              "description": "Specifies the content inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the content",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256",
+                    "sha384",
+                    "sha512",
+                    "sha3-256",
+                    "sha3-512",
+                    "blake2b-256",
+                    "blake3"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the content",
+                  "type": "string"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the content; if this is specified, a hash value must also be provided",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "signature": {
+          "description": "Information about the detached signature associated with the entry",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the format of the signature",
+              "type": "string"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                },
+                "url": {
+                  "description": "Specifies the location of the public key",
+                  "type": "string",
+                  "format": "uri"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/rekord/rekord_v0_0_1_schema.json"
+    },
+    "release": {
+      "description": "Release object",
+      "type": "object",
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
+        },
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/releaseSchema"
+            }
+          },
+          "additionalProperties": false
+        }
+      ]
+    },
+    "releaseSchema": {
+      "description": "Schema for release objects",
       "type": "object",
-      "additionalProperties": {
-        "$ref": "#/definitions/LogEntryAnon"
-      }
+      "title": "Release Schema",
+      "oneOf": [
+        {
+          "$ref": "#/definitions/releaseV001Schema"
+        }
+      ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/release/release_schema.json"
     },
-    "LogEntryAnon": {
+    "releaseV001Schema": {
+      "description": "Schema for release entries grouping all per-platform artifacts of a single release",
       "type": "object",
+      "title": "Release v0.0.1 Schema",
       "required": [
-        "body"
+        "signature",
+        "manifest"
       ],
       "properties": {
-        "body": {
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
           "type": "object",
           "additionalProperties": true
         },
-        "integratedTime": {
-          "type": "integer"
-        },
-        "logIndex": {
-          "type": "integer",
-          "minimum": 0
-        }
-      }
-    },
-    "LogInfo": {
-      "type": "object",
-      "required": [
-        "rootHash",
-        "treeSize",
-        "signedTreeHead"
-      ],
-      "properties": {
-        "rootHash": {
-          "description": "The current hash value stored at the root of the merkle tree",
-          "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
-        },
-        "signedTreeHead": {
-          "description": "The current signed tree head",
+        "manifest": {
+          "description": "Information about the signed JSON manifest that lists the artifacts of the release",
           "type": "object",
-          "required": [
-            "keyHint",
-            "logRoot",
-            "signature"
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
           ],
           "properties": {
-            "keyHint": {
-              "description": "Key hint",
+            "content": {
+              "description": "Specifies the manifest inline within the document",
               "type": "string",
               "format": "byte"
             },
-            "logRoot": {
-              "description": "Log root",
-              "type": "string",
-              "format": "byte"
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the manifest",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the manifest",
+                  "type": "string"
+                }
+              }
             },
-            "signature": {
-              "description": "Signature for log root",
+            "url": {
+              "description": "Specifies the location of the manifest; if this is specified, a hash value must also be provided",
               "type": "string",
-              "format": "byte"
+              "format": "uri"
             }
           }
         },
-        "treeSize": {
-          "description": "The current number of nodes in the merkle tree",
-          "type": "integer",
-          "minimum": 1
-        }
-      }
-    },
-    "LogInfoSignedTreeHead": {
-      "description": "The current signed tree head",
-      "type": "object",
-      "required": [
-        "keyHint",
-        "logRoot",
-        "signature"
-      ],
-      "properties": {
-        "keyHint": {
-          "description": "Key hint",
-          "type": "string",
-          "format": "byte"
-        },
-        "logRoot": {
-          "description": "Log root",
-          "type": "string",
-          "format": "byte"
-        },
-        "signature": {
-          "description": "Signature for log root",
-          "type": "string",
-          "format": "byte"
-        }
-      }
-    },
-    "ProposedEntry": {
-      "type": "object",
-      "required": [
-        "kind"
-      ],
-      "properties": {
-        "kind": {
-          "type": "string"
-        }
-      },
-      "discriminator": "kind"
-    },
-    "RekordV001SchemaData": {
-      "description": "Information about the content associated with the entry",
-      "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the content",
+        "release": {
+          "description": "The release identifier and artifacts as read from the manifest; this is populated by the server",
           "type": "object",
           "required": [
-            "algorithm",
-            "value"
+            "name",
+            "version",
+            "artifacts"
           ],
           "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
+            "artifacts": {
+              "description": "The artifacts that make up the release, typically one per platform",
+              "type": "array",
+              "minItems": 1,
+              "items": {
+                "$ref": "#/definitions/ReleaseV001SchemaReleaseArtifactsItems0"
+              }
+            },
+            "name": {
+              "description": "The name of the project or component being released",
               "type": "string",
-              "enum": [
-                "sha256"
+              "minLength": 1
+            },
+            "version": {
+              "description": "The version of the release, e.g. v1.2.3",
+              "type": "string",
+              "minLength": 1
+            }
+          }
+        },
+        "signature": {
+          "description": "Information about the detached signature over the release manifest",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "url"
               ]
             },
-            "value": {
-              "description": "The hash value for the content",
+            {
+              "required": [
+                "format",
+                "publicKey",
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "content": {
+              "description": "Specifies the content of the signature inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "format": {
+              "description": "Specifies the format of the signature",
               "type": "string"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                },
+                "url": {
+                  "description": "Specifies the location of the public key",
+                  "type": "string",
+                  "format": "uri"
+                }
+              }
+            },
+            "url": {
+              "description": "Specifies the location of the signature",
+              "type": "string",
+              "format": "uri"
             }
           }
-        },
-        "url": {
-          "description": "Specifies the location of the content; if this is specified, a hash value must also be provided",
-          "type": "string",
-          "format": "uri"
         }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/release/release_v0_0_1_schema.json"
     },
-    "RekordV001SchemaDataHash": {
-      "description": "Specifies the hash algorithm and value for the content",
+    "rpm": {
+      "description": "RPM object",
       "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
+      "allOf": [
+        {
+          "$ref": "#/definitions/ProposedEntry"
         },
-        "value": {
-          "description": "The hash value for the content",
-          "type": "string"
+        {
+          "required": [
+            "apiVersion",
+            "spec"
+          ],
+          "properties": {
+            "apiVersion": {
+              "type": "string",
+              "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
+            },
+            "spec": {
+              "$ref": "#/definitions/rpmSchema"
+            }
+          },
+          "additionalProperties": false
         }
-      }
+      ]
     },
-    "RekordV001SchemaSignature": {
-      "description": "Information about the detached signature associated with the entry",
+    "rpmSchema": {
+      "description": "Schema for RPM objects",
       "type": "object",
+      "title": "RPM Schema",
       "oneOf": [
         {
-          "required": [
-            "format",
-            "publicKey",
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "format",
-            "publicKey",
-            "content"
-          ]
+          "$ref": "#/definitions/rpmV001Schema"
         }
       ],
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/rpm/rpm_schema.json"
+    },
+    "rpmV001Schema": {
+      "description": "Schema for RPM entries",
+      "type": "object",
+      "title": "RPM v0.0.1 Schema",
+      "required": [
+        "publicKey",
+        "package"
+      ],
       "properties": {
-        "content": {
-          "description": "Specifies the content of the signature inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "format": {
-          "description": "Specifies the format of the signature",
-          "type": "string",
-          "enum": [
-            "pgp",
-            "minisign",
-            "x509",
-            "ssh"
-          ]
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
         },
-        "publicKey": {
-          "description": "The public key that can verify the signature",
+        "package": {
+          "description": "Information about the package associated with the entry",
           "type": "object",
           "oneOf": [
             {
@@ -1328,246 +6487,79 @@ func init() {
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the public key inline within the document",
+              "description": "Specifies the package inline within the document",
               "type": "string",
               "format": "byte"
             },
+            "hash": {
+              "description": "Specifies the hash algorithm and value for the package",
+              "type": "object",
+              "required": [
+                "algorithm",
+                "value"
+              ],
+              "properties": {
+                "algorithm": {
+                  "description": "The hashing function used to compute the hash value",
+                  "type": "string",
+                  "enum": [
+                    "sha256"
+                  ]
+                },
+                "value": {
+                  "description": "The hash value for the package",
+                  "type": "string"
+                }
+              }
+            },
+            "headers": {
+              "description": "Values of the RPM headers",
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              }
+            },
             "url": {
-              "description": "Specifies the location of the public key",
+              "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
               "type": "string",
               "format": "uri"
             }
           }
         },
-        "url": {
-          "description": "Specifies the location of the signature",
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "RekordV001SchemaSignaturePublicKey": {
-      "description": "The public key that can verify the signature",
-      "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "url": {
-          "description": "Specifies the location of the public key",
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "RpmV001SchemaPackage": {
-      "description": "Information about the package associated with the entry",
-      "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the package inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "hash": {
-          "description": "Specifies the hash algorithm and value for the package",
+        "publicKey": {
+          "description": "The PGP public key that can verify the RPM signature",
           "type": "object",
-          "required": [
-            "algorithm",
-            "value"
-          ],
-          "properties": {
-            "algorithm": {
-              "description": "The hashing function used to compute the hash value",
-              "type": "string",
-              "enum": [
-                "sha256"
+          "oneOf": [
+            {
+              "required": [
+                "url"
               ]
             },
-            "value": {
-              "description": "The hash value for the package",
-              "type": "string"
-            }
-          }
-        },
-        "headers": {
-          "description": "Values of the RPM headers",
-          "type": "object",
-          "additionalProperties": {
-            "type": "string"
-          }
-        },
-        "url": {
-          "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "RpmV001SchemaPackageHash": {
-      "description": "Specifies the hash algorithm and value for the package",
-      "type": "object",
-      "required": [
-        "algorithm",
-        "value"
-      ],
-      "properties": {
-        "algorithm": {
-          "description": "The hashing function used to compute the hash value",
-          "type": "string",
-          "enum": [
-            "sha256"
-          ]
-        },
-        "value": {
-          "description": "The hash value for the package",
-          "type": "string"
-        }
-      }
-    },
-    "RpmV001SchemaPublicKey": {
-      "description": "The PGP public key that can verify the RPM signature",
-      "type": "object",
-      "oneOf": [
-        {
-          "required": [
-            "url"
-          ]
-        },
-        {
-          "required": [
-            "content"
-          ]
-        }
-      ],
-      "properties": {
-        "content": {
-          "description": "Specifies the content of the public key inline within the document",
-          "type": "string",
-          "format": "byte"
-        },
-        "url": {
-          "description": "Specifies the location of the public key",
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "SearchIndex": {
-      "type": "object",
-      "properties": {
-        "hash": {
-          "type": "string",
-          "pattern": "^[0-9a-fA-F]{64}$"
-        },
-        "publicKey": {
-          "type": "object",
-          "required": [
-            "format"
+            {
+              "required": [
+                "content"
+              ]
+            }
           ],
           "properties": {
             "content": {
+              "description": "Specifies the content of the public key inline within the document",
               "type": "string",
               "format": "byte"
             },
-            "format": {
-              "type": "string",
-              "enum": [
-                "pgp",
-                "x509",
-                "minisign",
-                "ssh"
-              ]
-            },
             "url": {
+              "description": "Specifies the location of the public key",
               "type": "string",
               "format": "uri"
             }
           }
         }
-      }
-    },
-    "SearchIndexPublicKey": {
-      "type": "object",
-      "required": [
-        "format"
-      ],
-      "properties": {
-        "content": {
-          "type": "string",
-          "format": "byte"
-        },
-        "format": {
-          "type": "string",
-          "enum": [
-            "pgp",
-            "x509",
-            "minisign",
-            "ssh"
-          ]
-        },
-        "url": {
-          "type": "string",
-          "format": "uri"
-        }
-      }
-    },
-    "SearchLogQuery": {
-      "type": "object",
-      "properties": {
-        "entries": {
-          "type": "array",
-          "items": {
-            "minItems": 1,
-            "$ref": "#/definitions/ProposedEntry"
-          }
-        },
-        "entryUUIDs": {
-          "type": "array",
-          "items": {
-            "type": "string",
-            "pattern": "^[0-9a-fA-F]{64}$",
-            "minItems": 1
-          }
-        },
-        "logIndexes": {
-          "type": "array",
-          "minItems": 1,
-          "items": {
-            "type": "integer",
-            "minimum": 0
-          }
-        }
-      }
+      },
+      "$schema": "http://json-schema.org/draft-07/schema",
+      "$id": "http://rekor.dev/types/rpm/rpm_v0_0_1_schema.json"
     },
-    "rekord": {
-      "description": "Rekord object",
+    "tfprovider": {
+      "description": "Terraform provider release object",
       "type": "object",
       "allOf": [
         {
@@ -1584,36 +6576,42 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/rekordSchema"
+              "$ref": "#/definitions/tfproviderSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rekordSchema": {
-      "description": "Schema for Rekord objects",
+    "tfproviderSchema": {
+      "description": "Schema for Terraform provider release objects",
       "type": "object",
-      "title": "Rekor Schema",
+      "title": "Terraform Provider Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/rekordV001Schema"
+          "$ref": "#/definitions/tfproviderV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.dev/types/rekord/rekord_schema.json"
+      "$id": "http://rekor.dev/types/tfprovider/tfprovider_schema.json"
     },
-    "rekordV001Schema": {
-      "description": "Schema for Rekord object",
+    "tfproviderV001Schema": {
+      "description": "Schema for Terraform provider release entries",
       "type": "object",
-      "title": "Rekor v0.0.1 Schema",
+      "title": "Terraform Provider v0.0.1 Schema",
       "required": [
+        "publicKey",
         "signature",
-        "data"
+        "sha256sums"
       ],
       "properties": {
-        "data": {
-          "description": "Information about the content associated with the entry",
+        "extraData": {
+          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "publicKey": {
+          "description": "The PGP public key that can verify the SHA256SUMS signature",
           "type": "object",
           "oneOf": [
             {
@@ -1629,12 +6627,47 @@ func init() {
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content inline within the document",
+              "description": "Specifies the content of the public key inline within the document",
+              "type": "string",
+              "format": "byte"
+            },
+            "url": {
+              "description": "Specifies the location of the public key",
+              "type": "string",
+              "format": "uri"
+            }
+          }
+        },
+        "sha256sums": {
+          "description": "Information about the SHA256SUMS file published with the provider release",
+          "type": "object",
+          "oneOf": [
+            {
+              "required": [
+                "url"
+              ]
+            },
+            {
+              "required": [
+                "content"
+              ]
+            }
+          ],
+          "properties": {
+            "archives": {
+              "description": "The provider zip archives listed in the SHA256SUMS file; this is populated by the server",
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/TfproviderV001SchemaSha256sumsArchivesItems0"
+              }
+            },
+            "content": {
+              "description": "Specifies the SHA256SUMS file inline within the document",
               "type": "string",
               "format": "byte"
             },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the content",
+              "description": "Specifies the hash algorithm and value for the SHA256SUMS file",
               "type": "object",
               "required": [
                 "algorithm",
@@ -1649,38 +6682,29 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the content",
+                  "description": "The hash value for the SHA256SUMS file",
                   "type": "string"
                 }
               }
             },
             "url": {
-              "description": "Specifies the location of the content; if this is specified, a hash value must also be provided",
+              "description": "Specifies the location of the SHA256SUMS file; if this is specified, a hash value must also be provided",
               "type": "string",
               "format": "uri"
             }
           }
         },
-        "extraData": {
-          "description": "Arbitrary content to be included in the verifiable entry in the transparency log",
-          "type": "object",
-          "additionalProperties": true
-        },
         "signature": {
-          "description": "Information about the detached signature associated with the entry",
+          "description": "The detached PGP signature over the SHA256SUMS file",
           "type": "object",
           "oneOf": [
             {
               "required": [
-                "format",
-                "publicKey",
                 "url"
               ]
             },
             {
               "required": [
-                "format",
-                "publicKey",
                 "content"
               ]
             }
@@ -1691,44 +6715,6 @@ func init() {
               "type": "string",
               "format": "byte"
             },
-            "format": {
-              "description": "Specifies the format of the signature",
-              "type": "string",
-              "enum": [
-                "pgp",
-                "minisign",
-                "x509",
-                "ssh"
-              ]
-            },
-            "publicKey": {
-              "description": "The public key that can verify the signature",
-              "type": "object",
-              "oneOf": [
-                {
-                  "required": [
-                    "url"
-                  ]
-                },
-                {
-                  "required": [
-                    "content"
-                  ]
-                }
-              ],
-              "properties": {
-                "content": {
-                  "description": "Specifies the content of the public key inline within the document",
-                  "type": "string",
-                  "format": "byte"
-                },
-                "url": {
-                  "description": "Specifies the location of the public key",
-                  "type": "string",
-                  "format": "uri"
-                }
-              }
-            },
             "url": {
               "description": "Specifies the location of the signature",
               "type": "string",
@@ -1738,10 +6724,10 @@ func init() {
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.dev/types/rekord/rekord_v0_0_1_schema.json"
+      "$id": "http://rekor.dev/types/tfprovider/tfprovider_v0_0_1_schema.json"
     },
-    "rpm": {
-      "description": "RPM object",
+    "vmimage": {
+      "description": "Signed VM or cloud image object",
       "type": "object",
       "allOf": [
         {
@@ -1758,32 +6744,32 @@ func init() {
               "pattern": "^(0|[1-9]\\d*)\\.(0|[1-9]\\d*)\\.(0|[1-9]\\d*)(?:-((?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\\.(?:0|[1-9]\\d*|\\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\\+([0-9a-zA-Z-]+(?:\\.[0-9a-zA-Z-]+)*))?$"
             },
             "spec": {
-              "$ref": "#/definitions/rpmSchema"
+              "$ref": "#/definitions/vmimageSchema"
             }
           },
           "additionalProperties": false
         }
       ]
     },
-    "rpmSchema": {
-      "description": "Schema for RPM objects",
+    "vmimageSchema": {
+      "description": "Schema for VM image objects",
       "type": "object",
-      "title": "RPM Schema",
+      "title": "VM Image Schema",
       "oneOf": [
         {
-          "$ref": "#/definitions/rpmV001Schema"
+          "$ref": "#/definitions/vmimageV001Schema"
         }
       ],
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.dev/types/rpm/rpm_schema.json"
+      "$id": "http://rekor.dev/types/vmimage/vmimage_schema.json"
     },
-    "rpmV001Schema": {
-      "description": "Schema for RPM entries",
+    "vmimageV001Schema": {
+      "description": "Schema for VM image object",
       "type": "object",
-      "title": "RPM v0.0.1 Schema",
+      "title": "VM Image v0.0.1 Schema",
       "required": [
-        "publicKey",
-        "package"
+        "signature",
+        "image"
       ],
       "properties": {
         "extraData": {
@@ -1791,29 +6777,43 @@ func init() {
           "type": "object",
           "additionalProperties": true
         },
-        "package": {
-          "description": "Information about the package associated with the entry",
+        "image": {
+          "description": "Information about the signed image",
           "type": "object",
           "oneOf": [
             {
               "required": [
+                "format",
                 "url"
               ]
             },
             {
               "required": [
+                "format",
                 "content"
               ]
             }
           ],
           "properties": {
             "content": {
-              "description": "Specifies the package inline within the document",
+              "description": "Specifies the image inline within the document",
               "type": "string",
               "format": "byte"
             },
+            "format": {
+              "description": "The format of the image; for cloud images (e.g. ami) the signed content is the image manifest",
+              "type": "string",
+              "enum": [
+                "qcow2",
+                "raw",
+                "vmdk",
+                "vhd",
+                "ova",
+                "ami"
+              ]
+            },
             "hash": {
-              "description": "Specifies the hash algorithm and value for the package",
+              "description": "Specifies the hash algorithm and value for the image",
               "type": "object",
               "required": [
                 "algorithm",
@@ -1828,48 +6828,101 @@ func init() {
                   ]
                 },
                 "value": {
-                  "description": "The hash value for the package",
+                  "description": "The hash value for the image",
                   "type": "string"
                 }
               }
             },
-            "headers": {
-              "description": "Values of the RPM headers",
+            "identifier": {
+              "description": "Where the image is published",
               "type": "object",
-              "additionalProperties": {
-                "type": "string"
+              "required": [
+                "id"
+              ],
+              "properties": {
+                "id": {
+                  "description": "The identifier assigned to the image by the provider",
+                  "type": "string",
+                  "pattern": "^[A-Za-z0-9][A-Za-z0-9._:/-]*$"
+                },
+                "provider": {
+                  "description": "The cloud provider or registry the image is published to",
+                  "type": "string",
+                  "pattern": "^[a-z0-9][a-z0-9-]*$"
+                },
+                "region": {
+                  "description": "The region the image is published in, for regional images",
+                  "type": "string",
+                  "pattern": "^[a-z0-9][a-z0-9-]*$"
+                }
               }
             },
             "url": {
-              "description": "Specifies the location of the package; if this is specified, a hash value must also be provided",
+              "description": "Specifies the location of the image",
               "type": "string",
               "format": "uri"
             }
           }
         },
-        "publicKey": {
-          "description": "The PGP public key that can verify the RPM signature",
+        "signature": {
+          "description": "Information about the detached signature over the image (or, for cloud images, its manifest)",
           "type": "object",
           "oneOf": [
             {
               "required": [
+                "format",
+                "publicKey",
                 "url"
               ]
             },
             {
               "required": [
+                "format",
+                "publicKey",
                 "content"
               ]
             }
           ],
           "properties": {
             "content": {
-              "description": "Specifies the content of the public key inline within the document",
+              "description": "Specifies the content of the signature inline within the document",
               "type": "string",
               "format": "byte"
             },
+            "format": {
+              "description": "Specifies the format of the signature",
+              "type": "string"
+            },
+            "publicKey": {
+              "description": "The public key that can verify the signature",
+              "type": "object",
+              "oneOf": [
+                {
+                  "required": [
+                    "url"
+                  ]
+                },
+                {
+                  "required": [
+                    "content"
+                  ]
+                }
+              ],
+              "properties": {
+                "content": {
+                  "description": "Specifies the content of the public key inline within the document",
+                  "type": "string",
+                  "format": "byte"
+                },
+                "url": {
+                  "description": "Specifies the location of the public key",
+                  "type": "string",
+                  "format": "uri"
+                }
+              }
+            },
             "url": {
-              "description": "Specifies the location of the public key",
+              "description": "Specifies the location of the signature",
               "type": "string",
               "format": "uri"
             }
@@ -1877,7 +6930,7 @@ func init() {
         }
       },
       "$schema": "http://json-schema.org/draft-07/schema",
-      "$id": "http://rekor.dev/types/rpm/rpm_v0_0_1_schema.json"
+      "$id": "http://rekor.dev/types/vmimage/vmimage_v0_0_1_schema.json"
     }
   },
   "responses": {
@@ -1893,9 +6946,17 @@ func init() {
         "$ref": "#/definitions/Error"
       },
       "headers": {
+        "ETag": {
+          "type": "string",
+          "description": "UUID of the existing entry"
+        },
         "Location": {
           "type": "string",
           "format": "uri"
+        },
+        "X-Rekor-Log-Index": {
+          "type": "integer",
+          "description": "Log index of the existing entry"
         }
       }
     },
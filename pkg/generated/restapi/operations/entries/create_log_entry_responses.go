@@ -27,6 +27,7 @@ import (
 
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
 )
@@ -47,6 +48,10 @@ type CreateLogEntryCreated struct {
 
 	 */
 	Location strfmt.URI `json:"Location"`
+	/*Comma-separated UUIDs of existing entries that logged the same signature under a different public key
+
+	 */
+	XRekorSignatureReuse string `json:"X-Rekor-Signature-Reuse"`
 
 	/*
 	  In: Body
@@ -82,6 +87,17 @@ func (o *CreateLogEntryCreated) SetLocation(location strfmt.URI) {
 	o.Location = location
 }
 
+// WithXRekorSignatureReuse adds the xRekorSignatureReuse to the create log entry created response
+func (o *CreateLogEntryCreated) WithXRekorSignatureReuse(xRekorSignatureReuse string) *CreateLogEntryCreated {
+	o.XRekorSignatureReuse = xRekorSignatureReuse
+	return o
+}
+
+// SetXRekorSignatureReuse sets the xRekorSignatureReuse to the create log entry created response
+func (o *CreateLogEntryCreated) SetXRekorSignatureReuse(xRekorSignatureReuse string) {
+	o.XRekorSignatureReuse = xRekorSignatureReuse
+}
+
 // WithPayload adds the payload to the create log entry created response
 func (o *CreateLogEntryCreated) WithPayload(payload models.LogEntry) *CreateLogEntryCreated {
 	o.Payload = payload
@@ -110,6 +126,13 @@ func (o *CreateLogEntryCreated) WriteResponse(rw http.ResponseWriter, producer r
 		rw.Header().Set("Location", location)
 	}
 
+	// response header X-Rekor-Signature-Reuse
+
+	xRekorSignatureReuse := o.XRekorSignatureReuse
+	if xRekorSignatureReuse != "" {
+		rw.Header().Set("X-Rekor-Signature-Reuse", xRekorSignatureReuse)
+	}
+
 	rw.WriteHeader(201)
 	payload := o.Payload
 	if payload == nil {
@@ -174,10 +197,18 @@ const CreateLogEntryConflictCode int = 409
 swagger:response createLogEntryConflict
 */
 type CreateLogEntryConflict struct {
+	/*UUID of the existing entry
+
+	 */
+	ETag string `json:"ETag"`
 	/*
 
 	 */
 	Location strfmt.URI `json:"Location"`
+	/*Log index of the existing entry
+
+	 */
+	XRekorLogIndex int64 `json:"X-Rekor-Log-Index"`
 
 	/*
 	  In: Body
@@ -191,6 +222,17 @@ func NewCreateLogEntryConflict() *CreateLogEntryConflict {
 	return &CreateLogEntryConflict{}
 }
 
+// WithETag adds the eTag to the create log entry conflict response
+func (o *CreateLogEntryConflict) WithETag(eTag string) *CreateLogEntryConflict {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the create log entry conflict response
+func (o *CreateLogEntryConflict) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithLocation adds the location to the create log entry conflict response
 func (o *CreateLogEntryConflict) WithLocation(location strfmt.URI) *CreateLogEntryConflict {
 	o.Location = location
@@ -202,6 +244,17 @@ func (o *CreateLogEntryConflict) SetLocation(location strfmt.URI) {
 	o.Location = location
 }
 
+// WithXRekorLogIndex adds the xRekorLogIndex to the create log entry conflict response
+func (o *CreateLogEntryConflict) WithXRekorLogIndex(xRekorLogIndex int64) *CreateLogEntryConflict {
+	o.XRekorLogIndex = xRekorLogIndex
+	return o
+}
+
+// SetXRekorLogIndex sets the xRekorLogIndex to the create log entry conflict response
+func (o *CreateLogEntryConflict) SetXRekorLogIndex(xRekorLogIndex int64) {
+	o.XRekorLogIndex = xRekorLogIndex
+}
+
 // WithPayload adds the payload to the create log entry conflict response
 func (o *CreateLogEntryConflict) WithPayload(payload *models.Error) *CreateLogEntryConflict {
 	o.Payload = payload
@@ -216,6 +269,13 @@ func (o *CreateLogEntryConflict) SetPayload(payload *models.Error) {
 // WriteResponse to the client
 func (o *CreateLogEntryConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	// response header Location
 
 	location := o.Location.String()
@@ -223,6 +283,13 @@ func (o *CreateLogEntryConflict) WriteResponse(rw http.ResponseWriter, producer
 		rw.Header().Set("Location", location)
 	}
 
+	// response header X-Rekor-Log-Index
+
+	xRekorLogIndex := swag.FormatInt64(o.XRekorLogIndex)
+	if xRekorLogIndex != "" {
+		rw.Header().Set("X-Rekor-Log-Index", xRekorLogIndex)
+	}
+
 	rw.WriteHeader(409)
 	if o.Payload != nil {
 		payload := o.Payload
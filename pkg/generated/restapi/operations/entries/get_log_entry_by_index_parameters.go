@@ -49,6 +49,15 @@ type GetLogEntryByIndexParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*the ETag of a copy of the entry held by the client; the entry is not returned if it still matches
+	  In: header
+	*/
+	IfNoneMatch *string
+	/*whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests
+	  In: query
+	*/
+	IncludeProof *bool
+
 	/*specifies the index of the entry in the transparency log to be retrieved
 	  Required: true
 	  Minimum: 0
@@ -68,6 +77,15 @@ func (o *GetLogEntryByIndexParams) BindRequest(r *http.Request, route *middlewar
 
 	qs := runtime.Values(r.URL.Query())
 
+	if err := o.bindIfNoneMatch(r.Header[http.CanonicalHeaderKey("If-None-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qIncludeProof, qhkIncludeProof, _ := qs.GetOK("includeProof")
+	if err := o.bindIncludeProof(qIncludeProof, qhkIncludeProof, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qLogIndex, qhkLogIndex, _ := qs.GetOK("logIndex")
 	if err := o.bindLogIndex(qLogIndex, qhkLogIndex, route.Formats); err != nil {
 		res = append(res, err)
@@ -79,6 +97,46 @@ func (o *GetLogEntryByIndexParams) BindRequest(r *http.Request, route *middlewar
 	return nil
 }
 
+// bindIfNoneMatch binds and validates parameter IfNoneMatch from header.
+func (o *GetLogEntryByIndexParams) bindIfNoneMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.IfNoneMatch = &raw
+
+	return nil
+}
+
+// bindIncludeProof binds and validates parameter IncludeProof from query.
+func (o *GetLogEntryByIndexParams) bindIncludeProof(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeProof", "query", "bool", raw)
+	}
+	o.IncludeProof = &value
+
+	return nil
+}
+
 // bindLogIndex binds and validates parameter LogIndex from query.
 func (o *GetLogEntryByIndexParams) bindLogIndex(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
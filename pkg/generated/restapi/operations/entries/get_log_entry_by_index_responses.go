@@ -38,6 +38,10 @@ const GetLogEntryByIndexOKCode int = 200
 swagger:response getLogEntryByIndexOK
 */
 type GetLogEntryByIndexOK struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+
+	 */
+	ETag string `json:"ETag"`
 
 	/*
 	  In: Body
@@ -51,6 +55,17 @@ func NewGetLogEntryByIndexOK() *GetLogEntryByIndexOK {
 	return &GetLogEntryByIndexOK{}
 }
 
+// WithETag adds the eTag to the get log entry by index o k response
+func (o *GetLogEntryByIndexOK) WithETag(eTag string) *GetLogEntryByIndexOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get log entry by index o k response
+func (o *GetLogEntryByIndexOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the get log entry by index o k response
 func (o *GetLogEntryByIndexOK) WithPayload(payload models.LogEntry) *GetLogEntryByIndexOK {
 	o.Payload = payload
@@ -65,6 +80,13 @@ func (o *GetLogEntryByIndexOK) SetPayload(payload models.LogEntry) {
 // WriteResponse to the client
 func (o *GetLogEntryByIndexOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
@@ -77,6 +99,52 @@ func (o *GetLogEntryByIndexOK) WriteResponse(rw http.ResponseWriter, producer ru
 	}
 }
 
+// GetLogEntryByIndexNotModifiedCode is the HTTP code returned for type GetLogEntryByIndexNotModified
+const GetLogEntryByIndexNotModifiedCode int = 304
+
+/*GetLogEntryByIndexNotModified the entry has not changed since the copy with the ETag in If-None-Match was retrieved
+
+swagger:response getLogEntryByIndexNotModified
+*/
+type GetLogEntryByIndexNotModified struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+
+	 */
+	ETag string `json:"ETag"`
+}
+
+// NewGetLogEntryByIndexNotModified creates GetLogEntryByIndexNotModified with default headers values
+func NewGetLogEntryByIndexNotModified() *GetLogEntryByIndexNotModified {
+
+	return &GetLogEntryByIndexNotModified{}
+}
+
+// WithETag adds the eTag to the get log entry by index not modified response
+func (o *GetLogEntryByIndexNotModified) WithETag(eTag string) *GetLogEntryByIndexNotModified {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get log entry by index not modified response
+func (o *GetLogEntryByIndexNotModified) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
+// WriteResponse to the client
+func (o *GetLogEntryByIndexNotModified) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(304)
+}
+
 // GetLogEntryByIndexNotFoundCode is the HTTP code returned for type GetLogEntryByIndexNotFound
 const GetLogEntryByIndexNotFoundCode int = 404
 
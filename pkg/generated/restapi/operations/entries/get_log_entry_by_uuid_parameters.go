@@ -26,8 +26,10 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
 )
 
@@ -47,12 +49,20 @@ type GetLogEntryByUUIDParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*the ETag of a copy of the entry held by the client; the entry is not returned if it still matches
+	  In: header
+	*/
+	IfNoneMatch *string
 	/*the UUID of the entry to be retrieved from the log. The UUID is also the merkle tree hash of the entry.
 	  Required: true
 	  Pattern: ^[0-9a-fA-F]{64}$
 	  In: path
 	*/
 	EntryUUID string
+	/*whether to return the inclusion proof of the entry and the signed tree head it was computed against, so that the entry can be verified without further requests
+	  In: query
+	*/
+	IncludeProof *bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -64,17 +74,46 @@ func (o *GetLogEntryByUUIDParams) BindRequest(r *http.Request, route *middleware
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	if err := o.bindIfNoneMatch(r.Header[http.CanonicalHeaderKey("If-None-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rEntryUUID, rhkEntryUUID, _ := route.Params.GetOK("entryUUID")
 	if err := o.bindEntryUUID(rEntryUUID, rhkEntryUUID, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qIncludeProof, qhkIncludeProof, _ := qs.GetOK("includeProof")
+	if err := o.bindIncludeProof(qIncludeProof, qhkIncludeProof, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+// bindIfNoneMatch binds and validates parameter IfNoneMatch from header.
+func (o *GetLogEntryByUUIDParams) bindIfNoneMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.IfNoneMatch = &raw
+
+	return nil
+}
+
 // bindEntryUUID binds and validates parameter EntryUUID from path.
 func (o *GetLogEntryByUUIDParams) bindEntryUUID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -94,6 +133,28 @@ func (o *GetLogEntryByUUIDParams) bindEntryUUID(rawData []string, hasKey bool, f
 	return nil
 }
 
+// bindIncludeProof binds and validates parameter IncludeProof from query.
+func (o *GetLogEntryByUUIDParams) bindIncludeProof(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("includeProof", "query", "bool", raw)
+	}
+	o.IncludeProof = &value
+
+	return nil
+}
+
 // validateEntryUUID carries on validations for parameter EntryUUID
 func (o *GetLogEntryByUUIDParams) validateEntryUUID(formats strfmt.Registry) error {
 
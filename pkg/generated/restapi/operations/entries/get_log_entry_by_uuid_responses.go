@@ -38,6 +38,10 @@ const GetLogEntryByUUIDOKCode int = 200
 swagger:response getLogEntryByUuidOK
 */
 type GetLogEntryByUUIDOK struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+
+	 */
+	ETag string `json:"ETag"`
 
 	/*
 	  In: Body
@@ -51,6 +55,17 @@ func NewGetLogEntryByUUIDOK() *GetLogEntryByUUIDOK {
 	return &GetLogEntryByUUIDOK{}
 }
 
+// WithETag adds the eTag to the get log entry by Uuid o k response
+func (o *GetLogEntryByUUIDOK) WithETag(eTag string) *GetLogEntryByUUIDOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get log entry by Uuid o k response
+func (o *GetLogEntryByUUIDOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the get log entry by Uuid o k response
 func (o *GetLogEntryByUUIDOK) WithPayload(payload models.LogEntry) *GetLogEntryByUUIDOK {
 	o.Payload = payload
@@ -65,6 +80,13 @@ func (o *GetLogEntryByUUIDOK) SetPayload(payload models.LogEntry) {
 // WriteResponse to the client
 func (o *GetLogEntryByUUIDOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
@@ -77,6 +99,52 @@ func (o *GetLogEntryByUUIDOK) WriteResponse(rw http.ResponseWriter, producer run
 	}
 }
 
+// GetLogEntryByUUIDNotModifiedCode is the HTTP code returned for type GetLogEntryByUUIDNotModified
+const GetLogEntryByUUIDNotModifiedCode int = 304
+
+/*GetLogEntryByUUIDNotModified the entry has not changed since the copy with the ETag in If-None-Match was retrieved
+
+swagger:response getLogEntryByUuidNotModified
+*/
+type GetLogEntryByUUIDNotModified struct {
+	/*the UUID of the entry in quotes, which is a strong validator as entries never change once integrated
+
+	 */
+	ETag string `json:"ETag"`
+}
+
+// NewGetLogEntryByUUIDNotModified creates GetLogEntryByUUIDNotModified with default headers values
+func NewGetLogEntryByUUIDNotModified() *GetLogEntryByUUIDNotModified {
+
+	return &GetLogEntryByUUIDNotModified{}
+}
+
+// WithETag adds the eTag to the get log entry by Uuid not modified response
+func (o *GetLogEntryByUUIDNotModified) WithETag(eTag string) *GetLogEntryByUUIDNotModified {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get log entry by Uuid not modified response
+func (o *GetLogEntryByUUIDNotModified) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
+// WriteResponse to the client
+func (o *GetLogEntryByUUIDNotModified) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(304)
+}
+
 // GetLogEntryByUUIDNotFoundCode is the HTTP code returned for type GetLogEntryByUUIDNotFound
 const GetLogEntryByUUIDNotFoundCode int = 404
 
@@ -38,6 +38,10 @@ const SearchIndexOKCode int = 200
 swagger:response searchIndexOK
 */
 type SearchIndexOK struct {
+	/*Token to pass as continuationToken to retrieve the next page of results, if there are more
+
+	 */
+	XRekorContinuationToken string `json:"X-Rekor-Continuation-Token"`
 
 	/*
 	  In: Body
@@ -51,6 +55,17 @@ func NewSearchIndexOK() *SearchIndexOK {
 	return &SearchIndexOK{}
 }
 
+// WithXRekorContinuationToken adds the xRekorContinuationToken to the search index o k response
+func (o *SearchIndexOK) WithXRekorContinuationToken(xRekorContinuationToken string) *SearchIndexOK {
+	o.XRekorContinuationToken = xRekorContinuationToken
+	return o
+}
+
+// SetXRekorContinuationToken sets the xRekorContinuationToken to the search index o k response
+func (o *SearchIndexOK) SetXRekorContinuationToken(xRekorContinuationToken string) {
+	o.XRekorContinuationToken = xRekorContinuationToken
+}
+
 // WithPayload adds the payload to the search index o k response
 func (o *SearchIndexOK) WithPayload(payload []string) *SearchIndexOK {
 	o.Payload = payload
@@ -65,6 +80,13 @@ func (o *SearchIndexOK) SetPayload(payload []string) {
 // WriteResponse to the client
 func (o *SearchIndexOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header X-Rekor-Continuation-Token
+
+	xRekorContinuationToken := o.XRekorContinuationToken
+	if xRekorContinuationToken != "" {
+		rw.Header().Set("X-Rekor-Continuation-Token", xRekorContinuationToken)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
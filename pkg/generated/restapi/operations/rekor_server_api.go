@@ -64,39 +64,82 @@ func NewRekorServerAPI(spec *loads.Document) *RekorServerAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 		YamlConsumer: yamlpc.YAMLConsumer(),
 
+		ApplicationCborProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("applicationCbor producer has not yet been implemented")
+		}),
 		ApplicationXPemFileProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
 			return errors.NotImplemented("applicationXPemFile producer has not yet been implemented")
 		}),
+		ApplicationXProtobufProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("applicationXProtobuf producer has not yet been implemented")
+		}),
 		JSONProducer: runtime.JSONProducer(),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("textEventStream producer has not yet been implemented")
+		}),
+		TxtProducer: runtime.TextProducer(),
 		YamlProducer: yamlpc.YAMLProducer(),
 
+		EntriesCreateLogEntriesHandler: entries.CreateLogEntriesHandlerFunc(func(params entries.CreateLogEntriesParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.CreateLogEntries has not yet been implemented")
+		}),
 		EntriesCreateLogEntryHandler: entries.CreateLogEntryHandlerFunc(func(params entries.CreateLogEntryParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.CreateLogEntry has not yet been implemented")
 		}),
+		EntriesGetLogEntriesByRangeHandler: entries.GetLogEntriesByRangeHandlerFunc(func(params entries.GetLogEntriesByRangeParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.GetLogEntriesByRange has not yet been implemented")
+		}),
 		EntriesGetLogEntryByIndexHandler: entries.GetLogEntryByIndexHandlerFunc(func(params entries.GetLogEntryByIndexParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.GetLogEntryByIndex has not yet been implemented")
 		}),
 		EntriesGetLogEntryByUUIDHandler: entries.GetLogEntryByUUIDHandlerFunc(func(params entries.GetLogEntryByUUIDParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.GetLogEntryByUUID has not yet been implemented")
 		}),
+		EntriesGetLogEntryIndexesByTimeHandler: entries.GetLogEntryIndexesByTimeHandlerFunc(func(params entries.GetLogEntryIndexesByTimeParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.GetLogEntryIndexesByTime has not yet been implemented")
+		}),
 		EntriesGetLogEntryProofHandler: entries.GetLogEntryProofHandlerFunc(func(params entries.GetLogEntryProofParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.GetLogEntryProof has not yet been implemented")
 		}),
+		EntriesGetLogEntryProofByLeafHandler: entries.GetLogEntryProofByLeafHandlerFunc(func(params entries.GetLogEntryProofByLeafParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.GetLogEntryProofByLeaf has not yet been implemented")
+		}),
+		EntriesGetLogEntryV2ByIndexHandler: entries.GetLogEntryV2ByIndexHandlerFunc(func(params entries.GetLogEntryV2ByIndexParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.GetLogEntryV2ByIndex has not yet been implemented")
+		}),
+		EntriesGetLogEntryV2ByUUIDHandler: entries.GetLogEntryV2ByUUIDHandlerFunc(func(params entries.GetLogEntryV2ByUUIDParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.GetLogEntryV2ByUUID has not yet been implemented")
+		}),
+		TlogGetLogCheckpointHandler: tlog.GetLogCheckpointHandlerFunc(func(params tlog.GetLogCheckpointParams) middleware.Responder {
+			return middleware.NotImplemented("operation tlog.GetLogCheckpoint has not yet been implemented")
+		}),
 		TlogGetLogInfoHandler: tlog.GetLogInfoHandlerFunc(func(params tlog.GetLogInfoParams) middleware.Responder {
 			return middleware.NotImplemented("operation tlog.GetLogInfo has not yet been implemented")
 		}),
 		TlogGetLogProofHandler: tlog.GetLogProofHandlerFunc(func(params tlog.GetLogProofParams) middleware.Responder {
 			return middleware.NotImplemented("operation tlog.GetLogProof has not yet been implemented")
 		}),
+		TlogGetLogStatsHandler: tlog.GetLogStatsHandlerFunc(func(params tlog.GetLogStatsParams) middleware.Responder {
+			return middleware.NotImplemented("operation tlog.GetLogStats has not yet been implemented")
+		}),
 		TlogGetPublicKeyHandler: tlog.GetPublicKeyHandlerFunc(func(params tlog.GetPublicKeyParams) middleware.Responder {
 			return middleware.NotImplemented("operation tlog.GetPublicKey has not yet been implemented")
 		}),
+		TlogGetRekorConfigurationHandler: tlog.GetRekorConfigurationHandlerFunc(func(params tlog.GetRekorConfigurationParams) middleware.Responder {
+			return middleware.NotImplemented("operation tlog.GetRekorConfiguration has not yet been implemented")
+		}),
 		IndexSearchIndexHandler: index.SearchIndexHandlerFunc(func(params index.SearchIndexParams) middleware.Responder {
 			return middleware.NotImplemented("operation index.SearchIndex has not yet been implemented")
 		}),
 		EntriesSearchLogQueryHandler: entries.SearchLogQueryHandlerFunc(func(params entries.SearchLogQueryParams) middleware.Responder {
 			return middleware.NotImplemented("operation entries.SearchLogQuery has not yet been implemented")
 		}),
+		EntriesStreamLogEntriesHandler: entries.StreamLogEntriesHandlerFunc(func(params entries.StreamLogEntriesParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.StreamLogEntries has not yet been implemented")
+		}),
+		EntriesValidateLogEntryHandler: entries.ValidateLogEntryHandlerFunc(func(params entries.ValidateLogEntryParams) middleware.Responder {
+			return middleware.NotImplemented("operation entries.ValidateLogEntry has not yet been implemented")
+		}),
 	}
 }
 
@@ -130,34 +173,68 @@ type RekorServerAPI struct {
 	//   - application/yaml
 	YamlConsumer runtime.Consumer
 
+	// ApplicationCborProducer registers a producer for the following mime types:
+	//   - application/cbor
+	ApplicationCborProducer runtime.Producer
 	// ApplicationXPemFileProducer registers a producer for the following mime types:
 	//   - application/x-pem-file
 	ApplicationXPemFileProducer runtime.Producer
+	// ApplicationXProtobufProducer registers a producer for the following mime types:
+	//   - application/x-protobuf
+	ApplicationXProtobufProducer runtime.Producer
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
+	// TextEventStreamProducer registers a producer for the following mime types:
+	//   - text/event-stream
+	TextEventStreamProducer runtime.Producer
+	// TxtProducer registers a producer for the following mime types:
+	//   - text/plain
+	TxtProducer runtime.Producer
 	// YamlProducer registers a producer for the following mime types:
 	//   - application/yaml
 	YamlProducer runtime.Producer
 
+	// EntriesCreateLogEntriesHandler sets the operation handler for the create log entries operation
+	EntriesCreateLogEntriesHandler entries.CreateLogEntriesHandler
 	// EntriesCreateLogEntryHandler sets the operation handler for the create log entry operation
 	EntriesCreateLogEntryHandler entries.CreateLogEntryHandler
+	// EntriesGetLogEntriesByRangeHandler sets the operation handler for the get log entries by range operation
+	EntriesGetLogEntriesByRangeHandler entries.GetLogEntriesByRangeHandler
 	// EntriesGetLogEntryByIndexHandler sets the operation handler for the get log entry by index operation
 	EntriesGetLogEntryByIndexHandler entries.GetLogEntryByIndexHandler
 	// EntriesGetLogEntryByUUIDHandler sets the operation handler for the get log entry by UUID operation
 	EntriesGetLogEntryByUUIDHandler entries.GetLogEntryByUUIDHandler
+	// EntriesGetLogEntryIndexesByTimeHandler sets the operation handler for the get log entry indexes by time operation
+	EntriesGetLogEntryIndexesByTimeHandler entries.GetLogEntryIndexesByTimeHandler
 	// EntriesGetLogEntryProofHandler sets the operation handler for the get log entry proof operation
 	EntriesGetLogEntryProofHandler entries.GetLogEntryProofHandler
+	// EntriesGetLogEntryProofByLeafHandler sets the operation handler for the get log entry proof by leaf operation
+	EntriesGetLogEntryProofByLeafHandler entries.GetLogEntryProofByLeafHandler
+	// EntriesGetLogEntryV2ByIndexHandler sets the operation handler for the get log entry v2 by index operation
+	EntriesGetLogEntryV2ByIndexHandler entries.GetLogEntryV2ByIndexHandler
+	// EntriesGetLogEntryV2ByUUIDHandler sets the operation handler for the get log entry v2 by UUID operation
+	EntriesGetLogEntryV2ByUUIDHandler entries.GetLogEntryV2ByUUIDHandler
+	// TlogGetLogCheckpointHandler sets the operation handler for the get log checkpoint operation
+	TlogGetLogCheckpointHandler tlog.GetLogCheckpointHandler
 	// TlogGetLogInfoHandler sets the operation handler for the get log info operation
 	TlogGetLogInfoHandler tlog.GetLogInfoHandler
 	// TlogGetLogProofHandler sets the operation handler for the get log proof operation
 	TlogGetLogProofHandler tlog.GetLogProofHandler
+	// TlogGetLogStatsHandler sets the operation handler for the get log stats operation
+	TlogGetLogStatsHandler tlog.GetLogStatsHandler
 	// TlogGetPublicKeyHandler sets the operation handler for the get public key operation
 	TlogGetPublicKeyHandler tlog.GetPublicKeyHandler
+	// TlogGetRekorConfigurationHandler sets the operation handler for the get rekor configuration operation
+	TlogGetRekorConfigurationHandler tlog.GetRekorConfigurationHandler
 	// IndexSearchIndexHandler sets the operation handler for the search index operation
 	IndexSearchIndexHandler index.SearchIndexHandler
 	// EntriesSearchLogQueryHandler sets the operation handler for the search log query operation
 	EntriesSearchLogQueryHandler entries.SearchLogQueryHandler
+	// EntriesStreamLogEntriesHandler sets the operation handler for the stream log entries operation
+	EntriesStreamLogEntriesHandler entries.StreamLogEntriesHandler
+	// EntriesValidateLogEntryHandler sets the operation handler for the validate log entry operation
+	EntriesValidateLogEntryHandler entries.ValidateLogEntryHandler
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
 	ServeError func(http.ResponseWriter, *http.Request, error)
@@ -233,43 +310,88 @@ func (o *RekorServerAPI) Validate() error {
 		unregistered = append(unregistered, "YamlConsumer")
 	}
 
+	if o.ApplicationCborProducer == nil {
+		unregistered = append(unregistered, "ApplicationCborProducer")
+	}
 	if o.ApplicationXPemFileProducer == nil {
 		unregistered = append(unregistered, "ApplicationXPemFileProducer")
 	}
+	if o.ApplicationXProtobufProducer == nil {
+		unregistered = append(unregistered, "ApplicationXProtobufProducer")
+	}
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
+	if o.TxtProducer == nil {
+		unregistered = append(unregistered, "TxtProducer")
+	}
 	if o.YamlProducer == nil {
 		unregistered = append(unregistered, "YamlProducer")
 	}
 
+	if o.EntriesCreateLogEntriesHandler == nil {
+		unregistered = append(unregistered, "entries.CreateLogEntriesHandler")
+	}
 	if o.EntriesCreateLogEntryHandler == nil {
 		unregistered = append(unregistered, "entries.CreateLogEntryHandler")
 	}
+	if o.EntriesGetLogEntriesByRangeHandler == nil {
+		unregistered = append(unregistered, "entries.GetLogEntriesByRangeHandler")
+	}
 	if o.EntriesGetLogEntryByIndexHandler == nil {
 		unregistered = append(unregistered, "entries.GetLogEntryByIndexHandler")
 	}
 	if o.EntriesGetLogEntryByUUIDHandler == nil {
 		unregistered = append(unregistered, "entries.GetLogEntryByUUIDHandler")
 	}
+	if o.EntriesGetLogEntryIndexesByTimeHandler == nil {
+		unregistered = append(unregistered, "entries.GetLogEntryIndexesByTimeHandler")
+	}
 	if o.EntriesGetLogEntryProofHandler == nil {
 		unregistered = append(unregistered, "entries.GetLogEntryProofHandler")
 	}
+	if o.EntriesGetLogEntryProofByLeafHandler == nil {
+		unregistered = append(unregistered, "entries.GetLogEntryProofByLeafHandler")
+	}
+	if o.EntriesGetLogEntryV2ByIndexHandler == nil {
+		unregistered = append(unregistered, "entries.GetLogEntryV2ByIndexHandler")
+	}
+	if o.EntriesGetLogEntryV2ByUUIDHandler == nil {
+		unregistered = append(unregistered, "entries.GetLogEntryV2ByUUIDHandler")
+	}
+	if o.TlogGetLogCheckpointHandler == nil {
+		unregistered = append(unregistered, "tlog.GetLogCheckpointHandler")
+	}
 	if o.TlogGetLogInfoHandler == nil {
 		unregistered = append(unregistered, "tlog.GetLogInfoHandler")
 	}
 	if o.TlogGetLogProofHandler == nil {
 		unregistered = append(unregistered, "tlog.GetLogProofHandler")
 	}
+	if o.TlogGetLogStatsHandler == nil {
+		unregistered = append(unregistered, "tlog.GetLogStatsHandler")
+	}
 	if o.TlogGetPublicKeyHandler == nil {
 		unregistered = append(unregistered, "tlog.GetPublicKeyHandler")
 	}
+	if o.TlogGetRekorConfigurationHandler == nil {
+		unregistered = append(unregistered, "tlog.GetRekorConfigurationHandler")
+	}
 	if o.IndexSearchIndexHandler == nil {
 		unregistered = append(unregistered, "index.SearchIndexHandler")
 	}
 	if o.EntriesSearchLogQueryHandler == nil {
 		unregistered = append(unregistered, "entries.SearchLogQueryHandler")
 	}
+	if o.EntriesStreamLogEntriesHandler == nil {
+		unregistered = append(unregistered, "entries.StreamLogEntriesHandler")
+	}
+	if o.EntriesValidateLogEntryHandler == nil {
+		unregistered = append(unregistered, "entries.ValidateLogEntryHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -318,10 +440,18 @@ func (o *RekorServerAPI) ProducersFor(mediaTypes []string) map[string]runtime.Pr
 	result := make(map[string]runtime.Producer, len(mediaTypes))
 	for _, mt := range mediaTypes {
 		switch mt {
+		case "application/cbor":
+			result["application/cbor"] = o.ApplicationCborProducer
 		case "application/x-pem-file":
 			result["application/x-pem-file"] = o.ApplicationXPemFileProducer
+		case "application/x-protobuf":
+			result["application/x-protobuf"] = o.ApplicationXProtobufProducer
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
+		case "text/plain":
+			result["text/plain"] = o.TxtProducer
 		case "application/yaml":
 			result["application/yaml"] = o.YamlProducer
 		}
@@ -364,6 +494,14 @@ func (o *RekorServerAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/log/entries/batch"] = entries.NewCreateLogEntries(o.context, o.EntriesCreateLogEntriesHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/log/entries/validate"] = entries.NewValidateLogEntry(o.context, o.EntriesValidateLogEntryHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -371,6 +509,10 @@ func (o *RekorServerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/api/v1/log/entries/range"] = entries.NewGetLogEntriesByRange(o.context, o.EntriesGetLogEntriesByRangeHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/api/v1/log/entries"] = entries.NewGetLogEntryByIndex(o.context, o.EntriesGetLogEntryByIndexHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -379,10 +521,30 @@ func (o *RekorServerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/api/v2/log/entries"] = entries.NewGetLogEntryV2ByIndex(o.context, o.EntriesGetLogEntryV2ByIndexHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v2/log/entries/{entryUUID}"] = entries.NewGetLogEntryV2ByUUID(o.context, o.EntriesGetLogEntryV2ByUUIDHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/api/v1/log/entries/{entryUUID}/proof"] = entries.NewGetLogEntryProof(o.context, o.EntriesGetLogEntryProofHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/api/v1/log/entries/time"] = entries.NewGetLogEntryIndexesByTime(o.context, o.EntriesGetLogEntryIndexesByTimeHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/v1/log/entries/proof"] = entries.NewGetLogEntryProofByLeaf(o.context, o.EntriesGetLogEntryProofByLeafHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v1/log/checkpoint"] = tlog.NewGetLogCheckpoint(o.context, o.TlogGetLogCheckpointHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/api/v1/log"] = tlog.NewGetLogInfo(o.context, o.TlogGetLogInfoHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -391,7 +553,15 @@ func (o *RekorServerAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/api/v1/log/stats"] = tlog.NewGetLogStats(o.context, o.TlogGetLogStatsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/api/v1/log/publicKey"] = tlog.NewGetPublicKey(o.context, o.TlogGetPublicKeyHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/.well-known/rekor-configuration"] = tlog.NewGetRekorConfiguration(o.context, o.TlogGetRekorConfigurationHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -400,6 +570,10 @@ func (o *RekorServerAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/api/v1/log/entries/retrieve"] = entries.NewSearchLogQuery(o.context, o.EntriesSearchLogQueryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/v1/log/stream"] = entries.NewStreamLogEntries(o.context, o.EntriesStreamLogEntriesHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -50,7 +50,7 @@ func NewGetLogProof(ctx *middleware.Context, handler GetLogProofHandler) *GetLog
 
 Get information required to generate a consistency proof for the transparency log
 
-Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log
+Returns a list of hashes for specified tree sizes that can be used to confirm the consistency of the transparency log. Both sizes may be earlier than the current size of the log, so that tree heads recorded in the past can be checked against each other
 
 */
 type GetLogProof struct {
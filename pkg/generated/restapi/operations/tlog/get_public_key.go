@@ -50,7 +50,8 @@ func NewGetPublicKey(ctx *middleware.Context, handler GetPublicKeyHandler) *GetP
 
 Retrieve the public key that can be used to validate the signed tree head
 
-Returns the public key that can be used to validate the signed tree head
+Returns the public key that can be used to validate the signed tree head. Once the key of the log has been rotated, the keys that validate tree heads signed before the rotation are returned when their key hint is given
+
 
 */
 type GetPublicKey struct {
@@ -26,7 +26,9 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
 )
 
 // NewGetPublicKeyParams creates a new GetPublicKeyParams object
@@ -44,6 +46,11 @@ type GetPublicKeyParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*the base64-encoded key hint of the signed tree head to be validated, to retrieve the key it was produced with rather than the active one
+	  In: query
+	*/
+	KeyHint *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -55,8 +62,33 @@ func (o *GetPublicKeyParams) BindRequest(r *http.Request, route *middleware.Matc
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qKeyHint, qhkKeyHint, _ := qs.GetOK("keyHint")
+	if err := o.bindKeyHint(qKeyHint, qhkKeyHint, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindKeyHint binds and validates parameter KeyHint from query.
+func (o *GetPublicKeyParams) bindKeyHint(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.KeyHint = &raw
+
+	return nil
+}
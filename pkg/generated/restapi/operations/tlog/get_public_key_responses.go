@@ -72,6 +72,74 @@ func (o *GetPublicKeyOK) WriteResponse(rw http.ResponseWriter, producer runtime.
 	}
 }
 
+// GetPublicKeyBadRequestCode is the HTTP code returned for type GetPublicKeyBadRequest
+const GetPublicKeyBadRequestCode int = 400
+
+/*GetPublicKeyBadRequest The content supplied to the server was invalid
+
+swagger:response getPublicKeyBadRequest
+*/
+type GetPublicKeyBadRequest struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetPublicKeyBadRequest creates GetPublicKeyBadRequest with default headers values
+func NewGetPublicKeyBadRequest() *GetPublicKeyBadRequest {
+
+	return &GetPublicKeyBadRequest{}
+}
+
+// WithPayload adds the payload to the get public key bad request response
+func (o *GetPublicKeyBadRequest) WithPayload(payload *models.Error) *GetPublicKeyBadRequest {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get public key bad request response
+func (o *GetPublicKeyBadRequest) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetPublicKeyBadRequest) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(400)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+// GetPublicKeyNotFoundCode is the HTTP code returned for type GetPublicKeyNotFound
+const GetPublicKeyNotFoundCode int = 404
+
+/*GetPublicKeyNotFound The content requested could not be found
+
+swagger:response getPublicKeyNotFound
+*/
+type GetPublicKeyNotFound struct {
+}
+
+// NewGetPublicKeyNotFound creates GetPublicKeyNotFound with default headers values
+func NewGetPublicKeyNotFound() *GetPublicKeyNotFound {
+
+	return &GetPublicKeyNotFound{}
+}
+
+// WriteResponse to the client
+func (o *GetPublicKeyNotFound) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(404)
+}
+
 /*GetPublicKeyDefault There was an internal error in the server while processing the request
 
 swagger:response getPublicKeyDefault
@@ -13,6 +13,9 @@ import (
 // Set the default logger to development mode
 var Logger *zap.SugaredLogger
 
+// Level is the level of Logger; it can be changed while the server is running
+var Level = zap.NewAtomicLevel()
+
 func init() {
 	ConfigureLogger("dev")
 }
@@ -27,6 +30,8 @@ func ConfigureLogger(logType string) {
 		cfg = zap.NewDevelopmentConfig()
 		cfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	}
+	Level.SetLevel(cfg.Level.Level())
+	cfg.Level = Level
 	logger, err := cfg.Build()
 	if err != nil {
 		log.Fatalln("createLogger", err)
@@ -53,12 +58,27 @@ func WithRequestID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, middleware.RequestIDKey, id)
 }
 
+// RequestID returns the ID of the request r, or an empty string if it has none
+func RequestID(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	id, _ := r.Context().Value(middleware.RequestIDKey).(string)
+	return id
+}
+
 func RequestIDLogger(r *http.Request) *zap.SugaredLogger {
+	if r == nil {
+		return Logger
+	}
+	return ContextLogger(r.Context())
+}
+
+// ContextLogger returns a logger that tags messages with the ID of the request ctx belongs to, if any
+func ContextLogger(ctx context.Context) *zap.SugaredLogger {
 	proposedLogger := Logger
-	if r != nil {
-		if ctxRequestID, ok := r.Context().Value(middleware.RequestIDKey).(string); ok {
-			proposedLogger = proposedLogger.With(zap.String("requestID", ctxRequestID))
-		}
+	if ctxRequestID, ok := ctx.Value(middleware.RequestIDKey).(string); ok {
+		proposedLogger = proposedLogger.With(zap.String("requestID", ctxRequestID))
 	}
 	return proposedLogger
 }
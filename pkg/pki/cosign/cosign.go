@@ -0,0 +1,138 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"strings"
+)
+
+// Signature Signature as produced by cosign sign-blob, that is the base64 encoded ASN.1 ECDSA signature over the
+// SHA-256 digest of the message; the decoded signature is accepted as well
+type Signature struct {
+	signature []byte
+}
+
+// NewSignature creates and validates a cosign signature object
+func NewSignature(r io.Reader) (*Signature, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cosign signature: %w", err)
+	}
+
+	sig := b
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(b))); err == nil {
+		sig = decoded
+	}
+	var parsed struct{ R, S *big.Int }
+	if rest, err := asn1.Unmarshal(sig, &parsed); err != nil || len(rest) > 0 {
+		return nil, errors.New("invalid cosign signature: expected a base64 encoded ASN.1 ECDSA signature")
+	}
+	return &Signature{
+		signature: sig,
+	}, nil
+}
+
+// CanonicalValue implements the pki.Signature interface; signatures are stored base64 encoded, as cosign writes them
+func (s Signature) CanonicalValue() ([]byte, error) {
+	if len(s.signature) == 0 {
+		return nil, errors.New("cosign signature has not been initialized")
+	}
+	return []byte(base64.StdEncoding.EncodeToString(s.signature)), nil
+}
+
+// Verify implements the pki.Signature interface
+func (s Signature) Verify(r io.Reader, k interface{}) error {
+	if len(s.signature) == 0 {
+		return errors.New("cosign signature has not been initialized")
+	}
+
+	key, ok := k.(*PublicKey)
+	if !ok {
+		return fmt.Errorf("cannot use Verify with a non-cosign key")
+	}
+	if key.key == nil {
+		return errors.New("cosign public key has not been initialized")
+	}
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return fmt.Errorf("error reading message to verify signature: %w", err)
+	}
+	if !ecdsa.VerifyASN1(key.key, hasher.Sum(nil), s.signature) {
+		return errors.New("supplied signature does not match key")
+	}
+	return nil
+}
+
+// PublicKey Public Key as written by cosign generate-key-pair (cosign.pub), that is a PEM encoded ECDSA key;
+// the encrypted private key it is paired with is never accepted
+type PublicKey struct {
+	key *ecdsa.PublicKey
+}
+
+// NewPublicKey implements the pki.PublicKey interface
+func NewPublicKey(r io.Reader) (*PublicKey, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cosign public key: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, errors.New("invalid cosign public key: expected a PEM encoded public key")
+	}
+	if strings.Contains(block.Type, "PRIVATE KEY") {
+		return nil, errors.New("invalid cosign public key: a private key was supplied, use the public key (cosign.pub) instead")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("invalid cosign public key: unexpected PEM block type %q", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cosign public key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid cosign public key: expected an ECDSA key, found %T", key)
+	}
+	return &PublicKey{key: ecKey}, nil
+}
+
+// CanonicalValue implements the pki.PublicKey interface; keys are encoded the same way as x509 public keys, so
+// that entries are indexed under the same key regardless of the format used to upload them
+func (k PublicKey) CanonicalValue() ([]byte, error) {
+	if k.key == nil {
+		return nil, errors.New("cosign public key has not been initialized")
+	}
+	b, err := x509.MarshalPKIXPublicKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}), nil
+}
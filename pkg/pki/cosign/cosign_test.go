@@ -0,0 +1,159 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestReadPublicKey(t *testing.T) {
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, err := x509.MarshalPKIXPublicKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caseDesc   string
+		inputFile  string
+		input      []byte
+		errorFound bool
+	}{
+		{caseDesc: "cosign public key", inputFile: "testdata/cosign.pub", errorFound: false},
+		{caseDesc: "cosign private key", inputFile: "testdata/cosign.key", errorFound: true},
+		{caseDesc: "ed25519 key", input: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: edDER}), errorFound: true},
+		{caseDesc: "not PEM encoded", input: []byte("hello world"), errorFound: true},
+	}
+
+	for _, tc := range tests {
+		input := tc.input
+		if tc.inputFile != "" {
+			if input, err = ioutil.ReadFile(tc.inputFile); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got, err := NewPublicKey(bytes.NewReader(input)); ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+	}
+
+	pub, err := ioutil.ReadFile("testdata/cosign.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := NewPublicKey(bytes.NewReader(pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canonical, err := k.CanonicalValue(); err != nil || !bytes.Equal(canonical, pub) {
+		t.Errorf("unexpected canonical value: %s (%v)", canonical, err)
+	}
+	if _, err := (PublicKey{}).CanonicalValue(); err == nil {
+		t.Error("expected error for uninitialized key")
+	}
+}
+
+func TestReadSignature(t *testing.T) {
+	b64, err := ioutil.ReadFile("testdata/hello_world.txt.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(string(b64))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caseDesc   string
+		input      []byte
+		errorFound bool
+	}{
+		{caseDesc: "base64 encoded signature", input: b64, errorFound: false},
+		{caseDesc: "base64 encoded signature with newline", input: append(b64, '\n'), errorFound: false},
+		{caseDesc: "raw signature", input: raw, errorFound: false},
+		{caseDesc: "truncated signature", input: raw[:len(raw)-2], errorFound: true},
+		{caseDesc: "not a signature", input: []byte("hello world"), errorFound: true},
+	}
+
+	for _, tc := range tests {
+		got, err := NewSignature(bytes.NewReader(tc.input))
+		if ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+			continue
+		}
+		if got != nil {
+			if canonical, err := got.CanonicalValue(); err != nil || !bytes.Equal(canonical, b64) {
+				t.Errorf("%v: unexpected canonical value: %s (%v)", tc.caseDesc, canonical, err)
+			}
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	open := func(name string) *os.File {
+		t.Helper()
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+
+	k, err := NewPublicKey(open("testdata/cosign.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSignature(open("testdata/hello_world.txt.sig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Verify(open("testdata/hello_world.txt"), k); err != nil {
+		t.Errorf("unexpected error verifying signature: %v", err)
+	}
+	if err := s.Verify(strings.NewReader("tampered"), k); err == nil {
+		t.Error("expected error verifying a different message")
+	}
+	if err := s.Verify(open("testdata/hello_world.txt"), &PublicKey{}); err == nil {
+		t.Error("expected error verifying with uninitialized key")
+	}
+	if err := s.Verify(open("testdata/hello_world.txt"), "not a key"); err == nil {
+		t.Error("expected error verifying with wrong key type")
+	}
+	if err := (Signature{}).Verify(open("testdata/hello_world.txt"), k); err == nil {
+		t.Error("expected error verifying uninitialized signature")
+	}
+}
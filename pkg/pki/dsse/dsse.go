@@ -0,0 +1,251 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dsse verifies Dead Simple Signing Envelopes, as used by in-toto attestations and SLSA provenance, see
+// https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+package dsse
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// hash functions used with the larger ECDSA curves
+	_ "crypto/sha512"
+)
+
+// Envelope is a DSSE envelope; Payload holds the decoded payload
+type Envelope struct {
+	PayloadType string
+	Payload     []byte
+	Signatures  []Signature
+}
+
+// Signature is one of the signatures of an envelope; KeyID is an optional, unauthenticated hint of which key
+// made the signature
+type Signature struct {
+	KeyID string
+	Sig   []byte
+}
+
+type envelopeJSON struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []signatureJSON `json:"signatures"`
+}
+
+type signatureJSON struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// ParseEnvelope parses the JSON encoding of a DSSE envelope; the payload and signatures may use either the
+// standard or the URL-safe base64 alphabet, as the specification allows both
+func ParseEnvelope(b []byte) (*Envelope, error) {
+	raw := envelopeJSON{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid DSSE envelope: %w", err)
+	}
+	if raw.PayloadType == "" {
+		return nil, errors.New("invalid DSSE envelope: missing payload type")
+	}
+	if len(raw.Signatures) == 0 {
+		return nil, errors.New("invalid DSSE envelope: no signatures")
+	}
+
+	payload, err := decodeBase64(raw.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DSSE envelope: error decoding payload: %w", err)
+	}
+	e := &Envelope{PayloadType: raw.PayloadType, Payload: payload}
+	for i, s := range raw.Signatures {
+		sig, err := decodeBase64(s.Sig)
+		if err != nil || len(sig) == 0 {
+			return nil, fmt.Errorf("invalid DSSE envelope: signature %v is not base64 encoded", i)
+		}
+		e.Signatures = append(e.Signatures, Signature{KeyID: s.KeyID, Sig: sig})
+	}
+	return e, nil
+}
+
+// MarshalJSON encodes the envelope as specified, using the standard base64 alphabet
+func (e Envelope) MarshalJSON() ([]byte, error) {
+	raw := envelopeJSON{
+		PayloadType: e.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(e.Payload),
+		Signatures:  []signatureJSON{},
+	}
+	for _, s := range e.Signatures {
+		raw.Signatures = append(raw.Signatures, signatureJSON{KeyID: s.KeyID, Sig: base64.StdEncoding.EncodeToString(s.Sig)})
+	}
+	return json.Marshal(raw)
+}
+
+func decodeBase64(s string) ([]byte, error) {
+	if strings.ContainsAny(s, "-_") {
+		return base64.URLEncoding.DecodeString(padBase64(s))
+	}
+	return base64.StdEncoding.DecodeString(padBase64(s))
+}
+
+// padBase64 restores the padding that some signers strip
+func padBase64(s string) string {
+	if n := len(s) % 4; n != 0 {
+		return s + strings.Repeat("=", 4-n)
+	}
+	return s
+}
+
+// PAE returns the pre-authentication encoding of a payload, which is the message that is actually signed, so
+// that a signature cannot be replayed for a payload of a different type
+func PAE(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("DSSEv1 ")
+	buf.WriteString(strconv.Itoa(len(payloadType)))
+	buf.WriteByte(' ')
+	buf.WriteString(payloadType)
+	buf.WriteByte(' ')
+	buf.WriteString(strconv.Itoa(len(payload)))
+	buf.WriteByte(' ')
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// Verifier checks signatures made by a single key
+type Verifier interface {
+	// KeyID returns the ID of the key, or "" if it has none
+	KeyID() string
+	// Verify checks sig over message
+	Verify(message, sig []byte) error
+}
+
+// keyVerifier verifies signatures with a public key, hashing messages as is conventional for its algorithm
+type keyVerifier struct {
+	keyID string
+	key   crypto.PublicKey
+}
+
+// NewVerifier returns a Verifier for an ECDSA, Ed25519 or RSA (PKCS #1 v1.5 or PSS with SHA-256) public key
+func NewVerifier(keyID string, key crypto.PublicKey) (Verifier, error) {
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return keyVerifier{keyID: keyID, key: key}, nil
+	}
+	return nil, fmt.Errorf("unsupported DSSE key type %T", key)
+}
+
+func (v keyVerifier) KeyID() string {
+	return v.keyID
+}
+
+func (v keyVerifier) Verify(message, sig []byte) error {
+	switch pub := v.key.(type) {
+	case ed25519.PublicKey:
+		if ed25519.Verify(pub, message, sig) {
+			return nil
+		}
+	case *ecdsa.PublicKey:
+		h := curveHash(pub.Curve).New()
+		_, _ = h.Write(message)
+		if ecdsa.VerifyASN1(pub, h.Sum(nil), sig) {
+			return nil
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(message)
+		if rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig) == nil {
+			return nil
+		}
+		if rsa.VerifyPSS(pub, crypto.SHA256, digest[:], sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}) == nil {
+			return nil
+		}
+	}
+	return errors.New("supplied signature does not match key")
+}
+
+// curveHash returns the hash conventionally used with ECDSA keys on curve
+func curveHash(curve elliptic.Curve) crypto.Hash {
+	switch bits := curve.Params().BitSize; {
+	case bits > 384:
+		return crypto.SHA512
+	case bits > 256:
+		return crypto.SHA384
+	default:
+		return crypto.SHA256
+	}
+}
+
+// ThresholdError is returned when fewer distinct keys than required signed an envelope
+type ThresholdError struct {
+	Threshold int
+	Verified  int
+}
+
+func (e *ThresholdError) Error() string {
+	return fmt.Sprintf("DSSE envelope is signed by %v of the %v required keys", e.Verified, e.Threshold)
+}
+
+// Verify checks that at least threshold of the verifiers made a signature over the envelope, returning those
+// that did in the order they were given. A key counts once however many signatures it made, so repeating a
+// signature does not help reach the threshold. When both a signature and a verifier carry a key ID, the
+// signature is only checked against verifiers with the same ID; otherwise it is checked against all of them.
+func (e Envelope) Verify(threshold int, verifiers ...Verifier) ([]Verifier, error) {
+	if threshold < 1 {
+		return nil, fmt.Errorf("invalid DSSE threshold %v", threshold)
+	}
+	if len(verifiers) < threshold {
+		return nil, &ThresholdError{Threshold: threshold, Verified: 0}
+	}
+
+	message := PAE(e.PayloadType, e.Payload)
+	verified := make([]bool, len(verifiers))
+	for _, s := range e.Signatures {
+		for i, v := range verifiers {
+			if verified[i] {
+				continue
+			}
+			if s.KeyID != "" && v.KeyID() != "" && s.KeyID != v.KeyID() {
+				continue
+			}
+			if v.Verify(message, s.Sig) == nil {
+				verified[i] = true
+				break
+			}
+		}
+	}
+
+	var result []Verifier
+	for i, v := range verifiers {
+		if verified[i] {
+			result = append(result, v)
+		}
+	}
+	if len(result) < threshold {
+		return nil, &ThresholdError{Threshold: threshold, Verified: len(result)}
+	}
+	return result, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dsse
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestPAE(t *testing.T) {
+	// the test vector of the DSSE protocol specification
+	got := string(PAE("http://example.com/HelloWorld", []byte("hello world")))
+	if want := "DSSEv1 29 http://example.com/HelloWorld 11 hello world"; got != want {
+		t.Errorf("PAE() = %q, want %q", got, want)
+	}
+	if got := string(PAE("", nil)); got != "DSSEv1 0  0 " {
+		t.Errorf("PAE() of empty payload = %q", got)
+	}
+}
+
+func TestParseEnvelope(t *testing.T) {
+	payload := base64.StdEncoding.EncodeToString([]byte("hello world"))
+
+	tests := []struct {
+		caseDesc   string
+		input      string
+		errorFound bool
+	}{
+		{caseDesc: "valid envelope", input: `{"payloadType":"text/plain","payload":"` + payload + `","signatures":[{"keyid":"a","sig":"AQID"}]}`, errorFound: false},
+		{caseDesc: "URL-safe unpadded base64", input: `{"payloadType":"text/plain","payload":"aGVsbG8gd29ybGQ","signatures":[{"sig":"-_8"}]}`, errorFound: false},
+		{caseDesc: "missing payload type", input: `{"payload":"` + payload + `","signatures":[{"sig":"AQID"}]}`, errorFound: true},
+		{caseDesc: "no signatures", input: `{"payloadType":"text/plain","payload":"` + payload + `","signatures":[]}`, errorFound: true},
+		{caseDesc: "empty signature", input: `{"payloadType":"text/plain","payload":"` + payload + `","signatures":[{"sig":""}]}`, errorFound: true},
+		{caseDesc: "payload not base64", input: `{"payloadType":"text/plain","payload":"not base64!","signatures":[{"sig":"AQID"}]}`, errorFound: true},
+		{caseDesc: "unknown field", input: `{"payloadType":"text/plain","payload":"` + payload + `","signatures":[{"sig":"AQID"}],"extra":1}`, errorFound: true},
+		{caseDesc: "not JSON", input: `hello world`, errorFound: true},
+	}
+
+	for _, tc := range tests {
+		e, err := ParseEnvelope([]byte(tc.input))
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result parsing envelope: %v", tc.caseDesc, err)
+			continue
+		}
+		if err == nil && string(e.Payload) != "hello world" {
+			t.Errorf("%v: unexpected payload %q", tc.caseDesc, e.Payload)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloadType, payload := "application/vnd.in-toto+json", []byte(`{"_type":"https://in-toto.io/Statement/v0.1"}`)
+	message := PAE(payloadType, payload)
+	edSig := ed25519.Sign(edPriv, message)
+	digest := sha512.Sum384(message)
+	ecSig, err := ecKey.Sign(rand.Reader, digest[:], crypto.SHA384)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSig := ed25519.Sign(otherPriv, message)
+
+	edVerifier, err := NewVerifier("ed", edPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecVerifier, err := NewVerifier("ec", &ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anonVerifier, err := NewVerifier("", edPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewVerifier("", "not a key"); err == nil {
+		t.Error("expected error creating a verifier for an unsupported key")
+	}
+
+	tests := []struct {
+		caseDesc    string
+		payloadType string
+		signatures  []Signature
+		threshold   int
+		verifiers   []Verifier
+		verified    int
+		errorFound  bool
+	}{
+		{caseDesc: "single signature", signatures: []Signature{{KeyID: "ed", Sig: edSig}}, threshold: 1, verifiers: []Verifier{edVerifier}, verified: 1},
+		{caseDesc: "signature without key ID", signatures: []Signature{{Sig: edSig}}, threshold: 1, verifiers: []Verifier{ecVerifier, edVerifier}, verified: 1},
+		{caseDesc: "verifier without key ID", signatures: []Signature{{KeyID: "ed", Sig: edSig}}, threshold: 1, verifiers: []Verifier{anonVerifier}, verified: 1},
+		{caseDesc: "two of two", signatures: []Signature{{KeyID: "ec", Sig: ecSig}, {KeyID: "ed", Sig: edSig}}, threshold: 2, verifiers: []Verifier{edVerifier, ecVerifier}, verified: 2},
+		{caseDesc: "one of two above threshold of one", signatures: []Signature{{KeyID: "other", Sig: otherSig}, {KeyID: "ec", Sig: ecSig}}, threshold: 1, verifiers: []Verifier{edVerifier, ecVerifier}, verified: 1},
+		{caseDesc: "below threshold", signatures: []Signature{{KeyID: "ed", Sig: edSig}, {KeyID: "other", Sig: otherSig}}, threshold: 2, verifiers: []Verifier{edVerifier, ecVerifier}, errorFound: true},
+		{caseDesc: "repeated signature counts once", signatures: []Signature{{KeyID: "ed", Sig: edSig}, {KeyID: "ed", Sig: edSig}}, threshold: 2, verifiers: []Verifier{edVerifier, ecVerifier}, errorFound: true},
+		{caseDesc: "mismatched key ID", signatures: []Signature{{KeyID: "ec", Sig: edSig}}, threshold: 1, verifiers: []Verifier{edVerifier}, errorFound: true},
+		{caseDesc: "different payload type", payloadType: "text/plain", signatures: []Signature{{KeyID: "ed", Sig: edSig}}, threshold: 1, verifiers: []Verifier{edVerifier}, errorFound: true},
+		{caseDesc: "fewer verifiers than threshold", signatures: []Signature{{KeyID: "ed", Sig: edSig}}, threshold: 2, verifiers: []Verifier{edVerifier}, errorFound: true},
+		{caseDesc: "zero threshold", signatures: []Signature{{KeyID: "ed", Sig: edSig}}, threshold: 0, verifiers: []Verifier{edVerifier}, errorFound: true},
+	}
+
+	for _, tc := range tests {
+		e := Envelope{PayloadType: payloadType, Payload: payload, Signatures: tc.signatures}
+		if tc.payloadType != "" {
+			e.PayloadType = tc.payloadType
+		}
+		verified, err := e.Verify(tc.threshold, tc.verifiers...)
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result verifying envelope: %v", tc.caseDesc, err)
+			continue
+		}
+		if err == nil && len(verified) != tc.verified {
+			t.Errorf("%v: %v keys verified, expected %v", tc.caseDesc, len(verified), tc.verified)
+		}
+	}
+
+	e := Envelope{PayloadType: payloadType, Payload: payload, Signatures: []Signature{{KeyID: "ed", Sig: edSig}}}
+	_, err = e.Verify(2, edVerifier, ecVerifier)
+	var thresholdErr *ThresholdError
+	if !errors.As(err, &thresholdErr) || thresholdErr.Verified != 1 || thresholdErr.Threshold != 2 {
+		t.Errorf("expected threshold error, got %v", err)
+	}
+
+	// envelopes survive a round trip through their JSON encoding
+	b, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseEnvelope(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parsed.Verify(1, edVerifier); err != nil {
+		t.Errorf("unexpected error verifying parsed envelope: %v", err)
+	}
+}
+
+func TestSubjectDigests(t *testing.T) {
+	sha256Digest := "A665A45920422F9D417E4867EFDC4FB8A04A1F3FFF1FA07E998E86F7F7A27AE3"
+	sha512Digest := strings.Repeat("ab", 64)
+	statement := `{"_type":"https://in-toto.io/Statement/v0.1","subject":[` +
+		`{"name":"a","digest":{"sha256":"` + sha256Digest + `","gitCommit":"abc"}},` +
+		`{"name":"b","digest":{"sha512":"` + sha512Digest + `","sha256":"` + strings.ToLower(sha256Digest) + `"}}]}`
+
+	keys, err := Envelope{PayloadType: InTotoPayloadType, Payload: []byte(statement)}.SubjectIndexKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{SubjectKey(sha256Digest), "subject:" + sha512Digest}
+	if !reflect.DeepEqual(keys, expected) || expected[0] != "subject:"+strings.ToLower(sha256Digest) {
+		t.Errorf("unexpected subject index keys %v", keys)
+	}
+
+	// other payloads carry no subjects
+	if digests, err := (Envelope{PayloadType: "text/plain", Payload: []byte(statement)}).SubjectDigests(); err != nil || digests != nil {
+		t.Errorf("unexpected subjects %v of plain text payload: %v", digests, err)
+	}
+	for _, payload := range []string{
+		`not JSON`,
+		`{"subject":[{"name":"a","digest":{"sha256":"abc"}}]}`,
+	} {
+		if _, err := (Envelope{PayloadType: InTotoPayloadType, Payload: []byte(payload)}).SubjectDigests(); err == nil {
+			t.Errorf("expected error for statement %v", payload)
+		}
+	}
+}
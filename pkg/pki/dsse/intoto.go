@@ -0,0 +1,102 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dsse
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InTotoPayloadType is the payload type of envelopes carrying in-toto statements, such as SLSA provenance
+const InTotoPayloadType = "application/vnd.in-toto+json"
+
+// subjectDigestSizes are the sizes of the subject digests that are indexed, matching the digests the search
+// index accepts
+var subjectDigestSizes = map[string]int{
+	"sha256": 32,
+	"sha384": 48,
+	"sha512": 64,
+}
+
+type inTotoStatement struct {
+	Type    string `json:"_type"`
+	Subject []struct {
+		Name   string            `json:"name"`
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// SubjectKey returns the index key under which entries attesting to the artifact with the hex encoded digest
+// are stored
+func SubjectKey(digest string) string {
+	return "subject:" + strings.ToLower(digest)
+}
+
+// SubjectDigests returns the lowercase hex encoded SHA-256, SHA-384 and SHA-512 digests of the subjects of the
+// in-toto statement the envelope carries, or nil if its payload is of another type; digests of other algorithms,
+// such as git commits, are skipped
+func (e Envelope) SubjectDigests() ([]string, error) {
+	if e.PayloadType != InTotoPayloadType {
+		return nil, nil
+	}
+	var s inTotoStatement
+	if err := json.Unmarshal(e.Payload, &s); err != nil {
+		return nil, fmt.Errorf("invalid in-toto statement: %w", err)
+	}
+	seen := map[string]struct{}{}
+	var result []string
+	for _, subject := range s.Subject {
+		algs := make([]string, 0, len(subject.Digest))
+		for alg := range subject.Digest {
+			algs = append(algs, alg)
+		}
+		sort.Strings(algs)
+		for _, alg := range algs {
+			digest := subject.Digest[alg]
+			size, ok := subjectDigestSizes[strings.ToLower(alg)]
+			if !ok {
+				continue
+			}
+			if b, err := hex.DecodeString(digest); err != nil || len(b) != size {
+				return nil, fmt.Errorf("invalid %v digest of subject %q", alg, subject.Name)
+			}
+			digest = strings.ToLower(digest)
+			if _, ok := seen[digest]; !ok {
+				seen[digest] = struct{}{}
+				result = append(result, digest)
+			}
+		}
+	}
+	return result, nil
+}
+
+// SubjectIndexKeys returns the index keys of the subjects of the in-toto statement the envelope carries, for
+// entry types that log attestations to include among their IndexKeys
+func (e Envelope) SubjectIndexKeys() ([]string, error) {
+	digests, err := e.SubjectDigests()
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, digest := range digests {
+		result = append(result, SubjectKey(digest))
+	}
+	return result, nil
+}
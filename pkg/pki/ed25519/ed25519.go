@@ -0,0 +1,140 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ed25519
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// Signature Signature that is a bare Ed25519 signature over the message; the signature may be supplied as the
+// 64 raw bytes or base64 encoded
+type Signature struct {
+	signature []byte
+}
+
+// NewSignature creates and validates an Ed25519 signature object
+func NewSignature(r io.Reader) (*Signature, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ed25519 signature: %w", err)
+	}
+	if len(b) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(b)))
+		if err != nil || len(decoded) != ed25519.SignatureSize {
+			return nil, fmt.Errorf("invalid ed25519 signature: expected %v raw or base64 encoded bytes", ed25519.SignatureSize)
+		}
+		b = decoded
+	}
+	return &Signature{
+		signature: b,
+	}, nil
+}
+
+// CanonicalValue implements the pki.Signature interface
+func (s Signature) CanonicalValue() ([]byte, error) {
+	if len(s.signature) == 0 {
+		return nil, errors.New("ed25519 signature has not been initialized")
+	}
+	return s.signature, nil
+}
+
+// Verify implements the pki.Signature interface
+func (s Signature) Verify(r io.Reader, k interface{}) error {
+	if len(s.signature) == 0 {
+		return errors.New("ed25519 signature has not been initialized")
+	}
+
+	key, ok := k.(*PublicKey)
+	if !ok {
+		return fmt.Errorf("cannot use Verify with a non-ed25519 key")
+	}
+	if key.key == nil {
+		return errors.New("ed25519 public key has not been initialized")
+	}
+
+	message, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading message to verify signature: %w", err)
+	}
+	if !ed25519.Verify(key.key, message, s.signature) {
+		return errors.New("supplied signature does not match key")
+	}
+	return nil
+}
+
+// PublicKey Public Key that is a bare Ed25519 key; the key may be supplied as the 32 raw bytes, base64 encoded,
+// or as a PEM encoded "PUBLIC KEY" block
+type PublicKey struct {
+	key ed25519.PublicKey
+}
+
+// NewPublicKey implements the pki.PublicKey interface
+func NewPublicKey(r io.Reader) (*PublicKey, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ed25519 public key: %w", err)
+	}
+	if len(b) == ed25519.PublicKeySize {
+		return &PublicKey{key: ed25519.PublicKey(b)}, nil
+	}
+
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("invalid ed25519 public key: unexpected PEM block type %q", block.Type)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ed25519 public key: %w", err)
+		}
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid ed25519 public key: PEM block contains a %T", key)
+		}
+		return &PublicKey{key: edKey}, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(b)))
+	if err != nil || len(decoded) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key: expected %v raw or base64 encoded bytes, or a PEM encoded key", ed25519.PublicKeySize)
+	}
+	return &PublicKey{key: ed25519.PublicKey(decoded)}, nil
+}
+
+// CanonicalValue implements the pki.PublicKey interface; keys are encoded the same way as x509 Ed25519 keys, so
+// that entries are indexed under the same key regardless of the format used to upload them
+func (k PublicKey) CanonicalValue() ([]byte, error) {
+	if k.key == nil {
+		return nil, errors.New("ed25519 public key has not been initialized")
+	}
+	b, err := x509.MarshalPKIXPublicKey(k.key)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: "PUBLIC KEY", Bytes: b}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
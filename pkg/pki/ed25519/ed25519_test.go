@@ -0,0 +1,165 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ed25519
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestReadPublicKey(t *testing.T) {
+	raw, err := ioutil.ReadFile("testdata/ed25519.raw.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalPKIXPublicKey(ecKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caseDesc   string
+		input      []byte
+		errorFound bool
+	}{
+		{caseDesc: "raw key", input: raw, errorFound: false},
+		{caseDesc: "base64 encoded key", input: []byte(base64.StdEncoding.EncodeToString(raw) + "\n"), errorFound: false},
+		{caseDesc: "truncated raw key", input: raw[:31], errorFound: true},
+		{caseDesc: "ecdsa key in PEM block", input: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecDER}), errorFound: true},
+		{caseDesc: "wrong PEM block type", input: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ecDER}), errorFound: true},
+		{caseDesc: "not a key", input: []byte("hello world"), errorFound: true},
+	}
+
+	for _, tc := range tests {
+		if got, err := NewPublicKey(bytes.NewReader(tc.input)); ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+	}
+
+	// all encodings of the key have the same canonical value, which is also how x509 encodes Ed25519 keys
+	pemBytes, err := ioutil.ReadFile("testdata/ed25519.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, input := range [][]byte{raw, pemBytes, []byte(base64.StdEncoding.EncodeToString(raw))} {
+		k, err := NewPublicKey(bytes.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		canonical, err := k.CanonicalValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(canonical, pemBytes) {
+			t.Errorf("unexpected canonical value: %s", canonical)
+		}
+	}
+
+	if _, err := (PublicKey{}).CanonicalValue(); err == nil {
+		t.Error("expected error for uninitialized key")
+	}
+}
+
+func TestReadSignature(t *testing.T) {
+	tests := []struct {
+		caseDesc   string
+		inputFile  string
+		errorFound bool
+	}{
+		{caseDesc: "base64 encoded signature", inputFile: "testdata/hello_world.txt.sig", errorFound: false},
+		{caseDesc: "raw signature", inputFile: "testdata/hello_world.txt.raw.sig", errorFound: false},
+		{caseDesc: "not a signature", inputFile: "testdata/ed25519.pub", errorFound: true},
+	}
+
+	for _, tc := range tests {
+		file, err := os.Open(tc.inputFile)
+		if err != nil {
+			t.Fatalf("%v: cannot open %v", tc.caseDesc, tc.inputFile)
+		}
+		if got, err := NewSignature(file); ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
+			t.Errorf("%v: unexpected result testing %v: %v", tc.caseDesc, tc.inputFile, err)
+		}
+		file.Close()
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	open := func(name string) *os.File {
+		t.Helper()
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+
+	k, err := NewPublicKey(open("testdata/ed25519.raw.pub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sigFile := range []string{"testdata/hello_world.txt.sig", "testdata/hello_world.txt.raw.sig"} {
+		s, err := NewSignature(open(sigFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Verify(open("testdata/hello_world.txt"), k); err != nil {
+			t.Errorf("unexpected error verifying %v: %v", sigFile, err)
+		}
+		if err := s.Verify(strings.NewReader("tampered"), k); err == nil {
+			t.Errorf("expected error verifying %v over a different message", sigFile)
+		}
+		canonical, err := s.CanonicalValue()
+		if err != nil || len(canonical) != 64 {
+			t.Errorf("unexpected canonical signature: %v", err)
+		}
+	}
+
+	s, err := NewSignature(open("testdata/hello_world.txt.sig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(open("testdata/hello_world.txt"), &PublicKey{}); err == nil {
+		t.Error("expected error verifying with uninitialized key")
+	}
+	if err := s.Verify(open("testdata/hello_world.txt"), "not a key"); err == nil {
+		t.Error("expected error verifying with wrong key type")
+	}
+	if err := (Signature{}).Verify(open("testdata/hello_world.txt"), k); err == nil {
+		t.Error("expected error verifying uninitialized signature")
+	}
+}
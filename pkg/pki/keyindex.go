@@ -0,0 +1,146 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"sort"
+	"sync"
+)
+
+// DefaultKeyIndexScheme is the scheme used unless a deployment configures another one
+const DefaultKeyIndexScheme = "sha256"
+
+// KeyIndexFunc derives the index key under which entries signed with a public key are stored, from the
+// canonical encoding of the key; key is the parsed key when one is available (and nil otherwise, such as
+// for certificates that are not handled by a pki format), so that schemes can use format specific fingerprints
+type KeyIndexFunc func(canonical []byte, key PublicKey) (string, error)
+
+// Fingerprinter is optionally implemented by public keys that have a fingerprint conventionally used to
+// identify them, such as the fingerprint of a PGP primary key; it returns "" if there is none
+type Fingerprinter interface {
+	Fingerprint() string
+}
+
+// IndexValuer is optionally implemented by public keys whose canonical value carries more than the key, such as
+// a certificate along with the chain that issued it; the index key is then derived from IndexValue instead
+type IndexValuer interface {
+	IndexValue() ([]byte, error)
+}
+
+var (
+	keyIndexMu      sync.RWMutex
+	keyIndexSchemes = map[string]KeyIndexFunc{
+		"sha256":      hashKeyIndex(sha256.New),
+		"sha512":      hashKeyIndex(sha512.New),
+		"fingerprint": fingerprintKeyIndex,
+	}
+	keyIndexScheme = DefaultKeyIndexScheme
+)
+
+func hashKeyIndex(h func() hash.Hash) KeyIndexFunc {
+	return func(canonical []byte, _ PublicKey) (string, error) {
+		hasher := h()
+		if _, err := hasher.Write(canonical); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(hasher.Sum(nil)), nil
+	}
+}
+
+// fingerprintKeyIndex uses the native fingerprint of keys that have one, and the sha256 scheme otherwise
+func fingerprintKeyIndex(canonical []byte, key PublicKey) (string, error) {
+	if f, ok := key.(Fingerprinter); ok {
+		if fp := f.Fingerprint(); fp != "" {
+			return fp, nil
+		}
+	}
+	return hashKeyIndex(sha256.New)(canonical, key)
+}
+
+// RegisterKeyIndexScheme makes a scheme available to SetKeyIndexScheme, so that private deployments can
+// derive keys the way their other systems identify them; it is meant to be called from init functions
+func RegisterKeyIndexScheme(name string, fn KeyIndexFunc) {
+	keyIndexMu.Lock()
+	defer keyIndexMu.Unlock()
+	keyIndexSchemes[name] = fn
+}
+
+// KeyIndexSchemes returns the names of the registered schemes
+func KeyIndexSchemes() []string {
+	keyIndexMu.RLock()
+	defer keyIndexMu.RUnlock()
+	names := make([]string, 0, len(keyIndexSchemes))
+	for name := range keyIndexSchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetKeyIndexScheme selects the scheme used by KeyIndex and CanonicalKeyIndex
+func SetKeyIndexScheme(name string) error {
+	keyIndexMu.Lock()
+	defer keyIndexMu.Unlock()
+	if _, ok := keyIndexSchemes[name]; !ok {
+		return fmt.Errorf("unknown key index scheme '%v'", name)
+	}
+	keyIndexScheme = name
+	return nil
+}
+
+// KeyIndexScheme returns the name of the scheme in use
+func KeyIndexScheme() string {
+	keyIndexMu.RLock()
+	defer keyIndexMu.RUnlock()
+	return keyIndexScheme
+}
+
+// KeyIndex returns the index key of a public key under the scheme in use
+func KeyIndex(key PublicKey) (string, error) {
+	if key == nil {
+		return "", errors.New("public key has not been initialized")
+	}
+	var canonical []byte
+	var err error
+	if iv, ok := key.(IndexValuer); ok {
+		canonical, err = iv.IndexValue()
+	} else {
+		canonical, err = key.CanonicalValue()
+	}
+	if err != nil {
+		return "", err
+	}
+	return keyIndex(canonical, key)
+}
+
+// CanonicalKeyIndex returns the index key of a canonically encoded key that is not handled by a pki format
+func CanonicalKeyIndex(canonical []byte) (string, error) {
+	return keyIndex(canonical, nil)
+}
+
+func keyIndex(canonical []byte, key PublicKey) (string, error) {
+	keyIndexMu.RLock()
+	fn := keyIndexSchemes[keyIndexScheme]
+	keyIndexMu.RUnlock()
+	return fn(canonical, key)
+}
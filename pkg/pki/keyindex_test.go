@@ -0,0 +1,163 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestKey(t *testing.T, format, keyFile string) (PublicKey, []byte) {
+	t.Helper()
+	f, err := os.Open(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	key, err := NewArtifactFactory(format).NewPublicKey(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical, err := key.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, canonical
+}
+
+func TestKeyIndex(t *testing.T) {
+	defer func() {
+		if err := SetKeyIndexScheme(DefaultKeyIndexScheme); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	pgpKey, pgpCanonical := newTestKey(t, "pgp", "pgp/testdata/valid_armored_public.pgp")
+	sshKey, sshCanonical := newTestKey(t, "ssh", "ssh/testdata/id_rsa.pub")
+	x509Key, x509Canonical := newTestKey(t, "x509", "x509/testdata/ec.pub")
+
+	sshBytes, err := ioutil.ReadFile("ssh/testdata/id_rsa.pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, _, _, _, err := ssh.ParseAuthorizedKey(sshBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sha256Hex := func(b []byte) string {
+		h := sha256.Sum256(b)
+		return hex.EncodeToString(h[:])
+	}
+	sha512Hex := func(b []byte) string {
+		h := sha512.Sum512(b)
+		return hex.EncodeToString(h[:])
+	}
+
+	tests := []struct {
+		scheme   string
+		key      PublicKey
+		expected string
+	}{
+		{scheme: "sha256", key: sshKey, expected: sha256Hex(sshCanonical)},
+		{scheme: "sha256", key: pgpKey, expected: sha256Hex(pgpCanonical)},
+		{scheme: "sha512", key: x509Key, expected: sha512Hex(x509Canonical)},
+		{scheme: "fingerprint", key: sshKey, expected: ssh.FingerprintSHA256(sshPub)},
+		// x509 keys have no native fingerprint, so the sha256 scheme is used
+		{scheme: "fingerprint", key: x509Key, expected: sha256Hex(x509Canonical)},
+	}
+	for _, tc := range tests {
+		if err := SetKeyIndexScheme(tc.scheme); err != nil {
+			t.Fatal(err)
+		}
+		got, err := KeyIndex(tc.key)
+		if err != nil {
+			t.Errorf("unexpected error with scheme '%v': %v", tc.scheme, err)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("unexpected key with scheme '%v': got %v, expected %v", tc.scheme, got, tc.expected)
+		}
+	}
+
+	if err := SetKeyIndexScheme("fingerprint"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := KeyIndex(pgpKey); err != nil || !regexp.MustCompile("^[0-9a-f]{40}$").MatchString(got) {
+		t.Errorf("expected the pgp fingerprint, got %v (%v)", got, err)
+	}
+	if got, err := CanonicalKeyIndex(x509Canonical); err != nil || got != sha256Hex(x509Canonical) {
+		t.Errorf("unexpected canonical key index %v (%v)", got, err)
+	}
+
+	if _, err := KeyIndex(nil); err == nil {
+		t.Error("expected error for nil key")
+	}
+	if err := SetKeyIndexScheme("md5"); err == nil {
+		t.Error("expected error for unknown scheme")
+	}
+	if KeyIndexScheme() != "fingerprint" {
+		t.Errorf("unknown scheme should not have been selected: %v", KeyIndexScheme())
+	}
+}
+
+func TestRegisterKeyIndexScheme(t *testing.T) {
+	defer func() {
+		if err := SetKeyIndexScheme(DefaultKeyIndexScheme); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	RegisterKeyIndexScheme("constant", func(_ []byte, _ PublicKey) (string, error) {
+		return "constant", nil
+	})
+	found := false
+	for _, name := range KeyIndexSchemes() {
+		found = found || name == "constant"
+	}
+	if !found {
+		t.Fatalf("registered scheme not listed: %v", KeyIndexSchemes())
+	}
+	if err := SetKeyIndexScheme("constant"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := CanonicalKeyIndex([]byte("key")); err != nil || got != "constant" {
+		t.Errorf("registered scheme was not used: %v (%v)", got, err)
+	}
+}
+
+type indexValueKey struct{}
+
+func (indexValueKey) CanonicalValue() ([]byte, error) { return []byte("key and chain"), nil }
+func (indexValueKey) IndexValue() ([]byte, error)     { return []byte("key"), nil }
+
+func TestKeyIndexValue(t *testing.T) {
+	expected, err := CanonicalKeyIndex([]byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := KeyIndex(indexValueKey{}); err != nil || got != expected {
+		t.Errorf("expected the index key to be derived from IndexValue, got %v (%v)", got, err)
+	}
+}
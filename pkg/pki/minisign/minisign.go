@@ -26,9 +26,18 @@ import (
 	"strings"
 
 	minisign "github.com/jedisct1/go-minisign"
+	"golang.org/x/crypto/blake2b"
 )
 
-// Signature Signature that follows the minisign standard; supports both minisign and signify generated signatures
+var (
+	// legacyAlgorithm signs the message itself; signify and minisign before 0.10 produce these signatures
+	legacyAlgorithm = [2]byte{'E', 'd'}
+	// hashedAlgorithm signs the BLAKE2b-512 hash of the message; this is the default since minisign 0.10
+	hashedAlgorithm = [2]byte{'E', 'D'}
+)
+
+// Signature Signature that follows the minisign standard; supports both minisign and signify generated signatures,
+// including minisign signatures over prehashed messages
 type Signature struct {
 	signature *minisign.Signature
 }
@@ -99,9 +108,29 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 		return fmt.Errorf("minisign public key has not been initialized")
 	}
 
-	msg, err := ioutil.ReadAll(r)
-	if err != nil {
-		return fmt.Errorf("error reading message to verify signature: %w", err)
+	if key.key.KeyId != s.signature.KeyId {
+		return fmt.Errorf("signature was made by key ID %X, not %X", s.signature.KeyId, key.key.KeyId)
+	}
+
+	var msg []byte
+	switch s.signature.SignatureAlgorithm {
+	case legacyAlgorithm:
+		var err error
+		msg, err = ioutil.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("error reading message to verify signature: %w", err)
+		}
+	case hashedAlgorithm:
+		hasher, err := blake2b.New512(nil)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(hasher, r); err != nil {
+			return fmt.Errorf("error reading message to verify signature: %w", err)
+		}
+		msg = hasher.Sum(nil)
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm %q", s.signature.SignatureAlgorithm[:])
 	}
 
 	if !ed25519.Verify(ed25519.PublicKey(key.key.PublicKey[:]), msg, s.signature.Signature[:]) {
@@ -18,12 +18,16 @@ package minisign
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"io"
 	"os"
 	"testing"
 
 	"go.uber.org/goleak"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestMain(m *testing.M) {
@@ -307,3 +311,53 @@ func TestVerifySignature(t *testing.T) {
 		t.Errorf("expected error when using non key to verify")
 	}
 }
+
+func TestVerifyPrehashedSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	msg := []byte("hello, prehashed world")
+
+	// sign builds a signature file the way minisign does
+	sign := func(algorithm string, keyID, signed []byte) []byte {
+		sig := ed25519.Sign(priv, signed)
+		trustedComment := "timestamp:1620000000"
+		globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), trustedComment...))
+		return []byte("untrusted comment: signature from minisign secret key\n" +
+			base64.StdEncoding.EncodeToString(append(append([]byte(algorithm), keyID...), sig...)) + "\n" +
+			"trusted comment: " + trustedComment + "\n" +
+			base64.StdEncoding.EncodeToString(globalSig) + "\n")
+	}
+	hash := blake2b.Sum512(msg)
+
+	key, err := NewPublicKey(bytes.NewReader([]byte("untrusted comment: minisign public key\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...)) + "\n")))
+	if err != nil {
+		t.Fatalf("unexpected error reading public key: %v", err)
+	}
+
+	tests := []struct {
+		caseDesc string
+		sig      []byte
+		verified bool
+	}{
+		{caseDesc: "prehashed signature", sig: sign("ED", keyID, hash[:]), verified: true},
+		{caseDesc: "legacy signature", sig: sign("Ed", keyID, msg), verified: true},
+		{caseDesc: "prehashed signature over the message", sig: sign("ED", keyID, msg), verified: false},
+		{caseDesc: "signature from a different key ID", sig: sign("ED", []byte{8, 7, 6, 5, 4, 3, 2, 1}, hash[:]), verified: false},
+		{caseDesc: "unknown algorithm", sig: sign("XX", keyID, msg), verified: false},
+	}
+
+	for _, tc := range tests {
+		s, err := NewSignature(bytes.NewReader(tc.sig))
+		if err != nil {
+			t.Errorf("%v: unexpected error reading signature: %v", tc.caseDesc, err)
+			continue
+		}
+		if err := s.Verify(bytes.NewReader(msg), key); (err == nil) != tc.verified {
+			t.Errorf("%v: unexpected result in verifying signature: %v", tc.caseDesc, err)
+		}
+	}
+}
@@ -20,21 +20,108 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/clearsign"
 	"golang.org/x/crypto/openpgp/packet"
 
 	"golang.org/x/crypto/openpgp"
 )
 
-// Signature Signature that follows the PGP standard; supports both armored & binary detached signatures
+// KeyPolicy decides whether signatures by keys that are no longer valid are accepted; by default a signing key
+// only needs to have been valid when the signature was made
+type KeyPolicy struct {
+	// RejectExpired rejects signatures by keys that have expired by the time the signature is verified
+	RejectExpired bool
+	// RejectRevoked rejects signatures by keys that carry a revocation signature, reporting a PolicyError
+	// rather than failing to find a valid signing key
+	RejectRevoked bool
+}
+
+var (
+	keyPolicyMu sync.RWMutex
+	keyPolicy   KeyPolicy
+)
+
+// SetKeyPolicy configures the policy applied to the keys signatures are verified with
+func SetKeyPolicy(p KeyPolicy) {
+	keyPolicyMu.Lock()
+	defer keyPolicyMu.Unlock()
+	keyPolicy = p
+}
+
+func currentKeyPolicy() KeyPolicy {
+	keyPolicyMu.RLock()
+	defer keyPolicyMu.RUnlock()
+	return keyPolicy
+}
+
+// PolicyError is returned by Verify when a signature is rejected by the key policy, as opposed to being invalid
+type PolicyError struct {
+	KeyID  uint64
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("PGP key %X rejected by policy: %v", e.KeyID, e.Reason)
+}
+
+// check applies the policy to the keys in keyring with the given key ID, as of now
+func (p KeyPolicy) check(keyring openpgp.EntityList, keyID uint64, now time.Time) error {
+	if !p.RejectExpired && !p.RejectRevoked {
+		return nil
+	}
+	for _, e := range keyring {
+		primary := e.PrimaryKey.KeyId == keyID
+		var subkey *openpgp.Subkey
+		for i := range e.Subkeys {
+			if e.Subkeys[i].PublicKey.KeyId == keyID {
+				subkey = &e.Subkeys[i]
+			}
+		}
+		if !primary && subkey == nil {
+			continue
+		}
+
+		if p.RejectRevoked {
+			if len(e.Revocations) > 0 {
+				return &PolicyError{KeyID: keyID, Reason: "key is revoked"}
+			}
+			if subkey != nil && subkey.Sig.SigType == packet.SigTypeSubkeyRevocation {
+				return &PolicyError{KeyID: keyID, Reason: "subkey is revoked"}
+			}
+		}
+		if p.RejectExpired {
+			if selfSig := primarySelfSignature(e); selfSig != nil && keyExpired(e.PrimaryKey, selfSig, now) {
+				return &PolicyError{KeyID: keyID, Reason: "key has expired"}
+			}
+			if subkey != nil && subkey.Sig.SigType == packet.SigTypeSubkeyBinding && keyExpired(subkey.PublicKey, subkey.Sig, now) {
+				return &PolicyError{KeyID: keyID, Reason: "subkey has expired"}
+			}
+		}
+	}
+	return nil
+}
+
+// Signature Signature that follows the PGP standard; supports both armored & binary detached signatures,
+// including files that combine signature packets from several signers over the same artifact
 type Signature struct {
 	isArmored bool
 	signature []byte
+	// binary encoding of each signature packet, sorted so that combined files canonicalize deterministically
+	packets [][]byte
 }
 
 // NewSignature creates and validates a PGP signature object
@@ -46,40 +133,151 @@ func NewSignature(r io.Reader) (*Signature, error) {
 		return nil, fmt.Errorf("unable to read PGP signature: %w", err)
 	}
 
-	sigByteReader := bytes.NewReader(inputBuffer.Bytes())
-
-	var sigReader io.Reader
-	sigBlock, err := armor.Decode(sigByteReader)
+	var sigReaders []io.Reader
+	sigBlock, err := armor.Decode(bytes.NewReader(inputBuffer.Bytes()))
 	if err == nil {
 		s.isArmored = true
 		if sigBlock.Type != openpgp.SignatureType {
 			return nil, fmt.Errorf("invalid PGP signature provided")
 		}
-		sigReader = sigBlock.Body
+		// signers commonly concatenate their armored signatures, so there may be several blocks
+		sigReaders, err = armoredSignatureBlocks(inputBuffer.Bytes())
+		if err != nil {
+			return nil, err
+		}
 	} else {
-		s.isArmored = false
-		if _, err := sigByteReader.Seek(0, io.SeekStart); err != nil {
-			return nil, fmt.Errorf("unable to read binary PGP signature: %w", err)
+		if !isBinaryPacket(inputBuffer.Bytes()) {
+			return nil, errors.New("PGP signature is neither armored nor binary OpenPGP data")
 		}
-		sigReader = sigByteReader
+		s.isArmored = false
+		sigReaders = []io.Reader{bytes.NewReader(inputBuffer.Bytes())}
 	}
 
-	sigPktReader := packet.NewReader(sigReader)
-	sigPkt, err := sigPktReader.Next()
-	if err != nil {
-		return nil, fmt.Errorf("invalid PGP signature: %w", err)
+	for _, sigReader := range sigReaders {
+		packets, err := signaturePackets(sigReader)
+		if err != nil {
+			return nil, err
+		}
+		s.packets = append(s.packets, packets...)
 	}
 
-	if _, ok := sigPkt.(*packet.Signature); !ok {
-		if _, ok := sigPkt.(*packet.SignatureV3); !ok {
-			return nil, fmt.Errorf("valid PGP signature was not detected")
+	sort.Slice(s.packets, func(i, j int) bool {
+		return bytes.Compare(s.packets[i], s.packets[j]) < 0
+	})
+	deduped := s.packets[:1]
+	for _, pkt := range s.packets[1:] {
+		if !bytes.Equal(pkt, deduped[len(deduped)-1]) {
+			deduped = append(deduped, pkt)
 		}
 	}
+	s.packets = deduped
 
 	s.signature = inputBuffer.Bytes()
 	return &s, nil
 }
 
+// isBinaryPacket reports whether b starts with an OpenPGP packet tag (RFC 4880, section 4.2), as opposed to
+// armored text
+func isBinaryPacket(b []byte) bool {
+	return len(b) > 0 && b[0]&0x80 != 0
+}
+
+// armoredSignatureBlocks splits one or more concatenated armored signatures into the bodies of each block
+func armoredSignatureBlocks(armored []byte) ([]io.Reader, error) {
+	endToken := []byte("-----END " + openpgp.SignatureType + "-----")
+
+	var result []io.Reader
+	rest := armored
+	for len(bytes.TrimSpace(rest)) != 0 {
+		idx := bytes.Index(rest, endToken)
+		if idx == -1 {
+			return nil, fmt.Errorf("invalid PGP signature provided")
+		}
+		// the next block may start on the same line if a signature without a trailing newline was concatenated
+		blockEnd := idx + len(endToken)
+		lineEnd := bytes.IndexByte(rest[blockEnd:], '\n')
+		if lineEnd == -1 {
+			lineEnd = len(rest) - blockEnd
+		} else {
+			lineEnd++
+		}
+		if len(bytes.TrimSpace(rest[blockEnd:blockEnd+lineEnd])) == 0 {
+			blockEnd += lineEnd
+		}
+
+		sigBlock, err := armor.Decode(bytes.NewReader(rest[:blockEnd]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PGP signature: %w", err)
+		}
+		if sigBlock.Type != openpgp.SignatureType {
+			return nil, fmt.Errorf("invalid PGP signature provided")
+		}
+		result = append(result, sigBlock.Body)
+		rest = rest[blockEnd:]
+	}
+	return result, nil
+}
+
+// signaturePackets returns the binary encoding of each packet in r, all of which must be signatures
+func signaturePackets(r io.Reader) ([][]byte, error) {
+	var result [][]byte
+
+	opaqueReader := packet.NewOpaqueReader(r)
+	for {
+		op, err := opaqueReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid PGP signature: %w", err)
+		}
+
+		sigPkt, err := op.Parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid PGP signature: %w", err)
+		}
+		if _, ok := sigPkt.(*packet.Signature); !ok {
+			if _, ok := sigPkt.(*packet.SignatureV3); !ok {
+				return nil, fmt.Errorf("valid PGP signature was not detected")
+			}
+		}
+
+		var pktBuffer bytes.Buffer
+		if err := op.Serialize(&pktBuffer); err != nil {
+			return nil, fmt.Errorf("invalid PGP signature: %w", err)
+		}
+		result = append(result, pktBuffer.Bytes())
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("invalid PGP signature: %w", io.ErrUnexpectedEOF)
+	}
+	return result, nil
+}
+
+// IsCleartext reports whether b is a cleartext signed message (-----BEGIN PGP SIGNED MESSAGE-----)
+func IsCleartext(b []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(b), []byte("-----BEGIN PGP SIGNED MESSAGE-----"))
+}
+
+// SplitCleartext separates a cleartext signed message into the text that was signed and its binary signature.
+// The text is returned as OpenPGP hashes it, that is without dash escaping, with CRLF line endings, trailing
+// whitespace removed and no final line ending, so that the signature verifies over it as a detached signature.
+func SplitCleartext(b []byte) ([]byte, []byte, error) {
+	block, rest := clearsign.Decode(b)
+	if block == nil {
+		return nil, nil, errors.New("invalid PGP cleartext signed message")
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, nil, errors.New("unexpected data after PGP cleartext signed message")
+	}
+	sig, err := ioutil.ReadAll(block.ArmoredSignature.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid PGP cleartext signed message: %w", err)
+	}
+	return block.Bytes, sig, nil
+}
+
 // FetchSignature implements pki.Signature interface
 func FetchSignature(ctx context.Context, url string) (*Signature, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -106,10 +304,15 @@ func (s Signature) CanonicalValue() ([]byte, error) {
 		return nil, fmt.Errorf("PGP signature has not been initialized")
 	}
 
-	if s.isArmored {
+	if s.isArmored && len(s.packets) == 1 {
 		return s.signature, nil
 	}
 
+	content := s.signature
+	if len(s.packets) > 1 {
+		content = bytes.Join(s.packets, nil)
+	}
+
 	var canonicalBuffer bytes.Buffer
 	// Use an inner function so we can defer the Close()
 	if err := func() error {
@@ -119,7 +322,7 @@ func (s Signature) CanonicalValue() ([]byte, error) {
 		}
 		defer ew.Close()
 
-		if _, err := io.Copy(ew, bytes.NewReader(s.signature)); err != nil {
+		if _, err := io.Copy(ew, bytes.NewReader(content)); err != nil {
 			return fmt.Errorf("error generating canonical value of PGP signature: %w", err)
 		}
 		return nil
@@ -132,28 +335,181 @@ func (s Signature) CanonicalValue() ([]byte, error) {
 
 // Verify implements the pki.Signature interface
 func (s Signature) Verify(r io.Reader, k interface{}) error {
+	_, err := s.VerifySigners(r, k)
+	return err
+}
+
+// VerifySigners verifies the signature as Verify does, returning a public key holding only the keys of k that
+// made the signature, so that a keyring (such as that of a distribution) can be narrowed to them before it is
+// canonicalized and indexed; k itself is left as it is
+func (s Signature) VerifySigners(r io.Reader, k interface{}) (*PublicKey, error) {
 	if len(s.signature) == 0 {
-		return fmt.Errorf("PGP signature has not been initialized")
+		return nil, fmt.Errorf("PGP signature has not been initialized")
 	}
 
 	key, ok := k.(*PublicKey)
 	if !ok {
-		return fmt.Errorf("cannot use Verify with a non-PGP signature")
+		return nil, fmt.Errorf("cannot use Verify with a non-PGP signature")
 	}
 	if len(key.key) == 0 {
-		return fmt.Errorf("PGP public key has not been initialized")
+		return nil, fmt.Errorf("PGP public key has not been initialized")
 	}
 
-	verifyFn := openpgp.CheckDetachedSignature
-	if s.isArmored {
-		verifyFn = openpgp.CheckArmoredDetachedSignature
+	signers, err := verifyAll(r, key.key, s.packets, currentKeyPolicy())
+	if err != nil {
+		return nil, err
 	}
+	return &PublicKey{key: signers}, nil
+}
 
-	if _, err := verifyFn(key.key, r, bytes.NewReader(s.signature)); err != nil {
-		return err
+// verifyAll checks every signature packet against keyring, hashing the signed content only once; the keys of
+// the issuers are checked against policy before any content is read. It returns the entities of keyring that
+// made the signatures, in keyring order.
+func verifyAll(r io.Reader, keyring openpgp.EntityList, packets [][]byte, policy KeyPolicy) (openpgp.EntityList, error) {
+	type pendingSignature struct {
+		pkt         packet.Packet
+		issuerKeyID uint64
+		created     time.Time
+		hash        hash.Hash
 	}
 
-	return nil
+	pending := make([]pendingSignature, 0, len(packets))
+	writers := make([]io.Writer, 0, len(packets))
+	for _, raw := range packets {
+		pkt, err := packet.Read(bytes.NewReader(raw))
+		if err != nil {
+			return nil, err
+		}
+
+		var issuerKeyID uint64
+		var created time.Time
+		var hashFunc crypto.Hash
+		var sigType packet.SignatureType
+		switch sig := pkt.(type) {
+		case *packet.Signature:
+			if sig.IssuerKeyId == nil {
+				return nil, errors.New("PGP signature doesn't have an issuer")
+			}
+			issuerKeyID, created, hashFunc, sigType = *sig.IssuerKeyId, sig.CreationTime, sig.Hash, sig.SigType
+		case *packet.SignatureV3:
+			issuerKeyID, created, hashFunc, sigType = sig.IssuerKeyId, sig.CreationTime, sig.Hash, sig.SigType
+		default:
+			return nil, errors.New("non signature packet found")
+		}
+
+		if err := policy.check(keyring, issuerKeyID, time.Now()); err != nil {
+			return nil, err
+		}
+
+		if !hashFunc.Available() {
+			return nil, fmt.Errorf("hash not available: %v", hashFunc)
+		}
+		h := hashFunc.New()
+		switch sigType {
+		case packet.SigTypeBinary:
+			writers = append(writers, h)
+		case packet.SigTypeText:
+			writers = append(writers, openpgp.NewCanonicalTextHash(h))
+		default:
+			return nil, fmt.Errorf("unsupported signature type: %v", sigType)
+		}
+		pending = append(pending, pendingSignature{pkt: pkt, issuerKeyID: issuerKeyID, created: created, hash: h})
+	}
+
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, err
+	}
+
+	signers := map[*openpgp.Entity]struct{}{}
+	for _, sig := range pending {
+		keys := signingKeys(keyring, sig.issuerKeyID, sig.created)
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no valid signing key found in keyring for PGP signature issuer %X", sig.issuerKeyID)
+		}
+		var err error
+		for _, key := range keys {
+			switch pkt := sig.pkt.(type) {
+			case *packet.Signature:
+				err = key.key.VerifySignature(sig.hash, pkt)
+			case *packet.SignatureV3:
+				err = key.key.VerifySignatureV3(sig.hash, pkt)
+			}
+			if err == nil {
+				signers[key.entity] = struct{}{}
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result openpgp.EntityList
+	for _, e := range keyring {
+		if _, ok := signers[e]; ok {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+// signingKey is a key that may have made a signature, along with the entity it belongs to
+type signingKey struct {
+	entity *openpgp.Entity
+	key    *packet.PublicKey
+}
+
+// signingKeys returns the keys in keyring with the given key ID that were valid for signing at the time a
+// signature was made. This is the primary key unless its self-signature withholds the signing flag, or a subkey
+// whose current binding signature allows signing and carries the subkey's primary key binding signature (which
+// x/crypto verifies when the key is read), so that a subkey cannot be claimed by a primary key it did not agree
+// to. Keys that are revoked, or had expired when the signature was made, are skipped.
+func signingKeys(keyring openpgp.EntityList, keyID uint64, at time.Time) []signingKey {
+	var result []signingKey
+	for _, e := range keyring {
+		if len(e.Revocations) > 0 {
+			continue
+		}
+		if e.PrimaryKey.KeyId == keyID {
+			selfSig := primarySelfSignature(e)
+			if selfSig != nil && (!selfSig.FlagsValid || selfSig.FlagSign) && !keyExpired(e.PrimaryKey, selfSig, at) {
+				result = append(result, signingKey{entity: e, key: e.PrimaryKey})
+			}
+		}
+		for _, subkey := range e.Subkeys {
+			if subkey.PublicKey.KeyId != keyID || subkey.Sig.SigType != packet.SigTypeSubkeyBinding {
+				continue
+			}
+			if subkey.Sig.FlagsValid && subkey.Sig.FlagSign && subkey.Sig.EmbeddedSignature != nil && !keyExpired(subkey.PublicKey, subkey.Sig, at) {
+				result = append(result, signingKey{entity: e, key: subkey.PublicKey})
+			}
+		}
+	}
+	return result
+}
+
+// primarySelfSignature returns the self-signature of the primary user ID, or of any user ID if none is marked as
+// primary
+func primarySelfSignature(e *openpgp.Entity) *packet.Signature {
+	var result *packet.Signature
+	for _, id := range e.Identities {
+		if id.SelfSignature.IsPrimaryId != nil && *id.SelfSignature.IsPrimaryId {
+			return id.SelfSignature
+		}
+		if result == nil {
+			result = id.SelfSignature
+		}
+	}
+	return result
+}
+
+// keyExpired reports whether key had expired at the given time; the lifetime from the self or binding signature
+// counts from the creation of the key
+func keyExpired(key *packet.PublicKey, sig *packet.Signature, at time.Time) bool {
+	if sig.KeyLifetimeSecs == nil || *sig.KeyLifetimeSecs == 0 {
+		return false
+	}
+	return at.After(key.CreationTime.Add(time.Duration(*sig.KeyLifetimeSecs) * time.Second))
 }
 
 // PublicKey Public Key that follows the PGP standard; supports both armored & binary detached signatures
@@ -169,14 +525,21 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 	startToken := []byte(`-----BEGIN PGP`)
 	endToken := []byte(`-----END PGP`)
 
-	bufferedReader := bufio.NewReader(r)
-	armorCheck, err := bufferedReader.Peek(len(startToken))
+	rawKey, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read PGP public key: %w", err)
 	}
-	if bytes.Equal(startToken, armorCheck) {
-		// looks like we have armored input
-		scan := bufio.NewScanner(bufferedReader)
+	if len(rawKey) == 0 {
+		return nil, errors.New("unable to read PGP public key: empty input")
+	}
+
+	// binary keys start with a packet tag, which always has the high bit set; anything else is taken to be
+	// armored, which may be preceded by whitespace or text such as the output of "gpg --list-keys"
+	if !isBinaryPacket(rawKey) {
+		if !bytes.Contains(rawKey, startToken) {
+			return nil, errors.New("PGP public key is neither armored nor binary OpenPGP data")
+		}
+		scan := bufio.NewScanner(bytes.NewReader(rawKey))
 		scan.Split(bufio.ScanLines)
 
 		for scan.Scan() {
@@ -207,8 +570,7 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 			}
 		}
 	} else {
-		// process as binary
-		k.key, err = openpgp.ReadKeyRing(bufferedReader)
+		k.key, err = openpgp.ReadKeyRing(bytes.NewReader(rawKey))
 		if err != nil {
 			return nil, fmt.Errorf("error reading binary PGP public key: %w", err)
 		}
@@ -272,6 +634,66 @@ func (k PublicKey) CanonicalValue() ([]byte, error) {
 	return canonicalBuffer.Bytes(), nil
 }
 
+// Fingerprint implements the pki.Fingerprinter interface; it is the hex encoded fingerprint of the primary key,
+// or "" if the key ring holds more than one key
+func (k PublicKey) Fingerprint() string {
+	if len(k.key) != 1 || k.key[0].PrimaryKey == nil {
+		return ""
+	}
+	return hex.EncodeToString(k.key[0].PrimaryKey.Fingerprint[:])
+}
+
+// EmailKey returns the index key under which entries signed with a PGP key with a user ID for email are stored
+func EmailKey(email string) string {
+	return "email:" + strings.ToLower(email)
+}
+
+// Emails returns the email addresses of the user IDs of the keys, skipping user IDs their key has revoked
+func (k PublicKey) Emails() []string {
+	seen := map[string]struct{}{}
+	var result []string
+	for _, e := range k.key {
+		for _, id := range e.Identities {
+			if id.UserId.Email == "" || userIDRevoked(e, id) {
+				continue
+			}
+			email := strings.ToLower(id.UserId.Email)
+			if _, ok := seen[email]; !ok {
+				seen[email] = struct{}{}
+				result = append(result, email)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// sigTypeCertificationRevocation is the type of signatures revoking a user ID (RFC 4880, section 5.2.1), which
+// x/crypto does not define
+const sigTypeCertificationRevocation packet.SignatureType = 0x30
+
+func userIDRevoked(e *openpgp.Entity, id *openpgp.Identity) bool {
+	for _, sig := range id.Signatures {
+		if sig.SigType != sigTypeCertificationRevocation || sig.IssuerKeyId == nil || *sig.IssuerKeyId != e.PrimaryKey.KeyId {
+			continue
+		}
+		if e.PrimaryKey.VerifyUserIdSignature(id.Name, e.PrimaryKey, sig) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentityIndexKeys implements the pki.IdentityKey interface; it returns a key for the email address of each
+// user ID, so that entries can be found by any of the addresses of the signer
+func (k PublicKey) IdentityIndexKeys() []string {
+	var result []string
+	for _, email := range k.Emails() {
+		result = append(result, EmailKey(email))
+	}
+	return result
+}
+
 func (k PublicKey) KeyRing() (openpgp.KeyRing, error) {
 	if k.key == nil {
 		return nil, errors.New("PGP public key has not been initialized")
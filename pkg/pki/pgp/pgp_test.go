@@ -25,9 +25,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"go.uber.org/goleak"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
 )
 
 func TestMain(m *testing.M) {
@@ -91,6 +96,54 @@ func TestReadSignature(t *testing.T) {
 	}
 }
 
+func TestDetectEncoding(t *testing.T) {
+	armoredKey, err := ioutil.ReadFile("testdata/valid_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binaryKey, err := ioutil.ReadFile("testdata/valid_binary_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromBinary, err := NewPublicKey(bytes.NewReader(binaryKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := fromBinary.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keys are often copied with surrounding text, such as the output of gpg --list-keys
+	for name, input := range map[string][]byte{
+		"leading whitespace": append([]byte("\n\r\n  "), armoredKey...),
+		"leading text":       append([]byte("pub   rsa4096 2021-01-01 [SC]\nuid   Test <test@example.com>\n\n"), armoredKey...),
+		"binary":             binaryKey,
+	} {
+		k, err := NewPublicKey(bytes.NewReader(input))
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+			continue
+		}
+		if canonical, err := k.CanonicalValue(); err != nil || !bytes.Equal(canonical, expected) {
+			t.Errorf("%v: unexpected canonical value: %v", name, err)
+		}
+	}
+
+	for name, input := range map[string][]byte{
+		"empty":           {},
+		"text":            []byte("hello world"),
+		"truncated armor": armoredKey[:len(armoredKey)/2],
+	} {
+		if _, err := NewPublicKey(bytes.NewReader(input)); err == nil {
+			t.Errorf("%v: expected error reading public key", name)
+		}
+		if _, err := NewSignature(bytes.NewReader(input)); err == nil {
+			t.Errorf("%v: expected error reading signature", name)
+		}
+	}
+}
+
 type BadReader struct {
 }
 
@@ -360,6 +413,8 @@ func TestVerifySignature(t *testing.T) {
 		{caseDesc: "Valid V3 Armored Signature, Binary Key", dataFile: "testdata/hello_world.txt", sigFile: "testdata/hello_world.txt.asc.v3.sig", keyFile: "testdata/valid_binary_public.pgp", verified: true},
 		{caseDesc: "Valid V3 Binary Signature, Armored Key", dataFile: "testdata/hello_world.txt", sigFile: "testdata/hello_world.txt.v3.sig", keyFile: "testdata/valid_armored_public.pgp", verified: true},
 		{caseDesc: "Valid V3 Binary Signature, Binary Key", dataFile: "testdata/hello_world.txt", sigFile: "testdata/hello_world.txt.v3.sig", keyFile: "testdata/valid_binary_public.pgp", verified: true},
+		{caseDesc: "Valid Signature by Signing Subkey", dataFile: "testdata/hello_world.txt", sigFile: "testdata/hello_world.txt.subkey.sig", keyFile: "testdata/subkey_armored_public.pgp", verified: true},
+		{caseDesc: "Valid Signature by Revoked Subkey", dataFile: "testdata/hello_world.txt", sigFile: "testdata/hello_world.txt.revoked_subkey.sig", keyFile: "testdata/subkey_armored_public.pgp", verified: false},
 		{caseDesc: "Valid Signature, Incorrect Key", dataFile: "testdata/hello_world.txt", sigFile: "testdata/hello_world.txt.sig", keyFile: "testdata/valid_binary_complex_public.pgp", verified: false},
 		{caseDesc: "Data does not match Signature", dataFile: "testdata/armored_private.pgp", sigFile: "testdata/hello_world.txt.sig", keyFile: "testdata/valid_binary_complex_public.pgp", verified: false},
 	}
@@ -411,3 +466,406 @@ func TestVerifySignature(t *testing.T) {
 		t.Errorf("expected error when using non key to verify")
 	}
 }
+
+func TestCombinedSignatures(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/hello_world.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingSig, err := ioutil.ReadFile("testdata/hello_world.txt.asc.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingKey, err := ioutil.ReadFile("testdata/valid_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a second signer over the same content
+	entity, err := openpgp.NewEntity("Second Signer", "", "second@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var secondSig bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&secondSig, entity, bytes.NewReader(data), nil); err != nil {
+		t.Fatal(err)
+	}
+	var secondKey bytes.Buffer
+	w, err := armor.Encode(&secondKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	combined := append(append([]byte{}, existingSig...), secondSig.Bytes()...)
+	reversed := append(append([]byte{}, secondSig.Bytes()...), existingSig...)
+
+	s, err := NewSignature(bytes.NewReader(combined))
+	if err != nil {
+		t.Fatalf("unexpected error reading combined signature: %v", err)
+	}
+	if len(s.packets) != 2 {
+		t.Fatalf("expected 2 signature packets, got %d", len(s.packets))
+	}
+
+	bothKeys, err := NewPublicKey(bytes.NewReader(append(append([]byte{}, existingKey...), secondKey.Bytes()...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(bytes.NewReader(data), bothKeys); err != nil {
+		t.Errorf("unexpected error verifying combined signature: %v", err)
+	}
+	if err := s.Verify(bytes.NewReader([]byte("tampered")), bothKeys); err == nil {
+		t.Error("expected error verifying combined signature over different content")
+	}
+
+	oneKey, err := NewPublicKey(bytes.NewReader(existingKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(bytes.NewReader(data), oneKey); err == nil {
+		t.Error("expected error verifying combined signature without every signer's key")
+	}
+
+	// the order signers appear in the file must not affect the canonical value
+	r, err := NewSignature(bytes.NewReader(reversed))
+	if err != nil {
+		t.Fatalf("unexpected error reading combined signature: %v", err)
+	}
+	sCanonical, err := s.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rCanonical, err := r.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sCanonical, rCanonical) {
+		t.Error("canonical value of combined signature depends on signer order")
+	}
+
+	// and the canonical value must itself be readable and verifiable
+	c, err := NewSignature(bytes.NewReader(sCanonical))
+	if err != nil {
+		t.Fatalf("unexpected error reading canonical combined signature: %v", err)
+	}
+	if err := c.Verify(bytes.NewReader(data), bothKeys); err != nil {
+		t.Errorf("unexpected error verifying canonical combined signature: %v", err)
+	}
+}
+
+func TestSigningSubkeys(t *testing.T) {
+	newKey := func() *PublicKey {
+		t.Helper()
+		keyFile, err := os.Open("testdata/subkey_armored_public.pgp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer keyFile.Close()
+		k, err := NewPublicKey(keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+	verify := func(k *PublicKey) error {
+		t.Helper()
+		sigFile, err := os.Open("testdata/hello_world.txt.subkey.sig")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sigFile.Close()
+		s, err := NewSignature(sigFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.Open("testdata/hello_world.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer data.Close()
+		return s.Verify(data, k)
+	}
+
+	if err := verify(newKey()); err != nil {
+		t.Fatalf("unexpected error verifying signature by subkey: %v", err)
+	}
+
+	// the binding signature must carry the subkey's primary key binding signature
+	k := newKey()
+	for i := range k.key[0].Subkeys {
+		k.key[0].Subkeys[i].Sig.EmbeddedSignature = nil
+	}
+	if err := verify(k); err == nil {
+		t.Error("expected error verifying with subkey without primary key binding signature")
+	}
+
+	// as well as allow signing
+	k = newKey()
+	for i := range k.key[0].Subkeys {
+		k.key[0].Subkeys[i].Sig.FlagsValid = false
+	}
+	if err := verify(k); err == nil {
+		t.Error("expected error verifying with subkey without key flags")
+	}
+
+	// and the subkey must not have expired by the time the signature was made
+	k = newKey()
+	lifetime := uint32(1)
+	for i := range k.key[0].Subkeys {
+		k.key[0].Subkeys[i].Sig.KeyLifetimeSecs = &lifetime
+	}
+	if err := verify(k); err == nil {
+		t.Error("expected error verifying with expired subkey")
+	}
+}
+
+func TestEmails(t *testing.T) {
+	keyFile, err := os.Open("testdata/subkey_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keyFile.Close()
+	k, err := NewPublicKey(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the key also has a revoked user ID for old@example.com
+	expected := []string{"alice@example.com", "alice@work.example"}
+	if got := k.Emails(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected emails: got %v, expected %v", got, expected)
+	}
+	expectedKeys := []string{EmailKey("alice@example.com"), EmailKey("Alice@Work.Example")}
+	if got := k.IdentityIndexKeys(); !reflect.DeepEqual(got, expectedKeys) {
+		t.Errorf("unexpected index keys: got %v, expected %v", got, expectedKeys)
+	}
+	if got := (PublicKey{}).IdentityIndexKeys(); len(got) != 0 {
+		t.Errorf("unexpected index keys for empty key: %v", got)
+	}
+}
+
+func TestSplitCleartext(t *testing.T) {
+	message, err := ioutil.ReadFile("testdata/SHA256SUMS.asc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsCleartext(message) {
+		t.Fatal("expected a cleartext signed message")
+	}
+
+	signed, sig, err := SplitCleartext(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447  hello_world.txt\r\n- dash-escaped line"
+	if string(signed) != expected {
+		t.Errorf("unexpected signed text %q", signed)
+	}
+
+	keyFile, err := os.Open("testdata/subkey_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keyFile.Close()
+	k, err := NewPublicKey(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSignature(bytes.NewReader(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(bytes.NewReader(signed), k); err != nil {
+		t.Errorf("unexpected error verifying cleartext signature: %v", err)
+	}
+	if err := s.Verify(bytes.NewReader(append(signed, " tampered"...)), k); err == nil {
+		t.Error("expected error verifying tampered text")
+	}
+
+	detached, err := ioutil.ReadFile("testdata/hello_world.txt.asc.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, input := range map[string][]byte{
+		"detached signature":   detached,
+		"trailing data":        append(append([]byte{}, message...), message...),
+		"truncated message":    message[:len(message)/2],
+		"not a signed message": []byte("hello world"),
+	} {
+		if _, _, err := SplitCleartext(input); err == nil {
+			t.Errorf("%v: expected error", name)
+		}
+	}
+	if IsCleartext(detached) {
+		t.Error("detached signature reported as cleartext signed message")
+	}
+}
+
+func TestKeyPolicy(t *testing.T) {
+	defer SetKeyPolicy(KeyPolicy{})
+
+	// a key that expired an hour after it was created, with a signature made while it was valid
+	created := time.Now().Add(-24 * time.Hour)
+	config := &packet.Config{Time: func() time.Time { return created }}
+	e, err := openpgp.NewEntity("expired", "", "expired@example.com", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lifetime := uint32(3600)
+	for _, id := range e.Identities {
+		id.SelfSignature.KeyLifetimeSecs = &lifetime
+		if err := id.SelfSignature.SignUserId(id.UserId.Id, e.PrimaryKey, e.PrivateKey, config); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var keyBuf, sigBuf bytes.Buffer
+	if err := e.Serialize(&keyBuf); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("signed while the key was valid")
+	if err := openpgp.DetachSign(&sigBuf, e, bytes.NewReader(data), config); err != nil {
+		t.Fatal(err)
+	}
+	expiredKey, err := NewPublicKey(&keyBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredSig, err := NewSignature(&sigBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subkeyFile, err := ioutil.ReadFile("testdata/subkey_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subkeyKey, err := NewPublicKey(bytes.NewReader(subkeyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedSigFile, err := ioutil.ReadFile("testdata/hello_world.txt.revoked_subkey.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedSig, err := NewSignature(bytes.NewReader(revokedSigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validSigFile, err := ioutil.ReadFile("testdata/hello_world.txt.subkey.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	validSig, err := NewSignature(bytes.NewReader(validSigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	helloWorld, err := ioutil.ReadFile("testdata/hello_world.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caseDesc       string
+		policy         KeyPolicy
+		sig            *Signature
+		key            *PublicKey
+		data           []byte
+		verified       bool
+		expectedPolicy bool
+	}{
+		{caseDesc: "expired key without policy", sig: expiredSig, key: expiredKey, data: data, verified: true},
+		{caseDesc: "expired key rejected", policy: KeyPolicy{RejectExpired: true}, sig: expiredSig, key: expiredKey, data: data, expectedPolicy: true},
+		{caseDesc: "expired key with revocation policy", policy: KeyPolicy{RejectRevoked: true}, sig: expiredSig, key: expiredKey, data: data, verified: true},
+		{caseDesc: "revoked subkey without policy", sig: revokedSig, key: subkeyKey, data: helloWorld},
+		{caseDesc: "revoked subkey rejected", policy: KeyPolicy{RejectRevoked: true}, sig: revokedSig, key: subkeyKey, data: helloWorld, expectedPolicy: true},
+		{caseDesc: "valid subkey with policy", policy: KeyPolicy{RejectExpired: true, RejectRevoked: true}, sig: validSig, key: subkeyKey, data: helloWorld, verified: true},
+	}
+	for _, tc := range tests {
+		SetKeyPolicy(tc.policy)
+		err := tc.sig.Verify(bytes.NewReader(tc.data), tc.key)
+		if (err == nil) != tc.verified {
+			t.Errorf("%v: unexpected result verifying signature: %v", tc.caseDesc, err)
+		}
+		var pe *PolicyError
+		if errors.As(err, &pe) != tc.expectedPolicy {
+			t.Errorf("%v: unexpected policy decision: %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestKeyringSelection(t *testing.T) {
+	signerKey, err := ioutil.ReadFile("testdata/valid_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ioutil.ReadFile("testdata/subkey_armored_public.pgp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile("testdata/hello_world.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigFile, err := ioutil.ReadFile("testdata/hello_world.txt.sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewPublicKey(bytes.NewReader(signerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := signer.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := NewPublicKey(bytes.NewReader(append(append([]byte{}, otherKey...), signerKey...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keyring.Fingerprint() != "" {
+		t.Error("expected no fingerprint for a keyring of several keys")
+	}
+	s, err := NewSignature(bytes.NewReader(sigFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(bytes.NewReader(data), keyring); err != nil {
+		t.Fatalf("unexpected error verifying with keyring: %v", err)
+	}
+	signers, err := s.VerifySigners(bytes.NewReader(data), keyring)
+	if err != nil {
+		t.Fatalf("unexpected error verifying with keyring: %v", err)
+	}
+
+	// only the key that made the signature is canonicalized
+	canonical, err := signers.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(canonical, expected) {
+		t.Errorf("unexpected canonical value of signers:\n%s", canonical)
+	}
+	if signers.Fingerprint() != signer.Fingerprint() {
+		t.Errorf("unexpected fingerprint %v", signers.Fingerprint())
+	}
+	// the keyring verified with is left as it is
+	if len(keyring.key) != 2 {
+		t.Errorf("keyring changed by verification to %v keys", len(keyring.key))
+	}
+
+	other, err := NewPublicKey(bytes.NewReader(otherKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.VerifySigners(bytes.NewReader(data), other); err == nil {
+		t.Error("expected error verifying with keyring that does not hold the signing key")
+	}
+}
@@ -19,10 +19,15 @@ package pki
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/sigstore/rekor/pkg/pki/cosign"
+	"github.com/sigstore/rekor/pkg/pki/ed25519"
 	"github.com/sigstore/rekor/pkg/pki/minisign"
 	"github.com/sigstore/rekor/pkg/pki/ssh"
+	"github.com/sigstore/rekor/pkg/pki/tuf"
 	"github.com/sigstore/rekor/pkg/pki/x509"
 
 	"github.com/sigstore/rekor/pkg/pki/pgp"
@@ -33,12 +38,110 @@ type PublicKey interface {
 	CanonicalValue() ([]byte, error)
 }
 
+// IdentityKey is optionally implemented by public keys that an issuer has bound to identities (such as SSH
+// certificates), so that entries can be found by issuer and identity as well as by the key itself
+type IdentityKey interface {
+	IdentityIndexKeys() []string
+}
+
 // Signature Generic object representing a signature (regardless of format & algorithm)
 type Signature interface {
 	CanonicalValue() ([]byte, error)
 	Verify(r io.Reader, k interface{}) error
 }
 
+// Format creates the public keys and signatures of one signature format
+type Format struct {
+	NewPublicKey func(r io.Reader) (PublicKey, error)
+	NewSignature func(r io.Reader) (Signature, error)
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]Format{}
+)
+
+func init() {
+	RegisterFormat("pgp", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return pgp.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return pgp.NewSignature(r) },
+	})
+	RegisterFormat("minisign", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return minisign.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return minisign.NewSignature(r) },
+	})
+	RegisterFormat("x509", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return x509.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return x509.NewSignature(r) },
+	})
+	RegisterFormat("ssh", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return ssh.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return ssh.NewSignature(r) },
+	})
+	RegisterFormat("ed25519", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return ed25519.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return ed25519.NewSignature(r) },
+	})
+	RegisterFormat("tuf", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return tuf.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return tuf.NewSignature(r) },
+	})
+	RegisterFormat("cosign", Format{
+		NewPublicKey: func(r io.Reader) (PublicKey, error) { return cosign.NewPublicKey(r) },
+		NewSignature: func(r io.Reader) (Signature, error) { return cosign.NewSignature(r) },
+	})
+}
+
+// RegisterFormat makes a signature format available to ArtifactFactory under name (matched case-insensitively),
+// replacing any format of the same name, so that packages can add formats when imported; it is meant to be
+// called from init functions. Entry types accept any registered format, as returned by Formats.
+func RegisterFormat(name string, f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[strings.ToLower(name)] = f
+}
+
+// Formats returns the names of the registered formats
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsFormat reports whether a format is registered under name
+func IsFormat(name string) bool {
+	_, ok := lookupFormat(name)
+	return ok
+}
+
+func lookupFormat(name string) (Format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	f, ok := formats[strings.ToLower(name)]
+	return f, ok
+}
+
+// VerifySigners verifies sig over r with k, returning the public key to log along with the signature: a PGP
+// keyring is narrowed to the keys that made the signature, while other keys are returned as they are
+func VerifySigners(sig Signature, r io.Reader, k PublicKey) (PublicKey, error) {
+	if s, ok := sig.(*pgp.Signature); ok {
+		signers, err := s.VerifySigners(r, k)
+		if err != nil {
+			return nil, err
+		}
+		return signers, nil
+	}
+	if err := sig.Verify(r, k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
 type ArtifactFactory struct {
 	format string
 }
@@ -50,29 +153,15 @@ func NewArtifactFactory(format string) *ArtifactFactory {
 }
 
 func (a ArtifactFactory) NewPublicKey(r io.Reader) (PublicKey, error) {
-	switch strings.ToLower(a.format) {
-	case "pgp":
-		return pgp.NewPublicKey(r)
-	case "minisign":
-		return minisign.NewPublicKey(r)
-	case "x509":
-		return x509.NewPublicKey(r)
-	case "ssh":
-		return ssh.NewPublicKey(r)
+	if f, ok := lookupFormat(a.format); ok && f.NewPublicKey != nil {
+		return f.NewPublicKey(r)
 	}
 	return nil, fmt.Errorf("unknown key format '%v'", a.format)
 }
 
 func (a ArtifactFactory) NewSignature(r io.Reader) (Signature, error) {
-	switch strings.ToLower(a.format) {
-	case "pgp":
-		return pgp.NewSignature(r)
-	case "minisign":
-		return minisign.NewSignature(r)
-	case "x509":
-		return x509.NewSignature(r)
-	case "ssh":
-		return ssh.NewSignature(r)
+	if f, ok := lookupFormat(a.format); ok && f.NewSignature != nil {
+		return f.NewSignature(r)
 	}
 	return nil, fmt.Errorf("unknown key format '%v'", a.format)
 }
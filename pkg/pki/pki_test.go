@@ -17,7 +17,10 @@ limitations under the License.
 package pki
 
 import (
+	"errors"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"go.uber.org/goleak"
@@ -65,6 +68,27 @@ func TestFactoryNewKey(t *testing.T) {
 			sigFile:       "ssh/testdata/hello_world.txt.sig",
 			expectSuccess: true,
 		},
+		{
+			name:          "valid ed25519",
+			format:        "ed25519",
+			keyFile:       "ed25519/testdata/ed25519.pub",
+			sigFile:       "ed25519/testdata/hello_world.txt.sig",
+			expectSuccess: true,
+		},
+		{
+			name:          "valid tuf",
+			format:        "tuf",
+			keyFile:       "tuf/testdata/ed25519.json",
+			sigFile:       "tuf/testdata/hello_world.txt.ed25519.sig",
+			expectSuccess: true,
+		},
+		{
+			name:          "valid cosign",
+			format:        "cosign",
+			keyFile:       "cosign/testdata/cosign.pub",
+			sigFile:       "cosign/testdata/hello_world.txt.sig",
+			expectSuccess: true,
+		},
 		{
 			name:          "invalid ssh signature",
 			format:        "ssh",
@@ -107,3 +131,80 @@ func TestFactoryNewKey(t *testing.T) {
 		})
 	}
 }
+
+type constantKey struct{}
+
+func (constantKey) CanonicalValue() ([]byte, error) { return []byte("key"), nil }
+
+type constantSignature struct{}
+
+func (constantSignature) CanonicalValue() ([]byte, error) { return []byte("sig"), nil }
+func (constantSignature) Verify(_ io.Reader, k interface{}) error {
+	if _, ok := k.(constantKey); !ok {
+		return errors.New("unexpected key")
+	}
+	return nil
+}
+
+func TestRegisterFormat(t *testing.T) {
+	defer func() {
+		formatsMu.Lock()
+		defer formatsMu.Unlock()
+		delete(formats, "constant")
+	}()
+
+	// the built-in formats are registered like any other
+	for _, name := range []string{"pgp", "minisign", "x509", "ssh", "ed25519", "tuf", "cosign"} {
+		if !IsFormat(name) {
+			t.Errorf("built-in format %v not registered", name)
+		}
+	}
+	if IsFormat("constant") {
+		t.Fatal("format registered before RegisterFormat")
+	}
+	if _, err := NewArtifactFactory("Constant").NewPublicKey(strings.NewReader("")); err == nil {
+		t.Fatal("expected error for format that has not been registered")
+	}
+	RegisterFormat("constant", Format{
+		NewPublicKey: func(io.Reader) (PublicKey, error) { return constantKey{}, nil },
+		NewSignature: func(io.Reader) (Signature, error) { return constantSignature{}, nil },
+	})
+	found := false
+	for _, name := range Formats() {
+		found = found || name == "constant"
+	}
+	if !found {
+		t.Fatalf("registered format not listed: %v", Formats())
+	}
+	if !IsFormat("Constant") {
+		t.Error("registered format not matched regardless of case")
+	}
+
+	// formats are matched regardless of case, as the built-in ones always were
+	factory := NewArtifactFactory("Constant")
+	key, err := factory.NewPublicKey(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := factory.NewSignature(strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sig.Verify(strings.NewReader(""), key); err != nil {
+		t.Errorf("registered format was not used: %v", err)
+	}
+}
+
+func TestVerifySigners(t *testing.T) {
+	// keys of formats other than PGP are logged as they are
+	key, err := VerifySigners(constantSignature{}, strings.NewReader(""), constantKey{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := key.(constantKey); !ok {
+		t.Errorf("unexpected key %v", key)
+	}
+	if _, err := VerifySigners(constantSignature{}, strings.NewReader(""), nil); err == nil {
+		t.Error("expected error verifying with the wrong key")
+	}
+}
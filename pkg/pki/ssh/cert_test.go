@@ -0,0 +1,184 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssh
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func newCert(t *testing.T, ca ssh.Signer, key ssh.PublicKey, certType uint32, principals []string, after, before time.Time) *ssh.Certificate {
+	t.Helper()
+	cert := &ssh.Certificate{
+		Key:             key,
+		Serial:          1,
+		CertType:        certType,
+		KeyId:           "test@rekor.dev",
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(after.Unix()),
+		ValidBefore:     uint64(before.Unix()),
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestValidateCertificate(t *testing.T) {
+	ca := newSigner(t)
+	otherCA := newSigner(t)
+	userKey := newSigner(t).PublicKey()
+
+	now := time.Now()
+	hourAgo, hourFromNow := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tampered := newCert(t, ca, userKey, ssh.UserCert, []string{"alice"}, hourAgo, hourFromNow)
+	tampered.SignatureKey = otherCA.PublicKey()
+
+	caCert := newCert(t, otherCA, ca.PublicKey(), ssh.UserCert, []string{"ca"}, hourAgo, hourFromNow)
+	caCertSigner, err := ssh.NewCertSigner(caCert, ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		caseDesc      string
+		cert          *ssh.Certificate
+		expectSuccess bool
+	}{
+		{
+			caseDesc:      "valid user certificate",
+			cert:          newCert(t, ca, userKey, ssh.UserCert, []string{"alice", "bob"}, hourAgo, hourFromNow),
+			expectSuccess: true,
+		},
+		{
+			caseDesc:      "expired certificate",
+			cert:          newCert(t, ca, userKey, ssh.UserCert, []string{"alice"}, now.Add(-2*time.Hour), hourAgo),
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "certificate not yet valid",
+			cert:          newCert(t, ca, userKey, ssh.UserCert, []string{"alice"}, hourFromNow, now.Add(2*time.Hour)),
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "host certificate",
+			cert:          newCert(t, ca, userKey, ssh.HostCert, []string{"host.rekor.dev"}, hourAgo, hourFromNow),
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "certificate without principals",
+			cert:          newCert(t, ca, userKey, ssh.UserCert, nil, hourAgo, hourFromNow),
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "certificate signature does not match CA key",
+			cert:          tampered,
+			expectSuccess: false,
+		},
+		{
+			caseDesc:      "CA key is itself a certificate",
+			cert:          newCert(t, caCertSigner, userKey, ssh.UserCert, []string{"alice"}, hourAgo, hourFromNow),
+			expectSuccess: false,
+		},
+	}
+
+	for _, tc := range tests {
+		if err := validateCertificate(tc.cert, now); (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+		_, err := NewPublicKey(bytes.NewReader(ssh.MarshalAuthorizedKey(tc.cert)))
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result parsing public key in '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestCertificateIdentityIndexKeys(t *testing.T) {
+	ca := newSigner(t)
+	userKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(ed25519PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := newCert(t, ca, userKey, ssh.UserCert, []string{"alice", "bob"}, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	certBytes := ssh.MarshalAuthorizedKey(cert)
+
+	pub, err := NewPublicKey(bytes.NewReader(certBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	caHash := sha256.Sum256(ssh.MarshalAuthorizedKey(ca.PublicKey()))
+	expected := []string{hex.EncodeToString(caHash[:]), ssh.FingerprintSHA256(ca.PublicKey()), "keyid:test@rekor.dev", "principal:alice", "principal:bob"}
+	if got := pub.IdentityIndexKeys(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected index keys: got %v, expected %v", got, expected)
+	}
+
+	if pub.KeyID() != "test@rekor.dev" {
+		t.Errorf("unexpected key ID %q", pub.KeyID())
+	}
+
+	// a bare key has no identity to index
+	bare, err := NewPublicKey(bytes.NewReader(ssh.MarshalAuthorizedKey(userKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bare.IdentityIndexKeys(); len(got) != 0 {
+		t.Errorf("unexpected index keys for bare key: %v", got)
+	}
+	if bare.KeyID() != "" {
+		t.Errorf("unexpected key ID for bare key: %q", bare.KeyID())
+	}
+
+	// signatures made by the certified key verify against the certificate
+	data := []byte("my good data to be signed!")
+	armored, err := Sign(ed25519PrivateKey, bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := NewSignature(bytes.NewReader(armored))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sig.Verify(bytes.NewReader(data), pub); err != nil {
+		t.Errorf("unexpected error verifying signature with certificate: %v", err)
+	}
+	if err := sig.Verify(bytes.NewReader([]byte("other data")), pub); err == nil {
+		t.Error("expected error verifying signature over different data")
+	}
+}
@@ -9,16 +9,22 @@ import (
 )
 
 const (
-	namespace = "file"
-	pemType   = "SSH SIGNATURE"
+	// DefaultNamespace is the namespace ssh-keygen conventionally uses for signatures over files
+	DefaultNamespace = "file"
+	pemType          = "SSH SIGNATURE"
 )
 
+// Armor encodes a signature made with Sign (over a sha512 hash in the default namespace)
 func Armor(s *ssh.Signature, p ssh.PublicKey) []byte {
+	return armor(s, p, DefaultNamespace, defaultHashAlgorithm)
+}
+
+func armor(s *ssh.Signature, p ssh.PublicKey, namespace, hashAlg string) []byte {
 	sig := WrappedSig{
 		Version:       1,
 		PublicKey:     string(p.Marshal()),
 		Namespace:     namespace,
-		HashAlgorithm: defaultHashAlgorithm,
+		HashAlgorithm: hashAlg,
 		Signature:     string(ssh.Marshal(s)),
 	}
 	copy(sig.MagicHeader[:], []byte(magicHeader))
@@ -52,8 +58,9 @@ func Decode(b []byte) (*Signature, error) {
 	if string(sig.MagicHeader[:]) != magicHeader {
 		return nil, fmt.Errorf("invalid magic header: %s", sig.MagicHeader)
 	}
-	if sig.Namespace != "file" {
-		return nil, fmt.Errorf("invalid signature namespace: %s", sig.Namespace)
+	// the namespace is covered by the signature, so it is kept as is; ssh-keygen refuses to sign without one
+	if sig.Namespace == "" {
+		return nil, errors.New("signature namespace must not be empty")
 	}
 	if _, ok := supportedHashAlgorithms[sig.HashAlgorithm]; !ok {
 		return nil, fmt.Errorf("unsupported hash algorithm: %s", sig.HashAlgorithm)
@@ -64,7 +71,10 @@ func Decode(b []byte) (*Signature, error) {
 	if err := ssh.Unmarshal([]byte(sig.Signature), &sshSig); err != nil {
 		return nil, err
 	}
-	// TODO: check the format here (should be rsa-sha512)
+	// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig#L71
+	if sshSig.Format == ssh.KeyAlgoRSA {
+		return nil, errors.New("ssh-rsa (SHA1) signatures are not supported; use rsa-sha2-256 or rsa-sha2-512")
+	}
 
 	pk, err := ssh.ParsePublicKey([]byte(sig.PublicKey))
 	if err != nil {
@@ -75,5 +85,6 @@ func Decode(b []byte) (*Signature, error) {
 		signature: &sshSig,
 		pk:        pk,
 		hashAlg:   sig.HashAlgorithm,
+		namespace: sig.Namespace,
 	}, nil
 }
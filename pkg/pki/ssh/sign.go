@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
+	"errors"
 	"hash"
 	"io"
 
@@ -39,7 +40,7 @@ var supportedHashAlgorithms = map[string]func() hash.Hash{
 	"sha512": sha512.New,
 }
 
-func sign(s ssh.AlgorithmSigner, m io.Reader) (*ssh.Signature, error) {
+func sign(s ssh.AlgorithmSigner, m io.Reader, namespace string) (*ssh.Signature, error) {
 	hf := sha512.New()
 	if _, err := io.Copy(hf, m); err != nil {
 		return nil, err
@@ -47,7 +48,7 @@ func sign(s ssh.AlgorithmSigner, m io.Reader) (*ssh.Signature, error) {
 	mh := hf.Sum(nil)
 
 	sp := MessageWrapper{
-		Namespace:     "file",
+		Namespace:     namespace,
 		HashAlgorithm: defaultHashAlgorithm,
 		Hash:          string(mh),
 	}
@@ -69,7 +70,16 @@ func sign(s ssh.AlgorithmSigner, m io.Reader) (*ssh.Signature, error) {
 	return sig, nil
 }
 
+// Sign signs data in the default namespace, as "ssh-keygen -Y sign -n file" does
 func Sign(sshPrivateKey string, data io.Reader) ([]byte, error) {
+	return SignWithNamespace(sshPrivateKey, DefaultNamespace, data)
+}
+
+// SignWithNamespace signs data in namespace, as "ssh-keygen -Y sign -n <namespace>" does
+func SignWithNamespace(sshPrivateKey, namespace string, data io.Reader) ([]byte, error) {
+	if namespace == "" {
+		return nil, errors.New("namespace must not be empty")
+	}
 	s, err := ssh.ParsePrivateKey([]byte(sshPrivateKey))
 	if err != nil {
 		return nil, err
@@ -77,14 +87,14 @@ func Sign(sshPrivateKey string, data io.Reader) ([]byte, error) {
 
 	as, ok := s.(ssh.AlgorithmSigner)
 	if !ok {
-		return nil, err
+		return nil, errors.New("ssh key does not support choosing a signature algorithm")
 	}
 
-	sig, err := sign(as, data)
+	sig, err := sign(as, data, namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	armored := Armor(sig, s.PublicKey())
+	armored := armor(sig, s.PublicKey(), namespace, defaultHashAlgorithm)
 	return armored, nil
 }
@@ -2,11 +2,14 @@ package ssh
 
 import (
 	"bytes"
+	"crypto/rand"
 	"io/ioutil"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"golang.org/x/crypto/ssh"
 )
 
 var (
@@ -277,6 +280,76 @@ func TestRoundTrip(t *testing.T) {
 	}
 
 }
+func TestNamespaces(t *testing.T) {
+	td := t.TempDir()
+
+	data := []byte("hello, ssh world")
+	dataPath := write(t, data, td, "data")
+	privPath := write(t, []byte(ed25519PrivateKey), td, "id")
+	write(t, []byte(ed25519PublicKey), td, "id.pub")
+
+	// A signature from the cli in another namespace, over a sha256 hash, should validate here.
+	run(t, nil, "ssh-keygen", "-Y", "sign", "-n", "git", "-O", "hashalg=sha256", "-f", privPath, dataPath)
+	sigBytes, err := ioutil.ReadFile(dataPath + ".sig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := NewSignature(bytes.NewReader(sigBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.Namespace() != "git" {
+		t.Errorf("unexpected namespace %v", sig.Namespace())
+	}
+	key, err := NewPublicKey(strings.NewReader(ed25519PublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sig.Verify(bytes.NewReader(data), key); err != nil {
+		t.Error(err)
+	}
+
+	// The canonical value must keep the namespace and hash algorithm, or it would no longer verify.
+	canonical, err := sig.CanonicalValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(bytes.NewReader(data), canonical, []byte(ed25519PublicKey)); err != nil {
+		t.Errorf("canonical signature does not verify: %v", err)
+	}
+
+	// A signature from here in a namespace should only validate in the CLI in that namespace.
+	armored, err := SignWithNamespace(ed25519PrivateKey, "git", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigPath := write(t, armored, td, "oursig")
+	allowedSigners := write(t, []byte("test@rekor.dev "+ed25519PublicKey), td, "allowed_signer")
+	run(t, data, "ssh-keygen", "-Y", "verify", "-f", allowedSigners,
+		"-I", "test@rekor.dev", "-n", "git", "-s", sigPath)
+	runErr(t, data, "ssh-keygen", "-Y", "verify", "-f", allowedSigners,
+		"-I", "test@rekor.dev", "-n", "file", "-s", sigPath)
+
+	if _, err := SignWithNamespace(ed25519PrivateKey, "", bytes.NewReader(data)); err == nil {
+		t.Error("expected error signing without a namespace")
+	}
+}
+
+func TestRejectSHA1Signatures(t *testing.T) {
+	s, err := ssh.ParsePrivateKey([]byte(sshPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the message does not matter, only the signature format is checked when decoding
+	sig, err := s.(ssh.AlgorithmSigner).SignWithAlgorithm(rand.Reader, []byte("data"), ssh.SigAlgoRSA)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Decode(armor(sig, s.PublicKey(), DefaultNamespace, defaultHashAlgorithm)); err == nil {
+		t.Error("expected error decoding ssh-rsa signature")
+	}
+}
+
 
 func write(t *testing.T, d []byte, fp ...string) string {
 	p := filepath.Join(fp...)
@@ -17,9 +17,13 @@ limitations under the License.
 package ssh
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"time"
 
 	"golang.org/x/crypto/ssh"
 )
@@ -28,6 +32,7 @@ type Signature struct {
 	signature *ssh.Signature
 	pk        ssh.PublicKey
 	hashAlg   string
+	namespace string
 }
 
 // NewSignature creates and Validates an ssh signature object
@@ -45,7 +50,16 @@ func NewSignature(r io.Reader) (*Signature, error) {
 
 // CanonicalValue implements the pki.Signature interface
 func (s Signature) CanonicalValue() ([]byte, error) {
-	return []byte(Armor(s.signature, s.pk)), nil
+	if s.signature == nil {
+		return nil, fmt.Errorf("ssh signature has not been initialized")
+	}
+	return armor(s.signature, s.pk, s.namespace, s.hashAlg), nil
+}
+
+// Namespace returns the namespace the signature was made in (such as "file" or "git"); a signature only
+// attests to data in its namespace, so those relying on an entry should check it is the one they expect
+func (s Signature) Namespace() string {
+	return s.namespace
 }
 
 // Verify implements the pki.Signature interface
@@ -70,7 +84,7 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 	return Verify(r, cs, ck)
 }
 
-// PublicKey contains an ssh PublicKey
+// PublicKey contains an ssh PublicKey, which may be an OpenSSH user certificate
 type PublicKey struct {
 	key ssh.PublicKey
 }
@@ -86,10 +100,95 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cert, ok := key.(*ssh.Certificate); ok {
+		if err := validateCertificate(cert, time.Now()); err != nil {
+			return nil, fmt.Errorf("invalid ssh certificate: %w", err)
+		}
+	}
 
 	return &PublicKey{key: key}, nil
 }
 
+// supportedCriticalOptions are the critical options of user certificates that restrict how the
+// certificate may be used to log in, but not which files it may sign
+var supportedCriticalOptions = []string{"force-command", "source-address", "verify-required"}
+
+// validateCertificate checks that cert is a user certificate signed by its CA, valid at now and bound to
+// at least one principal; whether the CA is trusted is left to those searching the log by CA key
+func validateCertificate(cert *ssh.Certificate, now time.Time) error {
+	if cert.CertType != ssh.UserCert {
+		return errors.New("only user certificates can be used to sign artifacts")
+	}
+	if len(cert.ValidPrincipals) == 0 {
+		return errors.New("certificate is not bound to any principals")
+	}
+	if _, ok := cert.SignatureKey.(*ssh.Certificate); ok {
+		return errors.New("certificate authority key must not itself be a certificate")
+	}
+	checker := ssh.CertChecker{
+		SupportedCriticalOptions: supportedCriticalOptions,
+		Clock:                    func() time.Time { return now },
+	}
+	return checker.CheckCert(cert.ValidPrincipals[0], cert)
+}
+
+// PrincipalKey returns the index key under which entries signed with a certificate for principal are stored
+func PrincipalKey(principal string) string {
+	return "principal:" + principal
+}
+
+// KeyIDKey returns the index key under which entries signed with a certificate with keyID are stored
+func KeyIDKey(keyID string) string {
+	return "keyid:" + keyID
+}
+
+// Principals returns the principals a certificate was issued for, or nil for a bare key
+func (k PublicKey) Principals() []string {
+	if cert, ok := k.key.(*ssh.Certificate); ok {
+		return cert.ValidPrincipals
+	}
+	return nil
+}
+
+// IdentityIndexKeys implements the pki.IdentityKey interface; for certificates, it returns the hash and the
+// fingerprint of the canonical CA key (so entries can be found by searching for the CA public key, whichever
+// key index scheme is in use), the key ID and the principals
+func (k PublicKey) IdentityIndexKeys() []string {
+	cert, ok := k.key.(*ssh.Certificate)
+	if !ok {
+		return nil
+	}
+	caHash := sha256.Sum256(ssh.MarshalAuthorizedKey(cert.SignatureKey))
+	result := []string{hex.EncodeToString(caHash[:]), ssh.FingerprintSHA256(cert.SignatureKey)}
+	if cert.KeyId != "" {
+		result = append(result, KeyIDKey(cert.KeyId))
+	}
+	for _, p := range cert.ValidPrincipals {
+		result = append(result, PrincipalKey(p))
+	}
+	return result
+}
+
+// KeyID returns the key ID a certificate was issued with, or "" for a bare key
+func (k PublicKey) KeyID() string {
+	if cert, ok := k.key.(*ssh.Certificate); ok {
+		return cert.KeyId
+	}
+	return ""
+}
+
+// Fingerprint implements the pki.Fingerprinter interface; it is the SHA256 fingerprint printed by "ssh-keygen -l",
+// which for a certificate is the fingerprint of the certified key
+func (k PublicKey) Fingerprint() string {
+	if k.key == nil {
+		return ""
+	}
+	if cert, ok := k.key.(*ssh.Certificate); ok {
+		return ssh.FingerprintSHA256(cert.Key)
+	}
+	return ssh.FingerprintSHA256(k.key)
+}
+
 // CanonicalValue implements the pki.PublicKey interface
 func (k PublicKey) CanonicalValue() ([]byte, error) {
 	if k.key == nil {
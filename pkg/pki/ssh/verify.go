@@ -25,7 +25,7 @@ func Verify(message io.Reader, armoredSignature []byte, publicKey []byte) error
 	hm := h.Sum(nil)
 
 	toVerify := MessageWrapper{
-		Namespace:     "file",
+		Namespace:     decodedSignature.namespace,
 		HashAlgorithm: decodedSignature.hashAlg,
 		Hash:          string(hm),
 	}
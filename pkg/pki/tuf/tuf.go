@@ -0,0 +1,308 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuf
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// the schemes of keys and signatures as defined by securesystemslib, which are used by both python-tuf and go-tuf
+const (
+	schemeEd25519        = "ed25519"
+	schemeECDSAP256      = "ecdsa-sha2-nistp256"
+	schemeECDSAP384      = "ecdsa-sha2-nistp384"
+	schemeRSAPSSSHA256   = "rsassa-pss-sha256"
+	schemeRSAPKCS1SHA256 = "rsa-pkcs1v15-sha256"
+)
+
+// keyJSON is a key as listed in TUF metadata; the fields are declared in sorted order, so that marshalling the
+// struct yields the same encoding regardless of how the key was formatted
+type keyJSON struct {
+	KeyIDHashAlgorithms []string `json:"keyid_hash_algorithms,omitempty"`
+	KeyType             string   `json:"keytype"`
+	KeyVal              struct {
+		Public string `json:"public"`
+	} `json:"keyval"`
+	Scheme string `json:"scheme"`
+}
+
+// signatureJSON is a signature as listed in TUF metadata
+type signatureJSON struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// Signature Signature as made by TUF signers; it may be supplied as a TUF signature object (with a hex encoded
+// "sig" and optionally the "keyid" of the signer), or as the bare hex encoded signature
+type Signature struct {
+	keyID     string
+	signature []byte
+}
+
+// NewSignature creates and validates a TUF signature object
+func NewSignature(r io.Reader) (*Signature, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read TUF signature: %w", err)
+	}
+
+	b = bytes.TrimSpace(b)
+	s := signatureJSON{}
+	if len(b) > 0 && b[0] == '{' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return nil, fmt.Errorf("invalid TUF signature: %w", err)
+		}
+	} else {
+		s.Sig = string(b)
+	}
+
+	sig, err := hex.DecodeString(s.Sig)
+	if err != nil || len(sig) == 0 {
+		return nil, errors.New("invalid TUF signature: expected a hex encoded signature")
+	}
+	if s.KeyID != "" {
+		if _, err := hex.DecodeString(s.KeyID); err != nil {
+			return nil, fmt.Errorf("invalid TUF signature: malformed key ID %q", s.KeyID)
+		}
+	}
+	return &Signature{
+		keyID:     strings.ToLower(s.KeyID),
+		signature: sig,
+	}, nil
+}
+
+// CanonicalValue implements the pki.Signature interface; signatures are stored as TUF signature objects
+func (s Signature) CanonicalValue() ([]byte, error) {
+	if len(s.signature) == 0 {
+		return nil, errors.New("TUF signature has not been initialized")
+	}
+	return canonicalJSON(signatureJSON{KeyID: s.keyID, Sig: hex.EncodeToString(s.signature)})
+}
+
+// Verify implements the pki.Signature interface
+func (s Signature) Verify(r io.Reader, k interface{}) error {
+	if len(s.signature) == 0 {
+		return errors.New("TUF signature has not been initialized")
+	}
+
+	key, ok := k.(*PublicKey)
+	if !ok {
+		return fmt.Errorf("cannot use Verify with a non-TUF key")
+	}
+	if key.key == nil {
+		return errors.New("TUF public key has not been initialized")
+	}
+	if s.keyID != "" && s.keyID != key.keyID {
+		return fmt.Errorf("signature was made by key %v rather than %v", s.keyID, key.keyID)
+	}
+
+	message, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading message to verify signature: %w", err)
+	}
+
+	switch key.raw.Scheme {
+	case schemeEd25519:
+		if !ed25519.Verify(key.key.(ed25519.PublicKey), message, s.signature) {
+			return errors.New("supplied signature does not match key")
+		}
+	case schemeECDSAP256:
+		digest := sha256.Sum256(message)
+		if !ecdsa.VerifyASN1(key.key.(*ecdsa.PublicKey), digest[:], s.signature) {
+			return errors.New("supplied signature does not match key")
+		}
+	case schemeECDSAP384:
+		digest := sha512.Sum384(message)
+		if !ecdsa.VerifyASN1(key.key.(*ecdsa.PublicKey), digest[:], s.signature) {
+			return errors.New("supplied signature does not match key")
+		}
+	case schemeRSAPSSSHA256:
+		digest := sha256.Sum256(message)
+		return rsa.VerifyPSS(key.key.(*rsa.PublicKey), crypto.SHA256, digest[:], s.signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	case schemeRSAPKCS1SHA256:
+		digest := sha256.Sum256(message)
+		return rsa.VerifyPKCS1v15(key.key.(*rsa.PublicKey), crypto.SHA256, digest[:], s.signature)
+	default:
+		return fmt.Errorf("unsupported TUF signature scheme %q", key.raw.Scheme)
+	}
+	return nil
+}
+
+// PublicKey Public Key as listed in TUF metadata, that is a JSON object with "keytype", "scheme" and "keyval"
+// fields; Ed25519 keys are hex encoded, while ECDSA and RSA keys are PEM encoded (or, for ECDSA, the hex encoded
+// uncompressed point used by older versions of go-tuf)
+type PublicKey struct {
+	raw   keyJSON
+	key   crypto.PublicKey
+	keyID string
+}
+
+// NewPublicKey implements the pki.PublicKey interface
+func NewPublicKey(r io.Reader) (*PublicKey, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read TUF public key: %w", err)
+	}
+
+	raw := keyJSON{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("invalid TUF public key: %w", err)
+	}
+	key, err := parseKeyVal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TUF public key: %w", err)
+	}
+
+	id := sha256.Sum256(raw.keyIDInput())
+	return &PublicKey{raw: raw, key: key, keyID: hex.EncodeToString(id[:])}, nil
+}
+
+// keyIDInput returns the canonical JSON of the key as specified by TUF, which only escapes backslashes and
+// quotes in strings (and so is not valid JSON for PEM encoded keys, which contain newlines)
+func (raw keyJSON) keyIDInput() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	if len(raw.KeyIDHashAlgorithms) > 0 {
+		buf.WriteString(`"keyid_hash_algorithms":[`)
+		for i, alg := range raw.KeyIDHashAlgorithms {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			writeCanonicalString(&buf, alg)
+		}
+		buf.WriteString("],")
+	}
+	buf.WriteString(`"keytype":`)
+	writeCanonicalString(&buf, raw.KeyType)
+	buf.WriteString(`,"keyval":{"public":`)
+	writeCanonicalString(&buf, raw.KeyVal.Public)
+	buf.WriteString(`},"scheme":`)
+	writeCanonicalString(&buf, raw.Scheme)
+	buf.WriteString("}")
+	return buf.Bytes()
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	buf.WriteString(`"`)
+	buf.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s))
+	buf.WriteString(`"`)
+}
+
+// parseKeyVal decodes the public key of a TUF key, checking that its type matches the key type and scheme
+func parseKeyVal(raw keyJSON) (crypto.PublicKey, error) {
+	public := raw.KeyVal.Public
+	switch raw.Scheme {
+	case schemeEd25519:
+		if raw.KeyType != "ed25519" {
+			break
+		}
+		b, err := hex.DecodeString(public)
+		if err != nil || len(b) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("expected %v hex encoded bytes for ed25519 key", ed25519.PublicKeySize)
+		}
+		return ed25519.PublicKey(b), nil
+	case schemeECDSAP256, schemeECDSAP384:
+		if raw.KeyType != "ecdsa" && raw.KeyType != raw.Scheme {
+			break
+		}
+		curve := elliptic.P256()
+		if raw.Scheme == schemeECDSAP384 {
+			curve = elliptic.P384()
+		}
+		if b, err := hex.DecodeString(public); err == nil {
+			x, y := elliptic.Unmarshal(curve, b)
+			if x == nil {
+				return nil, errors.New("malformed ecdsa key")
+			}
+			return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+		}
+		pub, err := parsePEM(public)
+		if err != nil {
+			return nil, err
+		}
+		ecKey, ok := pub.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != curve {
+			return nil, fmt.Errorf("key does not match scheme %v", raw.Scheme)
+		}
+		return ecKey, nil
+	case schemeRSAPSSSHA256, schemeRSAPKCS1SHA256:
+		if raw.KeyType != "rsa" {
+			break
+		}
+		pub, err := parsePEM(public)
+		if err != nil {
+			return nil, err
+		}
+		rsaKey, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("key does not match scheme %v", raw.Scheme)
+		}
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", raw.Scheme)
+	}
+	return nil, fmt.Errorf("key type %q does not match scheme %v", raw.KeyType, raw.Scheme)
+}
+
+func parsePEM(public string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(public))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("expected a PEM encoded public key")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// CanonicalValue implements the pki.PublicKey interface; the public key is kept exactly as supplied, since
+// re-encoding it would change its key ID
+func (k PublicKey) CanonicalValue() ([]byte, error) {
+	if k.key == nil {
+		return nil, errors.New("TUF public key has not been initialized")
+	}
+	return canonicalJSON(k.raw)
+}
+
+// Fingerprint implements the pki.Fingerprinter interface, returning the key ID used to refer to the key in TUF metadata
+func (k PublicKey) Fingerprint() string {
+	return k.keyID
+}
+
+// canonicalJSON encodes v without insignificant whitespace or HTML escaping
+func canonicalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuf
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func open(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestReadPublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := hex.EncodeToString(elliptic.Marshal(elliptic.P256(), ecKey.X, ecKey.Y))
+
+	tests := []struct {
+		caseDesc   string
+		input      string
+		errorFound bool
+	}{
+		{caseDesc: "ed25519 key", input: `{"keytype":"ed25519","scheme":"ed25519","keyval":{"public":"8b359e93680a80c33e6da778ad52799dcf4db1b34e6dad00d0135fcce824951b"}}`, errorFound: false},
+		{caseDesc: "go-tuf ecdsa key", input: `{"keytype":"ecdsa-sha2-nistp256","scheme":"ecdsa-sha2-nistp256","keyval":{"public":"` + point + `"}}`, errorFound: false},
+		{caseDesc: "ecdsa key of the wrong curve", input: `{"keytype":"ecdsa","scheme":"ecdsa-sha2-nistp384","keyval":{"public":"` + point + `"}}`, errorFound: true},
+		{caseDesc: "key type not matching scheme", input: `{"keytype":"rsa","scheme":"ed25519","keyval":{"public":"8b359e93680a80c33e6da778ad52799dcf4db1b34e6dad00d0135fcce824951b"}}`, errorFound: true},
+		{caseDesc: "truncated ed25519 key", input: `{"keytype":"ed25519","scheme":"ed25519","keyval":{"public":"8b359e93"}}`, errorFound: true},
+		{caseDesc: "unknown scheme", input: `{"keytype":"dsa","scheme":"dsa-sha1","keyval":{"public":"00"}}`, errorFound: true},
+		{caseDesc: "not json", input: "-----BEGIN PUBLIC KEY-----", errorFound: true},
+	}
+
+	for _, tc := range tests {
+		if got, err := NewPublicKey(strings.NewReader(tc.input)); ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+	}
+
+	for _, name := range []string{"testdata/ed25519.json", "testdata/ecdsa.json", "testdata/rsa.json"} {
+		k, err := NewPublicKey(open(t, name))
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		canonical, err := k.CanonicalValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		k2, err := NewPublicKey(bytes.NewReader(canonical))
+		if err != nil {
+			t.Fatalf("%v: canonical value rejected: %v", name, err)
+		}
+		if k2.Fingerprint() != k.Fingerprint() {
+			t.Errorf("%v: key ID changed through canonicalization", name)
+		}
+	}
+
+	if _, err := (PublicKey{}).CanonicalValue(); err == nil {
+		t.Error("expected error for uninitialized key")
+	}
+}
+
+func TestKeyID(t *testing.T) {
+	// computed over the TUF canonical JSON of the key, in which the newlines of the PEM encoding are not escaped
+	k, err := NewPublicKey(open(t, "testdata/ecdsa.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := k.Fingerprint(); got != "80e2449597910e786d41d882b52a5aaf51d06b551215f2c8bda68908dffe9f04" {
+		t.Errorf("unexpected key ID %v", got)
+	}
+}
+
+func TestReadSignature(t *testing.T) {
+	tests := []struct {
+		caseDesc   string
+		input      string
+		errorFound bool
+	}{
+		{caseDesc: "hex signature", input: "3045022100ab\n", errorFound: false},
+		{caseDesc: "signature object", input: `{"keyid":"80e2","sig":"3045022100ab"}`, errorFound: false},
+		{caseDesc: "signature object without key ID", input: `{"sig":"3045022100ab"}`, errorFound: false},
+		{caseDesc: "malformed key ID", input: `{"keyid":"xyz","sig":"3045022100ab"}`, errorFound: true},
+		{caseDesc: "empty signature", input: `{"keyid":"80e2","sig":""}`, errorFound: true},
+		{caseDesc: "base64 signature", input: "MEUCIQCr", errorFound: true},
+	}
+
+	for _, tc := range tests {
+		if got, err := NewSignature(strings.NewReader(tc.input)); ((got != nil) == tc.errorFound) || ((err != nil) != tc.errorFound) {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	tests := []struct {
+		caseDesc      string
+		keyFile       string
+		sigFile       string
+		expectSuccess bool
+	}{
+		{caseDesc: "ed25519", keyFile: "testdata/ed25519.json", sigFile: "testdata/hello_world.txt.ed25519.sig", expectSuccess: true},
+		{caseDesc: "ecdsa with key ID", keyFile: "testdata/ecdsa.json", sigFile: "testdata/hello_world.txt.ecdsa.sig", expectSuccess: true},
+		{caseDesc: "rsassa-pss", keyFile: "testdata/rsa.json", sigFile: "testdata/hello_world.txt.rsa.sig", expectSuccess: true},
+		{caseDesc: "wrong key", keyFile: "testdata/rsa.json", sigFile: "testdata/hello_world.txt.ed25519.sig", expectSuccess: false},
+		{caseDesc: "key ID of another key", keyFile: "testdata/ed25519.json", sigFile: "testdata/hello_world.txt.ecdsa.sig", expectSuccess: false},
+	}
+
+	for _, tc := range tests {
+		k, err := NewPublicKey(open(t, tc.keyFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := NewSignature(open(t, tc.sigFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Verify(open(t, "testdata/hello_world.txt"), k); (err == nil) != tc.expectSuccess {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+		if err := s.Verify(strings.NewReader("tampered"), k); err == nil {
+			t.Errorf("%v: expected error verifying a different message", tc.caseDesc)
+		}
+
+		// the canonical signature must verify just the same
+		canonical, err := s.CanonicalValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cs, err := NewSignature(bytes.NewReader(canonical))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cs.Verify(open(t, "testdata/hello_world.txt"), k); (err == nil) != tc.expectSuccess {
+			t.Errorf("%v: unexpected result with canonical signature: %v", tc.caseDesc, err)
+		}
+	}
+
+	s, err := NewSignature(open(t, "testdata/hello_world.txt.ed25519.sig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(open(t, "testdata/hello_world.txt"), &PublicKey{}); err == nil {
+		t.Error("expected error verifying with uninitialized key")
+	}
+	if err := s.Verify(open(t, "testdata/hello_world.txt"), "not a key"); err == nil {
+		t.Error("expected error verifying with wrong key type")
+	}
+}
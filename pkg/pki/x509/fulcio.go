@@ -0,0 +1,96 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509
+
+import (
+	"encoding/asn1"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// oidFulcioIssuer is the extension in which Fulcio records the OIDC issuer that authenticated the subject of a
+// certificate, see https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+var oidFulcioIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+
+// URIKey returns the index key under which entries signed with a certificate for the SAN URI uri are stored
+func URIKey(uri string) string {
+	return "uri:" + uri
+}
+
+// IssuerKey returns the index key under which entries signed with a certificate whose subject was authenticated
+// by the OIDC issuer are stored
+func IssuerKey(issuer string) string {
+	return "oidc-issuer:" + strings.TrimSuffix(issuer, "/")
+}
+
+// IdentityKey returns the index key under which entries signed with a certificate for the SAN email address or
+// URI identity, whose subject was authenticated by the OIDC issuer, are stored
+func IdentityKey(issuer, identity string) string {
+	// email addresses are indexed in lower case as they are under EmailKey, while URIs always have a scheme
+	if !strings.Contains(identity, ":") {
+		identity = strings.ToLower(identity)
+	}
+	return "cert-identity:" + strings.TrimSuffix(issuer, "/") + " " + identity
+}
+
+// Emails returns the SAN email addresses of the certificate, or nil for a bare key
+func (k PublicKey) Emails() []string {
+	if k.cert == nil {
+		return nil
+	}
+	seen := map[string]struct{}{}
+	var result []string
+	for _, email := range k.cert.c.EmailAddresses {
+		email = strings.ToLower(email)
+		if _, ok := seen[email]; !ok {
+			seen[email] = struct{}{}
+			result = append(result, email)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// URIs returns the SAN URIs of the certificate, such as the workflow identity of a CI job, or nil for a bare key
+func (k PublicKey) URIs() []string {
+	if k.cert == nil {
+		return nil
+	}
+	var result []string
+	for _, u := range k.cert.c.URIs {
+		result = append(result, u.String())
+	}
+	return result
+}
+
+// Issuer returns the OIDC issuer recorded in a certificate issued by Fulcio, or "" if there is none
+func (k PublicKey) Issuer() string {
+	if k.cert == nil {
+		return ""
+	}
+	for _, e := range k.cert.c.Extensions {
+		if !e.Id.Equal(oidFulcioIssuer) {
+			continue
+		}
+		// the issuer is the raw value of the extension rather than a DER encoded string
+		if utf8.Valid(e.Value) {
+			return string(e.Value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// extensions of the attestation certificates YubiKeys issue for keys generated in a PIV slot, see
+// https://developers.yubico.com/PIV/Introduction/PIV_attestation.html
+var (
+	oidYubicoFirmware   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 41482, 3, 3}
+	oidYubicoSerial     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 41482, 3, 7}
+	oidYubicoPolicy     = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 41482, 3, 8}
+	oidYubicoFormFactor = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 41482, 3, 9}
+)
+
+// pivAttestationPrefix starts the common name of attestation certificates, which ends with the slot
+const pivAttestationPrefix = "YubiKey PIV Attestation "
+
+// PIVAttestation describes the device and slot holding a key, as attested by a YubiKey PIV attestation certificate
+type PIVAttestation struct {
+	// Serial is the serial number of the YubiKey
+	Serial uint32
+	// Slot is the PIV slot the key was generated in, in hex, such as "9c"
+	Slot string
+	// Firmware is the firmware version of the YubiKey, such as "5.2.7"
+	Firmware string
+}
+
+// PIVKey returns the index key under which entries signed with a key attested to be held by the PIV device
+// with serial are stored; device is either the serial number, or the serial number and slot as "serial/slot"
+func PIVKey(device string) string {
+	return "piv:" + strings.ToLower(device)
+}
+
+// isPIVAttestation reports whether c claims to be a YubiKey PIV attestation certificate
+func isPIVAttestation(c *x509.Certificate) bool {
+	if strings.HasPrefix(c.Subject.CommonName, pivAttestationPrefix) {
+		return true
+	}
+	for _, e := range c.Extensions {
+		if e.Id.Equal(oidYubicoSerial) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePIVAttestation reads the attested device and slot of a YubiKey PIV attestation certificate; the
+// certificate must be followed by the attestation certificate of the device that issued it, as otherwise
+// nothing ties the claims to a YubiKey
+func parsePIVAttestation(certs []*cert) (*PIVAttestation, error) {
+	c := certs[0].c
+	if len(certs) < 2 {
+		return nil, errors.New("PIV attestation certificate must be followed by the attestation certificate of the device")
+	}
+
+	slot := strings.ToLower(strings.TrimPrefix(c.Subject.CommonName, pivAttestationPrefix))
+	if !validPIVSlot(slot) {
+		return nil, fmt.Errorf("invalid PIV slot in attestation certificate subject %q", c.Subject.CommonName)
+	}
+	a := &PIVAttestation{Slot: slot}
+
+	var haveSerial bool
+	for _, e := range c.Extensions {
+		switch {
+		case e.Id.Equal(oidYubicoFirmware):
+			if len(e.Value) != 3 {
+				return nil, errors.New("invalid firmware version in PIV attestation certificate")
+			}
+			a.Firmware = fmt.Sprintf("%d.%d.%d", e.Value[0], e.Value[1], e.Value[2])
+		case e.Id.Equal(oidYubicoSerial):
+			var serial int64
+			if rest, err := asn1.Unmarshal(e.Value, &serial); err != nil || len(rest) != 0 || serial <= 0 || serial > math.MaxUint32 {
+				return nil, errors.New("invalid serial number in PIV attestation certificate")
+			}
+			a.Serial = uint32(serial)
+			haveSerial = true
+		case e.Id.Equal(oidYubicoPolicy):
+			if len(e.Value) != 2 {
+				return nil, errors.New("invalid PIN and touch policy in PIV attestation certificate")
+			}
+		case e.Id.Equal(oidYubicoFormFactor):
+			if len(e.Value) != 1 {
+				return nil, errors.New("invalid form factor in PIV attestation certificate")
+			}
+		}
+	}
+	if !haveSerial {
+		return nil, errors.New("PIV attestation certificate does not carry the serial number of the device")
+	}
+	return a, nil
+}
+
+// validPIVSlot reports whether slot is the hex encoding of a PIV slot that holds keys: the authentication,
+// signature, key management and card authentication slots, and the retired key management slots
+func validPIVSlot(slot string) bool {
+	if len(slot) != 2 {
+		return false
+	}
+	n, err := strconv.ParseUint(slot, 16, 8)
+	if err != nil {
+		return false
+	}
+	switch {
+	case n == 0x9a, n == 0x9c, n == 0x9d, n == 0x9e:
+		return true
+	case n >= 0x82 && n <= 0x95:
+		return true
+	}
+	return false
+}
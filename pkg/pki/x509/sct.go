@@ -0,0 +1,155 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
+)
+
+var (
+	ctLogKeysMu sync.RWMutex
+	// ctLogKeys maps the log ID (the SHA256 hash of the DER encoded public key) of each CT log to its key
+	ctLogKeys map[[sha256.Size]byte]crypto.PublicKey
+)
+
+// SetCTLogKeys configures the PEM encoded public keys of the certificate transparency logs that SCTs embedded in
+// certificates are verified against; with no keys configured, embedded SCTs are not checked
+func SetCTLogKeys(b []byte) error {
+	var keys map[[sha256.Size]byte]crypto.PublicKey
+	for len(bytes.TrimSpace(b)) > 0 {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			return errors.New("CT log keys must be PEM encoded public keys")
+		}
+		if block.Type != "PUBLIC KEY" {
+			return fmt.Errorf("unexpected PEM block type %q in CT log keys", block.Type)
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid CT log key: %w", err)
+		}
+		if keys == nil {
+			keys = map[[sha256.Size]byte]crypto.PublicKey{}
+		}
+		keys[sha256.Sum256(block.Bytes)] = key
+	}
+
+	ctLogKeysMu.Lock()
+	defer ctLogKeysMu.Unlock()
+	ctLogKeys = keys
+	return nil
+}
+
+func currentCTLogKeys() map[[sha256.Size]byte]crypto.PublicKey {
+	ctLogKeysMu.RLock()
+	defer ctLogKeysMu.RUnlock()
+	return ctLogKeys
+}
+
+// SCT is a signed certificate timestamp embedded in a certificate that verified against a configured CT log
+type SCT struct {
+	// LogID is the hex encoded ID of the log that issued the timestamp
+	LogID string
+	// Timestamp is when the log promised to include the certificate
+	Timestamp time.Time
+}
+
+// SCTError is returned when the SCTs embedded in a certificate do not satisfy the configured CT logs
+type SCTError struct {
+	Reason string
+}
+
+func (e *SCTError) Error() string {
+	return "embedded SCT verification failed: " + e.Reason
+}
+
+// verifyEmbeddedSCTs checks the SCTs embedded in leaf against the configured CT logs, returning those that
+// verified. SCTs from logs that are not configured are ignored, but a certificate carrying SCTs must have at
+// least one from a configured log, and none that fail to verify; the issuer is needed to rebuild the
+// precertificate the logs signed.
+func verifyEmbeddedSCTs(leaf, issuer *cert) ([]SCT, error) {
+	keys := currentCTLogKeys()
+	if keys == nil {
+		return nil, nil
+	}
+
+	ctLeaf, err := ctx509.ParseCertificate(leaf.b)
+	if ctx509.IsFatal(err) {
+		return nil, fmt.Errorf("parsing certificate for SCT verification: %w", err)
+	}
+	if len(ctLeaf.SCTList.SCTList) == 0 {
+		return nil, nil
+	}
+	if issuer == nil {
+		return nil, &SCTError{Reason: "certificate must be followed by its issuer so its SCTs can be verified"}
+	}
+	ctIssuer, err := ctx509.ParseCertificate(issuer.b)
+	if ctx509.IsFatal(err) {
+		return nil, fmt.Errorf("parsing issuer for SCT verification: %w", err)
+	}
+
+	var result []SCT
+	for _, serialized := range ctLeaf.SCTList.SCTList {
+		var sct ct.SignedCertificateTimestamp
+		if rest, err := cttls.Unmarshal(serialized.Val, &sct); err != nil || len(rest) != 0 {
+			return nil, &SCTError{Reason: "invalid embedded SCT"}
+		}
+		key, ok := keys[sct.LogID.KeyID]
+		if !ok {
+			continue
+		}
+		logID := hex.EncodeToString(sct.LogID.KeyID[:])
+		if err := verifySCT(key, ctLeaf, ctIssuer, sct); err != nil {
+			return nil, &SCTError{Reason: fmt.Sprintf("SCT from CT log %v does not verify: %v", logID, err)}
+		}
+		result = append(result, SCT{
+			LogID:     logID,
+			Timestamp: time.Unix(0, int64(sct.Timestamp)*int64(time.Millisecond)).UTC(),
+		})
+	}
+	if len(result) == 0 {
+		return nil, &SCTError{Reason: "certificate carries no SCT from a known CT log"}
+	}
+	return result, nil
+}
+
+// verifySCT checks the signature of a CT log over the precertificate an embedded SCT was issued for
+func verifySCT(key crypto.PublicKey, leaf, issuer *ctx509.Certificate, sct ct.SignedCertificateTimestamp) error {
+	mtl, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{leaf, issuer}, sct.Timestamp)
+	if err != nil {
+		return err
+	}
+	verifier, err := ct.NewSignatureVerifier(key)
+	if err != nil {
+		return err
+	}
+	return verifier.VerifySCTSignature(sct, ct.LogEntry{Leaf: *mtl})
+}
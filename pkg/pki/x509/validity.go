@@ -0,0 +1,53 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidityError is returned when a certificate is used outside of its validity period
+type ValidityError struct {
+	// Subject is the subject of the certificate that is not valid
+	Subject   string
+	NotBefore time.Time
+	NotAfter  time.Time
+	// At is the time the certificate was checked against
+	At time.Time
+}
+
+func (e *ValidityError) Error() string {
+	return fmt.Sprintf("certificate %q is valid from %v to %v, but not at %v", e.Subject,
+		e.NotBefore.UTC().Format(time.RFC3339), e.NotAfter.UTC().Format(time.RFC3339), e.At.UTC().Format(time.RFC3339))
+}
+
+// ValidAt checks that the certificate, and the rest of the chain kept with it, is valid at t. Signatures made
+// with short-lived certificates are verified against the time their entry was integrated into the log (or
+// against a signed timestamp) rather than the current time, so t should be one of those; bare keys are
+// valid at any time.
+func (k PublicKey) ValidAt(t time.Time) error {
+	if k.cert == nil {
+		return nil
+	}
+	for _, c := range append([]*cert{k.cert}, k.chain...) {
+		if t.Before(c.c.NotBefore) || t.After(c.c.NotAfter) {
+			return &ValidityError{Subject: c.c.Subject.String(), NotBefore: c.c.NotBefore, NotAfter: c.c.NotAfter, At: t}
+		}
+	}
+	return nil
+}
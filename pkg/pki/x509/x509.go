@@ -21,16 +21,62 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/sha256"
+	_ "crypto/sha512" // for the hashes used with P-384 and P-521 keys
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strconv"
+	"sync"
+
+	"github.com/sigstore/rekor/pkg/pki/pgp"
+)
+
+var (
+	trustRootsMu sync.RWMutex
+	trustRoots   *x509.CertPool
 )
 
+// SetTrustRoots configures the PEM encoded root certificates that certificates must be issued by; with no
+// roots configured, any certificate is accepted as long as the chain supplied with it is internally consistent
+func SetTrustRoots(b []byte) error {
+	var pool *x509.CertPool
+	for len(bytes.TrimSpace(b)) > 0 {
+		var block *pem.Block
+		block, b = pem.Decode(b)
+		if block == nil {
+			return errors.New("trust roots must be PEM encoded certificates")
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block type %q in trust roots", block.Type)
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("invalid trust root: %w", err)
+		}
+		if pool == nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AddCert(c)
+	}
+
+	trustRootsMu.Lock()
+	defer trustRootsMu.Unlock()
+	trustRoots = pool
+	return nil
+}
+
+func currentTrustRoots() *x509.CertPool {
+	trustRootsMu.RLock()
+	defer trustRootsMu.RUnlock()
+	return trustRoots
+}
+
 type Signature struct {
 	signature []byte
 }
@@ -57,13 +103,11 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 		return fmt.Errorf("X509 signature has not been initialized")
 	}
 
-	hasher := sha256.New()
-	tee := io.TeeReader(r, hasher)
-	message, err := ioutil.ReadAll(tee)
+	message, err := ioutil.ReadAll(r)
 	if err != nil {
 		return err
 	}
-	hash := hasher.Sum(nil)
+	hash := sha256.Sum256(message)
 
 	key, ok := k.(*PublicKey)
 	if !ok {
@@ -77,14 +121,23 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 
 	switch pub := p.(type) {
 	case *rsa.PublicKey:
-		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash, s.signature)
+		return rsa.VerifyPKCS1v15(pub, crypto.SHA256, hash[:], s.signature)
 	case ed25519.PublicKey:
 		if ed25519.Verify(pub, message, s.signature) {
 			return nil
 		}
 		return errors.New("supplied signature does not match key")
 	case *ecdsa.PublicKey:
-		if ecdsa.VerifyASN1(pub, hash, s.signature) {
+		// signatures are usually made with the hash matching the size of the curve, but SHA-256 was the only
+		// one accepted for every curve before and so remains valid
+		if h := curveHash(pub.Curve); h != crypto.SHA256 {
+			hasher := h.New()
+			_, _ = hasher.Write(message)
+			if ecdsa.VerifyASN1(pub, hasher.Sum(nil), s.signature) {
+				return nil
+			}
+		}
+		if ecdsa.VerifyASN1(pub, hash[:], s.signature) {
 			return nil
 		}
 		return errors.New("supplied signature does not match key")
@@ -93,10 +146,26 @@ func (s Signature) Verify(r io.Reader, k interface{}) error {
 	}
 }
 
-// PublicKey Public Key that follows the x509 standard
+// curveHash returns the hash conventionally used with ECDSA keys on curve, as specified by RFC 5656
+func curveHash(curve elliptic.Curve) crypto.Hash {
+	switch bits := curve.Params().BitSize; {
+	case bits > 384:
+		return crypto.SHA512
+	case bits > 256:
+		return crypto.SHA384
+	default:
+		return crypto.SHA256
+	}
+}
+
+// PublicKey Public Key that follows the x509 standard; a certificate may be followed by the certificates that
+// issued it, in which case the chain is validated and kept with the key
 type PublicKey struct {
-	key  interface{}
-	cert *cert
+	key         interface{}
+	cert        *cert
+	chain       []*cert
+	attestation *PIVAttestation
+	scts        []SCT
 }
 
 type cert struct {
@@ -111,7 +180,7 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 		return nil, err
 	}
 
-	block, _ := pem.Decode(rawPub)
+	block, rest := pem.Decode(rawPub)
 	if block == nil {
 		return nil, fmt.Errorf("invalid public key: %s", string(rawPub))
 	}
@@ -124,46 +193,172 @@ func NewPublicKey(r io.Reader) (*PublicKey, error) {
 		}
 		return &PublicKey{key: key}, nil
 	case "CERTIFICATE":
-		c, err := x509.ParseCertificate(block.Bytes)
-		if err != nil {
-			return nil, err
+		var certs []*cert
+		for block != nil {
+			if block.Type != "CERTIFICATE" {
+				return nil, fmt.Errorf("unexpected PEM block type %q in certificate chain", block.Type)
+			}
+			c, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, &cert{c: c, b: block.Bytes})
+			block, rest = pem.Decode(rest)
 		}
-		return &PublicKey{
-			cert: &cert{
-				c: c,
-				b: block.Bytes,
-			}}, nil
+		return newCertificateKey(certs)
 	}
 	return nil, fmt.Errorf("invalid public key: %s", string(rawPub))
 }
 
-// CanonicalValue implements the pki.PublicKey interface
-func (k PublicKey) CanonicalValue() ([]byte, error) {
+// newCertificateKey validates the chain of a leaf certificate, which is followed by the certificates submitted
+// with it. If trust roots are configured, the chain is built up to one of them at the time the leaf was issued,
+// since short-lived certificates have usually expired by the time anyone checks them; the expiry of the leaf
+// is for verifiers to check against the time the entry was integrated into the log.
+func newCertificateKey(certs []*cert) (*PublicKey, error) {
+	leaf := certs[0]
 
-	var p pem.Block
-	switch {
-	case k.key != nil:
-		b, err := x509.MarshalPKIXPublicKey(k.key)
-		if err != nil {
+	var attestation *PIVAttestation
+	if isPIVAttestation(leaf.c) {
+		var err error
+		if attestation, err = parsePIVAttestation(certs); err != nil {
 			return nil, err
 		}
+	}
 
-		p = pem.Block{
-			Type:  "PUBLIC KEY",
-			Bytes: b,
+	roots := currentTrustRoots()
+	if roots == nil {
+		for i := 0; i+1 < len(certs); i++ {
+			if err := certs[i].c.CheckSignatureFrom(certs[i+1].c); err != nil {
+				return nil, fmt.Errorf("invalid certificate chain: certificate %v is not issued by the certificate that follows it: %w", i, err)
+			}
 		}
-	case k.cert != nil:
-		p = pem.Block{
-			Type:  "CERTIFICATE",
-			Bytes: k.cert.b,
+		return withSCTs(&PublicKey{cert: leaf, chain: certs[1:], attestation: attestation})
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c.c)
+	}
+	chains, err := leaf.c.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   leaf.c.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("certificate is not issued by a trusted root: %w", err)
+	}
+
+	// keep the shortest chain, so the canonical value does not depend on the order certificates were submitted in
+	verified := chains[0]
+	for _, c := range chains[1:] {
+		if len(c) < len(verified) {
+			verified = c
 		}
-	default:
+	}
+	k := &PublicKey{cert: leaf, attestation: attestation}
+	for _, c := range verified[1:] {
+		k.chain = append(k.chain, &cert{c: c, b: c.Raw})
+	}
+	return withSCTs(k)
+}
+
+// withSCTs verifies the SCTs embedded in the certificate of k against the configured CT logs
+func withSCTs(k *PublicKey) (*PublicKey, error) {
+	var issuer *cert
+	if len(k.chain) > 0 {
+		issuer = k.chain[0]
+	}
+	scts, err := verifyEmbeddedSCTs(k.cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+	k.scts = scts
+	return k, nil
+}
+
+// CanonicalValue implements the pki.PublicKey interface; certificates are followed by the rest of their chain,
+// ending with the trust root when the chain was validated against one
+func (k PublicKey) CanonicalValue() ([]byte, error) {
+	if k.key == nil && k.cert == nil {
+		return nil, fmt.Errorf("x509 public key has not been initialized")
+	}
+
+	var buf bytes.Buffer
+	if err := k.encode(&buf); err != nil {
+		return nil, err
+	}
+	for _, c := range k.chain {
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: c.b}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// IndexValue implements the pki.IndexValuer interface, so that entries are found by the signing certificate
+// whether or not its chain is supplied
+func (k PublicKey) IndexValue() ([]byte, error) {
+	if k.key == nil && k.cert == nil {
 		return nil, fmt.Errorf("x509 public key has not been initialized")
 	}
 
 	var buf bytes.Buffer
-	if err := pem.Encode(&buf, &p); err != nil {
+	if err := k.encode(&buf); err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
+
+// Attestation returns the device and slot attested to hold the key, or nil if the certificate is not a PIV
+// attestation certificate
+func (k PublicKey) Attestation() *PIVAttestation {
+	return k.attestation
+}
+
+// SCTs returns the embedded SCTs of the certificate that verified against the configured CT logs
+func (k PublicKey) SCTs() []SCT {
+	return k.scts
+}
+
+// IdentityIndexKeys implements the pki.IdentityKey interface; for certificates, it returns keys for the SAN
+// email addresses (the same keys PGP user IDs are indexed under) and URIs, and for the OIDC issuer recorded by
+// Fulcio, so that entries can be found by who signed them. Each email address and URI is also indexed together
+// with the issuer, so that entries can be found by identity from a given issuer. For PIV attestation certificates, it also returns
+// keys for the serial number of the device and for the slot on that device, so that entries signed with
+// hardware backed keys can be told apart and found.
+func (k PublicKey) IdentityIndexKeys() []string {
+	var result []string
+	for _, email := range k.Emails() {
+		result = append(result, pgp.EmailKey(email))
+	}
+	for _, uri := range k.URIs() {
+		result = append(result, URIKey(uri))
+	}
+	if issuer := k.Issuer(); issuer != "" {
+		result = append(result, IssuerKey(issuer))
+		for _, email := range k.Emails() {
+			result = append(result, IdentityKey(issuer, email))
+		}
+		for _, uri := range k.URIs() {
+			result = append(result, IdentityKey(issuer, uri))
+		}
+	}
+	if k.attestation != nil {
+		serial := strconv.FormatUint(uint64(k.attestation.Serial), 10)
+		result = append(result, PIVKey(serial), PIVKey(serial+"/"+k.attestation.Slot))
+	}
+	return result
+}
+
+// encode writes the key, or the leaf certificate, as a PEM block
+func (k PublicKey) encode(w io.Writer) error {
+	if k.cert != nil {
+		return pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: k.cert.b})
+	}
+	b, err := x509.MarshalPKIXPublicKey(k.key)
+	if err != nil {
+		return err
+	}
+	return pem.Encode(w, &pem.Block{Type: "PUBLIC KEY", Bytes: b})
+}
@@ -21,13 +21,25 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	ct "github.com/google/certificate-transparency-go"
+	cttls "github.com/google/certificate-transparency-go/tls"
+	ctx509 "github.com/google/certificate-transparency-go/x509"
 )
 
 // Generated with:
@@ -207,3 +219,537 @@ func TestSignature_VerifyFail(t *testing.T) {
 		})
 	}
 }
+
+type testCA struct {
+	cert *x509.Certificate
+	priv *ecdsa.PrivateKey
+}
+
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *testCA) *testCA {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, signer := tmpl, priv
+	if parent != nil {
+		issuer, signer = parent.cert, parent.priv
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, priv.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: c, priv: priv}
+}
+
+func newTestChain(t *testing.T, notBefore, notAfter time.Time) (root, intermediate, leaf *testCA) {
+	t.Helper()
+	ca := func(serial int64, name string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: name},
+			NotBefore:             time.Now().Add(-24 * time.Hour),
+			NotAfter:              time.Now().Add(24 * time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+	}
+	root = newTestCert(t, ca(1, "root"), nil)
+	intermediate = newTestCert(t, ca(2, "intermediate"), root)
+	leaf = newTestCert(t, &x509.Certificate{
+		SerialNumber:   big.NewInt(3),
+		Subject:        pkix.Name{CommonName: "leaf"},
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses: []string{"alice@example.com"},
+	}, intermediate)
+	return root, intermediate, leaf
+}
+
+func encodeCerts(certs ...*testCA) []byte {
+	var b []byte
+	for _, c := range certs {
+		b = append(b, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+	}
+	return b
+}
+
+func TestCertificateChain(t *testing.T) {
+	root, intermediate, leaf := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	otherRoot, _, _ := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	// short-lived certificates have usually expired by the time they are logged
+	_, expiredIntermediate, expiredLeaf := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(-time.Minute))
+
+	tests := []struct {
+		caseDesc          string
+		roots             []byte
+		input             []byte
+		expectedCanonical []byte
+		errorFound        bool
+	}{
+		{caseDesc: "leaf only", input: encodeCerts(leaf), expectedCanonical: encodeCerts(leaf)},
+		{caseDesc: "chain without roots", input: encodeCerts(leaf, intermediate), expectedCanonical: encodeCerts(leaf, intermediate)},
+		{caseDesc: "full chain without roots", input: encodeCerts(leaf, intermediate, root), expectedCanonical: encodeCerts(leaf, intermediate, root)},
+		{caseDesc: "chain out of order without roots", input: encodeCerts(intermediate, leaf), errorFound: true},
+		{caseDesc: "unrelated certificate in chain", input: encodeCerts(leaf, otherRoot), errorFound: true},
+		{caseDesc: "chain with non-certificate block", input: append(encodeCerts(leaf), []byte(ecdsaPub)...), errorFound: true},
+		{caseDesc: "chain to trusted root", roots: encodeCerts(root), input: encodeCerts(leaf, intermediate), expectedCanonical: encodeCerts(leaf, intermediate, root)},
+		{caseDesc: "chain including trusted root", roots: encodeCerts(root), input: encodeCerts(leaf, intermediate, root), expectedCanonical: encodeCerts(leaf, intermediate, root)},
+		{caseDesc: "chain to one of several roots", roots: encodeCerts(otherRoot, root), input: encodeCerts(leaf, intermediate), expectedCanonical: encodeCerts(leaf, intermediate, root)},
+		{caseDesc: "leaf missing its intermediate", roots: encodeCerts(root), input: encodeCerts(leaf), errorFound: true},
+		{caseDesc: "chain to untrusted root", roots: encodeCerts(otherRoot), input: encodeCerts(leaf, intermediate), errorFound: true},
+		{caseDesc: "expired leaf", roots: encodeCerts(expiredIntermediate), input: encodeCerts(expiredLeaf), expectedCanonical: encodeCerts(expiredLeaf, expiredIntermediate)},
+		{caseDesc: "public key with trust roots", roots: encodeCerts(root), input: []byte(ecdsaPub), expectedCanonical: []byte(ecdsaPub + "\n")},
+	}
+
+	defer func() {
+		if err := SetTrustRoots(nil); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	for _, tc := range tests {
+		if err := SetTrustRoots(tc.roots); err != nil {
+			t.Fatal(err)
+		}
+		k, err := NewPublicKey(bytes.NewReader(tc.input))
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		canonical, err := k.CanonicalValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(canonical, tc.expectedCanonical) {
+			t.Errorf("%v: unexpected canonical value:\n%s", tc.caseDesc, canonical)
+		}
+		// the canonical value must be accepted again as is
+		if _, err := NewPublicKey(bytes.NewReader(canonical)); err != nil {
+			t.Errorf("%v: canonical value rejected: %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestCertificateChainVerify(t *testing.T) {
+	_, intermediate, leaf := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	k, err := NewPublicKey(bytes.NewReader(encodeCerts(leaf, intermediate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hey! this is my test data")
+	h := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, leaf.priv, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSignature(bytes.NewReader(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify(bytes.NewReader(data), k); err != nil {
+		t.Errorf("unexpected error verifying with the leaf of a chain: %v", err)
+	}
+
+	// entries are indexed by the leaf, whether or not the chain is supplied
+	iv, err := k.IndexValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(iv, encodeCerts(leaf)) {
+		t.Errorf("unexpected index value:\n%s", iv)
+	}
+}
+
+func TestSetTrustRoots(t *testing.T) {
+	defer func() {
+		if err := SetTrustRoots(nil); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	for _, input := range []string{"not pem", ecdsaPub, "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"} {
+		if err := SetTrustRoots([]byte(input)); err == nil {
+			t.Errorf("expected error for trust roots %q", input)
+		}
+	}
+	if err := SetTrustRoots([]byte("\n")); err != nil || currentTrustRoots() != nil {
+		t.Errorf("expected empty trust roots to disable validation: %v", err)
+	}
+}
+
+func TestSignature_VerifyCurves(t *testing.T) {
+	data := []byte("hey! this is my test data")
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			der, err := x509.MarshalPKIXPublicKey(priv.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+			pub, err := NewPublicKey(bytes.NewReader(pubPEM))
+			if err != nil {
+				t.Fatal(err)
+			}
+			canonical, err := pub.CanonicalValue()
+			if err != nil || !bytes.Equal(canonical, pubPEM) {
+				t.Errorf("unexpected canonical value: %v", err)
+			}
+
+			// signed both with the hash matching the curve and with SHA-256
+			for _, h := range []crypto.Hash{curveHash(curve), crypto.SHA256} {
+				hasher := h.New()
+				hasher.Write(data)
+				sigBytes, err := ecdsa.SignASN1(rand.Reader, priv, hasher.Sum(nil))
+				if err != nil {
+					t.Fatal(err)
+				}
+				s, err := NewSignature(bytes.NewReader(sigBytes))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s.Verify(bytes.NewReader(data), pub); err != nil {
+					t.Errorf("unexpected error verifying signature made with %v: %v", h, err)
+				}
+				if err := s.Verify(strings.NewReader("tampered"), pub); err == nil {
+					t.Errorf("expected error verifying signature made with %v over a different message", h)
+				}
+			}
+		})
+	}
+}
+
+func newTestAttestation(t *testing.T, subject string, extensions []pkix.Extension) (device, attestation *testCA) {
+	t.Helper()
+	_, device, _ = newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	attestation = newTestCert(t, &x509.Certificate{
+		SerialNumber:    big.NewInt(4),
+		Subject:         pkix.Name{CommonName: subject},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: extensions,
+	}, device)
+	return device, attestation
+}
+
+func TestPIVAttestation(t *testing.T) {
+	serial := func(n int64) pkix.Extension {
+		b, err := asn1.Marshal(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkix.Extension{Id: oidYubicoSerial, Value: b}
+	}
+	firmware := pkix.Extension{Id: oidYubicoFirmware, Value: []byte{5, 2, 7}}
+	policy := pkix.Extension{Id: oidYubicoPolicy, Value: []byte{1, 1}}
+
+	device, attestation := newTestAttestation(t, "YubiKey PIV Attestation 9c", []pkix.Extension{firmware, serial(12345678), policy})
+	k, err := NewPublicKey(bytes.NewReader(encodeCerts(attestation, device)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := PIVAttestation{Serial: 12345678, Slot: "9c", Firmware: "5.2.7"}
+	if a := k.Attestation(); a == nil || *a != expected {
+		t.Errorf("unexpected attestation %+v", a)
+	}
+	if keys := k.IdentityIndexKeys(); !reflect.DeepEqual(keys, []string{"piv:12345678", "piv:12345678/9c"}) {
+		t.Errorf("unexpected identity index keys %v", keys)
+	}
+	if PIVKey("12345678/9C") != "piv:12345678/9c" {
+		t.Error("expected PIV index keys to be case insensitive")
+	}
+
+	// certificates that are not attestations carry no device identities
+	_, intermediate, leaf := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	k, err = NewPublicKey(bytes.NewReader(encodeCerts(leaf, intermediate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.Attestation() != nil || !reflect.DeepEqual(k.IdentityIndexKeys(), []string{"email:alice@example.com"}) {
+		t.Error("unexpected attestation for a certificate that is not a PIV attestation")
+	}
+
+	tests := []struct {
+		caseDesc   string
+		subject    string
+		extensions []pkix.Extension
+	}{
+		{caseDesc: "missing serial", subject: "YubiKey PIV Attestation 9c", extensions: []pkix.Extension{firmware}},
+		{caseDesc: "negative serial", subject: "YubiKey PIV Attestation 9c", extensions: []pkix.Extension{serial(-1)}},
+		{caseDesc: "serial out of range", subject: "YubiKey PIV Attestation 9c", extensions: []pkix.Extension{serial(1 << 40)}},
+		{caseDesc: "malformed serial", subject: "YubiKey PIV Attestation 9c", extensions: []pkix.Extension{{Id: oidYubicoSerial, Value: []byte{1, 2}}}},
+		{caseDesc: "malformed firmware", subject: "YubiKey PIV Attestation 9c", extensions: []pkix.Extension{serial(1), {Id: oidYubicoFirmware, Value: []byte{5}}}},
+		{caseDesc: "malformed policy", subject: "YubiKey PIV Attestation 9c", extensions: []pkix.Extension{serial(1), {Id: oidYubicoPolicy, Value: []byte{1}}}},
+		{caseDesc: "missing slot", subject: "attestation", extensions: []pkix.Extension{serial(1)}},
+		{caseDesc: "invalid slot", subject: "YubiKey PIV Attestation f9", extensions: []pkix.Extension{serial(1)}},
+	}
+	for _, tc := range tests {
+		device, attestation := newTestAttestation(t, tc.subject, tc.extensions)
+		if _, err := NewPublicKey(bytes.NewReader(encodeCerts(attestation, device))); err == nil {
+			t.Errorf("%v: expected error", tc.caseDesc)
+		}
+	}
+
+	// without the attestation certificate of the device, nothing ties the claims to a YubiKey
+	_, attestation = newTestAttestation(t, "YubiKey PIV Attestation 9a", []pkix.Extension{serial(1)})
+	if _, err := NewPublicKey(bytes.NewReader(encodeCerts(attestation))); err == nil {
+		t.Error("expected error for attestation certificate without its device certificate")
+	}
+}
+
+func TestCertificateIdentities(t *testing.T) {
+	_, intermediate, _ := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	workflow, err := url.Parse("https://github.com/example/repo/.github/workflows/release.yml@refs/heads/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf := newTestCert(t, &x509.Certificate{
+		SerialNumber:    big.NewInt(5),
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		EmailAddresses:  []string{"Alice@Example.com", "alice@example.com"},
+		URIs:            []*url.URL{workflow},
+		ExtraExtensions: []pkix.Extension{{Id: oidFulcioIssuer, Value: []byte("https://token.actions.githubusercontent.com")}},
+	}, intermediate)
+
+	k, err := NewPublicKey(bytes.NewReader(encodeCerts(leaf, intermediate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if emails := k.Emails(); !reflect.DeepEqual(emails, []string{"alice@example.com"}) {
+		t.Errorf("unexpected emails %v", emails)
+	}
+	if issuer := k.Issuer(); issuer != "https://token.actions.githubusercontent.com" {
+		t.Errorf("unexpected issuer %q", issuer)
+	}
+	expected := []string{
+		"email:alice@example.com",
+		"uri:" + workflow.String(),
+		"oidc-issuer:https://token.actions.githubusercontent.com",
+		"cert-identity:https://token.actions.githubusercontent.com alice@example.com",
+		"cert-identity:https://token.actions.githubusercontent.com " + workflow.String(),
+	}
+	if keys := k.IdentityIndexKeys(); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("unexpected identity index keys %v", keys)
+	}
+	if IssuerKey("https://token.actions.githubusercontent.com/") != expected[2] {
+		t.Error("expected trailing slash of issuer to be ignored")
+	}
+	if IdentityKey("https://token.actions.githubusercontent.com/", "Alice@Example.com") != expected[3] {
+		t.Error("expected email address of identity to be matched regardless of case")
+	}
+
+	// bare keys carry no identities
+	k, err = NewPublicKey(strings.NewReader(ecdsaPub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys := k.IdentityIndexKeys(); keys != nil {
+		t.Errorf("unexpected identity index keys for bare key %v", keys)
+	}
+}
+
+// newTestSCTCert issues a leaf certificate with an SCT from logKey embedded in it, the way a CA does after
+// logging the precertificate; the SCT is signed with signer, which is normally logKey
+func newTestSCTCert(t *testing.T, issuer *testCA, logKey, signer *ecdsa.PrivateKey) *testCA {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(6),
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		EmailAddresses: []string{"alice@example.com"},
+	}
+	create := func() *x509.Certificate {
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer.cert, priv.Public(), issuer.priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	// the log signs the certificate as it is without the SCT extension, which is how it is rebuilt from the
+	// certificate carrying the SCT
+	sctExtension := func(value []byte) []pkix.Extension {
+		ext, err := asn1.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []pkix.Extension{{Id: asn1.ObjectIdentifier(ctx509.OIDExtensionCTSCT), Value: ext}}
+	}
+	tmpl.ExtraExtensions = sctExtension(nil)
+	precert, err := ctx509.ParseCertificate(create().Raw)
+	if ctx509.IsFatal(err) {
+		t.Fatal(err)
+	}
+	ctIssuer, err := ctx509.ParseCertificate(issuer.cert.Raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sct := ct.SignedCertificateTimestamp{SCTVersion: ct.V1, Timestamp: uint64(time.Now().Unix() * 1000)}
+	logDER, err := x509.MarshalPKIXPublicKey(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sct.LogID.KeyID = sha256.Sum256(logDER)
+	mtl, err := ct.MerkleTreeLeafForEmbeddedSCT([]*ctx509.Certificate{precert, ctIssuer}, sct.Timestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := ct.SerializeSCTSignatureInput(sct, ct.LogEntry{Leaf: *mtl})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha256.Sum256(input)
+	sig, err := ecdsa.SignASN1(rand.Reader, signer, h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sct.Signature = ct.DigitallySigned{
+		Algorithm: cttls.SignatureAndHashAlgorithm{Hash: cttls.SHA256, Signature: cttls.ECDSA},
+		Signature: sig,
+	}
+
+	serialized, err := cttls.Marshal(sct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := cttls.Marshal(ctx509.SignedCertificateTimestampList{SCTList: []ctx509.SerializedSCT{{Val: serialized}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.ExtraExtensions = sctExtension(list)
+	return &testCA{cert: create(), priv: priv}
+}
+
+func TestEmbeddedSCTs(t *testing.T) {
+	defer func() {
+		if err := SetCTLogKeys(nil); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	logKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherLogKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodeKeys := func(keys ...*ecdsa.PrivateKey) []byte {
+		var b []byte
+		for _, k := range keys {
+			der, err := x509.MarshalPKIXPublicKey(k.Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			b = append(b, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...)
+		}
+		return b
+	}
+
+	_, intermediate, plainLeaf := newTestChain(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	leaf := newTestSCTCert(t, intermediate, logKey, logKey)
+	forged := newTestSCTCert(t, intermediate, logKey, otherLogKey)
+
+	tests := []struct {
+		caseDesc   string
+		logKeys    []byte
+		input      []byte
+		expectSCTs int
+		errorFound bool
+	}{
+		{caseDesc: "no CT logs configured", input: encodeCerts(leaf, intermediate)},
+		{caseDesc: "SCT from configured log", logKeys: encodeKeys(logKey), input: encodeCerts(leaf, intermediate), expectSCTs: 1},
+		{caseDesc: "SCT from one of several logs", logKeys: encodeKeys(otherLogKey, logKey), input: encodeCerts(leaf, intermediate), expectSCTs: 1},
+		{caseDesc: "SCT only from unknown log", logKeys: encodeKeys(otherLogKey), input: encodeCerts(leaf, intermediate), errorFound: true},
+		{caseDesc: "SCT without issuer", logKeys: encodeKeys(logKey), input: encodeCerts(leaf), errorFound: true},
+		{caseDesc: "SCT not signed by the log it names", logKeys: encodeKeys(logKey), input: encodeCerts(forged, intermediate), errorFound: true},
+		{caseDesc: "certificate without SCTs", logKeys: encodeKeys(logKey), input: encodeCerts(plainLeaf, intermediate)},
+	}
+	for _, tc := range tests {
+		if err := SetCTLogKeys(tc.logKeys); err != nil {
+			t.Fatal(err)
+		}
+		k, err := NewPublicKey(bytes.NewReader(tc.input))
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+			continue
+		}
+		if err != nil {
+			var se *SCTError
+			if !errors.As(err, &se) {
+				t.Errorf("%v: expected SCTError, got %v", tc.caseDesc, err)
+			}
+			continue
+		}
+		if len(k.SCTs()) != tc.expectSCTs {
+			t.Errorf("%v: unexpected SCTs %v", tc.caseDesc, k.SCTs())
+		}
+	}
+
+	if err := SetCTLogKeys([]byte(pkcs1v15Priv)); err == nil {
+		t.Error("expected error for CT log keys that are not public keys")
+	}
+}
+
+func TestValidAt(t *testing.T) {
+	issued := time.Now().Add(-time.Hour).Truncate(time.Second)
+	_, intermediate, leaf := newTestChain(t, issued, issued.Add(10*time.Minute))
+
+	k, err := NewPublicKey(bytes.NewReader(encodeCerts(leaf, intermediate)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		caseDesc   string
+		at         time.Time
+		errorFound bool
+	}{
+		{caseDesc: "while the leaf is valid", at: issued.Add(5 * time.Minute)},
+		{caseDesc: "at the start of the validity period", at: issued},
+		{caseDesc: "before the leaf was issued", at: issued.Add(-time.Second), errorFound: true},
+		{caseDesc: "after the leaf expired", at: issued.Add(11 * time.Minute), errorFound: true},
+		{caseDesc: "after the intermediate expired", at: time.Now().Add(48 * time.Hour), errorFound: true},
+	}
+	for _, tc := range tests {
+		err := k.ValidAt(tc.at)
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result: %v", tc.caseDesc, err)
+		}
+		var ve *ValidityError
+		if err != nil && !errors.As(err, &ve) {
+			t.Errorf("%v: expected a ValidityError, got %v", tc.caseDesc, err)
+		}
+	}
+
+	// bare keys are valid at any time
+	bare, err := NewPublicKey(strings.NewReader(ecdsaPub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bare.ValidAt(time.Time{}); err != nil {
+		t.Errorf("unexpected error for bare key: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/spf13/viper"
+
+	"github.com/sigstore/rekor/pkg/log"
+)
+
+func init() {
+	RegisterBlobStore("azure", newAzureBlobStore)
+}
+
+const (
+	// azureStorageResource is the resource managed identity tokens are requested for
+	azureStorageResource = "https://storage.azure.com/"
+	// azureTokenRefreshMargin is how long before a token expires it is refreshed, and how long a failed refresh
+	// waits before it is retried
+	azureTokenRefreshMargin = 5 * time.Minute
+	// azureDownloadRetries bounds the attempts at resuming a download whose connection broke
+	azureDownloadRetries = 3
+)
+
+// AzureBlobStore keeps each blob in a block blob of an Azure Storage container named after its digest
+type AzureBlobStore struct {
+	container azblob.ContainerURL
+	prefix    string
+}
+
+// newAzureBlobStore authenticates with a SAS token if one is configured, and with the managed identity of the host
+// otherwise
+func newAzureBlobStore(ctx context.Context) (BlobStore, error) {
+	containerURL := viper.GetString("attestation_storage.azure.container_url")
+	if containerURL == "" {
+		return nil, errors.New("attestation_storage.azure.container_url must be set for the azure blob store")
+	}
+	u, err := url.Parse(containerURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container URL: %w", err)
+	}
+	var credential azblob.Credential
+	if sas := viper.GetString("attestation_storage.azure.sas_token"); sas != "" {
+		u.RawQuery = strings.TrimPrefix(sas, "?")
+		credential = azblob.NewAnonymousCredential()
+	} else if credential, err = managedIdentityCredential(viper.GetString("attestation_storage.azure.managed_identity_client_id")); err != nil {
+		return nil, err
+	}
+	return NewAzureBlobStore(azblob.NewContainerURL(*u, azblob.NewPipeline(credential, azblob.PipelineOptions{})), viper.GetString("attestation_storage.azure.prefix")), nil
+}
+
+// managedIdentityCredential returns a credential whose token is obtained from the instance metadata service for the
+// system assigned identity of the host, or for the user assigned identity with clientID if it is not empty, and
+// refreshed before it expires
+func managedIdentityCredential(clientID string) (azblob.Credential, error) {
+	endpoint, err := adal.GetMSIVMEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	var spt *adal.ServicePrincipalToken
+	if clientID != "" {
+		spt, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(endpoint, azureStorageResource, clientID)
+	} else {
+		spt, err = adal.NewServicePrincipalTokenFromMSI(endpoint, azureStorageResource)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := spt.Refresh(); err != nil {
+		return nil, fmt.Errorf("obtaining managed identity token: %w", err)
+	}
+	return azblob.NewTokenCredential(spt.Token().AccessToken, func(c azblob.TokenCredential) time.Duration {
+		if err := spt.Refresh(); err != nil {
+			log.Logger.Errorw("failed to refresh managed identity token for attestation storage", "error", err)
+			return azureTokenRefreshMargin
+		}
+		c.SetToken(spt.Token().AccessToken)
+		return time.Until(spt.Token().Expires()) - azureTokenRefreshMargin
+	}), nil
+}
+
+// NewAzureBlobStore returns a blob store keeping blobs in container, under blob names starting with prefix
+func NewAzureBlobStore(container azblob.ContainerURL, prefix string) *AzureBlobStore {
+	return &AzureBlobStore{container: container, prefix: prefix}
+}
+
+// Put only creates blobs that do not exist yet, as a blob named after a digest already holds the content
+func (a *AzureBlobStore) Put(ctx context.Context, content []byte) (string, error) {
+	digest := BlobDigest(content)
+	return digest, a.PutReader(ctx, digest, bytes.NewReader(content), int64(len(content)))
+}
+
+// PutReader uploads content unless the blob exists, conditionally on it not existing in case it is created after
+// being looked for
+func (a *AzureBlobStore) PutReader(ctx context.Context, digest string, content io.ReadSeeker, size int64) error {
+	if stored, err := a.Has(ctx, digest); err != nil || stored {
+		return err
+	}
+	_, err := a.container.NewBlockBlobURL(a.prefix+digest).Upload(ctx, content,
+		azblob.BlobHTTPHeaders{ContentType: "application/octet-stream"}, azblob.Metadata{},
+		azblob.BlobAccessConditions{ModifiedAccessConditions: azblob.ModifiedAccessConditions{IfNoneMatch: azblob.ETagAny}},
+		azblob.DefaultAccessTier, nil, azblob.ClientProvidedKeyOptions{})
+	var serr azblob.StorageError
+	if errors.As(err, &serr) && serr.ServiceCode() == azblob.ServiceCodeBlobAlreadyExists {
+		return nil
+	}
+	return err
+}
+
+func (a *AzureBlobStore) Get(ctx context.Context, digest string) ([]byte, error) {
+	content, err := a.GetRaw(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBlob(digest, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (a *AzureBlobStore) GetRaw(ctx context.Context, digest string) ([]byte, error) {
+	r, err := a.GetReader(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func (a *AzureBlobStore) GetReader(ctx context.Context, digest string) (io.ReadCloser, error) {
+	if err := validBlobDigest(digest); err != nil {
+		return nil, err
+	}
+	resp, err := a.container.NewBlockBlobURL(a.prefix+digest).Download(ctx, 0, azblob.CountToEnd, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	var serr azblob.StorageError
+	if errors.As(err, &serr) && serr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: azureDownloadRetries}), nil
+}
+
+func (a *AzureBlobStore) Has(ctx context.Context, digest string) (bool, error) {
+	if err := validBlobDigest(digest); err != nil {
+		return false, err
+	}
+	_, err := a.container.NewBlockBlobURL(a.prefix+digest).GetProperties(ctx, azblob.BlobAccessConditions{}, azblob.ClientProvidedKeyOptions{})
+	var serr azblob.StorageError
+	if errors.As(err, &serr) && serr.ServiceCode() == azblob.ServiceCodeBlobNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,131 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/spf13/viper"
+)
+
+// fakeAzure serves the uploads and the downloads of the block blobs of a single container
+type fakeAzure struct {
+	mu      sync.Mutex
+	blobs   map[string][]byte
+	uploads int
+	query   url.Values
+}
+
+func (f *fakeAzure) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.query = r.URL.Query()
+	name := strings.TrimPrefix(r.URL.Path, "/container/")
+	content, ok := f.blobs[name]
+	switch r.Method {
+	case http.MethodPut:
+		f.uploads++
+		if ok && r.Header.Get("If-None-Match") == "*" {
+			w.Header().Set("x-ms-error-code", string(azblob.ServiceCodeBlobAlreadyExists))
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.blobs[name], _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodHead, http.MethodGet:
+		if !ok {
+			w.Header().Set("x-ms-error-code", string(azblob.ServiceCodeBlobNotFound))
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestAzureBlobStore(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeAzure{blobs: map[string][]byte{}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL + "/container")
+	a := NewAzureBlobStore(azblob.NewContainerURL(*u, azblob.NewPipeline(azblob.NewAnonymousCredential(), azblob.PipelineOptions{})), "attestations/")
+
+	digest, err := a.Put(ctx, []byte("envelope"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.blobs["attestations/"+digest]; !ok || digest != BlobDigest([]byte("envelope")) {
+		t.Errorf("blob not stored under its digest %v: %v", digest, f.blobs)
+	}
+	// storing the same content again finds the blob already there
+	if again, err := a.Put(ctx, []byte("envelope")); err != nil || again != digest || f.uploads != 1 {
+		t.Errorf("second Put() = %v, %v after %v uploads", again, err, f.uploads)
+	}
+	if stored, err := a.Has(ctx, BlobDigest([]byte("missing"))); err != nil || stored {
+		t.Errorf("Has() = %v, %v for a missing blob", stored, err)
+	}
+	if got, err := a.Get(ctx, digest); err != nil || string(got) != "envelope" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	if _, err := a.Get(ctx, BlobDigest([]byte("missing"))); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound, got %v", err)
+	}
+	f.blobs["attestations/"+digest] = []byte("altered")
+	if _, err := a.Get(ctx, digest); err == nil {
+		t.Error("expected error for content that does not match its digest")
+	}
+}
+
+func TestAzureBlobStoreSAS(t *testing.T) {
+	f := &fakeAzure{blobs: map[string][]byte{}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	viper.Set("attestation_storage.azure.container_url", srv.URL+"/container")
+	defer viper.Set("attestation_storage.azure.container_url", "")
+	viper.Set("attestation_storage.azure.sas_token", "?sv=2019-12-12&sig=signature")
+	defer viper.Set("attestation_storage.azure.sas_token", "")
+
+	a, err := NewBlobStore(context.Background(), "azure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Put(context.Background(), []byte("envelope")); err != nil {
+		t.Fatal(err)
+	}
+	if f.query.Get("sig") != "signature" || f.query.Get("sv") != "2019-12-12" {
+		t.Errorf("SAS token not sent with request: %v", f.query)
+	}
+
+	viper.Set("attestation_storage.azure.container_url", "")
+	if _, err := NewBlobStore(context.Background(), "azure"); err == nil {
+		t.Error("expected error without a container URL")
+	}
+}
@@ -0,0 +1,436 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"cloud.google.com/go/bigtable"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterIndexBackend("bigtable", newBigtableBackend)
+}
+
+// Rows of the index table start with the index key they belong to, so that the rows of hashes and email
+// addresses, the bulk of the index, spread evenly over the key space, and everything stored under a key can be
+// read with a single scan. A NUL byte, which index keys never contain, separates the key from a tag naming the
+// structure the row belongs to:
+//
+//   <key> NUL l <inverted position>         one row per value of a list; inverting the position counted from
+//                                           the tail makes a scan return the newest values first
+//   <key> NUL n                             the number of values ever written to the list
+//   <key> NUL z <score> NUL <member>        one row per member of a sorted set, in the order of scores
+//   <key> NUL s <member>                    the current score of a member of a sorted set
+//   <key> NUL c                             one column of the counters family per counter
+//   <key> NUL v                             a single value
+//
+// Every row but those of counters keeps its cell in the index family. The table must have both families,
+// each keeping a single version, e.g. created with `cbt createtable rekor-index families=i:maxversions=1,c:maxversions=1`.
+const (
+	bigtableIndexFamily    = "i"
+	bigtableCounterFamily  = "c"
+	bigtableValueColumn    = "v"
+	bigtableKeySeparator   = "\x00"
+	bigtableMaxBulkMutates = 100000
+)
+
+// BigtableBackend keeps the index in a Cloud Bigtable table, which scales out beyond the memory of a single
+// Redis server
+type BigtableBackend struct {
+	table *bigtable.Table
+}
+
+func newBigtableBackend(ctx context.Context) (IndexBackend, error) {
+	client, err := bigtable.NewClient(ctx, viper.GetString("index.bigtable.project"), viper.GetString("index.bigtable.instance"))
+	if err != nil {
+		return nil, err
+	}
+	return NewBigtableBackend(client.Open(viper.GetString("index.bigtable.table"))), nil
+}
+
+// NewBigtableBackend returns a backend storing the index in table
+func NewBigtableBackend(table *bigtable.Table) *BigtableBackend {
+	return &BigtableBackend{table: table}
+}
+
+func bigtableRow(key, tag string) string {
+	return key + bigtableKeySeparator + tag
+}
+
+func listRow(key string, position int64) string {
+	return bigtableRow(key, "l"+sortableInt(math.MaxInt64-position))
+}
+
+func scoreRow(key string, score int64, member string) string {
+	return bigtableRow(key, "z"+sortableInt(score)+bigtableKeySeparator+member)
+}
+
+func encodeInt(n int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(n))
+	return b
+}
+
+func decodeInt(b []byte) (int64, error) {
+	if len(b) != 8 {
+		return 0, fmt.Errorf("expected 8 byte integer, got %d bytes", len(b))
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+// cell returns the value of the column of family in row, or nil if the row has none
+func cell(row bigtable.Row, family, column string) []byte {
+	for _, item := range row[family] {
+		if item.Column == family+":"+column {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+var latestOnly = bigtable.RowFilter(bigtable.LatestNFilter(1))
+
+func (b *BigtableBackend) Write(ctx context.Context, key, value string) error {
+	return b.WriteBatch(ctx, []IndexWrite{{Key: key, Value: value}})
+}
+
+// WriteBatch reserves positions in each list written to with one increment per list, then applies all of
+// the writes in bulk
+func (b *BigtableBackend) WriteBatch(ctx context.Context, writes []IndexWrite) error {
+	counts := map[string]int64{}
+	var keys []string
+	for _, w := range writes {
+		if counts[w.Key] == 0 {
+			keys = append(keys, w.Key)
+		}
+		counts[w.Key]++
+	}
+	// next holds the position of the next value written to each list
+	next := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		rmw := bigtable.NewReadModifyWrite()
+		rmw.Increment(bigtableIndexFamily, "n", counts[key])
+		row, err := b.table.ApplyReadModifyWrite(ctx, bigtableRow(key, "n"), rmw)
+		if err != nil {
+			return err
+		}
+		length, err := decodeInt(cell(row, bigtableIndexFamily, "n"))
+		if err != nil {
+			return err
+		}
+		next[key] = length - counts[key] + 1
+	}
+
+	rows := make([]string, 0, len(writes))
+	muts := make([]*bigtable.Mutation, 0, len(writes))
+	for _, w := range writes {
+		m := bigtable.NewMutation()
+		m.Set(bigtableIndexFamily, bigtableValueColumn, bigtable.ServerTime, []byte(w.Value))
+		rows = append(rows, listRow(w.Key, next[w.Key]))
+		muts = append(muts, m)
+		next[w.Key]++
+	}
+	for len(rows) > 0 {
+		n := len(rows)
+		if n > bigtableMaxBulkMutates {
+			n = bigtableMaxBulkMutates
+		}
+		errs, err := b.table.ApplyBulk(ctx, rows[:n], muts[:n])
+		if err != nil {
+			return err
+		}
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		rows, muts = rows[n:], muts[n:]
+	}
+	return nil
+}
+
+func (b *BigtableBackend) Lookup(ctx context.Context, key string) ([]string, error) {
+	lists, err := b.LookupBatch(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	return lists[0], nil
+}
+
+// LookupBatch reads the lists under all of keys with a single scan
+func (b *BigtableBackend) LookupBatch(ctx context.Context, keys []string) ([][]string, error) {
+	// an empty list of ranges would scan the whole table
+	if len(keys) == 0 {
+		return [][]string{}, nil
+	}
+	ranges := make(bigtable.RowRangeList, 0, len(keys))
+	values := make(map[string][]string, len(keys))
+	for _, key := range keys {
+		if _, ok := values[key]; !ok {
+			ranges = append(ranges, bigtable.PrefixRange(bigtableRow(key, "l")))
+			values[key] = []string{}
+		}
+	}
+	err := b.table.ReadRows(ctx, ranges, func(row bigtable.Row) bool {
+		key := row.Key()[:strings.Index(row.Key(), bigtableKeySeparator)]
+		values[key] = append(values[key], string(cell(row, bigtableIndexFamily, bigtableValueColumn)))
+		return true
+	}, latestOnly)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]string, len(keys))
+	for i, key := range keys {
+		result[i] = values[key]
+	}
+	return result, nil
+}
+
+func (b *BigtableBackend) Length(ctx context.Context, key string) (int64, error) {
+	row, err := b.table.ReadRow(ctx, bigtableRow(key, "n"), latestOnly)
+	if err != nil {
+		return 0, err
+	}
+	value := cell(row, bigtableIndexFamily, "n")
+	if value == nil {
+		return 0, nil
+	}
+	return decodeInt(value)
+}
+
+func (b *BigtableBackend) RangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error) {
+	result := []string{}
+	if from < to {
+		return result, nil
+	}
+	// rows of higher positions come first, and the end of a range is exclusive
+	rowRange := bigtable.NewRange(listRow(key, from), listRow(key, to)+bigtableKeySeparator)
+	err := b.table.ReadRows(ctx, rowRange, func(row bigtable.Row) bool {
+		result = append(result, string(cell(row, bigtableIndexFamily, bigtableValueColumn)))
+		return true
+	}, latestOnly)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WriteScore deletes the row ordering member under its previous score, if it had one
+func (b *BigtableBackend) WriteScore(ctx context.Context, key, member string, score int64) error {
+	memberRow := bigtableRow(key, "s"+member)
+	row, err := b.table.ReadRow(ctx, memberRow, latestOnly)
+	if err != nil {
+		return err
+	}
+	if value := cell(row, bigtableIndexFamily, "n"); value != nil {
+		previous, err := decodeInt(value)
+		if err != nil {
+			return err
+		}
+		if previous == score {
+			return nil
+		}
+		m := bigtable.NewMutation()
+		m.DeleteRow()
+		if err := b.table.Apply(ctx, scoreRow(key, previous, member), m); err != nil {
+			return err
+		}
+	}
+	set := bigtable.NewMutation()
+	set.Set(bigtableIndexFamily, bigtableValueColumn, bigtable.ServerTime, []byte(member))
+	if err := b.table.Apply(ctx, scoreRow(key, score, member), set); err != nil {
+		return err
+	}
+	record := bigtable.NewMutation()
+	record.Set(bigtableIndexFamily, "n", bigtable.ServerTime, encodeInt(score))
+	return b.table.Apply(ctx, memberRow, record)
+}
+
+// RangeByScore reads the skipped members too, as Bigtable scans cannot start at an offset
+func (b *BigtableBackend) RangeByScore(ctx context.Context, key string, min, max, offset, limit int64) ([]string, error) {
+	result := []string{}
+	if min > max || limit <= 0 {
+		return result, nil
+	}
+	// '\x01' sorts right after the separator, so the end of the range follows every member scored max
+	rowRange := bigtable.NewRange(bigtableRow(key, "z"+sortableInt(min)), bigtableRow(key, "z"+sortableInt(max)+"\x01"))
+	var skipped int64
+	err := b.table.ReadRows(ctx, rowRange, func(row bigtable.Row) bool {
+		if skipped < offset {
+			skipped++
+			return true
+		}
+		result = append(result, string(cell(row, bigtableIndexFamily, bigtableValueColumn)))
+		return true
+	}, latestOnly, bigtable.LimitRows(offset+limit))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *BigtableBackend) RangeByPrefix(ctx context.Context, key, prefix string, limit int64) ([]string, error) {
+	result := []string{}
+	if limit <= 0 {
+		return result, nil
+	}
+	err := b.table.ReadRows(ctx, bigtable.PrefixRange(scoreRow(key, 0, prefix)), func(row bigtable.Row) bool {
+		result = append(result, string(cell(row, bigtableIndexFamily, bigtableValueColumn)))
+		return true
+	}, latestOnly, bigtable.LimitRows(limit))
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *BigtableBackend) Increment(ctx context.Context, key, field string, n int64) error {
+	rmw := bigtable.NewReadModifyWrite()
+	rmw.Increment(bigtableCounterFamily, field, n)
+	_, err := b.table.ApplyReadModifyWrite(ctx, bigtableRow(key, "c"), rmw)
+	return err
+}
+
+func (b *BigtableBackend) Counters(ctx context.Context, key string) (map[string]int64, error) {
+	row, err := b.table.ReadRow(ctx, bigtableRow(key, "c"), latestOnly)
+	if err != nil {
+		return nil, err
+	}
+	return b.counters(row)
+}
+
+// counters decodes the cells of the counters family of row
+func (b *BigtableBackend) counters(row bigtable.Row) (map[string]int64, error) {
+	result := make(map[string]int64, len(row[bigtableCounterFamily]))
+	for _, item := range row[bigtableCounterFamily] {
+		n, err := decodeInt(item.Value)
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimPrefix(item.Column, bigtableCounterFamily+":")] = n
+	}
+	return result, nil
+}
+
+// SetIfAbsent sets the value only if the row has no cell, in a single conditional mutation
+func (b *BigtableBackend) SetIfAbsent(ctx context.Context, key, value string) error {
+	set := bigtable.NewMutation()
+	set.Set(bigtableIndexFamily, bigtableValueColumn, bigtable.ServerTime, []byte(value))
+	return b.table.Apply(ctx, bigtableRow(key, "v"), bigtable.NewCondMutation(bigtable.PassAllFilter(), nil, set))
+}
+
+func (b *BigtableBackend) Get(ctx context.Context, key string) (string, error) {
+	row, err := b.table.ReadRow(ctx, bigtableRow(key, "v"), latestOnly)
+	if err != nil {
+		return "", err
+	}
+	return string(cell(row, bigtableIndexFamily, bigtableValueColumn)), nil
+}
+
+// Scan reads the whole table in order, in which the rows of each structure are contiguous
+func (b *BigtableBackend) Scan(ctx context.Context, fn func(IndexRecord) error) error {
+	var pending *IndexRecord
+	flush := func() error {
+		if pending == nil {
+			return nil
+		}
+		r := *pending
+		pending = nil
+		if r.Type == RecordList {
+			// the rows of a list are read newest first
+			for i, j := 0, len(r.Values)-1; i < j; i, j = i+1, j-1 {
+				r.Values[i], r.Values[j] = r.Values[j], r.Values[i]
+			}
+		}
+		return fn(r)
+	}
+	var scanErr error
+	err := b.table.ReadRows(ctx, bigtable.InfiniteRange(""), func(row bigtable.Row) bool {
+		sep := strings.Index(row.Key(), bigtableKeySeparator)
+		if sep < 0 {
+			return true
+		}
+		key, tag := row.Key()[:sep], row.Key()[sep+1:]
+		if tag == "" {
+			return true
+		}
+		var recordType string
+		switch tag[0] {
+		case 'l':
+			recordType = RecordList
+		case 'z':
+			recordType = RecordScores
+		case 'c':
+			recordType = RecordCounters
+		case 'v':
+			recordType = RecordValue
+		default:
+			// list lengths and the scores of members are read from the rows of lists and sorted sets
+			return true
+		}
+		if pending != nil && (pending.Key != key || pending.Type != recordType || len(pending.Scores) >= scanChunkSize) {
+			if scanErr = flush(); scanErr != nil {
+				return false
+			}
+		}
+		if pending == nil {
+			pending = &IndexRecord{Type: recordType, Key: key}
+		}
+		switch recordType {
+		case RecordList:
+			pending.Values = append(pending.Values, string(cell(row, bigtableIndexFamily, bigtableValueColumn)))
+		case RecordScores:
+			end := strings.Index(tag[1:], bigtableKeySeparator)
+			if end < 0 {
+				scanErr = fmt.Errorf("malformed sorted set row %q", row.Key())
+				return false
+			}
+			score, err := parseSortableInt(tag[1 : 1+end])
+			if err != nil {
+				scanErr = fmt.Errorf("sorted set row %q: %w", row.Key(), err)
+				return false
+			}
+			if pending.Scores == nil {
+				pending.Scores = map[string]int64{}
+			}
+			pending.Scores[string(cell(row, bigtableIndexFamily, bigtableValueColumn))] = score
+		case RecordCounters:
+			counters, err := b.counters(row)
+			if err != nil {
+				scanErr = err
+				return false
+			}
+			pending.Scores = counters
+		case RecordValue:
+			pending.Value = string(cell(row, bigtableIndexFamily, bigtableValueColumn))
+		}
+		return true
+	}, latestOnly)
+	if err != nil {
+		return err
+	}
+	if scanErr != nil {
+		return scanErr
+	}
+	return flush()
+}
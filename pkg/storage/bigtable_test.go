@@ -0,0 +1,180 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/bigtable/bttest"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+func newTestBigtableBackend(t *testing.T) *BigtableBackend {
+	t.Helper()
+	ctx := context.Background()
+	srv, err := bttest.NewServer("localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	admin, err := bigtable.NewAdminClient(ctx, "project", "instance", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.CreateTable(ctx, "index"); err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range []string{bigtableIndexFamily, bigtableCounterFamily} {
+		if err := admin.CreateColumnFamily(ctx, "index", family); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client, err := bigtable.NewClient(ctx, "project", "instance", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewBigtableBackend(client.Open("index"))
+}
+
+func TestBigtableBackendLists(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBigtableBackend(t)
+	if err := b.Write(ctx, "alice@example.com", "1"); err != nil {
+		t.Fatal(err)
+	}
+	writes := []IndexWrite{{"alice@example.com", "2"}, {"abcd", "x"}, {"alice@example.com", "3"}}
+	if err := b.WriteBatch(ctx, writes); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := b.Lookup(ctx, "alice@example.com"); err != nil || !reflect.DeepEqual(got, []string{"3", "2", "1"}) {
+		t.Errorf("Lookup() = %v, %v", got, err)
+	}
+	if got, err := b.LookupBatch(ctx, []string{"missing", "abcd", "alice@example.com"}); err != nil || !reflect.DeepEqual(got, [][]string{{}, {"x"}, {"3", "2", "1"}}) {
+		t.Errorf("LookupBatch() = %v, %v", got, err)
+	}
+	if n, err := b.Length(ctx, "alice@example.com"); err != nil || n != 3 {
+		t.Errorf("Length() = %v, %v", n, err)
+	}
+	if got, err := b.RangeFromTail(ctx, "alice@example.com", 2, 1); err != nil || !reflect.DeepEqual(got, []string{"2", "1"}) {
+		t.Errorf("RangeFromTail(2, 1) = %v, %v", got, err)
+	}
+	if got, err := b.RangeFromTail(ctx, "alice@example.com", 10, 3); err != nil || !reflect.DeepEqual(got, []string{"3"}) {
+		t.Errorf("RangeFromTail(10, 3) = %v, %v", got, err)
+	}
+}
+
+func TestBigtableBackendScores(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBigtableBackend(t)
+	for _, s := range []struct {
+		member string
+		score  int64
+	}{{"1", 10}, {"2", 20}, {"3", 20}, {"4", 40}, {"4", 15}} {
+		if err := b.WriteScore(ctx, "time", s.member, s.score); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := b.RangeByScore(ctx, "time", 10, 30, 0, 10); err != nil || !reflect.DeepEqual(got, []string{"1", "4", "2", "3"}) {
+		t.Errorf("RangeByScore(10, 30) = %v, %v", got, err)
+	}
+	if got, err := b.RangeByScore(ctx, "time", 0, 100, 1, 2); err != nil || !reflect.DeepEqual(got, []string{"4", "2"}) {
+		t.Errorf("RangeByScore with offset 1 and limit 2 = %v, %v", got, err)
+	}
+
+	for _, member := range []string{"abd", "abc", "b", "ab"} {
+		if err := b.WriteScore(ctx, "keys", member, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := b.RangeByPrefix(ctx, "keys", "ab", 2); err != nil || !reflect.DeepEqual(got, []string{"ab", "abc"}) {
+		t.Errorf("RangeByPrefix(ab, 2) = %v, %v", got, err)
+	}
+}
+
+func TestBigtableBackendCountersAndValues(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBigtableBackend(t)
+	for _, field := range []string{"rekord", "rekord", "jar"} {
+		if err := b.Increment(ctx, "counts", field, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := b.Counters(ctx, "counts"); err != nil || !reflect.DeepEqual(got, map[string]int64{"rekord": 2, "jar": 1}) {
+		t.Errorf("Counters() = %v, %v", got, err)
+	}
+
+	for _, value := range []string{"a", "b"} {
+		if err := b.SetIfAbsent(ctx, "scheme", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := b.Get(ctx, "scheme"); err != nil || got != "a" {
+		t.Errorf("Get() = %q, %v, want the first value set", got, err)
+	}
+	if got, err := b.Get(ctx, "unset"); err != nil || got != "" {
+		t.Errorf("Get() of unset value = %q, %v", got, err)
+	}
+}
+
+func TestBigtableBackendScan(t *testing.T) {
+	ctx := context.Background()
+	b := newTestBigtableBackend(t)
+	if err := b.WriteBatch(ctx, []IndexWrite{{"a", "1"}, {"a", "2"}, {"b", "x"}}); err != nil {
+		t.Fatal(err)
+	}
+	for member, score := range map[string]int64{"1": -10, "2": 20} {
+		if err := b.WriteScore(ctx, "a", member, score); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Increment(ctx, "counts", "rekord", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetIfAbsent(ctx, "scheme", "a"); err != nil {
+		t.Fatal(err)
+	}
+
+	var records []IndexRecord
+	if err := b.Scan(ctx, func(r IndexRecord) error {
+		records = append(records, r)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// records follow the order of row keys, the rows of each structure sorting after the key it is stored under
+	want := []IndexRecord{
+		{Type: RecordList, Key: "a", Values: []string{"1", "2"}},
+		{Type: RecordScores, Key: "a", Scores: map[string]int64{"1": -10, "2": 20}},
+		{Type: RecordList, Key: "b", Values: []string{"x"}},
+		{Type: RecordCounters, Key: "counts", Scores: map[string]int64{"rekord": 3}},
+		{Type: RecordValue, Key: "scheme", Value: "a"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("Scan() = %+v, want %+v", records, want)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+)
+
+// ErrBlobNotFound is returned by BlobStore.Get for digests that have never been stored
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore holds large blobs that are kept out of the log, such as attestations and signature envelopes, addressed
+// by the lowercase hex encoded SHA-256 digest of their content. A blob is stored only once however many times it is
+// put, as content already stored under its digest is not uploaded again.
+type BlobStore interface {
+	// Put stores content unless it is already stored, returning its digest
+	Put(ctx context.Context, content []byte) (string, error)
+	// Get returns the content stored under digest, after checking that it matches it
+	Get(ctx context.Context, digest string) ([]byte, error)
+	// Has reports whether content is stored under digest
+	Has(ctx context.Context, digest string) (bool, error)
+}
+
+// BlobDigest returns the digest content is stored under
+func BlobDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// checkBlob returns an error unless content is stored under digest, so that a blob altered in storage is never
+// returned as the one logged
+func checkBlob(digest string, content []byte) error {
+	if got := BlobDigest(content); got != digest {
+		return fmt.Errorf("blob stored under %v has digest %v", digest, got)
+	}
+	return nil
+}
+
+// validBlobDigest returns an error for digests that are not lowercase hex encoded SHA-256 digests, which would
+// otherwise name arbitrary objects of the underlying storage
+func validBlobDigest(digest string) error {
+	if b, err := hex.DecodeString(digest); err != nil || len(b) != sha256.Size || hex.EncodeToString(b) != digest {
+		return fmt.Errorf("invalid blob digest %q", digest)
+	}
+	return nil
+}
+
+// BlobStoreFactory creates a blob store configured by the server flags
+type BlobStoreFactory func(ctx context.Context) (BlobStore, error)
+
+var (
+	blobStoresMu sync.RWMutex
+	blobStores   = map[string]BlobStoreFactory{
+		"memory": func(context.Context) (BlobStore, error) { return NewMemoryBlobStore(), nil },
+	}
+)
+
+// RegisterBlobStore makes a blob store available to NewBlobStore; it is meant to be called from init functions
+func RegisterBlobStore(name string, factory BlobStoreFactory) {
+	blobStoresMu.Lock()
+	defer blobStoresMu.Unlock()
+	blobStores[name] = factory
+}
+
+// BlobStores returns the names of the registered blob stores
+func BlobStores() []string {
+	blobStoresMu.RLock()
+	defer blobStoresMu.RUnlock()
+	names := make([]string, 0, len(blobStores))
+	for name := range blobStores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewBlobStore creates the named blob store
+func NewBlobStore(ctx context.Context, name string) (BlobStore, error) {
+	blobStoresMu.RLock()
+	factory, ok := blobStores[name]
+	blobStoresMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown blob store '%v', expected one of %v", name, BlobStores())
+	}
+	return factory(ctx)
+}
+
+// MemoryBlobStore keeps blobs in memory, for tests and single instance development servers
+type MemoryBlobStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemoryBlobStore returns an empty MemoryBlobStore
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{blobs: map[string][]byte{}}
+}
+
+func (m *MemoryBlobStore) Put(ctx context.Context, content []byte) (string, error) {
+	digest := BlobDigest(content)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.blobs[digest]; !ok {
+		m.blobs[digest] = append([]byte{}, content...)
+	}
+	return digest, nil
+}
+
+func (m *MemoryBlobStore) Get(ctx context.Context, digest string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	content, ok := m.blobs[digest]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return append([]byte{}, content...), nil
+}
+
+func (m *MemoryBlobStore) Has(ctx context.Context, digest string) (bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.blobs[digest]
+	return ok, nil
+}
+
+func (m *MemoryBlobStore) PutReader(ctx context.Context, digest string, content io.ReadSeeker, size int64) error {
+	b, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.blobs[digest]; !ok {
+		m.blobs[digest] = b
+	}
+	return nil
+}
+
+// GetRaw returns the same content as Get, as the memory store never checks content against its digest
+func (m *MemoryBlobStore) GetRaw(ctx context.Context, digest string) ([]byte, error) {
+	return m.Get(ctx, digest)
+}
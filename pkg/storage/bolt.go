@@ -0,0 +1,354 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"time"
+
+	"github.com/spf13/viper"
+	bolt "go.etcd.io/bbolt"
+)
+
+func init() {
+	RegisterIndexBackend("bolt", func(context.Context) (IndexBackend, error) {
+		return OpenBoltBackend(viper.GetString("index.bolt.path"))
+	})
+}
+
+// The file holds a top level bucket per kind of structure, each holding a nested bucket per index key:
+//
+//   lists     values by their 8 byte position counted from the tail; the sequence of the bucket is the length
+//   scores    a members bucket holding the score of each member, and an ordered bucket holding each member
+//             under its score followed by a NUL byte and the member, so that a cursor walks them in order
+//   counters  the 8 byte value of each counter
+//
+// Single values are kept directly in the values bucket.
+var (
+	boltLists    = []byte("lists")
+	boltScores   = []byte("scores")
+	boltMembers  = []byte("members")
+	boltOrdered  = []byte("ordered")
+	boltCounters = []byte("counters")
+	boltValues   = []byte("values")
+)
+
+// BoltBackend keeps the index in a bbolt file on local disk, so that a single server can run without any
+// external service; the file can only be opened by one server at a time
+type BoltBackend struct {
+	db *bolt.DB
+}
+
+// OpenBoltBackend opens the file at path, creating it if needed
+func OpenBoltBackend(path string) (*BoltBackend, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltLists, boltScores, boltCounters, boltValues} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltBackend{db: db}, nil
+}
+
+// Close closes the file
+func (b *BoltBackend) Close() error {
+	return b.db.Close()
+}
+
+func orderedKey(score int64, member string) []byte {
+	return []byte(sortableInt(score) + "\x00" + member)
+}
+
+func (b *BoltBackend) Write(ctx context.Context, key, value string) error {
+	return b.WriteBatch(ctx, []IndexWrite{{Key: key, Value: value}})
+}
+
+// WriteBatch writes all of the values in a single transaction, which bbolt coalesces with those of concurrent
+// batches to save on disk syncs
+func (b *BoltBackend) WriteBatch(ctx context.Context, writes []IndexWrite) error {
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		lists := tx.Bucket(boltLists)
+		for _, w := range writes {
+			list, err := lists.CreateBucketIfNotExists([]byte(w.Key))
+			if err != nil {
+				return err
+			}
+			position, err := list.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err := list.Put(encodeInt(int64(position)), []byte(w.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// rangeFromTail reads the values of the list from the from-th to the to-th last; a list holds every position
+// up to its length
+func rangeFromTail(tx *bolt.Tx, key string, from, to int64) []string {
+	result := []string{}
+	list := tx.Bucket(boltLists).Bucket([]byte(key))
+	if list == nil {
+		return result
+	}
+	if length := int64(list.Sequence()); from > length {
+		from = length
+	}
+	if to < 1 {
+		to = 1
+	}
+	for position := from; position >= to; position-- {
+		result = append(result, string(list.Get(encodeInt(position))))
+	}
+	return result
+}
+
+func (b *BoltBackend) Lookup(ctx context.Context, key string) ([]string, error) {
+	var result []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		result = rangeFromTail(tx, key, math.MaxInt64, 1)
+		return nil
+	})
+	return result, err
+}
+
+// LookupBatch reads all of the lists in a single transaction
+func (b *BoltBackend) LookupBatch(ctx context.Context, keys []string) ([][]string, error) {
+	result := make([][]string, len(keys))
+	err := b.db.View(func(tx *bolt.Tx) error {
+		for i, key := range keys {
+			result[i] = rangeFromTail(tx, key, math.MaxInt64, 1)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (b *BoltBackend) Length(ctx context.Context, key string) (int64, error) {
+	var n int64
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if list := tx.Bucket(boltLists).Bucket([]byte(key)); list != nil {
+			n = int64(list.Sequence())
+		}
+		return nil
+	})
+	return n, err
+}
+
+func (b *BoltBackend) RangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error) {
+	var result []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		result = rangeFromTail(tx, key, from, to)
+		return nil
+	})
+	return result, err
+}
+
+func (b *BoltBackend) WriteScore(ctx context.Context, key, member string, score int64) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		set, err := tx.Bucket(boltScores).CreateBucketIfNotExists([]byte(key))
+		if err != nil {
+			return err
+		}
+		members, err := set.CreateBucketIfNotExists(boltMembers)
+		if err != nil {
+			return err
+		}
+		ordered, err := set.CreateBucketIfNotExists(boltOrdered)
+		if err != nil {
+			return err
+		}
+		if previous := members.Get([]byte(member)); previous != nil {
+			n, err := decodeInt(previous)
+			if err != nil {
+				return err
+			}
+			if err := ordered.Delete(orderedKey(n, member)); err != nil {
+				return err
+			}
+		}
+		if err := members.Put([]byte(member), encodeInt(score)); err != nil {
+			return err
+		}
+		return ordered.Put(orderedKey(score, member), []byte(member))
+	})
+}
+
+func (b *BoltBackend) RangeByScore(ctx context.Context, key string, min, max, offset, limit int64) ([]string, error) {
+	result := []string{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		set := tx.Bucket(boltScores).Bucket([]byte(key))
+		if set == nil {
+			return nil
+		}
+		// '\x01' sorts right after the NUL byte, so the end follows every member scored max
+		end := []byte(sortableInt(max) + "\x01")
+		c := set.Bucket(boltOrdered).Cursor()
+		for k, v := c.Seek([]byte(sortableInt(min))); k != nil && bytes.Compare(k, end) < 0 && int64(len(result)) < limit; k, v = c.Next() {
+			if offset > 0 {
+				offset--
+				continue
+			}
+			result = append(result, string(v))
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (b *BoltBackend) RangeByPrefix(ctx context.Context, key, prefix string, limit int64) ([]string, error) {
+	result := []string{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		set := tx.Bucket(boltScores).Bucket([]byte(key))
+		if set == nil {
+			return nil
+		}
+		start := orderedKey(0, prefix)
+		c := set.Bucket(boltOrdered).Cursor()
+		for k, v := c.Seek(start); k != nil && bytes.HasPrefix(k, start) && int64(len(result)) < limit; k, v = c.Next() {
+			result = append(result, string(v))
+		}
+		return nil
+	})
+	return result, err
+}
+
+func (b *BoltBackend) Increment(ctx context.Context, key, field string, by int64) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		counters, err := tx.Bucket(boltCounters).CreateBucketIfNotExists([]byte(key))
+		if err != nil {
+			return err
+		}
+		var n int64
+		if value := counters.Get([]byte(field)); value != nil {
+			if n, err = decodeInt(value); err != nil {
+				return err
+			}
+		}
+		return counters.Put([]byte(field), encodeInt(n+by))
+	})
+}
+
+func (b *BoltBackend) Counters(ctx context.Context, key string) (map[string]int64, error) {
+	result := map[string]int64{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		counters := tx.Bucket(boltCounters).Bucket([]byte(key))
+		if counters == nil {
+			return nil
+		}
+		return counters.ForEach(func(field, value []byte) error {
+			n, err := decodeInt(value)
+			if err != nil {
+				return err
+			}
+			result[string(field)] = n
+			return nil
+		})
+	})
+	return result, err
+}
+
+func (b *BoltBackend) SetIfAbsent(ctx context.Context, key, value string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		values := tx.Bucket(boltValues)
+		if values.Get([]byte(key)) != nil {
+			return nil
+		}
+		return values.Put([]byte(key), []byte(value))
+	})
+}
+
+func (b *BoltBackend) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		value = string(tx.Bucket(boltValues).Get([]byte(key)))
+		return nil
+	})
+	return value, err
+}
+
+// Scan reads the whole file in a single read transaction
+func (b *BoltBackend) Scan(ctx context.Context, fn func(IndexRecord) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(boltLists).ForEach(func(key, _ []byte) error {
+			r := IndexRecord{Type: RecordList, Key: string(key)}
+			// positions are big-endian sequence numbers, so the cursor reads the oldest first
+			if err := tx.Bucket(boltLists).Bucket(key).ForEach(func(_, value []byte) error {
+				r.Values = append(r.Values, string(value))
+				return nil
+			}); err != nil {
+				return err
+			}
+			return fn(r)
+		}); err != nil {
+			return err
+		}
+		if err := tx.Bucket(boltScores).ForEach(func(key, _ []byte) error {
+			r := IndexRecord{Type: RecordScores, Key: string(key), Scores: map[string]int64{}}
+			if err := tx.Bucket(boltScores).Bucket(key).Bucket(boltMembers).ForEach(func(member, score []byte) error {
+				n, err := decodeInt(score)
+				if err != nil {
+					return err
+				}
+				r.Scores[string(member)] = n
+				if len(r.Scores) < scanChunkSize {
+					return nil
+				}
+				chunk := r
+				r.Scores = map[string]int64{}
+				return fn(chunk)
+			}); err != nil {
+				return err
+			}
+			if len(r.Scores) == 0 {
+				return nil
+			}
+			return fn(r)
+		}); err != nil {
+			return err
+		}
+		if err := tx.Bucket(boltCounters).ForEach(func(key, _ []byte) error {
+			r := IndexRecord{Type: RecordCounters, Key: string(key), Scores: map[string]int64{}}
+			if err := tx.Bucket(boltCounters).Bucket(key).ForEach(func(field, value []byte) error {
+				n, err := decodeInt(value)
+				r.Scores[string(field)] = n
+				return err
+			}); err != nil {
+				return err
+			}
+			return fn(r)
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(boltValues).ForEach(func(key, value []byte) error {
+			return fn(IndexRecord{Type: RecordValue, Key: string(key), Value: string(value)})
+		})
+	})
+}
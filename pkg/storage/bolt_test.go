@@ -0,0 +1,115 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBoltBackend(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "index.db")
+	b, err := OpenBoltBackend(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Write(ctx, "a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WriteBatch(ctx, []IndexWrite{{"a", "2"}, {"b", "x"}, {"a", "3"}}); err != nil {
+		t.Fatal(err)
+	}
+	for member, score := range map[string]int64{"1": 10, "2": 20, "3": 20, "4": 40} {
+		if err := b.WriteScore(ctx, "t", member, score); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.WriteScore(ctx, "t", "4", 15); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"rekord", "rekord", "jar"} {
+		if err := b.Increment(ctx, "counts", field, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, member := range []string{"abd", "abc", "b", "ab"} {
+		if err := b.WriteScore(ctx, "keys", member, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = b.SetIfAbsent(ctx, "scheme", "a")
+	_ = b.SetIfAbsent(ctx, "scheme", "b")
+
+	// everything written must survive reopening the file
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = OpenBoltBackend(path); err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+
+	if got, _ := b.Lookup(ctx, "a"); !reflect.DeepEqual(got, []string{"3", "2", "1"}) {
+		t.Errorf("Lookup(a) = %v", got)
+	}
+	if got, _ := b.LookupBatch(ctx, []string{"b", "missing"}); !reflect.DeepEqual(got, [][]string{{"x"}, {}}) {
+		t.Errorf("LookupBatch(b, missing) = %v", got)
+	}
+	if n, _ := b.Length(ctx, "a"); n != 3 {
+		t.Errorf("Length(a) = %v", n)
+	}
+	if got, _ := b.RangeFromTail(ctx, "a", 10, 2); !reflect.DeepEqual(got, []string{"3", "2"}) {
+		t.Errorf("RangeFromTail(10, 2) = %v", got)
+	}
+	if got, _ := b.RangeByScore(ctx, "t", 10, 30, 0, 10); !reflect.DeepEqual(got, []string{"1", "4", "2", "3"}) {
+		t.Errorf("RangeByScore(10, 30) = %v", got)
+	}
+	if got, _ := b.RangeByScore(ctx, "t", 0, 100, 1, 2); !reflect.DeepEqual(got, []string{"4", "2"}) {
+		t.Errorf("RangeByScore with offset 1 and limit 2 = %v", got)
+	}
+	if got, _ := b.RangeByPrefix(ctx, "keys", "ab", 2); !reflect.DeepEqual(got, []string{"ab", "abc"}) {
+		t.Errorf("RangeByPrefix(ab, 2) = %v", got)
+	}
+	if got, _ := b.Counters(ctx, "counts"); !reflect.DeepEqual(got, map[string]int64{"rekord": 2, "jar": 1}) {
+		t.Errorf("Counters() = %v", got)
+	}
+	if got, _ := b.Get(ctx, "scheme"); got != "a" {
+		t.Errorf("Get() = %q, want the first value set", got)
+	}
+
+	var records []IndexRecord
+	if err := b.Scan(ctx, func(r IndexRecord) error {
+		records = append(records, r)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := []IndexRecord{
+		{Type: RecordList, Key: "a", Values: []string{"1", "2", "3"}},
+		{Type: RecordList, Key: "b", Values: []string{"x"}},
+		{Type: RecordScores, Key: "keys", Scores: map[string]int64{"abd": 0, "abc": 0, "b": 0, "ab": 0}},
+		{Type: RecordScores, Key: "t", Scores: map[string]int64{"1": 10, "2": 20, "3": 20, "4": 15}},
+		{Type: RecordCounters, Key: "counts", Scores: map[string]int64{"rekord": 2, "jar": 1}},
+		{Type: RecordValue, Key: "scheme", Value: "a"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("Scan() = %+v, want %+v", records, want)
+	}
+}
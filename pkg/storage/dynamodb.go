@@ -0,0 +1,500 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterIndexBackend("dynamodb", newDynamoDBBackend)
+}
+
+// The index is kept in a single table whose items are addressed by a partition key k and a sort key s, both
+// strings. Each structure of the index gets its own partition, named by the kind of structure and its key:
+//
+//   list/<key>      one item per value, sorted by its position counted from the tail of the list
+//   length/<key>    a single item counting the values ever written to the list
+//   zset/<key>      one item per member, sorted by score and then member
+//   zscore/<key>    one item per member, holding its current score
+//   counters/<key>  one item per counter
+//   value/<key>     a single item holding the value
+const (
+	dynamoPartitionKey = "k"
+	dynamoSortKey      = "s"
+	dynamoValue        = "v"
+	dynamoNumber       = "n"
+
+	// dynamoSingleItem is the sort key of partitions holding a single item
+	dynamoSingleItem = "item"
+	// dynamoMaxBatchWrite is the number of items DynamoDB accepts in a single BatchWriteItem request
+	dynamoMaxBatchWrite = 25
+	// dynamoMaxBatchRetries bounds the attempts at writing items DynamoDB left unprocessed under throttling
+	dynamoMaxBatchRetries = 8
+)
+
+// DynamoDBBackend keeps the index in a DynamoDB table billed on demand, so that it needs no capacity planning
+type DynamoDBBackend struct {
+	client dynamodbiface.DynamoDBAPI
+	table  string
+}
+
+func newDynamoDBBackend(ctx context.Context) (IndexBackend, error) {
+	cfg := aws.NewConfig()
+	if region := viper.GetString("index.dynamodb.region"); region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	if endpoint := viper.GetString("index.dynamodb.endpoint"); endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	d := NewDynamoDBBackend(dynamodb.New(sess), viper.GetString("index.dynamodb.table"))
+	if err := d.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewDynamoDBBackend returns a backend storing the index in table through client
+func NewDynamoDBBackend(client dynamodbiface.DynamoDBAPI, table string) *DynamoDBBackend {
+	return &DynamoDBBackend{client: client, table: table}
+}
+
+// ensureTable creates the table with on-demand capacity unless it already exists
+func (d *DynamoDBBackend) ensureTable(ctx context.Context) error {
+	_, err := d.client.DescribeTableWithContext(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(d.table)})
+	var aerr awserr.Error
+	if err == nil || !errors.As(err, &aerr) || aerr.Code() != dynamodb.ErrCodeResourceNotFoundException {
+		return err
+	}
+	if _, err := d.client.CreateTableWithContext(ctx, &dynamodb.CreateTableInput{
+		TableName:   aws.String(d.table),
+		BillingMode: aws.String(dynamodb.BillingModePayPerRequest),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String(dynamoPartitionKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+			{AttributeName: aws.String(dynamoSortKey), AttributeType: aws.String(dynamodb.ScalarAttributeTypeS)},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{AttributeName: aws.String(dynamoPartitionKey), KeyType: aws.String(dynamodb.KeyTypeHash)},
+			{AttributeName: aws.String(dynamoSortKey), KeyType: aws.String(dynamodb.KeyTypeRange)},
+		},
+	}); err != nil {
+		return err
+	}
+	return d.client.WaitUntilTableExistsWithContext(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(d.table)})
+}
+
+// sortableInt encodes n as a string that sorts as n does, including negative numbers
+func sortableInt(n int64) string {
+	return fmt.Sprintf("%020d", uint64(n)^(1<<63))
+}
+
+// parseSortableInt decodes a string encoded by sortableInt
+func parseSortableInt(s string) (int64, error) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(n ^ (1 << 63)), nil
+}
+
+func itemKey(partition, sort string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		dynamoPartitionKey: {S: aws.String(partition)},
+		dynamoSortKey:      {S: aws.String(sort)},
+	}
+}
+
+func item(partition, sort string, attrs map[string]*dynamodb.AttributeValue) map[string]*dynamodb.AttributeValue {
+	result := itemKey(partition, sort)
+	for name, value := range attrs {
+		result[name] = value
+	}
+	return result
+}
+
+func numberValue(av *dynamodb.AttributeValue) (int64, error) {
+	if av == nil || av.N == nil {
+		return 0, nil
+	}
+	return strconv.ParseInt(*av.N, 10, 64)
+}
+
+// add adds n to the number held by the item at partition and sort, returning the result
+func (d *DynamoDBBackend) add(ctx context.Context, partition, sort string, n int64) (int64, error) {
+	out, err := d.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(d.table),
+		Key:                       itemKey(partition, sort),
+		UpdateExpression:          aws.String("ADD " + dynamoNumber + " :n"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":n": {N: aws.String(strconv.FormatInt(n, 10))}},
+		ReturnValues:              aws.String(dynamodb.ReturnValueUpdatedNew),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return numberValue(out.Attributes[dynamoNumber])
+}
+
+// query returns the items of partition with sort keys between from and to inclusive, or all of its items if both
+// are empty, stopping after limit items unless limit is negative
+func (d *DynamoDBBackend) query(ctx context.Context, partition, from, to string, forward bool, limit int64) ([]map[string]*dynamodb.AttributeValue, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(d.table),
+		KeyConditionExpression:    aws.String("#k = :k"),
+		ExpressionAttributeNames:  map[string]*string{"#k": aws.String(dynamoPartitionKey)},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":k": {S: aws.String(partition)}},
+		ScanIndexForward:          aws.Bool(forward),
+		ConsistentRead:            aws.Bool(true),
+	}
+	if from != "" || to != "" {
+		input.KeyConditionExpression = aws.String("#k = :k AND #s BETWEEN :from AND :to")
+		input.ExpressionAttributeNames["#s"] = aws.String(dynamoSortKey)
+		input.ExpressionAttributeValues[":from"] = &dynamodb.AttributeValue{S: aws.String(from)}
+		input.ExpressionAttributeValues[":to"] = &dynamodb.AttributeValue{S: aws.String(to)}
+	}
+	var items []map[string]*dynamodb.AttributeValue
+	err := d.client.QueryPagesWithContext(ctx, input, func(page *dynamodb.QueryOutput, _ bool) bool {
+		items = append(items, page.Items...)
+		return limit < 0 || int64(len(items)) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	if limit >= 0 && int64(len(items)) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func stringValue(av *dynamodb.AttributeValue) string {
+	if av == nil {
+		return ""
+	}
+	return aws.StringValue(av.S)
+}
+
+func stringValues(items []map[string]*dynamodb.AttributeValue) []string {
+	result := make([]string, 0, len(items))
+	for _, i := range items {
+		result = append(result, stringValue(i[dynamoValue]))
+	}
+	return result
+}
+
+func (d *DynamoDBBackend) Write(ctx context.Context, key, value string) error {
+	return d.WriteBatch(ctx, []IndexWrite{{Key: key, Value: value}})
+}
+
+// WriteBatch reserves positions in each list written to with one counter update per list, then writes the
+// values with as few BatchWriteItem requests as possible
+func (d *DynamoDBBackend) WriteBatch(ctx context.Context, writes []IndexWrite) error {
+	counts := map[string]int64{}
+	var keys []string
+	for _, w := range writes {
+		if counts[w.Key] == 0 {
+			keys = append(keys, w.Key)
+		}
+		counts[w.Key]++
+	}
+	// next holds the position of the next value written to each list
+	next := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		length, err := d.add(ctx, "length/"+key, dynamoSingleItem, counts[key])
+		if err != nil {
+			return err
+		}
+		next[key] = length - counts[key] + 1
+	}
+
+	requests := make([]*dynamodb.WriteRequest, 0, len(writes))
+	for _, w := range writes {
+		requests = append(requests, &dynamodb.WriteRequest{PutRequest: &dynamodb.PutRequest{
+			Item: item("list/"+w.Key, sortableInt(next[w.Key]), map[string]*dynamodb.AttributeValue{dynamoValue: {S: aws.String(w.Value)}}),
+		}})
+		next[w.Key]++
+	}
+	for len(requests) > 0 {
+		n := len(requests)
+		if n > dynamoMaxBatchWrite {
+			n = dynamoMaxBatchWrite
+		}
+		if err := d.batchWrite(ctx, requests[:n]); err != nil {
+			return err
+		}
+		requests = requests[n:]
+	}
+	return nil
+}
+
+// batchWrite writes requests, retrying those DynamoDB leaves unprocessed with exponential backoff
+func (d *DynamoDBBackend) batchWrite(ctx context.Context, requests []*dynamodb.WriteRequest) error {
+	delay := 50 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		out, err := d.client.BatchWriteItemWithContext(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]*dynamodb.WriteRequest{d.table: requests},
+		})
+		if err != nil {
+			return err
+		}
+		requests = out.UnprocessedItems[d.table]
+		if len(requests) == 0 {
+			return nil
+		}
+		if attempt == dynamoMaxBatchRetries {
+			return fmt.Errorf("%d index writes left unprocessed by DynamoDB", len(requests))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (d *DynamoDBBackend) Lookup(ctx context.Context, key string) ([]string, error) {
+	return d.RangeFromTail(ctx, key, math.MaxInt64, 1)
+}
+
+func (d *DynamoDBBackend) LookupBatch(ctx context.Context, keys []string) ([][]string, error) {
+	result := make([][]string, len(keys))
+	for i, key := range keys {
+		values, err := d.Lookup(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = values
+	}
+	return result, nil
+}
+
+func (d *DynamoDBBackend) Length(ctx context.Context, key string) (int64, error) {
+	out, err := d.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(d.table),
+		Key:            itemKey("length/"+key, dynamoSingleItem),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return numberValue(out.Item[dynamoNumber])
+}
+
+func (d *DynamoDBBackend) RangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error) {
+	if from < to {
+		return []string{}, nil
+	}
+	items, err := d.query(ctx, "list/"+key, sortableInt(to), sortableInt(from), false, -1)
+	if err != nil {
+		return nil, err
+	}
+	return stringValues(items), nil
+}
+
+// WriteScore replaces the item ordering member under its previous score, if it had one
+func (d *DynamoDBBackend) WriteScore(ctx context.Context, key, member string, score int64) error {
+	out, err := d.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:    aws.String(d.table),
+		Item:         item("zscore/"+key, member, map[string]*dynamodb.AttributeValue{dynamoNumber: {N: aws.String(strconv.FormatInt(score, 10))}}),
+		ReturnValues: aws.String(dynamodb.ReturnValueAllOld),
+	})
+	if err != nil {
+		return err
+	}
+	if old, ok := out.Attributes[dynamoNumber]; ok {
+		previous, err := numberValue(old)
+		if err != nil {
+			return err
+		}
+		if previous == score {
+			return nil
+		}
+		if _, err := d.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(d.table),
+			Key:       itemKey("zset/"+key, sortableInt(previous)+"/"+member),
+		}); err != nil {
+			return err
+		}
+	}
+	_, err = d.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(d.table),
+		Item:      item("zset/"+key, sortableInt(score)+"/"+member, map[string]*dynamodb.AttributeValue{dynamoValue: {S: aws.String(member)}}),
+	})
+	return err
+}
+
+// RangeByScore reads the skipped members too, as DynamoDB queries cannot start at an offset
+func (d *DynamoDBBackend) RangeByScore(ctx context.Context, key string, min, max, offset, limit int64) ([]string, error) {
+	if min > max {
+		return []string{}, nil
+	}
+	// '0' sorts right after '/', so the upper bound follows every member scored max
+	items, err := d.query(ctx, "zset/"+key, sortableInt(min)+"/", sortableInt(max)+"0", true, offset+limit)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= int64(len(items)) {
+		return []string{}, nil
+	}
+	return stringValues(items[offset:]), nil
+}
+
+func (d *DynamoDBBackend) RangeByPrefix(ctx context.Context, key, prefix string, limit int64) ([]string, error) {
+	from := sortableInt(0) + "/" + prefix
+	// the largest code point sorts after every other character a member starting with prefix can continue with
+	items, err := d.query(ctx, "zset/"+key, from, from+"\U0010FFFF", true, limit)
+	if err != nil {
+		return nil, err
+	}
+	return stringValues(items), nil
+}
+
+func (d *DynamoDBBackend) Increment(ctx context.Context, key, field string, n int64) error {
+	_, err := d.add(ctx, "counters/"+key, field, n)
+	return err
+}
+
+func (d *DynamoDBBackend) Counters(ctx context.Context, key string) (map[string]int64, error) {
+	items, err := d.query(ctx, "counters/"+key, "", "", true, -1)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64, len(items))
+	for _, i := range items {
+		n, err := numberValue(i[dynamoNumber])
+		if err != nil {
+			return nil, err
+		}
+		result[stringValue(i[dynamoSortKey])] = n
+	}
+	return result, nil
+}
+
+func (d *DynamoDBBackend) SetIfAbsent(ctx context.Context, key, value string) error {
+	_, err := d.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(d.table),
+		Item:                     item("value/"+key, dynamoSingleItem, map[string]*dynamodb.AttributeValue{dynamoValue: {S: aws.String(value)}}),
+		ConditionExpression:      aws.String("attribute_not_exists(#k)"),
+		ExpressionAttributeNames: map[string]*string{"#k": aws.String(dynamoPartitionKey)},
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return nil
+	}
+	return err
+}
+
+func (d *DynamoDBBackend) Get(ctx context.Context, key string) (string, error) {
+	out, err := d.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(d.table),
+		Key:            itemKey("value/"+key, dynamoSingleItem),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	return stringValue(out.Item[dynamoValue]), nil
+}
+
+// Scan reads the whole table, reading each list from its partition once the scan has found it, as the items of
+// a partition are not returned in order by a scan
+func (d *DynamoDBBackend) Scan(ctx context.Context, fn func(IndexRecord) error) error {
+	var lists []string
+	pending := map[string]*IndexRecord{}
+	var fnErr error
+	emit := func(r IndexRecord) bool {
+		fnErr = fn(r)
+		return fnErr == nil
+	}
+	err := d.client.ScanPagesWithContext(ctx, &dynamodb.ScanInput{TableName: aws.String(d.table), ConsistentRead: aws.Bool(true)}, func(page *dynamodb.ScanOutput, _ bool) bool {
+		for _, i := range page.Items {
+			partition, sort := stringValue(i[dynamoPartitionKey]), stringValue(i[dynamoSortKey])
+			kind, key := partition, ""
+			if slash := strings.Index(partition, "/"); slash >= 0 {
+				kind, key = partition[:slash], partition[slash+1:]
+			}
+			switch kind {
+			case "length":
+				lists = append(lists, key)
+			case "zscore", "counters":
+				recordType := RecordScores
+				if kind == "counters" {
+					recordType = RecordCounters
+				}
+				n, err := numberValue(i[dynamoNumber])
+				if err != nil {
+					fnErr = err
+					return false
+				}
+				r := pending[partition]
+				if r == nil {
+					r = &IndexRecord{Type: recordType, Key: key, Scores: map[string]int64{}}
+					pending[partition] = r
+				}
+				r.Scores[sort] = n
+				if len(r.Scores) >= scanChunkSize {
+					delete(pending, partition)
+					if !emit(*r) {
+						return false
+					}
+				}
+			case "value":
+				if !emit(IndexRecord{Type: RecordValue, Key: key, Value: stringValue(i[dynamoValue])}) {
+					return false
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if fnErr != nil {
+		return fnErr
+	}
+	for _, r := range pending {
+		if err := fn(*r); err != nil {
+			return err
+		}
+	}
+	for _, key := range lists {
+		items, err := d.query(ctx, "list/"+key, "", "", true, -1)
+		if err != nil {
+			return err
+		}
+		if err := fn(IndexRecord{Type: RecordList, Key: key, Values: stringValues(items)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
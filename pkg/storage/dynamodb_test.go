@@ -0,0 +1,128 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+// fakeDynamoDB records the writes made by the backend; it leaves the first item of each batch unprocessed once
+type fakeDynamoDB struct {
+	dynamodbiface.DynamoDBAPI
+	counters  map[string]int64
+	puts      []map[string]*dynamodb.AttributeValue
+	batches   []int
+	throttled bool
+}
+
+func (f *fakeDynamoDB) UpdateItemWithContext(_ aws.Context, in *dynamodb.UpdateItemInput, _ ...request.Option) (*dynamodb.UpdateItemOutput, error) {
+	k := *in.Key[dynamoPartitionKey].S + "|" + *in.Key[dynamoSortKey].S
+	n, _ := strconv.ParseInt(*in.ExpressionAttributeValues[":n"].N, 10, 64)
+	f.counters[k] += n
+	return &dynamodb.UpdateItemOutput{Attributes: map[string]*dynamodb.AttributeValue{dynamoNumber: {N: aws.String(strconv.FormatInt(f.counters[k], 10))}}}, nil
+}
+
+func (f *fakeDynamoDB) BatchWriteItemWithContext(_ aws.Context, in *dynamodb.BatchWriteItemInput, _ ...request.Option) (*dynamodb.BatchWriteItemOutput, error) {
+	requests := in.RequestItems["index"]
+	f.batches = append(f.batches, len(requests))
+	out := &dynamodb.BatchWriteItemOutput{}
+	if !f.throttled {
+		f.throttled = true
+		out.UnprocessedItems = map[string][]*dynamodb.WriteRequest{"index": requests[:1]}
+		requests = requests[1:]
+	}
+	for _, r := range requests {
+		f.puts = append(f.puts, r.PutRequest.Item)
+	}
+	return out, nil
+}
+
+func (f *fakeDynamoDB) PutItemWithContext(_ aws.Context, in *dynamodb.PutItemInput, _ ...request.Option) (*dynamodb.PutItemOutput, error) {
+	if in.ConditionExpression != nil {
+		return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "exists", nil)
+	}
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func TestSortableInt(t *testing.T) {
+	values := []int64{math.MinInt64, -10, -1, 0, 1, 9, 10, 1600000000, math.MaxInt64}
+	encoded := make([]string, len(values))
+	for i, v := range values {
+		encoded[i] = sortableInt(v)
+	}
+	if !sort.StringsAreSorted(encoded) {
+		t.Errorf("encoding does not preserve order: %v", encoded)
+	}
+	for i, s := range encoded {
+		if n, err := parseSortableInt(s); err != nil || n != values[i] {
+			t.Errorf("parseSortableInt(%v) = %v, %v, want %v", s, n, err, values[i])
+		}
+	}
+}
+
+func TestDynamoDBWriteBatch(t *testing.T) {
+	f := &fakeDynamoDB{counters: map[string]int64{"length/a|item": 2}}
+	d := NewDynamoDBBackend(f, "index")
+
+	var writes []IndexWrite
+	for i := 0; i < 30; i++ {
+		writes = append(writes, IndexWrite{Key: "b", Value: fmt.Sprint(i)})
+	}
+	writes = append(writes, IndexWrite{Key: "a", Value: "x"}, IndexWrite{Key: "a", Value: "y"})
+	if err := d.WriteBatch(context.Background(), writes); err != nil {
+		t.Fatal(err)
+	}
+
+	if f.counters["length/a|item"] != 4 || f.counters["length/b|item"] != 30 {
+		t.Errorf("unexpected list lengths %v", f.counters)
+	}
+	// the item left unprocessed is retried on its own
+	if fmt.Sprint(f.batches) != "[25 1 7]" {
+		t.Errorf("unexpected batch sizes %v", f.batches)
+	}
+	positions := map[string]string{}
+	for _, i := range f.puts {
+		positions[*i[dynamoValue].S] = *i[dynamoPartitionKey].S + "@" + *i[dynamoSortKey].S
+	}
+	if len(positions) != 32 {
+		t.Fatalf("expected 32 items written, got %v", len(positions))
+	}
+	if positions["0"] != "list/b@"+sortableInt(1) || positions["29"] != "list/b@"+sortableInt(30) {
+		t.Errorf("unexpected positions in list b: %v, %v", positions["0"], positions["29"])
+	}
+	if positions["x"] != "list/a@"+sortableInt(3) || positions["y"] != "list/a@"+sortableInt(4) {
+		t.Errorf("unexpected positions in list a: %v, %v", positions["x"], positions["y"])
+	}
+}
+
+func TestDynamoDBSetIfAbsent(t *testing.T) {
+	d := NewDynamoDBBackend(&fakeDynamoDB{}, "index")
+	if err := d.SetIfAbsent(context.Background(), "scheme", "a"); err != nil {
+		t.Errorf("failed condition should not be an error: %v", err)
+	}
+}
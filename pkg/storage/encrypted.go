@@ -0,0 +1,178 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"gocloud.dev/secrets"
+	// key management services whose keys can be referred to by attestation_storage.encryption_key
+	_ "gocloud.dev/secrets/awskms"
+	_ "gocloud.dev/secrets/gcpkms"
+	_ "gocloud.dev/secrets/localsecrets"
+)
+
+// sealedBlobMagic starts every blob stored by an EncryptedBlobStore, telling it apart from blobs stored in plaintext
+// before encryption was enabled
+const sealedBlobMagic = "rekor-sealed-blob/v1\n"
+
+// dataKeySize is the size of the AES-256 key each blob is encrypted with
+const dataKeySize = 32
+
+// EncryptedBlobStore keeps blobs encrypted in another store, under the digest of their plaintext. Each blob is
+// encrypted with its own AES-256-GCM data key, which is in turn encrypted by a key held in a key management
+// service and stored along with the blob, so that the content of the store cannot be read without access to that
+// key. Blobs are held in memory while they are encrypted or decrypted.
+type EncryptedBlobStore struct {
+	store  RawBlobStore
+	keeper *secrets.Keeper
+}
+
+// OpenEncryptedBlobStore returns a store encrypting blobs kept in store with the key keyURL refers to, such as
+// gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>,
+// awskms://<key ID or alias>?region=<region>, or base64key://<base64 encoded 32 byte key> for a local key
+func OpenEncryptedBlobStore(ctx context.Context, store BlobStore, keyURL string) (*EncryptedBlobStore, error) {
+	raw, ok := store.(RawBlobStore)
+	if !ok {
+		return nil, fmt.Errorf("blob store of type %T cannot hold encrypted blobs", store)
+	}
+	keeper, err := secrets.OpenKeeper(ctx, keyURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening encryption key: %w", err)
+	}
+	return NewEncryptedBlobStore(raw, keeper), nil
+}
+
+// NewEncryptedBlobStore returns a store keeping blobs in store, encrypted with data keys that keeper encrypts
+func NewEncryptedBlobStore(store RawBlobStore, keeper *secrets.Keeper) *EncryptedBlobStore {
+	return &EncryptedBlobStore{store: store, keeper: keeper}
+}
+
+// Put encrypts content unless it is already stored, so that storing a blob again does not call the key management
+// service
+func (e *EncryptedBlobStore) Put(ctx context.Context, content []byte) (string, error) {
+	digest := BlobDigest(content)
+	if stored, err := e.store.Has(ctx, digest); err != nil || stored {
+		return digest, err
+	}
+	sealed, err := e.seal(ctx, digest, content)
+	if err != nil {
+		return "", err
+	}
+	return digest, e.store.PutReader(ctx, digest, bytes.NewReader(sealed), int64(len(sealed)))
+}
+
+// Get decrypts the blob stored under digest; blobs stored in plaintext before encryption was enabled are returned
+// as they are, after checking them against their digest like any other
+func (e *EncryptedBlobStore) Get(ctx context.Context, digest string) ([]byte, error) {
+	stored, err := e.store.GetRaw(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	content := stored
+	if bytes.HasPrefix(stored, []byte(sealedBlobMagic)) {
+		if content, err = e.open(ctx, digest, stored); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkBlob(digest, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (e *EncryptedBlobStore) Has(ctx context.Context, digest string) (bool, error) {
+	return e.store.Has(ctx, digest)
+}
+
+// seal encrypts content with a new data key, returning the magic, the length of the encrypted data key, the
+// encrypted data key, the nonce and the ciphertext, which is authenticated along with digest so that a blob cannot
+// be passed off as another
+func (e *EncryptedBlobStore) seal(ctx context.Context, digest string, content []byte) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := e.keeper.Encrypt(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting data key: %w", err)
+	}
+	aead, err := newBlobAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := bytes.NewBufferString(sealedBlobMagic)
+	_ = binary.Write(sealed, binary.BigEndian, uint32(len(wrappedKey)))
+	sealed.Write(wrappedKey)
+	sealed.Write(nonce)
+	sealed.Write(aead.Seal(nil, nonce, content, []byte(digest)))
+	return sealed.Bytes(), nil
+}
+
+// open decrypts a blob encrypted by seal
+func (e *EncryptedBlobStore) open(ctx context.Context, digest string, sealed []byte) ([]byte, error) {
+	r := bytes.NewReader(sealed[len(sealedBlobMagic):])
+	var keyLen uint32
+	if err := binary.Read(r, binary.BigEndian, &keyLen); err != nil || int64(keyLen) > int64(r.Len()) {
+		return nil, errors.New("malformed encrypted blob")
+	}
+	wrappedKey := make([]byte, keyLen)
+	_, _ = r.Read(wrappedKey)
+	dataKey, err := e.keeper.Decrypt(ctx, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting data key: %w", err)
+	}
+	aead, err := newBlobAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() < aead.NonceSize() {
+		return nil, errors.New("malformed encrypted blob")
+	}
+	nonce := make([]byte, aead.NonceSize())
+	_, _ = r.Read(nonce)
+	ciphertext := make([]byte, r.Len())
+	_, _ = r.Read(ciphertext)
+	content, err := aead.Open(nil, nonce, ciphertext, []byte(digest))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting blob %v: %w", digest, err)
+	}
+	return content, nil
+}
+
+func newBlobAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != dataKeySize {
+		return nil, errors.New("invalid data key")
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"gocloud.dev/secrets/localsecrets"
+)
+
+func TestEncryptedBlobStore(t *testing.T) {
+	ctx := context.Background()
+	inner := NewMemoryBlobStore()
+	e := NewEncryptedBlobStore(inner, localsecrets.NewKeeper([32]byte{1}))
+	content := []byte("provenance built on builder-7.internal.example.com")
+
+	digest, err := e.Put(ctx, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != BlobDigest(content) {
+		t.Errorf("Put() = %v, want the digest of the plaintext", digest)
+	}
+	stored, err := inner.GetRaw(ctx, digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(stored, content) || !bytes.HasPrefix(stored, []byte(sealedBlobMagic)) {
+		t.Errorf("blob stored in plaintext: %q", stored)
+	}
+	if got, err := e.Get(ctx, digest); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+	if stored, err := e.Has(ctx, digest); err != nil || !stored {
+		t.Errorf("Has() = %v, %v", stored, err)
+	}
+	// storing the same content again keeps the blob first stored
+	if _, err := e.Put(ctx, content); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := inner.GetRaw(ctx, digest); !bytes.Equal(again, stored) {
+		t.Error("blob was stored again")
+	}
+
+	// blobs stored before encryption was enabled are still read
+	plainDigest, _ := inner.Put(ctx, []byte("plaintext"))
+	if got, err := e.Get(ctx, plainDigest); err != nil || string(got) != "plaintext" {
+		t.Errorf("Get() = %q, %v for a plaintext blob", got, err)
+	}
+
+	if _, err := NewEncryptedBlobStore(inner, localsecrets.NewKeeper([32]byte{2})).Get(ctx, digest); err == nil {
+		t.Error("expected error decrypting with a different key")
+	}
+	// a blob copied under another digest does not decrypt
+	other := BlobDigest([]byte("other"))
+	_ = inner.PutReader(ctx, other, bytes.NewReader(stored), int64(len(stored)))
+	if _, err := e.Get(ctx, other); err == nil {
+		t.Error("expected error for a blob stored under another digest")
+	}
+	truncated := BlobDigest([]byte("truncated"))
+	_ = inner.PutReader(ctx, truncated, bytes.NewReader(stored[:len(sealedBlobMagic)+2]), 0)
+	if _, err := e.Get(ctx, truncated); err == nil {
+		t.Error("expected error for a truncated blob")
+	}
+	if _, err := e.Get(ctx, BlobDigest([]byte("missing"))); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound, got %v", err)
+	}
+
+	if _, err := OpenEncryptedBlobStore(ctx, inner, "base64key://"); err != nil {
+		t.Errorf("unexpected error opening a local key: %v", err)
+	}
+	if _, err := OpenEncryptedBlobStore(ctx, inner, "unknown://key"); err == nil {
+		t.Error("expected error for an unknown key URL scheme")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"cloud.google.com/go/storage"
+	"github.com/spf13/viper"
+	"google.golang.org/api/googleapi"
+)
+
+func init() {
+	RegisterBlobStore("gcs", newGCSBlobStore)
+}
+
+// GCSBlobStore keeps each blob in an object of a Cloud Storage bucket named after its digest
+type GCSBlobStore struct {
+	bucket *storage.BucketHandle
+	prefix string
+}
+
+// newGCSBlobStore authenticates with the application default credentials, which on GKE are those of the service
+// account the pod's Kubernetes service account is bound to through workload identity
+func newGCSBlobStore(ctx context.Context) (BlobStore, error) {
+	bucket := viper.GetString("attestation_storage.gcs.bucket")
+	if bucket == "" {
+		return nil, errors.New("attestation_storage.gcs.bucket must be set for the gcs blob store")
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewGCSBlobStore(client.Bucket(bucket), viper.GetString("attestation_storage.gcs.prefix")), nil
+}
+
+// NewGCSBlobStore returns a blob store keeping blobs in bucket, under object names starting with prefix
+func NewGCSBlobStore(bucket *storage.BucketHandle, prefix string) *GCSBlobStore {
+	return &GCSBlobStore{bucket: bucket, prefix: prefix}
+}
+
+// Put only creates objects that do not exist yet, as an object named after a digest already holds the content
+func (g *GCSBlobStore) Put(ctx context.Context, content []byte) (string, error) {
+	digest := BlobDigest(content)
+	return digest, g.PutReader(ctx, digest, bytes.NewReader(content), int64(len(content)))
+}
+
+// PutReader uploads content in chunks, so only one chunk of it is held in memory at a time; the upload is
+// conditional on the object not existing, in case it is created after being looked for
+func (g *GCSBlobStore) PutReader(ctx context.Context, digest string, content io.ReadSeeker, size int64) error {
+	if stored, err := g.Has(ctx, digest); err != nil || stored {
+		return err
+	}
+	w := g.bucket.Object(g.prefix + digest).If(storage.Conditions{DoesNotExist: true}).NewWriter(ctx)
+	w.ContentType = "application/octet-stream"
+	if _, err := io.Copy(w, content); err != nil {
+		_ = w.Close()
+		return err
+	}
+	err := w.Close()
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Code == http.StatusPreconditionFailed {
+		return nil
+	}
+	return err
+}
+
+func (g *GCSBlobStore) Get(ctx context.Context, digest string) ([]byte, error) {
+	content, err := g.GetRaw(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBlob(digest, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (g *GCSBlobStore) GetRaw(ctx context.Context, digest string) ([]byte, error) {
+	r, err := g.GetReader(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func (g *GCSBlobStore) GetReader(ctx context.Context, digest string) (io.ReadCloser, error) {
+	if err := validBlobDigest(digest); err != nil {
+		return nil, err
+	}
+	r, err := g.bucket.Object(g.prefix + digest).NewReader(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (g *GCSBlobStore) Has(ctx context.Context, digest string) (bool, error) {
+	if err := validBlobDigest(digest); err != nil {
+		return false, err
+	}
+	_, err := g.bucket.Object(g.prefix + digest).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
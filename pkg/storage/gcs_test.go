@@ -0,0 +1,135 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// fakeGCS serves the multipart uploads and the reads of the objects of a single bucket
+type fakeGCS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads int
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/upload/storage/v1/b/bucket/o") {
+		f.uploads++
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		parts := multipart.NewReader(r.Body, params["boundary"])
+		var metadata struct {
+			Name string `json:"name"`
+		}
+		part, err := parts.NextPart()
+		if err == nil {
+			err = json.NewDecoder(part).Decode(&metadata)
+		}
+		if err == nil {
+			part, err = parts.NextPart()
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		content, _ := ioutil.ReadAll(part)
+		if _, ok := f.objects[metadata.Name]; ok && r.URL.Query().Get("ifGenerationMatch") == "0" {
+			http.Error(w, `{"error":{"code":412,"message":"precondition failed"}}`, http.StatusPreconditionFailed)
+			return
+		}
+		f.objects[metadata.Name] = content
+		_ = json.NewEncoder(w).Encode(map[string]string{"bucket": "bucket", "name": metadata.Name})
+		return
+	}
+	// metadata is read from the endpoint or, with the emulator host set, from its root
+	metadataPath := strings.TrimPrefix(r.URL.Path, "/storage/v1")
+	if name := strings.TrimPrefix(metadataPath, "/b/bucket/o/"); name != metadataPath {
+		if _, ok := f.objects[name]; !ok {
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"bucket": "bucket", "name": name})
+		return
+	}
+	content, ok := f.objects[strings.TrimPrefix(r.URL.Path, "/bucket/")]
+	if r.Method != http.MethodGet || !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_, _ = w.Write(content)
+}
+
+func TestGCSBlobStore(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeGCS{objects: map[string][]byte{}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+	// reads are made from the emulator host, and everything else from the endpoint
+	defer os.Unsetenv("STORAGE_EMULATOR_HOST")
+	os.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(srv.URL, "http://"))
+	client, err := storage.NewClient(ctx, option.WithEndpoint(srv.URL+"/storage/v1/"), option.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGCSBlobStore(client.Bucket("bucket"), "attestations/")
+
+	digest, err := g.Put(ctx, []byte("envelope"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.objects["attestations/"+digest]; !ok || digest != BlobDigest([]byte("envelope")) {
+		t.Errorf("object not stored under its digest %v: %v", digest, f.objects)
+	}
+	// storing the same content again finds the object already there
+	if again, err := g.Put(ctx, []byte("envelope")); err != nil || again != digest || f.uploads != 1 {
+		t.Errorf("second Put() = %v, %v after %v uploads", again, err, f.uploads)
+	}
+	if stored, err := g.Has(ctx, BlobDigest([]byte("missing"))); err != nil || stored {
+		t.Errorf("Has() = %v, %v for a missing object", stored, err)
+	}
+	if got, err := g.Get(ctx, digest); err != nil || string(got) != "envelope" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	if _, err := g.Get(ctx, BlobDigest([]byte("missing"))); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound, got %v", err)
+	}
+	f.objects["attestations/"+digest] = []byte("altered")
+	if _, err := g.Get(ctx, digest); err == nil {
+		t.Error("expected error for content that does not match its digest")
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+func init() {
+	RegisterIndexBackend("memory", func(context.Context) (IndexBackend, error) {
+		return NewMemoryBackend(), nil
+	})
+}
+
+// MemoryBackend keeps the index in the memory of the server, which loses it on restart; it suits development
+// and tests, where running Redis is not worth it
+type MemoryBackend struct {
+	mu       sync.RWMutex
+	lists    map[string][]string // oldest first
+	scores   map[string]map[string]int64
+	counters map[string]map[string]int64
+	values   map[string]string
+}
+
+// NewMemoryBackend returns an empty backend
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{
+		lists:    map[string][]string{},
+		scores:   map[string]map[string]int64{},
+		counters: map[string]map[string]int64{},
+		values:   map[string]string{},
+	}
+}
+
+func (m *MemoryBackend) Write(ctx context.Context, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lists[key] = append(m.lists[key], value)
+	return nil
+}
+
+func (m *MemoryBackend) WriteBatch(ctx context.Context, writes []IndexWrite) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, w := range writes {
+		m.lists[w.Key] = append(m.lists[w.Key], w.Value)
+	}
+	return nil
+}
+
+// newestFirst returns a copy of the slice s[from:to] of a list in reverse
+func newestFirst(s []string, from, to int) []string {
+	result := make([]string, 0, to-from)
+	for i := to - 1; i >= from; i-- {
+		result = append(result, s[i])
+	}
+	return result
+}
+
+func (m *MemoryBackend) Lookup(ctx context.Context, key string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s := m.lists[key]
+	return newestFirst(s, 0, len(s)), nil
+}
+
+func (m *MemoryBackend) LookupBatch(ctx context.Context, keys []string) ([][]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([][]string, len(keys))
+	for i, key := range keys {
+		s := m.lists[key]
+		result[i] = newestFirst(s, 0, len(s))
+	}
+	return result, nil
+}
+
+func (m *MemoryBackend) Length(ctx context.Context, key string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return int64(len(m.lists[key])), nil
+}
+
+func (m *MemoryBackend) RangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s := m.lists[key]
+	// the k-th last value of a list kept oldest first is s[k-1]
+	if from > int64(len(s)) {
+		from = int64(len(s))
+	}
+	if to < 1 {
+		to = 1
+	}
+	if from < to {
+		return []string{}, nil
+	}
+	return newestFirst(s, int(to-1), int(from)), nil
+}
+
+func (m *MemoryBackend) WriteScore(ctx context.Context, key, member string, score int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.scores[key] == nil {
+		m.scores[key] = map[string]int64{}
+	}
+	m.scores[key][member] = score
+	return nil
+}
+
+func (m *MemoryBackend) RangeByScore(ctx context.Context, key string, min, max, offset, limit int64) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	set := m.scores[key]
+	members := []string{}
+	for member, score := range set {
+		if score >= min && score <= max {
+			members = append(members, member)
+		}
+	}
+	// like Redis, members with the same score are ordered lexicographically
+	sort.Slice(members, func(i, j int) bool {
+		if set[members[i]] != set[members[j]] {
+			return set[members[i]] < set[members[j]]
+		}
+		return members[i] < members[j]
+	})
+	if offset >= int64(len(members)) {
+		return []string{}, nil
+	}
+	members = members[offset:]
+	if limit >= 0 && limit < int64(len(members)) {
+		members = members[:limit]
+	}
+	return members, nil
+}
+
+func (m *MemoryBackend) RangeByPrefix(ctx context.Context, key, prefix string, limit int64) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	members := []string{}
+	for member, score := range m.scores[key] {
+		if score == 0 && strings.HasPrefix(member, prefix) {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+	if limit >= 0 && limit < int64(len(members)) {
+		members = members[:limit]
+	}
+	return members, nil
+}
+
+func (m *MemoryBackend) Increment(ctx context.Context, key, field string, n int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counters[key] == nil {
+		m.counters[key] = map[string]int64{}
+	}
+	m.counters[key][field] += n
+	return nil
+}
+
+func (m *MemoryBackend) Counters(ctx context.Context, key string) (map[string]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return copyCounts(m.counters[key]), nil
+}
+
+func (m *MemoryBackend) SetIfAbsent(ctx context.Context, key, value string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.values[key]; !ok {
+		m.values[key] = value
+	}
+	return nil
+}
+
+func (m *MemoryBackend) Get(ctx context.Context, key string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.values[key], nil
+}
+
+// Scan copies the index before calling fn, so that fn may write to the backend
+func (m *MemoryBackend) Scan(ctx context.Context, fn func(IndexRecord) error) error {
+	m.mu.RLock()
+	var records []IndexRecord
+	for key, values := range m.lists {
+		records = append(records, IndexRecord{Type: RecordList, Key: key, Values: append([]string{}, values...)})
+	}
+	for key, set := range m.scores {
+		records = append(records, IndexRecord{Type: RecordScores, Key: key, Scores: copyCounts(set)})
+	}
+	for key, counters := range m.counters {
+		records = append(records, IndexRecord{Type: RecordCounters, Key: key, Scores: copyCounts(counters)})
+	}
+	for key, value := range m.values {
+		records = append(records, IndexRecord{Type: RecordValue, Key: key, Value: value})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Key != records[j].Key {
+			return records[i].Key < records[j].Key
+		}
+		return records[i].Type < records[j].Type
+	})
+	for _, r := range records {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyCounts(counts map[string]int64) map[string]int64 {
+	result := make(map[string]int64, len(counts))
+	for k, v := range counts {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMemoryBackendLists(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryBackend()
+	if err := m.Write(ctx, "a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteBatch(ctx, []IndexWrite{{"a", "2"}, {"b", "x"}, {"a", "3"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := m.Lookup(ctx, "a"); !reflect.DeepEqual(got, []string{"3", "2", "1"}) {
+		t.Errorf("Lookup(a) = %v", got)
+	}
+	if got, _ := m.LookupBatch(ctx, []string{"b", "missing"}); !reflect.DeepEqual(got, [][]string{{"x"}, {}}) {
+		t.Errorf("LookupBatch(b, missing) = %v", got)
+	}
+	if n, _ := m.Length(ctx, "a"); n != 3 {
+		t.Errorf("Length(a) = %v", n)
+	}
+
+	tests := []struct {
+		caseDesc string
+		from, to int64
+		want     []string
+	}{
+		{caseDesc: "whole list", from: 3, to: 1, want: []string{"3", "2", "1"}},
+		{caseDesc: "oldest two", from: 2, to: 1, want: []string{"2", "1"}},
+		{caseDesc: "newest", from: 3, to: 3, want: []string{"3"}},
+		{caseDesc: "from beyond the head", from: 10, to: 2, want: []string{"3", "2"}},
+		{caseDesc: "empty range", from: 1, to: 2, want: []string{}},
+	}
+	for _, tc := range tests {
+		if got, _ := m.RangeFromTail(ctx, "a", tc.from, tc.to); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: RangeFromTail(%v, %v) = %v, want %v", tc.caseDesc, tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestMemoryBackendScores(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryBackend()
+	for member, score := range map[string]int64{"4": 40, "1": 10, "3": 20, "2": 20} {
+		if err := m.WriteScore(ctx, "t", member, score); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := m.RangeByScore(ctx, "t", 10, 30, 0, 10); !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+		t.Errorf("RangeByScore(10, 30) = %v", got)
+	}
+	if got, _ := m.RangeByScore(ctx, "t", 0, 100, 1, 2); !reflect.DeepEqual(got, []string{"2", "3"}) {
+		t.Errorf("RangeByScore with offset 1 and limit 2 = %v", got)
+	}
+	if got, _ := m.RangeByScore(ctx, "t", 0, 100, 5, 2); len(got) != 0 {
+		t.Errorf("RangeByScore past the end = %v", got)
+	}
+}
+
+func TestMemoryBackendPrefixes(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryBackend()
+	for _, member := range []string{"abd", "abc", "b", "ab"} {
+		if err := m.WriteScore(ctx, "keys", member, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = m.WriteScore(ctx, "keys", "abz", 1)
+
+	tests := []struct {
+		caseDesc string
+		prefix   string
+		limit    int64
+		want     []string
+	}{
+		{caseDesc: "members with prefix", prefix: "ab", limit: 10, want: []string{"ab", "abc", "abd"}},
+		{caseDesc: "limited", prefix: "ab", limit: 2, want: []string{"ab", "abc"}},
+		{caseDesc: "no match", prefix: "c", limit: 10, want: []string{}},
+	}
+	for _, tc := range tests {
+		if got, _ := m.RangeByPrefix(ctx, "keys", tc.prefix, tc.limit); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: RangeByPrefix(%q, %v) = %v, want %v", tc.caseDesc, tc.prefix, tc.limit, got, tc.want)
+		}
+	}
+}
+
+func TestMemoryBackendCountersAndValues(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryBackend()
+	for _, field := range []string{"rekord", "rekord", "jar"} {
+		if err := m.Increment(ctx, "counts", field, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := m.Counters(ctx, "counts"); !reflect.DeepEqual(got, map[string]int64{"rekord": 2, "jar": 1}) {
+		t.Errorf("Counters() = %v", got)
+	}
+
+	if got, _ := m.Get(ctx, "scheme"); got != "" {
+		t.Errorf("Get() of unset value = %q", got)
+	}
+	_ = m.SetIfAbsent(ctx, "scheme", "a")
+	_ = m.SetIfAbsent(ctx, "scheme", "b")
+	if got, _ := m.Get(ctx, "scheme"); got != "a" {
+		t.Errorf("Get() = %q, want the first value set", got)
+	}
+}
+
+func TestMemoryBackendScan(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryBackend()
+	_ = m.WriteBatch(ctx, []IndexWrite{{"a", "1"}, {"a", "2"}})
+	_ = m.WriteScore(ctx, "a", "1", 10)
+	_ = m.Increment(ctx, "counts", "rekord", 5)
+	_ = m.SetIfAbsent(ctx, "scheme", "a")
+
+	var records []IndexRecord
+	err := m.Scan(ctx, func(r IndexRecord) error {
+		// writing while scanning must not deadlock
+		records = append(records, r)
+		return m.Write(ctx, "b", "x")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []IndexRecord{
+		{Type: RecordList, Key: "a", Values: []string{"1", "2"}},
+		{Type: RecordScores, Key: "a", Scores: map[string]int64{"1": 10}},
+		{Type: RecordCounters, Key: "counts", Scores: map[string]int64{"rekord": 5}},
+		{Type: RecordValue, Key: "scheme", Value: "a"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("Scan() = %+v, want %+v", records, want)
+	}
+}
+
+func TestNewIndexBackend(t *testing.T) {
+	if _, err := NewIndexBackend(context.Background(), "memory"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewIndexBackend(context.Background(), "bogus"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
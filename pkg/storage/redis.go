@@ -0,0 +1,374 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strconv"
+
+	radix "github.com/mediocregopher/radix/v4"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterIndexBackend("redis", newRedisBackend)
+}
+
+// RedisBackend keeps lists in Redis lists, sorted sets in Redis sorted sets and counters in Redis hashes.
+//
+// Every command acts on a single key, so the index can be spread across the slots of a Redis Cluster without
+// hash tags; only pipelines, which a cluster runs against a single slot, are split by the slot of their keys.
+type RedisBackend struct {
+	client  RedisClient
+	cluster bool
+}
+
+// RedisClient is what the backend needs of a radix.Client connected to a single server or of a
+// radix.MultiClient, such as a *radix.Cluster or a *radix.Sentinel
+type RedisClient interface {
+	Do(context.Context, radix.Action) error
+}
+
+func newRedisBackend(ctx context.Context) (IndexBackend, error) {
+	addr := fmt.Sprintf("%v:%v", viper.GetString("redis_server.address"), viper.GetUint64("redis_server.port"))
+	nodes := viper.GetStringSlice("redis_server.nodes")
+	if len(nodes) == 0 {
+		nodes = []string{addr}
+	}
+	poolConfig, err := redisPoolConfig()
+	if err != nil {
+		return nil, err
+	}
+	var client RedisClient
+	switch mode := viper.GetString("redis_server.mode"); mode {
+	case "standalone":
+		client, err = poolConfig.New(ctx, "tcp", addr)
+	case "cluster":
+		client, err = radix.ClusterConfig{PoolConfig: poolConfig}.New(ctx, nodes)
+	case "sentinel":
+		// sentinels are reached over TLS along with the servers, but are not sent the credentials of the servers
+		sentinelDialer := radix.Dialer{NetDialer: poolConfig.Dialer.NetDialer}
+		client, err = radix.SentinelConfig{PoolConfig: poolConfig, SentinelDialer: sentinelDialer}.New(ctx, viper.GetString("redis_server.sentinel_primary"), nodes)
+	default:
+		return nil, fmt.Errorf("unknown redis_server.mode %q, expected standalone, cluster or sentinel", mode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisBackend(client), nil
+}
+
+// redisPoolConfig returns the configuration of the connections to each Redis server, with the credentials, TLS
+// settings and pool size of the redis_server flags
+func redisPoolConfig() (radix.PoolConfig, error) {
+	config := radix.PoolConfig{
+		Size: viper.GetInt("redis_server.pool_size"),
+		Dialer: radix.Dialer{
+			AuthUser: viper.GetString("redis_server.username"),
+			AuthPass: viper.GetString("redis_server.password"),
+		},
+	}
+	if !viper.GetBool("redis_server.enable_tls") {
+		return config, nil
+	}
+	/* #nosec G402 */
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: viper.GetBool("redis_server.insecure_skip_verify"),
+	}
+	if caFile := viper.GetString("redis_server.tls_ca"); caFile != "" {
+		pem, err := ioutil.ReadFile(filepath.Clean(caFile))
+		if err != nil {
+			return config, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return config, fmt.Errorf("no PEM encoded certificates found in %v", caFile)
+		}
+	}
+	config.Dialer.NetDialer = &tls.Dialer{Config: tlsConfig}
+	return config, nil
+}
+
+// NewRedisBackend returns a backend storing the index through client, which may be a *radix.Cluster
+func NewRedisBackend(client RedisClient) *RedisBackend {
+	_, cluster := client.(*radix.Cluster)
+	return &RedisBackend{client: client, cluster: cluster}
+}
+
+// pipeline runs the commands in a single pipeline, or in one pipeline per hash slot against a cluster
+func (r *RedisBackend) pipeline(ctx context.Context, cmds []radix.Action) error {
+	groups := [][]radix.Action{cmds}
+	if r.cluster {
+		groups = bySlot(cmds)
+	}
+	for _, group := range groups {
+		p := radix.NewPipeline()
+		for _, cmd := range group {
+			p.Append(cmd)
+		}
+		if err := r.client.Do(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bySlot groups commands acting on a single key by the cluster hash slot of their key, keeping their order
+// within each slot
+func bySlot(cmds []radix.Action) [][]radix.Action {
+	var groups [][]radix.Action
+	slots := map[uint16]int{}
+	for _, cmd := range cmds {
+		slot := radix.ClusterSlot([]byte(cmd.Properties().Keys[0]))
+		i, ok := slots[slot]
+		if !ok {
+			i = len(groups)
+			slots[slot] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], cmd)
+	}
+	return groups
+}
+
+func (r *RedisBackend) Write(ctx context.Context, key, value string) error {
+	return r.client.Do(ctx, radix.Cmd(nil, "LPUSH", key, value))
+}
+
+// WriteBatch sends the writes in a single pipeline, or one per hash slot against a cluster
+func (r *RedisBackend) WriteBatch(ctx context.Context, writes []IndexWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+	cmds := make([]radix.Action, len(writes))
+	for i, w := range writes {
+		cmds[i] = radix.Cmd(nil, "LPUSH", w.Key, w.Value)
+	}
+	return r.pipeline(ctx, cmds)
+}
+
+func (r *RedisBackend) Lookup(ctx context.Context, key string) ([]string, error) {
+	var result []string
+	if err := r.client.Do(ctx, radix.Cmd(&result, "LRANGE", key, "0", "-1")); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LookupBatch sends the lookups in a single pipeline, or one per hash slot against a cluster
+func (r *RedisBackend) LookupBatch(ctx context.Context, keys []string) ([][]string, error) {
+	result := make([][]string, len(keys))
+	if len(keys) == 0 {
+		return result, nil
+	}
+	cmds := make([]radix.Action, len(keys))
+	for i, key := range keys {
+		cmds[i] = radix.Cmd(&result[i], "LRANGE", key, "0", "-1")
+	}
+	if err := r.pipeline(ctx, cmds); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *RedisBackend) Length(ctx context.Context, key string) (int64, error) {
+	var n int64
+	if err := r.client.Do(ctx, radix.Cmd(&n, "LLEN", key)); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (r *RedisBackend) RangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error) {
+	var result []string
+	if err := r.client.Do(ctx, radix.Cmd(&result, "LRANGE", key, strconv.FormatInt(-from, 10), strconv.FormatInt(-to, 10))); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *RedisBackend) WriteScore(ctx context.Context, key, member string, score int64) error {
+	return r.client.Do(ctx, radix.Cmd(nil, "ZADD", key, strconv.FormatInt(score, 10), member))
+}
+
+func (r *RedisBackend) RangeByScore(ctx context.Context, key string, min, max, offset, limit int64) ([]string, error) {
+	var result []string
+	args := []string{key, strconv.FormatInt(min, 10), strconv.FormatInt(max, 10), "LIMIT", strconv.FormatInt(offset, 10), strconv.FormatInt(limit, 10)}
+	if err := r.client.Do(ctx, radix.Cmd(&result, "ZRANGEBYSCORE", args...)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RangeByPrefix scans the set by member with ZRANGEBYLEX, which requires every member to have the same score
+func (r *RedisBackend) RangeByPrefix(ctx context.Context, key, prefix string, limit int64) ([]string, error) {
+	var result []string
+	// members are text, so none starting with prefix sorts after prefix followed by the byte 0xff
+	args := []string{key, "[" + prefix, "[" + prefix + "\xff", "LIMIT", "0", strconv.FormatInt(limit, 10)}
+	if err := r.client.Do(ctx, radix.Cmd(&result, "ZRANGEBYLEX", args...)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *RedisBackend) Increment(ctx context.Context, key, field string, n int64) error {
+	return r.client.Do(ctx, radix.Cmd(nil, "HINCRBY", key, field, strconv.FormatInt(n, 10)))
+}
+
+func (r *RedisBackend) Counters(ctx context.Context, key string) (map[string]int64, error) {
+	var counts map[string]string
+	if err := r.client.Do(ctx, radix.Cmd(&counts, "HGETALL", key)); err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64, len(counts))
+	for field, count := range counts {
+		n, err := strconv.ParseInt(count, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("counter %v: %w", field, err)
+		}
+		result[field] = n
+	}
+	return result, nil
+}
+
+func (r *RedisBackend) SetIfAbsent(ctx context.Context, key, value string) error {
+	return r.client.Do(ctx, radix.Cmd(nil, "SETNX", key, value))
+}
+
+func (r *RedisBackend) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	if err := r.client.Do(ctx, radix.Cmd(&value, "GET", key)); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// scanClient lets a radix.Scanner, which only calls Do, iterate over the keys of a single server
+type scanClient struct {
+	RedisClient
+}
+
+func (scanClient) Addr() net.Addr { return nil }
+
+func (scanClient) Close() error { return nil }
+
+// Scan iterates over the keys of every primary of a cluster, or of the single primary otherwise
+func (r *RedisBackend) Scan(ctx context.Context, fn func(IndexRecord) error) error {
+	primaries := []RedisClient{r.client}
+	if mc, ok := r.client.(radix.MultiClient); ok {
+		replicaSets, err := mc.Clients()
+		if err != nil {
+			return err
+		}
+		primaries = primaries[:0]
+		for _, rs := range replicaSets {
+			primaries = append(primaries, rs.Primary)
+		}
+	}
+	for _, primary := range primaries {
+		if err := r.scanServer(ctx, primary, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RedisBackend) scanServer(ctx context.Context, server RedisClient, fn func(IndexRecord) error) error {
+	scanner := radix.ScannerConfig{Count: 1000}.New(scanClient{server})
+	var key string
+	for scanner.Next(ctx, &key) {
+		record, err := r.record(ctx, server, key)
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return scanner.Close()
+}
+
+// record reads the structure under key according to the type of Redis value holding it
+func (r *RedisBackend) record(ctx context.Context, server RedisClient, key string) (IndexRecord, error) {
+	var keyType string
+	if err := server.Do(ctx, radix.Cmd(&keyType, "TYPE", key)); err != nil {
+		return IndexRecord{}, err
+	}
+	record := IndexRecord{Key: key}
+	switch keyType {
+	case "list":
+		record.Type = RecordList
+		var values []string
+		if err := server.Do(ctx, radix.Cmd(&values, "LRANGE", key, "0", "-1")); err != nil {
+			return record, err
+		}
+		record.Values = make([]string, len(values))
+		for i, value := range values {
+			record.Values[len(values)-1-i] = value
+		}
+	case "zset":
+		record.Type = RecordScores
+		var pairs []string
+		if err := server.Do(ctx, radix.Cmd(&pairs, "ZRANGE", key, "0", "-1", "WITHSCORES")); err != nil {
+			return record, err
+		}
+		scores, err := parseCounts(key, pairs)
+		if err != nil {
+			return record, err
+		}
+		record.Scores = scores
+	case "hash":
+		record.Type = RecordCounters
+		var pairs []string
+		if err := server.Do(ctx, radix.Cmd(&pairs, "HGETALL", key)); err != nil {
+			return record, err
+		}
+		counters, err := parseCounts(key, pairs)
+		if err != nil {
+			return record, err
+		}
+		record.Scores = counters
+	case "string":
+		record.Type = RecordValue
+		if err := server.Do(ctx, radix.Cmd(&record.Value, "GET", key)); err != nil {
+			return record, err
+		}
+	default:
+		return record, fmt.Errorf("key %v holds a %v, which the index does not use", key, keyType)
+	}
+	return record, nil
+}
+
+// parseCounts parses alternating names and numbers, as returned for hashes and sorted sets with scores
+func parseCounts(key string, pairs []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		n, err := strconv.ParseInt(pairs[i+1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%v of %v: %w", pairs[i], key, err)
+		}
+		result[pairs[i]] = n
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	radix "github.com/mediocregopher/radix/v4"
+	"github.com/spf13/viper"
+)
+
+func TestBySlot(t *testing.T) {
+	keys := []string{"{a}1", "b", "{a}2", "a", "b"}
+	var cmds []radix.Action
+	for _, key := range keys {
+		cmds = append(cmds, radix.Cmd(nil, "LPUSH", key, "x"))
+	}
+	var got [][]string
+	for _, group := range bySlot(cmds) {
+		var groupKeys []string
+		for _, cmd := range group {
+			groupKeys = append(groupKeys, cmd.Properties().Keys[0])
+		}
+		got = append(got, groupKeys)
+	}
+	// keys sharing a hash tag share a slot with the tag itself
+	want := [][]string{{"{a}1", "{a}2", "a"}, {"b", "b"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bySlot() = %v, want %v", got, want)
+	}
+}
+
+func TestRedisPoolConfig(t *testing.T) {
+	for _, key := range []string{"redis_server.username", "redis_server.password", "redis_server.enable_tls", "redis_server.tls_ca", "redis_server.pool_size"} {
+		defer func(key string, value interface{}) { viper.Set(key, value) }(key, viper.Get(key))
+	}
+	viper.Set("redis_server.username", "rekor")
+	viper.Set("redis_server.password", "secret")
+	viper.Set("redis_server.pool_size", 16)
+	viper.Set("redis_server.enable_tls", false)
+	config, err := redisPoolConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Dialer.AuthUser != "rekor" || config.Dialer.AuthPass != "secret" || config.Size != 16 {
+		t.Errorf("unexpected config %+v", config)
+	}
+	if config.Dialer.NetDialer != nil {
+		t.Error("expected plain connections without TLS")
+	}
+
+	viper.Set("redis_server.enable_tls", true)
+	if config, err = redisPoolConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := config.Dialer.NetDialer.(*tls.Dialer); !ok {
+		t.Errorf("expected TLS connections, got %T", config.Dialer.NetDialer)
+	}
+
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(ca, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("redis_server.tls_ca", ca)
+	if _, err := redisPoolConfig(); err == nil {
+		t.Error("expected error for a CA file without certificates")
+	}
+}
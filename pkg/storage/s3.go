@@ -0,0 +1,147 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	RegisterBlobStore("s3", newS3BlobStore)
+}
+
+// s3CodeNotFound is the error code of HEAD requests for objects that do not exist, which have no body to carry
+// the code NoSuchKey
+const s3CodeNotFound = "NotFound"
+
+// S3BlobStore keeps each blob in an object of an S3 bucket named after its digest, which any S3 compatible storage
+// can serve
+type S3BlobStore struct {
+	client s3iface.S3API
+	bucket string
+	prefix string
+}
+
+func newS3BlobStore(ctx context.Context) (BlobStore, error) {
+	bucket := viper.GetString("attestation_storage.s3.bucket")
+	if bucket == "" {
+		return nil, errors.New("attestation_storage.s3.bucket must be set for the s3 blob store")
+	}
+	cfg := aws.NewConfig()
+	if region := viper.GetString("attestation_storage.s3.region"); region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	if endpoint := viper.GetString("attestation_storage.s3.endpoint"); endpoint != "" {
+		// other S3 compatible servers are usually addressed by path rather than by a subdomain per bucket
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewS3BlobStore(s3.New(sess), bucket, viper.GetString("attestation_storage.s3.prefix")), nil
+}
+
+// NewS3BlobStore returns a blob store keeping blobs in bucket through client, under object names starting with
+// prefix
+func NewS3BlobStore(client s3iface.S3API, bucket, prefix string) *S3BlobStore {
+	return &S3BlobStore{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, content []byte) (string, error) {
+	digest := BlobDigest(content)
+	return digest, s.PutReader(ctx, digest, bytes.NewReader(content), int64(len(content)))
+}
+
+func (s *S3BlobStore) PutReader(ctx context.Context, digest string, content io.ReadSeeker, size int64) error {
+	// S3 cannot make writes conditional on the object not existing, so it is looked for first
+	if stored, err := s.Has(ctx, digest); err != nil || stored {
+		return err
+	}
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(s.prefix + digest),
+		Body:          content,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String("application/octet-stream"),
+	})
+	return err
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, digest string) ([]byte, error) {
+	content, err := s.GetRaw(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBlob(digest, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+func (s *S3BlobStore) GetRaw(ctx context.Context, digest string) ([]byte, error) {
+	r, err := s.GetReader(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func (s *S3BlobStore) GetReader(ctx context.Context, digest string) (io.ReadCloser, error) {
+	if err := validBlobDigest(digest); err != nil {
+		return nil, err
+	}
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + digest),
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (s *S3BlobStore) Has(ctx context.Context, digest string) (bool, error) {
+	if err := validBlobDigest(digest); err != nil {
+		return false, err
+	}
+	_, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + digest),
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && (aerr.Code() == s3CodeNotFound || aerr.Code() == s3.ErrCodeNoSuchKey) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// fakeS3 keeps the objects of a single bucket in a map by name
+type fakeS3 struct {
+	s3iface.S3API
+	objects map[string][]byte
+	puts    int
+}
+
+func (f *fakeS3) PutObjectWithContext(_ aws.Context, in *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	f.puts++
+	content, err := ioutil.ReadAll(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects[aws.StringValue(in.Key)] = content
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) GetObjectWithContext(_ aws.Context, in *s3.GetObjectInput, _ ...request.Option) (*s3.GetObjectOutput, error) {
+	content, ok := f.objects[aws.StringValue(in.Key)]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(content))}, nil
+}
+
+func (f *fakeS3) HeadObjectWithContext(_ aws.Context, in *s3.HeadObjectInput, _ ...request.Option) (*s3.HeadObjectOutput, error) {
+	if _, ok := f.objects[aws.StringValue(in.Key)]; !ok {
+		return nil, awserr.New(s3CodeNotFound, "not found", nil)
+	}
+	return &s3.HeadObjectOutput{}, nil
+}
+
+func TestS3BlobStore(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeS3{objects: map[string][]byte{}}
+	s := NewS3BlobStore(f, "bucket", "attestations/")
+
+	digest, err := s.Put(ctx, []byte("envelope"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest != BlobDigest([]byte("envelope")) {
+		t.Errorf("Put() = %v, want the digest of the content", digest)
+	}
+	if _, ok := f.objects["attestations/"+digest]; !ok {
+		t.Errorf("object not stored under its digest: %v", f.objects)
+	}
+	// storing the same content again finds the object already there
+	if again, err := s.Put(ctx, []byte("envelope")); err != nil || again != digest || f.puts != 1 {
+		t.Errorf("second Put() = %v, %v after %v puts", again, err, f.puts)
+	}
+	if stored, err := s.Has(ctx, BlobDigest([]byte("missing"))); err != nil || stored {
+		t.Errorf("Has() = %v, %v for a missing object", stored, err)
+	}
+	if got, err := s.Get(ctx, digest); err != nil || string(got) != "envelope" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	if _, err := s.Get(ctx, BlobDigest([]byte("missing"))); !errors.Is(err, ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound, got %v", err)
+	}
+	if _, err := s.Get(ctx, "../"+digest[3:]); err == nil {
+		t.Error("expected error for an invalid digest")
+	}
+	f.objects["attestations/"+digest] = []byte("altered")
+	if _, err := s.Get(ctx, digest); err == nil {
+		t.Error("expected error for content that does not match its digest")
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// IndexWrite is a value to add to the list under a key of the search index
+type IndexWrite struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// IndexBackend stores the search index. Most of the index is lists of UUIDs under keys derived from the content
+// of entries; alongside them, the index keeps a sorted set of log indexes by integrated time, sorted sets of the
+// keys that can be searched by prefix, counters for statistics, and single values recording how the index was
+// built.
+type IndexBackend interface {
+	// Write adds value to the head of the list under key
+	Write(ctx context.Context, key, value string) error
+	// WriteBatch adds the value of each write to the head of the list under its key, in order
+	WriteBatch(ctx context.Context, writes []IndexWrite) error
+	// Lookup returns the values of the list under key, newest first; a key that was never written to holds
+	// an empty list
+	Lookup(ctx context.Context, key string) ([]string, error)
+	// LookupBatch returns the values of the list under each key, in the order of keys
+	LookupBatch(ctx context.Context, keys []string) ([][]string, error)
+	// Length returns the number of values in the list under key
+	Length(ctx context.Context, key string) (int64, error)
+	// RangeFromTail returns the values of the list under key from the from-th to the to-th last, newest
+	// first; as values are only added to the head, positions counted from the tail never change
+	RangeFromTail(ctx context.Context, key string, from, to int64) ([]string, error)
+
+	// WriteScore sets the score of member in the sorted set under key
+	WriteScore(ctx context.Context, key, member string, score int64) error
+	// RangeByScore returns up to limit members of the sorted set under key scored between min and max
+	// inclusive, ordered by score, after skipping the first offset of them
+	RangeByScore(ctx context.Context, key string, min, max, offset, limit int64) ([]string, error)
+	// RangeByPrefix returns up to limit members of the sorted set under key that have a score of 0 and start
+	// with prefix, in lexicographic order; sets scanned this way keep every member at a score of 0
+	RangeByPrefix(ctx context.Context, key, prefix string, limit int64) ([]string, error)
+
+	// Increment adds n to the counter named field under key
+	Increment(ctx context.Context, key, field string, n int64) error
+	// Counters returns the value of each counter under key
+	Counters(ctx context.Context, key string) (map[string]int64, error)
+
+	// SetIfAbsent sets the value under key unless one is already set
+	SetIfAbsent(ctx context.Context, key, value string) error
+	// Get returns the value under key, or an empty string if none is set
+	Get(ctx context.Context, key string) (string, error)
+
+	// Scan calls fn with every structure of the index, stopping at the first error it returns; structures
+	// written while scanning may or may not be seen
+	Scan(ctx context.Context, fn func(IndexRecord) error) error
+}
+
+// The types of IndexRecord
+const (
+	RecordList     = "list"
+	RecordScores   = "scores"
+	RecordCounters = "counters"
+	RecordValue    = "value"
+)
+
+// scanChunkSize is the number of members of a sorted set put in a single record by the backends that read the
+// set a part at a time
+const scanChunkSize = 1000
+
+// IndexRecord holds a structure of the index as Scan reads it, so that it can be written to another backend. The
+// members of a large sorted set may be split across several records.
+type IndexRecord struct {
+	Type string `json:"type"`
+	Key  string `json:"key"`
+	// Values holds the values of a list, oldest first
+	Values []string `json:"values,omitempty"`
+	// Scores holds the score of each member of a sorted set, or the value of each counter
+	Scores map[string]int64 `json:"scores,omitempty"`
+	// Value holds a single value
+	Value string `json:"value,omitempty"`
+}
+
+// IndexBackendFactory returns a connected backend, reading its settings from the server configuration
+type IndexBackendFactory func(ctx context.Context) (IndexBackend, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]IndexBackendFactory{}
+)
+
+// RegisterIndexBackend makes the backend returned by factory selectable as name by the index.backend setting;
+// backends built outside of this repository register themselves from an init function of their package, which
+// the server is built with
+func RegisterIndexBackend(name string, factory IndexBackendFactory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
+
+// IndexBackends returns the names of the registered backends
+func IndexBackends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewIndexBackend returns the backend registered as name
+func NewIndexBackend(ctx context.Context, name string) (IndexBackend, error) {
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown index backend '%v', expected one of %v", name, IndexBackends())
+	}
+	return factory(ctx)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// BlobStreamer is implemented by blob stores that can store content read from a file rather than held in memory
+type BlobStreamer interface {
+	// PutReader stores the size bytes of content under digest, which the caller has computed from them, unless
+	// content is already stored under digest
+	PutReader(ctx context.Context, digest string, content io.ReadSeeker, size int64) error
+}
+
+// BlobReader is implemented by blob stores that can read stored content as it is consumed rather than into memory
+type BlobReader interface {
+	// GetReader returns the content stored under digest without checking it against digest
+	GetReader(ctx context.Context, digest string) (io.ReadCloser, error)
+}
+
+// RawBlobStore is implemented by blob stores that can keep content under a digest other than its own, which layers
+// transforming blobs before they are stored, such as encryption, need to store them under the digest of the content
+// they were given
+type RawBlobStore interface {
+	BlobStore
+	BlobStreamer
+	// GetRaw returns the content stored under digest without checking it against digest
+	GetRaw(ctx context.Context, digest string) ([]byte, error)
+}
+
+// BlobSizeError is returned by PutStream for content larger than its limit
+type BlobSizeError struct {
+	Limit int64
+}
+
+func (e *BlobSizeError) Error() string {
+	return fmt.Sprintf("blob exceeds the maximum size of %v bytes", e.Limit)
+}
+
+// PutStream stores the content read from r in store unless it is already stored, returning its digest and size. The
+// content is spooled to a temporary file while its digest is computed, and read from there by stores implementing
+// BlobStreamer, so that large blobs are never held in memory. Content larger than maxSize bytes is rejected with a
+// BlobSizeError as soon as it exceeds it; a maxSize of 0 means no limit.
+func PutStream(ctx context.Context, store BlobStore, r io.Reader, maxSize int64) (string, int64, error) {
+	f, err := ioutil.TempFile("", "rekor-blob-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if maxSize > 0 {
+		// reading one byte more than the limit is enough to detect exceeding it
+		r = io.LimitReader(r, maxSize+1)
+	}
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, h), r)
+	if err != nil {
+		return "", size, err
+	}
+	if maxSize > 0 && size > maxSize {
+		return "", size, &BlobSizeError{Limit: maxSize}
+	}
+	digest := hex.EncodeToString(h.Sum(nil))
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", size, err
+	}
+
+	if streamer, ok := store.(BlobStreamer); ok {
+		return digest, size, streamer.PutReader(ctx, digest, f, size)
+	}
+	if stored, err := store.Has(ctx, digest); err != nil || stored {
+		return digest, size, err
+	}
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", size, err
+	}
+	_, err = store.Put(ctx, content)
+	return digest, size, err
+}
+
+// GetStream returns a reader of the content stored under digest, read as it is consumed from stores implementing
+// BlobReader and from memory otherwise. Reading the content fails at its end, rather than returning io.EOF, if it
+// does not match digest, so that a blob altered in storage is never mistaken for the one logged.
+func GetStream(ctx context.Context, store BlobStore, digest string) (io.ReadCloser, error) {
+	reader, ok := store.(BlobReader)
+	if !ok {
+		content, err := store.Get(ctx, digest)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(content)), nil
+	}
+	r, err := reader.GetReader(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	return &checkedReader{ReadCloser: r, h: sha256.New(), digest: digest}, nil
+}
+
+// checkedReader checks the content it reads against its digest on reaching its end
+type checkedReader struct {
+	io.ReadCloser
+	h      hash.Hash
+	digest string
+}
+
+func (c *checkedReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.h.Write(p[:n])
+	if err == io.EOF {
+		if got := hex.EncodeToString(c.h.Sum(nil)); got != c.digest {
+			return n, fmt.Errorf("blob stored under %v has digest %v", c.digest, got)
+		}
+	}
+	return n, err
+}
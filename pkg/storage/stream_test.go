@@ -0,0 +1,141 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// streamingBlobStore records the blobs stored through PutReader
+type streamingBlobStore struct {
+	*MemoryBlobStore
+	streamed int
+}
+
+func (s *streamingBlobStore) PutReader(ctx context.Context, digest string, content io.ReadSeeker, size int64) error {
+	s.streamed++
+	b, err := ioutil.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	if int64(len(b)) != size {
+		return errors.New("size does not match content")
+	}
+	if err := checkBlob(digest, b); err != nil {
+		return err
+	}
+	_, err = s.Put(ctx, b)
+	return err
+}
+
+// bufferedBlobStore only stores content held in memory
+type bufferedBlobStore struct {
+	BlobStore
+}
+
+func TestPutStream(t *testing.T) {
+	ctx := context.Background()
+	content := strings.Repeat("attestation", 1000)
+	streaming := &streamingBlobStore{MemoryBlobStore: NewMemoryBlobStore()}
+
+	tests := []struct {
+		caseDesc      string
+		store         BlobStore
+		maxSize       int64
+		expectSuccess bool
+	}{
+		{caseDesc: "store reading from the spooled file", store: streaming, expectSuccess: true},
+		{caseDesc: "store reading from memory", store: bufferedBlobStore{NewMemoryBlobStore()}, expectSuccess: true},
+		{caseDesc: "content at the limit", store: NewMemoryBlobStore(), maxSize: int64(len(content)), expectSuccess: true},
+		{caseDesc: "content over the limit", store: NewMemoryBlobStore(), maxSize: int64(len(content)) - 1, expectSuccess: false},
+	}
+	for _, tc := range tests {
+		digest, size, err := PutStream(ctx, tc.store, strings.NewReader(content), tc.maxSize)
+		if !tc.expectSuccess {
+			var sizeErr *BlobSizeError
+			if !errors.As(err, &sizeErr) {
+				t.Errorf("expected BlobSizeError in '%v', got %v", tc.caseDesc, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error in '%v': %v", tc.caseDesc, err)
+			continue
+		}
+		if digest != BlobDigest([]byte(content)) || size != int64(len(content)) {
+			t.Errorf("unexpected digest %v or size %v in '%v'", digest, size, tc.caseDesc)
+		}
+		if got, err := tc.store.Get(ctx, digest); err != nil || string(got) != content {
+			t.Errorf("content not stored in '%v': %v", tc.caseDesc, err)
+		}
+	}
+	if streaming.streamed != 1 {
+		t.Errorf("expected content to be streamed once, got %v", streaming.streamed)
+	}
+}
+
+func TestGetStream(t *testing.T) {
+	ctx := context.Background()
+	f := &fakeS3{objects: map[string][]byte{}}
+	s3Store := NewS3BlobStore(f, "bucket", "")
+	memory := NewMemoryBlobStore()
+	for _, store := range []BlobStore{s3Store, memory} {
+		if _, err := store.Put(ctx, []byte("envelope")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	digest := BlobDigest([]byte("envelope"))
+
+	tests := []struct {
+		caseDesc      string
+		store         BlobStore
+		digest        string
+		altered       bool
+		expectSuccess bool
+	}{
+		{caseDesc: "store reading as the content is consumed", store: s3Store, digest: digest, expectSuccess: true},
+		{caseDesc: "store reading into memory", store: memory, digest: digest, expectSuccess: true},
+		{caseDesc: "content altered in storage", store: s3Store, digest: digest, altered: true},
+		{caseDesc: "content never stored", store: s3Store, digest: BlobDigest([]byte("missing"))},
+	}
+	for _, tc := range tests {
+		f.objects[digest] = []byte("envelope")
+		if tc.altered {
+			f.objects[digest] = []byte("altered")
+		}
+		r, err := GetStream(ctx, tc.store, tc.digest)
+		if err != nil {
+			if tc.expectSuccess {
+				t.Errorf("unexpected error in '%v': %v", tc.caseDesc, err)
+			}
+			continue
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected error in '%v': %v", tc.caseDesc, err)
+		}
+		if tc.expectSuccess && string(got) != "envelope" {
+			t.Errorf("unexpected content %q in '%v'", got, tc.caseDesc)
+		}
+	}
+}
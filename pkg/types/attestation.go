@@ -0,0 +1,109 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+// AttestationStorage keeps attestations, such as signature envelopes, out of the log, addressed by the lowercase
+// hex encoded SHA-256 digest of their content; it is implemented by the blob stores of pkg/storage
+type AttestationStorage interface {
+	Put(ctx context.Context, content []byte) (string, error)
+	Get(ctx context.Context, digest string) ([]byte, error)
+	Has(ctx context.Context, digest string) (bool, error)
+}
+
+// AttestationReferences is implemented by kinds whose entries may keep attestations in attestation storage, so that
+// the entries referring to each stored attestation are recorded
+type AttestationReferences interface {
+	// StoredAttestationDigests returns the digests of the attestations the entry keeps in attestation storage
+	StoredAttestationDigests() []string
+}
+
+// PendingAttestations is implemented by kinds whose entries log attestations by their digest alone, which are only
+// stored once the entry has been added to the log, so that entries that are rejected or only validated never leave
+// attestations nothing refers to in attestation storage
+type PendingAttestations interface {
+	// PendingAttestations returns the attestations to store once the entry has been added to the log
+	PendingAttestations() [][]byte
+}
+
+var (
+	attestationMu      sync.RWMutex
+	attestationStorage AttestationStorage
+	maxAttestationSize int64
+)
+
+// SetAttestationStorage makes kinds that carry attestations store them in s and log only their digest, or keep
+// them inline in the entries they log if s is nil
+func SetAttestationStorage(s AttestationStorage) {
+	attestationMu.Lock()
+	defer attestationMu.Unlock()
+	attestationStorage = s
+}
+
+// StoredAttestations returns the storage set by SetAttestationStorage, or nil if attestations are kept inline
+func StoredAttestations() AttestationStorage {
+	attestationMu.RLock()
+	defer attestationMu.RUnlock()
+	return attestationStorage
+}
+
+// AttestationSizeError is returned for attestations larger than the size set by SetMaxAttestationSize
+type AttestationSizeError struct {
+	Limit int64
+}
+
+func (e *AttestationSizeError) Error() string {
+	return fmt.Sprintf("attestation exceeds the maximum size of %v bytes", e.Limit)
+}
+
+// SetMaxAttestationSize limits the attestations kinds accept to n bytes, or lifts the limit if n is 0
+func SetMaxAttestationSize(n int64) {
+	attestationMu.Lock()
+	defer attestationMu.Unlock()
+	maxAttestationSize = n
+}
+
+// MaxAttestationSize returns the limit set by SetMaxAttestationSize
+func MaxAttestationSize() int64 {
+	attestationMu.RLock()
+	defer attestationMu.RUnlock()
+	return maxAttestationSize
+}
+
+// ReadAttestation reads an attestation from r, stopping with an AttestationSizeError as soon as it exceeds the
+// maximum size
+func ReadAttestation(r io.Reader) ([]byte, error) {
+	max := MaxAttestationSize()
+	if max <= 0 {
+		return ioutil.ReadAll(r)
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > max {
+		return nil, &AttestationSizeError{Limit: max}
+	}
+	return content, nil
+}
@@ -0,0 +1,308 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package commitment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types/commitment"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	commitment.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	CommitmentObj           models.CommitmentV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if v.keyObj != nil {
+		if key, err := pki.KeyIndex(v.keyObj); err != nil {
+			log.Logger.Error(err)
+		} else {
+			result = append(result, key)
+		}
+	}
+
+	if v.CommitmentObj.Ciphertext != nil && v.CommitmentObj.Ciphertext.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.CommitmentObj.Ciphertext.Hash.Value)))
+	}
+
+	if v.CommitmentObj.PlaintextCommitment != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.CommitmentObj.PlaintextCommitment.Value)))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	commitment, ok := pe.(*models.Commitment)
+	if !ok {
+		return errors.New("cannot unmarshal non commitment v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(commitment.Spec, &v.CommitmentObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.CommitmentObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.CommitmentObj.Ciphertext != nil && v.CommitmentObj.Ciphertext.URL.String() != "" {
+		return true
+	}
+	if v.CommitmentObj.Signature != nil && v.CommitmentObj.Signature.URL.String() != "" {
+		return true
+	}
+	if v.CommitmentObj.Signature != nil && v.CommitmentObj.Signature.PublicKey != nil && v.CommitmentObj.Signature.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	ciphertext := v.CommitmentObj.Ciphertext
+	ciphertextSHA := strings.ToLower(swag.StringValue(ciphertext.Hash.Value))
+	artifactFactory := pki.NewArtifactFactory(v.CommitmentObj.Signature.Format)
+
+	// the ciphertext is optional; when it is provided, it is only used to check the hash
+	if len(ciphertext.Content) > 0 || ciphertext.URL.String() != "" {
+		g.Go(func() error {
+			ciphertextReadCloser, err := util.FileOrURLReadCloser(ctx, ciphertext.URL.String(), ciphertext.Content)
+			if err != nil {
+				return err
+			}
+			defer ciphertextReadCloser.Close()
+
+			hasher := sha256.New()
+			/* #nosec G110 */
+			if _, err := io.Copy(hasher, ciphertextReadCloser); err != nil {
+				return err
+			}
+			computedSHA := hex.EncodeToString(hasher.Sum(nil))
+			if computedSHA != ciphertextSHA {
+				return fmt.Errorf("SHA mismatch: %s != %s", computedSHA, ciphertextSHA)
+			}
+			return nil
+		})
+	}
+
+	var signature pki.Signature
+	g.Go(func() error {
+		sigReadCloser, err := util.FileOrURLReadCloser(ctx, v.CommitmentObj.Signature.URL.String(),
+			v.CommitmentObj.Signature.Content)
+		if err != nil {
+			return err
+		}
+		defer sigReadCloser.Close()
+
+		signature, err = artifactFactory.NewSignature(sigReadCloser)
+		return err
+	})
+
+	var key pki.PublicKey
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.CommitmentObj.Signature.PublicKey.URL.String(),
+			v.CommitmentObj.Signature.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		key, err = artifactFactory.NewPublicKey(keyReadCloser)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	statement := Statement(ciphertextSHA, swag.StringValue(v.CommitmentObj.PlaintextCommitment.Value))
+	signers, err := pki.VerifySigners(signature, bytes.NewReader(statement), key)
+	if err != nil {
+		return &types.VerificationError{Err: err}
+	}
+
+	// if we get here, the signature over the commitment statement was verified without error
+	v.keyObj, v.sigObj = signers, signature
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.sigObj == nil {
+		return nil, errors.New("signature object not initialized before canonicalization")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.CommitmentV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.CommitmentV001SchemaSignature{}
+	// signature URL (if known) is not set deliberately
+	canonicalEntry.Signature.Format = v.CommitmentObj.Signature.Format
+
+	var err error
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// key URL (if known) is not set deliberately
+	canonicalEntry.Signature.PublicKey = &models.CommitmentV001SchemaSignaturePublicKey{}
+	canonicalEntry.Signature.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// ciphertext content and URL are not set deliberately; only the commitment is logged
+	canonicalEntry.Ciphertext = &models.CommitmentV001SchemaCiphertext{}
+	canonicalEntry.Ciphertext.Hash = &models.CommitmentV001SchemaCiphertextHash{
+		Algorithm: v.CommitmentObj.Ciphertext.Hash.Algorithm,
+		Value:     swag.String(strings.ToLower(swag.StringValue(v.CommitmentObj.Ciphertext.Hash.Value))),
+	}
+	canonicalEntry.PlaintextCommitment = v.CommitmentObj.PlaintextCommitment
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.CommitmentObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	commitmentObj := models.Commitment{}
+	commitmentObj.APIVersion = swag.String(APIVERSION)
+	commitmentObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&commitmentObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	ciphertext := v.CommitmentObj.Ciphertext
+	if ciphertext == nil {
+		return errors.New("missing ciphertext")
+	}
+	if ciphertext.Hash == nil {
+		return errors.New("missing ciphertext hash")
+	}
+	if !govalidator.IsHash(swag.StringValue(ciphertext.Hash.Value), swag.StringValue(ciphertext.Hash.Algorithm)) {
+		return errors.New("invalid value for ciphertext hash")
+	}
+
+	if v.CommitmentObj.PlaintextCommitment == nil {
+		return errors.New("missing plaintext commitment")
+	}
+
+	sig := v.CommitmentObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if !pki.IsFormat(sig.Format) {
+		return fmt.Errorf("unsupported signature format %q, expected one of %v", sig.Format, pki.Formats())
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	return nil
+}
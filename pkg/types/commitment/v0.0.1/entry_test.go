@@ -0,0 +1,399 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commitment
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.uber.org/goleak"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+var (
+	testPlaintext  = []byte("the confidential artifact")
+	testCiphertext = []byte("the artifact after encryption")
+	testSalt       = []byte("0123456789abcdef")
+)
+
+func signStatement(t *testing.T, statement []byte) (sig []byte, pubKey []byte) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Artifact Publisher", "", "publisher@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sigBuf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sigBuf, entity, bytes.NewReader(statement), nil); err != nil {
+		t.Fatal(err)
+	}
+	var keyBuf bytes.Buffer
+	w, err := armor.Encode(&keyBuf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return sigBuf.Bytes(), keyBuf.Bytes()
+}
+
+func testCommitment(t *testing.T) (ciphertextSHA, plaintextCommitment string) {
+	t.Helper()
+	h := sha256.Sum256(testCiphertext)
+	commitment, err := PlaintextCommitment(testSalt, bytes.NewReader(testPlaintext))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(h[:]), commitment
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	ciphertextSHA, plaintextCommitment := testCommitment(t)
+	sigBytes, keyBytes := signStatement(t, Statement(ciphertextSHA, plaintextCommitment))
+	_, otherKeyBytes := signStatement(t, Statement(ciphertextSHA, plaintextCommitment))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &sigBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/signature":
+				file = &sigBytes
+			case "/key":
+				file = &keyBytes
+			case "/ciphertext":
+				file = &testCiphertext
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	ciphertextHash := func(value string) *models.CommitmentV001SchemaCiphertextHash {
+		return &models.CommitmentV001SchemaCiphertextHash{
+			Algorithm: swag.String(models.CommitmentV001SchemaCiphertextHashAlgorithmSha256),
+			Value:     swag.String(value),
+		}
+	}
+	commitment := func(value string) *models.CommitmentV001SchemaPlaintextCommitment {
+		return &models.CommitmentV001SchemaPlaintextCommitment{
+			Algorithm: swag.String(models.CommitmentV001SchemaPlaintextCommitmentAlgorithmHmacSha256),
+			Value:     swag.String(value),
+		}
+	}
+	urlSignature := func() *models.CommitmentV001SchemaSignature {
+		return &models.CommitmentV001SchemaSignature{
+			Format: "pgp",
+			URL:    strfmt.URI(testServer.URL + "/signature"),
+			PublicKey: &models.CommitmentV001SchemaSignaturePublicKey{
+				URL: strfmt.URI(testServer.URL + "/key"),
+			},
+		}
+	}
+	contentSignature := func(key []byte) *models.CommitmentV001SchemaSignature {
+		return &models.CommitmentV001SchemaSignature{
+			Format:  "pgp",
+			Content: strfmt.Base64(sigBytes),
+			PublicKey: &models.CommitmentV001SchemaSignaturePublicKey{
+				Content: strfmt.Base64(key),
+			},
+		}
+	}
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without ciphertext",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           urlSignature(),
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "ciphertext without hash",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						URL: strfmt.URI(testServer.URL + "/ciphertext"),
+					},
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           urlSignature(),
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "missing plaintext commitment",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash: ciphertextHash(ciphertextSHA),
+					},
+					Signature: urlSignature(),
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature and key by url",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash: ciphertextHash(ciphertextSHA),
+					},
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           urlSignature(),
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "ciphertext url with matching hash",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash: ciphertextHash(ciphertextSHA),
+						URL:  strfmt.URI(testServer.URL + "/ciphertext"),
+					},
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           contentSignature(keyBytes),
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "ciphertext content with incorrect hash",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash:    ciphertextHash("3030303030303030303030303030303030303030303030303030303030303030"),
+						Content: strfmt.Base64(testCiphertext),
+					},
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           contentSignature(keyBytes),
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature over a different commitment",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash: ciphertextHash(ciphertextSHA),
+					},
+					PlaintextCommitment: commitment(ciphertextSHA),
+					Signature:           contentSignature(keyBytes),
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature by a different key",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash: ciphertextHash(ciphertextSHA),
+					},
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           contentSignature(otherKeyBytes),
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature content & key content",
+			entry: V001Entry{
+				CommitmentObj: models.CommitmentV001Schema{
+					Ciphertext: &models.CommitmentV001SchemaCiphertext{
+						Hash: ciphertextHash(ciphertextSHA),
+					},
+					PlaintextCommitment: commitment(plaintextCommitment),
+					Signature:           contentSignature(keyBytes),
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Commitment{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.CommitmentObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestCanonicalizeOmitsCiphertext(t *testing.T) {
+	ciphertextSHA, plaintextCommitment := testCommitment(t)
+	sigBytes, keyBytes := signStatement(t, Statement(ciphertextSHA, plaintextCommitment))
+
+	v := V001Entry{
+		CommitmentObj: models.CommitmentV001Schema{
+			Ciphertext: &models.CommitmentV001SchemaCiphertext{
+				Hash: &models.CommitmentV001SchemaCiphertextHash{
+					Algorithm: swag.String(models.CommitmentV001SchemaCiphertextHashAlgorithmSha256),
+					Value:     swag.String(ciphertextSHA),
+				},
+				Content: strfmt.Base64(testCiphertext),
+			},
+			PlaintextCommitment: &models.CommitmentV001SchemaPlaintextCommitment{
+				Algorithm: swag.String(models.CommitmentV001SchemaPlaintextCommitmentAlgorithmHmacSha256),
+				Value:     swag.String(plaintextCommitment),
+			},
+			Signature: &models.CommitmentV001SchemaSignature{
+				Format:  "pgp",
+				Content: strfmt.Base64(sigBytes),
+				PublicKey: &models.CommitmentV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(keyBytes),
+				},
+			},
+		},
+	}
+	b, err := v.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatalf("unexpected error canonicalizing: %v", err)
+	}
+	canonical := struct {
+		Spec models.CommitmentV001Schema `json:"spec"`
+	}{}
+	if err := json.Unmarshal(b, &canonical); err != nil {
+		t.Fatal(err)
+	}
+	if len(canonical.Spec.Ciphertext.Content) != 0 || canonical.Spec.Ciphertext.URL != "" {
+		t.Error("canonical entry must not carry the ciphertext")
+	}
+	if bytes.Contains(b, testPlaintext) || bytes.Contains(b, testSalt) {
+		t.Error("canonical entry must not carry the plaintext or salt")
+	}
+
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{ciphertextSHA, plaintextCommitment} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
+
+func TestVerifyDisclosure(t *testing.T) {
+	_, plaintextCommitment := testCommitment(t)
+	spec := models.CommitmentV001Schema{
+		PlaintextCommitment: &models.CommitmentV001SchemaPlaintextCommitment{
+			Algorithm: swag.String(models.CommitmentV001SchemaPlaintextCommitmentAlgorithmHmacSha256),
+			Value:     swag.String(plaintextCommitment),
+		},
+	}
+
+	if err := VerifyDisclosure(spec, testSalt, bytes.NewReader(testPlaintext)); err != nil {
+		t.Errorf("unexpected error verifying disclosure: %v", err)
+	}
+	if err := VerifyDisclosure(spec, []byte("fedcba9876543210"), bytes.NewReader(testPlaintext)); err == nil {
+		t.Error("expected error verifying disclosure with the wrong salt")
+	}
+	if err := VerifyDisclosure(spec, testSalt, bytes.NewReader(testCiphertext)); err == nil {
+		t.Error("expected error verifying disclosure of a different plaintext")
+	}
+	if err := VerifyDisclosure(models.CommitmentV001Schema{}, testSalt, bytes.NewReader(testPlaintext)); err == nil {
+		t.Error("expected error verifying disclosure against an entry without a commitment")
+	}
+	if _, err := PlaintextCommitment([]byte("short"), bytes.NewReader(testPlaintext)); err == nil {
+		t.Error("expected error computing commitment with a short salt")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package commitment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// MinSaltSize is the smallest salt accepted when computing a plaintext commitment; shorter salts would
+// allow the plaintext of low entropy artifacts to be recovered by guessing
+const MinSaltSize = 16
+
+// Statement returns the message the signature of an entry is made over. Signing the statement rather
+// than the artifact binds the signer to both the ciphertext and the plaintext it decrypts to.
+func Statement(ciphertextSHA256, plaintextCommitment string) []byte {
+	return []byte(fmt.Sprintf("rekor commitment v0.0.1\nciphertext sha256:%v\nplaintext hmac-sha256:%v\n",
+		strings.ToLower(ciphertextSHA256), strings.ToLower(plaintextCommitment)))
+}
+
+// PlaintextCommitment returns the hex encoded HMAC-SHA256 of plaintext keyed with salt
+func PlaintextCommitment(salt []byte, plaintext io.Reader) (string, error) {
+	if len(salt) < MinSaltSize {
+		return "", fmt.Errorf("salt must be at least %d bytes", MinSaltSize)
+	}
+	mac := hmac.New(sha256.New, salt)
+	if _, err := io.Copy(mac, plaintext); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// VerifyDisclosure checks that a plaintext and salt disclosed after the entry was logged open its
+// commitment. Together with the inclusion proof of the entry, this shows the plaintext existed no
+// later than the time the entry was integrated into the log.
+func VerifyDisclosure(spec models.CommitmentV001Schema, salt []byte, plaintext io.Reader) error {
+	if spec.PlaintextCommitment == nil {
+		return errors.New("entry has no plaintext commitment")
+	}
+	computed, err := PlaintextCommitment(salt, plaintext)
+	if err != nil {
+		return err
+	}
+	committed := strings.ToLower(swag.StringValue(spec.PlaintextCommitment.Value))
+	if subtle.ConstantTimeCompare([]byte(computed), []byte(committed)) != 1 {
+		return errors.New("disclosed plaintext and salt do not match the commitment")
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dct
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/types/dct"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+// defaultRole is the top-level targets role of a Notary repository
+const defaultRole = "targets"
+
+func init() {
+	dct.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	DctObj                  models.DctV001Schema
+	fetchedExternalEntities bool
+	metadataBytes           []byte
+	keyObj                  *roleKey
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if v.keyObj != nil {
+		if canonical, err := v.keyObj.canonical(); err != nil {
+			log.Logger.Error(err)
+		} else if key, err := pki.CanonicalKeyIndex(canonical); err != nil {
+			log.Logger.Error(err)
+		} else {
+			result = append(result, key)
+		}
+	}
+
+	if v.DctObj.Metadata.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.DctObj.Metadata.Hash.Value)))
+	}
+
+	for _, t := range v.DctObj.Targets {
+		result = append(result, strings.ToLower(swag.StringValue(t.Digest)))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	dct, ok := pe.(*models.Dct)
+	if !ok {
+		return errors.New("cannot unmarshal non Docker Content Trust v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(dct.Spec, &v.DctObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.DctObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.DctObj.Metadata != nil && v.DctObj.Metadata.URL.String() != "" {
+		return true
+	}
+	if v.DctObj.PublicKey != nil && v.DctObj.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	oldSHA := ""
+	if v.DctObj.Metadata.Hash != nil && v.DctObj.Metadata.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.DctObj.Metadata.Hash.Value)
+	}
+
+	var metadataBytes []byte
+	g.Go(func() error {
+		metadataReadCloser, err := util.FileOrURLReadCloser(ctx, v.DctObj.Metadata.URL.String(), v.DctObj.Metadata.Content)
+		if err != nil {
+			return err
+		}
+		defer metadataReadCloser.Close()
+
+		metadataBytes, err = ioutil.ReadAll(metadataReadCloser)
+		return err
+	})
+
+	var key *roleKey
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.DctObj.PublicKey.URL.String(),
+			v.DctObj.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		keyBytes, err := ioutil.ReadAll(keyReadCloser)
+		if err != nil {
+			return err
+		}
+		key, err = parseRoleKey(keyBytes)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	computedSHA := sha256.Sum256(metadataBytes)
+	computedSHAStr := hex.EncodeToString(computedSHA[:])
+	if oldSHA != "" && computedSHAStr != oldSHA {
+		return fmt.Errorf("SHA mismatch: %s != %s", computedSHAStr, oldSHA)
+	}
+
+	targets, err := verifyTargets(metadataBytes, key)
+	if err != nil {
+		return err
+	}
+	verifiedTargets := make([]*models.DctV001SchemaTargetsItems0, 0, len(targets))
+	for _, t := range targets {
+		verifiedTargets = append(verifiedTargets, &models.DctV001SchemaTargetsItems0{
+			Name:   swag.String(t.name),
+			Digest: swag.String(t.digest),
+			Length: swag.Int64(t.length),
+		})
+	}
+	if v.DctObj.Targets != nil && !reflect.DeepEqual(v.DctObj.Targets, verifiedTargets) {
+		return errors.New("targets do not match the signed targets metadata")
+	}
+
+	// if we get here, the metadata signature was verified without error
+	v.metadataBytes = metadataBytes
+	v.keyObj = key
+	if oldSHA == "" {
+		v.DctObj.Metadata.Hash = &models.DctV001SchemaMetadataHash{}
+		v.DctObj.Metadata.Hash.Algorithm = swag.String(models.DctV001SchemaMetadataHashAlgorithmSha256)
+		v.DctObj.Metadata.Hash.Value = swag.String(computedSHAStr)
+	}
+	v.DctObj.Targets = verifiedTargets
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.DctV001Schema{}
+	canonicalEntry.Gun = v.DctObj.Gun
+	canonicalEntry.Role = v.DctObj.Role
+	if canonicalEntry.Role == "" {
+		canonicalEntry.Role = defaultRole
+	}
+
+	// key URL (if known) is not set deliberately
+	key, err := v.keyObj.canonical()
+	if err != nil {
+		return nil, err
+	}
+	canonicalEntry.PublicKey = &models.DctV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content = strfmt.Base64(key)
+
+	// the metadata is kept inline since it carries the signatures; its URL (if known) is not set deliberately
+	canonicalEntry.Metadata = &models.DctV001SchemaMetadata{}
+	canonicalEntry.Metadata.Hash = v.DctObj.Metadata.Hash
+	canonicalEntry.Metadata.Content = strfmt.Base64(v.metadataBytes)
+
+	canonicalEntry.Targets = v.DctObj.Targets
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.DctObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	dctObj := models.Dct{}
+	dctObj.APIVersion = swag.String(APIVERSION)
+	dctObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&dctObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	if swag.StringValue(v.DctObj.Gun) == "" {
+		return errors.New("missing gun")
+	}
+
+	key := v.DctObj.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	metadata := v.DctObj.Metadata
+	if metadata == nil {
+		return errors.New("missing metadata")
+	}
+
+	if len(metadata.Content) == 0 && metadata.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for metadata")
+	}
+
+	hash := metadata.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	return nil
+}
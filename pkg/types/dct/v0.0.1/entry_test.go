@@ -0,0 +1,410 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dct
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+var testManifest = []byte(`{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json"}`)
+
+// signTargets produces targets metadata listing testManifest as "latest", laid out the way a Notary server serves it
+func signTargets(t *testing.T, priv crypto.Signer, method, roleType string) []byte {
+	t.Helper()
+	manifestSHA := sha256.Sum256(testManifest)
+	signed, err := json.Marshal(map[string]interface{}{
+		"_type":       roleType,
+		"delegations": map[string]interface{}{"keys": map[string]interface{}{}, "roles": []interface{}{}},
+		"expires":     "2031-06-01T00:00:00Z",
+		"targets": map[string]interface{}{
+			"latest": map[string]interface{}{
+				"hashes": map[string][]byte{"sha256": manifestSHA[:]},
+				"length": len(testManifest),
+			},
+		},
+		"version": 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical, err := canonicalJSON(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sig []byte
+	switch k := priv.(type) {
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(canonical)
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[32-len(rb):32], rb)
+		copy(sig[64-len(sb):], sb)
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, canonical)
+	default:
+		t.Fatalf("unexpected key type %T", priv)
+	}
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"signed":     json.RawMessage(signed),
+		"signatures": []tufSignature{{KeyID: "0123456789abcdef", Method: method, Sig: sig}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata
+}
+
+func testECDSAKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	priv, keyBytes := testECDSAKey(t)
+	metadataBytes := signTargets(t, priv, "ecdsa", "Targets")
+	_, otherKeyBytes := testECDSAKey(t)
+	rootBytes := signTargets(t, priv, "ecdsa", "Root")
+	wrongMethodBytes := signTargets(t, priv, "rsapss", "Targets")
+
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tufKeyBytes, err := json.Marshal(map[string]interface{}{
+		"keytype": "ecdsa",
+		"keyval":  map[string]interface{}{"private": nil, "public": der},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edMetadataBytes := signTargets(t, edPriv, "ed25519", "Targets")
+	edKeyBytes, err := json.Marshal(map[string]interface{}{
+		"keytype": "ed25519",
+		"keyval":  map[string]interface{}{"private": nil, "public": []byte(edPub)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := sha256.New()
+	_, _ = h.Write(metadataBytes)
+	metadataSHA := hex.EncodeToString(h.Sum(nil))
+	manifestSHA := sha256.Sum256(testManifest)
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &metadataBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/key":
+				file = &keyBytes
+			case "/targets.json":
+				file = &metadataBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	gun := swag.String("docker.example.com/library/app")
+	contentEntry := func(key, metadata []byte) V001Entry {
+		return V001Entry{
+			DctObj: models.DctV001Schema{
+				Gun: gun,
+				PublicKey: &models.DctV001SchemaPublicKey{
+					Content: strfmt.Base64(key),
+				},
+				Metadata: &models.DctV001SchemaMetadata{
+					Content: strfmt.Base64(metadata),
+				},
+			},
+		}
+	}
+
+	mismatchedTargets := contentEntry(keyBytes, metadataBytes)
+	mismatchedTargets.DctObj.Targets = []*models.DctV001SchemaTargetsItems0{
+		{
+			Name:   swag.String("latest"),
+			Digest: swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+			Length: swag.Int64(int64(len(testManifest))),
+		},
+	}
+	matchingTargets := contentEntry(keyBytes, metadataBytes)
+	matchingTargets.DctObj.Role = "targets/releases"
+	matchingTargets.DctObj.Targets = []*models.DctV001SchemaTargetsItems0{
+		{
+			Name:   swag.String("latest"),
+			Digest: swag.String(hex.EncodeToString(manifestSHA[:])),
+			Length: swag.Int64(int64(len(testManifest))),
+		},
+	}
+	noGun := contentEntry(keyBytes, metadataBytes)
+	noGun.DctObj.Gun = nil
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc:               "missing gun",
+			entry:                  noGun,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key without metadata",
+			entry: V001Entry{
+				DctObj: models.DctV001Schema{
+					Gun: gun,
+					PublicKey: &models.DctV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "metadata and key url",
+			entry: V001Entry{
+				DctObj: models.DctV001Schema{
+					Gun: gun,
+					PublicKey: &models.DctV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Metadata: &models.DctV001SchemaMetadata{
+						URL: strfmt.URI(testServer.URL + "/targets.json"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "metadata url with incorrect hash value",
+			entry: V001Entry{
+				DctObj: models.DctV001Schema{
+					Gun: gun,
+					PublicKey: &models.DctV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Metadata: &models.DctV001SchemaMetadata{
+						Hash: &models.DctV001SchemaMetadataHash{
+							Algorithm: swag.String(models.DctV001SchemaMetadataHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/targets.json"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "metadata url with correct hash value",
+			entry: V001Entry{
+				DctObj: models.DctV001Schema{
+					Gun: gun,
+					PublicKey: &models.DctV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Metadata: &models.DctV001SchemaMetadata{
+						Hash: &models.DctV001SchemaMetadataHash{
+							Algorithm: swag.String(models.DctV001SchemaMetadataHashAlgorithmSha256),
+							Value:     swag.String(metadataSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/targets.json"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc:                  "TUF key object",
+			entry:                     contentEntry(tufKeyBytes, metadataBytes),
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc:                  "ed25519 TUF key object",
+			entry:                     contentEntry(edKeyBytes, edMetadataBytes),
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc:                  "metadata signed by a different key",
+			entry:                     contentEntry(otherKeyBytes, metadataBytes),
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc:                  "root rather than targets metadata",
+			entry:                     contentEntry(keyBytes, rootBytes),
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc:                  "signature method not matching the key",
+			entry:                     contentEntry(keyBytes, wrongMethodBytes),
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc:                  "targets not matching the metadata",
+			entry:                     mismatchedTargets,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc:                  "delegated role with matching targets",
+			entry:                     matchingTargets,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Dct{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.DctObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	priv, keyBytes := testECDSAKey(t)
+	metadataBytes := signTargets(t, priv, "ecdsa", "Targets")
+
+	v := V001Entry{
+		DctObj: models.DctV001Schema{
+			Gun: swag.String("docker.example.com/library/app"),
+			PublicKey: &models.DctV001SchemaPublicKey{
+				Content: strfmt.Base64(keyBytes),
+			},
+			Metadata: &models.DctV001SchemaMetadata{
+				Content: strfmt.Base64(metadataBytes),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	metadataSHA := sha256.Sum256(metadataBytes)
+	keySHA := sha256.Sum256(keyBytes)
+	manifestSHA := sha256.Sum256(testManifest)
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{
+		hex.EncodeToString(metadataSHA[:]),
+		hex.EncodeToString(keySHA[:]),
+		hex.EncodeToString(manifestSHA[:]),
+	} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
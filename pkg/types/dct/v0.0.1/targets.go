@@ -0,0 +1,235 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package dct
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/sigstore/rekor/pkg/types"
+)
+
+// signedMetadata is the envelope of every TUF metadata file served by Notary
+type signedMetadata struct {
+	Signed     json.RawMessage `json:"signed"`
+	Signatures []tufSignature  `json:"signatures"`
+}
+
+type tufSignature struct {
+	KeyID  string `json:"keyid"`
+	Method string `json:"method"`
+	Sig    []byte `json:"sig"`
+}
+
+type targetsRole struct {
+	Type    string                `json:"_type"`
+	Version int64                 `json:"version"`
+	Targets map[string]targetFile `json:"targets"`
+}
+
+type targetFile struct {
+	Hashes map[string][]byte `json:"hashes"`
+	Length int64             `json:"length"`
+}
+
+// tufKey is a public key as listed in the keys of a Notary root.json
+type tufKey struct {
+	KeyType string `json:"keytype"`
+	KeyVal  struct {
+		Public []byte `json:"public"`
+	} `json:"keyval"`
+}
+
+// target is a single entry of verified targets metadata
+type target struct {
+	name   string
+	digest string
+	length int64
+}
+
+// roleKey is the public key of the role that signed the metadata, along with the
+// certificate it was taken from (if any)
+type roleKey struct {
+	pub  crypto.PublicKey
+	cert *x509.Certificate
+}
+
+// parseRoleKey accepts a PEM or DER encoded certificate or PKIX public key, or a TUF key object
+func parseRoleKey(b []byte) (*roleKey, error) {
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		k := tufKey{}
+		if err := json.Unmarshal(trimmed, &k); err != nil {
+			return nil, fmt.Errorf("error parsing TUF key: %w", err)
+		}
+		switch k.KeyType {
+		case "ecdsa-x509", "rsa-x509":
+			return parseRoleKey(k.KeyVal.Public)
+		case "ecdsa", "rsa":
+			pub, err := x509.ParsePKIXPublicKey(k.KeyVal.Public)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing TUF key: %w", err)
+			}
+			return &roleKey{pub: pub}, nil
+		case "ed25519":
+			if len(k.KeyVal.Public) != ed25519.PublicKeySize {
+				return nil, errors.New("malformed ed25519 TUF key")
+			}
+			return &roleKey{pub: ed25519.PublicKey(k.KeyVal.Public)}, nil
+		default:
+			return nil, fmt.Errorf("unsupported TUF key type %q", k.KeyType)
+		}
+	}
+
+	der := b
+	if block, _ := pem.Decode(b); block != nil {
+		der = block.Bytes
+	}
+	if cert, err := x509.ParseCertificate(der); err == nil {
+		return &roleKey{pub: cert.PublicKey, cert: cert}, nil
+	}
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.New("public key is neither a certificate, a PKIX public key nor a TUF key")
+	}
+	return &roleKey{pub: pub}, nil
+}
+
+// canonical returns the PEM encoding of the key, matching the canonical value of x509 public keys
+func (k *roleKey) canonical() ([]byte, error) {
+	if k.cert != nil {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: k.cert.Raw}), nil
+	}
+	der, err := x509.MarshalPKIXPublicKey(k.pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// verifyTargets checks that metadata is targets metadata carrying at least one signature by key,
+// and returns the targets it lists sorted by name
+func verifyTargets(metadata []byte, key *roleKey) ([]target, error) {
+	m := signedMetadata{}
+	if err := json.Unmarshal(metadata, &m); err != nil {
+		return nil, fmt.Errorf("error parsing targets metadata: %w", err)
+	}
+	if len(m.Signed) == 0 {
+		return nil, errors.New("targets metadata has no signed section")
+	}
+
+	signed, err := canonicalJSON(m.Signed)
+	if err != nil {
+		return nil, fmt.Errorf("error canonicalizing targets metadata: %w", err)
+	}
+	verified := false
+	for _, sig := range m.Signatures {
+		if verifySignature(key.pub, sig, signed) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, &types.VerificationError{Err: errors.New("targets metadata is not signed by the supplied public key")}
+	}
+
+	role := targetsRole{}
+	if err := json.Unmarshal(m.Signed, &role); err != nil {
+		return nil, fmt.Errorf("error parsing targets metadata: %w", err)
+	}
+	if role.Type != "Targets" {
+		return nil, fmt.Errorf("metadata is of type %q rather than Targets", role.Type)
+	}
+
+	targets := make([]target, 0, len(role.Targets))
+	for name, t := range role.Targets {
+		sha := t.Hashes["sha256"]
+		if len(sha) != sha256.Size {
+			return nil, fmt.Errorf("target %v does not have a sha256 hash", name)
+		}
+		targets = append(targets, target{name: name, digest: hex.EncodeToString(sha), length: t.Length})
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i].name < targets[j].name })
+	return targets, nil
+}
+
+// verifySignature checks a signature made with one of the methods used by Notary
+func verifySignature(pub crypto.PublicKey, sig tufSignature, signed []byte) error {
+	digest := sha256.Sum256(signed)
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		if sig.Method != "ecdsa" {
+			break
+		}
+		// Notary encodes ECDSA signatures as the fixed size concatenation of r and s
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig.Sig) != 2*size {
+			return errors.New("malformed ECDSA signature")
+		}
+		r := new(big.Int).SetBytes(sig.Sig[:size])
+		s := new(big.Int).SetBytes(sig.Sig[size:])
+		if !ecdsa.Verify(k, digest[:], r, s) {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if sig.Method != "rsapss" {
+			break
+		}
+		return rsa.VerifyPSS(k, crypto.SHA256, digest[:], sig.Sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case ed25519.PublicKey:
+		if sig.Method != "ed25519" {
+			break
+		}
+		if !ed25519.Verify(k, signed, sig.Sig) {
+			return errors.New("signature verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", pub)
+	}
+	return fmt.Errorf("signature method %q does not match key type %T", sig.Method, pub)
+}
+
+// canonicalJSON re-encodes b with sorted keys, no insignificant whitespace and no HTML escaping,
+// which is the form Notary signs
+func canonicalJSON(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+)
+
+// IndexKeyFunc derives index keys from an entry, in addition to those the entry returns from IndexKeys
+type IndexKeyFunc func(entry EntryImpl) ([]string, error)
+
+// IndexKeyExtractor derives index keys from the entries of some kinds, so that what entries can be searched by
+// is configured by the server rather than fixed by each kind
+type IndexKeyExtractor struct {
+	// Kinds are the kinds of entries the extractor applies to, or empty for entries of every kind
+	Kinds []string
+	// Enabled is whether the extractor runs unless the server disables it
+	Enabled bool
+	// Extract returns the keys of an entry whose external entities have been fetched
+	Extract IndexKeyFunc
+}
+
+func (e IndexKeyExtractor) appliesTo(kind string) bool {
+	if len(e.Kinds) == 0 {
+		return true
+	}
+	for _, k := range e.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	indexKeyMu         sync.RWMutex
+	indexKeyExtractors = map[string]IndexKeyExtractor{
+		"identities": {Enabled: true, Extract: identityIndexKeys},
+	}
+	// indexKeyEnabled holds the extractors enabled or disabled by the server, overriding their default
+	indexKeyEnabled = map[string]bool{}
+)
+
+// RegisterIndexKeyExtractor makes an extractor available under name; it is meant to be called from init functions
+func RegisterIndexKeyExtractor(name string, e IndexKeyExtractor) {
+	indexKeyMu.Lock()
+	defer indexKeyMu.Unlock()
+	indexKeyExtractors[name] = e
+}
+
+// IndexKeyExtractors returns the names of the registered extractors
+func IndexKeyExtractors() []string {
+	indexKeyMu.RLock()
+	defer indexKeyMu.RUnlock()
+	names := make([]string, 0, len(indexKeyExtractors))
+	for name := range indexKeyExtractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigureIndexKeyExtractors enables and disables the named extractors; an extractor named in both is disabled
+func ConfigureIndexKeyExtractors(enable, disable []string) error {
+	indexKeyMu.Lock()
+	defer indexKeyMu.Unlock()
+	enabled := map[string]bool{}
+	for _, names := range []struct {
+		names   []string
+		enabled bool
+	}{{enable, true}, {disable, false}} {
+		for _, name := range names.names {
+			if _, ok := indexKeyExtractors[name]; !ok {
+				return fmt.Errorf("unknown index key extractor '%v'", name)
+			}
+			enabled[name] = names.enabled
+		}
+	}
+	indexKeyEnabled = enabled
+	return nil
+}
+
+// ExtractIndexKeys returns the keys the enabled extractors derive from an entry of kind. Extractors that fail are
+// logged and skipped, as are all of them if the external entities of the entry cannot be fetched, in the same way
+// entries skip the IndexKeys they cannot compute.
+func ExtractIndexKeys(kind string, entry EntryImpl) []string {
+	indexKeyMu.RLock()
+	var names []string
+	extractors := map[string]IndexKeyExtractor{}
+	for name, e := range indexKeyExtractors {
+		enabled, ok := indexKeyEnabled[name]
+		if !ok {
+			enabled = e.Enabled
+		}
+		if enabled && e.appliesTo(kind) {
+			names = append(names, name)
+			extractors[name] = e
+		}
+	}
+	indexKeyMu.RUnlock()
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	if entry.HasExternalEntities() {
+		if err := entry.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return nil
+		}
+	}
+	var result []string
+	for _, name := range names {
+		keys, err := extractors[name].Extract(entry)
+		if err != nil {
+			log.Logger.Warnw("index key extractor failed", "extractor", name, "kind", kind, "error", err)
+			continue
+		}
+		result = append(result, keys...)
+	}
+	return result
+}
+
+// identityIndexKeys returns the keys of the identities of the key signing an entry, such as the email addresses
+// of a certificate
+func identityIndexKeys(entry EntryImpl) ([]string, error) {
+	sk, ok := entry.(SigningKey)
+	if !ok {
+		return nil, nil
+	}
+	if ik, ok := sk.SigningKey().(pki.IdentityKey); ok {
+		return ik.IdentityIndexKeys(), nil
+	}
+	return nil, nil
+}
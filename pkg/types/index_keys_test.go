@@ -0,0 +1,84 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sigstore/rekor/pkg/pki"
+)
+
+// identityKey is a public key bound to a single email address
+type identityKey struct{}
+
+func (identityKey) CanonicalValue() ([]byte, error) { return []byte("key"), nil }
+
+func (identityKey) IdentityIndexKeys() []string { return []string{"email:signer@example.com"} }
+
+type signedEntry struct {
+	EntryImpl
+	digest string
+}
+
+func (signedEntry) HasExternalEntities() bool { return false }
+
+func (signedEntry) SigningKey() pki.PublicKey { return identityKey{} }
+
+func TestExtractIndexKeys(t *testing.T) {
+	RegisterIndexKeyExtractor("digest", IndexKeyExtractor{Kinds: []string{"signed"}, Extract: func(e EntryImpl) ([]string, error) {
+		return []string{e.(signedEntry).digest}, nil
+	}})
+	RegisterIndexKeyExtractor("failing", IndexKeyExtractor{Enabled: true, Extract: func(EntryImpl) ([]string, error) {
+		return []string{"partial"}, errors.New("failed")
+	}})
+	defer func() {
+		indexKeyMu.Lock()
+		delete(indexKeyExtractors, "digest")
+		delete(indexKeyExtractors, "failing")
+		indexKeyEnabled = map[string]bool{}
+		indexKeyMu.Unlock()
+	}()
+	entry := signedEntry{digest: "abc"}
+
+	tests := []struct {
+		caseDesc string
+		enable   []string
+		disable  []string
+		kind     string
+		want     []string
+	}{
+		{caseDesc: "enabled by default", kind: "signed", want: []string{"email:signer@example.com"}},
+		{caseDesc: "enabled by the server", enable: []string{"digest"}, kind: "signed", want: []string{"abc", "email:signer@example.com"}},
+		{caseDesc: "enabled for another kind", enable: []string{"digest"}, kind: "other", want: []string{"email:signer@example.com"}},
+		{caseDesc: "disabled by the server", disable: []string{"identities"}, kind: "signed", want: nil},
+		{caseDesc: "both enabled and disabled", enable: []string{"digest"}, disable: []string{"digest", "identities"}, kind: "signed", want: nil},
+	}
+	for _, tc := range tests {
+		if err := ConfigureIndexKeyExtractors(tc.enable, tc.disable); err != nil {
+			t.Fatalf("%v: %v", tc.caseDesc, err)
+		}
+		if got := ExtractIndexKeys(tc.kind, entry); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %v, want %v", tc.caseDesc, got, tc.want)
+		}
+	}
+
+	if err := ConfigureIndexKeyExtractors(nil, []string{"missing"}); err == nil {
+		t.Error("expected error disabling an unknown extractor")
+	}
+}
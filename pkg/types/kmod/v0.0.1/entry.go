@@ -0,0 +1,270 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kmod
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/types/kmod"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	kmod.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	KmodObj                 models.KmodV001Schema
+	fetchedExternalEntities bool
+	certObj                 *x509.Certificate
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if v.certObj != nil {
+		if key, err := pki.CanonicalKeyIndex(canonicalCertificate(v.certObj)); err != nil {
+			log.Logger.Error(err)
+		} else {
+			result = append(result, key)
+		}
+	}
+
+	if v.KmodObj.Module.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.KmodObj.Module.Hash.Value)))
+	}
+
+	if v.KmodObj.Module.StrippedHash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.KmodObj.Module.StrippedHash.Value)))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	kmod, ok := pe.(*models.Kmod)
+	if !ok {
+		return errors.New("cannot unmarshal non kernel module v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(kmod.Spec, &v.KmodObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.KmodObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.KmodObj.Module != nil && v.KmodObj.Module.URL.String() != "" {
+		return true
+	}
+	if v.KmodObj.PublicKey != nil && v.KmodObj.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	oldSHA := ""
+	if v.KmodObj.Module.Hash != nil && v.KmodObj.Module.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.KmodObj.Module.Hash.Value)
+	}
+
+	// the signature trails the module, so the whole file is needed before it can be verified
+	var koBytes []byte
+	g.Go(func() error {
+		moduleReadCloser, err := util.FileOrURLReadCloser(ctx, v.KmodObj.Module.URL.String(), v.KmodObj.Module.Content)
+		if err != nil {
+			return err
+		}
+		defer moduleReadCloser.Close()
+
+		koBytes, err = ioutil.ReadAll(moduleReadCloser)
+		return err
+	})
+
+	var cert *x509.Certificate
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.KmodObj.PublicKey.URL.String(),
+			v.KmodObj.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		certBytes, err := ioutil.ReadAll(keyReadCloser)
+		if err != nil {
+			return err
+		}
+		cert, err = parseCertificate(certBytes)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	computedSHA := sha256.Sum256(koBytes)
+	computedSHAStr := hex.EncodeToString(computedSHA[:])
+	if oldSHA != "" && computedSHAStr != oldSHA {
+		return fmt.Errorf("SHA mismatch: %s != %s", computedSHAStr, oldSHA)
+	}
+
+	module, sig, err := splitModuleSignature(koBytes)
+	if err != nil {
+		return err
+	}
+	if err := verifyModuleSignature(module, sig, cert); err != nil {
+		return &types.VerificationError{Err: err}
+	}
+	strippedSHA := sha256.Sum256(module)
+
+	// if we get here, the module signature was verified without error
+	v.certObj = cert
+	if oldSHA == "" {
+		v.KmodObj.Module.Hash = &models.KmodV001SchemaModuleHash{}
+		v.KmodObj.Module.Hash.Algorithm = swag.String(models.KmodV001SchemaModuleHashAlgorithmSha256)
+		v.KmodObj.Module.Hash.Value = swag.String(computedSHAStr)
+	}
+	v.KmodObj.Module.StrippedHash = &models.KmodV001SchemaModuleStrippedHash{}
+	v.KmodObj.Module.StrippedHash.Algorithm = swag.String(models.KmodV001SchemaModuleStrippedHashAlgorithmSha256)
+	v.KmodObj.Module.StrippedHash.Value = swag.String(hex.EncodeToString(strippedSHA[:]))
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.certObj == nil {
+		return nil, errors.New("certificate object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.KmodV001Schema{}
+
+	// key URL (if known) is not set deliberately
+	canonicalEntry.PublicKey = &models.KmodV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content = canonicalCertificate(v.certObj)
+
+	canonicalEntry.Module = &models.KmodV001SchemaModule{}
+	canonicalEntry.Module.Hash = v.KmodObj.Module.Hash
+	canonicalEntry.Module.StrippedHash = v.KmodObj.Module.StrippedHash
+	// module content is not set deliberately
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.KmodObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	kmodObj := models.Kmod{}
+	kmodObj.APIVersion = swag.String(APIVERSION)
+	kmodObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&kmodObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	key := v.KmodObj.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	module := v.KmodObj.Module
+	if module == nil {
+		return errors.New("missing module")
+	}
+
+	if len(module.Content) == 0 && module.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for module")
+	}
+
+	hash := module.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	return nil
+}
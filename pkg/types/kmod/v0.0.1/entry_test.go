@@ -0,0 +1,381 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmod
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.mozilla.org/pkcs7"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+// signModule produces a module with an appended signature in the same layout as scripts/sign-file.c
+func signModule(t *testing.T, module []byte) (ko []byte, certDER []byte) {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Build time autogenerated kernel key"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err = x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sd, err := pkcs7.NewSignedData(module)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	if err := sd.SignWithoutAttr(cert, priv, pkcs7.SignerInfoConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	sd.Detach()
+	sig, err := sd.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(module)
+	buf.Write(sig)
+	if err := binary.Write(&buf, binary.BigEndian, moduleSignature{IDType: pkeyIDPKCS7, SigLen: uint32(len(sig))}); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString(moduleSigMagic)
+	return buf.Bytes(), certDER
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	moduleBytes := []byte("\x7fELF this is not really a kernel module")
+	koBytes, certDER := signModule(t, moduleBytes)
+	certBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	_, otherCertDER := signModule(t, moduleBytes)
+
+	h := sha256.New()
+	_, _ = h.Write(koBytes)
+	koSHA := hex.EncodeToString(h.Sum(nil))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &koBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/cert":
+				file = &certBytes
+			case "/module":
+				file = &koBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key without module",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/cert"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "public key with empty module",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/cert"),
+					},
+					Module: &models.KmodV001SchemaModule{},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "module url without hash",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/cert"),
+					},
+					Module: &models.KmodV001SchemaModule{
+						URL: strfmt.URI(testServer.URL + "/module"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "module url with incorrect hash value",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/cert"),
+					},
+					Module: &models.KmodV001SchemaModule{
+						Hash: &models.KmodV001SchemaModuleHash{
+							Algorithm: swag.String(models.KmodV001SchemaModuleHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/module"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "module url with 404 error on certificate",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/404"),
+					},
+					Module: &models.KmodV001SchemaModule{
+						Hash: &models.KmodV001SchemaModuleHash{
+							Algorithm: swag.String(models.KmodV001SchemaModuleHashAlgorithmSha256),
+							Value:     swag.String(koSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/module"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "module url with complete hash value",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/cert"),
+					},
+					Module: &models.KmodV001SchemaModule{
+						Hash: &models.KmodV001SchemaModuleHash{
+							Algorithm: swag.String(models.KmodV001SchemaModuleHashAlgorithmSha256),
+							Value:     swag.String(koSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/module"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "unsigned module content",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						Content: strfmt.Base64(certBytes),
+					},
+					Module: &models.KmodV001SchemaModule{
+						Content: strfmt.Base64(moduleBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "module content signed by a different certificate",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						Content: strfmt.Base64(otherCertDER),
+					},
+					Module: &models.KmodV001SchemaModule{
+						Content: strfmt.Base64(koBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "DER certificate content & module content",
+			entry: V001Entry{
+				KmodObj: models.KmodV001Schema{
+					PublicKey: &models.KmodV001SchemaPublicKey{
+						Content: strfmt.Base64(certDER),
+					},
+					Module: &models.KmodV001SchemaModule{
+						Content: strfmt.Base64(koBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Kmod{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.KmodObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestSplitModuleSignature(t *testing.T) {
+	moduleBytes := []byte("\x7fELF module body")
+	koBytes, _ := signModule(t, moduleBytes)
+
+	module, sig, err := splitModuleSignature(koBytes)
+	if err != nil {
+		t.Fatalf("unexpected error splitting module signature: %v", err)
+	}
+	if !bytes.Equal(module, moduleBytes) {
+		t.Errorf("stripped module does not match original module")
+	}
+	if len(sig) == 0 {
+		t.Errorf("empty signature returned")
+	}
+
+	if _, _, err := splitModuleSignature(moduleBytes); err == nil {
+		t.Error("unexpected success splitting unsigned module")
+	}
+	if _, _, err := splitModuleSignature([]byte(moduleSigMagic)); err == nil {
+		t.Error("unexpected success splitting truncated trailer")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(moduleBytes)
+	_ = binary.Write(&buf, binary.BigEndian, moduleSignature{IDType: pkeyIDPKCS7, SigLen: 1 << 20})
+	buf.WriteString(moduleSigMagic)
+	if _, _, err := splitModuleSignature(buf.Bytes()); err == nil {
+		t.Error("unexpected success splitting module with oversized signature length")
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	moduleBytes := []byte("\x7fELF module body")
+	koBytes, certDER := signModule(t, moduleBytes)
+
+	v := V001Entry{
+		KmodObj: models.KmodV001Schema{
+			PublicKey: &models.KmodV001SchemaPublicKey{
+				Content: strfmt.Base64(certDER),
+			},
+			Module: &models.KmodV001SchemaModule{
+				Content: strfmt.Base64(koBytes),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	strippedSHA := sha256.Sum256(moduleBytes)
+	expected := hex.EncodeToString(strippedSHA[:])
+	for _, k := range v.IndexKeys() {
+		if k == expected {
+			return
+		}
+	}
+	t.Errorf("stripped module digest %v not found in index keys", expected)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package kmod
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// moduleSigMagic is the marker the kernel's sign-file tool appends after the signature
+const moduleSigMagic = "~Module signature appended~\n"
+
+// pkeyIDPKCS7 is the only signature identifier type accepted by the kernel
+const pkeyIDPKCS7 = 2
+
+// moduleSignature mirrors struct module_signature from include/linux/module_signature.h
+type moduleSignature struct {
+	Algo      uint8
+	Hash      uint8
+	IDType    uint8
+	SignerLen uint8
+	KeyIDLen  uint8
+	Pad       [3]uint8
+	SigLen    uint32
+}
+
+// splitModuleSignature separates a signed kernel module into the module contents
+// and the DER-encoded PKCS#7 signature appended to it
+func splitModuleSignature(ko []byte) ([]byte, []byte, error) {
+	if !bytes.HasSuffix(ko, []byte(moduleSigMagic)) {
+		return nil, nil, errors.New("module does not have an appended signature")
+	}
+	rest := ko[:len(ko)-len(moduleSigMagic)]
+
+	infoLen := binary.Size(moduleSignature{})
+	if len(rest) < infoLen {
+		return nil, nil, errors.New("module signature trailer is truncated")
+	}
+	var info moduleSignature
+	if err := binary.Read(bytes.NewReader(rest[len(rest)-infoLen:]), binary.BigEndian, &info); err != nil {
+		return nil, nil, fmt.Errorf("error reading module signature trailer: %w", err)
+	}
+	rest = rest[:len(rest)-infoLen]
+
+	if info.IDType != pkeyIDPKCS7 {
+		return nil, nil, fmt.Errorf("unsupported module signature type %d", info.IDType)
+	}
+	if info.Algo != 0 || info.Hash != 0 || info.SignerLen != 0 || info.KeyIDLen != 0 {
+		return nil, nil, errors.New("unexpected non-zero fields in PKCS#7 module signature trailer")
+	}
+	if uint64(info.SigLen) > uint64(len(rest)) {
+		return nil, nil, errors.New("module signature length exceeds module size")
+	}
+
+	split := len(rest) - int(info.SigLen)
+	return rest[:split], rest[split:], nil
+}
+
+// verifyModuleSignature checks that sig is a valid detached PKCS#7 signature over module made by cert
+func verifyModuleSignature(module, sig []byte, cert *x509.Certificate) error {
+	p7, err := pkcs7.Parse(sig)
+	if err != nil {
+		return fmt.Errorf("error parsing PKCS#7 module signature: %w", err)
+	}
+	// only trust the supplied certificate, never one embedded in the signature
+	p7.Certificates = []*x509.Certificate{cert}
+	p7.Content = module
+	return p7.Verify()
+}
+
+// parseCertificate accepts either a PEM or DER encoded X.509 certificate, since
+// kernel build trees usually carry the signing certificate in DER form
+func parseCertificate(b []byte) (*x509.Certificate, error) {
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block type %v", block.Type)
+		}
+		b = block.Bytes
+	}
+	return x509.ParseCertificate(b)
+}
+
+// canonicalCertificate returns the PEM encoding of cert, matching the canonical value of x509 public keys
+func canonicalCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	})
+}
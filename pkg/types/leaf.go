@@ -0,0 +1,50 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"context"
+	"encoding/hex"
+
+	rfc6962 "github.com/google/trillian/merkle/rfc6962/hasher"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// CanonicalLeaf returns the canonical form of a proposed entry, which is the leaf the log stores for it, and the
+// Merkle leaf hash of that leaf. External entities of the entry are fetched, but the log itself is not contacted,
+// so clients can work out the leaf hash of an entry before (or instead of) uploading it.
+func CanonicalLeaf(ctx context.Context, pe models.ProposedEntry) ([]byte, []byte, error) {
+	entry, err := NewEntry(pe)
+	if err != nil {
+		return nil, nil, err
+	}
+	leaf, err := entry.Canonicalize(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return leaf, rfc6962.DefaultHasher.HashLeaf(leaf), nil
+}
+
+// EntryUUID returns the UUID the log assigns to a proposed entry, which is the hex encoded leaf hash of its
+// canonical form; an entry with this UUID already in the log means an equivalent entry has been uploaded before
+func EntryUUID(ctx context.Context, pe models.ProposedEntry) (string, error) {
+	_, leafHash, err := CanonicalLeaf(ctx, pe)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(leafHash), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+type leafEntry struct{ canonical []byte }
+
+func (e leafEntry) Kind() string                                                       { return "leaftest" }
+func (e leafEntry) SetKind(string)                                                     {}
+func (e leafEntry) Validate(formats strfmt.Registry) error                             { return nil }
+func (e leafEntry) ContextValidate(ctx context.Context, formats strfmt.Registry) error { return nil }
+
+type leafType struct{}
+
+func (leafType) Kind() string { return "leaftest" }
+
+func (leafType) UnmarshalEntry(pe models.ProposedEntry) (EntryImpl, error) {
+	return &leafImpl{canonical: pe.(leafEntry).canonical}, nil
+}
+
+type leafImpl struct{ canonical []byte }
+
+func (e *leafImpl) APIVersion() string                              { return "0.0.1" }
+func (e *leafImpl) IndexKeys() []string                             { return nil }
+func (e *leafImpl) FetchExternalEntities(ctx context.Context) error { return nil }
+func (e *leafImpl) HasExternalEntities() bool                       { return false }
+func (e *leafImpl) Unmarshal(pe models.ProposedEntry) error         { return nil }
+func (e *leafImpl) Validate() error                                 { return nil }
+func (e *leafImpl) Canonicalize(ctx context.Context) ([]byte, error) {
+	if e.canonical == nil {
+		return nil, errors.New("cannot canonicalize")
+	}
+	return e.canonical, nil
+}
+
+func TestEntryUUID(t *testing.T) {
+	TypeMap.Set("leaftest", func() TypeImpl { return leafType{} })
+
+	canonical := []byte(`{"kind":"leaftest"}`)
+	leaf, leafHash, err := CanonicalLeaf(context.Background(), leafEntry{canonical: canonical})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(leaf, canonical) {
+		t.Errorf("unexpected leaf %q", leaf)
+	}
+	// RFC 6962 leaf hashes are taken over the leaf prefixed with a zero byte
+	want := sha256.Sum256(append([]byte{0}, canonical...))
+	if !bytes.Equal(leafHash, want[:]) {
+		t.Errorf("unexpected leaf hash %x, want %x", leafHash, want)
+	}
+
+	uuid, err := EntryUUID(context.Background(), leafEntry{canonical: canonical})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uuid != hex.EncodeToString(want[:]) {
+		t.Errorf("unexpected UUID %v", uuid)
+	}
+
+	if _, err := EntryUUID(context.Background(), leafEntry{}); err == nil {
+		t.Error("expected error for entry that cannot be canonicalized")
+	}
+	if _, err := EntryUUID(context.Background(), InvalidEntry{}); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+}
@@ -0,0 +1,497 @@
+/*
+Copyright © 2020 Bob Callaway <bcallawa@redhat.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mlmodel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types/mlmodel"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	mlmodel.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+	types.RegisterIndexKeyExtractor("mlmodel-provenance", types.IndexKeyExtractor{Kinds: []string{mlmodel.KIND}, Extract: provenanceIndexKeys})
+}
+
+type V001Entry struct {
+	MlmodelObj              models.MlmodelV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+// ModelKey returns the index key used to look up every entry for a model; model may be a bare
+// name or qualified as name@version
+func ModelKey(model string) string {
+	return "model:" + strings.ToLower(model)
+}
+
+// provenanceIndexKeys returns the digests of the datasets a model was trained on, so that the models trained on
+// a dataset can be found by searching for its digest
+func provenanceIndexKeys(entry types.EntryImpl) ([]string, error) {
+	v, ok := entry.(*V001Entry)
+	if !ok {
+		return nil, nil
+	}
+	prov := v.MlmodelObj.Provenance
+	if prov == nil {
+		return nil, nil
+	}
+	var result []string
+	for _, dataset := range prov.Datasets {
+		if dataset != nil && dataset.Digest != "" {
+			result = append(result, strings.ToLower(dataset.Digest))
+		}
+	}
+	return result, nil
+}
+
+// safetensors files start with the little-endian length of a JSON header, which the format caps at 100MB
+const maxSafetensorsHeaderLen = 100 << 20
+
+// headerWriter keeps the first bytes written to it so the model format can be checked while streaming
+type headerWriter struct {
+	buf []byte
+}
+
+func (h *headerWriter) Write(p []byte) (int, error) {
+	if remaining := 9 - len(h.buf); remaining > 0 {
+		if len(p) < remaining {
+			remaining = len(p)
+		}
+		h.buf = append(h.buf, p[:remaining]...)
+	}
+	return len(p), nil
+}
+
+// checkModelHeader rejects content that clearly is not in the claimed format
+func checkModelHeader(format string, header []byte) error {
+	if format != models.MlmodelV001SchemaModelFormatSafetensors {
+		return nil
+	}
+	if len(header) < 9 {
+		return errors.New("model is too short to be a safetensors file")
+	}
+	headerLen := binary.LittleEndian.Uint64(header[:8])
+	if headerLen == 0 || headerLen > maxSafetensorsHeaderLen || header[8] != '{' {
+		return errors.New("model is not a valid safetensors file")
+	}
+	return nil
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if key, err := pki.KeyIndex(v.keyObj); err != nil {
+		log.Logger.Error(err)
+	} else {
+		result = append(result, key)
+	}
+
+	if v.MlmodelObj.Model.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.MlmodelObj.Model.Hash.Value)))
+	}
+
+	if name := v.MlmodelObj.Model.Name; name != "" {
+		result = append(result, ModelKey(name))
+		if version := v.MlmodelObj.Model.Version; version != "" {
+			result = append(result, ModelKey(fmt.Sprintf("%s@%s", name, version)))
+		}
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	mlmodel, ok := pe.(*models.Mlmodel)
+	if !ok {
+		return errors.New("cannot unmarshal non ML model v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(mlmodel.Spec, &v.MlmodelObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.MlmodelObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.MlmodelObj.Model != nil && v.MlmodelObj.Model.URL.String() != "" {
+		return true
+	}
+	if v.MlmodelObj.Signature != nil && v.MlmodelObj.Signature.URL.String() != "" {
+		return true
+	}
+	if v.MlmodelObj.Signature != nil && v.MlmodelObj.Signature.PublicKey != nil && v.MlmodelObj.Signature.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	hashR, hashW := io.Pipe()
+	sigR, sigW := io.Pipe()
+	defer hashR.Close()
+	defer sigR.Close()
+
+	closePipesOnError := func(err error) error {
+		pipeReaders := []*io.PipeReader{hashR, sigR}
+		pipeWriters := []*io.PipeWriter{hashW, sigW}
+		for idx := range pipeReaders {
+			if e := pipeReaders[idx].CloseWithError(err); e != nil {
+				log.Logger.Error(fmt.Errorf("error closing pipe: %w", e))
+			}
+			if e := pipeWriters[idx].CloseWithError(err); e != nil {
+				log.Logger.Error(fmt.Errorf("error closing pipe: %w", e))
+			}
+		}
+		return err
+	}
+
+	oldSHA := ""
+	if v.MlmodelObj.Model.Hash != nil && v.MlmodelObj.Model.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.MlmodelObj.Model.Hash.Value)
+	}
+	artifactFactory := pki.NewArtifactFactory(v.MlmodelObj.Signature.Format)
+
+	g.Go(func() error {
+		defer hashW.Close()
+		defer sigW.Close()
+
+		dataReadCloser, err := util.FileOrURLReadCloser(ctx, v.MlmodelObj.Model.URL.String(), v.MlmodelObj.Model.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer dataReadCloser.Close()
+
+		header := &headerWriter{}
+		/* #nosec G110 */
+		if _, err := io.Copy(io.MultiWriter(hashW, sigW, header), dataReadCloser); err != nil {
+			return closePipesOnError(err)
+		}
+		if err := checkModelHeader(v.MlmodelObj.Model.Format, header.buf); err != nil {
+			return closePipesOnError(err)
+		}
+		return nil
+	})
+
+	hashResult := make(chan string)
+
+	g.Go(func() error {
+		defer close(hashResult)
+		hasher := sha256.New()
+
+		if _, err := io.Copy(hasher, hashR); err != nil {
+			return closePipesOnError(err)
+		}
+
+		computedSHA := hex.EncodeToString(hasher.Sum(nil))
+		if oldSHA != "" && computedSHA != oldSHA {
+			return closePipesOnError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case hashResult <- computedSHA:
+			return nil
+		}
+	})
+
+	sigResult := make(chan pki.Signature)
+
+	g.Go(func() error {
+		defer close(sigResult)
+
+		sigReadCloser, err := util.FileOrURLReadCloser(ctx, v.MlmodelObj.Signature.URL.String(),
+			v.MlmodelObj.Signature.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer sigReadCloser.Close()
+
+		signature, err := artifactFactory.NewSignature(sigReadCloser)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sigResult <- signature:
+			return nil
+		}
+	})
+
+	keyResult := make(chan pki.PublicKey)
+
+	g.Go(func() error {
+		defer close(keyResult)
+
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.MlmodelObj.Signature.PublicKey.URL.String(),
+			v.MlmodelObj.Signature.PublicKey.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer keyReadCloser.Close()
+
+		key, err := artifactFactory.NewPublicKey(keyReadCloser)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case keyResult <- key:
+			return nil
+		}
+	})
+
+	g.Go(func() error {
+		v.keyObj, v.sigObj = <-keyResult, <-sigResult
+
+		if v.keyObj == nil || v.sigObj == nil {
+			return closePipesOnError(errors.New("failed to read signature or public key"))
+		}
+
+		signers, err := pki.VerifySigners(v.sigObj, sigR, v.keyObj)
+		if err != nil {
+			return closePipesOnError(&types.VerificationError{Err: err})
+		}
+		v.keyObj = signers
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			return nil
+		}
+	})
+
+	computedSHA := <-hashResult
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// if we get here, all goroutines succeeded without error
+	if oldSHA == "" {
+		v.MlmodelObj.Model.Hash = &models.MlmodelV001SchemaModelHash{}
+		v.MlmodelObj.Model.Hash.Algorithm = swag.String(models.MlmodelV001SchemaModelHashAlgorithmSha256)
+		v.MlmodelObj.Model.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.sigObj == nil {
+		return nil, errors.New("signature object not initialized before canonicalization")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.MlmodelV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.MlmodelV001SchemaSignature{}
+	// signature URL (if known) is not set deliberately
+	canonicalEntry.Signature.Format = v.MlmodelObj.Signature.Format
+
+	var err error
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// key URL (if known) is not set deliberately
+	canonicalEntry.Signature.PublicKey = &models.MlmodelV001SchemaSignaturePublicKey{}
+	canonicalEntry.Signature.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Model = &models.MlmodelV001SchemaModel{}
+	canonicalEntry.Model.Format = v.MlmodelObj.Model.Format
+	canonicalEntry.Model.Hash = v.MlmodelObj.Model.Hash
+	canonicalEntry.Model.Name = v.MlmodelObj.Model.Name
+	canonicalEntry.Model.Version = v.MlmodelObj.Model.Version
+	// model content is not set deliberately
+
+	// provenance is part of what the signer attests to, so it is kept verbatim
+	canonicalEntry.Provenance = v.MlmodelObj.Provenance
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.MlmodelObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	mlmodelObj := models.Mlmodel{}
+	mlmodelObj.APIVersion = swag.String(APIVERSION)
+	mlmodelObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&mlmodelObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// CanonicalSignatureAndKey returns the canonical encodings of the signature and public key, which are only
+// known once external entities have been fetched
+func (v V001Entry) CanonicalSignatureAndKey() ([]byte, []byte, error) {
+	if v.sigObj == nil || v.keyObj == nil {
+		return nil, nil, errors.New("signature and key objects not initialized")
+	}
+	sig, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, key, nil
+}
+
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	sig := v.MlmodelObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if !pki.IsFormat(sig.Format) {
+		return fmt.Errorf("unsupported signature format %q, expected one of %v", sig.Format, pki.Formats())
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	model := v.MlmodelObj.Model
+	if model == nil {
+		return errors.New("missing model")
+	}
+
+	if len(model.Content) == 0 && model.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for model")
+	}
+
+	if model.Name == "" {
+		return errors.New("missing model name")
+	}
+
+	if model.Format == "" {
+		return errors.New("missing model format")
+	}
+
+	hash := model.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	if prov := v.MlmodelObj.Provenance; prov != nil {
+		if prov.BaseModel != "" && strings.EqualFold(prov.BaseModel, model.Name) {
+			return errors.New("a model cannot be its own base model")
+		}
+		for _, dataset := range prov.Datasets {
+			if dataset == nil || swag.StringValue(dataset.Name) == "" {
+				return errors.New("every dataset in provenance must be named")
+			}
+		}
+	}
+
+	return nil
+}
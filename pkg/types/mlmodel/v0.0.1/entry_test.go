@@ -0,0 +1,403 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mlmodel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.uber.org/goleak"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+// testSafetensors builds a minimal safetensors file: a little-endian header length, the JSON header, then the tensor data
+func testSafetensors() []byte {
+	header := []byte(`{"weight":{"dtype":"F32","shape":[2],"data_offsets":[0,8]}}`)
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, uint64(len(header)))
+	buf.Write(header)
+	buf.Write(make([]byte, 8))
+	return buf.Bytes()
+}
+
+func signModel(t *testing.T, model []byte) (sig []byte, pubKey []byte) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Model Publisher", "", "models@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sigBuf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sigBuf, entity, bytes.NewReader(model), nil); err != nil {
+		t.Fatal(err)
+	}
+	var keyBuf bytes.Buffer
+	w, err := armor.Encode(&keyBuf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return sigBuf.Bytes(), keyBuf.Bytes()
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	modelBytes := testSafetensors()
+	sigBytes, keyBytes := signModel(t, modelBytes)
+	_, otherKeyBytes := signModel(t, modelBytes)
+	notModelBytes := []byte("definitely not a safetensors file")
+	notModelSigBytes, notModelKeyBytes := signModel(t, notModelBytes)
+
+	h := sha256.New()
+	_, _ = h.Write(modelBytes)
+	modelSHA := hex.EncodeToString(h.Sum(nil))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &sigBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/signature":
+				file = &sigBytes
+			case "/key":
+				file = &keyBytes
+			case "/model":
+				file = &modelBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	urlSignature := func() *models.MlmodelV001SchemaSignature {
+		return &models.MlmodelV001SchemaSignature{
+			Format: "pgp",
+			URL:    strfmt.URI(testServer.URL + "/signature"),
+			PublicKey: &models.MlmodelV001SchemaSignaturePublicKey{
+				URL: strfmt.URI(testServer.URL + "/key"),
+			},
+		}
+	}
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without model",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: urlSignature(),
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "model without name",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: urlSignature(),
+					Model: &models.MlmodelV001SchemaModel{
+						Format: models.MlmodelV001SchemaModelFormatSafetensors,
+						URL:    strfmt.URI(testServer.URL + "/model"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "model that is its own base model",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: urlSignature(),
+					Model: &models.MlmodelV001SchemaModel{
+						Name:   "example/tiny-model",
+						Format: models.MlmodelV001SchemaModelFormatSafetensors,
+						URL:    strfmt.URI(testServer.URL + "/model"),
+					},
+					Provenance: &models.MlmodelV001SchemaProvenance{
+						BaseModel: "example/tiny-model",
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "model url without hash",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: urlSignature(),
+					Model: &models.MlmodelV001SchemaModel{
+						Name:   "example/tiny-model",
+						Format: models.MlmodelV001SchemaModelFormatSafetensors,
+						URL:    strfmt.URI(testServer.URL + "/model"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "model url with incorrect hash value",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: urlSignature(),
+					Model: &models.MlmodelV001SchemaModel{
+						Name:   "example/tiny-model",
+						Format: models.MlmodelV001SchemaModelFormatSafetensors,
+						Hash: &models.MlmodelV001SchemaModelHash{
+							Algorithm: swag.String(models.MlmodelV001SchemaModelHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/model"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "model url with complete hash value and provenance",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: urlSignature(),
+					Model: &models.MlmodelV001SchemaModel{
+						Name:    "example/tiny-model",
+						Version: "1.0",
+						Format:  models.MlmodelV001SchemaModelFormatSafetensors,
+						Hash: &models.MlmodelV001SchemaModelHash{
+							Algorithm: swag.String(models.MlmodelV001SchemaModelHashAlgorithmSha256),
+							Value:     swag.String(modelSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/model"),
+					},
+					Provenance: &models.MlmodelV001SchemaProvenance{
+						Framework: "pytorch",
+						BaseModel: "example/base-model",
+						Datasets: []*models.MlmodelV001SchemaProvenanceDatasetsItems0{
+							{Name: swag.String("example/dataset"), Digest: modelSHA},
+						},
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "model content signed by a different key",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: &models.MlmodelV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.MlmodelV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(otherKeyBytes),
+						},
+					},
+					Model: &models.MlmodelV001SchemaModel{
+						Name:    "example/tiny-model",
+						Format:  models.MlmodelV001SchemaModelFormatSafetensors,
+						Content: strfmt.Base64(modelBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signed content that is not a safetensors file",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: &models.MlmodelV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(notModelSigBytes),
+						PublicKey: &models.MlmodelV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(notModelKeyBytes),
+						},
+					},
+					Model: &models.MlmodelV001SchemaModel{
+						Name:    "example/tiny-model",
+						Format:  models.MlmodelV001SchemaModelFormatSafetensors,
+						Content: strfmt.Base64(notModelBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature content, key content & model content",
+			entry: V001Entry{
+				MlmodelObj: models.MlmodelV001Schema{
+					Signature: &models.MlmodelV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.MlmodelV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(keyBytes),
+						},
+					},
+					Model: &models.MlmodelV001SchemaModel{
+						Name:    "example/tiny-model",
+						Format:  models.MlmodelV001SchemaModelFormatSafetensors,
+						Content: strfmt.Base64(modelBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Mlmodel{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.MlmodelObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	modelBytes := testSafetensors()
+	sigBytes, keyBytes := signModel(t, modelBytes)
+
+	v := V001Entry{
+		MlmodelObj: models.MlmodelV001Schema{
+			Signature: &models.MlmodelV001SchemaSignature{
+				Format:  "pgp",
+				Content: strfmt.Base64(sigBytes),
+				PublicKey: &models.MlmodelV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(keyBytes),
+				},
+			},
+			Model: &models.MlmodelV001SchemaModel{
+				Name:    "Example/Tiny-Model",
+				Version: "1.0",
+				Format:  models.MlmodelV001SchemaModelFormatSafetensors,
+				Content: strfmt.Base64(modelBytes),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	modelSHA := sha256.Sum256(modelBytes)
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{hex.EncodeToString(modelSHA[:]), ModelKey("example/tiny-model"), ModelKey("example/tiny-model@1.0")} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
+
+func TestProvenanceIndexKeys(t *testing.T) {
+	digest := strings.Repeat("AB", 32)
+	v := &V001Entry{
+		MlmodelObj: models.MlmodelV001Schema{
+			Provenance: &models.MlmodelV001SchemaProvenance{
+				BaseModel: "example/base",
+				Datasets: []*models.MlmodelV001SchemaProvenanceDatasetsItems0{
+					{Name: swag.String("undigested")},
+					{Name: swag.String("digested"), Digest: digest},
+				},
+			},
+		},
+	}
+	keys, err := provenanceIndexKeys(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{strings.ToLower(digest)}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("provenanceIndexKeys() = %v, want %v", keys, want)
+	}
+	if keys, _ := provenanceIndexKeys(&V001Entry{}); len(keys) != 0 {
+		t.Errorf("unexpected keys %v for a model without provenance", keys)
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package notation
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/types/notation"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	notation.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	NotationObj             models.NotationV001Schema
+	fetchedExternalEntities bool
+	envelopeBytes           []byte
+	certObj                 *x509.Certificate
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+// targetDigestKey returns the index key for an OCI digest; sha256 digests are indexed by their hex value
+// alone, so that they can be found with the same hash search as every other artifact
+func targetDigestKey(digest string) string {
+	digest = strings.ToLower(digest)
+	return strings.TrimPrefix(digest, "sha256:")
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if v.certObj != nil {
+		if key, err := pki.CanonicalKeyIndex(canonicalCertificate(v.certObj)); err != nil {
+			log.Logger.Error(err)
+		} else {
+			result = append(result, key)
+		}
+	}
+
+	if v.NotationObj.Envelope.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.NotationObj.Envelope.Hash.Value)))
+	}
+
+	if v.NotationObj.TargetArtifact != nil {
+		result = append(result, targetDigestKey(swag.StringValue(v.NotationObj.TargetArtifact.Digest)))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	notation, ok := pe.(*models.Notation)
+	if !ok {
+		return errors.New("cannot unmarshal non Notation v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(notation.Spec, &v.NotationObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.NotationObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.NotationObj.Envelope != nil && (v.NotationObj.Envelope.URL.String() != "" || v.storedEnvelope()) {
+		return true
+	}
+	return false
+}
+
+// storedEnvelope reports whether the envelope is only referred to by its hash, as it is in entries logged while
+// the server stores attestations separately
+func (v V001Entry) storedEnvelope() bool {
+	envelope := v.NotationObj.Envelope
+	return len(envelope.Content) == 0 && envelope.URL.String() == "" && envelope.Hash != nil
+}
+
+// StoredAttestationDigests returns the digest of the envelope if it is kept in attestation storage, either because
+// the entry was logged with it stored or because it is stored as the entry is logged
+func (v V001Entry) StoredAttestationDigests() []string {
+	envelope := v.NotationObj.Envelope
+	if envelope == nil || envelope.Hash == nil || (!v.storedEnvelope() && types.StoredAttestations() == nil) {
+		return nil
+	}
+	return []string{strings.ToLower(swag.StringValue(envelope.Hash.Value))}
+}
+
+// PendingAttestations returns the envelope to store once the entry has been added to the log, if the server stores
+// attestations separately and it was not read from there
+func (v V001Entry) PendingAttestations() [][]byte {
+	if types.StoredAttestations() == nil || v.storedEnvelope() || len(v.envelopeBytes) == 0 {
+		return nil
+	}
+	return [][]byte{v.envelopeBytes}
+}
+
+// readEnvelope returns the envelope from its URL or content, subject to the maximum attestation size, or from
+// attestation storage by its hash
+func (v V001Entry) readEnvelope(ctx context.Context) ([]byte, error) {
+	if v.storedEnvelope() {
+		store := types.StoredAttestations()
+		if store == nil {
+			return nil, errors.New("envelope is kept in attestation storage, which is not configured")
+		}
+		return store.Get(ctx, swag.StringValue(v.NotationObj.Envelope.Hash.Value))
+	}
+	envelopeReadCloser, err := util.FileOrURLReadCloser(ctx, v.NotationObj.Envelope.URL.String(), v.NotationObj.Envelope.Content)
+	if err != nil {
+		return nil, err
+	}
+	defer envelopeReadCloser.Close()
+	return types.ReadAttestation(envelopeReadCloser)
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	oldSHA := ""
+	if v.NotationObj.Envelope.Hash != nil && v.NotationObj.Envelope.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.NotationObj.Envelope.Hash.Value)
+	}
+
+	envelopeBytes, err := v.readEnvelope(ctx)
+	if err != nil {
+		return err
+	}
+
+	computedSHA := sha256.Sum256(envelopeBytes)
+	computedSHAStr := hex.EncodeToString(computedSHA[:])
+	if oldSHA != "" && computedSHAStr != oldSHA {
+		return fmt.Errorf("SHA mismatch: %s != %s", computedSHAStr, oldSHA)
+	}
+
+	target, cert, err := verifyEnvelope(swag.StringValue(v.NotationObj.Envelope.MediaType), envelopeBytes)
+	if err != nil {
+		return err
+	}
+	if claimed := v.NotationObj.TargetArtifact; claimed != nil {
+		if swag.StringValue(claimed.Digest) != target.Digest || swag.StringValue(claimed.MediaType) != target.MediaType ||
+			swag.Int64Value(claimed.Size) != target.Size {
+			return errors.New("target artifact does not match the descriptor signed in the envelope")
+		}
+	}
+
+	// if we get here, the envelope signature was verified without error
+	v.envelopeBytes = envelopeBytes
+	v.certObj = cert
+	if oldSHA == "" {
+		v.NotationObj.Envelope.Hash = &models.NotationV001SchemaEnvelopeHash{}
+		v.NotationObj.Envelope.Hash.Algorithm = swag.String(models.NotationV001SchemaEnvelopeHashAlgorithmSha256)
+		v.NotationObj.Envelope.Hash.Value = swag.String(computedSHAStr)
+	}
+	v.NotationObj.TargetArtifact = &models.NotationV001SchemaTargetArtifact{
+		MediaType: swag.String(target.MediaType),
+		Digest:    swag.String(target.Digest),
+		Size:      swag.Int64(target.Size),
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.certObj == nil {
+		return nil, errors.New("certificate object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.NotationV001Schema{}
+
+	// the envelope is kept inline since it is the signature, unless the server stores attestations separately, in
+	// which case only its hash is logged and the envelope is stored once the entry is added, as returned by
+	// PendingAttestations; its URL (if known) is not set deliberately
+	canonicalEntry.Envelope = &models.NotationV001SchemaEnvelope{}
+	canonicalEntry.Envelope.MediaType = v.NotationObj.Envelope.MediaType
+	canonicalEntry.Envelope.Hash = v.NotationObj.Envelope.Hash
+	if types.StoredAttestations() == nil {
+		canonicalEntry.Envelope.Content = strfmt.Base64(v.envelopeBytes)
+	}
+
+	canonicalEntry.TargetArtifact = v.NotationObj.TargetArtifact
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.NotationObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	notationObj := models.Notation{}
+	notationObj.APIVersion = swag.String(APIVERSION)
+	notationObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&notationObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	envelope := v.NotationObj.Envelope
+	if envelope == nil {
+		return errors.New("missing envelope")
+	}
+
+	if len(envelope.Content) == 0 && envelope.URL.String() == "" && (envelope.Hash == nil || types.StoredAttestations() == nil) {
+		return errors.New("one of 'content' or 'url' must be specified for envelope")
+	}
+
+	if swag.StringValue(envelope.MediaType) == "" {
+		return errors.New("missing envelope media type")
+	}
+
+	hash := envelope.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	return nil
+}
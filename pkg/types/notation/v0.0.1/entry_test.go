@@ -0,0 +1,598 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notation
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/types"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const testDigest = "sha256:73c803930ea3ba1e54bc25c2bdc53edd0284c62ed651fe7b00369da519a3c333"
+
+func testPayload(t *testing.T) []byte {
+	t.Helper()
+	payload, err := json.Marshal(notationPayload{
+		TargetArtifact: &descriptor{
+			MediaType: "application/vnd.oci.image.manifest.v1+json",
+			Digest:    testDigest,
+			Size:      16724,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+// testSigner returns a key and a self-signed certificate for it
+func testSigner(t *testing.T, rsaKey bool) (crypto.Signer, []byte) {
+	t.Helper()
+	var priv crypto.Signer
+	var err error
+	if rsaKey {
+		priv, err = rsa.GenerateKey(rand.Reader, 2048)
+	} else {
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "notation-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, certDER
+}
+
+// sign returns a signature in the encoding shared by JWS and COSE: PSS for RSA keys, r||s for ECDSA keys
+func sign(t *testing.T, priv crypto.Signer, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig := make([]byte, 64)
+		rb, sb := r.Bytes(), s.Bytes()
+		copy(sig[32-len(rb):32], rb)
+		copy(sig[64-len(sb):], sb)
+		return sig
+	}
+	t.Fatalf("unexpected key type %T", priv)
+	return nil
+}
+
+func signJWS(t *testing.T, priv crypto.Signer, certDER []byte, alg string, payload []byte) []byte {
+	t.Helper()
+	protected, err := json.Marshal(map[string]interface{}{
+		"alg":                          alg,
+		"cty":                          payloadContentType,
+		"crit":                         []string{"io.cncf.notary.signingScheme"},
+		"io.cncf.notary.signingScheme": "notary.x509",
+		"io.cncf.notary.signingTime":   time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := jwsEnvelope{
+		Payload:   base64.RawURLEncoding.EncodeToString(payload),
+		Protected: base64.RawURLEncoding.EncodeToString(protected),
+	}
+	env.Header.X5C = []string{base64.StdEncoding.EncodeToString(certDER)}
+	env.Signature = base64.RawURLEncoding.EncodeToString(sign(t, priv, []byte(env.Protected+"."+env.Payload)))
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func signCOSE(t *testing.T, priv crypto.Signer, certDER []byte, alg int64, payload []byte) []byte {
+	t.Helper()
+	protected, err := cbor.Marshal(map[interface{}]interface{}{
+		coseHeaderAlgorithm:   alg,
+		coseHeaderContentType: payloadContentType,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	toBeSigned, err := cbor.Marshal([]interface{}{"Signature1", protected, []byte{}, payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := coseSign1{
+		Protected:   protected,
+		Unprotected: map[interface{}]interface{}{coseHeaderX5Chain: [][]byte{certDER}},
+		Payload:     payload,
+		Signature:   sign(t, priv, toBeSigned),
+	}
+	b, err := cbor.Marshal(cbor.Tag{Number: coseSign1Tag, Content: msg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	payload := testPayload(t)
+	ecPriv, ecCert := testSigner(t, false)
+	rsaPriv, rsaCert := testSigner(t, true)
+	jwsBytes := signJWS(t, ecPriv, ecCert, "ES256", payload)
+	rsaJWSBytes := signJWS(t, rsaPriv, rsaCert, "PS256", payload)
+	coseBytes := signCOSE(t, ecPriv, ecCert, -7, payload)
+	rsaCOSEBytes := signCOSE(t, rsaPriv, rsaCert, -37, payload)
+	_, otherCert := testSigner(t, false)
+	wrongCertJWSBytes := signJWS(t, ecPriv, otherCert, "ES256", payload)
+	wrongAlgJWSBytes := signJWS(t, ecPriv, ecCert, "PS256", payload)
+	noTargetJWSBytes := signJWS(t, ecPriv, ecCert, "ES256", []byte(`{}`))
+
+	h := sha256.New()
+	_, _ = h.Write(jwsBytes)
+	jwsSHA := hex.EncodeToString(h.Sum(nil))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &jwsBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/jws":
+				file = &jwsBytes
+			case "/cose":
+				file = &coseBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	jose := swag.String(models.NotationV001SchemaEnvelopeMediaTypeApplicationJoseJSON)
+	cose := swag.String(models.NotationV001SchemaEnvelopeMediaTypeApplicationCose)
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "envelope without content or url",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "envelope without media type",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						Content: strfmt.Base64(jwsBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "JWS envelope url",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						URL:       strfmt.URI(testServer.URL + "/jws"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "JWS envelope url with incorrect hash value",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Hash: &models.NotationV001SchemaEnvelopeHash{
+							Algorithm: swag.String(models.NotationV001SchemaEnvelopeHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/jws"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "JWS envelope url with correct hash value",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Hash: &models.NotationV001SchemaEnvelopeHash{
+							Algorithm: swag.String(models.NotationV001SchemaEnvelopeHashAlgorithmSha256),
+							Value:     swag.String(jwsSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/jws"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "COSE envelope url",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: cose,
+						URL:       strfmt.URI(testServer.URL + "/cose"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "RSA JWS envelope content",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Content:   strfmt.Base64(rsaJWSBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "RSA COSE envelope content",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: cose,
+						Content:   strfmt.Base64(rsaCOSEBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "JWS envelope submitted as COSE",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: cose,
+						Content:   strfmt.Base64(jwsBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "JWS envelope carrying a different certificate",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Content:   strfmt.Base64(wrongCertJWSBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "JWS envelope with algorithm not matching the key",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Content:   strfmt.Base64(wrongAlgJWSBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "JWS envelope without target artifact",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Content:   strfmt.Base64(noTargetJWSBytes),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "JWS envelope with mismatched target artifact",
+			entry: V001Entry{
+				NotationObj: models.NotationV001Schema{
+					Envelope: &models.NotationV001SchemaEnvelope{
+						MediaType: jose,
+						Content:   strfmt.Base64(jwsBytes),
+					},
+					TargetArtifact: &models.NotationV001SchemaTargetArtifact{
+						MediaType: swag.String("application/vnd.oci.image.manifest.v1+json"),
+						Digest:    swag.String("sha256:0000000000000000000000000000000000000000000000000000000000000000"),
+						Size:      swag.Int64(16724),
+					},
+				},
+			},
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Notation{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.NotationObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	priv, certDER := testSigner(t, false)
+	coseBytes := signCOSE(t, priv, certDER, -7, testPayload(t))
+
+	v := V001Entry{
+		NotationObj: models.NotationV001Schema{
+			Envelope: &models.NotationV001SchemaEnvelope{
+				MediaType: swag.String(models.NotationV001SchemaEnvelopeMediaTypeApplicationCose),
+				Content:   strfmt.Base64(coseBytes),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	envelopeSHA := sha256.Sum256(coseBytes)
+	certSHA := sha256.Sum256(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{
+		hex.EncodeToString(envelopeSHA[:]),
+		hex.EncodeToString(certSHA[:]),
+		"73c803930ea3ba1e54bc25c2bdc53edd0284c62ed651fe7b00369da519a3c333",
+	} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
+
+// mapStorage keeps attestations in a map by digest
+type mapStorage map[string][]byte
+
+func (m mapStorage) Put(_ context.Context, content []byte) (string, error) {
+	sum := sha256.Sum256(content)
+	m[hex.EncodeToString(sum[:])] = content
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (m mapStorage) Get(_ context.Context, digest string) ([]byte, error) {
+	content, ok := m[digest]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return content, nil
+}
+
+func (m mapStorage) Has(_ context.Context, digest string) (bool, error) {
+	_, ok := m[digest]
+	return ok, nil
+}
+
+func TestStoredEnvelope(t *testing.T) {
+	priv, certDER := testSigner(t, false)
+	coseBytes := signCOSE(t, priv, certDER, -7, testPayload(t))
+	store := mapStorage{}
+	types.SetAttestationStorage(store)
+	defer types.SetAttestationStorage(nil)
+
+	v := V001Entry{
+		NotationObj: models.NotationV001Schema{
+			Envelope: &models.NotationV001SchemaEnvelope{
+				MediaType: swag.String(models.NotationV001SchemaEnvelopeMediaTypeApplicationCose),
+				Content:   strfmt.Base64(coseBytes),
+			},
+		},
+	}
+	canonical, err := v.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the envelope is only stored once the entry has been added to the log
+	if len(store) != 0 {
+		t.Fatal("envelope stored while canonicalizing")
+	}
+	pending := v.PendingAttestations()
+	if !reflect.DeepEqual(pending, [][]byte{coseBytes}) {
+		t.Fatalf("unexpected pending attestations %v", pending)
+	}
+	if _, err := store.Put(context.TODO(), pending[0]); err != nil {
+		t.Fatal(err)
+	}
+	envelopeSHA := sha256.Sum256(coseBytes)
+
+	var logged models.Notation
+	if err := json.Unmarshal(canonical, &logged); err != nil {
+		t.Fatal(err)
+	}
+	entry := &V001Entry{}
+	if err := entry.Unmarshal(&logged); err != nil {
+		t.Fatalf("unexpected error unmarshalling logged entry: %v", err)
+	}
+	if len(entry.NotationObj.Envelope.Content) != 0 {
+		t.Error("envelope content was logged along with its digest")
+	}
+	if !entry.HasExternalEntities() {
+		t.Error("stored envelope should be an external entity")
+	}
+	if err := entry.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error reading stored envelope: %v", err)
+	}
+	if entry.certObj == nil {
+		t.Error("certificate of stored envelope was not parsed")
+	}
+	if pending := entry.PendingAttestations(); pending != nil {
+		t.Errorf("envelope read from attestation storage is pending again: %v", pending)
+	}
+	if digests := entry.StoredAttestationDigests(); !reflect.DeepEqual(digests, []string{hex.EncodeToString(envelopeSHA[:])}) {
+		t.Errorf("unexpected stored attestation digests %v", digests)
+	}
+
+	types.SetAttestationStorage(nil)
+	if err := (&V001Entry{}).Unmarshal(&logged); err == nil {
+		t.Error("expected error for an envelope logged by digest without attestation storage")
+	}
+}
+
+func TestMaxEnvelopeSize(t *testing.T) {
+	priv, certDER := testSigner(t, false)
+	coseBytes := signCOSE(t, priv, certDER, -7, testPayload(t))
+	defer types.SetMaxAttestationSize(0)
+
+	for _, tc := range []struct {
+		caseDesc      string
+		max           int64
+		expectSuccess bool
+	}{
+		{caseDesc: "no limit", max: 0, expectSuccess: true},
+		{caseDesc: "envelope at the limit", max: int64(len(coseBytes)), expectSuccess: true},
+		{caseDesc: "envelope over the limit", max: int64(len(coseBytes)) - 1, expectSuccess: false},
+	} {
+		types.SetMaxAttestationSize(tc.max)
+		v := V001Entry{
+			NotationObj: models.NotationV001Schema{
+				Envelope: &models.NotationV001SchemaEnvelope{
+					MediaType: swag.String(models.NotationV001SchemaEnvelopeMediaTypeApplicationCose),
+					Content:   strfmt.Base64(coseBytes),
+				},
+			},
+		}
+		err := v.FetchExternalEntities(context.TODO())
+		if (err == nil) != tc.expectSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+		var sizeErr *types.AttestationSizeError
+		if err != nil && !errors.As(err, &sizeErr) {
+			t.Errorf("expected AttestationSizeError in '%v', got %v", tc.caseDesc, err)
+		}
+	}
+}
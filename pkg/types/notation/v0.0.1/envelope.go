@@ -0,0 +1,322 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package notation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/sigstore/rekor/pkg/types"
+
+	// hash functions referenced by the signature algorithms below
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// payloadContentType is the content type of the payload signed by every Notation envelope
+const payloadContentType = "application/vnd.cncf.notary.payload.v1+json"
+
+// coseSign1Tag is the CBOR tag that identifies a COSE_Sign1 structure
+const coseSign1Tag = 18
+
+// COSE header labels, see RFC 8152 section 3.1 and RFC 9360
+const (
+	coseHeaderAlgorithm   = 1
+	coseHeaderContentType = 3
+	coseHeaderX5Chain     = 33
+)
+
+// signatureAlgorithm is one of the algorithms allowed by the Notation signature specification
+type signatureAlgorithm struct {
+	hash   crypto.Hash
+	rsaPSS bool
+}
+
+var jwsAlgorithms = map[string]signatureAlgorithm{
+	"PS256": {crypto.SHA256, true},
+	"PS384": {crypto.SHA384, true},
+	"PS512": {crypto.SHA512, true},
+	"ES256": {crypto.SHA256, false},
+	"ES384": {crypto.SHA384, false},
+	"ES512": {crypto.SHA512, false},
+}
+
+var coseAlgorithms = map[int64]signatureAlgorithm{
+	-37: {crypto.SHA256, true},
+	-38: {crypto.SHA384, true},
+	-39: {crypto.SHA512, true},
+	-7:  {crypto.SHA256, false},
+	-35: {crypto.SHA384, false},
+	-36: {crypto.SHA512, false},
+}
+
+// descriptor is the OCI content descriptor of the signed artifact
+type descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type notationPayload struct {
+	TargetArtifact *descriptor `json:"targetArtifact"`
+}
+
+// jwsEnvelope is the flattened JWS JSON serialization used by Notation
+type jwsEnvelope struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Header    struct {
+		X5C []string `json:"x5c"`
+	} `json:"header"`
+	Signature string `json:"signature"`
+}
+
+type jwsProtectedHeader struct {
+	Algorithm   string `json:"alg"`
+	ContentType string `json:"cty"`
+}
+
+type coseSign1 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected map[interface{}]interface{}
+	Payload     []byte
+	Signature   []byte
+}
+
+// verifyEnvelope checks the signature of a Notation envelope against the leaf certificate it carries,
+// returning the descriptor of the signed artifact and the signing certificate
+func verifyEnvelope(mediaType string, envelope []byte) (*descriptor, *x509.Certificate, error) {
+	var payload []byte
+	var err error
+	var cert *x509.Certificate
+	switch mediaType {
+	case "application/jose+json":
+		payload, cert, err = verifyJWS(envelope)
+	case "application/cose":
+		payload, cert, err = verifyCOSE(envelope)
+	default:
+		return nil, nil, fmt.Errorf("unsupported envelope media type %v", mediaType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := notationPayload{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, nil, fmt.Errorf("error parsing envelope payload: %w", err)
+	}
+	if p.TargetArtifact == nil || p.TargetArtifact.Digest == "" || p.TargetArtifact.MediaType == "" {
+		return nil, nil, errors.New("envelope payload does not describe a target artifact")
+	}
+	return p.TargetArtifact, cert, nil
+}
+
+func verifyJWS(envelope []byte) ([]byte, *x509.Certificate, error) {
+	env := jwsEnvelope{}
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return nil, nil, fmt.Errorf("error parsing JWS envelope: %w", err)
+	}
+
+	protectedBytes, err := base64.RawURLEncoding.DecodeString(env.Protected)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding JWS protected header: %w", err)
+	}
+	protected := jwsProtectedHeader{}
+	if err := json.Unmarshal(protectedBytes, &protected); err != nil {
+		return nil, nil, fmt.Errorf("error parsing JWS protected header: %w", err)
+	}
+	if protected.ContentType != payloadContentType {
+		return nil, nil, fmt.Errorf("unexpected payload content type %q", protected.ContentType)
+	}
+	alg, ok := jwsAlgorithms[protected.Algorithm]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported JWS algorithm %q", protected.Algorithm)
+	}
+
+	// certificates in x5c use standard rather than URL-safe base64 (RFC 7515 section 4.1.6)
+	chain := make([][]byte, 0, len(env.Header.X5C))
+	for _, c := range env.Header.X5C {
+		der, err := base64.StdEncoding.DecodeString(c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error decoding certificate chain: %w", err)
+		}
+		chain = append(chain, der)
+	}
+	cert, err := leafCertificate(chain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding JWS payload: %w", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(env.Signature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding JWS signature: %w", err)
+	}
+	if err := verifySignature(alg, cert, []byte(env.Protected+"."+env.Payload), sig); err != nil {
+		return nil, nil, &types.VerificationError{Err: err}
+	}
+	return payload, cert, nil
+}
+
+func verifyCOSE(envelope []byte) ([]byte, *x509.Certificate, error) {
+	tag := cbor.RawTag{}
+	if err := cbor.Unmarshal(envelope, &tag); err != nil {
+		return nil, nil, fmt.Errorf("error parsing COSE envelope: %w", err)
+	}
+	if tag.Number != coseSign1Tag {
+		return nil, nil, fmt.Errorf("unexpected COSE tag %d", tag.Number)
+	}
+	msg := coseSign1{}
+	if err := cbor.Unmarshal(tag.Content, &msg); err != nil {
+		return nil, nil, fmt.Errorf("error parsing COSE_Sign1 structure: %w", err)
+	}
+
+	protected := map[interface{}]interface{}{}
+	if err := cbor.Unmarshal(msg.Protected, &protected); err != nil {
+		return nil, nil, fmt.Errorf("error parsing COSE protected header: %w", err)
+	}
+	if cty, _ := coseHeader(protected, coseHeaderContentType).(string); cty != payloadContentType {
+		return nil, nil, fmt.Errorf("unexpected payload content type %q", cty)
+	}
+	algLabel, ok := coseInt(coseHeader(protected, coseHeaderAlgorithm))
+	if !ok {
+		return nil, nil, errors.New("COSE protected header does not specify an algorithm")
+	}
+	alg, ok := coseAlgorithms[algLabel]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported COSE algorithm %d", algLabel)
+	}
+
+	// x5chain is a single certificate or an array of them, see RFC 9360 section 2
+	var chain [][]byte
+	switch x5chain := coseHeader(msg.Unprotected, coseHeaderX5Chain).(type) {
+	case []byte:
+		chain = [][]byte{x5chain}
+	case []interface{}:
+		for _, c := range x5chain {
+			der, ok := c.([]byte)
+			if !ok {
+				return nil, nil, errors.New("malformed COSE certificate chain")
+			}
+			chain = append(chain, der)
+		}
+	}
+	cert, err := leafCertificate(chain)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toBeSigned, err := cbor.Marshal([]interface{}{"Signature1", msg.Protected, []byte{}, msg.Payload})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := verifySignature(alg, cert, toBeSigned, msg.Signature); err != nil {
+		return nil, nil, &types.VerificationError{Err: err}
+	}
+	return msg.Payload, cert, nil
+}
+
+// coseHeader returns the value of the header with the given integer label
+func coseHeader(header map[interface{}]interface{}, label int64) interface{} {
+	for k, v := range header {
+		if l, ok := coseInt(k); ok && l == label {
+			return v
+		}
+	}
+	return nil
+}
+
+func coseInt(v interface{}) (int64, bool) {
+	switch i := v.(type) {
+	case int64:
+		return i, true
+	case uint64:
+		if i > 1<<63-1 {
+			return 0, false
+		}
+		return int64(i), true
+	}
+	return 0, false
+}
+
+// leafCertificate parses the first certificate of chain, which signs the envelope; the rest of the chain
+// is only meaningful to a Notation trust policy and is not evaluated here
+func leafCertificate(chain [][]byte) (*x509.Certificate, error) {
+	if len(chain) == 0 {
+		return nil, errors.New("envelope does not contain a signing certificate")
+	}
+	cert, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing signing certificate: %w", err)
+	}
+	return cert, nil
+}
+
+func verifySignature(alg signatureAlgorithm, cert *x509.Certificate, signed, sig []byte) error {
+	h := alg.hash.New()
+	_, _ = h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if !alg.rsaPSS {
+			return errors.New("signature algorithm does not match RSA signing certificate")
+		}
+		if err := rsa.VerifyPSS(pub, alg.hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return fmt.Errorf("envelope signature verification failed: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		if alg.rsaPSS {
+			return errors.New("signature algorithm does not match ECDSA signing certificate")
+		}
+		// both JWS and COSE encode ECDSA signatures as the fixed size concatenation of r and s
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("malformed ECDSA signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("envelope signature verification failed")
+		}
+	default:
+		return fmt.Errorf("unsupported signing certificate key type %T", pub)
+	}
+	return nil
+}
+
+// canonicalCertificate returns the PEM encoding of cert, matching the canonical value of x509 public keys
+func canonicalCertificate(cert *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: cert.Raw,
+	})
+}
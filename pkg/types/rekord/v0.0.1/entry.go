@@ -18,30 +18,35 @@ package rekord
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/sigstore/rekor/pkg/log"
 	"github.com/sigstore/rekor/pkg/types"
 	"github.com/sigstore/rekor/pkg/util"
 
-	"github.com/asaskevich/govalidator"
-
 	"github.com/go-openapi/strfmt"
+	"github.com/mitchellh/mapstructure"
 
 	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/pki/pgp"
 	"github.com/sigstore/rekor/pkg/types/rekord"
 
 	"github.com/go-openapi/swag"
-	"github.com/mitchellh/mapstructure"
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
 	"golang.org/x/sync/errgroup"
+	"lukechampine.com/blake3"
 )
 
 const (
@@ -67,6 +72,9 @@ func NewEntry() types.EntryImpl {
 	return &V001Entry{}
 }
 
+// Base64StringtoByteArray returns a mapstructure hook decoding base64 strings into byte slices.
+//
+// Deprecated: entries are decoded with types.DecodeEntry, which decodes base64 fields itself.
 func Base64StringtoByteArray() mapstructure.DecodeHookFunc {
 	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
 		if f.Kind() != reflect.String || t.Kind() != reflect.Slice {
@@ -91,21 +99,20 @@ func (v V001Entry) IndexKeys() []string {
 		}
 	}
 
-	key, err := v.keyObj.CanonicalValue()
-	if err != nil {
+	if key, err := pki.KeyIndex(v.keyObj); err != nil {
 		log.Logger.Error(err)
 	} else {
-		hasher := sha256.New()
-		if _, err := hasher.Write(key); err != nil {
-			log.Logger.Error(err)
-		} else {
-			result = append(result, strings.ToLower(hex.EncodeToString(hasher.Sum(nil))))
-		}
+		result = append(result, key)
 	}
 
-	if v.RekordObj.Data.Hash != nil {
+	if v.RekordObj.Data != nil && v.RekordObj.Data.Hash != nil {
 		result = append(result, strings.ToLower(swag.StringValue(v.RekordObj.Data.Hash.Value)))
 	}
+	if v.RekordObj.Data != nil {
+		for _, h := range v.RekordObj.Data.AdditionalHashes {
+			result = append(result, strings.ToLower(swag.StringValue(h.Value)))
+		}
+	}
 
 	return result
 }
@@ -116,17 +123,7 @@ func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
 		return errors.New("cannot unmarshal non Rekord v0.0.1 type")
 	}
 
-	cfg := mapstructure.DecoderConfig{
-		DecodeHook: Base64StringtoByteArray(),
-		Result:     &v.RekordObj,
-	}
-
-	dec, err := mapstructure.NewDecoder(&cfg)
-	if err != nil {
-		return fmt.Errorf("error initializing decoder: %w", err)
-	}
-
-	if err := dec.Decode(rekord.Spec); err != nil {
+	if err := types.DecodeEntry(rekord.Spec, &v.RekordObj); err != nil {
 		return err
 	}
 	// field validation
@@ -164,6 +161,12 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 		return err
 	}
 
+	if v.RekordObj.Data == nil {
+		if err := v.splitCleartext(); err != nil {
+			return err
+		}
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	hashR, hashW := io.Pipe()
@@ -186,8 +189,23 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 	}
 
 	oldSHA := ""
+	algorithm := models.RekordV001SchemaDataHashAlgorithmSha256
 	if v.RekordObj.Data.Hash != nil && v.RekordObj.Data.Hash.Value != nil {
 		oldSHA = swag.StringValue(v.RekordObj.Data.Hash.Value)
+		algorithm = swag.StringValue(v.RekordObj.Data.Hash.Algorithm)
+	}
+	hasher, err := newHasher(algorithm)
+	if err != nil {
+		return err
+	}
+	// every additional hash is computed in the same pass over the content
+	hashWriters := []io.Writer{hasher}
+	additionalHashers := make([]hash.Hash, len(v.RekordObj.Data.AdditionalHashes))
+	for i, h := range v.RekordObj.Data.AdditionalHashes {
+		if additionalHashers[i], err = newHasher(swag.StringValue(h.Algorithm)); err != nil {
+			return err
+		}
+		hashWriters = append(hashWriters, additionalHashers[i])
 	}
 	artifactFactory := pki.NewArtifactFactory(v.RekordObj.Signature.Format)
 
@@ -212,9 +230,8 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 
 	g.Go(func() error {
 		defer close(hashResult)
-		hasher := sha256.New()
 
-		if _, err := io.Copy(hasher, hashR); err != nil {
+		if _, err := io.Copy(io.MultiWriter(hashWriters...), hashR); err != nil {
 			return closePipesOnError(err)
 		}
 
@@ -222,6 +239,12 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 		if oldSHA != "" && computedSHA != oldSHA {
 			return closePipesOnError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
 		}
+		for i, h := range v.RekordObj.Data.AdditionalHashes {
+			computed := hex.EncodeToString(additionalHashers[i].Sum(nil))
+			if expected := strings.ToLower(swag.StringValue(h.Value)); computed != expected {
+				return closePipesOnError(fmt.Errorf("%s mismatch: %s != %s", swag.StringValue(h.Algorithm), computed, expected))
+			}
+		}
 
 		select {
 		case <-ctx.Done():
@@ -288,10 +311,11 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 			return closePipesOnError(errors.New("failed to read signature or public key"))
 		}
 
-		var err error
-		if err = v.sigObj.Verify(sigR, v.keyObj); err != nil {
-			return closePipesOnError(err)
+		signers, err := pki.VerifySigners(v.sigObj, sigR, v.keyObj)
+		if err != nil {
+			return closePipesOnError(&types.VerificationError{Err: err})
 		}
+		v.keyObj = signers
 
 		select {
 		case <-ctx.Done():
@@ -310,7 +334,7 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 	// if we get here, all goroutines succeeded without error
 	if oldSHA == "" {
 		v.RekordObj.Data.Hash = &models.RekordV001SchemaDataHash{}
-		v.RekordObj.Data.Hash.Algorithm = swag.String(models.RekordV001SchemaDataHashAlgorithmSha256)
+		v.RekordObj.Data.Hash.Algorithm = swag.String(algorithm)
 		v.RekordObj.Data.Hash.Value = swag.String(computedSHA)
 	}
 
@@ -318,6 +342,52 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 	return nil
 }
 
+// newHasher returns the hash function of an algorithm the data hash may be declared with
+func newHasher(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case models.RekordV001SchemaDataHashAlgorithmSha256:
+		return sha256.New(), nil
+	case models.RekordV001SchemaDataHashAlgorithmSha384:
+		return sha512.New384(), nil
+	case models.RekordV001SchemaDataHashAlgorithmSha512:
+		return sha512.New(), nil
+	case models.RekordV001SchemaDataHashAlgorithmSha3256:
+		return sha3.New256(), nil
+	case models.RekordV001SchemaDataHashAlgorithmSha3512:
+		return sha3.New512(), nil
+	case models.RekordV001SchemaDataHashAlgorithmBlake2b256:
+		return blake2b.New256(nil)
+	case models.RekordV001SchemaDataHashAlgorithmBlake3:
+		return blake3.New(32, nil), nil
+	}
+	return nil, fmt.Errorf("unsupported hash algorithm %q", algorithm)
+}
+
+// validHashValue reports whether value is a hex encoded digest of the size produced by algorithm
+func validHashValue(algorithm, value string) bool {
+	hasher, err := newHasher(algorithm)
+	if err != nil {
+		return false
+	}
+	if len(value) != hex.EncodedLen(hasher.Size()) {
+		return false
+	}
+	_, err = hex.DecodeString(value)
+	return err == nil
+}
+
+// splitCleartext replaces a PGP cleartext signed message supplied as the signature content with its detached
+// signature, and uses the signed text as the data of the entry
+func (v *V001Entry) splitCleartext() error {
+	signed, sig, err := pgp.SplitCleartext(v.RekordObj.Signature.Content)
+	if err != nil {
+		return err
+	}
+	v.RekordObj.Data = &models.RekordV001SchemaData{Content: signed}
+	v.RekordObj.Signature.Content = sig
+	return nil
+}
+
 func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 	if err := v.FetchExternalEntities(ctx); err != nil {
 		return nil, err
@@ -352,6 +422,16 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 
 	canonicalEntry.Data = &models.RekordV001SchemaData{}
 	canonicalEntry.Data.Hash = v.RekordObj.Data.Hash
+	// additional hashes are sorted by algorithm, so the order they were listed in does not change the entry
+	for _, h := range v.RekordObj.Data.AdditionalHashes {
+		canonicalEntry.Data.AdditionalHashes = append(canonicalEntry.Data.AdditionalHashes, &models.RekordV001SchemaDataAdditionalHashesItems0{
+			Algorithm: h.Algorithm,
+			Value:     swag.String(strings.ToLower(swag.StringValue(h.Value))),
+		})
+	}
+	sort.Slice(canonicalEntry.Data.AdditionalHashes, func(i, j int) bool {
+		return swag.StringValue(canonicalEntry.Data.AdditionalHashes[i].Algorithm) < swag.StringValue(canonicalEntry.Data.AdditionalHashes[j].Algorithm)
+	})
 	// data content is not set deliberately
 
 	// ExtraData is copied through unfiltered
@@ -370,6 +450,28 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 	return bytes, nil
 }
 
+// CanonicalSignatureAndKey returns the canonical encodings of the signature and public key, which are only
+// known once external entities have been fetched
+func (v V001Entry) CanonicalSignatureAndKey() ([]byte, []byte, error) {
+	if v.sigObj == nil || v.keyObj == nil {
+		return nil, nil, errors.New("signature and key objects not initialized")
+	}
+	sig, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, key, nil
+}
+
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
 //Validate performs cross-field validation for fields in object
 func (v V001Entry) Validate() error {
 
@@ -377,6 +479,9 @@ func (v V001Entry) Validate() error {
 	if sig == nil {
 		return errors.New("missing signature")
 	}
+	if !pki.IsFormat(sig.Format) {
+		return fmt.Errorf("unsupported signature format %q, expected one of %v", sig.Format, pki.Formats())
+	}
 	if len(sig.Content) == 0 && sig.URL.String() == "" {
 		return errors.New("one of 'content' or 'url' must be specified for signature")
 	}
@@ -391,7 +496,11 @@ func (v V001Entry) Validate() error {
 
 	data := v.RekordObj.Data
 	if data == nil {
-		return errors.New("missing data")
+		// the data may only be left out if it is part of a cleartext signed message
+		if !strings.EqualFold(sig.Format, "pgp") || !pgp.IsCleartext(sig.Content) {
+			return errors.New("missing data")
+		}
+		return nil
 	}
 
 	if len(data.Content) == 0 && data.URL.String() == "" {
@@ -399,10 +508,26 @@ func (v V001Entry) Validate() error {
 	}
 
 	hash := data.Hash
+	algorithms := map[string]bool{models.RekordV001SchemaDataHashAlgorithmSha256: true}
 	if hash != nil {
-		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+		if !validHashValue(swag.StringValue(hash.Algorithm), swag.StringValue(hash.Value)) {
 			return errors.New("invalid value for hash")
 		}
+		algorithms = map[string]bool{swag.StringValue(hash.Algorithm): true}
+	}
+	// each algorithm may only be used once, including by the hash the server computes when none is given
+	for _, h := range data.AdditionalHashes {
+		if h == nil {
+			return errors.New("missing additional hash")
+		}
+		algorithm := swag.StringValue(h.Algorithm)
+		if !validHashValue(algorithm, swag.StringValue(h.Value)) {
+			return fmt.Errorf("invalid value for %v hash", algorithm)
+		}
+		if algorithms[algorithm] {
+			return fmt.Errorf("more than one %v hash specified", algorithm)
+		}
+		algorithms[algorithm] = true
 	}
 
 	return nil
@@ -19,18 +19,23 @@ package rekord
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"go.uber.org/goleak"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"lukechampine.com/blake3"
 )
 
 func TestMain(m *testing.M) {
@@ -56,10 +61,24 @@ func TestCrossFieldValidation(t *testing.T) {
 	sigBytes, _ := ioutil.ReadFile("../../../../tests/test_file.sig")
 	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_public_key.key")
 	dataBytes, _ := ioutil.ReadFile("../../../../tests/test_file.txt")
+	cleartextBytes, _ := ioutil.ReadFile("../../../pki/pgp/testdata/SHA256SUMS.asc")
+	cleartextKeyBytes, _ := ioutil.ReadFile("../../../pki/pgp/testdata/subkey_armored_public.pgp")
 
 	h := sha256.New()
 	_, _ = h.Write(dataBytes)
 	dataSHA := hex.EncodeToString(h.Sum(nil))
+	sum384 := sha512.Sum384(dataBytes)
+	dataSHA384 := hex.EncodeToString(sum384[:])
+	sum512 := sha512.Sum512(dataBytes)
+	dataSHA512 := hex.EncodeToString(sum512[:])
+	sum3256 := sha3.Sum256(dataBytes)
+	dataSHA3256 := hex.EncodeToString(sum3256[:])
+	sum3512 := sha3.Sum512(dataBytes)
+	dataSHA3512 := hex.EncodeToString(sum3512[:])
+	sumBLAKE2b := blake2b.Sum256(dataBytes)
+	dataBLAKE2b256 := hex.EncodeToString(sumBLAKE2b[:])
+	sumBLAKE3 := blake3.Sum256(dataBytes)
+	dataBLAKE3 := hex.EncodeToString(sumBLAKE3[:])
 
 	testServer := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -336,6 +355,25 @@ func TestCrossFieldValidation(t *testing.T) {
 			expectUnmarshalSuccess:    true,
 			expectCanonicalizeSuccess: false,
 		},
+		{
+			caseDesc: "signature with unregistered format",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format:  "unregistered",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(keyBytes),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Content: strfmt.Base64(dataBytes),
+					},
+				},
+			},
+			hasExtEntities:         false,
+			expectUnmarshalSuccess: false,
+		},
 		{
 			caseDesc: "signature with sig content, key content & with data with content",
 			entry: V001Entry{
@@ -404,6 +442,222 @@ func TestCrossFieldValidation(t *testing.T) {
 			expectUnmarshalSuccess:    true,
 			expectCanonicalizeSuccess: true,
 		},
+		{
+			caseDesc: "signature with data & url and complete sha384 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha384),
+							Value:     swag.String(dataSHA384),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "signature with data & url and complete sha512 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha512),
+							Value:     swag.String(dataSHA512),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "signature with data & url and complete sha3-256 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha3256),
+							Value:     swag.String(dataSHA3256),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "signature with data & url and complete sha3-512 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha3512),
+							Value:     swag.String(dataSHA3512),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "signature with data & url and complete blake2b-256 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmBlake2b256),
+							Value:     swag.String(dataBLAKE2b256),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "signature with data & url and complete blake3 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmBlake3),
+							Value:     swag.String(dataBLAKE3),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "signature with data & url and blake3 hash value of other data",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmBlake3),
+							Value:     swag.String(dataBLAKE2b256),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature with data & url and sha3-256 hash value of the wrong length",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha3256),
+							Value:     swag.String(dataSHA3512),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    false,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature with data & url and incorrect sha384 hash value",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format: "pgp",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Data: &models.RekordV001SchemaData{
+						Hash: &models.RekordV001SchemaDataHash{
+							Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha384),
+							Value:     swag.String(dataSHA512[:96]),
+						},
+						URL: strfmt.URI(testServer.URL + "/data"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
 		{
 			caseDesc: "signature with sig content, url key & with data with url and complete hash value",
 			entry: V001Entry{
@@ -496,6 +750,40 @@ func TestCrossFieldValidation(t *testing.T) {
 			expectUnmarshalSuccess:    true,
 			expectCanonicalizeSuccess: true,
 		},
+		{
+			caseDesc: "cleartext signed message without data",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(cleartextBytes),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(cleartextKeyBytes),
+						},
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "cleartext signed message with wrong key",
+			entry: V001Entry{
+				RekordObj: models.RekordV001Schema{
+					Signature: &models.RekordV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(cleartextBytes),
+						PublicKey: &models.RekordV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(keyBytes),
+						},
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
 		{
 			caseDesc: "valid obj with extradata",
 			entry: V001Entry{
@@ -553,3 +841,138 @@ func TestCrossFieldValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestCanonicalHashAlgorithm(t *testing.T) {
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test_file.sig")
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_public_key.key")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test_file.txt")
+	sum := sha3.Sum512(dataBytes)
+
+	v := V001Entry{
+		RekordObj: models.RekordV001Schema{
+			Signature: &models.RekordV001SchemaSignature{
+				Format:    "pgp",
+				Content:   strfmt.Base64(sigBytes),
+				PublicKey: &models.RekordV001SchemaSignaturePublicKey{Content: strfmt.Base64(keyBytes)},
+			},
+			Data: &models.RekordV001SchemaData{
+				Hash: &models.RekordV001SchemaDataHash{
+					Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha3512),
+					Value:     swag.String(hex.EncodeToString(sum[:])),
+				},
+				Content: strfmt.Base64(dataBytes),
+			},
+		},
+	}
+	b, err := v.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical := models.Rekord{}
+	if err := canonical.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	spec, ok := canonical.Spec.(map[string]interface{})
+	if !ok {
+		t.Fatalf("unexpected canonical spec %T", canonical.Spec)
+	}
+	hash := spec["data"].(map[string]interface{})["hash"].(map[string]interface{})
+	if hash["algorithm"] != "sha3-512" || hash["value"] != hex.EncodeToString(sum[:]) {
+		t.Errorf("hash algorithm not preserved in canonical entry: %v", hash)
+	}
+}
+
+func TestAdditionalHashes(t *testing.T) {
+	sigBytes, _ := ioutil.ReadFile("../../../../tests/test_file.sig")
+	keyBytes, _ := ioutil.ReadFile("../../../../tests/test_public_key.key")
+	dataBytes, _ := ioutil.ReadFile("../../../../tests/test_file.txt")
+	sum256 := sha256.Sum256(dataBytes)
+	sum512 := sha512.Sum512(dataBytes)
+	sum3 := sha3.Sum256(dataBytes)
+	dataSHA256 := hex.EncodeToString(sum256[:])
+	dataSHA512 := hex.EncodeToString(sum512[:])
+	dataSHA3 := hex.EncodeToString(sum3[:])
+
+	entry := func(additional ...*models.RekordV001SchemaDataAdditionalHashesItems0) V001Entry {
+		return V001Entry{
+			RekordObj: models.RekordV001Schema{
+				Signature: &models.RekordV001SchemaSignature{
+					Format:    "pgp",
+					Content:   strfmt.Base64(sigBytes),
+					PublicKey: &models.RekordV001SchemaSignaturePublicKey{Content: strfmt.Base64(keyBytes)},
+				},
+				Data: &models.RekordV001SchemaData{
+					Hash: &models.RekordV001SchemaDataHash{
+						Algorithm: swag.String(models.RekordV001SchemaDataHashAlgorithmSha256),
+						Value:     swag.String(dataSHA256),
+					},
+					AdditionalHashes: additional,
+					Content:          strfmt.Base64(dataBytes),
+				},
+			},
+		}
+	}
+	additional := func(algorithm, value string) *models.RekordV001SchemaDataAdditionalHashesItems0 {
+		return &models.RekordV001SchemaDataAdditionalHashesItems0{Algorithm: swag.String(algorithm), Value: swag.String(value)}
+	}
+
+	tests := []struct {
+		caseDesc   string
+		entry      V001Entry
+		errorFound bool
+	}{
+		{caseDesc: "additional sha512 hash", entry: entry(additional("sha512", strings.ToUpper(dataSHA512))), errorFound: false},
+		{caseDesc: "two additional hashes", entry: entry(additional("sha512", dataSHA512), additional("sha3-256", dataSHA3)), errorFound: false},
+		{caseDesc: "additional hash does not match", entry: entry(additional("sha3-256", dataSHA256)), errorFound: true},
+		{caseDesc: "additional hash of wrong length", entry: entry(additional("sha512", dataSHA256)), errorFound: true},
+		{caseDesc: "additional hash repeats primary algorithm", entry: entry(additional("sha256", dataSHA256)), errorFound: true},
+		{caseDesc: "additional hash algorithm repeated", entry: entry(additional("sha512", dataSHA512), additional("sha512", dataSHA512)), errorFound: true},
+	}
+
+	for _, tc := range tests {
+		b, err := tc.entry.Canonicalize(context.TODO())
+		if (err != nil) != tc.errorFound {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		keys := tc.entry.IndexKeys()
+		for _, h := range tc.entry.RekordObj.Data.AdditionalHashes {
+			found := false
+			for _, k := range keys {
+				found = found || k == strings.ToLower(*h.Value)
+			}
+			if !found {
+				t.Errorf("%v: %v hash missing from index keys %v", tc.caseDesc, *h.Algorithm, keys)
+			}
+		}
+
+		canonical := models.Rekord{}
+		if err := canonical.UnmarshalJSON(b); err != nil {
+			t.Fatal(err)
+		}
+		data := canonical.Spec.(map[string]interface{})["data"].(map[string]interface{})
+		hashes := data["additionalHashes"].([]interface{})
+		if len(hashes) != len(tc.entry.RekordObj.Data.AdditionalHashes) {
+			t.Errorf("%v: unexpected additional hashes in canonical entry: %v", tc.caseDesc, hashes)
+		}
+		for i := 1; i < len(hashes); i++ {
+			if hashes[i-1].(map[string]interface{})["algorithm"].(string) > hashes[i].(map[string]interface{})["algorithm"].(string) {
+				t.Errorf("%v: additional hashes not sorted in canonical entry: %v", tc.caseDesc, hashes)
+			}
+		}
+	}
+
+	// entries without additional hashes keep their canonical form
+	plain := entry()
+	b, err := plain.Canonicalize(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "additionalHashes") {
+		t.Errorf("unexpected additionalHashes in canonical entry: %s", b)
+	}
+}
@@ -0,0 +1,357 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package release
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types/release"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	release.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	ReleaseObj              models.ReleaseV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+// ReleaseKey returns the index key used to look up every artifact of a given release
+func ReleaseKey(name, version string) string {
+	return strings.ToLower(fmt.Sprintf("%s@%s", name, version))
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if key, err := pki.KeyIndex(v.keyObj); err != nil {
+		log.Logger.Error(err)
+	} else {
+		result = append(result, key)
+	}
+
+	if v.ReleaseObj.Manifest.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.ReleaseObj.Manifest.Hash.Value)))
+	}
+
+	if rel := v.ReleaseObj.Release; rel != nil {
+		result = append(result, ReleaseKey(swag.StringValue(rel.Name), swag.StringValue(rel.Version)))
+		for _, artifact := range rel.Artifacts {
+			if artifact != nil && artifact.Hash != nil {
+				result = append(result, strings.ToLower(swag.StringValue(artifact.Hash.Value)))
+			}
+		}
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	release, ok := pe.(*models.Release)
+	if !ok {
+		return errors.New("cannot unmarshal non Release v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(release.Spec, &v.ReleaseObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.ReleaseObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.ReleaseObj.Manifest != nil && v.ReleaseObj.Manifest.URL.String() != "" {
+		return true
+	}
+	if v.ReleaseObj.Signature != nil && v.ReleaseObj.Signature.URL.String() != "" {
+		return true
+	}
+	if v.ReleaseObj.Signature != nil && v.ReleaseObj.Signature.PublicKey != nil && v.ReleaseObj.Signature.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	oldSHA := ""
+	if v.ReleaseObj.Manifest.Hash != nil && v.ReleaseObj.Manifest.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.ReleaseObj.Manifest.Hash.Value)
+	}
+	artifactFactory := pki.NewArtifactFactory(v.ReleaseObj.Signature.Format)
+
+	// the manifest is small and must be parsed after it is verified, so it is read fully into memory
+	var manifestBytes []byte
+	g.Go(func() error {
+		manifestReadCloser, err := util.FileOrURLReadCloser(ctx, v.ReleaseObj.Manifest.URL.String(), v.ReleaseObj.Manifest.Content)
+		if err != nil {
+			return err
+		}
+		defer manifestReadCloser.Close()
+
+		manifestBytes, err = ioutil.ReadAll(manifestReadCloser)
+		return err
+	})
+
+	var signature pki.Signature
+	g.Go(func() error {
+		sigReadCloser, err := util.FileOrURLReadCloser(ctx, v.ReleaseObj.Signature.URL.String(),
+			v.ReleaseObj.Signature.Content)
+		if err != nil {
+			return err
+		}
+		defer sigReadCloser.Close()
+
+		signature, err = artifactFactory.NewSignature(sigReadCloser)
+		return err
+	})
+
+	var key pki.PublicKey
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.ReleaseObj.Signature.PublicKey.URL.String(),
+			v.ReleaseObj.Signature.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		key, err = artifactFactory.NewPublicKey(keyReadCloser)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if key == nil || signature == nil {
+		return errors.New("failed to read signature or public key")
+	}
+
+	hasher := sha256.New()
+	if _, err := hasher.Write(manifestBytes); err != nil {
+		return err
+	}
+	computedSHA := hex.EncodeToString(hasher.Sum(nil))
+	if oldSHA != "" && computedSHA != oldSHA {
+		return fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA)
+	}
+
+	signers, err := pki.VerifySigners(signature, bytes.NewReader(manifestBytes), key)
+	if err != nil {
+		return &types.VerificationError{Err: err}
+	}
+
+	rel := &models.ReleaseV001SchemaRelease{}
+	if err := json.Unmarshal(manifestBytes, rel); err != nil {
+		return fmt.Errorf("error parsing release manifest: %w", err)
+	}
+	if err := rel.Validate(strfmt.Default); err != nil {
+		return fmt.Errorf("invalid release manifest: %w", err)
+	}
+	for _, artifact := range rel.Artifacts {
+		if artifact == nil || !govalidator.IsHash(swag.StringValue(artifact.Hash.Value), swag.StringValue(artifact.Hash.Algorithm)) {
+			return errors.New("invalid value for artifact hash in release manifest")
+		}
+	}
+
+	// if we get here, the manifest was verified and parsed without error
+	v.keyObj, v.sigObj = signers, signature
+	v.ReleaseObj.Release = rel
+	if oldSHA == "" {
+		v.ReleaseObj.Manifest.Hash = &models.ReleaseV001SchemaManifestHash{}
+		v.ReleaseObj.Manifest.Hash.Algorithm = swag.String(models.ReleaseV001SchemaManifestHashAlgorithmSha256)
+		v.ReleaseObj.Manifest.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.sigObj == nil {
+		return nil, errors.New("signature object not initialized before canonicalization")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.ReleaseV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.ReleaseV001SchemaSignature{}
+	// signature URL (if known) is not set deliberately
+	canonicalEntry.Signature.Format = v.ReleaseObj.Signature.Format
+
+	var err error
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// key URL (if known) is not set deliberately
+	canonicalEntry.Signature.PublicKey = &models.ReleaseV001SchemaSignaturePublicKey{}
+	canonicalEntry.Signature.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Manifest = &models.ReleaseV001SchemaManifest{}
+	canonicalEntry.Manifest.Hash = v.ReleaseObj.Manifest.Hash
+	// manifest content is not set deliberately; its contents are captured in Release
+
+	canonicalEntry.Release = v.ReleaseObj.Release
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.ReleaseObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	releaseObj := models.Release{}
+	releaseObj.APIVersion = swag.String(APIVERSION)
+	releaseObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&releaseObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// CanonicalSignatureAndKey returns the canonical encodings of the signature and public key, which are only
+// known once external entities have been fetched
+func (v V001Entry) CanonicalSignatureAndKey() ([]byte, []byte, error) {
+	if v.sigObj == nil || v.keyObj == nil {
+		return nil, nil, errors.New("signature and key objects not initialized")
+	}
+	sig, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, key, nil
+}
+
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	sig := v.ReleaseObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if !pki.IsFormat(sig.Format) {
+		return fmt.Errorf("unsupported signature format %q, expected one of %v", sig.Format, pki.Formats())
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	manifest := v.ReleaseObj.Manifest
+	if manifest == nil {
+		return errors.New("missing manifest")
+	}
+
+	if len(manifest.Content) == 0 && manifest.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for manifest")
+	}
+
+	hash := manifest.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	return nil
+}
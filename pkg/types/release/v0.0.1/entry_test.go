@@ -0,0 +1,389 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package release
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.uber.org/goleak"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const testManifest = `{
+  "name": "rekor-cli",
+  "version": "v0.1.0",
+  "artifacts": [
+    {
+      "name": "rekor-cli-linux-amd64",
+      "platform": "linux/amd64",
+      "hash": {"algorithm": "sha256", "value": "88f5b7a4d51e1e0a5f2b3d6f39a3c1b8f16c5e2ad1d5b5e19b4ad5a0c1a5f2b3"}
+    },
+    {
+      "name": "rekor-cli-darwin-arm64",
+      "platform": "darwin/arm64",
+      "hash": {"algorithm": "sha256", "value": "0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"}
+    }
+  ]
+}`
+
+func signManifest(t *testing.T, manifest []byte) (sig []byte, pubKey []byte) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(manifest)
+	sig, err = ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	return sig, pubKey
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	manifestBytes := []byte(testManifest)
+	sigBytes, keyBytes := signManifest(t, manifestBytes)
+	invalidManifestBytes := []byte(`{"name": "rekor-cli", "version": "v0.1.0", "artifacts": []}`)
+	invalidSigBytes, invalidKeyBytes := signManifest(t, invalidManifestBytes)
+
+	h := sha256.New()
+	_, _ = h.Write(manifestBytes)
+	manifestSHA := hex.EncodeToString(h.Sum(nil))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &sigBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/signature":
+				file = &sigBytes
+			case "/key":
+				file = &keyBytes
+			case "/manifest":
+				file = &manifestBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without url or content",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+					},
+				},
+			},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without public key",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without manifest",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "manifest url without hash",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						URL: strfmt.URI(testServer.URL + "/manifest"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "manifest url with incorrect hash value",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						Hash: &models.ReleaseV001SchemaManifestHash{
+							Algorithm: swag.String(models.ReleaseV001SchemaManifestHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/manifest"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "manifest url with 404 error on signature",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+						URL:    strfmt.URI(testServer.URL + "/404"),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						Hash: &models.ReleaseV001SchemaManifestHash{
+							Algorithm: swag.String(models.ReleaseV001SchemaManifestHashAlgorithmSha256),
+							Value:     swag.String(manifestSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/manifest"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "manifest url with complete hash value",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format: "x509",
+						URL:    strfmt.URI(testServer.URL + "/signature"),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							URL: strfmt.URI(testServer.URL + "/key"),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						Hash: &models.ReleaseV001SchemaManifestHash{
+							Algorithm: swag.String(models.ReleaseV001SchemaManifestHashAlgorithmSha256),
+							Value:     swag.String(manifestSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/manifest"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "manifest content signed by a different key",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format:  "x509",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(invalidKeyBytes),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						Content: strfmt.Base64(manifestBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signed manifest content without artifacts",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format:  "x509",
+						Content: strfmt.Base64(invalidSigBytes),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(invalidKeyBytes),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						Content: strfmt.Base64(invalidManifestBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature with sig content, key content & manifest content",
+			entry: V001Entry{
+				ReleaseObj: models.ReleaseV001Schema{
+					Signature: &models.ReleaseV001SchemaSignature{
+						Format:  "x509",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(keyBytes),
+						},
+					},
+					Manifest: &models.ReleaseV001SchemaManifest{
+						Content: strfmt.Base64(manifestBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Release{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.ReleaseObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	manifestBytes := []byte(testManifest)
+	sigBytes, keyBytes := signManifest(t, manifestBytes)
+
+	v := V001Entry{
+		ReleaseObj: models.ReleaseV001Schema{
+			Signature: &models.ReleaseV001SchemaSignature{
+				Format:  "x509",
+				Content: strfmt.Base64(sigBytes),
+				PublicKey: &models.ReleaseV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(keyBytes),
+				},
+			},
+			Manifest: &models.ReleaseV001SchemaManifest{
+				Content: strfmt.Base64(manifestBytes),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{
+		"rekor-cli@v0.1.0",
+		"88f5b7a4d51e1e0a5f2b3d6f39a3c1b8f16c5e2ad1d5b5e19b4ad5a0c1a5f2b3",
+		"0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0",
+	} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
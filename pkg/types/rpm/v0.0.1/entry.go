@@ -18,14 +18,12 @@ package rpm
 import (
 	"context"
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"reflect"
 	"strconv"
 	"strings"
 
@@ -43,7 +41,6 @@ import (
 
 	rpmutils "github.com/cavaliercoder/go-rpm"
 	"github.com/go-openapi/swag"
-	"github.com/mitchellh/mapstructure"
 	"github.com/sigstore/rekor/pkg/generated/models"
 	"golang.org/x/sync/errgroup"
 )
@@ -71,20 +68,6 @@ func NewEntry() types.EntryImpl {
 	return &V001Entry{}
 }
 
-func Base64StringtoByteArray() mapstructure.DecodeHookFunc {
-	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
-		if f.Kind() != reflect.String || t.Kind() != reflect.Slice {
-			return data, nil
-		}
-
-		bytes, err := base64.StdEncoding.DecodeString(data.(string))
-		if err != nil {
-			return []byte{}, fmt.Errorf("failed parsing base64 data: %v", err)
-		}
-		return bytes, nil
-	}
-}
-
 func (v V001Entry) IndexKeys() []string {
 	var result []string
 
@@ -95,16 +78,10 @@ func (v V001Entry) IndexKeys() []string {
 		}
 	}
 
-	key, err := v.keyObj.CanonicalValue()
-	if err != nil {
+	if key, err := pki.KeyIndex(v.keyObj); err != nil {
 		log.Logger.Error(err)
 	} else {
-		hasher := sha256.New()
-		if _, err := hasher.Write(key); err != nil {
-			log.Logger.Error(err)
-		} else {
-			result = append(result, strings.ToLower(hex.EncodeToString(hasher.Sum(nil))))
-		}
+		result = append(result, key)
 	}
 
 	if v.RPMModel.Package.Hash != nil {
@@ -120,17 +97,7 @@ func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
 		return errors.New("cannot unmarshal non RPM v0.0.1 type")
 	}
 
-	cfg := mapstructure.DecoderConfig{
-		DecodeHook: Base64StringtoByteArray(),
-		Result:     &v.RPMModel,
-	}
-
-	dec, err := mapstructure.NewDecoder(&cfg)
-	if err != nil {
-		return fmt.Errorf("error initializing decoder: %w", err)
-	}
-
-	if err := dec.Decode(rpm.Spec); err != nil {
+	if err := types.DecodeEntry(rpm.Spec, &v.RPMModel); err != nil {
 		return err
 	}
 	// field validation
@@ -253,7 +220,7 @@ func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
 		}
 
 		if _, err := rpmutils.GPGCheck(sigR, keyring); err != nil {
-			return closePipesOnError(err)
+			return closePipesOnError(&types.VerificationError{Err: err})
 		}
 
 		select {
@@ -359,6 +326,11 @@ func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
 	return bytes, nil
 }
 
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
 //Validate performs cross-field validation for fields in object
 func (v V001Entry) Validate() error {
 	key := v.RPMModel.PublicKey
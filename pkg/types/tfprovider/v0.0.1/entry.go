@@ -0,0 +1,371 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tfprovider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types/tfprovider"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+func init() {
+	tfprovider.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	TfproviderObj           models.TfproviderV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if key, err := pki.KeyIndex(v.keyObj); err != nil {
+		log.Logger.Error(err)
+	} else {
+		result = append(result, key)
+	}
+
+	if v.TfproviderObj.Sha256sums.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.TfproviderObj.Sha256sums.Hash.Value)))
+	}
+
+	for _, archive := range v.TfproviderObj.Sha256sums.Archives {
+		if archive != nil {
+			result = append(result, strings.ToLower(swag.StringValue(archive.Sha256)))
+		}
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	tfprovider, ok := pe.(*models.Tfprovider)
+	if !ok {
+		return errors.New("cannot unmarshal non Terraform provider v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(tfprovider.Spec, &v.TfproviderObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.TfproviderObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.TfproviderObj.Sha256sums != nil && v.TfproviderObj.Sha256sums.URL.String() != "" {
+		return true
+	}
+	if v.TfproviderObj.Signature != nil && v.TfproviderObj.Signature.URL.String() != "" {
+		return true
+	}
+	if v.TfproviderObj.PublicKey != nil && v.TfproviderObj.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+// parseSHA256SUMS reads the output of sha256sum(1) and returns the entries that
+// refer to provider zip archives; other files (e.g. the registry manifest) are skipped
+func parseSHA256SUMS(sums []byte) ([]*models.TfproviderV001SchemaSha256sumsArchivesItems0, error) {
+	var archives []*models.TfproviderV001SchemaSha256sumsArchivesItems0
+
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("malformed line in SHA256SUMS: %q", line)
+		}
+		digest, filename := strings.ToLower(fields[0]), strings.TrimPrefix(fields[1], "*")
+		if !govalidator.IsSHA256(digest) {
+			return nil, fmt.Errorf("invalid SHA256 digest in SHA256SUMS for %v", filename)
+		}
+		if !strings.HasSuffix(filename, ".zip") {
+			continue
+		}
+		archives = append(archives, &models.TfproviderV001SchemaSha256sumsArchivesItems0{
+			Filename: swag.String(filename),
+			Sha256:   swag.String(digest),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(archives) == 0 {
+		return nil, errors.New("SHA256SUMS does not reference any provider zip archives")
+	}
+	return archives, nil
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	oldSHA := ""
+	if v.TfproviderObj.Sha256sums.Hash != nil && v.TfproviderObj.Sha256sums.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.TfproviderObj.Sha256sums.Hash.Value)
+	}
+	artifactFactory := pki.NewArtifactFactory("pgp")
+
+	// SHA256SUMS is small and must be parsed after it is verified, so it is read fully into memory
+	var sumsBytes []byte
+	g.Go(func() error {
+		sumsReadCloser, err := util.FileOrURLReadCloser(ctx, v.TfproviderObj.Sha256sums.URL.String(), v.TfproviderObj.Sha256sums.Content)
+		if err != nil {
+			return err
+		}
+		defer sumsReadCloser.Close()
+
+		sumsBytes, err = ioutil.ReadAll(sumsReadCloser)
+		return err
+	})
+
+	var signature pki.Signature
+	g.Go(func() error {
+		sigReadCloser, err := util.FileOrURLReadCloser(ctx, v.TfproviderObj.Signature.URL.String(),
+			v.TfproviderObj.Signature.Content)
+		if err != nil {
+			return err
+		}
+		defer sigReadCloser.Close()
+
+		signature, err = artifactFactory.NewSignature(sigReadCloser)
+		return err
+	})
+
+	var key pki.PublicKey
+	g.Go(func() error {
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.TfproviderObj.PublicKey.URL.String(),
+			v.TfproviderObj.PublicKey.Content)
+		if err != nil {
+			return err
+		}
+		defer keyReadCloser.Close()
+
+		key, err = artifactFactory.NewPublicKey(keyReadCloser)
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if key == nil || signature == nil {
+		return errors.New("failed to read signature or public key")
+	}
+
+	hasher := sha256.New()
+	if _, err := hasher.Write(sumsBytes); err != nil {
+		return err
+	}
+	computedSHA := hex.EncodeToString(hasher.Sum(nil))
+	if oldSHA != "" && computedSHA != oldSHA {
+		return fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA)
+	}
+
+	signers, err := pki.VerifySigners(signature, bytes.NewReader(sumsBytes), key)
+	if err != nil {
+		return &types.VerificationError{Err: err}
+	}
+
+	archives, err := parseSHA256SUMS(sumsBytes)
+	if err != nil {
+		return err
+	}
+
+	// if we get here, SHA256SUMS was verified and parsed without error
+	v.keyObj, v.sigObj = signers, signature
+	v.TfproviderObj.Sha256sums.Archives = archives
+	if oldSHA == "" {
+		v.TfproviderObj.Sha256sums.Hash = &models.TfproviderV001SchemaSha256sumsHash{}
+		v.TfproviderObj.Sha256sums.Hash.Algorithm = swag.String(models.TfproviderV001SchemaSha256sumsHashAlgorithmSha256)
+		v.TfproviderObj.Sha256sums.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.sigObj == nil {
+		return nil, errors.New("signature object not initialized before canonicalization")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.TfproviderV001Schema{}
+
+	var err error
+	// signature URL (if known) is not set deliberately
+	canonicalEntry.Signature = &models.TfproviderV001SchemaSignature{}
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// key URL (if known) is not set deliberately
+	canonicalEntry.PublicKey = &models.TfproviderV001SchemaPublicKey{}
+	canonicalEntry.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Sha256sums = &models.TfproviderV001SchemaSha256sums{}
+	canonicalEntry.Sha256sums.Hash = v.TfproviderObj.Sha256sums.Hash
+	canonicalEntry.Sha256sums.Archives = v.TfproviderObj.Sha256sums.Archives
+	// SHA256SUMS content is not set deliberately; the archives it lists are captured above
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.TfproviderObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	tfproviderObj := models.Tfprovider{}
+	tfproviderObj.APIVersion = swag.String(APIVERSION)
+	tfproviderObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&tfproviderObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// CanonicalSignatureAndKey returns the canonical encodings of the signature and public key, which are only
+// known once external entities have been fetched
+func (v V001Entry) CanonicalSignatureAndKey() ([]byte, []byte, error) {
+	if v.sigObj == nil || v.keyObj == nil {
+		return nil, nil, errors.New("signature and key objects not initialized")
+	}
+	sig, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, key, nil
+}
+
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	sig := v.TfproviderObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := v.TfproviderObj.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	sums := v.TfproviderObj.Sha256sums
+	if sums == nil {
+		return errors.New("missing sha256sums")
+	}
+
+	if len(sums.Content) == 0 && sums.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for sha256sums")
+	}
+
+	hash := sums.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	return nil
+}
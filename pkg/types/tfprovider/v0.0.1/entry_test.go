@@ -0,0 +1,379 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tfprovider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.uber.org/goleak"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+const (
+	linuxZipSHA  = "5e4ce3e9b5a0c1d2e3f405162738495a6b7c8d9e0f1a2b3c4d5e6f708192a3b4"
+	darwinZipSHA = "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"
+)
+
+var testSHA256SUMS = []byte(linuxZipSHA + "  terraform-provider-example_1.0.0_linux_amd64.zip\n" +
+	darwinZipSHA + "  terraform-provider-example_1.0.0_darwin_arm64.zip\n" +
+	"0000000000000000000000000000000000000000000000000000000000000000  terraform-provider-example_1.0.0_manifest.json\n")
+
+func signSHA256SUMS(t *testing.T, sums []byte) (sig []byte, pubKey []byte) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Example Provider", "", "provider@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sigBuf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sigBuf, entity, bytes.NewReader(sums), nil); err != nil {
+		t.Fatal(err)
+	}
+	var keyBuf bytes.Buffer
+	w, err := armor.Encode(&keyBuf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return sigBuf.Bytes(), keyBuf.Bytes()
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	sumsBytes := testSHA256SUMS
+	sigBytes, keyBytes := signSHA256SUMS(t, sumsBytes)
+	_, otherKeyBytes := signSHA256SUMS(t, sumsBytes)
+	noZipsBytes := []byte("0000000000000000000000000000000000000000000000000000000000000000  terraform-provider-example_1.0.0_manifest.json\n")
+	noZipsSigBytes, noZipsKeyBytes := signSHA256SUMS(t, noZipsBytes)
+
+	h := sha256.New()
+	_, _ = h.Write(sumsBytes)
+	sumsSHA := hex.EncodeToString(h.Sum(nil))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &sigBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/signature":
+				file = &sigBytes
+			case "/key":
+				file = &keyBytes
+			case "/sha256sums":
+				file = &sumsBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without public key",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature and key without sha256sums",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "sha256sums url without hash",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						URL: strfmt.URI(testServer.URL + "/sha256sums"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "sha256sums url with incorrect hash value",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						Hash: &models.TfproviderV001SchemaSha256sumsHash{
+							Algorithm: swag.String(models.TfproviderV001SchemaSha256sumsHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/sha256sums"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "sha256sums url with 404 error on key",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/404"),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						Hash: &models.TfproviderV001SchemaSha256sumsHash{
+							Algorithm: swag.String(models.TfproviderV001SchemaSha256sumsHashAlgorithmSha256),
+							Value:     swag.String(sumsSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/sha256sums"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "sha256sums url with complete hash value",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						URL: strfmt.URI(testServer.URL + "/signature"),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						URL: strfmt.URI(testServer.URL + "/key"),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						Hash: &models.TfproviderV001SchemaSha256sumsHash{
+							Algorithm: swag.String(models.TfproviderV001SchemaSha256sumsHashAlgorithmSha256),
+							Value:     swag.String(sumsSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/sha256sums"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "sha256sums content signed by a different key",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						Content: strfmt.Base64(otherKeyBytes),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						Content: strfmt.Base64(sumsBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signed sha256sums content without any zip archives",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						Content: strfmt.Base64(noZipsSigBytes),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						Content: strfmt.Base64(noZipsKeyBytes),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						Content: strfmt.Base64(noZipsBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature content, key content & sha256sums content",
+			entry: V001Entry{
+				TfproviderObj: models.TfproviderV001Schema{
+					Signature: &models.TfproviderV001SchemaSignature{
+						Content: strfmt.Base64(sigBytes),
+					},
+					PublicKey: &models.TfproviderV001SchemaPublicKey{
+						Content: strfmt.Base64(keyBytes),
+					},
+					Sha256sums: &models.TfproviderV001SchemaSha256sums{
+						Content: strfmt.Base64(sumsBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Tfprovider{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.TfproviderObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestParseSHA256SUMS(t *testing.T) {
+	archives, err := parseSHA256SUMS(testSHA256SUMS)
+	if err != nil {
+		t.Fatalf("unexpected error parsing SHA256SUMS: %v", err)
+	}
+	if len(archives) != 2 {
+		t.Fatalf("expected 2 zip archives, got %d", len(archives))
+	}
+	if swag.StringValue(archives[0].Sha256) != linuxZipSHA || swag.StringValue(archives[1].Sha256) != darwinZipSHA {
+		t.Errorf("unexpected archive digests parsed from SHA256SUMS")
+	}
+
+	if _, err := parseSHA256SUMS([]byte("not-a-digest  terraform-provider-example_1.0.0_linux_amd64.zip\n")); err == nil {
+		t.Error("unexpected success parsing SHA256SUMS with invalid digest")
+	}
+	if _, err := parseSHA256SUMS([]byte(linuxZipSHA + "\n")); err == nil {
+		t.Error("unexpected success parsing SHA256SUMS with missing file name")
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	sigBytes, keyBytes := signSHA256SUMS(t, testSHA256SUMS)
+
+	v := V001Entry{
+		TfproviderObj: models.TfproviderV001Schema{
+			Signature: &models.TfproviderV001SchemaSignature{
+				Content: strfmt.Base64(sigBytes),
+			},
+			PublicKey: &models.TfproviderV001SchemaPublicKey{
+				Content: strfmt.Base64(keyBytes),
+			},
+			Sha256sums: &models.TfproviderV001SchemaSha256sums{
+				Content: strfmt.Base64(testSHA256SUMS),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{linuxZipSHA, darwinZipSHA} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
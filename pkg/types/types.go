@@ -18,10 +18,12 @@ package types
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 
 	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/rekor/pkg/pki"
 )
 
 type TypeImpl interface {
@@ -39,6 +41,32 @@ type EntryImpl interface {
 	Validate() error
 }
 
+// DetachedSignature is optionally implemented by entries whose signature is a standalone blob over the
+// artifact, which allows the server to notice the same signature being logged under different public keys
+type DetachedSignature interface {
+	CanonicalSignatureAndKey() ([]byte, []byte, error)
+}
+
+// SigningKey is optionally implemented by entries whose signature is verified with a pki public key, which
+// allows the server to check the key against the time the entry is integrated
+type SigningKey interface {
+	SigningKey() pki.PublicKey
+}
+
+// VerificationError is returned when the signature of an entry does not verify against its public key or
+// certificate, as opposed to the entry being malformed or its content being unavailable
+type VerificationError struct {
+	Err error
+}
+
+func (e *VerificationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
 type TypeFactory func() TypeImpl
 
 type typeMap struct {
@@ -76,3 +104,14 @@ func NewEntry(pe models.ProposedEntry) (EntryImpl, error) {
 	}
 	return nil, fmt.Errorf("could not create entry for kind '%v'", pe.Kind())
 }
+
+// DecodeEntry decodes the spec of a proposed entry into the schema of its kind. The spec is either the generic
+// JSON value the generated ProposedEntry unmarshalled it to or a schema built in code, so it is round-tripped
+// through JSON, which decodes base64 fields the way the schemas define them
+func DecodeEntry(spec interface{}, schema interface{}) error {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("error marshalling spec: %w", err)
+	}
+	return json.Unmarshal(b, schema)
+}
@@ -0,0 +1,441 @@
+/*
+Copyright © 2020 Bob Callaway <bcallawa@redhat.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package vmimage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/sigstore/rekor/pkg/log"
+	"github.com/sigstore/rekor/pkg/types"
+	"github.com/sigstore/rekor/pkg/util"
+
+	"github.com/asaskevich/govalidator"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/sigstore/rekor/pkg/pki"
+	"github.com/sigstore/rekor/pkg/types/vmimage"
+
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	APIVERSION = "0.0.1"
+)
+
+var amiIDRegexp = regexp.MustCompile(`^ami-([0-9a-f]{8}|[0-9a-f]{17})$`)
+
+func init() {
+	vmimage.SemVerToFacFnMap.Set(APIVERSION, NewEntry)
+}
+
+type V001Entry struct {
+	VmimageObj              models.VmimageV001Schema
+	fetchedExternalEntities bool
+	keyObj                  pki.PublicKey
+	sigObj                  pki.Signature
+}
+
+func (v V001Entry) APIVersion() string {
+	return APIVERSION
+}
+
+func NewEntry() types.EntryImpl {
+	return &V001Entry{}
+}
+
+// ImageIdentifier formats the identifier of a published image the way it is searched for; regional
+// images are qualified by their region since providers only guarantee IDs are unique within one
+func ImageIdentifier(region, id string) string {
+	if region == "" {
+		return id
+	}
+	return fmt.Sprintf("%s:%s", region, id)
+}
+
+// ImageKey returns the index key used to look up every entry for a published image
+func ImageKey(identifier string) string {
+	return "image:" + strings.ToLower(identifier)
+}
+
+func (v V001Entry) IndexKeys() []string {
+	var result []string
+
+	if v.HasExternalEntities() {
+		if err := v.FetchExternalEntities(context.Background()); err != nil {
+			log.Logger.Error(err)
+			return result
+		}
+	}
+
+	if key, err := pki.KeyIndex(v.keyObj); err != nil {
+		log.Logger.Error(err)
+	} else {
+		result = append(result, key)
+	}
+
+	if v.VmimageObj.Image.Hash != nil {
+		result = append(result, strings.ToLower(swag.StringValue(v.VmimageObj.Image.Hash.Value)))
+	}
+
+	if id := v.VmimageObj.Image.Identifier; id != nil {
+		result = append(result, ImageKey(ImageIdentifier(id.Region, swag.StringValue(id.ID))))
+	}
+
+	return result
+}
+
+func (v *V001Entry) Unmarshal(pe models.ProposedEntry) error {
+	vmimage, ok := pe.(*models.Vmimage)
+	if !ok {
+		return errors.New("cannot unmarshal non VM image v0.0.1 type")
+	}
+
+	if err := types.DecodeEntry(vmimage.Spec, &v.VmimageObj); err != nil {
+		return err
+	}
+	// field validation
+	if err := v.VmimageObj.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	// cross field validation
+	return v.Validate()
+}
+
+func (v V001Entry) HasExternalEntities() bool {
+	if v.fetchedExternalEntities {
+		return false
+	}
+
+	if v.VmimageObj.Image != nil && v.VmimageObj.Image.URL.String() != "" {
+		return true
+	}
+	if v.VmimageObj.Signature != nil && v.VmimageObj.Signature.URL.String() != "" {
+		return true
+	}
+	if v.VmimageObj.Signature != nil && v.VmimageObj.Signature.PublicKey != nil && v.VmimageObj.Signature.PublicKey.URL.String() != "" {
+		return true
+	}
+	return false
+}
+
+func (v *V001Entry) FetchExternalEntities(ctx context.Context) error {
+	if v.fetchedExternalEntities {
+		return nil
+	}
+
+	if err := v.Validate(); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	hashR, hashW := io.Pipe()
+	sigR, sigW := io.Pipe()
+	defer hashR.Close()
+	defer sigR.Close()
+
+	closePipesOnError := func(err error) error {
+		pipeReaders := []*io.PipeReader{hashR, sigR}
+		pipeWriters := []*io.PipeWriter{hashW, sigW}
+		for idx := range pipeReaders {
+			if e := pipeReaders[idx].CloseWithError(err); e != nil {
+				log.Logger.Error(fmt.Errorf("error closing pipe: %w", e))
+			}
+			if e := pipeWriters[idx].CloseWithError(err); e != nil {
+				log.Logger.Error(fmt.Errorf("error closing pipe: %w", e))
+			}
+		}
+		return err
+	}
+
+	oldSHA := ""
+	if v.VmimageObj.Image.Hash != nil && v.VmimageObj.Image.Hash.Value != nil {
+		oldSHA = swag.StringValue(v.VmimageObj.Image.Hash.Value)
+	}
+	artifactFactory := pki.NewArtifactFactory(v.VmimageObj.Signature.Format)
+
+	g.Go(func() error {
+		defer hashW.Close()
+		defer sigW.Close()
+
+		dataReadCloser, err := util.FileOrURLReadCloser(ctx, v.VmimageObj.Image.URL.String(), v.VmimageObj.Image.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer dataReadCloser.Close()
+
+		/* #nosec G110 */
+		if _, err := io.Copy(io.MultiWriter(hashW, sigW), dataReadCloser); err != nil {
+			return closePipesOnError(err)
+		}
+		return nil
+	})
+
+	hashResult := make(chan string)
+
+	g.Go(func() error {
+		defer close(hashResult)
+		hasher := sha256.New()
+
+		if _, err := io.Copy(hasher, hashR); err != nil {
+			return closePipesOnError(err)
+		}
+
+		computedSHA := hex.EncodeToString(hasher.Sum(nil))
+		if oldSHA != "" && computedSHA != oldSHA {
+			return closePipesOnError(fmt.Errorf("SHA mismatch: %s != %s", computedSHA, oldSHA))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case hashResult <- computedSHA:
+			return nil
+		}
+	})
+
+	sigResult := make(chan pki.Signature)
+
+	g.Go(func() error {
+		defer close(sigResult)
+
+		sigReadCloser, err := util.FileOrURLReadCloser(ctx, v.VmimageObj.Signature.URL.String(),
+			v.VmimageObj.Signature.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer sigReadCloser.Close()
+
+		signature, err := artifactFactory.NewSignature(sigReadCloser)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case sigResult <- signature:
+			return nil
+		}
+	})
+
+	keyResult := make(chan pki.PublicKey)
+
+	g.Go(func() error {
+		defer close(keyResult)
+
+		keyReadCloser, err := util.FileOrURLReadCloser(ctx, v.VmimageObj.Signature.PublicKey.URL.String(),
+			v.VmimageObj.Signature.PublicKey.Content)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+		defer keyReadCloser.Close()
+
+		key, err := artifactFactory.NewPublicKey(keyReadCloser)
+		if err != nil {
+			return closePipesOnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case keyResult <- key:
+			return nil
+		}
+	})
+
+	g.Go(func() error {
+		v.keyObj, v.sigObj = <-keyResult, <-sigResult
+
+		if v.keyObj == nil || v.sigObj == nil {
+			return closePipesOnError(errors.New("failed to read signature or public key"))
+		}
+
+		signers, err := pki.VerifySigners(v.sigObj, sigR, v.keyObj)
+		if err != nil {
+			return closePipesOnError(&types.VerificationError{Err: err})
+		}
+		v.keyObj = signers
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			return nil
+		}
+	})
+
+	computedSHA := <-hashResult
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// if we get here, all goroutines succeeded without error
+	if oldSHA == "" {
+		v.VmimageObj.Image.Hash = &models.VmimageV001SchemaImageHash{}
+		v.VmimageObj.Image.Hash.Algorithm = swag.String(models.VmimageV001SchemaImageHashAlgorithmSha256)
+		v.VmimageObj.Image.Hash.Value = swag.String(computedSHA)
+	}
+
+	v.fetchedExternalEntities = true
+	return nil
+}
+
+func (v *V001Entry) Canonicalize(ctx context.Context) ([]byte, error) {
+	if err := v.FetchExternalEntities(ctx); err != nil {
+		return nil, err
+	}
+	if v.sigObj == nil {
+		return nil, errors.New("signature object not initialized before canonicalization")
+	}
+	if v.keyObj == nil {
+		return nil, errors.New("key object not initialized before canonicalization")
+	}
+
+	canonicalEntry := models.VmimageV001Schema{}
+
+	// need to canonicalize signature & key content
+	canonicalEntry.Signature = &models.VmimageV001SchemaSignature{}
+	// signature URL (if known) is not set deliberately
+	canonicalEntry.Signature.Format = v.VmimageObj.Signature.Format
+
+	var err error
+	canonicalEntry.Signature.Content, err = v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// key URL (if known) is not set deliberately
+	canonicalEntry.Signature.PublicKey = &models.VmimageV001SchemaSignaturePublicKey{}
+	canonicalEntry.Signature.PublicKey.Content, err = v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalEntry.Image = &models.VmimageV001SchemaImage{}
+	canonicalEntry.Image.Format = v.VmimageObj.Image.Format
+	canonicalEntry.Image.Hash = v.VmimageObj.Image.Hash
+	canonicalEntry.Image.Identifier = v.VmimageObj.Image.Identifier
+	// image content is not set deliberately
+
+	// ExtraData is copied through unfiltered
+	canonicalEntry.ExtraData = v.VmimageObj.ExtraData
+
+	// wrap in valid object with kind and apiVersion set
+	vmimageObj := models.Vmimage{}
+	vmimageObj.APIVersion = swag.String(APIVERSION)
+	vmimageObj.Spec = &canonicalEntry
+
+	bytes, err := json.Marshal(&vmimageObj)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+// CanonicalSignatureAndKey returns the canonical encodings of the signature and public key, which are only
+// known once external entities have been fetched
+func (v V001Entry) CanonicalSignatureAndKey() ([]byte, []byte, error) {
+	if v.sigObj == nil || v.keyObj == nil {
+		return nil, nil, errors.New("signature and key objects not initialized")
+	}
+	sig, err := v.sigObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := v.keyObj.CanonicalValue()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, key, nil
+}
+
+// SigningKey returns the key the signature was verified with, or nil if external entities have not been fetched
+func (v V001Entry) SigningKey() pki.PublicKey {
+	return v.keyObj
+}
+
+// Validate performs cross-field validation for fields in object
+func (v V001Entry) Validate() error {
+
+	sig := v.VmimageObj.Signature
+	if sig == nil {
+		return errors.New("missing signature")
+	}
+	if !pki.IsFormat(sig.Format) {
+		return fmt.Errorf("unsupported signature format %q, expected one of %v", sig.Format, pki.Formats())
+	}
+	if len(sig.Content) == 0 && sig.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for signature")
+	}
+
+	key := sig.PublicKey
+	if key == nil {
+		return errors.New("missing public key")
+	}
+	if len(key.Content) == 0 && key.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for publicKey")
+	}
+
+	image := v.VmimageObj.Image
+	if image == nil {
+		return errors.New("missing image")
+	}
+
+	if len(image.Content) == 0 && image.URL.String() == "" {
+		return errors.New("one of 'content' or 'url' must be specified for image")
+	}
+
+	if image.Format == "" {
+		return errors.New("missing image format")
+	}
+
+	hash := image.Hash
+	if hash != nil {
+		if !govalidator.IsHash(swag.StringValue(hash.Value), swag.StringValue(hash.Algorithm)) {
+			return errors.New("invalid value for hash")
+		}
+	}
+
+	// AMIs are regional and their IDs have a well-known shape, so both can be checked
+	if image.Format == models.VmimageV001SchemaImageFormatAmi {
+		id := image.Identifier
+		if id == nil {
+			return errors.New("identifier must be specified for ami images")
+		}
+		if id.Region == "" {
+			return errors.New("region must be specified for ami images")
+		}
+		if !amiIDRegexp.MatchString(swag.StringValue(id.ID)) {
+			return fmt.Errorf("invalid ami image ID: %v", swag.StringValue(id.ID))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,358 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmimage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"go.uber.org/goleak"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestMain(m *testing.M) {
+	goleak.VerifyTestMain(m)
+}
+
+func TestNewEntryReturnType(t *testing.T) {
+	entry := NewEntry()
+	if reflect.TypeOf(entry) != reflect.ValueOf(&V001Entry{}).Type() {
+		t.Errorf("invalid type returned from NewEntry: %T", entry)
+	}
+}
+
+var testManifest = []byte(`{"name":"example-image","architecture":"x86_64","snapshotId":"snap-0123456789abcdef0"}`)
+
+func signImage(t *testing.T, image []byte) (sig []byte, pubKey []byte) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Image Builder", "", "images@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sigBuf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&sigBuf, entity, bytes.NewReader(image), nil); err != nil {
+		t.Fatal(err)
+	}
+	var keyBuf bytes.Buffer
+	w, err := armor.Encode(&keyBuf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return sigBuf.Bytes(), keyBuf.Bytes()
+}
+
+func TestCrossFieldValidation(t *testing.T) {
+	type TestCase struct {
+		caseDesc                  string
+		entry                     V001Entry
+		hasExtEntities            bool
+		expectUnmarshalSuccess    bool
+		expectCanonicalizeSuccess bool
+	}
+
+	imageBytes := testManifest
+	sigBytes, keyBytes := signImage(t, imageBytes)
+	_, otherKeyBytes := signImage(t, imageBytes)
+
+	h := sha256.New()
+	_, _ = h.Write(imageBytes)
+	imageSHA := hex.EncodeToString(h.Sum(nil))
+
+	testServer := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			file := &sigBytes
+			var err error
+
+			switch r.URL.Path {
+			case "/signature":
+				file = &sigBytes
+			case "/key":
+				file = &keyBytes
+			case "/image":
+				file = &imageBytes
+			default:
+				err = errors.New("unknown URL")
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(*file)
+		}))
+	defer testServer.Close()
+
+	urlSignature := func() *models.VmimageV001SchemaSignature {
+		return &models.VmimageV001SchemaSignature{
+			Format: "pgp",
+			URL:    strfmt.URI(testServer.URL + "/signature"),
+			PublicKey: &models.VmimageV001SchemaSignaturePublicKey{
+				URL: strfmt.URI(testServer.URL + "/key"),
+			},
+		}
+	}
+
+	testCases := []TestCase{
+		{
+			caseDesc:               "empty obj",
+			entry:                  V001Entry{},
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "signature without image",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "image without format",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+					Image: &models.VmimageV001SchemaImage{
+						URL: strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "qcow2 image url without identifier",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+					Image: &models.VmimageV001SchemaImage{
+						Format: models.VmimageV001SchemaImageFormatQcow2,
+						URL:    strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "ami without region",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+					Image: &models.VmimageV001SchemaImage{
+						Format: models.VmimageV001SchemaImageFormatAmi,
+						Identifier: &models.VmimageV001SchemaImageIdentifier{
+							ID: swag.String("ami-0123456789abcdef0"),
+						},
+						URL: strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "ami with malformed ID",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+					Image: &models.VmimageV001SchemaImage{
+						Format: models.VmimageV001SchemaImageFormatAmi,
+						Identifier: &models.VmimageV001SchemaImageIdentifier{
+							Region: "us-east-1",
+							ID:     swag.String("img-0123"),
+						},
+						URL: strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			hasExtEntities:         true,
+			expectUnmarshalSuccess: false,
+		},
+		{
+			caseDesc: "ami manifest url with incorrect hash value",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+					Image: &models.VmimageV001SchemaImage{
+						Format: models.VmimageV001SchemaImageFormatAmi,
+						Identifier: &models.VmimageV001SchemaImageIdentifier{
+							Provider: "aws",
+							Region:   "us-east-1",
+							ID:       swag.String("ami-0123456789abcdef0"),
+						},
+						Hash: &models.VmimageV001SchemaImageHash{
+							Algorithm: swag.String(models.VmimageV001SchemaImageHashAlgorithmSha256),
+							Value:     swag.String("3030303030303030303030303030303030303030303030303030303030303030"),
+						},
+						URL: strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "ami manifest url with complete hash value",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: urlSignature(),
+					Image: &models.VmimageV001SchemaImage{
+						Format: models.VmimageV001SchemaImageFormatAmi,
+						Identifier: &models.VmimageV001SchemaImageIdentifier{
+							Provider: "aws",
+							Region:   "us-east-1",
+							ID:       swag.String("ami-0123456789abcdef0"),
+						},
+						Hash: &models.VmimageV001SchemaImageHash{
+							Algorithm: swag.String(models.VmimageV001SchemaImageHashAlgorithmSha256),
+							Value:     swag.String(imageSHA),
+						},
+						URL: strfmt.URI(testServer.URL + "/image"),
+					},
+				},
+			},
+			hasExtEntities:            true,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+		{
+			caseDesc: "image content signed by a different key",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: &models.VmimageV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.VmimageV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(otherKeyBytes),
+						},
+					},
+					Image: &models.VmimageV001SchemaImage{
+						Format:  models.VmimageV001SchemaImageFormatOva,
+						Content: strfmt.Base64(imageBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: false,
+		},
+		{
+			caseDesc: "signature content, key content & image content",
+			entry: V001Entry{
+				VmimageObj: models.VmimageV001Schema{
+					Signature: &models.VmimageV001SchemaSignature{
+						Format:  "pgp",
+						Content: strfmt.Base64(sigBytes),
+						PublicKey: &models.VmimageV001SchemaSignaturePublicKey{
+							Content: strfmt.Base64(keyBytes),
+						},
+					},
+					Image: &models.VmimageV001SchemaImage{
+						Format:  models.VmimageV001SchemaImageFormatOva,
+						Content: strfmt.Base64(imageBytes),
+					},
+				},
+			},
+			hasExtEntities:            false,
+			expectUnmarshalSuccess:    true,
+			expectCanonicalizeSuccess: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.entry.Validate(); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		v := &V001Entry{}
+		r := models.Vmimage{
+			APIVersion: swag.String(tc.entry.APIVersion()),
+			Spec:       tc.entry.VmimageObj,
+		}
+
+		if err := v.Unmarshal(&r); (err == nil) != tc.expectUnmarshalSuccess {
+			t.Errorf("unexpected result in '%v': %v", tc.caseDesc, err)
+		}
+
+		if tc.entry.HasExternalEntities() != tc.hasExtEntities {
+			t.Errorf("unexpected result from HasExternalEntities for '%v'", tc.caseDesc)
+		}
+
+		if _, err := tc.entry.Canonicalize(context.TODO()); (err == nil) != tc.expectCanonicalizeSuccess {
+			t.Errorf("unexpected result from Canonicalize for '%v': %v", tc.caseDesc, err)
+		}
+	}
+}
+
+func TestIndexKeys(t *testing.T) {
+	sigBytes, keyBytes := signImage(t, testManifest)
+
+	v := V001Entry{
+		VmimageObj: models.VmimageV001Schema{
+			Signature: &models.VmimageV001SchemaSignature{
+				Format:  "pgp",
+				Content: strfmt.Base64(sigBytes),
+				PublicKey: &models.VmimageV001SchemaSignaturePublicKey{
+					Content: strfmt.Base64(keyBytes),
+				},
+			},
+			Image: &models.VmimageV001SchemaImage{
+				Format: models.VmimageV001SchemaImageFormatAmi,
+				Identifier: &models.VmimageV001SchemaImageIdentifier{
+					Region: "us-east-1",
+					ID:     swag.String("ami-0123456789abcdef0"),
+				},
+				Content: strfmt.Base64(testManifest),
+			},
+		},
+	}
+	if err := v.FetchExternalEntities(context.TODO()); err != nil {
+		t.Fatalf("unexpected error fetching entities: %v", err)
+	}
+
+	imageSHA := sha256.Sum256(testManifest)
+	keys := map[string]bool{}
+	for _, k := range v.IndexKeys() {
+		keys[k] = true
+	}
+	for _, expected := range []string{hex.EncodeToString(imageSHA[:]), ImageKey("us-east-1:ami-0123456789abcdef0")} {
+		if !keys[expected] {
+			t.Errorf("expected index key %v not found in %v", expected, keys)
+		}
+	}
+}
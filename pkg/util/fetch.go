@@ -18,33 +18,173 @@ package util
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
+// FetchPolicy limits how external entities are downloaded, so that a malicious or flaky URL cannot stall or
+// exhaust the server; the zero value downloads without limits and does not retry
+type FetchPolicy struct {
+	// MaxSize is the maximum number of bytes read from a single URL, or 0 for no limit
+	MaxSize int64
+	// Retries is the number of times a download that fails part way through is resumed with a range request
+	Retries int
+	// RetryDelay is the time to wait before resuming a download
+	RetryDelay time.Duration
+}
+
+var (
+	fetchPolicyMu sync.RWMutex
+	fetchPolicy   FetchPolicy
+)
+
+// SetFetchPolicy configures the policy applied to URLs fetched by FileOrURLReadCloser
+func SetFetchPolicy(p FetchPolicy) {
+	fetchPolicyMu.Lock()
+	defer fetchPolicyMu.Unlock()
+	fetchPolicy = p
+}
+
+func currentFetchPolicy() FetchPolicy {
+	fetchPolicyMu.RLock()
+	defer fetchPolicyMu.RUnlock()
+	return fetchPolicy
+}
+
+// SizeError is returned when the content of a URL is larger than the maximum size of the fetch policy
+type SizeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("content of %v exceeds the maximum size of %v bytes", e.URL, e.Limit)
+}
+
+// FetchError is returned when the content of a URL cannot be fetched, either because the server cannot be
+// reached or because it does not return the content
+type FetchError struct {
+	URL string
+	Err error
+}
+
+func (e *FetchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FetchError) Unwrap() error {
+	return e.Err
+}
+
 // FileOrURLReadCloser Note: caller is responsible for closing ReadCloser returned from method!
 func FileOrURLReadCloser(ctx context.Context, url string, content []byte) (io.ReadCloser, error) {
 	var dataReader io.ReadCloser
 	if url != "" {
-		//TODO: set timeout here, SSL settings?
-		client := &http.Client{}
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
-		resp, err := client.Do(req)
-		if err != nil {
+		//TODO: SSL settings?
+		r := &urlReader{ctx: ctx, url: url, client: &http.Client{}, policy: currentFetchPolicy()}
+		if err := r.open(); err != nil {
 			return nil, err
 		}
-		if resp.StatusCode < 200 || resp.StatusCode > 299 {
-			return nil, fmt.Errorf("error received while fetching artifact: %v", resp.Status)
-		}
-
-		dataReader = resp.Body
+		dataReader = r
 	} else {
 		dataReader = ioutil.NopCloser(bytes.NewReader(content))
 	}
 	return dataReader, nil
 }
+
+// urlReader reads the content of a URL, enforcing the size limit of its policy and resuming the download with a
+// range request when the connection fails part way through
+type urlReader struct {
+	ctx    context.Context
+	url    string
+	client *http.Client
+	policy FetchPolicy
+
+	body    io.ReadCloser
+	read    int64
+	retries int
+	// validator is the ETag or Last-Modified date of the first response, so that a resumed download fails rather
+	// than splicing together two versions of the content
+	validator string
+}
+
+func (r *urlReader) open() error {
+	req, err := http.NewRequestWithContext(r.ctx, "GET", r.url, nil)
+	if err != nil {
+		return err
+	}
+	if r.read > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", r.read))
+		req.Header.Set("If-Range", r.validator)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return &FetchError{URL: r.url, Err: err}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return &FetchError{URL: r.url, Err: fmt.Errorf("error received while fetching artifact: %v", resp.Status)}
+	}
+
+	if r.read > 0 {
+		// anything but the requested range means the content changed or the server cannot resume
+		if resp.StatusCode != http.StatusPartialContent || !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", r.read)) {
+			resp.Body.Close()
+			return fmt.Errorf("error resuming fetch of artifact: unexpected response %v", resp.Status)
+		}
+	} else {
+		r.validator = resp.Header.Get("ETag")
+		if r.validator == "" || strings.HasPrefix(r.validator, "W/") {
+			r.validator = resp.Header.Get("Last-Modified")
+		}
+	}
+	if r.policy.MaxSize > 0 && resp.ContentLength > 0 && r.read+resp.ContentLength > r.policy.MaxSize {
+		resp.Body.Close()
+		return &SizeError{URL: r.url, Limit: r.policy.MaxSize}
+	}
+	r.body = resp.Body
+	return nil
+}
+
+func (r *urlReader) Read(p []byte) (int, error) {
+	if r.policy.MaxSize > 0 && int64(len(p)) > r.policy.MaxSize-r.read+1 {
+		// read at most one byte more than the limit, which is enough to detect exceeding it
+		p = p[:r.policy.MaxSize-r.read+1]
+	}
+	n, err := r.body.Read(p)
+	r.read += int64(n)
+	if r.policy.MaxSize > 0 && r.read > r.policy.MaxSize {
+		return n, &SizeError{URL: r.url, Limit: r.policy.MaxSize}
+	}
+	if err == nil || errors.Is(err, io.EOF) || !r.resumable() {
+		return n, err
+	}
+
+	r.body.Close()
+	r.retries++
+	select {
+	case <-r.ctx.Done():
+		return n, r.ctx.Err()
+	case <-time.After(r.policy.RetryDelay):
+	}
+	if err := r.open(); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// resumable reports whether a failed download may be resumed where it stopped; content without an ETag or
+// Last-Modified date cannot be resumed safely
+func (r *urlReader) resumable() bool {
+	return r.retries < r.policy.Retries && r.validator != "" && r.ctx.Err() == nil
+}
+
+func (r *urlReader) Close() error {
+	return r.body.Close()
+}
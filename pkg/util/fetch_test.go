@@ -0,0 +1,117 @@
+/*
+Copyright © 2021 The Rekor Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFileOrURLReadCloser(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+
+	// flaky serves content, dropping the connection half way through the first response
+	flaky := func(etag string, honourRange bool) http.HandlerFunc {
+		requests := 0
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if etag != "" {
+				w.Header().Set("ETag", etag)
+			}
+			start := 0
+			if rng := r.Header.Get("Range"); rng != "" && honourRange && r.Header.Get("If-Range") == etag {
+				start, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)-start))
+				w.WriteHeader(http.StatusPartialContent)
+			} else {
+				w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			}
+			if requests == 1 {
+				_, _ = w.Write(content[:len(content)/2])
+				w.(http.Flusher).Flush()
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			_, _ = w.Write(content[start:])
+		}
+	}
+	chunked := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content[:10])
+		w.(http.Flusher).Flush()
+		_, _ = w.Write(content[10:])
+	}
+
+	tests := []struct {
+		caseDesc   string
+		handler    http.HandlerFunc
+		policy     FetchPolicy
+		errorFound bool
+		sizeError  bool
+	}{
+		{caseDesc: "no limit", handler: chunked, policy: FetchPolicy{}},
+		{caseDesc: "content at limit", handler: chunked, policy: FetchPolicy{MaxSize: int64(len(content))}},
+		{caseDesc: "content over limit without length", handler: chunked, policy: FetchPolicy{MaxSize: 100}, errorFound: true, sizeError: true},
+		{caseDesc: "length over limit", handler: flaky("", false), policy: FetchPolicy{MaxSize: 100}, errorFound: true, sizeError: true},
+		{caseDesc: "resumed download", handler: flaky(`"v1"`, true), policy: FetchPolicy{Retries: 1}},
+		{caseDesc: "no retries", handler: flaky(`"v1"`, true), policy: FetchPolicy{}, errorFound: true},
+		{caseDesc: "no validator", handler: flaky("", true), policy: FetchPolicy{Retries: 1}, errorFound: true},
+		{caseDesc: "range not supported", handler: flaky(`"v1"`, false), policy: FetchPolicy{Retries: 1}, errorFound: true},
+	}
+
+	for _, tc := range tests {
+		server := httptest.NewServer(tc.handler)
+		SetFetchPolicy(tc.policy)
+		rc, err := FileOrURLReadCloser(context.Background(), server.URL, nil)
+		var got []byte
+		if err == nil {
+			got, err = ioutil.ReadAll(rc)
+			rc.Close()
+		}
+		server.Close()
+
+		if (err != nil) != tc.errorFound {
+			t.Errorf("%v: unexpected result fetching URL: %v", tc.caseDesc, err)
+			continue
+		}
+		var sizeErr *SizeError
+		if errors.As(err, &sizeErr) != tc.sizeError {
+			t.Errorf("%v: unexpected error type %v", tc.caseDesc, err)
+		}
+		if err == nil && !bytes.Equal(got, content) {
+			t.Errorf("%v: unexpected content %q", tc.caseDesc, got)
+		}
+	}
+	SetFetchPolicy(FetchPolicy{})
+
+	rc, err := FileOrURLReadCloser(context.Background(), "", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ioutil.ReadAll(rc); !bytes.Equal(got, content) {
+		t.Errorf("unexpected inline content %q", got)
+	}
+}